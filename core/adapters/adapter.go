@@ -11,8 +11,22 @@ import (
 )
 
 var (
+	// TaskTypeAssert is the identifier for the Assert adapter.
+	TaskTypeAssert = models.MustNewTaskType("assert")
 	// TaskTypeCopy is the identifier for the Copy adapter.
 	TaskTypeCopy = models.MustNewTaskType("copy")
+	// TaskTypeAggregate is the identifier for the Aggregate adapter.
+	TaskTypeAggregate = models.MustNewTaskType("aggregate")
+	// TaskTypeConvertUnits is the identifier for the ConvertUnits adapter.
+	TaskTypeConvertUnits = models.MustNewTaskType("convertunits")
+	// TaskTypeDecode is the identifier for the Decode adapter.
+	TaskTypeDecode = models.MustNewTaskType("decode")
+	// TaskTypeDelay is the identifier for the Delay adapter.
+	TaskTypeDelay = models.MustNewTaskType("delay")
+	// TaskTypeEncode is the identifier for the Encode adapter.
+	TaskTypeEncode = models.MustNewTaskType("encode")
+	// TaskTypeEncodePacked is the identifier for the EncodePacked adapter.
+	TaskTypeEncodePacked = models.MustNewTaskType("encodepacked")
 	// TaskTypeEthBool is the identifier for the EthBool adapter.
 	TaskTypeEthBool = models.MustNewTaskType("ethbool")
 	// TaskTypeEthBytes32 is the identifier for the EthBytes32 adapter.
@@ -23,26 +37,44 @@ var (
 	TaskTypeEthUint256 = models.MustNewTaskType("ethuint256")
 	// TaskTypeEthTx is the identifier for the EthTx adapter.
 	TaskTypeEthTx = models.MustNewTaskType("ethtx")
+	// TaskTypeEthTxCommitReveal is the identifier for the EthTxCommitReveal adapter.
+	TaskTypeEthTxCommitReveal = models.MustNewTaskType("ethtxcommitreveal")
 	// TaskTypeEthTxABIEncode is the identifier for the EthTxABIEncode adapter.
 	TaskTypeEthTxABIEncode = models.MustNewTaskType("ethtxabiencode")
 	// TaskTypeHTTPGetWithUnrestrictedNetworkAccess is the identifier for the HTTPGet adapter, with local/private IP access enabled.
 	TaskTypeHTTPGetWithUnrestrictedNetworkAccess = models.MustNewTaskType("httpgetwithunrestrictednetworkaccess")
 	// TaskTypeHTTPPostWithUnrestrictedNetworkAccess is the identifier for the HTTPPost adapter, with local/private IP access enabled.
 	TaskTypeHTTPPostWithUnrestrictedNetworkAccess = models.MustNewTaskType("httppostwithunrestrictednetworkaccess")
+	// TaskTypeHash is the identifier for the Hash adapter.
+	TaskTypeHash = models.MustNewTaskType("hash")
 	// TaskTypeHTTPGet is the identifier for the HTTPGet adapter.
 	TaskTypeHTTPGet = models.MustNewTaskType("httpget")
 	// TaskTypeHTTPPost is the identifier for the HTTPPost adapter.
 	TaskTypeHTTPPost = models.MustNewTaskType("httppost")
 	// TaskTypeJSONParse is the identifier for the JSONParse adapter.
 	TaskTypeJSONParse = models.MustNewTaskType("jsonparse")
+	// TaskTypeMap is the identifier for the Map adapter.
+	TaskTypeMap = models.MustNewTaskType("map")
+	// TaskTypeMerge is the identifier for the Merge adapter.
+	TaskTypeMerge = models.MustNewTaskType("merge")
+	// TaskTypeMerkle is the identifier for the Merkle adapter.
+	TaskTypeMerkle = models.MustNewTaskType("merkle")
 	// TaskTypeMultiply is the identifier for the Multiply adapter.
 	TaskTypeMultiply = models.MustNewTaskType("multiply")
+	// TaskTypeParseNumber is the identifier for the ParseNumber adapter.
+	TaskTypeParseNumber = models.MustNewTaskType("parsenumber")
 	// TaskTypeNoOp is the identifier for the NoOp adapter.
 	TaskTypeNoOp = models.MustNewTaskType("noop")
 	// TaskTypeNoOpPendOutgoing is the identifier for the NoOpPendOutgoing adapter.
 	TaskTypeNoOpPendOutgoing = models.MustNewTaskType("nooppendoutgoing")
+	// TaskTypePluck is the identifier for the Pluck adapter.
+	TaskTypePluck = models.MustNewTaskType("pluck")
+	// TaskTypeReduce is the identifier for the Reduce adapter.
+	TaskTypeReduce = models.MustNewTaskType("reduce")
 	// TaskTypeSleep is the identifier for the Sleep adapter.
 	TaskTypeSleep = models.MustNewTaskType("sleep")
+	// TaskTypeTypedData is the identifier for the TypedData adapter.
+	TaskTypeTypedData = models.MustNewTaskType("typeddata")
 	// TaskTypeWasm is the wasm interpereter adapter
 	TaskTypeWasm = models.MustNewTaskType("wasm")
 	// TaskTypeRandom is the identifier for the Random adapter.
@@ -51,6 +83,8 @@ var (
 	TaskTypeCompare = models.MustNewTaskType("compare")
 	// TaskTypeQuotient is the identifier for the Quotient adapter.
 	TaskTypeQuotient = models.MustNewTaskType("quotient")
+	// TaskTypeSign is the identifier for the Sign adapter.
+	TaskTypeSign = models.MustNewTaskType("sign")
 )
 
 // BaseAdapter is the minimum interface required to create an adapter. Only core
@@ -113,8 +147,22 @@ func For(task models.TaskSpec, config orm.ConfigReader, orm *orm.ORM) (*Pipeline
 // FindNativeAdapterFor find the native adapter for a given task
 func FindNativeAdapterFor(task models.TaskSpec) BaseAdapter {
 	switch task.Type {
+	case TaskTypeAssert:
+		return &Assert{}
 	case TaskTypeCopy:
 		return &Copy{}
+	case TaskTypeAggregate:
+		return &Aggregate{}
+	case TaskTypeConvertUnits:
+		return &ConvertUnits{}
+	case TaskTypeDecode:
+		return &Decode{}
+	case TaskTypeDelay:
+		return &Delay{}
+	case TaskTypeEncode:
+		return &Encode{}
+	case TaskTypeEncodePacked:
+		return &EncodePacked{}
 	case TaskTypeEthBool:
 		return &EthBool{}
 	case TaskTypeEthBytes32:
@@ -125,8 +173,12 @@ func FindNativeAdapterFor(task models.TaskSpec) BaseAdapter {
 		return &EthUint256{}
 	case TaskTypeEthTx:
 		return &EthTx{}
+	case TaskTypeEthTxCommitReveal:
+		return &EthTxCommitReveal{}
 	case TaskTypeEthTxABIEncode:
 		return &EthTxABIEncode{}
+	case TaskTypeHash:
+		return &Hash{}
 	case TaskTypeHTTPGetWithUnrestrictedNetworkAccess:
 		return &HTTPGet{AllowUnrestrictedNetworkAccess: true}
 	case TaskTypeHTTPPostWithUnrestrictedNetworkAccess:
@@ -137,14 +189,28 @@ func FindNativeAdapterFor(task models.TaskSpec) BaseAdapter {
 		return &HTTPPost{}
 	case TaskTypeJSONParse:
 		return &JSONParse{}
+	case TaskTypeMap:
+		return &Map{}
+	case TaskTypeMerge:
+		return &Merge{}
+	case TaskTypeMerkle:
+		return &Merkle{}
 	case TaskTypeMultiply:
 		return &Multiply{}
+	case TaskTypeParseNumber:
+		return &ParseNumber{}
 	case TaskTypeNoOp:
 		return &NoOp{}
 	case TaskTypeNoOpPendOutgoing:
 		return &NoOpPendOutgoing{}
+	case TaskTypePluck:
+		return &Pluck{}
+	case TaskTypeReduce:
+		return &Reduce{}
 	case TaskTypeSleep:
 		return &Sleep{}
+	case TaskTypeTypedData:
+		return &TypedData{}
 	case TaskTypeWasm:
 		return &Wasm{}
 	case TaskTypeRandom:
@@ -153,6 +219,8 @@ func FindNativeAdapterFor(task models.TaskSpec) BaseAdapter {
 		return &Compare{}
 	case TaskTypeQuotient:
 		return &Quotient{}
+	case TaskTypeSign:
+		return &Sign{}
 	default:
 		return nil
 	}
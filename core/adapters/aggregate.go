@@ -0,0 +1,125 @@
+package adapters
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// Aggregate reduces the array in its input to a single number. Path
+// optionally locates the array within the input's result, and Field the
+// number within each of its elements.
+type Aggregate struct {
+	Operation string   `json:"operation"`
+	Path      JSONPath `json:"path"`
+	Field     JSONPath `json:"field"`
+}
+
+// TaskType returns the type of Adapter.
+func (a *Aggregate) TaskType() models.TaskType {
+	return TaskTypeAggregate
+}
+
+// Perform returns the "sum", "product", "min", "max", "mean" or "median" of
+// the elements of the array, or their "count". The median of an even number
+// of elements is the mean of the middle two.
+//
+// For example, if the input value is [{"balance": "1.5"}, {"balance": 2}],
+// the adapter's "operation" is "sum" and its "field" is "balance", the
+// result's value will be "3.5".
+func (a *Aggregate) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	elements, err := arrayElements(input.Result(), a.Path, a.Field)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	if a.Operation == "count" {
+		return models.NewRunOutputCompleteWithResult(len(elements))
+	}
+
+	values, err := decimalElements(elements)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	var result decimal.Decimal
+	switch a.Operation {
+	case "sum", "mean":
+		result = decimal.Zero
+		for _, value := range values {
+			result = result.Add(value)
+		}
+		if a.Operation == "mean" {
+			if len(values) == 0 {
+				return models.NewRunOutputError(errors.New("cannot take the mean of an empty array"))
+			}
+			result = result.Div(decimal.New(int64(len(values)), 0))
+		}
+	case "product":
+		result = decimal.New(1, 0)
+		for _, value := range values {
+			result = result.Mul(value)
+		}
+	case "min", "max":
+		if len(values) == 0 {
+			return models.NewRunOutputError(fmt.Errorf("cannot take the %s of an empty array", a.Operation))
+		}
+		if a.Operation == "min" {
+			result = decimal.Min(values[0], values[1:]...)
+		} else {
+			result = decimal.Max(values[0], values[1:]...)
+		}
+	case "median":
+		if len(values) == 0 {
+			return models.NewRunOutputError(errors.New("cannot take the median of an empty array"))
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i].LessThan(values[j]) })
+		middle := len(values) / 2
+		result = values[middle]
+		if len(values)%2 == 0 {
+			result = values[middle-1].Add(values[middle]).Div(decimal.New(2, 0))
+		}
+	default:
+		return models.NewRunOutputError(fmt.Errorf("unknown operation %q, must be sum, count, min, max, mean, median or product", a.Operation))
+	}
+	return models.NewRunOutputCompleteWithResult(result.String())
+}
+
+// arrayElements returns the elements of the array at path within result, or
+// the value at field within each of them.
+func arrayElements(result gjson.Result, path, field JSONPath) ([]gjson.Result, error) {
+	if len(path) > 0 {
+		result = result.Get(gjsonPath(path))
+	}
+	elements, err := models.ArrayValue(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(field) == 0 {
+		return elements, nil
+	}
+	fields := make([]gjson.Result, len(elements))
+	for i, element := range elements {
+		fields[i] = element.Get(gjsonPath(field))
+		if !fields[i].Exists() {
+			return nil, fmt.Errorf("element %d has no field %s", i, gjsonPath(field))
+		}
+	}
+	return fields, nil
+}
+
+func decimalElements(elements []gjson.Result) ([]decimal.Decimal, error) {
+	values := make([]decimal.Decimal, len(elements))
+	for i, element := range elements {
+		value, err := models.DecimalValue(element)
+		if err != nil {
+			return nil, errors.Wrapf(err, "element %d", i)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,56 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate_Perform(t *testing.T) {
+	balances := `{"result":{"accounts":[{"balance":"1.5"},{"balance":2},{"balance":"-0.5"}]}}`
+	tests := []struct {
+		name    string
+		params  string
+		json    string
+		want    string
+		errored bool
+	}{
+		{"sum", `{"operation":"sum"}`, `{"result":[1,2,3.5]}`, "6.5", false},
+		{"sum of field", `{"operation":"sum","path":["accounts"],"field":["balance"]}`, balances, "3", false},
+		{"count", `{"operation":"count"}`, `{"result":[{},{},{}]}`, "3", false},
+		{"min", `{"operation":"min","path":"accounts","field":"balance"}`, balances, "-0.5", false},
+		{"max", `{"operation":"max"}`, `{"result":["7","12","9"]}`, "12", false},
+		{"mean", `{"operation":"mean"}`, `{"result":[1,2,3,4]}`, "2.5", false},
+		{"product", `{"operation":"product"}`, `{"result":[1.5,2,3]}`, "9", false},
+		{"empty sum", `{"operation":"sum"}`, `{"result":[]}`, "0", false},
+		{"empty mean", `{"operation":"mean"}`, `{"result":[]}`, "", true},
+		{"missing field", `{"operation":"sum","field":["balance"]}`, `{"result":[{"balance":1},{}]}`, "", true},
+		{"not numbers", `{"operation":"sum"}`, `{"result":[1,"two"]}`, "", true},
+		{"not an array", `{"operation":"sum"}`, `{"result":"1,2"}`, "", true},
+		{"median", `{"operation":"median"}`, `{"result":[30,"12",21.5]}`, "21.5", false},
+		{"median of even count", `{"operation":"median"}`, `{"result":[40,10,30,20]}`, "25", false},
+		{"empty median", `{"operation":"median"}`, `{"result":[]}`, "", true},
+		{"unknown operation", `{"operation":"mode"}`, `{"result":[1]}`, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.Aggregate{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			if test.errored {
+				assert.Error(t, result.Error())
+			} else {
+				require.NoError(t, result.Error())
+				assert.Equal(t, test.want, result.Result().String())
+			}
+		})
+	}
+}
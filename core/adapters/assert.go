@@ -0,0 +1,161 @@
+package adapters
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// Assert checks the previous task's result, passing it on unchanged if the
+// check holds and failing the run with an AssertionError if it does not.
+// Canary and data quality jobs are built from it, alerting on the errored
+// runs through the job's errors and run events.
+//
+// The Operator eq or neq checks that the result equals, or does not equal,
+// Value, compared as numbers if both are numbers and as text otherwise. The
+// operators gt, gte, lt and lte compare the result to Value as numbers,
+// withinpercent checks that it is within Percent percent of Value, and
+// matches checks that it matches the regular expression Value. Message, if
+// given, describes the check in the error.
+type Assert struct {
+	Operator string           `json:"operator"`
+	Value    string           `json:"value"`
+	Percent  *decimal.Decimal `json:"percent,omitempty"`
+	Message  string           `json:"message,omitempty"`
+}
+
+// AssertionError is the error of a run whose Assert task's check failed,
+// giving the check and the result which failed it. It is also added to the
+// run's result data under "assertion", so that alerts can act on its fields
+// rather than parse the error message.
+type AssertionError struct {
+	Message  string           `json:"message,omitempty"`
+	Path     string           `json:"path"`
+	Operator string           `json:"operator"`
+	Expected string           `json:"expected"`
+	Percent  *decimal.Decimal `json:"percent,omitempty"`
+	Actual   string           `json:"actual"`
+}
+
+func (e *AssertionError) Error() string {
+	var failure string
+	switch e.Operator {
+	case "eq":
+		failure = fmt.Sprintf("%s %s does not equal %s", e.Path, e.Actual, e.Expected)
+	case "neq":
+		failure = fmt.Sprintf("%s %s equals %s", e.Path, e.Actual, e.Expected)
+	case "gt":
+		failure = fmt.Sprintf("%s %s is not greater than %s", e.Path, e.Actual, e.Expected)
+	case "gte":
+		failure = fmt.Sprintf("%s %s is less than %s", e.Path, e.Actual, e.Expected)
+	case "lt":
+		failure = fmt.Sprintf("%s %s is not less than %s", e.Path, e.Actual, e.Expected)
+	case "lte":
+		failure = fmt.Sprintf("%s %s is greater than %s", e.Path, e.Actual, e.Expected)
+	case "withinpercent":
+		failure = fmt.Sprintf("%s %s is not within %s%% of %s", e.Path, e.Actual, e.Percent, e.Expected)
+	case "matches":
+		failure = fmt.Sprintf("%s %q does not match %s", e.Path, e.Actual, e.Expected)
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("assertion failed: %s: %s", e.Message, failure)
+	}
+	return "assertion failed: " + failure
+}
+
+// TaskType returns the type of Adapter.
+func (a *Assert) TaskType() models.TaskType {
+	return TaskTypeAssert
+}
+
+// Validate checks that the operator is known and has the parameters it
+// needs, so that a misconfigured check is caught when the job is created
+// rather than failing every run.
+func (a *Assert) Validate() error {
+	switch a.Operator {
+	case "eq", "neq":
+	case "gt", "gte", "lt", "lte":
+		if _, err := decimal.NewFromString(a.Value); err != nil {
+			return fmt.Errorf("assert %s value %q is not a number", a.Operator, a.Value)
+		}
+	case "withinpercent":
+		if _, err := decimal.NewFromString(a.Value); err != nil {
+			return fmt.Errorf("assert %s value %q is not a number", a.Operator, a.Value)
+		}
+		if a.Percent == nil || a.Percent.IsNegative() {
+			return fmt.Errorf("assert %s needs a non-negative percent", a.Operator)
+		}
+	case "matches":
+		if _, err := regexp.Compile(a.Value); err != nil {
+			return fmt.Errorf("assert %s value is not a regular expression: %v", a.Operator, err)
+		}
+	default:
+		return fmt.Errorf("unknown assert operator %q, must be one of eq, neq, gt, gte, lt, lte, withinpercent, matches", a.Operator)
+	}
+	return nil
+}
+
+// Perform checks the input's result, passing the input on if the check holds.
+func (a *Assert) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	if err := a.Validate(); err != nil {
+		return models.NewRunOutputError(err)
+	}
+	result := input.Result()
+
+	var ok bool
+	switch a.Operator {
+	case "eq", "neq":
+		actual, aerr := models.DecimalValue(result)
+		value, verr := decimal.NewFromString(a.Value)
+		if aerr == nil && verr == nil {
+			ok = actual.Equal(value)
+		} else {
+			ok = result.String() == a.Value
+		}
+		if a.Operator == "neq" {
+			ok = !ok
+		}
+	case "gt", "gte", "lt", "lte", "withinpercent":
+		actual, err := models.DecimalValue(result)
+		if err != nil {
+			return models.NewRunOutputError(ErrResultNotNumber)
+		}
+		value := decimal.RequireFromString(a.Value)
+		switch a.Operator {
+		case "gt":
+			ok = actual.GreaterThan(value)
+		case "gte":
+			ok = actual.GreaterThanOrEqual(value)
+		case "lt":
+			ok = actual.LessThan(value)
+		case "lte":
+			ok = actual.LessThanOrEqual(value)
+		case "withinpercent":
+			tolerance := value.Abs().Mul(*a.Percent).Div(decimal.NewFromInt(100))
+			ok = actual.Sub(value).Abs().LessThanOrEqual(tolerance)
+		}
+	case "matches":
+		ok = regexp.MustCompile(a.Value).MatchString(result.String())
+	}
+
+	if !ok {
+		aerr := &AssertionError{
+			Message:  a.Message,
+			Path:     "result",
+			Operator: a.Operator,
+			Expected: a.Value,
+			Percent:  a.Percent,
+			Actual:   result.String(),
+		}
+		data, err := input.Data().Add("assertion", aerr)
+		if err != nil {
+			return models.NewRunOutputError(aerr)
+		}
+		return models.NewRunOutputErrorWithData(aerr, data)
+	}
+	return models.NewRunOutputComplete(input.Data())
+}
@@ -0,0 +1,86 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssert_Perform(t *testing.T) {
+	t.Parallel()
+
+	two := decimal.NewFromInt(2)
+	tests := []struct {
+		name    string
+		input   interface{}
+		adapter adapters.Assert
+		wantErr string
+	}{
+		{"eq numbers", "100.0", adapters.Assert{Operator: "eq", Value: "100"}, ""},
+		{"eq text", "ok", adapters.Assert{Operator: "eq", Value: "ok"}, ""},
+		{"eq fails", 99, adapters.Assert{Operator: "eq", Value: "100"}, "assertion failed: result 99 does not equal 100"},
+		{"neq", "down", adapters.Assert{Operator: "neq", Value: "up"}, ""},
+		{"gt", 101, adapters.Assert{Operator: "gt", Value: "100"}, ""},
+		{"gt fails", 100, adapters.Assert{Operator: "gt", Value: "100"}, "assertion failed: result 100 is not greater than 100"},
+		{"lte", "100", adapters.Assert{Operator: "lte", Value: "100"}, ""},
+		{"within percent", 102, adapters.Assert{Operator: "withinpercent", Value: "100", Percent: &two}, ""},
+		{"within percent of negative", -98, adapters.Assert{Operator: "withinpercent", Value: "-100", Percent: &two}, ""},
+		{
+			"within percent fails",
+			103,
+			adapters.Assert{Operator: "withinpercent", Value: "100", Percent: &two, Message: "ETH price near reference"},
+			"assertion failed: ETH price near reference: result 103 is not within 2% of 100",
+		},
+		{"matches", "0xdeadbeef", adapters.Assert{Operator: "matches", Value: "^0x[0-9a-f]+$"}, ""},
+		{"matches fails", "deadbeef", adapters.Assert{Operator: "matches", Value: "^0x"}, `assertion failed: result "deadbeef" does not match ^0x`},
+		{"not a number", "abc", adapters.Assert{Operator: "gt", Value: "1"}, adapters.ErrResultNotNumber.Error()},
+		{"unknown operator", 1, adapters.Assert{Operator: "between", Value: "1"}, `unknown assert operator "between", must be one of eq, neq, gt, gte, lt, lte, withinpercent, matches`},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithResult(test.input)
+			result := test.adapter.Perform(input, nil)
+			if test.wantErr == "" {
+				require.NoError(t, result.Error())
+				assert.Equal(t, input.Result().String(), result.Result().String())
+			} else {
+				require.Error(t, result.Error())
+				assert.Equal(t, test.wantErr, result.Error().Error())
+			}
+		})
+	}
+}
+
+func TestAssert_Perform_AddsAssertionToResult(t *testing.T) {
+	t.Parallel()
+
+	two := decimal.NewFromInt(2)
+	adapter := adapters.Assert{Operator: "withinpercent", Value: "100", Percent: &two, Message: "ETH price near reference"}
+	result := adapter.Perform(cltest.NewRunInputWithResult(103), nil)
+	require.Error(t, result.Error())
+
+	assert.Equal(t, "103", result.Result().String())
+	assertion := result.Get("assertion")
+	assert.Equal(t, "result", assertion.Get("path").String())
+	assert.Equal(t, "withinpercent", assertion.Get("operator").String())
+	assert.Equal(t, "100", assertion.Get("expected").String())
+	assert.Equal(t, "103", assertion.Get("actual").String())
+	assert.Equal(t, "2", assertion.Get("percent").String())
+	assert.Equal(t, "ETH price near reference", assertion.Get("message").String())
+}
+
+func TestAssert_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, (&adapters.Assert{Operator: "gte", Value: "1.5"}).Validate())
+	assert.Error(t, (&adapters.Assert{Operator: "gte", Value: "high"}).Validate())
+	assert.Error(t, (&adapters.Assert{Operator: "withinpercent", Value: "100"}).Validate())
+	assert.Error(t, (&adapters.Assert{Operator: "matches", Value: "("}).Validate())
+}
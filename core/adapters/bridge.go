@@ -9,6 +9,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
@@ -27,7 +28,8 @@ func (ba *Bridge) TaskType() models.TaskType {
 }
 
 // Perform sends a POST request containing the JSON of the input to the
-// external adapter specified in the BridgeType.
+// external adapter specified in the BridgeType, or to one of its failover
+// URLs if the adapter's URL fails to respond.
 //
 // It records the RunResult returned to it, and optionally marks the RunResult pending.
 //
@@ -75,6 +77,9 @@ func (ba *Bridge) handleNewRun(input models.RunInput, meta *models.JSON, store *
 	}
 
 	httpConfig := defaultHTTPConfig(store)
+	httpConfig.retryable = bridgeResponseRetryable
+	httpConfig.maxRetryAfter = store.Config.BridgeMaxRetryAfter().Duration()
+	httpConfig.forRun(input.JobRunID())
 
 	body, err := ba.postToExternalAdapter(input, meta, responseURL, httpConfig)
 	if err != nil {
@@ -82,10 +87,16 @@ func (ba *Bridge) handleNewRun(input models.RunInput, meta *models.JSON, store *
 	}
 
 	input = input.CloneWithData(data)
-	return ba.responseToRunResult(body, input)
+	return ba.responseToRunResult(body, input, httpConfig.jsonDepthLimit)
 }
 
-func (ba *Bridge) responseToRunResult(body []byte, input models.RunInput) models.RunOutput {
+// responseToRunResult decodes the external adapter's response, which is
+// rejected if its JSON is nested more than depthLimit levels deep.
+func (ba *Bridge) responseToRunResult(body []byte, input models.RunInput, depthLimit int) models.RunOutput {
+	if err := utils.CheckJSONDepth(body, depthLimit); err != nil {
+		return models.NewRunOutputError(baRunResultError("unmarshaling JSON", err))
+	}
+
 	var brr models.BridgeRunResult
 	err := json.Unmarshal(body, &brr)
 	if err != nil {
@@ -132,18 +143,34 @@ func (ba *Bridge) postToExternalAdapter(
 		return nil, fmt.Errorf("marshaling request body: %v", err)
 	}
 
-	request, err := http.NewRequest("POST", ba.URL.String(), bytes.NewBuffer(in))
-	if err != nil {
-		return nil, fmt.Errorf("building outgoing bridge http post: %v", err)
+	urls := append([]models.WebURL{ba.URL}, ba.FailoverURLs...)
+	requests := make([]*http.Request, len(urls))
+	for i, u := range urls {
+		request, err := http.NewRequest("POST", u.String(), bytes.NewBuffer(in))
+		if err != nil {
+			return nil, fmt.Errorf("building outgoing bridge http post: %v", err)
+		}
+		request.Header.Set("Authorization", "Bearer "+ba.BridgeType.OutgoingToken)
+		request.Header.Set("Content-Type", "application/json")
+		requests[i] = request
 	}
-	request.Header.Set("Authorization", "Bearer "+ba.BridgeType.OutgoingToken)
-	request.Header.Set("Content-Type", "application/json")
 
-	client := http.Client{}
+	bridge := ba.Name.String()
+	if err := bridgeCircuits.check(bridge); err != nil {
+		return nil, err
+	}
 
-	bytes, statusCode, err := withRetry(&client, request, config)
+	client := http.Client{Transport: externalTransport(config, http.DefaultTransport)}
 
-	if err != nil {
+	bytes, statusCode, err := withFailover(&client, endpoints.order(requests, ba.URLSelection), config)
+	category, retryAfter := bridgeErrorCategory(statusCode, bytes, err)
+	bridgeCircuits.record(bridge, category, retryAfter, config.maxRetryAfter)
+
+	if _, ok := err.(*RemoteServerError); ok && statusCode < 400 {
+		// The adapter returned a retryable error on every attempt, which
+		// errors the run as any other error in its response does
+		return bytes, nil
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -155,6 +182,24 @@ func (ba *Bridge) postToExternalAdapter(
 	return bytes, nil
 }
 
+// bridgeResponseRetryable decides whether an external adapter's response
+// should be retried. Adapters may classify their errors with an errorType of
+// "retryable" or "fatal" in the response body. Otherwise rate limited and
+// upstream down errors are retried, and all other responses are not.
+func bridgeResponseRetryable(statusCode int, responseBody []byte) bool {
+	var brr models.BridgeRunResult
+	if err := json.Unmarshal(responseBody, &brr); err == nil {
+		switch brr.ErrorType {
+		case models.BridgeErrorRetryable:
+			return true
+		case models.BridgeErrorFatal:
+			return false
+		}
+	}
+	category, _ := bridgeErrorCategory(statusCode, responseBody, nil)
+	return category == models.BridgeErrorRateLimited || category == models.BridgeErrorUpstreamDown
+}
+
 func baRunResultError(str string, err error) error {
 	return fmt.Errorf("ExternalBridge %v: %v", str, err)
 }
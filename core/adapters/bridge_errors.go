@@ -0,0 +1,200 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+const (
+	// bridgeCircuitThreshold is the number of calls in a row failing with
+	// upstream down or rate limited errors after which a bridge's circuit
+	// opens
+	bridgeCircuitThreshold = 5
+	// bridgeCircuitCooldown is how long a bridge's circuit stays open once
+	// opened by failing calls
+	bridgeCircuitCooldown = time.Minute
+	// bridgeAuthCooldown is how long a bridge's circuit stays open after the
+	// adapter's credentials were rejected
+	bridgeAuthCooldown = 5 * time.Minute
+)
+
+// bridgeCircuitStats records the errors each bridge has returned by category,
+// and opens a bridge's circuit when calling it again is bound to fail, so
+// that runs error at once instead of waiting on the adapter. It is shared by
+// every task, like endpoints.
+type bridgeCircuitStats struct {
+	mu      sync.Mutex
+	bridges map[string]*bridgeCircuit
+}
+
+type bridgeCircuit struct {
+	counts      map[models.BridgeErrorCategory]uint64
+	consecutive int
+	category    models.BridgeErrorCategory
+	openUntil   time.Time
+}
+
+var bridgeCircuits = &bridgeCircuitStats{bridges: make(map[string]*bridgeCircuit)}
+
+// BridgeCircuitOpenError is returned in place of calling a bridge whose
+// circuit is open.
+type BridgeCircuitOpenError struct {
+	Bridge    string
+	Category  models.BridgeErrorCategory
+	OpenUntil time.Time
+}
+
+func (e *BridgeCircuitOpenError) Error() string {
+	return fmt.Sprintf("bridge %s is failing with %s errors, not calling it again until %s",
+		e.Bridge, e.Category, e.OpenUntil.Format(time.RFC3339))
+}
+
+func (s *bridgeCircuitStats) circuit(bridge string) *bridgeCircuit {
+	circuit, ok := s.bridges[bridge]
+	if !ok {
+		circuit = &bridgeCircuit{counts: make(map[models.BridgeErrorCategory]uint64)}
+		s.bridges[bridge] = circuit
+	}
+	return circuit
+}
+
+// check returns an error if the bridge's circuit is open.
+func (s *bridgeCircuitStats) check(bridge string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	circuit, ok := s.bridges[bridge]
+	if !ok || !time.Now().Before(circuit.openUntil) {
+		return nil
+	}
+	return &BridgeCircuitOpenError{Bridge: bridge, Category: circuit.category, OpenUntil: circuit.openUntil}
+}
+
+// record notes the category of the error a call to the bridge ended with,
+// which is empty if the call succeeded or its error could not be classified,
+// opening the bridge's circuit if calling it again is bound to fail. The
+// circuit of a rate limited bridge opens for as long as its adapter asks, up
+// to maxRetryAfter, so that a bogus retryAfter cannot take it out of service
+// indefinitely.
+func (s *bridgeCircuitStats) record(bridge string, category models.BridgeErrorCategory, retryAfter, maxRetryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	circuit := s.circuit(bridge)
+	if category == "" {
+		circuit.consecutive = 0
+		return
+	}
+	circuit.counts[category]++
+
+	now := time.Now()
+	switch category {
+	case models.BridgeErrorAuth:
+		circuit.open(category, now.Add(bridgeAuthCooldown))
+	case models.BridgeErrorRateLimited, models.BridgeErrorUpstreamDown:
+		circuit.consecutive++
+		if category == models.BridgeErrorRateLimited && retryAfter > 0 {
+			if retryAfter > maxRetryAfter {
+				retryAfter = maxRetryAfter
+			}
+			circuit.open(category, now.Add(retryAfter))
+		} else if circuit.consecutive >= bridgeCircuitThreshold {
+			circuit.open(category, now.Add(bridgeCircuitCooldown))
+		}
+	}
+}
+
+// reset closes the bridge's circuit, so that it is called again straight
+// away. Its error counts are kept.
+func (s *bridgeCircuitStats) reset(bridge string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if circuit, ok := s.bridges[bridge]; ok {
+		circuit.consecutive = 0
+		circuit.category = ""
+		circuit.openUntil = time.Time{}
+	}
+}
+
+// ResetBridgeCircuit closes the circuit of the bridge if it is open, such as
+// once the bridge has been updated to fix what it was failing with.
+func ResetBridgeCircuit(bridge string) {
+	bridgeCircuits.reset(bridge)
+}
+
+func (c *bridgeCircuit) open(category models.BridgeErrorCategory, until time.Time) {
+	if until.After(c.openUntil) {
+		c.category = category
+		c.openUntil = until
+	}
+	c.consecutive = 0
+}
+
+// BridgeErrorStats are the errors a bridge has returned by category since
+// the node started, and when its circuit closes if it is open.
+type BridgeErrorStats struct {
+	Bridge    string                                `json:"bridge"`
+	Errors    map[models.BridgeErrorCategory]uint64 `json:"errors"`
+	OpenUntil *time.Time                            `json:"openUntil"`
+	Category  models.BridgeErrorCategory            `json:"category,omitempty"`
+}
+
+// BridgeErrors returns the error counts of each bridge which has been
+// called, sorted by bridge name.
+func BridgeErrors() []BridgeErrorStats {
+	bridgeCircuits.mu.Lock()
+	defer bridgeCircuits.mu.Unlock()
+	now := time.Now()
+	stats := []BridgeErrorStats{}
+	for bridge, circuit := range bridgeCircuits.bridges {
+		stat := BridgeErrorStats{Bridge: bridge, Errors: make(map[models.BridgeErrorCategory]uint64)}
+		for category, count := range circuit.counts {
+			stat.Errors[category] = count
+		}
+		if now.Before(circuit.openUntil) {
+			openUntil := circuit.openUntil
+			stat.OpenUntil = &openUntil
+			stat.Category = circuit.category
+		}
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Bridge < stats[j].Bridge })
+	return stats
+}
+
+// bridgeErrorCategory classifies the response an external adapter ended a
+// call with. The category given by the adapter is used if there is one,
+// otherwise it is inferred from the status code. A response the adapter did
+// not send, such as a timeout, is classified as upstream down.
+func bridgeErrorCategory(statusCode int, responseBody []byte, err error) (models.BridgeErrorCategory, time.Duration) {
+	if err != nil && statusCode == 0 {
+		return models.BridgeErrorUpstreamDown, 0
+	}
+	var brr models.BridgeRunResult
+	if json.Unmarshal(responseBody, &brr) != nil {
+		return statusErrorCategory(statusCode), 0
+	}
+	retryAfter := time.Duration(brr.RetryAfter) * time.Second
+	if brr.ErrorCategory != "" && (brr.HasError() || statusCode >= 400) {
+		return brr.ErrorCategory, retryAfter
+	}
+	return statusErrorCategory(statusCode), retryAfter
+}
+
+func statusErrorCategory(statusCode int) models.BridgeErrorCategory {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return models.BridgeErrorRateLimited
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return models.BridgeErrorAuth
+	case statusCode == http.StatusRequestTimeout, statusCode >= 500:
+		return models.BridgeErrorUpstreamDown
+	case statusCode >= 400:
+		return models.BridgeErrorBadRequest
+	}
+	return ""
+}
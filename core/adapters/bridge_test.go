@@ -3,8 +3,12 @@ package adapters_test
 import (
 	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/adapters"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
@@ -252,3 +256,207 @@ func TestBridgeResponse_TooLarge(t *testing.T) {
 	assert.Contains(t, result.Error().Error(), "HTTP response too large")
 	assert.Equal(t, "", result.Result().String())
 }
+
+func TestBridgeResponse_TooDeep(t *testing.T) {
+	config, cfgCleanup := cltest.NewConfig(t)
+	defer cfgCleanup()
+	config.Set("JSON_DEPTH_LIMIT", "2")
+
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	deepPayload := `{"data": {"result": [[1]]}}`
+	mock, serverCleanup := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", deepPayload,
+		func(h http.Header, b string) {},
+	)
+	defer serverCleanup()
+
+	_, bt := cltest.NewBridgeType(t, "auctionBidding", mock.URL)
+	ba := &adapters.Bridge{BridgeType: *bt}
+
+	input := cltest.NewRunInputWithResult("100")
+	result := ba.Perform(input, store)
+
+	require.Error(t, result.Error())
+	assert.Contains(t, result.Error().Error(), "JSON is nested more than 2 levels deep")
+}
+
+func TestBridge_Perform_failsOverToFailoverURL(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	jobRunID := models.NewID()
+	taskRunID := *models.NewID()
+
+	down, _ := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", "")
+	down.Close()
+	failover, cleanup := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", `{"data": {"result": "failover"}}`,
+		func(h http.Header, b string) {
+			assert.Contains(t, b, jobRunID.String())
+		},
+	)
+	defer cleanup()
+
+	_, bt := cltest.NewBridgeType(t, "regionalBridge", down.URL)
+	bt.FailoverURLs = models.WebURLs{cltest.WebURL(t, failover.URL)}
+	ba := &adapters.Bridge{BridgeType: *bt}
+
+	input := *models.NewRunInput(jobRunID, taskRunID, cltest.JSONFromString(t, `{}`), models.RunStatusUnstarted)
+	result := ba.Perform(input, store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, "failover", result.Result().String())
+}
+
+func TestBridge_Perform_errorTypes(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("DEFAULT_MAX_HTTP_ATTEMPTS", "3")
+
+	tests := []struct {
+		name      string
+		status    int
+		responses []string
+		wantCalls uint32
+		wantError string
+	}{
+		{"retryable error retried until it succeeds", http.StatusOK,
+			[]string{`{"error": "source timed out", "errorType": "retryable"}`, `{"data": {"result": "42"}}`}, 2, ""},
+		{"retryable error retried on every attempt", http.StatusOK,
+			[]string{`{"error": "source timed out", "errorType": "retryable"}`}, 3, "source timed out"},
+		{"fatal server error not retried", http.StatusInternalServerError,
+			[]string{`{"error": "unknown pair", "errorType": "fatal"}`}, 1, "unknown pair"},
+		{"server error without an error type retried", http.StatusServiceUnavailable,
+			[]string{`{"error": "unavailable"}`}, 3, "unavailable"},
+		{"too many requests retried", http.StatusTooManyRequests,
+			[]string{`{"error": "slow down"}`}, 3, "slow down"},
+		{"client error not retried", http.StatusBadRequest,
+			[]string{`{"error": "bad request"}`}, 1, "bad request"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls uint32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := int(atomic.AddUint32(&calls, 1))
+				if call > len(test.responses) {
+					call = len(test.responses)
+				}
+				w.WriteHeader(test.status)
+				io.WriteString(w, test.responses[call-1])
+			}))
+			defer server.Close()
+
+			_, bt := cltest.NewBridgeType(t, "errortypes", server.URL)
+			ba := &adapters.Bridge{BridgeType: *bt}
+			input := *models.NewRunInput(models.NewID(), *models.NewID(), cltest.JSONFromString(t, `{}`), models.RunStatusUnstarted)
+			result := ba.Perform(input, store)
+
+			assert.Equal(t, test.wantCalls, atomic.LoadUint32(&calls))
+			if test.wantError == "" {
+				require.NoError(t, result.Error())
+				assert.Equal(t, "42", result.Result().String())
+			} else {
+				require.Error(t, result.Error())
+				assert.Contains(t, result.Error().Error(), test.wantError)
+			}
+		})
+	}
+}
+
+func TestBridge_Perform_errorCategories(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("DEFAULT_MAX_HTTP_ATTEMPTS", "2")
+
+	tests := []struct {
+		name         string
+		bridge       string
+		status       int
+		response     string
+		wantCalls    uint32
+		wantCategory models.BridgeErrorCategory
+		wantOpen     bool
+	}{
+		{"rate limited with a retry hint", "ratelimitedhint", http.StatusOK,
+			`{"error": "quota exceeded", "errorCategory": "rateLimited", "retryAfter": 60}`, 2, models.BridgeErrorRateLimited, true},
+		{"rate limited without a retry hint", "ratelimited", http.StatusTooManyRequests,
+			`{"error": "slow down"}`, 2, models.BridgeErrorRateLimited, false},
+		{"rejected credentials", "rejectedcredentials", http.StatusUnauthorized,
+			`{"error": "bad api key"}`, 1, models.BridgeErrorAuth, true},
+		{"bad request given by the adapter", "badrequest", http.StatusOK,
+			`{"error": "unknown pair", "errorCategory": "badRequest"}`, 1, models.BridgeErrorBadRequest, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls uint32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddUint32(&calls, 1)
+				w.WriteHeader(test.status)
+				io.WriteString(w, test.response)
+			}))
+			defer server.Close()
+
+			_, bt := cltest.NewBridgeType(t, test.bridge, server.URL)
+			ba := &adapters.Bridge{BridgeType: *bt}
+			input := *models.NewRunInput(models.NewID(), *models.NewID(), cltest.JSONFromString(t, `{}`), models.RunStatusUnstarted)
+
+			result := ba.Perform(input, store)
+			require.Error(t, result.Error())
+			assert.Equal(t, test.wantCalls, atomic.LoadUint32(&calls))
+
+			var stats *adapters.BridgeErrorStats
+			for _, s := range adapters.BridgeErrors() {
+				if s.Bridge == test.bridge {
+					s := s
+					stats = &s
+				}
+			}
+			require.NotNil(t, stats)
+			assert.Equal(t, uint64(1), stats.Errors[test.wantCategory])
+
+			result = ba.Perform(input, store)
+			require.Error(t, result.Error())
+			if test.wantOpen {
+				assert.Equal(t, test.wantCategory, stats.Category)
+				assert.Equal(t, test.wantCalls, atomic.LoadUint32(&calls), "adapter called while its circuit is open")
+				assert.Contains(t, result.Error().Error(), "not calling it again")
+			} else {
+				assert.Nil(t, stats.OpenUntil)
+				assert.Equal(t, 2*test.wantCalls, atomic.LoadUint32(&calls))
+			}
+		})
+	}
+}
+
+func TestBridge_Perform_capsRetryAfter(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("DEFAULT_MAX_HTTP_ATTEMPTS", "1")
+	store.Config.Set("BRIDGE_MAX_RETRY_AFTER", "10s")
+
+	var calls uint32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddUint32(&calls, 1)
+		io.WriteString(w, `{"error": "quota exceeded", "errorCategory": "rateLimited", "retryAfter": 31536000}`)
+	}))
+	defer server.Close()
+
+	_, bt := cltest.NewBridgeType(t, "ratelimitedforayear", server.URL)
+	ba := &adapters.Bridge{BridgeType: *bt}
+	input := *models.NewRunInput(models.NewID(), *models.NewID(), cltest.JSONFromString(t, `{}`), models.RunStatusUnstarted)
+
+	result := ba.Perform(input, store)
+	require.Error(t, result.Error())
+	var openUntil *time.Time
+	for _, s := range adapters.BridgeErrors() {
+		if s.Bridge == "ratelimitedforayear" {
+			openUntil = s.OpenUntil
+		}
+	}
+	require.NotNil(t, openUntil)
+	assert.WithinDuration(t, time.Now().Add(10*time.Second), *openUntil, 5*time.Second)
+
+	// Resetting the circuit calls the adapter again straight away
+	adapters.ResetBridgeCircuit("ratelimitedforayear")
+	result = ba.Perform(input, store)
+	require.Error(t, result.Error())
+	assert.Equal(t, uint32(2), atomic.LoadUint32(&calls))
+}
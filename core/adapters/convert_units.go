@@ -0,0 +1,188 @@
+package adapters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// ConvertUnits converts the input's "result" field from one unit to another
+// of the same kind.
+type ConvertUnits struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// TaskType returns the type of Adapter.
+func (cu *ConvertUnits) TaskType() models.TaskType {
+	return TaskTypeConvertUnits
+}
+
+// unit converts values to and from the base unit of its dimension, such as
+// wei for amounts of ether. Units whose values are strings, such as
+// durations and dates, parse and format them in the base unit.
+type unit struct {
+	dimension string
+	toBase    func(decimal.Decimal) decimal.Decimal
+	fromBase  func(decimal.Decimal) decimal.Decimal
+	parse     func(string) (decimal.Decimal, error)
+	format    func(decimal.Decimal) string
+}
+
+// unitDivisionPrecision is the number of decimal places kept when dividing,
+// enough for a single wei in ether with room to spare.
+const unitDivisionPrecision = 36
+
+func scaledUnit(dimension string, factor decimal.Decimal) unit {
+	return unit{
+		dimension: dimension,
+		toBase:    func(d decimal.Decimal) decimal.Decimal { return d.Mul(factor) },
+		fromBase:  func(d decimal.Decimal) decimal.Decimal { return d.DivRound(factor, unitDivisionPrecision) },
+	}
+}
+
+var kelvinOffset = decimal.RequireFromString("273.15")
+
+// units holds each supported unit by name. Amounts of ether are in wei,
+// ratios in fractions of one, temperatures in kelvin and times in
+// nanoseconds.
+var units = map[string]unit{
+	"wei":    scaledUnit("ether", decimal.New(1, 0)),
+	"kwei":   scaledUnit("ether", decimal.New(1, 3)),
+	"mwei":   scaledUnit("ether", decimal.New(1, 6)),
+	"gwei":   scaledUnit("ether", decimal.New(1, 9)),
+	"szabo":  scaledUnit("ether", decimal.New(1, 12)),
+	"finney": scaledUnit("ether", decimal.New(1, 15)),
+	"eth":    scaledUnit("ether", decimal.New(1, 18)),
+	"ether":  scaledUnit("ether", decimal.New(1, 18)),
+
+	"ratio":   scaledUnit("ratio", decimal.New(1, 0)),
+	"percent": scaledUnit("ratio", decimal.New(1, -2)),
+	"bps":     scaledUnit("ratio", decimal.New(1, -4)),
+	"ppm":     scaledUnit("ratio", decimal.New(1, -6)),
+
+	"kelvin": scaledUnit("temperature", decimal.New(1, 0)),
+	"celsius": {
+		dimension: "temperature",
+		toBase:    func(d decimal.Decimal) decimal.Decimal { return d.Add(kelvinOffset) },
+		fromBase:  func(d decimal.Decimal) decimal.Decimal { return d.Sub(kelvinOffset) },
+	},
+	"fahrenheit": {
+		dimension: "temperature",
+		toBase: func(d decimal.Decimal) decimal.Decimal {
+			return d.Sub(decimal.New(32, 0)).Mul(decimal.New(5, 0)).DivRound(decimal.New(9, 0), unitDivisionPrecision).Add(kelvinOffset)
+		},
+		fromBase: func(d decimal.Decimal) decimal.Decimal {
+			return d.Sub(kelvinOffset).Mul(decimal.New(9, 0)).DivRound(decimal.New(5, 0), unitDivisionPrecision).Add(decimal.New(32, 0))
+		},
+	},
+
+	"ns":      scaledUnit("time", decimal.New(1, 0)),
+	"us":      scaledUnit("time", decimal.New(1, 3)),
+	"ms":      scaledUnit("time", decimal.New(1, 6)),
+	"seconds": scaledUnit("time", decimal.New(1, 9)),
+	"minutes": scaledUnit("time", decimal.New(60, 9)),
+	"hours":   scaledUnit("time", decimal.New(3600, 9)),
+	"days":    scaledUnit("time", decimal.New(86400, 9)),
+	// duration is a Go duration string such as "1h30m"
+	"duration": {
+		dimension: "time",
+		toBase:    func(d decimal.Decimal) decimal.Decimal { return d },
+		fromBase:  func(d decimal.Decimal) decimal.Decimal { return d },
+		parse: func(s string) (decimal.Decimal, error) {
+			duration, err := time.ParseDuration(s)
+			return decimal.New(int64(duration), 0), err
+		},
+		format: func(d decimal.Decimal) string { return time.Duration(d.IntPart()).String() },
+	},
+	// rfc3339 is a date such as "2020-09-13T12:26:40Z", converted to and
+	// from the time since the Unix epoch, so that it can be converted to a
+	// Unix timestamp in seconds or milliseconds. Dates are formatted in UTC.
+	"rfc3339": {
+		dimension: "time",
+		toBase:    func(d decimal.Decimal) decimal.Decimal { return d },
+		fromBase:  func(d decimal.Decimal) decimal.Decimal { return d },
+		parse: func(s string) (decimal.Decimal, error) {
+			t, err := time.Parse(time.RFC3339Nano, s)
+			return decimal.New(t.UnixNano(), 0), err
+		},
+		format: func(d decimal.Decimal) string { return time.Unix(0, d.IntPart()).UTC().Format(time.RFC3339Nano) },
+	},
+}
+
+var unitAliases = map[string]string{
+	"bp":          "bps",
+	"basispoints": "bps",
+	"%":           "percent",
+	"c":           "celsius",
+	"f":           "fahrenheit",
+	"k":           "kelvin",
+	"s":           "seconds",
+	"sec":         "seconds",
+	"min":         "minutes",
+	"h":           "hours",
+	"d":           "days",
+	"unix":        "seconds",
+	"unixms":      "ms",
+	"iso8601":     "rfc3339",
+}
+
+func lookupUnit(name string) (unit, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := unitAliases[name]; ok {
+		name = alias
+	}
+	u, ok := units[name]
+	if !ok {
+		names := make([]string, 0, len(units))
+		for n := range units {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return unit{}, fmt.Errorf("unknown unit %q, must be one of %s", name, strings.Join(names, ", "))
+	}
+	return u, nil
+}
+
+// Perform returns the input's "result" field converted from the adapter's
+// "from" unit to its "to" unit.
+//
+// For example, if the input value is "21.5", "from" is "gwei" and "to" is
+// "eth", the result's value will be "0.0000000215". If the input value is
+// 1600000000, "from" is "unix" and "to" is "rfc3339", the result's value will
+// be "2020-09-13T12:26:40Z".
+func (cu *ConvertUnits) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	from, err := lookupUnit(cu.From)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	to, err := lookupUnit(cu.To)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	if from.dimension != to.dimension {
+		return models.NewRunOutputError(fmt.Errorf("cannot convert %s, a unit of %s, to %s, a unit of %s", cu.From, from.dimension, cu.To, to.dimension))
+	}
+
+	var value decimal.Decimal
+	if result := input.Result(); from.parse != nil && result.Type == gjson.String {
+		if value, err = from.parse(result.Str); err != nil {
+			return models.NewRunOutputError(err)
+		}
+	} else if value, err = models.DecimalValue(result); err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	converted := to.fromBase(from.toBase(value))
+	if to.format != nil {
+		return models.NewRunOutputCompleteWithResult(to.format(converted))
+	}
+	return models.NewRunOutputCompleteWithResult(converted.String())
+}
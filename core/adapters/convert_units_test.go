@@ -0,0 +1,76 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertUnits_Perform_Success(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		json   string
+		want   string
+	}{
+		{"gwei to eth", `{"from":"gwei","to":"eth"}`, `{"result":"21.5"}`, "0.0000000215"},
+		{"eth to wei", `{"from":"ETH","to":"wei"}`, `{"result":1.5}`, "1500000000000000000"},
+		{"bps to percent", `{"from":"bps","to":"percent"}`, `{"result":250}`, "2.5"},
+		{"percent to ratio", `{"from":"%","to":"ratio"}`, `{"result":"12.5"}`, "0.125"},
+		{"celsius to fahrenheit", `{"from":"celsius","to":"fahrenheit"}`, `{"result":100}`, "212"},
+		{"fahrenheit to celsius", `{"from":"F","to":"C"}`, `{"result":"-40"}`, "-40"},
+		{"celsius to kelvin", `{"from":"celsius","to":"kelvin"}`, `{"result":"0"}`, "273.15"},
+		{"timestamp ms to seconds", `{"from":"ms","to":"seconds"}`, `{"result":1600000000000}`, "1600000000"},
+		{"minutes to hours", `{"from":"min","to":"hours"}`, `{"result":90}`, "1.5"},
+		{"duration to seconds", `{"from":"duration","to":"s"}`, `{"result":"1h30m"}`, "5400"},
+		{"seconds to duration", `{"from":"seconds","to":"duration"}`, `{"result":5400}`, "1h30m0s"},
+		{"unix to rfc3339", `{"from":"unix","to":"rfc3339"}`, `{"result":1600000000}`, "2020-09-13T12:26:40Z"},
+		{"unix ms to rfc3339", `{"from":"unixms","to":"rfc3339"}`, `{"result":"1600000000500"}`, "2020-09-13T12:26:40.5Z"},
+		{"iso8601 to unix", `{"from":"iso8601","to":"unix"}`, `{"result":"2020-09-13T14:26:40+02:00"}`, "1600000000"},
+		{"rfc3339 to unix ms", `{"from":"rfc3339","to":"unixms"}`, `{"result":"2020-09-13T12:26:40.25Z"}`, "1600000000250"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.ConvertUnits{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().String())
+		})
+	}
+}
+
+func TestConvertUnits_Perform_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		json   string
+		want   string
+	}{
+		{"unknown unit", `{"from":"gwei","to":"btc"}`, `{"result":1}`, `unknown unit "btc"`},
+		{"different dimensions", `{"from":"gwei","to":"celsius"}`, `{"result":1}`, "cannot convert gwei, a unit of ether, to celsius, a unit of temperature"},
+		{"not a number", `{"from":"gwei","to":"eth"}`, `{"result":"lots"}`, ""},
+		{"bad duration", `{"from":"duration","to":"seconds"}`, `{"result":"soon"}`, ""},
+		{"bad date", `{"from":"rfc3339","to":"unix"}`, `{"result":"yesterday"}`, "cannot parse"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.ConvertUnits{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			require.Error(t, result.Error())
+			assert.Contains(t, result.Error().Error(), test.want)
+		})
+	}
+}
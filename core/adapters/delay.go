@@ -0,0 +1,101 @@
+package adapters
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// DelayUntilKey is the key of the time a delayed task is resumed at, which
+// the Delay adapter keeps in the task's data while the run waits.
+const DelayUntilKey = "delayUntil"
+
+// Delay adapter suspends the run for the given duration. Unlike Sleep, it
+// does not hold a worker while waiting: the run is saved as pending_sleep and
+// resumed by the scheduler once the duration has passed, so the delay
+// survives a restart of the node.
+//
+// With a Jitter, the run is delayed by up to that much longer. The extra
+// delay is derived from the node's account and the round being answered, the
+// input's dataPrefix unless Round says otherwise, so that each node of an
+// oracle set submits at a different but reproducible time in every round
+// instead of all of them competing for the same block.
+type Delay struct {
+	Duration models.Duration `json:"duration"`
+	Jitter   models.Duration `json:"jitter"`
+	Round    string          `json:"round"`
+}
+
+// TaskType returns the type of Adapter.
+func (adapter *Delay) TaskType() models.TaskType {
+	return TaskTypeDelay
+}
+
+// Perform suspends the run on its first invocation and passes the input on
+// unchanged once resumed after the delay.
+func (adapter *Delay) Perform(input models.RunInput, str *store.Store) models.RunOutput {
+	now := str.Clock.Now()
+	if !input.Status().PendingSleep() {
+		until := now.Add(adapter.Duration.Duration() + adapter.jitter(input, str))
+		data, err := input.Data().Add(DelayUntilKey, until.UTC().Format(time.RFC3339Nano))
+		if err != nil {
+			return models.NewRunOutputError(err)
+		}
+		logger.Debugw("Task delaying run", "duration", adapter.Duration, "until", until)
+		return models.NewRunOutputPendingSleepWithData(data)
+	}
+
+	if !DelayElapsed(input.Data(), now) {
+		return models.NewRunOutputPendingSleepWithData(input.Data())
+	}
+	data, err := input.Data().Delete(DelayUntilKey)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputComplete(data)
+}
+
+// jitter returns the extra delay of the run, which is the same each time for
+// a node and round.
+func (adapter *Delay) jitter(input models.RunInput, str *store.Store) time.Duration {
+	max := adapter.Jitter.Duration()
+	if max <= 0 {
+		return 0
+	}
+	round := adapter.Round
+	if round == "" {
+		round = input.Data().Get("dataPrefix").String()
+	}
+	if round == "" && input.JobRunID() != nil {
+		round = input.JobRunID().String()
+	}
+	seed := []byte(round)
+	if account, err := str.KeyStore.GetFirstAccount(); err == nil {
+		seed = append(account.Address.Bytes(), seed...)
+	}
+	return DelayJitter(seed, max)
+}
+
+// DelayJitter returns a duration in [0, max) determined by seed.
+func DelayJitter(seed []byte, max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	hash := utils.MustHash(string(seed))
+	return time.Duration(binary.BigEndian.Uint64(hash[:8]) % uint64(max))
+}
+
+// DelayElapsed returns true if the delay recorded in the data of a task
+// suspended by the Delay adapter has passed at now. A task without a valid
+// deadline is treated as due, so that it can never stay suspended forever.
+func DelayElapsed(data models.JSON, now time.Time) bool {
+	until, err := time.Parse(time.RFC3339Nano, data.Get(DelayUntilKey).String())
+	if err != nil {
+		return true
+	}
+	return !now.Before(until)
+}
@@ -0,0 +1,108 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelay_Perform(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	clock := new(mocks.AfterNower)
+	clock.On("Now").Return(now).Once()
+	store.Clock = clock
+
+	adapter := adapters.Delay{}
+	require.NoError(t, json.Unmarshal([]byte(`{"duration": "30s"}`), &adapter))
+
+	input := cltest.NewRunInputWithString(t, `{"result": "0x1"}`)
+	result := adapter.Perform(input, store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, models.RunStatusPendingSleep, result.Status())
+	assert.Equal(t, "0x1", result.Data().Get("result").String())
+	assert.Equal(t, "2020-10-01T12:00:30Z", result.Data().Get(adapters.DelayUntilKey).String())
+
+	resumed := *models.NewRunInput(input.JobRunID(), input.TaskRunID(), result.Data(), models.RunStatusPendingSleep)
+
+	clock.On("Now").Return(now.Add(29 * time.Second)).Once()
+	result = adapter.Perform(resumed, store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, models.RunStatusPendingSleep, result.Status())
+
+	clock.On("Now").Return(now.Add(30 * time.Second)).Once()
+	result = adapter.Perform(resumed, store)
+	require.NoError(t, result.Error())
+	assert.Equal(t, models.RunStatusCompleted, result.Status())
+	assert.Equal(t, `{"result":"0x1"}`, result.Data().String())
+
+	clock.AssertExpectations(t)
+}
+
+func TestDelayElapsed(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{"before", `{"delayUntil": "2020-10-01T12:00:01Z"}`, false},
+		{"at", `{"delayUntil": "2020-10-01T12:00:00Z"}`, true},
+		{"after", `{"delayUntil": "2020-10-01T11:59:59Z"}`, true},
+		{"missing", `{}`, true},
+		{"invalid", `{"delayUntil": "soon"}`, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			data := cltest.JSONFromString(t, test.data)
+			assert.Equal(t, test.want, adapters.DelayElapsed(data, now))
+		})
+	}
+}
+
+func TestDelay_Perform_Jitter(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	clock := new(mocks.AfterNower)
+	clock.On("Now").Return(now)
+	store.Clock = clock
+
+	adapter := adapters.Delay{}
+	require.NoError(t, json.Unmarshal([]byte(`{"duration": "30s", "jitter": "10s"}`), &adapter))
+
+	delayUntil := func(dataPrefix string) time.Time {
+		input := cltest.NewRunInputWithString(t, fmt.Sprintf(`{"result": "0x1", "dataPrefix": %q}`, dataPrefix))
+		result := adapter.Perform(input, store)
+		require.NoError(t, result.Error())
+		until, err := time.Parse(time.RFC3339Nano, result.Data().Get(adapters.DelayUntilKey).String())
+		require.NoError(t, err)
+		assert.False(t, until.Before(now.Add(30*time.Second)))
+		assert.True(t, until.Before(now.Add(40*time.Second)))
+		return until
+	}
+
+	assert.Equal(t, delayUntil("0x01"), delayUntil("0x01"))
+	assert.NotEqual(t, delayUntil("0x01"), delayUntil("0x02"))
+}
+
+func TestDelayJitter(t *testing.T) {
+	assert.Equal(t, time.Duration(4248994493), adapters.DelayJitter([]byte("round1"), 10*time.Second))
+	assert.Equal(t, time.Duration(9490762838), adapters.DelayJitter([]byte("round2"), 10*time.Second))
+	assert.Equal(t, time.Duration(0), adapters.DelayJitter([]byte("round1"), 0))
+}
@@ -1,5 +1,19 @@
 // Package adapters contain the core adapters used by the Chainlink node.
 //
+// Results
+//
+// Each adapter reads the previous task's result as the type it needs, using
+// the coercion rules of models.ValueType. A result which cannot be coerced,
+// such as a bool given to Multiply, errors the run with a message naming the
+// value and the type it was needed as.
+//
+// Aggregate
+//
+// The Aggregate adapter reduces an array to its "sum", "product", "min", "max",
+// "mean", "median" or "count". The optional "path" locates the array within
+// the result and "field" the number within each element.
+//   { "type": "Aggregate", "params": {"operation": "sum", "field": ["balance"] }}
+//
 // Bridge
 //
 // The Bridge adapter is used to send and receive data to and from external adapters.
@@ -8,6 +22,27 @@
 // For example:
 //  {"id": "b8004e2989e24e1d8e4449afad2eb480", "data": {}}
 //
+// An adapter may return an "errorType" of "retryable" along with its error,
+// for errors which calling it again may resolve, and the call is retried as
+// an HTTP task's would be. An "errorType" of "fatal" errors the run at once,
+// whatever the response's status code. Without an errorType, server errors,
+// 408 and 429 responses are retried.
+//  {"id": "b8004e2989e24e1d8e4449afad2eb480", "error": "rate limited", "errorType": "retryable"}
+//
+// An adapter may also give the "errorCategory" of its error: "rateLimited",
+// "auth", "upstreamDown" or "badRequest", which is otherwise inferred from
+// the status code. Rate limited and upstream down errors are retried, the
+// others are not. A rate limited adapter which gives a "retryAfter" in
+// seconds is not called again until it has passed, up to
+// BRIDGE_MAX_RETRY_AFTER, nor is an adapter whose credentials were rejected
+// for several minutes, nor one which has failed several calls in a row for a
+// minute. Runs calling it in the meantime error at once. Updating the bridge
+// type calls it again straight away.
+//  {"id": "b8004e2989e24e1d8e4449afad2eb480", "error": "quota exceeded", "errorCategory": "rateLimited", "retryAfter": 60}
+//
+// A bridge type may list failoverURLs, hosting the same external adapter in
+// other regions, which are posted to when the adapter's URL fails to respond.
+//
 // Compare
 //
 // The Compare adapter is used to compare the previous task's result
@@ -15,6 +50,53 @@
 // adapter will save `true` or `false` in the task run's result.
 //  { "type": "Compare", "params": {"operator": "eq", "value": "Hello" }}
 //
+// ConvertUnits
+//
+// The ConvertUnits adapter converts the input value between units of ether
+// (wei, gwei, eth...), ratios (bps, percent, ppm, ratio), temperatures (celsius,
+// fahrenheit, kelvin) or times (ns, ms, seconds, minutes, hours, days, and
+// duration strings such as "1h30m"), in place of multiplying by a constant.
+// Unix timestamps (unix, unixms) convert to and from rfc3339 dates such as
+// "2020-09-13T12:26:40Z".
+//   { "type": "ConvertUnits", "params": {"from": "gwei", "to": "eth" }}
+//   { "type": "ConvertUnits", "params": {"from": "unix", "to": "rfc3339" }}
+//
+// Decode
+//
+// The Decode adapter decodes a "hex", "base64" or "base64url" encoded result
+// into 0x-prefixed hex, or with "string" into a UTF-8 string.
+//   { "type": "Decode", "params": {"encoding": "base64", "string": true }}
+//
+// Delay
+//
+// The Delay adapter suspends the run for the given duration before passing
+// its input on. The run is saved while it waits and resumed by the scheduler,
+// so no worker is held and a restart does not cut the delay short. A
+// "jitter" adds up to that much more delay, fixed for the node and the round
+// given by "round" or else the input's dataPrefix, to spread the submissions
+// of an oracle set over several blocks.
+//   { "type": "Delay", "params": {"duration": "30s", "jitter": "15s" }}
+//
+// Encode
+//
+// The Encode adapter encodes the result as "hex", "base64" or "base64url". A
+// hex result is encoded as the bytes it represents.
+//   { "type": "Encode", "params": {"encoding": "base64" }}
+//
+// EncodePacked
+//
+// The EncodePacked adapter encodes an array of values as Solidity's
+// abi.encodePacked does, given the ABI "types" of the values, and returns
+// the hex encoded bytes.
+//   { "type": "EncodePacked", "params": {"types": ["address", "uint256"] }}
+//
+// Hash
+//
+// The Hash adapter returns the hex encoded "keccak256", the default, or
+// "sha256" hash of the result. A hex result is hashed as the bytes it
+// represents.
+//   { "type": "Hash", "params": {"function": "sha256" }}
+//
 // HTTPGet
 //
 // The HTTPGet adapter is used to grab the JSON data from the given URL.
@@ -24,6 +106,17 @@
 // restrictions on which IPs may be fetched. Local network and multicast IPs
 // are disallowed by default and attempting to connect will result in an error.
 //
+// The same data may be fetched from several URLs, for instance a provider's
+// regional endpoints. Requests go to the failoverURLs when the first URL
+// fails to respond, without waiting to retry it. With a urlSelection of
+// "latency", rather than the default "ordered", the URLs are tried fastest
+// first, going by recent response times, and those which recently failed
+// are tried last.
+//  { "type": "HTTPGet", "params": {
+//    "get": "https://us.some-api-example.net/api",
+//    "failoverURLs": ["https://eu.some-api-example.net/api"],
+//    "urlSelection": "latency"
+//  }}
 //
 // HTTPPost
 //
@@ -34,6 +127,8 @@
 // restrictions on which IPs may be fetched. Local network and multicast IPs
 // are disallowed by default and attempting to connect will result in an error.
 //
+// HTTPPost accepts failoverURLs and urlSelection as HTTPGet does.
+//
 // HTTPGetWithUnrestrictedNetworkAccess
 //
 // Identical to HTTPGet except there are no IP restrictions. Use with caution.
@@ -81,12 +176,71 @@
 //     }
 //   }
 //
+// EthTxCommitReveal
+//
+// The EthTxCommitReveal adapter submits the result, a bytes32, in two
+// transactions. The first calls commitFunctionSelector with the keccak256
+// hash of the result followed by a random 32 byte salt. Once that is
+// confirmed and revealDelay has passed, the second calls
+// revealFunctionSelector with the result and the salt. It requires the
+// bulletproof tx manager.
+//   {
+//     "type": "EthTxCommitReveal", "params": {
+//       "address": "0x0000000000000000000000000000000000000000",
+//       "commitFunctionSelector": "0xf14fcbc8",
+//       "revealFunctionSelector": "0x2c1f9d3a",
+//       "revealDelay": "5m"
+//     }
+//   }
+//
+// Map
+//
+// The Map adapter performs its tasks once for each element of the array
+// in its input, and returns the array of their results. Each element is
+// passed to the first task as its result. Tasks which send transactions or
+// do not complete synchronously cannot be used within a map.
+//   { "type": "Map", "params": {"path": ["tokens"], "tasks": [
+//     { "type": "HTTPGet", "params": {"get": "https://some-api-example.net/price"} },
+//     { "type": "JSONParse", "params": {"path": ["price"]} }
+//   ]}}
+//
+// Merge
+//
+// The Merge adapter returns an object assembled from its values, which
+// will usually be the results of earlier named tasks. The keys given to
+// each value name it in the merged result.
+//   { "type": "Merge", "params": {"values": {
+//     "price": "$(fetchPrice.result)",
+//     "volume": "$(fetchVolume.result.volume)"
+//   }}}
+//
+// Merkle
+//
+// The Merkle adapter builds a Merkle tree over an array of values and returns
+// its root, or with "proofs" its root, leaves and the proof of each leaf. The
+// "encoding" of the values may be "bytes", "string", "uint256", "address" or
+// "leaf", and the "hash" "keccak256" or "sha256". Pairs are sorted before being
+// hashed unless "sortPairs" is false.
+//   { "type": "Merkle", "params": {"encoding": "address", "proofs": true }}
+//
 // Multiplier
 //
 // The Multiplier adapter multiplies the given input value times another specified
 // value.
 //   { "type": "Multiply", "params": {"times": 100 }}
 //
+// ParseNumber
+//
+// The ParseNumber adapter converts a numeric string into a decimal. It accepts
+// thousands separators, exponents and the decimal comma of locales such as
+// "de", given by "locale" or "decimalSeparator".
+//   { "type": "ParseNumber", "params": {"locale": "de-DE" }}
+//
+// Pluck
+//
+// The Pluck adapter returns an array of the values of a field of each element.
+//   { "type": "Pluck", "params": {"path": ["accounts"], "field": ["address"] }}
+//
 // Quotient
 //
 // The Quotient adapter gives the result of x / y where x is a specified value (dividend)
@@ -98,6 +252,54 @@
 // value.
 //   { "type": "Quotient", "params": {"dividend": 1 }}
 //
+// Reduce
+//
+// The Reduce adapter folds an array into a number by evaluating an expression
+// for each element, where "x" is the element, "i" its index and "acc" the value
+// so far, starting from "initial". Expressions may use + - * /, parentheses,
+// min, max and abs.
+//   { "type": "Reduce", "params": {"expression": "acc + x * x", "initial": 0 }}
+//
+// Sign
+//
+// The Sign adapter signs the previous task's result with a key held by the node
+// and returns the hex encoded signature. The default "eth_sign" scheme hashes
+// the result with keccak256 and signs it with the eth_sign message prefix, using
+// the account given by "key", which is required and cannot be the funding
+// account.
+//   { "type": "Sign", "params": {"key": "0x356a04bCe728ba4c62A30294A55E6A8600a320B3" }}
+//
+// The "personal_sign" scheme signs the result itself with the EIP-191 message
+// prefix, as wallets' personal_sign does, and the "eip712" scheme signs the
+// EIP-712 typed data given by the result, provided its domain's
+// verifyingContract and chainId are among "domains". With "components", the
+// result holds the signature's r, s and v as well as the signature.
+//   { "type": "Sign", "params": {
+//     "scheme": "eip712",
+//     "key": "0x356a04bCe728ba4c62A30294A55E6A8600a320B3",
+//     "domains": [{"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC", "chainId": 1}],
+//     "components": true
+//   }}
+//
+// The "ed25519" scheme signs the result with the off-chain key of the OCR key
+// bundle whose ID is given by "key". OCR key bundles encrypted with the
+// keystore password are unlocked when the node starts.
+//   { "type": "Sign", "params": {"scheme": "ed25519", "key": "<bundle ID>" }}
+//
+// TypedData
+//
+// The TypedData adapter builds EIP-712 typed data for the Sign adapter's
+// "eip712" scheme from its "types", "primaryType", "domain" and "message",
+// or the result when there is no "message", and checks the domain and message
+// against their types. The EIP712Domain type may be left out, to be made up
+// of the fields the domain has.
+//   { "type": "TypedData", "params": {
+//     "types": {"Order": [{"name": "price", "type": "uint256"}]},
+//     "primaryType": "Order",
+//     "domain": {"name": "Exchange", "version": "1", "chainId": 1},
+//     "message": {"price": "$(fetchPrice.result)"}
+//   }}
+//
 // Random
 //
 // Random adapter generates proofs of randomness verifiable against a public key
@@ -120,7 +322,9 @@
 //   WARNING: required to provide. Solutions to these limitations are planned.
 //
 // Here is an example of a Random task specification. For an example of a full
-// jobspec using this, see ../internal/testdata/randomness_job.json.
+// jobspec using this, see ../internal/testdata/randomness_job.json. A
+// randomnesslog job must have a Random task, whose publicKey is a VRF key
+// registered with the node, such as one made by `chainlink local vrf create`.
 //
 //  {
 //    "type": "Random",
@@ -0,0 +1,103 @@
+package adapters
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// EncodePacked encodes the values of the array in its input as Solidity's
+// abi.encodePacked would, with Types giving the ABI type of each value. Path
+// optionally locates the array within the input's result.
+type EncodePacked struct {
+	Types []string `json:"types"`
+	Path  JSONPath `json:"path"`
+}
+
+// TaskType returns the type of Adapter.
+func (e *EncodePacked) TaskType() models.TaskType {
+	return TaskTypeEncodePacked
+}
+
+// Perform returns the hex encoded packed encoding of the values. Values are
+// given as EthTxABIEncode takes its arguments, with integers too large for a
+// JSON number given as decimal or 0x-prefixed hex strings.
+//
+// For example, if the input value is ["0x7E57", 1] and the adapter's "types"
+// are ["bytes", "uint16"], the result's value will be "0x7e570001".
+func (e *EncodePacked) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	elements, err := arrayElements(input.Result(), e.Path, nil)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	if len(elements) != len(e.Types) {
+		return models.NewRunOutputError(fmt.Errorf("got %d values for %d types", len(elements), len(e.Types)))
+	}
+
+	var packed []byte
+	for i, element := range elements {
+		typ, err := abi.NewType(e.Types[i], "", nil)
+		if err != nil {
+			return models.NewRunOutputError(errors.Wrapf(err, "type %d", i))
+		} else if !isSupportedABIType(&typ) {
+			return models.NewRunOutputError(fmt.Errorf("type %d: %s is not supported", i, e.Types[i]))
+		}
+		encoded, err := encPacked(&typ, element.Value(), fmt.Sprintf("%d", i))
+		if err != nil {
+			return models.NewRunOutputError(err)
+		}
+		packed = append(packed, encoded...)
+	}
+	return models.NewRunOutputCompleteWithResult(hexutil.Encode(packed))
+}
+
+// encPacked encodes jval without padding, except for the elements of arrays,
+// which are padded to a word each as in the standard encoding.
+func encPacked(typ *abi.Type, jval interface{}, name string) ([]byte, error) {
+	switch typ.T {
+	case abi.BytesTy:
+		return bytesFromJSON(jval, name)
+	case abi.StringTy:
+		s, ok := jval.(string)
+		if !ok {
+			return nil, errors.Errorf("argument %s is not a string", name)
+		}
+		return []byte(s), nil
+	case abi.SliceTy:
+		s, ok := jval.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("argument %s is not an array", name)
+		}
+		var result []byte
+		for i, elem := range s {
+			encoded, err := encStatic(typ.Elem, elem, fmt.Sprintf("%s[%v]", name, i))
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, encoded...)
+		}
+		return result, nil
+	case abi.ArrayTy:
+		return encStatic(typ, jval, name)
+	}
+
+	word, err := encStatic(typ, jval, name)
+	if err != nil {
+		return nil, err
+	}
+	switch typ.T {
+	case abi.AddressTy:
+		return word[evmWordSize-20:], nil
+	case abi.BoolTy:
+		return word[evmWordSize-1:], nil
+	case abi.FixedBytesTy:
+		return word[:typ.Size], nil
+	default: // abi.IntTy, abi.UintTy
+		return word[evmWordSize-typ.Size/8:], nil
+	}
+}
@@ -0,0 +1,52 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodePacked_Perform(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  string
+		json    string
+		want    string
+		errored bool
+	}{
+		{"bytes and uint16", `{"types":["bytes","uint16"]}`, `{"result":["0x7E57",1]}`, "0x7e570001", false},
+		{
+			"all kinds",
+			`{"types":["address","uint256","int8","string","bool","uint8[]","bytes2"],"path":["values"]}`,
+			`{"result":{"values":["0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf","0x8000000000000000000000000000000000000000000000000000000000000000",-1,"hi",true,[1,2],"0xabcd"]}}`,
+			"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf8000000000000000000000000000000000000000000000000000000000000000ff6869010000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000002abcd",
+			false,
+		},
+		{"too few values", `{"types":["uint8","uint8"]}`, `{"result":[1]}`, "", true},
+		{"out of range", `{"types":["uint8"]}`, `{"result":[256]}`, "", true},
+		{"invalid type", `{"types":["uint7"]}`, `{"result":[1]}`, "", true},
+		{"unsupported type", `{"types":["tuple"]}`, `{"result":[1]}`, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.EncodePacked{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			if test.errored {
+				assert.Error(t, result.Error())
+				return
+			}
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().String())
+		})
+	}
+}
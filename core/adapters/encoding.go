@@ -0,0 +1,92 @@
+package adapters
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// Encode encodes the input's result as "hex", "base64" or "base64url", given
+// by Encoding.
+type Encode struct {
+	Encoding string `json:"encoding"`
+}
+
+// TaskType returns the type of Adapter.
+func (e *Encode) TaskType() models.TaskType {
+	return TaskTypeEncode
+}
+
+// Perform returns the encoded result. A 0x-prefixed hex result is encoded as
+// the bytes it represents, any other result as its string value, so that
+// "hello" is encoded in base64 as "aGVsbG8=" and in hex as "0x68656c6c6f".
+func (e *Encode) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	payload, err := signingPayload(input.Result().String())
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	switch e.Encoding {
+	case "hex":
+		return models.NewRunOutputCompleteWithResult(hexutil.Encode(payload))
+	case "base64":
+		return models.NewRunOutputCompleteWithResult(base64.StdEncoding.EncodeToString(payload))
+	case "base64url":
+		return models.NewRunOutputCompleteWithResult(base64.URLEncoding.EncodeToString(payload))
+	}
+	return models.NewRunOutputError(unknownEncodingError(e.Encoding))
+}
+
+// Decode decodes the input's result from "hex", "base64" or "base64url",
+// given by Encoding.
+type Decode struct {
+	Encoding string `json:"encoding"`
+	// String returns the decoded bytes as a UTF-8 string rather than hex
+	// encoded.
+	String bool `json:"string"`
+}
+
+// TaskType returns the type of Adapter.
+func (d *Decode) TaskType() models.TaskType {
+	return TaskTypeDecode
+}
+
+// Perform returns the decoded bytes, as 0x-prefixed hex or, if String is
+// set, as a string. Hex may be given with or without its 0x prefix, and
+// base64 with or without padding.
+func (d *Decode) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	encoded := input.Result().String()
+	var decoded []byte
+	var err error
+	switch d.Encoding {
+	case "hex":
+		decoded, err = hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(encoded, "0x"), "0X"))
+	case "base64":
+		decoded, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	case "base64url":
+		decoded, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	default:
+		return models.NewRunOutputError(unknownEncodingError(d.Encoding))
+	}
+	if err != nil {
+		return models.NewRunOutputError(errors.Wrapf(err, "%q is not valid %s", encoded, d.Encoding))
+	}
+
+	if !d.String {
+		return models.NewRunOutputCompleteWithResult(hexutil.Encode(decoded))
+	} else if !utf8.Valid(decoded) {
+		return models.NewRunOutputError(errors.New("decoded bytes are not a UTF-8 string"))
+	}
+	return models.NewRunOutputCompleteWithResult(string(decoded))
+}
+
+func unknownEncodingError(encoding string) error {
+	return fmt.Errorf("unknown encoding %q, must be hex, base64 or base64url", encoding)
+}
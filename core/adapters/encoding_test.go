@@ -0,0 +1,75 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_Perform(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		json     string
+		want     string
+		errored  bool
+	}{
+		{"hex", "hex", `{"result":"hello"}`, "0x68656c6c6f", false},
+		{"base64 string", "base64", `{"result":"hello"}`, "aGVsbG8=", false},
+		{"base64 bytes", "base64", `{"result":"0xfbff"}`, "+/8=", false},
+		{"base64url bytes", "base64url", `{"result":"0xfbff"}`, "-_8=", false},
+		{"unknown encoding", "base58", `{"result":"hello"}`, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.Encode{Encoding: test.encoding}
+			result := adapter.Perform(input, nil)
+
+			if test.errored {
+				assert.Error(t, result.Error())
+				return
+			}
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().String())
+		})
+	}
+}
+
+func TestDecode_Perform(t *testing.T) {
+	tests := []struct {
+		name    string
+		adapter adapters.Decode
+		json    string
+		want    string
+		errored bool
+	}{
+		{"hex", adapters.Decode{Encoding: "hex", String: true}, `{"result":"0x68656c6c6f"}`, "hello", false},
+		{"unprefixed hex", adapters.Decode{Encoding: "hex"}, `{"result":"FBFF"}`, "0xfbff", false},
+		{"base64", adapters.Decode{Encoding: "base64"}, `{"result":"+/8="}`, "0xfbff", false},
+		{"unpadded base64", adapters.Decode{Encoding: "base64", String: true}, `{"result":"aGVsbG8"}`, "hello", false},
+		{"base64url", adapters.Decode{Encoding: "base64url"}, `{"result":"-_8="}`, "0xfbff", false},
+		{"invalid base64", adapters.Decode{Encoding: "base64"}, `{"result":"-_8="}`, "", true},
+		{"invalid UTF-8", adapters.Decode{Encoding: "hex", String: true}, `{"result":"0xfbff"}`, "", true},
+		{"unknown encoding", adapters.Decode{Encoding: "base58"}, `{"result":"abc"}`, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			result := test.adapter.Perform(input, nil)
+
+			if test.errored {
+				assert.Error(t, result.Error())
+				return
+			}
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().String())
+		})
+	}
+}
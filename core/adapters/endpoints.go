@@ -0,0 +1,87 @@
+package adapters
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+const (
+	// endpointLatencyWeight is the weight of each new response time in a
+	// host's moving average
+	endpointLatencyWeight = 0.3
+	// endpointFailureCooldown is how long a host which failed to respond is
+	// tried after the others
+	endpointFailureCooldown = time.Minute
+)
+
+// endpointStats records how quickly each host has lately responded, and when
+// it last failed to, which latency based URL selection orders requests by.
+// It is shared by every task, so that one run's failure spares the next.
+type endpointStats struct {
+	mu    sync.Mutex
+	hosts map[string]*hostStats
+}
+
+type hostStats struct {
+	latency  time.Duration
+	failedAt time.Time
+}
+
+var endpoints = &endpointStats{hosts: make(map[string]*hostStats)}
+
+// record notes the outcome of a request to host.
+func (s *endpointStats) record(host string, latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats, ok := s.hosts[host]
+	if !ok {
+		stats = &hostStats{}
+		s.hosts[host] = stats
+	}
+	if err != nil {
+		stats.failedAt = time.Now()
+		return
+	}
+	if stats.latency == 0 {
+		stats.latency = latency
+	} else {
+		stats.latency = time.Duration(endpointLatencyWeight*float64(latency) + (1-endpointLatencyWeight)*float64(stats.latency))
+	}
+}
+
+// order returns the requests in the order they should be tried. Ordered
+// selection keeps them as given. Latency selection puts hosts which have
+// just failed last, and the rest fastest first, with hosts which have not
+// been tried yet ahead of them all so that their latency becomes known.
+func (s *endpointStats) order(requests []*http.Request, selection models.EndpointSelection) []*http.Request {
+	if selection.OrDefault() != models.EndpointSelectionLatency || len(requests) < 2 {
+		return requests
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	failing := func(r *http.Request) bool {
+		stats, ok := s.hosts[r.URL.Host]
+		return ok && now.Sub(stats.failedAt) < endpointFailureCooldown
+	}
+	latency := func(r *http.Request) time.Duration {
+		if stats, ok := s.hosts[r.URL.Host]; ok {
+			return stats.latency
+		}
+		return 0
+	}
+
+	ordered := append([]*http.Request{}, requests...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if fi, fj := failing(ordered[i]), failing(ordered[j]); fi != fj {
+			return fj
+		}
+		return latency(ordered[i]) < latency(ordered[j])
+	})
+	return ordered
+}
@@ -109,6 +109,8 @@ func TestEthUint256_Perform(t *testing.T) {
 			"0x00000000000000000000000000000000000000000000000000000000000041a0", false},
 		{"scientific string", `{"result":"1.68e+4"}`,
 			"0x00000000000000000000000000000000000000000000000000000000000041a0", false},
+		{"integer larger than a float64 can hold", `{"result":12345678901234567890123}`,
+			"0x00000000000000000000000000000000000000000000029d42b64e76714244cb", false},
 		{"negative integer", `{"result":-123}`, "", true},
 		{"negative string", `{"result":"-123"}`, "", true},
 		{"negative float", `{"result":-123.99}`, "", true},
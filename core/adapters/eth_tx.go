@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
@@ -35,6 +36,9 @@ type EthTx struct {
 	DataPrefix       hexutil.Bytes           `json:"dataPrefix"`
 	DataFormat       string                  `json:"format"`
 	GasLimit         uint64                  `json:"gasLimit,omitempty"`
+	// ResultKeys names the values of the input data to send, in order, in
+	// place of the single result. See getMultiWordTxData.
+	ResultKeys []string `json:"resultKeys,omitempty"`
 
 	// GasPrice only needed for legacy tx manager
 	GasPrice *utils.Big `json:"gasPrice" gorm:"type:numeric"`
@@ -48,6 +52,15 @@ func (e *EthTx) TaskType() models.TaskType {
 	return TaskTypeEthTx
 }
 
+// Validate returns an error if the task's resultKeys cannot be sent in its
+// format.
+func (e *EthTx) Validate() error {
+	if len(e.ResultKeys) > 0 && e.DataFormat != "" && e.DataFormat != DataFormatBytes {
+		return fmt.Errorf("resultKeys cannot be sent in the %s format, only as bytes32 words or bytes", e.DataFormat)
+	}
+	return nil
+}
+
 // Perform creates the run result for the transaction if the existing run result
 // is not currently pending. Then it confirms the transaction was confirmed on
 // the blockchain.
@@ -129,6 +142,10 @@ func (e *EthTx) insertEthTx(input models.RunInput, store *strpkg.Store) models.R
 		gasLimit = e.GasLimit
 	}
 
+	if err := store.CheckGasQuota(fromAddress, gasLimit, time.Now()); err != nil {
+		return models.NewRunOutputError(errors.Wrap(err, "insertEthTx failed"))
+	}
+
 	if err := store.IdempotentInsertEthTaskRunTx(taskRunID, fromAddress, toAddress, encodedPayload, gasLimit); err != nil {
 		err = errors.Wrap(err, "insertEthTx failed")
 		logger.Error(err)
@@ -216,6 +233,10 @@ func (e *EthTx) legacyPerform(input models.RunInput, store *strpkg.Store) models
 // getTxData returns the data to save against the callback encoded according to
 // the dataFormat parameter in the job spec
 func getTxData(e *EthTx, input models.RunInput) ([]byte, error) {
+	if len(e.ResultKeys) > 0 {
+		return getMultiWordTxData(e, input)
+	}
+
 	result := input.Result()
 	if e.DataFormat == "" {
 		return common.HexToHash(result.Str).Bytes(), nil
@@ -226,16 +247,48 @@ func getTxData(e *EthTx, input models.RunInput) ([]byte, error) {
 		return []byte{}, err
 	}
 	if e.DataFormat == DataFormatBytes || len(e.DataPrefix) > 0 {
-		payloadOffset := utils.EVMWordUint64(utils.EVMWordByteLen)
-		if len(e.DataPrefix) > 0 {
-			payloadOffset = utils.EVMWordUint64(utils.EVMWordByteLen * 2)
-			return utils.ConcatBytes(payloadOffset, output), nil
-		}
-		return utils.ConcatBytes(payloadOffset, output), nil
+		return utils.ConcatBytes(bytesPayloadOffset(e), output), nil
 	}
 	return utils.ConcatBytes(output), nil
 }
 
+// getMultiWordTxData encodes the values of the input data named by the task's
+// resultKeys as bytes32 words, see utils.EVMTranscodeBytes32. Without a
+// format the words are sent as consecutive bytes32 arguments. With the bytes
+// format they are sent as a single bytes argument holding their ABI encoding,
+// for the consumer to abi.decode.
+func getMultiWordTxData(e *EthTx, input models.RunInput) ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	words := make([][]byte, len(e.ResultKeys))
+	for i, key := range e.ResultKeys {
+		value := input.Data().Get(key)
+		if !value.Exists() {
+			return nil, fmt.Errorf("no value for result key %s", key)
+		}
+		word, err := utils.EVMTranscodeBytes32(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while encoding result key %s", key)
+		}
+		words[i] = word
+	}
+	payload := utils.ConcatBytes(words...)
+	if e.DataFormat == DataFormatBytes {
+		return utils.ConcatBytes(bytesPayloadOffset(e), utils.EVMEncodeBytes(payload)), nil
+	}
+	return payload, nil
+}
+
+// bytesPayloadOffset is the offset word of a bytes argument following the
+// task's data prefix.
+func bytesPayloadOffset(e *EthTx) []byte {
+	if len(e.DataPrefix) > 0 {
+		return utils.EVMWordUint64(utils.EVMWordByteLen * 2)
+	}
+	return utils.EVMWordUint64(utils.EVMWordByteLen)
+}
+
 func createTxRunResult(
 	address common.Address,
 	gasPrice *utils.Big,
@@ -0,0 +1,214 @@
+package adapters
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// EthTxCommitReveal submits the result to a contract in two transactions,
+// for contracts which keep answers secret until every oracle has committed
+// to one. The first calls CommitFunctionSelector with the keccak256 hash of
+// the result, a bytes32, and a random salt. Once it is confirmed and
+// RevealDelay has passed, the second calls RevealFunctionSelector with the
+// result and the salt, from the same address.
+//
+// Both transactions are sent by the bulletproof tx manager, and the state of
+// the submission is kept with them, so that a restarted node carries on
+// where it left off.
+type EthTxCommitReveal struct {
+	ToAddress              common.Address          `json:"address"`
+	FromAddresses          []common.Address        `json:"fromAddresses,omitempty"`
+	CommitFunctionSelector models.FunctionSelector `json:"commitFunctionSelector"`
+	RevealFunctionSelector models.FunctionSelector `json:"revealFunctionSelector"`
+	DataPrefix             hexutil.Bytes           `json:"dataPrefix"`
+	RevealDelay            models.Duration         `json:"revealDelay"`
+	GasLimit               uint64                  `json:"gasLimit,omitempty"`
+
+	MinRequiredOutgoingConfirmations uint64 `json:"minRequiredOutgoingConfirmations,omitempty"`
+}
+
+// TaskType returns the type of Adapter.
+func (e *EthTxCommitReveal) TaskType() models.TaskType {
+	return TaskTypeEthTxCommitReveal
+}
+
+// Perform commits to the result on its first invocation, and on each later
+// one advances the submission when its commit or reveal transaction is
+// confirmed, completing with the hash of the reveal transaction.
+func (e *EthTxCommitReveal) Perform(input models.RunInput, store *strpkg.Store) models.RunOutput {
+	if !store.Config.EnableBulletproofTxManager() {
+		return models.NewRunOutputError(errors.New("EthTxCommitReveal requires ENABLE_BULLETPROOF_TX_MANAGER"))
+	}
+
+	cr, err := store.FindEthCommitReveal(input.TaskRunID().UUID())
+	if err != nil {
+		return models.NewRunOutputError(errors.Wrap(err, "EthTxCommitReveal failed to load its submission"))
+	}
+	switch {
+	case cr == nil:
+		return e.commit(input, store)
+	case cr.RevealEthTx == nil:
+		return e.reveal(*cr, input, store)
+	default:
+		return e.checkRevealed(*cr, input, store)
+	}
+}
+
+func (e *EthTxCommitReveal) commit(input models.RunInput, store *strpkg.Store) models.RunOutput {
+	answer, err := commitRevealAnswer(input.Result())
+	if err != nil {
+		return models.NewRunOutputError(errors.Wrap(err, "EthTxCommitReveal cannot commit to the result"))
+	}
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return models.NewRunOutputError(errors.Wrap(err, "EthTxCommitReveal failed to generate salt"))
+	}
+	commitment, err := utils.Keccak256(utils.ConcatBytes(answer, salt))
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	fromAddress, err := (&EthTx{FromAddresses: e.FromAddresses}).pickFromAddress(input, store)
+	if err != nil {
+		return models.NewRunOutputError(errors.Wrap(err, "EthTxCommitReveal failed to pickFromAddress"))
+	}
+	// The reveal is counted up front too, so that a commit is not sent which
+	// the namespace's quota would leave unrevealed
+	gasLimit := e.gasLimit(store)
+	if err := store.CheckGasQuota(fromAddress, 2*gasLimit, time.Now()); err != nil {
+		return models.NewRunOutputError(errors.Wrap(err, "EthTxCommitReveal failed"))
+	}
+
+	payload := utils.ConcatBytes(e.CommitFunctionSelector.Bytes(), e.DataPrefix, commitment)
+	if err := store.InsertEthCommit(input.TaskRunID().UUID(), answer, salt, fromAddress, e.ToAddress, payload, gasLimit); err != nil {
+		logger.Error(err)
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputPendingOutgoingConfirmationsWithData(input.Data())
+}
+
+// commitRevealAnswer returns the result as the bytes32 committed to and
+// revealed. A hex string is taken as the bytes of the answer, and must be at
+// most 32 bytes long; a number, or a decimal string, is encoded as an int256.
+// Anything else is rejected rather than committing to an answer which is not
+// the result.
+func commitRevealAnswer(result gjson.Result) ([]byte, error) {
+	if result.Type == gjson.String && utils.HasHexPrefix(strings.TrimSpace(result.Str)) {
+		digits := utils.RemoveHexPrefix(strings.TrimSpace(result.Str))
+		if len(digits)%2 == 1 {
+			digits = "0" + digits
+		}
+		b, err := hex.DecodeString(digits)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid hex answer %q", result.Str)
+		} else if len(b) > utils.EVMWordByteLen {
+			return nil, fmt.Errorf("hex answer is %d bytes long, longer than a bytes32", len(b))
+		}
+		return common.LeftPadBytes(b, utils.EVMWordByteLen), nil
+	}
+	i, err := models.IntValue(result)
+	if err != nil {
+		return nil, err
+	}
+	return utils.EVMWordSignedBigInt(i)
+}
+
+// reveal schedules the reveal once the commit is confirmed, and sends it
+// once it is due.
+func (e *EthTxCommitReveal) reveal(cr models.EthCommitReveal, input models.RunInput, store *strpkg.Store) models.RunOutput {
+	if cr.RevealAt == nil {
+		hash, output := e.confirmedTxHash(cr.CommitEthTx, input, store)
+		if hash == nil {
+			return output
+		}
+		revealAt := store.Clock.Now().Add(e.RevealDelay.Duration())
+		if err := store.ScheduleEthReveal(cr.TaskRunID, revealAt); err != nil {
+			return models.NewRunOutputError(errors.Wrap(err, "EthTxCommitReveal failed to schedule reveal"))
+		}
+		cr.RevealAt = &revealAt
+	}
+	if store.Clock.Now().Before(*cr.RevealAt) {
+		return models.NewRunOutputPendingOutgoingConfirmationsWithData(input.Data())
+	}
+
+	gasLimit := e.gasLimit(store)
+	if err := store.CheckGasQuota(cr.CommitEthTx.FromAddress, gasLimit, time.Now()); err != nil {
+		return models.NewRunOutputError(errors.Wrap(err, "EthTxCommitReveal failed to reveal"))
+	}
+	payload := utils.ConcatBytes(e.RevealFunctionSelector.Bytes(), e.DataPrefix, cr.Answer, cr.Salt)
+	err := store.InsertEthReveal(cr.TaskRunID, cr.CommitEthTx.FromAddress, e.ToAddress, payload, gasLimit)
+	if err != nil {
+		logger.Error(err)
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputPendingOutgoingConfirmationsWithData(input.Data())
+}
+
+func (e *EthTxCommitReveal) checkRevealed(cr models.EthCommitReveal, input models.RunInput, store *strpkg.Store) models.RunOutput {
+	revealHash, output := e.confirmedTxHash(*cr.RevealEthTx, input, store)
+	if revealHash == nil {
+		return output
+	}
+	commitHash, output := e.confirmedTxHash(cr.CommitEthTx, input, store)
+	if commitHash == nil {
+		return output
+	}
+
+	data, err := input.Data().MultiAdd(models.KV{
+		"result":       revealHash.Hex(),
+		"commitTxHash": commitHash.Hex(),
+		"revealTxHash": revealHash.Hex(),
+		// HACK: latestOutgoingTxHash is used for backwards compatibility with the stats pusher
+		"latestOutgoingTxHash": revealHash.Hex(),
+	})
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputComplete(data)
+}
+
+// confirmedTxHash returns the hash of the transaction once it has enough
+// confirmations, and otherwise the output of the run waiting for it.
+func (e *EthTxCommitReveal) confirmedTxHash(etx models.EthTx, input models.RunInput, store *strpkg.Store) (*common.Hash, models.RunOutput) {
+	switch etx.State {
+	case models.EthTxFatalError:
+		return nil, models.NewRunOutputError(etx.GetError())
+	case models.EthTxConfirmed:
+	default:
+		return nil, models.NewRunOutputPendingOutgoingConfirmationsWithData(input.Data())
+	}
+
+	minConfs := e.MinRequiredOutgoingConfirmations
+	if minConfs == 0 {
+		minConfs = store.Config.MinRequiredOutgoingConfirmations()
+	}
+	hash, err := getConfirmedTxHash(etx.ID, store.DB, minConfs)
+	if err != nil {
+		logger.Error(err)
+		return nil, models.NewRunOutputError(err)
+	}
+	if hash == nil {
+		return nil, models.NewRunOutputPendingOutgoingConfirmationsWithData(input.Data())
+	}
+	return hash, models.RunOutput{}
+}
+
+func (e *EthTxCommitReveal) gasLimit(store *strpkg.Store) uint64 {
+	if e.GasLimit == 0 {
+		return store.Config.EthGasLimitDefault()
+	}
+	return e.GasLimit
+}
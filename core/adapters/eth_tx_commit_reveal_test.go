@@ -0,0 +1,223 @@
+package adapters_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthTxCommitReveal_Perform(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Config.Set("ENABLE_BULLETPROOF_TX_MANAGER", true)
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	now := time.Now()
+	clock := new(mocks.AfterNower)
+	clock.On("Now").Return(now).Times(2)
+	clock.On("Now").Return(now.Add(time.Minute))
+	store.Clock = clock
+
+	commitSelector := models.HexToFunctionSelector("0xf14fcbc8")
+	revealSelector := models.HexToFunctionSelector("0x2c1f9d3a")
+	dataPrefix := hexutil.MustDecode("0x88888888")
+	adapter := adapters.EthTxCommitReveal{
+		ToAddress:                        cltest.NewAddress(),
+		CommitFunctionSelector:           commitSelector,
+		RevealFunctionSelector:           revealSelector,
+		DataPrefix:                       dataPrefix,
+		RevealDelay:                      models.MustMakeDuration(time.Minute),
+		GasLimit:                         42,
+		MinRequiredOutgoingConfirmations: 1,
+	}
+
+	answer := "0x0000000000000000000000000000000000000000000000000000000000000539"
+	taskRunID := cltest.MustInsertTaskRun(t, store)
+	input := *models.NewRunInputWithResult(models.NewID(), taskRunID, answer, models.RunStatusUnstarted)
+	require.NoError(t, store.IdempotentInsertHead(models.Head{Hash: cltest.NewHash(), Number: 10}))
+
+	confirm := func(column string) models.EthTx {
+		etx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, cltest.NewRandomInt64(), 1)
+		cltest.MustInsertEthReceipt(t, store, 1, cltest.NewHash(), etx.EthTxAttempts[0].Hash)
+		require.NoError(t, store.DB.Exec(`UPDATE eth_commit_reveals SET `+column+` = ? WHERE task_run_id = ?`, etx.ID, taskRunID.UUID()).Error)
+		return etx
+	}
+
+	// Commits to the answer
+	output := adapter.Perform(input, store)
+	require.NoError(t, output.Error())
+	assert.Equal(t, models.RunStatusPendingOutgoingConfirmations, output.Status())
+
+	cr, err := store.FindEthCommitReveal(taskRunID.UUID())
+	require.NoError(t, err)
+	require.NotNil(t, cr)
+	assert.Equal(t, hexutil.MustDecode(answer), cr.Answer)
+	require.Len(t, cr.Salt, 32)
+	commitment, err := utils.Keccak256(utils.ConcatBytes(cr.Answer, cr.Salt))
+	require.NoError(t, err)
+	assert.Equal(t, utils.ConcatBytes(commitSelector.Bytes(), dataPrefix, commitment), cr.CommitEthTx.EncodedPayload)
+	assert.Equal(t, uint64(42), cr.CommitEthTx.GasLimit)
+	assert.Equal(t, models.EthTxUnstarted, cr.CommitEthTx.State)
+
+	// Waits for the commit to be confirmed
+	output = adapter.Perform(input, store)
+	require.NoError(t, output.Error())
+	assert.Equal(t, models.RunStatusPendingOutgoingConfirmations, output.Status())
+
+	// Schedules the reveal once it is
+	commit := confirm("commit_eth_tx_id")
+	output = adapter.Perform(input, store)
+	require.NoError(t, output.Error())
+	assert.Equal(t, models.RunStatusPendingOutgoingConfirmations, output.Status())
+	cr, err = store.FindEthCommitReveal(taskRunID.UUID())
+	require.NoError(t, err)
+	require.NotNil(t, cr.RevealAt)
+	assert.WithinDuration(t, now.Add(time.Minute), *cr.RevealAt, time.Millisecond)
+	assert.Nil(t, cr.RevealEthTx)
+
+	// Reveals once the delay has passed, from the committing address
+	output = adapter.Perform(input, store)
+	require.NoError(t, output.Error())
+	assert.Equal(t, models.RunStatusPendingOutgoingConfirmations, output.Status())
+	cr, err = store.FindEthCommitReveal(taskRunID.UUID())
+	require.NoError(t, err)
+	require.NotNil(t, cr.RevealEthTx)
+	assert.Equal(t, utils.ConcatBytes(revealSelector.Bytes(), dataPrefix, cr.Answer, cr.Salt), cr.RevealEthTx.EncodedPayload)
+	assert.Equal(t, commit.FromAddress, cr.RevealEthTx.FromAddress)
+
+	// Completes once the reveal is confirmed
+	reveal := confirm("reveal_eth_tx_id")
+	output = adapter.Perform(input, store)
+	require.NoError(t, output.Error())
+	assert.Equal(t, models.RunStatusCompleted, output.Status())
+	assert.Equal(t, reveal.EthTxAttempts[0].Hash.Hex(), output.Result().String())
+	assert.Equal(t, commit.EthTxAttempts[0].Hash.Hex(), output.Get("commitTxHash").String())
+}
+
+func TestEthTxCommitReveal_Perform_Answers(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Config.Set("ENABLE_BULLETPROOF_TX_MANAGER", true)
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	adapter := adapters.EthTxCommitReveal{
+		ToAddress:                        cltest.NewAddress(),
+		GasLimit:                         42,
+		MinRequiredOutgoingConfirmations: 1,
+	}
+	require.NoError(t, store.IdempotentInsertHead(models.Head{Hash: cltest.NewHash(), Number: 10}))
+
+	tests := []struct {
+		name   string
+		result interface{}
+		want   string
+	}{
+		{"hex", "0x539", "0x0000000000000000000000000000000000000000000000000000000000000539"},
+		{"decimal string", "1337", "0x0000000000000000000000000000000000000000000000000000000000000539"},
+		{"number", 1337, "0x0000000000000000000000000000000000000000000000000000000000000539"},
+		{"negative", -1, "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{"hex longer than bytes32", "0x01" + strings.Repeat("00", 32), ""},
+		{"int256 overflow", "57896044618658097711785492504343953926634992332820282019728792003956564819968", ""},
+		{"fraction", "13.37", ""},
+		{"text", "hello", ""},
+		{"invalid hex", "0xzz", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			taskRunID := cltest.MustInsertTaskRun(t, store)
+			input := *models.NewRunInputWithResult(models.NewID(), taskRunID, test.result, models.RunStatusUnstarted)
+			output := adapter.Perform(input, store)
+			cr, err := store.FindEthCommitReveal(taskRunID.UUID())
+			require.NoError(t, err)
+			if test.want == "" {
+				assert.Error(t, output.Error())
+				assert.Nil(t, cr)
+				return
+			}
+			require.NoError(t, output.Error())
+			require.NotNil(t, cr)
+			assert.Equal(t, hexutil.MustDecode(test.want), cr.Answer)
+		})
+	}
+}
+
+func TestEthTxCommitReveal_Perform_GasQuota(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Config.Set("ENABLE_BULLETPROOF_TX_MANAGER", true)
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	adapter := adapters.EthTxCommitReveal{
+		ToAddress:                        cltest.NewAddress(),
+		GasLimit:                         42,
+		MinRequiredOutgoingConfirmations: 1,
+	}
+	taskRunID := cltest.MustInsertTaskRun(t, store)
+	input := *models.NewRunInputWithResult(models.NewID(), taskRunID, "0x1", models.RunStatusUnstarted)
+	require.NoError(t, store.IdempotentInsertHead(models.Head{Hash: cltest.NewHash(), Number: 10}))
+	setQuota := func(gas int64) {
+		require.NoError(t, store.UpdateNamespaceQuotas(models.DefaultNamespace, models.NamespaceQuotas{MaxGasPerDay: clnull.Int64From(gas)}))
+	}
+
+	// Does not commit unless there is gas left for the reveal too
+	setQuota(83)
+	output := adapter.Perform(input, store)
+	assert.True(t, orm.IsQuotaExceeded(output.Error()))
+	setQuota(84)
+	output = adapter.Perform(input, store)
+	require.NoError(t, output.Error())
+
+	etx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, cltest.NewRandomInt64(), 1)
+	cltest.MustInsertEthReceipt(t, store, 1, cltest.NewHash(), etx.EthTxAttempts[0].Hash)
+	require.NoError(t, store.DB.Exec(`UPDATE eth_commit_reveals SET commit_eth_tx_id = ? WHERE task_run_id = ?`, etx.ID, taskRunID.UUID()).Error)
+
+	// Does not reveal once the gas has been used up since
+	var used struct{ Gas int64 }
+	require.NoError(t, store.DB.Raw(`SELECT SUM(gas_limit) AS gas FROM eth_txes`).Scan(&used).Error)
+	setQuota(used.Gas + 41)
+	output = adapter.Perform(input, store)
+	assert.True(t, orm.IsQuotaExceeded(output.Error()))
+	cr, err := store.FindEthCommitReveal(taskRunID.UUID())
+	require.NoError(t, err)
+	assert.Nil(t, cr.RevealEthTx)
+
+	setQuota(used.Gas + 42)
+	output = adapter.Perform(input, store)
+	require.NoError(t, output.Error())
+	cr, err = store.FindEthCommitReveal(taskRunID.UUID())
+	require.NoError(t, err)
+	assert.NotNil(t, cr.RevealEthTx)
+}
+
+func TestEthTxCommitReveal_Perform_RequiresBulletproofTxManager(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	adapter := adapters.EthTxCommitReveal{ToAddress: cltest.NewAddress()}
+	input := *models.NewRunInputWithResult(models.NewID(), *models.NewID(), "0x1", models.RunStatusUnstarted)
+	output := adapter.Perform(input, store)
+	assert.EqualError(t, output.Error(), "EthTxCommitReveal requires ENABLE_BULLETPROOF_TX_MANAGER")
+}
@@ -138,6 +138,78 @@ func TestEthTxAdapter_Perform_BytesFormatWithDataPrefix(t *testing.T) {
 	txManager.AssertExpectations(t)
 }
 
+func TestEthTxAdapter_Perform_ResultKeys(t *testing.T) {
+	t.Parallel()
+
+	words := "000000000000000000000000000000000000000000000000000000000000007b" + // price: 123
+		"4554482d55534400000000000000000000000000000000000000000000000000" + // pair: "ETH-USD"
+		"0000000000000000000000000000000000000000000000000000000000001234" // hash: 0x1234
+
+	tests := []struct {
+		name    string
+		format  string
+		payload string
+	}{
+		{"bytes32 words", "", words},
+		{"bytes", "bytes",
+			"0000000000000000000000000000000000000000000000000000000000000020" + // offset
+				"0000000000000000000000000000000000000000000000000000000000000060" + // length in bytes
+				words},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			store, cleanup := cltest.NewStore(t)
+			defer cleanup()
+
+			txManager := new(mocks.TxManager)
+			tx := &models.Tx{Attempts: []*models.TxAttempt{&models.TxAttempt{}}}
+			txManager.On("Connected").Maybe().Return(true)
+			txManager.On("CreateTxWithGas", mock.Anything, mock.Anything,
+				hexutil.MustDecode("0xdeadcafe"+test.payload),
+				mock.Anything, mock.Anything).Return(tx, nil)
+			txManager.On("CheckAttempt", mock.Anything, mock.Anything).Return(&types.Receipt{}, strpkg.Unconfirmed, nil)
+			store.TxManager = txManager
+
+			adapter := adapters.EthTx{
+				FunctionSelector: models.HexToFunctionSelector("0xdeadcafe"),
+				DataFormat:       test.format,
+				ResultKeys:       []string{"price", "pair", "hash"},
+			}
+			input := cltest.NewRunInput(cltest.JSONFromString(t, `{"price": 123, "pair": "ETH-USD", "hash": "0x1234"}`))
+			result := adapter.Perform(input, store)
+
+			assert.NoError(t, result.Error())
+			assert.Equal(t, models.RunStatusPendingOutgoingConfirmations, result.Status())
+			txManager.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEthTxAdapter_Perform_ResultKeysErrors(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	txManager := new(mocks.TxManager)
+	txManager.On("Connected").Maybe().Return(true)
+	store.TxManager = txManager
+
+	input := cltest.NewRunInput(cltest.JSONFromString(t, `{"price": 123, "long": "a string far longer than thirty two bytes"}`))
+
+	adapter := adapters.EthTx{ResultKeys: []string{"price", "missing"}}
+	assert.Error(t, adapter.Perform(input, store).Error())
+
+	adapter = adapters.EthTx{ResultKeys: []string{"long"}}
+	assert.Error(t, adapter.Perform(input, store).Error())
+
+	adapter = adapters.EthTx{ResultKeys: []string{"price"}, DataFormat: "uint256"}
+	assert.Error(t, adapter.Validate())
+	assert.Error(t, adapter.Perform(input, store).Error())
+}
+
 func TestEthTxAdapter_Perform_Preformatted(t *testing.T) {
 	t.Parallel()
 
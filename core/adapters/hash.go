@@ -0,0 +1,58 @@
+package adapters
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Hash hashes the input's result with Function, which is "keccak256", the
+// default, or "sha256".
+type Hash struct {
+	Function string `json:"function"`
+}
+
+// TaskType returns the type of Adapter.
+func (h *Hash) TaskType() models.TaskType {
+	return TaskTypeHash
+}
+
+// Perform returns the hex encoded hash of the input's result. A 0x-prefixed
+// hex result is hashed as the bytes it represents, any other result as its
+// string value.
+//
+// For example, if the input value is "hello" and the adapter's "function" is
+// "sha256", the result's value will be
+// "0x2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824".
+func (h *Hash) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	hash, err := hashFunction(h.Function)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	payload, err := signingPayload(input.Result().String())
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputCompleteWithResult(hexutil.Encode(hash(payload)))
+}
+
+func hashFunction(name string) (func([]byte) []byte, error) {
+	switch name {
+	case "", "keccak256":
+		return func(b []byte) []byte {
+			hash, _ := utils.Keccak256(b)
+			return hash
+		}, nil
+	case "sha256":
+		return func(b []byte) []byte {
+			hash := sha256.Sum256(b)
+			return hash[:]
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown hash %q, must be keccak256 or sha256", name)
+}
@@ -0,0 +1,42 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHash_Perform(t *testing.T) {
+	tests := []struct {
+		name     string
+		function string
+		json     string
+		want     string
+		errored  bool
+	}{
+		{"keccak256 string", "", `{"result":"hello"}`, "0x1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8", false},
+		{"keccak256 hex", "keccak256", `{"result":"0x68656c6c6f"}`, "0x1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8", false},
+		{"sha256", "sha256", `{"result":"hello"}`, "0x2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", false},
+		{"invalid hex", "", `{"result":"0xzz"}`, "", true},
+		{"unknown function", "md5", `{"result":"hello"}`, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.Hash{Function: test.function}
+			result := adapter.Perform(input, nil)
+
+			if test.errored {
+				assert.Error(t, result.Error())
+				return
+			}
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().String())
+		})
+	}
+}
@@ -23,13 +23,17 @@ import (
 )
 
 // HTTPGet requires a URL which is used for a GET request when the adapter is called.
+// FailoverURLs are tried, with the same path, query parameters and headers,
+// when the URL fails to respond, in the order given by URLSelection.
 type HTTPGet struct {
-	URL                            models.WebURL   `json:"url"`
-	GET                            models.WebURL   `json:"get"`
-	Headers                        http.Header     `json:"headers"`
-	QueryParams                    QueryParameters `json:"queryParams"`
-	ExtendedPath                   ExtendedPath    `json:"extPath"`
-	AllowUnrestrictedNetworkAccess bool            `json:"-"`
+	URL                            models.WebURL            `json:"url"`
+	GET                            models.WebURL            `json:"get"`
+	FailoverURLs                   models.WebURLs           `json:"failoverURLs,omitempty"`
+	URLSelection                   models.EndpointSelection `json:"urlSelection,omitempty"`
+	Headers                        http.Header              `json:"headers"`
+	QueryParams                    QueryParameters          `json:"queryParams"`
+	ExtendedPath                   ExtendedPath             `json:"extPath"`
+	AllowUnrestrictedNetworkAccess bool                     `json:"-"`
 }
 
 // HTTPRequestConfig holds the configurable settings for an http request
@@ -37,7 +41,36 @@ type HTTPRequestConfig struct {
 	timeout                        time.Duration
 	maxAttempts                    uint
 	sizeLimit                      int64
+	jsonDepthLimit                 int
 	allowUnrestrictedNetworkAccess bool
+	hostLimits                     utils.HostLimits
+	providerQuotas                 utils.ProviderQuotas
+	quotaAlertPercent              uint64
+	adaptiveTimeoutMargin          time.Duration
+	// maxRetryAfter caps how long a bridge is skipped for when its adapter
+	// asks not to be called again for a while
+	maxRetryAfter time.Duration
+	// retryable decides whether a response should be retried, in place of
+	// retrying 5xx responses
+	retryable func(statusCode int, responseBody []byte) bool
+	// fixtures, if set, record the responses or replay them
+	fixtures *HTTPFixtures
+	// ctx is the context of the run the request is sent for, which cancels
+	// the request if the run's deadline passes
+	ctx context.Context
+}
+
+// forRun binds the requests to the run with the given ID.
+func (c *HTTPRequestConfig) forRun(runID *models.ID) {
+	c.fixtures = fixturesFor(runID)
+	c.ctx = runContext(runID)
+}
+
+func (c HTTPRequestConfig) isRetryable(statusCode int, responseBody []byte) bool {
+	if c.retryable != nil {
+		return c.retryable(statusCode, responseBody)
+	}
+	return 500 <= statusCode && statusCode < 600
 }
 
 // TaskType returns the type of Adapter.
@@ -48,13 +81,13 @@ func (hga *HTTPGet) TaskType() models.TaskType {
 // Perform ensures that the adapter's URL responds to a GET request without
 // errors and returns the response body as the "value" field of the result.
 func (hga *HTTPGet) Perform(input models.RunInput, store *store.Store) models.RunOutput {
-	request, err := hga.GetRequest()
+	requests, err := hga.GetRequests()
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
 	httpConfig := defaultHTTPConfig(store)
 	httpConfig.allowUnrestrictedNetworkAccess = hga.AllowUnrestrictedNetworkAccess
-	return sendRequest(input, request, httpConfig)
+	return sendRequest(input, endpoints.order(requests, hga.URLSelection), httpConfig)
 }
 
 // GetURL retrieves the GET field if set otherwise returns the URL field
@@ -67,7 +100,17 @@ func (hga *HTTPGet) GetURL() string {
 
 // GetRequest returns the HTTP request including query parameters and headers
 func (hga *HTTPGet) GetRequest() (*http.Request, error) {
-	request, err := http.NewRequest("GET", hga.GetURL(), nil)
+	return hga.newRequest(hga.GetURL())
+}
+
+// GetRequests returns the request to the adapter's URL followed by those to
+// its failover URLs.
+func (hga *HTTPGet) GetRequests() ([]*http.Request, error) {
+	return buildRequests(hga.GetURL(), hga.FailoverURLs, hga.newRequest)
+}
+
+func (hga *HTTPGet) newRequest(rawURL string) (*http.Request, error) {
+	request, err := http.NewRequest("GET", rawURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -78,14 +121,17 @@ func (hga *HTTPGet) GetRequest() (*http.Request, error) {
 }
 
 // HTTPPost requires a URL which is used for a POST request when the adapter is called.
+// FailoverURLs and URLSelection behave as they do for HTTPGet.
 type HTTPPost struct {
-	URL                            models.WebURL   `json:"url"`
-	POST                           models.WebURL   `json:"post"`
-	Headers                        http.Header     `json:"headers"`
-	QueryParams                    QueryParameters `json:"queryParams"`
-	Body                           *string         `json:"body,omitempty"`
-	ExtendedPath                   ExtendedPath    `json:"extPath"`
-	AllowUnrestrictedNetworkAccess bool            `json:"-"`
+	URL                            models.WebURL            `json:"url"`
+	POST                           models.WebURL            `json:"post"`
+	FailoverURLs                   models.WebURLs           `json:"failoverURLs,omitempty"`
+	URLSelection                   models.EndpointSelection `json:"urlSelection,omitempty"`
+	Headers                        http.Header              `json:"headers"`
+	QueryParams                    QueryParameters          `json:"queryParams"`
+	Body                           *string                  `json:"body,omitempty"`
+	ExtendedPath                   ExtendedPath             `json:"extPath"`
+	AllowUnrestrictedNetworkAccess bool                     `json:"-"`
 }
 
 // TaskType returns the type of Adapter.
@@ -96,13 +142,13 @@ func (hpa *HTTPPost) TaskType() models.TaskType {
 // Perform ensures that the adapter's URL responds to a POST request without
 // errors and returns the response body as the "value" field of the result.
 func (hpa *HTTPPost) Perform(input models.RunInput, store *store.Store) models.RunOutput {
-	request, err := hpa.GetRequest(input.Data().String())
+	requests, err := hpa.GetRequests(input.Data().String())
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
 	httpConfig := defaultHTTPConfig(store)
 	httpConfig.allowUnrestrictedNetworkAccess = hpa.AllowUnrestrictedNetworkAccess
-	return sendRequest(input, request, httpConfig)
+	return sendRequest(input, endpoints.order(requests, hpa.URLSelection), httpConfig)
 }
 
 // GetURL retrieves the POST field if set otherwise returns the URL field
@@ -118,19 +164,45 @@ func (hpa *HTTPPost) GetURL() string {
 //
 // HTTPPost's Body parameter overrides the given argument if present.
 func (hpa *HTTPPost) GetRequest(body string) (*http.Request, error) {
+	return hpa.newRequest(body)(hpa.GetURL())
+}
+
+// GetRequests takes the request body and returns the request to the
+// adapter's URL followed by those to its failover URLs.
+func (hpa *HTTPPost) GetRequests(body string) ([]*http.Request, error) {
+	return buildRequests(hpa.GetURL(), hpa.FailoverURLs, hpa.newRequest(body))
+}
+
+func (hpa *HTTPPost) newRequest(body string) func(string) (*http.Request, error) {
 	if hpa.Body != nil {
 		body = *hpa.Body
 	}
-	reqBody := bytes.NewBufferString(body)
+	return func(rawURL string) (*http.Request, error) {
+		request, err := http.NewRequest("POST", rawURL, bytes.NewBufferString(body))
+		if err != nil {
+			return nil, err
+		}
+		appendExtendedPath(request, hpa.ExtendedPath)
+		appendQueryParams(request, hpa.QueryParams)
+		setHeaders(request, hpa.Headers, "application/json")
+		return request, nil
+	}
+}
 
-	request, err := http.NewRequest("POST", hpa.GetURL(), reqBody)
-	if err != nil {
-		return nil, err
+func buildRequests(primary string, failovers models.WebURLs, newRequest func(string) (*http.Request, error)) ([]*http.Request, error) {
+	urls := []string{primary}
+	for _, failover := range failovers {
+		urls = append(urls, failover.String())
 	}
-	appendExtendedPath(request, hpa.ExtendedPath)
-	appendQueryParams(request, hpa.QueryParams)
-	setHeaders(request, hpa.Headers, "application/json")
-	return request, nil
+	requests := make([]*http.Request, len(urls))
+	for i, rawURL := range urls {
+		request, err := newRequest(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		requests[i] = request
+	}
+	return requests, nil
 }
 
 func appendExtendedPath(request *http.Request, extPath ExtendedPath) {
@@ -154,23 +226,24 @@ func keyExists(key string, query url.Values) bool {
 
 func setHeaders(request *http.Request, headers http.Header, contentType string) {
 	if headers != nil {
-		request.Header = headers
+		request.Header = headers.Clone()
 	}
 	if contentType != "" {
 		request.Header.Set("Content-Type", contentType)
 	}
 }
 
-func sendRequest(input models.RunInput, request *http.Request, config HTTPRequestConfig) models.RunOutput {
+func sendRequest(input models.RunInput, requests []*http.Request, config HTTPRequestConfig) models.RunOutput {
 	tr := &http.Transport{
 		DisableCompression: true,
 	}
 	if !config.allowUnrestrictedNetworkAccess {
 		tr.DialContext = restrictedDialContext
 	}
-	client := &http.Client{Transport: tr}
+	config.forRun(input.JobRunID())
+	client := &http.Client{Transport: externalTransport(config, tr)}
 
-	bytes, statusCode, err := withRetry(client, request, config)
+	bytes, statusCode, err := withFailover(client, requests, config)
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
@@ -186,12 +259,24 @@ func sendRequest(input models.RunInput, request *http.Request, config HTTPReques
 	return models.NewRunOutputCompleteWithResult(responseBody)
 }
 
-// withRetry executes the http request in a retry. Timeout is controlled with a context
-// Retry occurs if the request timeout, or there is any kind of connection or transport-layer error
-// Retry also occurs on remote server 5xx errors
-func withRetry(
+// withFailover sends the requests, which are to one or more endpoints serving
+// the same data, until one succeeds or the attempts run out. Each attempt
+// times out after config.timeout, and retrying stops once the run's context,
+// config.ctx, is done.
+//
+// An attempt fails on a timeout, a connection or transport-layer error, or a
+// response which config deems retryable, by default one with a 5xx status.
+// A response too large for config.sizeLimit is not retried, as the responses
+// to later attempts would most likely be too large as well.
+//
+// Each attempt goes to the next endpoint in turn, so a failing endpoint is
+// followed immediately by the next one, and the backoff only applies once
+// every endpoint has failed. Every endpoint is tried at least once, even if
+// there are more of them than config.maxAttempts. The latency and outcome of
+// each attempt are recorded against its endpoint's host.
+func withFailover(
 	client *http.Client,
-	originalRequest *http.Request,
+	requests []*http.Request,
 	config HTTPRequestConfig,
 ) (responseBody []byte, statusCode int, err error) {
 	bb := &backoff.Backoff{
@@ -199,12 +284,19 @@ func withRetry(
 		Max:    20 * time.Minute, // We stop retrying on the number of attempts!
 		Jitter: true,
 	}
-	for {
-		responseBody, statusCode, err = makeHTTPCall(client, originalRequest, config)
+	maxAttempts := config.maxAttempts
+	if uint(len(requests)) > maxAttempts {
+		maxAttempts = uint(len(requests))
+	}
+	for attempt := uint(0); ; attempt++ {
+		request := requests[attempt%uint(len(requests))]
+		start := time.Now()
+		responseBody, statusCode, err = makeHTTPCall(client, request, config)
+		endpoints.record(request.URL.Host, time.Since(start), err)
 		if err == nil {
 			return responseBody, statusCode, nil
 		}
-		if uint(bb.Attempt())+1 >= config.maxAttempts { // Stop retrying.
+		if attempt+1 >= maxAttempts || config.ctx.Err() != nil { // Stop retrying.
 			return responseBody, statusCode, err
 		}
 		switch err.(type) {
@@ -214,9 +306,11 @@ func withRetry(
 		case *HTTPResponseTooLargeError:
 			return responseBody, statusCode, err
 		}
-		// Sleep and retry.
-		time.Sleep(bb.Duration())
-		logger.Debugw("http adapter error, will retry", "error", err.Error(), "attempt", bb.Attempt(), "timeout", config.timeout)
+		if (attempt+1)%uint(len(requests)) == 0 {
+			// Sleep and retry.
+			time.Sleep(bb.Duration())
+		}
+		logger.Debugw("http adapter error, will retry", "error", err.Error(), "url", request.URL.String(), "attempt", attempt+1, "timeout", config.timeout)
 	}
 }
 
@@ -225,9 +319,17 @@ func makeHTTPCall(
 	originalRequest *http.Request,
 	config HTTPRequestConfig,
 ) (responseBody []byte, statusCode int, err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.timeout)
+	ctx, cancel := context.WithTimeout(config.ctx, config.timeout)
 	defer cancel()
 	requestWithTimeout := originalRequest.Clone(ctx)
+	if originalRequest.GetBody != nil {
+		// The body of an earlier attempt has already been read
+		body, e := originalRequest.GetBody()
+		if e != nil {
+			return nil, 0, e
+		}
+		requestWithTimeout.Body = body
+	}
 
 	start := time.Now()
 
@@ -253,7 +355,7 @@ func makeHTTPCall(
 	responseBody = bytes
 
 	// Retry on 5xx since this might give a different result
-	if 500 <= r.StatusCode && r.StatusCode < 600 {
+	if config.isRetryable(r.StatusCode, responseBody) {
 		return responseBody, statusCode, &RemoteServerError{responseBody, statusCode}
 	}
 
@@ -407,9 +509,31 @@ func (ep *ExtendedPath) UnmarshalJSON(input []byte) error {
 
 func defaultHTTPConfig(store *store.Store) HTTPRequestConfig {
 	return HTTPRequestConfig{
-		store.Config.DefaultHTTPTimeout().Duration(),
-		store.Config.DefaultMaxHTTPAttempts(),
-		store.Config.DefaultHTTPLimit(),
-		false,
+		timeout:               store.Config.DefaultHTTPTimeout().Duration(),
+		maxAttempts:           store.Config.DefaultMaxHTTPAttempts(),
+		sizeLimit:             store.Config.DefaultHTTPLimit(),
+		jsonDepthLimit:        store.Config.JSONDepthLimit(),
+		hostLimits:            store.Config.HTTPHostLimits(),
+		providerQuotas:        store.Config.ProviderQuotas(),
+		quotaAlertPercent:     store.Config.ProviderQuotaAlertPercent(),
+		adaptiveTimeoutMargin: store.Config.HTTPAdaptiveTimeoutMargin().Duration(),
+		ctx:                   context.Background(),
+	}
+}
+
+// externalTransport keeps the requests sent over transport within the host
+// limits, counts them towards the quotas of the providers they are sent to,
+// and records the latencies of their sources. Replayed requests are never
+// sent, so they are neither limited nor counted.
+func externalTransport(config HTTPRequestConfig, transport http.RoundTripper) http.RoundTripper {
+	if config.fixtures != nil && config.fixtures.replay {
+		return config.fixtures.transport(config.sizeLimit, nil)
+	}
+	timed := utils.NewLatencyTrackingTransport(utils.SourceRequestLatencies, config.adaptiveTimeoutMargin, transport)
+	counted := utils.NewProviderCountingTransport(utils.ProviderRequests, config.providerQuotas, config.quotaAlertPercent, timed)
+	limited := utils.NewHostLimitedTransport(config.hostLimits, counted)
+	if config.fixtures != nil {
+		return config.fixtures.transport(config.sizeLimit, limited)
 	}
+	return limited
 }
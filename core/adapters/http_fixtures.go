@@ -0,0 +1,159 @@
+package adapters
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// HTTPFixtures either records the responses to the requests sent by the HTTP
+// and bridge tasks of a run, or replays responses recorded earlier in place of
+// sending the requests, so that a run can be reproduced without depending on
+// the external APIs it calls.
+type HTTPFixtures struct {
+	replay bool
+
+	mu       sync.Mutex
+	fixtures []models.HTTPFixture
+	used     []bool
+}
+
+// NewHTTPRecorder returns HTTPFixtures which record every response received.
+func NewHTTPRecorder() *HTTPFixtures {
+	return &HTTPFixtures{}
+}
+
+// NewHTTPReplayer returns HTTPFixtures which replay the given responses.
+//
+// A request is answered with the first fixture not yet replayed which has the
+// same method and URL, so the same URL may be recorded more than once. The
+// request bodies are not compared, since those of bridges hold the run's ID.
+// A request without a matching fixture fails.
+func NewHTTPReplayer(fixtures []models.HTTPFixture) *HTTPFixtures {
+	return &HTTPFixtures{
+		replay:   true,
+		fixtures: fixtures,
+		used:     make([]bool, len(fixtures)),
+	}
+}
+
+// Recorded returns the fixtures recorded so far, in the order the responses
+// were received.
+func (f *HTTPFixtures) Recorded() []models.HTTPFixture {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]models.HTTPFixture{}, f.fixtures...)
+}
+
+func (f *HTTPFixtures) record(fixture models.HTTPFixture) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fixtures = append(f.fixtures, fixture)
+}
+
+func (f *HTTPFixtures) next(method, url string) (models.HTTPFixture, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, fixture := range f.fixtures {
+		if !f.used[i] && fixture.Method == method && fixture.URL == url {
+			f.used[i] = true
+			return fixture, true
+		}
+	}
+	return models.HTTPFixture{}, false
+}
+
+// runFixtures holds the fixtures used by each run which records or replays
+// its requests.
+var runFixtures = struct {
+	sync.Mutex
+	byRun map[string]*HTTPFixtures
+}{byRun: make(map[string]*HTTPFixtures)}
+
+// UseHTTPFixtures makes the HTTP and bridge tasks of the run with the given ID
+// record their responses to, or replay them from, fixtures until the returned
+// function is called.
+func UseHTTPFixtures(runID *models.ID, fixtures *HTTPFixtures) func() {
+	runFixtures.Lock()
+	defer runFixtures.Unlock()
+	runFixtures.byRun[runID.String()] = fixtures
+	return func() {
+		runFixtures.Lock()
+		defer runFixtures.Unlock()
+		delete(runFixtures.byRun, runID.String())
+	}
+}
+
+func fixturesFor(runID *models.ID) *HTTPFixtures {
+	runFixtures.Lock()
+	defer runFixtures.Unlock()
+	return runFixtures.byRun[runID.String()]
+}
+
+// transport returns a RoundTripper which records the responses received over
+// transport, or which replays the fixtures without using transport at all.
+// Recorded responses are read up to sizeLimit bytes, as the tasks read them.
+func (f *HTTPFixtures) transport(sizeLimit int64, transport http.RoundTripper) http.RoundTripper {
+	return &fixtureTransport{fixtures: f, sizeLimit: sizeLimit, transport: transport}
+}
+
+type fixtureTransport struct {
+	fixtures  *HTTPFixtures
+	sizeLimit int64
+	transport http.RoundTripper
+}
+
+func (t *fixtureTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	url := request.URL.String()
+	if t.fixtures.replay {
+		fixture, ok := t.fixtures.next(request.Method, url)
+		if !ok {
+			return nil, fmt.Errorf("no recorded response to %s %s", request.Method, url)
+		}
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", fixture.StatusCode, http.StatusText(fixture.StatusCode)),
+			StatusCode: fixture.StatusCode,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(fixture.ResponseBody)),
+			Request:    request,
+		}, nil
+	}
+
+	var requestBody []byte
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		if requestBody, err = ioutil.ReadAll(body); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := t.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := ioutil.ReadAll(newMaxBytesReader(response.Body, t.sizeLimit))
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewBuffer(responseBody))
+
+	t.fixtures.record(models.HTTPFixture{
+		Method:       request.Method,
+		URL:          url,
+		RequestBody:  string(requestBody),
+		StatusCode:   response.StatusCode,
+		ResponseBody: string(responseBody),
+	})
+	return response, nil
+}
@@ -141,6 +141,27 @@ func TestHTTP_TooLarge(t *testing.T) {
 	}
 }
 
+func TestHTTP_TooLarge_Recorded(t *testing.T) {
+	cfg := orm.NewConfig()
+	cfg.Set("DEFAULT_HTTP_LIMIT", "1")
+	store := &store.Store{Config: cfg}
+
+	jobRunID := models.NewID()
+	input := cltest.NewRunInputWithResultAndJobRunID("inputValue", jobRunID)
+	recorder := adapters.NewHTTPRecorder()
+	defer adapters.UseHTTPFixtures(jobRunID, recorder)()
+
+	mock, cleanup := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", "12")
+	defer cleanup()
+
+	hga := &adapters.HTTPGet{URL: cltest.WebURL(t, mock.URL), AllowUnrestrictedNetworkAccess: true}
+	result := hga.Perform(input, store)
+
+	require.Error(t, result.Error())
+	assert.Contains(t, result.Error().Error(), "HTTP response too large")
+	assert.Empty(t, recorder.Recorded())
+}
+
 func TestHTTP_PerformWithRestrictedIP(t *testing.T) {
 	cfg := orm.NewConfig()
 	store := &store.Store{Config: cfg}
@@ -846,3 +867,89 @@ func fillBlob(size int64) []byte {
 	}
 	return body
 }
+
+func TestHTTP_Failover(t *testing.T) {
+	str := leanStore()
+
+	tests := []struct {
+		verb    string
+		factory func(url models.WebURL, failover models.WebURL) adapters.BaseAdapter
+	}{
+		{"GET", func(url models.WebURL, failover models.WebURL) adapters.BaseAdapter {
+			return &adapters.HTTPGet{URL: url, FailoverURLs: models.WebURLs{failover}, AllowUnrestrictedNetworkAccess: true}
+		}},
+		{"POST", func(url models.WebURL, failover models.WebURL) adapters.BaseAdapter {
+			return &adapters.HTTPPost{URL: url, FailoverURLs: models.WebURLs{failover}, AllowUnrestrictedNetworkAccess: true}
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.verb, func(t *testing.T) {
+			failing := uint32(0)
+			primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddUint32(&failing, 1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer primary.Close()
+			failover, cleanup := cltest.NewHTTPMockServer(t, http.StatusOK, test.verb, `{"value":"failover"}`,
+				func(_ http.Header, body string) {
+					if test.verb == "POST" {
+						assert.JSONEq(t, `{"result":"inputValue"}`, body)
+					}
+				})
+			defer cleanup()
+
+			adapter := test.factory(cltest.WebURL(t, primary.URL), cltest.WebURL(t, failover.URL))
+			result := adapter.Perform(cltest.NewRunInputWithResult("inputValue"), str)
+
+			require.NoError(t, result.Error())
+			assert.Equal(t, `{"value":"failover"}`, result.Result().String())
+			assert.Equal(t, uint32(1), atomic.LoadUint32(&failing))
+		})
+	}
+}
+
+func TestHTTP_Failover_LatencySelectionSkipsFailedURL(t *testing.T) {
+	str := leanStore()
+
+	failing := uint32(0)
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddUint32(&failing, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	failover, cleanup := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"value":"failover"}`)
+	defer cleanup()
+
+	hga := &adapters.HTTPGet{
+		URL:                            cltest.WebURL(t, primary.URL),
+		FailoverURLs:                   models.WebURLs{cltest.WebURL(t, failover.URL)},
+		URLSelection:                   models.EndpointSelectionLatency,
+		AllowUnrestrictedNetworkAccess: true,
+	}
+	for i := 0; i < 2; i++ {
+		result := hga.Perform(cltest.NewRunInputWithResult("inputValue"), str)
+		require.NoError(t, result.Error())
+	}
+
+	// The primary is only tried by the first run, the second going straight
+	// to the failover URL
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&failing))
+}
+
+func TestHTTP_UnmarshalFailoverURLs(t *testing.T) {
+	hga := adapters.HTTPGet{}
+	err := json.Unmarshal([]byte(`{"get": "https://us.example.com", "failoverURLs": ["https://eu.example.com"], "urlSelection": "Latency"}`), &hga)
+	require.NoError(t, err)
+	require.Len(t, hga.FailoverURLs, 1)
+	assert.Equal(t, "https://eu.example.com", hga.FailoverURLs[0].String())
+	assert.Equal(t, models.EndpointSelectionLatency, hga.URLSelection)
+
+	requests, err := hga.GetRequests()
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	assert.Equal(t, "us.example.com", requests[0].URL.Host)
+	assert.Equal(t, "eu.example.com", requests[1].URL.Host)
+
+	err = json.Unmarshal([]byte(`{"get": "https://us.example.com", "urlSelection": "random"}`), &hga)
+	assert.Error(t, err)
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
-	simplejson "github.com/bitly/go-simplejson"
 	gjson "github.com/tidwall/gjson"
 )
 
@@ -37,7 +36,10 @@ func (jpa *JSONParse) TaskType() models.TaskType {
 //   }
 //
 // Then ["0","last"] would be the path, and "1111" would be the returned value
-func (jpa *JSONParse) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+//
+// The path is followed through the raw JSON, so only the returned value is
+// decoded, however large the rest of the document is.
+func (jpa *JSONParse) Perform(input models.RunInput, store *store.Store) models.RunOutput {
 	var val string
 	var err error
 
@@ -52,26 +54,35 @@ func (jpa *JSONParse) Perform(input models.RunInput, _ *store.Store) models.RunO
 		return models.NewRunOutputError(err)
 	}
 
-	js, err := simplejson.NewJson([]byte(val))
-	if err != nil {
-		return models.NewRunOutputError(err)
+	if store != nil {
+		if err = utils.CheckJSONStringDepth(val, store.Config.JSONDepthLimit()); err != nil {
+			return models.NewRunOutputError(err)
+		}
+	}
+	if !gjson.Valid(val) {
+		return models.NewRunOutputError(errors.New("result is not valid JSON"))
 	}
 
+	js := gjson.Parse(val)
 	last, err := dig(js, jpa.Path)
 	if err != nil {
 		return moldErrorOutput(js, jpa.Path, input)
 	}
 
-	return models.NewRunOutputCompleteWithResult(last.Interface())
+	value, err := decodeValue(last)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputCompleteWithResult(value)
 }
 
-func dig(js *simplejson.Json, path []string) (*simplejson.Json, error) {
+func dig(js gjson.Result, path []string) (gjson.Result, error) {
 	var ok bool
 	for _, k := range path[:] {
-		if isArray(js, k) {
+		if js.IsArray() {
 			js, ok = arrayGet(js, k)
 		} else {
-			js, ok = js.CheckGet(k)
+			js, ok = objectGet(js, k)
 		}
 		if !ok {
 			return js, errors.New("No value could be found for the key '" + k + "'")
@@ -82,54 +93,64 @@ func dig(js *simplejson.Json, path []string) (*simplejson.Json, error) {
 
 // only error if any keys prior to the last one in the path are nonexistent.
 // i.e. Path = ["errorIfNonExistent", "nullIfNonExistent"]
-func moldErrorOutput(js *simplejson.Json, path []string, input models.RunInput) models.RunOutput {
+func moldErrorOutput(js gjson.Result, path []string, input models.RunInput) models.RunOutput {
 	if _, err := getEarlyPath(js, path); err != nil {
 		return models.NewRunOutputError(err)
 	}
 	return models.NewRunOutputCompleteWithResult(nil)
 }
 
-func getEarlyPath(js *simplejson.Json, path []string) (*simplejson.Json, error) {
-	var ok bool
-	for _, k := range path[:len(path)-1] {
-		if isArray(js, k) {
-			js, ok = arrayGet(js, k)
-		} else {
-			js, ok = js.CheckGet(k)
-		}
-		if !ok {
-			return js, errors.New("No value could be found for the key '" + k + "'")
+func getEarlyPath(js gjson.Result, path []string) (gjson.Result, error) {
+	return dig(js, path[:len(path)-1])
+}
+
+// objectGet returns the value of key in the object. Keys are compared
+// literally, rather than as gjson paths, so they may contain dots or
+// wildcards. As with encoding/json, the last of any duplicate keys wins.
+func objectGet(js gjson.Result, key string) (gjson.Result, bool) {
+	if !js.IsObject() {
+		return js, false
+	}
+	var value gjson.Result
+	found := false
+	js.ForEach(func(k, v gjson.Result) bool {
+		if k.String() == key {
+			value, found = v, true
 		}
+		return true
+	})
+	if !found {
+		return js, false
 	}
-	return js, nil
+	return value, true
 }
 
-func arrayGet(js *simplejson.Json, key string) (*simplejson.Json, bool) {
+func arrayGet(js gjson.Result, key string) (gjson.Result, bool) {
 	input, err := strconv.ParseInt(key, 10, 32)
 	if err != nil {
 		return js, false
 	}
-	a, err := js.Array()
-	if err != nil {
-		return js, false
-	}
+	length := int(js.Get("#").Int())
 
 	index := int(input)
 	if index < 0 {
-		index = len(a) + index
+		index = length + index
 	}
 
-	if index >= len(a) || index < 0 {
+	if index >= length || index < 0 {
 		return js, false
 	}
-	return js.GetIndex(index), true
+	return js.Get(strconv.Itoa(index)), true
 }
 
-func isArray(js *simplejson.Json, key string) bool {
-	if _, err := js.Array(); err != nil {
-		return false
-	}
-	return true
+// decodeValue decodes the JSON of a value found in the document, keeping
+// numbers as json.Number so that they do not lose precision.
+func decodeValue(js gjson.Result) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(js.Raw))
+	decoder.UseNumber()
+	var value interface{}
+	err := decoder.Decode(&value)
+	return value, err
 }
 
 // JSONPath is a path to a value in a JSON object
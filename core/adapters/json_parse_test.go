@@ -31,6 +31,10 @@ func TestJsonParse_Perform(t *testing.T) {
 			`{"result":"0.99991"}`, models.RunStatusCompleted, false},
 		{"float result", `{"availability":0.99991}`, []string{"availability"},
 			`{"result":0.99991}`, models.RunStatusCompleted, false},
+		{"large integer result", `{"wei":123456789012345678901234567890}`, []string{"wei"},
+			`{"result":123456789012345678901234567890}`, models.RunStatusCompleted, false},
+		{"invalid JSON", `{"high":"11850.00",`, []string{"high"},
+			``, models.RunStatusErrored, true},
 		{
 			"index array",
 			`{"data": [0, 1]}`,
@@ -168,6 +172,26 @@ func TestJsonParse_Perform_WithPreParsedJSON(t *testing.T) {
 	assert.NoError(t, result.Error())
 }
 
+func TestJsonParse_Perform_DepthLimit(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig(t)
+	defer cfgCleanup()
+	config.Set("JSON_DEPTH_LIMIT", "3")
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	adapter := adapters.JSONParse{Path: []string{"data", "0", "0"}}
+
+	result := adapter.Perform(cltest.NewRunInputWithResult(`{"data": [[1, "[[["]]}`), store)
+	assert.NoError(t, result.Error())
+	assert.Equal(t, `{"result":1}`, result.Data().String())
+
+	result = adapter.Perform(cltest.NewRunInputWithResult(`{"data": [[[1]]]}`), store)
+	assert.Error(t, result.Error())
+	assert.Equal(t, models.RunStatusErrored, result.Status())
+}
+
 func TestJSON_UnmarshalJSON(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
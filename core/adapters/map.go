@@ -0,0 +1,124 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/pkg/errors"
+)
+
+// mapUnsupportedTasks are the task types which cannot be performed for each
+// element of a Map, because they send transactions or do not complete
+// synchronously.
+var mapUnsupportedTasks = map[models.TaskType]bool{
+	TaskTypeDelay:             true,
+	TaskTypeEthTx:             true,
+	TaskTypeEthTxABIEncode:    true,
+	TaskTypeEthTxCommitReveal: true,
+	TaskTypeNoOpPendOutgoing:  true,
+	TaskTypeSleep:             true,
+}
+
+// Map performs its Tasks once for each element of the array in its input,
+// collecting the result of the last task for each element into an array.
+// Path optionally locates the array within the input's result.
+type Map struct {
+	Path  JSONPath                 `json:"path"`
+	Tasks []models.TaskSpecRequest `json:"tasks"`
+}
+
+// TaskType returns the type of Adapter.
+func (m *Map) TaskType() models.TaskType {
+	return TaskTypeMap
+}
+
+// TaskSpecs returns the tasks performed for each element.
+func (m *Map) TaskSpecs() []models.TaskSpec {
+	specs := make([]models.TaskSpec, len(m.Tasks))
+	for i, task := range m.Tasks {
+		specs[i] = models.TaskSpec{
+			Type:   task.Type,
+			Params: task.Params,
+		}
+	}
+	return specs
+}
+
+// Validate returns an error if the Map has no tasks, or if any of its tasks
+// cannot be performed within a Map.
+func (m *Map) Validate() error {
+	if len(m.Tasks) == 0 {
+		return errors.New("map must have at least one task")
+	}
+	for _, task := range m.Tasks {
+		if mapUnsupportedTasks[task.Type] {
+			return fmt.Errorf("%s tasks cannot be performed within a map", task.Type)
+		}
+	}
+	return nil
+}
+
+// Perform runs the tasks for each element in turn, in the same way as the
+// tasks of a job run, and errors if any task errors or does not complete
+// synchronously.
+func (m *Map) Perform(input models.RunInput, store *store.Store) models.RunOutput {
+	if err := m.Validate(); err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	array := input.Result()
+	if len(m.Path) > 0 {
+		array = array.Get(gjsonPath(m.Path))
+	}
+	elements, err := models.ArrayValue(array)
+	if err != nil {
+		return models.NewRunOutputError(errors.Wrap(err, "map input"))
+	}
+
+	tasks := m.TaskSpecs()
+	results := []json.RawMessage{}
+	for i, element := range elements {
+		data, err := input.Data().Add("result", json.RawMessage(element.Raw))
+		if err != nil {
+			return models.NewRunOutputError(err)
+		}
+		for j, task := range tasks {
+			output := performMapTask(task, input.CloneWithData(data), store)
+			if output.HasError() {
+				return models.NewRunOutputError(errors.Wrapf(output.Error(), "element %d, task %d (%s)", i, j, task.Type))
+			}
+			if !output.Status().Completed() {
+				return models.NewRunOutputError(fmt.Errorf("element %d, task %d (%s) did not complete synchronously", i, j, task.Type))
+			}
+			data = output.Data()
+		}
+
+		result := data.Get("result").Raw
+		if result == "" {
+			result = "null"
+		}
+		results = append(results, json.RawMessage(result))
+	}
+	return models.NewRunOutputCompleteWithResult(results)
+}
+
+func performMapTask(task models.TaskSpec, input models.RunInput, store *store.Store) models.RunOutput {
+	adapter, err := For(task, store.Config, store.ORM)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return adapter.Perform(input, store)
+}
+
+// gjsonPath converts a JSONPath into the equivalent gjson path.
+func gjsonPath(path JSONPath) string {
+	escaped := make([]string, len(path))
+	for i, key := range path {
+		escaped[i] = strings.NewReplacer(".", `\.`, "*", `\*`, "?", `\?`).Replace(key)
+	}
+	return strings.Join(escaped, ".")
+}
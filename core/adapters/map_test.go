@@ -0,0 +1,113 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMap_Perform(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	tests := []struct {
+		name    string
+		params  string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			"multiplies each element",
+			`{"tasks": [{"type": "multiply", "params": {"times": 10}}]}`,
+			`{"result": ["1", "2.5", "3"]}`,
+			`["10", "25", "30"]`,
+			false,
+		},
+		{
+			"follows path and chains tasks",
+			`{"path": ["data", "tokens"], "tasks": [
+				{"type": "jsonparse", "params": {"path": ["price"]}},
+				{"type": "multiply", "params": {"times": 100}}
+			]}`,
+			`{"result": {"data": {"tokens": [{"price": 1.5}, {"price": 2}]}}}`,
+			`["150", "200"]`,
+			false,
+		},
+		{
+			"empty array",
+			`{"tasks": [{"type": "noop"}]}`,
+			`{"result": []}`,
+			`[]`,
+			false,
+		},
+		{
+			"not an array",
+			`{"tasks": [{"type": "noop"}]}`,
+			`{"result": "1"}`,
+			``,
+			true,
+		},
+		{
+			"task errors",
+			`{"tasks": [{"type": "jsonparse", "params": {"path": ["missing", "price"]}}]}`,
+			`{"result": [{"price": 1}]}`,
+			``,
+			true,
+		},
+		{
+			"task unsupported within a map",
+			`{"tasks": [{"type": "noop"}, {"type": "nooppendoutgoing"}]}`,
+			`{"result": [1]}`,
+			``,
+			true,
+		},
+		{
+			"no tasks",
+			`{"tasks": []}`,
+			`{"result": [1]}`,
+			``,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adapter := adapters.Map{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+
+			input := cltest.NewRunInput(cltest.JSONFromString(t, test.input))
+			output := adapter.Perform(input, store)
+			if test.wantErr {
+				assert.True(t, output.HasError())
+				return
+			}
+			require.NoError(t, output.Error())
+			assert.JSONEq(t, test.want, output.Result().Raw)
+		})
+	}
+}
+
+func TestMap_Validate(t *testing.T) {
+	t.Parallel()
+
+	adapter := adapters.Map{Tasks: []models.TaskSpecRequest{{Type: adapters.TaskTypeEthTx}}}
+	assert.EqualError(t, adapter.Validate(), "ethtx tasks cannot be performed within a map")
+
+	adapter = adapters.Map{Tasks: []models.TaskSpecRequest{{Type: adapters.TaskTypeEthTxCommitReveal}}}
+	assert.EqualError(t, adapter.Validate(), "ethtxcommitreveal tasks cannot be performed within a map")
+
+	adapter = adapters.Map{Tasks: []models.TaskSpecRequest{{Type: adapters.TaskTypeDelay}}}
+	assert.EqualError(t, adapter.Validate(), "delay tasks cannot be performed within a map")
+
+	adapter = adapters.Map{Tasks: []models.TaskSpecRequest{{Type: adapters.TaskTypeMultiply}}}
+	assert.NoError(t, adapter.Validate())
+}
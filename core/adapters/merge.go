@@ -0,0 +1,30 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// Merge assembles its Values into a single JSON object result. Values are
+// usually $(name.result) variables, which are interpolated with the results
+// of earlier named tasks before Perform is called, so the keys of Values
+// rename those results in the merged object.
+type Merge struct {
+	Values models.JSON `json:"values"`
+}
+
+// TaskType returns the type of Adapter.
+func (m *Merge) TaskType() models.TaskType {
+	return TaskTypeMerge
+}
+
+// Perform returns the object of Values as the result.
+func (m *Merge) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	if !m.Values.IsObject() {
+		return models.NewRunOutputError(fmt.Errorf("merge values must be an object, got %s", m.Values.Raw))
+	}
+	return models.NewRunOutputCompleteWithResult(json.RawMessage(m.Values.Raw))
+}
@@ -0,0 +1,44 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge_Perform(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  string
+		want    string
+		wantErr bool
+	}{
+		{"object", `{"values": {"price": "123.45", "volume": 1000, "pair": ["ETH", "USD"]}}`, `{"price": "123.45", "volume": 1000, "pair": ["ETH", "USD"]}`, false},
+		{"empty object", `{"values": {}}`, `{}`, false},
+		{"not an object", `{"values": [1, 2]}`, ``, true},
+		{"missing values", `{}`, ``, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adapter := adapters.Merge{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+
+			input := cltest.NewRunInputWithResult("previous")
+			output := adapter.Perform(input, nil)
+			if test.wantErr {
+				assert.True(t, output.HasError())
+				return
+			}
+			require.NoError(t, output.Error())
+			assert.JSONEq(t, test.want, output.Result().Raw)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package adapters
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// Merkle builds a Merkle tree over the array in its input, returning its
+// root and, if Proofs is set, the proof of each leaf. Path optionally locates
+// the array within the input's result.
+type Merkle struct {
+	Path JSONPath `json:"path"`
+	// Hash is "keccak256", the default, or "sha256".
+	Hash string `json:"hash"`
+	// Encoding is how each value is turned into bytes before being hashed
+	// into a leaf: "bytes", the default, "string", "uint256" or "address",
+	// each as abi.encodePacked would encode them. With "leaf" the values are
+	// taken as leaf hashes already.
+	Encoding string `json:"encoding"`
+	// SortPairs, true by default, sorts each pair of hashes before hashing
+	// them together, as OpenZeppelin's MerkleProof expects.
+	SortPairs *bool `json:"sortPairs,omitempty"`
+	Proofs    bool  `json:"proofs"`
+}
+
+// TaskType returns the type of Adapter.
+func (m *Merkle) TaskType() models.TaskType {
+	return TaskTypeMerkle
+}
+
+// MerkleResult is the result of a Merkle task which returns proofs. The
+// proof of each leaf holds the hashes of its siblings from the bottom up.
+type MerkleResult struct {
+	Root   hexutil.Bytes     `json:"root"`
+	Leaves []hexutil.Bytes   `json:"leaves"`
+	Proofs [][]hexutil.Bytes `json:"proofs"`
+}
+
+// Perform returns the hex encoded root of the tree built over the elements
+// of the array, or a MerkleResult if proofs were asked for. A node left
+// without a sibling on its level is moved up to the next level as it is.
+func (m *Merkle) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	hash, err := hashFunction(m.Hash)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	elements, err := arrayElements(input.Result(), m.Path, nil)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	if len(elements) == 0 {
+		return models.NewRunOutputError(errors.New("cannot build a Merkle tree without leaves"))
+	}
+
+	leaves := make([][]byte, len(elements))
+	for i, element := range elements {
+		if leaves[i], err = m.leaf(element, hash); err != nil {
+			return models.NewRunOutputError(errors.Wrapf(err, "element %d", i))
+		}
+	}
+
+	sortPairs := m.SortPairs == nil || *m.SortPairs
+	levels := [][][]byte{leaves}
+	for level := leaves; len(level) > 1; {
+		var next [][]byte
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			left, right := level[i], level[i+1]
+			if sortPairs && bytes.Compare(left, right) > 0 {
+				left, right = right, left
+			}
+			next = append(next, hash(append(append([]byte{}, left...), right...)))
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	root := hexutil.Bytes(levels[len(levels)-1][0])
+
+	if !m.Proofs {
+		return models.NewRunOutputCompleteWithResult(root.String())
+	}
+	result := MerkleResult{Root: root}
+	for i, leaf := range leaves {
+		result.Leaves = append(result.Leaves, leaf)
+		proof := []hexutil.Bytes{}
+		for index, level := i, 0; level < len(levels)-1; index, level = index/2, level+1 {
+			if sibling := index ^ 1; sibling < len(levels[level]) {
+				proof = append(proof, levels[level][sibling])
+			}
+		}
+		result.Proofs = append(result.Proofs, proof)
+	}
+	return models.NewRunOutputCompleteWithResult(result)
+}
+
+func (m *Merkle) leaf(element gjson.Result, hash func([]byte) []byte) ([]byte, error) {
+	switch m.Encoding {
+	case "", "bytes":
+		b, err := models.BytesValue(element)
+		if err != nil {
+			return nil, err
+		}
+		return hash(b), nil
+	case "string":
+		s, err := models.StringValue(element)
+		if err != nil {
+			return nil, err
+		}
+		return hash([]byte(s)), nil
+	case "uint256":
+		i, err := models.IntValue(element)
+		if err != nil {
+			return nil, err
+		}
+		word, err := utils.EVMWordBigInt(i)
+		if err != nil {
+			return nil, err
+		}
+		return hash(word), nil
+	case "address":
+		if element.Type != gjson.String || !common.IsHexAddress(element.Str) {
+			return nil, fmt.Errorf("%s is not an address", element.Raw)
+		}
+		return hash(common.HexToAddress(element.Str).Bytes()), nil
+	case "leaf":
+		b, err := hexutil.Decode(element.Str)
+		if err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("%s is not a 32 byte hex encoded leaf", element.Raw)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unknown encoding %q, must be bytes, string, uint256, address or leaf", m.Encoding)
+}
@@ -0,0 +1,81 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerkle_Perform(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		json   string
+		want   string
+	}{
+		{"strings", `{"encoding":"string"}`, `{"result":["a","b","c"]}`, "0x5842148bc6ebeb52af882a317c765fccd3ae80589b21a9b8cbf21abb630e46a7"},
+		{"leaves", `{"encoding":"leaf","path":["leaves"]}`, `{"result":{"leaves":[
+			"0x3ac225168df54212a25c1c01fd35bebfea408fdac2e31ddd6f80a4bbf9a5f1cb",
+			"0xb5553de315e0edf504d9150af82dafa5c4667fa618ed0a6f19c69b41166c5510",
+			"0x0b42b6393c1f53060fe3ddbfcd7aadcca894465a5a438f69c87d790b2299b9b2"]}}`, "0x5842148bc6ebeb52af882a317c765fccd3ae80589b21a9b8cbf21abb630e46a7"},
+		{"unsorted sha256 uint256", `{"encoding":"uint256","hash":"sha256","sortPairs":false}`, `{"result":[1,"2"]}`, "0x56af8f5d76765ecd266c7bbc471280f0b5962cab703465e0d9d06932fa47b782"},
+		{"single leaf", `{}`, `{"result":["0xab"]}`, "0x468fc9c005382579139846222b7b0aebc9182ba073b2455938a86d9753bfb078"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.Merkle{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().String())
+		})
+	}
+}
+
+func TestMerkle_Perform_Proofs(t *testing.T) {
+	input := cltest.NewRunInputWithString(t, `{"result":["a","b","c"]}`)
+	adapter := adapters.Merkle{Encoding: "string", Proofs: true}
+	result := adapter.Perform(input, nil)
+	require.NoError(t, result.Error())
+
+	assert.Equal(t, "0x5842148bc6ebeb52af882a317c765fccd3ae80589b21a9b8cbf21abb630e46a7", result.Result().Get("root").String())
+	assert.Equal(t, "0x3ac225168df54212a25c1c01fd35bebfea408fdac2e31ddd6f80a4bbf9a5f1cb", result.Result().Get("leaves.0").String())
+	assert.JSONEq(t, `[
+		["0xb5553de315e0edf504d9150af82dafa5c4667fa618ed0a6f19c69b41166c5510", "0x0b42b6393c1f53060fe3ddbfcd7aadcca894465a5a438f69c87d790b2299b9b2"],
+		["0x3ac225168df54212a25c1c01fd35bebfea408fdac2e31ddd6f80a4bbf9a5f1cb", "0x0b42b6393c1f53060fe3ddbfcd7aadcca894465a5a438f69c87d790b2299b9b2"],
+		["0x805b21d846b189efaeb0377d6bb0d201b3872a363e607c25088f025b0c6ae1f8"]
+	]`, result.Result().Get("proofs").Raw)
+}
+
+func TestMerkle_Perform_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		json   string
+	}{
+		{"empty", `{}`, `{"result":[]}`},
+		{"not an array", `{}`, `{"result":"0xab"}`},
+		{"unknown hash", `{"hash":"md5"}`, `{"result":["a"]}`},
+		{"unknown encoding", `{"encoding":"rlp"}`, `{"result":["a"]}`},
+		{"bad address", `{"encoding":"address"}`, `{"result":["0x12"]}`},
+		{"bad leaf", `{"encoding":"leaf"}`, `{"result":["0x12"]}`},
+		{"negative uint256", `{"encoding":"uint256"}`, `{"result":[-1]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.Merkle{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			assert.Error(t, adapter.Perform(input, nil).Error())
+		})
+	}
+}
@@ -5,9 +5,6 @@ package adapters
 import (
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
-
-	"github.com/pkg/errors"
-	"github.com/shopspring/decimal"
 )
 
 // Perform returns the input's "result" field, multiplied times the adapter's
@@ -16,10 +13,9 @@ import (
 // For example, if input value is "99.994" and the adapter's "times" is
 // set to "100", the result's value will be "9999.4".
 func (ma *Multiply) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
-	val := input.Result()
-	dec, err := decimal.NewFromString(val.String())
+	dec, err := models.DecimalValue(input.Result())
 	if err != nil {
-		return models.NewRunOutputError(errors.Wrapf(err, "cannot parse into big.Float: %v", val.String()))
+		return models.NewRunOutputError(err)
 	}
 	if ma.Times != nil {
 		dec = dec.Mul(*ma.Times)
@@ -0,0 +1,217 @@
+package adapters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// ParseNumber converts a numeric string, as published by data sources in
+// various locales, into a decimal.
+type ParseNumber struct {
+	// DecimalSeparator is either "." or ",". It defaults to the separator of
+	// Locale, or to "." if no locale is given either.
+	DecimalSeparator string `json:"decimalSeparator,omitempty"`
+	// Locale is a language tag such as "en-US" or "de", used to pick the
+	// decimal separator.
+	Locale string `json:"locale,omitempty"`
+}
+
+// TaskType returns the type of Adapter.
+func (p *ParseNumber) TaskType() models.TaskType {
+	return TaskTypeParseNumber
+}
+
+// maxParseNumberExponent bounds the exponent of the numbers parsed, as the
+// digits of a number such as "1e999999999" would take long to write out,
+// and are of no use to later tasks anyway.
+const maxParseNumberExponent = 128
+
+// decimalCommaLanguages are the languages whose locales write a comma before
+// the fractional part of a number.
+var decimalCommaLanguages = map[string]bool{
+	"cs": true, "da": true, "de": true, "el": true, "es": true, "fi": true,
+	"fr": true, "id": true, "it": true, "nb": true, "nl": true, "no": true,
+	"pl": true, "pt": true, "ro": true, "ru": true, "sv": true, "tr": true,
+	"uk": true, "vi": true,
+}
+
+// decimalPointLanguages are the languages whose locales write a point before
+// the fractional part of a number.
+var decimalPointLanguages = map[string]bool{
+	"en": true, "he": true, "hi": true, "ja": true, "ko": true, "ms": true,
+	"th": true, "zh": true,
+}
+
+func (p *ParseNumber) decimalSeparator() (rune, error) {
+	switch p.DecimalSeparator {
+	case ".":
+		return '.', nil
+	case ",":
+		return ',', nil
+	case "":
+	default:
+		return 0, fmt.Errorf("decimalSeparator must be \".\" or \",\", got %q", p.DecimalSeparator)
+	}
+	if p.Locale == "" {
+		return '.', nil
+	}
+	language := strings.ToLower(strings.SplitN(strings.Replace(p.Locale, "_", "-", -1), "-", 2)[0])
+	if decimalCommaLanguages[language] {
+		return ',', nil
+	} else if decimalPointLanguages[language] {
+		return '.', nil
+	}
+	return 0, fmt.Errorf("unsupported locale %q, set the decimalSeparator instead", p.Locale)
+}
+
+// Perform parses the input's "result" field into a decimal.
+//
+// Thousands separators are accepted in the integer part, if every group after
+// the first has three digits, which guards against a decimal separator of
+// another locale being taken for one. The separator may be a point or comma,
+// whichever is not the decimal separator, a space or an apostrophe. An
+// exponent such as "e-3" may follow the number, up to an exponent of 128
+// either way.
+//
+// For example, with a "decimalSeparator" of ",", an input of "1.234,5" gives
+// "1234.5", while an input of "1,234.5" fails.
+func (p *ParseNumber) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	separator, err := p.decimalSeparator()
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	result := input.Result()
+	if result.Type != gjson.String {
+		dec, err := models.DecimalValue(result)
+		if err != nil {
+			return models.NewRunOutputError(err)
+		}
+		return parsedNumberOutput(dec)
+	}
+	dec, err := parseLocaleNumber(result.Str, separator)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return parsedNumberOutput(dec)
+}
+
+// parsedNumberOutput completes the task with dec, unless its exponent is
+// out of bounds.
+func parsedNumberOutput(dec decimal.Decimal) models.RunOutput {
+	if exp := dec.Exponent(); exp > maxParseNumberExponent || exp < -maxParseNumberExponent {
+		return models.NewRunOutputError(fmt.Errorf("the exponent of %se%d is out of bounds, it may be at most %d either way", dec.Coefficient(), exp, maxParseNumberExponent))
+	}
+	return models.NewRunOutputCompleteWithResult(dec.String())
+}
+
+func parseLocaleNumber(input string, separator rune) (decimal.Decimal, error) {
+	invalid := func(reason string) (decimal.Decimal, error) {
+		return decimal.Decimal{}, fmt.Errorf("cannot parse %q as a number: %s", input, reason)
+	}
+
+	text := strings.TrimSpace(input)
+	sign := ""
+	if r := []rune(text); len(r) > 0 {
+		switch r[0] {
+		case '-', '\u2212':
+			sign = "-"
+			text = string(r[1:])
+		case '+':
+			text = string(r[1:])
+		}
+	}
+
+	mantissa, exponent := text, ""
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		mantissa, exponent = text[:i], text[i+1:]
+		digits := strings.TrimLeft(exponent, "+-")
+		if len(exponent)-len(digits) > 1 || !isDigits(digits) {
+			return invalid("malformed exponent")
+		}
+	}
+
+	parts := strings.Split(mantissa, string(separator))
+	if len(parts) > 2 {
+		return invalid(fmt.Sprintf("more than one decimal separator %q", separator))
+	}
+	integer, fraction := parts[0], ""
+	if len(parts) == 2 {
+		fraction = parts[1]
+		if fraction != "" && !isDigits(fraction) {
+			return invalid("the fractional part must only have digits")
+		}
+	}
+	if integer == "" && fraction == "" {
+		return invalid("no digits")
+	}
+
+	integer, err := removeThousandsSeparators(integer, separator)
+	if err != nil {
+		return invalid(err.Error())
+	}
+
+	if integer == "" {
+		integer = "0"
+	}
+	normalized := sign + integer
+	if fraction != "" {
+		normalized += "." + fraction
+	}
+	if exponent != "" {
+		normalized += "e" + exponent
+	}
+	return decimal.NewFromString(normalized)
+}
+
+// removeThousandsSeparators returns the digits of the integer part of a
+// number, checking that its digits are grouped in threes if it has
+// separators.
+func removeThousandsSeparators(integer string, decimalSeparator rune) (string, error) {
+	var groupSeparator rune
+	for _, r := range integer {
+		if r >= '0' && r <= '9' {
+			continue
+		}
+		if r == decimalSeparator || !isThousandsSeparator(r) {
+			return "", fmt.Errorf("unexpected character %q", r)
+		}
+		if groupSeparator != 0 && r != groupSeparator {
+			return "", fmt.Errorf("mixed thousands separators %q and %q", groupSeparator, r)
+		}
+		groupSeparator = r
+	}
+	if groupSeparator == 0 {
+		return integer, nil
+	}
+
+	groups := strings.Split(integer, string(groupSeparator))
+	for i, group := range groups {
+		if (i == 0 && (len(group) == 0 || len(group) > 3)) || (i > 0 && len(group) != 3) {
+			return "", fmt.Errorf("digits separated by %q are not in groups of three", groupSeparator)
+		}
+	}
+	return strings.Join(groups, ""), nil
+}
+
+func isThousandsSeparator(r rune) bool {
+	switch r {
+	case '.', ',', ' ', '\'', '\u00a0', '\u202f', '\u2019':
+		return true
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
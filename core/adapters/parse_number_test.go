@@ -0,0 +1,84 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumber_Perform_Success(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		json   string
+		want   string
+	}{
+		{"plain", `{}`, `{"result":"1234.5"}`, "1234.5"},
+		{"json number", `{}`, `{"result":1234.5}`, "1234.5"},
+		{"thousands commas", `{}`, `{"result":"1,234,567.89"}`, "1234567.89"},
+		{"thousands spaces", `{}`, `{"result":" 1 234 567.89 "}`, "1234567.89"},
+		{"thousands apostrophes", `{}`, `{"result":"1'234.5"}`, "1234.5"},
+		{"scientific", `{}`, `{"result":"1.5e3"}`, "1500"},
+		{"negative scientific", `{}`, `{"result":"-2.5E-3"}`, "-0.0025"},
+		{"largest exponent", `{}`, `{"result":"1e128"}`, "1" + strings.Repeat("0", 128)},
+		{"leading point", `{}`, `{"result":".5"}`, "0.5"},
+		{"decimal comma", `{"decimalSeparator":","}`, `{"result":"1.234,5"}`, "1234.5"},
+		{"decimal comma locale", `{"locale":"de-DE"}`, `{"result":"1.234.567,89"}`, "1234567.89"},
+		{"decimal comma no thousands", `{"locale":"fr"}`, `{"result":"3,14"}`, "3.14"},
+		{"decimal point locale", `{"locale":"en_US"}`, `{"result":"1,234.5"}`, "1234.5"},
+		{"unicode minus", `{"locale":"sv"}`, `{"result":"−12,5"}`, "-12.5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.ParseNumber{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().String())
+		})
+	}
+}
+
+func TestParseNumber_Perform_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		json   string
+	}{
+		{"decimal comma read as thousands", `{}`, `{"result":"1,5"}`},
+		{"other locale", `{}`, `{"result":"1.234,5"}`},
+		{"thousands point read as decimal", `{"decimalSeparator":","}`, `{"result":"1,234.5"}`},
+		{"bad grouping", `{}`, `{"result":"12,34,567"}`},
+		{"mixed separators", `{}`, `{"result":"1,234 567"}`},
+		{"two decimal separators", `{}`, `{"result":"1.2.3"}`},
+		{"malformed exponent", `{}`, `{"result":"1e+-3"}`},
+		{"huge exponent", `{}`, `{"result":"1e999999999"}`},
+		{"huge negative exponent", `{}`, `{"result":"1e-999999999"}`},
+		{"huge json exponent", `{}`, `{"result":1e999999999}`},
+		{"no digits", `{}`, `{"result":"."}`},
+		{"letters", `{}`, `{"result":"12abc"}`},
+		{"object", `{}`, `{"result":{"foo":"bar"}}`},
+		{"bad separator", `{"decimalSeparator":";"}`, `{"result":"1"}`},
+		{"unknown locale", `{"locale":"xx"}`, `{"result":"1"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.ParseNumber{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			assert.Error(t, result.Error())
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"encoding/json"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/pkg/errors"
+)
+
+// Pluck extracts a field from each element of the array in its input. Path
+// optionally locates the array within the input's result.
+type Pluck struct {
+	Path  JSONPath `json:"path"`
+	Field JSONPath `json:"field"`
+}
+
+// TaskType returns the type of Adapter.
+func (p *Pluck) TaskType() models.TaskType {
+	return TaskTypePluck
+}
+
+// Perform returns an array of the value of the adapter's "field" in each
+// element, and errors if an element lacks the field.
+//
+// For example, if the input value is [{"address": "0x1"}, {"address": "0x2"}]
+// and the adapter's "field" is "address", the result's value will be
+// ["0x1", "0x2"].
+func (p *Pluck) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	if len(p.Field) == 0 {
+		return models.NewRunOutputError(errors.New("pluck requires a field"))
+	}
+	fields, err := arrayElements(input.Result(), p.Path, p.Field)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	results := make([]json.RawMessage, len(fields))
+	for i, field := range fields {
+		results[i] = json.RawMessage(field.Raw)
+	}
+	return models.NewRunOutputCompleteWithResult(results)
+}
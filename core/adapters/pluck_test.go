@@ -0,0 +1,27 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluck_Perform(t *testing.T) {
+	input := cltest.NewRunInputWithString(t, `{"result":{"data":[{"user":{"address":"0x1"}},{"user":{"address":"0x2","balance":3}}]}}`)
+	adapter := adapters.Pluck{Path: adapters.JSONPath{"data"}, Field: adapters.JSONPath{"user", "address"}}
+	result := adapter.Perform(input, nil)
+	require.NoError(t, result.Error())
+	assert.JSONEq(t, `["0x1","0x2"]`, result.Result().Raw)
+
+	adapter = adapters.Pluck{Path: adapters.JSONPath{"data"}, Field: adapters.JSONPath{"user", "balance"}}
+	result = adapter.Perform(input, nil)
+	assert.EqualError(t, result.Error(), "element 0 has no field user.balance")
+
+	adapter = adapters.Pluck{Path: adapters.JSONPath{"data"}}
+	result = adapter.Perform(input, nil)
+	assert.Error(t, result.Error())
+}
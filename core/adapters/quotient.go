@@ -47,10 +47,13 @@ func (q *Quotient) UnmarshalJSON(buf []byte) error {
 // For example, if input value is "2.5", and the adapter's "dividend" value
 // is "1", the result's value will be "0.4".
 func (q *Quotient) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
-	val := input.Result()
-	i, ok := (&big.Float{}).SetString(val.String())
+	divisor, err := models.DecimalValue(input.Result())
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	i, ok := (&big.Float{}).SetString(divisor.String())
 	if !ok {
-		return models.NewRunOutputError(fmt.Errorf("cannot parse into big.Float: %v", val.String()))
+		return models.NewRunOutputError(fmt.Errorf("cannot parse into big.Float: %v", divisor.String()))
 	}
 	if i.Cmp(big.NewFloat(0)) == 0 {
 		return models.NewRunOutputError(fmt.Errorf("cannot divide by zero"))
@@ -0,0 +1,294 @@
+package adapters
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Reduce folds the numbers in the array in its input into a single number
+// by evaluating Expression for each of them in turn. Path optionally locates
+// the array within the input's result, and Field the number within each of
+// its elements.
+type Reduce struct {
+	Expression string           `json:"expression"`
+	Initial    *decimal.Decimal `json:"initial,omitempty"`
+	Path       JSONPath         `json:"path"`
+	Field      JSONPath         `json:"field"`
+}
+
+// TaskType returns the type of Adapter.
+func (r *Reduce) TaskType() models.TaskType {
+	return TaskTypeReduce
+}
+
+// Perform evaluates the adapter's "expression" once per element, with "x"
+// set to the element, "i" to its index and "acc" to the value of the
+// expression for the element before, or to "initial" (zero by default) for
+// the first element. Expressions may use numbers, + - * /, parentheses and
+// the functions min, max and abs.
+//
+// For example, if the input value is [3, 1, 2], "expression" is
+// "max(acc, x * 2)", the result's value will be "6".
+func (r *Reduce) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	expression, err := parseReduceExpression(r.Expression)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	elements, err := arrayElements(input.Result(), r.Path, r.Field)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	values, err := decimalElements(elements)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	acc := decimal.Zero
+	if r.Initial != nil {
+		acc = *r.Initial
+	}
+	for i, value := range values {
+		vars := map[string]decimal.Decimal{"acc": acc, "x": value, "i": decimal.New(int64(i), 0)}
+		if acc, err = expression(vars); err != nil {
+			return models.NewRunOutputError(errors.Wrapf(err, "element %d", i))
+		}
+	}
+	return models.NewRunOutputCompleteWithResult(acc.String())
+}
+
+// reduceExpression evaluates a parsed expression with the given variables.
+type reduceExpression func(vars map[string]decimal.Decimal) (decimal.Decimal, error)
+
+var reduceFunctions = map[string]func(decimal.Decimal, ...decimal.Decimal) decimal.Decimal{
+	"min": decimal.Min,
+	"max": decimal.Max,
+}
+
+// parseReduceExpression parses an arithmetic expression by recursive
+// descent, with * and / binding tighter than + and -.
+func parseReduceExpression(input string) (reduceExpression, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, errors.New("reduce requires an expression")
+	}
+	p := &expressionParser{input: input}
+	p.next()
+	expression, err := p.sum()
+	if err == nil && p.token != "" {
+		err = fmt.Errorf("unexpected %q", p.token)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid expression %q", input)
+	}
+	return expression, nil
+}
+
+type expressionParser struct {
+	input string
+	pos   int
+	token string
+}
+
+// next reads the next number, identifier or symbol into token, which is
+// empty at the end of the input.
+func (p *expressionParser) next() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	switch {
+	case p.pos == len(p.input):
+	case isNumberByte(p.input[p.pos]):
+		for p.pos < len(p.input) && isNumberByte(p.input[p.pos]) {
+			p.pos++
+		}
+	case isIdentifierByte(p.input[p.pos]):
+		for p.pos < len(p.input) && isIdentifierByte(p.input[p.pos]) {
+			p.pos++
+		}
+	default:
+		p.pos++
+	}
+	p.token = p.input[start:p.pos]
+}
+
+func isNumberByte(b byte) bool {
+	return (b >= '0' && b <= '9') || b == '.'
+}
+
+func isIdentifierByte(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || b == '_'
+}
+
+func (p *expressionParser) sum() (reduceExpression, error) {
+	left, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+	for p.token == "+" || p.token == "-" {
+		operator := p.token
+		p.next()
+		right, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpression(left, right, func(a, b decimal.Decimal) (decimal.Decimal, error) {
+			if operator == "+" {
+				return a.Add(b), nil
+			}
+			return a.Sub(b), nil
+		})
+	}
+	return left, nil
+}
+
+func (p *expressionParser) product() (reduceExpression, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.token == "*" || p.token == "/" {
+		operator := p.token
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpression(left, right, func(a, b decimal.Decimal) (decimal.Decimal, error) {
+			if operator == "*" {
+				return a.Mul(b), nil
+			} else if b.IsZero() {
+				return decimal.Decimal{}, errors.New("division by zero")
+			}
+			return a.Div(b), nil
+		})
+	}
+	return left, nil
+}
+
+func (p *expressionParser) unary() (reduceExpression, error) {
+	if p.token != "-" {
+		return p.primary()
+	}
+	p.next()
+	operand, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return func(vars map[string]decimal.Decimal) (decimal.Decimal, error) {
+		value, err := operand(vars)
+		return value.Neg(), err
+	}, nil
+}
+
+func (p *expressionParser) primary() (reduceExpression, error) {
+	token := p.token
+	switch {
+	case token == "":
+		return nil, errors.New("unexpected end of expression")
+	case token == "(":
+		p.next()
+		inner, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if p.token != ")" {
+			return nil, errors.New("missing )")
+		}
+		p.next()
+		return inner, nil
+	case isNumberByte(token[0]):
+		value, err := decimal.NewFromString(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", token)
+		}
+		p.next()
+		return func(map[string]decimal.Decimal) (decimal.Decimal, error) { return value, nil }, nil
+	case isIdentifierByte(token[0]):
+		p.next()
+		if p.token == "(" {
+			return p.call(token)
+		}
+		if token != "acc" && token != "x" && token != "i" {
+			return nil, fmt.Errorf("unknown variable %q, must be acc, x or i", token)
+		}
+		return func(vars map[string]decimal.Decimal) (decimal.Decimal, error) { return vars[token], nil }, nil
+	}
+	return nil, fmt.Errorf("unexpected %q", token)
+}
+
+func (p *expressionParser) call(name string) (reduceExpression, error) {
+	var args []reduceExpression
+	p.next()
+	for p.token != ")" {
+		if len(args) > 0 {
+			if p.token != "," {
+				return nil, fmt.Errorf("expected , or ) in call to %s", name)
+			}
+			p.next()
+		}
+		arg, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.next()
+
+	evaluate := func(vars map[string]decimal.Decimal) ([]decimal.Decimal, error) {
+		values := make([]decimal.Decimal, len(args))
+		for i, arg := range args {
+			value, err := arg(vars)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+	if name == "abs" {
+		if len(args) != 1 {
+			return nil, errors.New("abs takes one argument")
+		}
+		return func(vars map[string]decimal.Decimal) (decimal.Decimal, error) {
+			values, err := evaluate(vars)
+			if err != nil {
+				return decimal.Decimal{}, err
+			}
+			return values[0].Abs(), nil
+		}, nil
+	}
+	function, ok := reduceFunctions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q, must be min, max or abs", name)
+	} else if len(args) == 0 {
+		return nil, fmt.Errorf("%s takes at least one argument", name)
+	}
+	return func(vars map[string]decimal.Decimal) (decimal.Decimal, error) {
+		values, err := evaluate(vars)
+		if err != nil {
+			return decimal.Decimal{}, err
+		}
+		return function(values[0], values[1:]...), nil
+	}, nil
+}
+
+func binaryExpression(left, right reduceExpression, operate func(a, b decimal.Decimal) (decimal.Decimal, error)) reduceExpression {
+	return func(vars map[string]decimal.Decimal) (decimal.Decimal, error) {
+		a, err := left(vars)
+		if err != nil {
+			return decimal.Decimal{}, err
+		}
+		b, err := right(vars)
+		if err != nil {
+			return decimal.Decimal{}, err
+		}
+		return operate(a, b)
+	}
+}
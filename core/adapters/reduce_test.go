@@ -0,0 +1,72 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReduce_Perform_Success(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		json   string
+		want   string
+	}{
+		{"sum", `{"expression":"acc + x"}`, `{"result":[1,2,3]}`, "6"},
+		{"precedence", `{"expression":"acc + x * x"}`, `{"result":[1,2,3]}`, "14"},
+		{"parentheses", `{"expression":"(acc + x) * 2"}`, `{"result":[1,2]}`, "8"},
+		{"initial", `{"expression":"acc * x","initial":"1.5"}`, `{"result":[2,3]}`, "9"},
+		{"functions", `{"expression":"max(acc, abs(x - 10))"}`, `{"result":[3,25,12]}`, "15"},
+		{"min of field", `{"expression":"min(acc, x)","initial":100,"path":["prices"],"field":["usd"]}`, `{"result":{"prices":[{"usd":"7.5"},{"usd":4}]}}`, "4"},
+		{"index", `{"expression":"acc + i * x"}`, `{"result":[5,5,5]}`, "15"},
+		{"negation", `{"expression":"-acc - -x"}`, `{"result":[1,2]}`, "1"},
+		{"empty array", `{"expression":"acc + x","initial":7}`, `{"result":[]}`, "7"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.Reduce{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			require.NoError(t, result.Error())
+			assert.Equal(t, test.want, result.Result().String())
+		})
+	}
+}
+
+func TestReduce_Perform_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		json       string
+		want       string
+	}{
+		{"no expression", "", `{"result":[1]}`, "reduce requires an expression"},
+		{"unknown variable", "acc + y", `{"result":[1]}`, `unknown variable "y"`},
+		{"unknown function", "sqrt(x)", `{"result":[1]}`, `unknown function "sqrt"`},
+		{"trailing tokens", "acc + x )", `{"result":[1]}`, `unexpected ")"`},
+		{"unclosed parenthesis", "(acc + x", `{"result":[1]}`, "missing )"},
+		{"incomplete", "acc +", `{"result":[1]}`, "unexpected end of expression"},
+		{"division by zero", "x / acc", `{"result":[1]}`, "element 0: division by zero"},
+		{"not numbers", "acc + x", `{"result":[{"a":1}]}`, "element 0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithString(t, test.json)
+			adapter := adapters.Reduce{Expression: test.expression}
+			result := adapter.Perform(input, nil)
+
+			require.Error(t, result.Error())
+			assert.Contains(t, result.Error().Error(), test.want)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package adapters
+
+import (
+	"context"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// runContexts holds the context of each run being executed with a deadline.
+var runContexts = struct {
+	sync.Mutex
+	byRun map[string]context.Context
+}{byRun: make(map[string]context.Context)}
+
+// WithRunContext binds the requests sent by the HTTP and bridge tasks of the
+// run with the given ID to ctx, so that they are cancelled along with it,
+// until the returned function is called.
+func WithRunContext(runID *models.ID, ctx context.Context) func() {
+	runContexts.Lock()
+	defer runContexts.Unlock()
+	runContexts.byRun[runID.String()] = ctx
+	return func() {
+		runContexts.Lock()
+		defer runContexts.Unlock()
+		delete(runContexts.byRun, runID.String())
+	}
+}
+
+func runContext(runID *models.ID) context.Context {
+	runContexts.Lock()
+	defer runContexts.Unlock()
+	if ctx, ok := runContexts.byRun[runID.String()]; ok {
+		return ctx
+	}
+	return context.Background()
+}
@@ -0,0 +1,213 @@
+package adapters
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// SignSchemeEthSign signs the keccak256 hash of the result with an
+	// Ethereum account, using the eth_sign message prefix.
+	SignSchemeEthSign = "eth_sign"
+	// SignSchemePersonalSign signs the result with an Ethereum account as
+	// personal_sign does, prefixing the message itself rather than its hash,
+	// as EIP-191 version 0x45 specifies.
+	SignSchemePersonalSign = "personal_sign"
+	// SignSchemeEIP712 signs the EIP-712 typed data in the result with an
+	// Ethereum account, as eth_signTypedData_v4 does.
+	SignSchemeEIP712 = "eip712"
+	// SignSchemeEd25519 signs the result with the off-chain ed25519 key of an
+	// OCR key bundle.
+	SignSchemeEd25519 = "ed25519"
+)
+
+// Sign signs the upstream result with a key held by the node, so that
+// consumers which do not read the chain can still verify where a value came
+// from.
+//
+// With the default "eth_sign" scheme, and the "personal_sign" and "eip712"
+// schemes, Key is the address of an account in the node's keystore, which
+// must be given and cannot be the funding account. With the "ed25519"
+// scheme, Key is the ID of an unlocked OCR key bundle.
+//
+// A 0x-prefixed hex result is signed as the bytes it represents, any other
+// result is signed as its string value, except with the "eip712" scheme,
+// where the result is the typed data, with its types, primaryType, domain
+// and message. Typed data is only signed for a domain whose
+// verifyingContract and chainId are among Domains, so that a run's input
+// cannot have the node sign for a contract the job was not written for.
+type Sign struct {
+	Scheme  string       `json:"scheme"`
+	Key     string       `json:"key"`
+	Domains []SignDomain `json:"domains"`
+	// Components returns the r, s and v of an Ethereum signature alongside
+	// it, for contracts which take them as separate arguments to ecrecover.
+	Components bool `json:"components"`
+}
+
+// SignDomain is an EIP-712 domain a Sign task may sign typed data for.
+type SignDomain struct {
+	VerifyingContract common.Address `json:"verifyingContract"`
+	ChainID           *utils.Big     `json:"chainId"`
+}
+
+// SignatureComponents is the result of a Sign task with Components set. V is
+// 27 or 28, as ecrecover expects.
+type SignatureComponents struct {
+	Signature hexutil.Bytes `json:"signature"`
+	R         hexutil.Bytes `json:"r"`
+	S         hexutil.Bytes `json:"s"`
+	V         uint8         `json:"v"`
+}
+
+// TaskType returns the type of Adapter.
+func (s *Sign) TaskType() models.TaskType {
+	return TaskTypeSign
+}
+
+// Perform returns the hex encoded signature on the input's result.
+func (s *Sign) Perform(input models.RunInput, store *store.Store) models.RunOutput {
+	scheme := strings.ToLower(s.Scheme)
+	var signature []byte
+	var err error
+	switch scheme {
+	case "", SignSchemeEthSign, SignSchemePersonalSign:
+		var msg []byte
+		if msg, err = signingPayload(input.Result().String()); err == nil {
+			signature, err = s.ethSign(scheme, msg, store)
+		}
+	case SignSchemeEIP712:
+		signature, err = s.signTypedData(input.Result(), store)
+	case SignSchemeEd25519:
+		var msg []byte
+		if msg, err = signingPayload(input.Result().String()); err == nil {
+			signature, err = store.OCRKeyStore.SignOffChain(s.Key, msg)
+		}
+	default:
+		err = fmt.Errorf("unsupported signing scheme %s", s.Scheme)
+	}
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	if !s.Components {
+		return models.NewRunOutputCompleteWithResult(hexutil.Encode(signature))
+	} else if scheme == SignSchemeEd25519 {
+		return models.NewRunOutputError(errors.New("ed25519 signatures have no r, s and v components"))
+	}
+	return models.NewRunOutputCompleteWithResult(SignatureComponents{
+		Signature: signature,
+		R:         signature[:32],
+		S:         signature[32:64],
+		V:         signature[64] + 27,
+	})
+}
+
+func (s *Sign) account(store *store.Store) (accounts.Account, error) {
+	if s.Key == "" {
+		return accounts.Account{}, errors.New("sign requires the address of the key to sign with")
+	} else if !common.IsHexAddress(s.Key) {
+		return accounts.Account{}, fmt.Errorf("%s is not a valid ethereum address", s.Key)
+	}
+	address := common.HexToAddress(s.Key)
+	key, err := store.KeyByAddress(address)
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return accounts.Account{}, err
+	} else if key.IsFunding {
+		return accounts.Account{}, fmt.Errorf("cannot sign with the funding account %s", address.Hex())
+	}
+	return store.KeyStore.GetAccountByAddress(address)
+}
+
+func (s *Sign) ethSign(scheme string, msg []byte, store *store.Store) ([]byte, error) {
+	account, err := s.account(store)
+	if err != nil {
+		return nil, err
+	}
+
+	var signature models.Signature
+	if scheme == SignSchemePersonalSign {
+		signature, err = store.KeyStore.SignPersonalMessageWithAccount(account, msg)
+	} else {
+		var hash []byte
+		if hash, err = utils.Keccak256(msg); err == nil {
+			signature, err = store.KeyStore.SignHashWithAccount(account, common.BytesToHash(hash))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return signature.Bytes(), nil
+}
+
+func (s *Sign) signTypedData(result gjson.Result, store *store.Store) ([]byte, error) {
+	raw := result.Raw
+	if result.Type == gjson.String {
+		raw = result.Str
+	}
+	typedData, err := parseTypedData(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkDomain(typedData); err != nil {
+		return nil, err
+	}
+	domainSeparator, structHash, err := hashTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.account(store)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := store.KeyStore.SignTypedDataWithAccount(account, common.BytesToHash(domainSeparator), common.BytesToHash(structHash))
+	if err != nil {
+		return nil, err
+	}
+	return signature.Bytes(), nil
+}
+
+// checkDomain returns an error unless the typed data's domain commits to a
+// verifyingContract and chainId which are among the task's Domains.
+func (s *Sign) checkDomain(typedData core.TypedData) error {
+	if len(s.Domains) == 0 {
+		return errors.New("the eip712 scheme requires the domains that may be signed for")
+	}
+	committed := map[string]bool{}
+	for _, field := range typedData.Types["EIP712Domain"] {
+		committed[field.Name] = true
+	}
+	domain := typedData.Domain
+	if !committed["verifyingContract"] || !committed["chainId"] || domain.ChainId == nil || !common.IsHexAddress(domain.VerifyingContract) {
+		return errors.New("EIP-712 domain must have a verifyingContract and chainId")
+	}
+	contract := common.HexToAddress(domain.VerifyingContract)
+	chainID := (*big.Int)(domain.ChainId)
+	for _, allowed := range s.Domains {
+		if allowed.VerifyingContract == contract && allowed.ChainID != nil && allowed.ChainID.ToInt().Cmp(chainID) == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("EIP-712 domain with verifyingContract %s and chainId %s is not among the task's domains", contract.Hex(), chainID)
+}
+
+func signingPayload(result string) ([]byte, error) {
+	if utils.HasHexPrefix(result) {
+		return hexutil.Decode(result)
+	}
+	return []byte(result), nil
+}
@@ -0,0 +1,222 @@
+package adapters_test
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/sjson"
+)
+
+func TestSign_Perform_EthSign(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, store.KeyStore.Unlock(cltest.Password))
+	account, err := store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		key    string
+		result string
+		msg    []byte
+	}{
+		{"string", account.Address.Hex(), "hello", []byte("hello")},
+		{"hex", account.Address.Hex(), "0xdeadbeef", []byte{0xde, 0xad, 0xbe, 0xef}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := cltest.NewRunInputWithResult(test.result)
+			adapter := adapters.Sign{Key: test.key}
+			result := adapter.Perform(input, store)
+			require.NoError(t, result.Error())
+
+			signature, err := hexutil.Decode(result.Result().String())
+			require.NoError(t, err)
+
+			hash, err := utils.Keccak256(test.msg)
+			require.NoError(t, err)
+			prefixed, err := utils.Keccak256(append([]byte(strpkg.EthereumMessageHashPrefix), hash...))
+			require.NoError(t, err)
+			pubKey, err := crypto.SigToPub(prefixed, signature)
+			require.NoError(t, err)
+			assert.Equal(t, account.Address, crypto.PubkeyToAddress(*pubKey))
+		})
+	}
+}
+
+func TestSign_Perform_PersonalSign(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, store.KeyStore.Unlock(cltest.Password))
+	account, err := store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+
+	input := cltest.NewRunInputWithResult("hello")
+	adapter := adapters.Sign{Scheme: adapters.SignSchemePersonalSign, Key: account.Address.Hex(), Components: true}
+	result := adapter.Perform(input, store)
+	require.NoError(t, result.Error())
+
+	signature, err := hexutil.Decode(result.Result().Get("signature").String())
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(signature[:32]), result.Result().Get("r").String())
+	assert.Equal(t, hexutil.Encode(signature[32:64]), result.Result().Get("s").String())
+	assert.Equal(t, int64(signature[64])+27, result.Result().Get("v").Int())
+
+	hash, err := utils.Keccak256([]byte("\x19Ethereum Signed Message:\n5hello"))
+	require.NoError(t, err)
+	pubKey, err := crypto.SigToPub(hash, signature)
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, crypto.PubkeyToAddress(*pubKey))
+}
+
+// eip712Mail is the example typed data given by EIP-712.
+const eip712Mail = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestSign_Perform_EIP712(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, store.KeyStore.Unlock(cltest.Password))
+	account, err := store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+
+	input := cltest.NewRunInputWithString(t, `{"result":`+eip712Mail+`}`)
+	adapter := adapters.Sign{
+		Scheme:  adapters.SignSchemeEIP712,
+		Key:     account.Address.Hex(),
+		Domains: []adapters.SignDomain{{VerifyingContract: common.HexToAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"), ChainID: utils.NewBigI(1)}},
+	}
+	result := adapter.Perform(input, store)
+	require.NoError(t, result.Error())
+
+	signature, err := hexutil.Decode(result.Result().String())
+	require.NoError(t, err)
+	digest := hexutil.MustDecode("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2")
+	pubKey, err := crypto.SigToPub(digest, signature)
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestSign_Perform_Ed25519(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key, err := ocrkey.NewKeyBundle()
+	require.NoError(t, err)
+	encryptedKey, err := key.Encrypt(cltest.Password)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateEncryptedOCRKeyBundle(encryptedKey))
+
+	input := cltest.NewRunInputWithResult("hello")
+	adapter := adapters.Sign{Scheme: adapters.SignSchemeEd25519, Key: key.ID}
+
+	result := adapter.Perform(input, store)
+	assert.Error(t, result.Error(), "bundle has not been unlocked")
+
+	unlocked, err := store.OCRKeyStore.Unlock(cltest.Password)
+	require.NoError(t, err)
+	require.Equal(t, []string{key.ID}, unlocked)
+
+	result = adapter.Perform(input, store)
+	require.NoError(t, result.Error())
+	signature, err := hexutil.Decode(result.Result().String())
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(ed25519.PublicKey(key.PublicKeyOffChain()), []byte("hello"), signature))
+}
+
+func TestSign_Perform_Errors(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, store.KeyStore.Unlock(cltest.Password))
+	account, err := store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+	key := account.Address.Hex()
+
+	funding := models.Key{Address: models.EIP55Address(cltest.NewAddress().Hex()), JSON: cltest.JSONFromString(t, `{"key": 2}`), IsFunding: true}
+	require.NoError(t, store.CreateKeyIfNotExists(funding))
+
+	contract := common.HexToAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC")
+	otherChain := []adapters.SignDomain{{VerifyingContract: contract, ChainID: utils.NewBigI(3)}}
+	otherContract := []adapters.SignDomain{{VerifyingContract: cltest.NewAddress(), ChainID: utils.NewBigI(1)}}
+	mailDomain := []adapters.SignDomain{{VerifyingContract: contract, ChainID: utils.NewBigI(1)}}
+	uncommitted, err := sjson.Delete(eip712Mail, "types.EIP712Domain.3")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		adapter adapters.Sign
+		result  string
+	}{
+		{"unknown scheme", adapters.Sign{Scheme: "rsa", Key: key}, "hello"},
+		{"missing key", adapters.Sign{}, "hello"},
+		{"invalid address", adapters.Sign{Key: "not an address"}, "hello"},
+		{"missing account", adapters.Sign{Key: cltest.NewAddress().Hex()}, "hello"},
+		{"funding account", adapters.Sign{Key: funding.Address.Hex()}, "hello"},
+		{"invalid hex", adapters.Sign{Key: key}, "0xzz"},
+		{"invalid typed data", adapters.Sign{Scheme: adapters.SignSchemeEIP712, Key: key, Domains: mailDomain}, `{"primaryType": "Mail"}`},
+		{"no domains", adapters.Sign{Scheme: adapters.SignSchemeEIP712, Key: key}, eip712Mail},
+		{"other chain", adapters.Sign{Scheme: adapters.SignSchemeEIP712, Key: key, Domains: otherChain}, eip712Mail},
+		{"other contract", adapters.Sign{Scheme: adapters.SignSchemeEIP712, Key: key, Domains: otherContract}, eip712Mail},
+		{"uncommitted contract", adapters.Sign{Scheme: adapters.SignSchemeEIP712, Key: key, Domains: mailDomain}, uncommitted},
+		{"ed25519 components", adapters.Sign{Scheme: adapters.SignSchemeEd25519, Components: true}, "hello"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.adapter.Perform(cltest.NewRunInputWithResult(test.result), store)
+			assert.Error(t, result.Error())
+		})
+	}
+}
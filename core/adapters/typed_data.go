@@ -0,0 +1,125 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/signer/core"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// TypedData builds EIP-712 typed data, to be signed by a Sign task with the
+// "eip712" scheme. Domain and Message may hold $(name.result) variables, and
+// the input's result is taken as the message when Message is not given.
+type TypedData struct {
+	Types       map[string][]core.Type `json:"types"`
+	PrimaryType string                 `json:"primaryType"`
+	Domain      models.JSON            `json:"domain"`
+	Message     models.JSON            `json:"message"`
+}
+
+// TaskType returns the type of Adapter.
+func (t *TypedData) TaskType() models.TaskType {
+	return TaskTypeTypedData
+}
+
+// eip712DomainFields are the fields a domain may have, in the order EIP-712
+// gives them.
+var eip712DomainFields = []core.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+// Perform returns the typed data, with its types, primaryType, domain and
+// message, after checking that the domain and message match their types.
+// When Types has no EIP712Domain type, it is made up of the fields Domain
+// has.
+func (t *TypedData) Perform(input models.RunInput, _ *store.Store) models.RunOutput {
+	if !t.Domain.IsObject() {
+		return models.NewRunOutputError(errors.New("typed data requires a domain object"))
+	}
+	message := t.Message.Result
+	if !message.Exists() {
+		message = input.Result()
+	}
+	if !message.IsObject() {
+		return models.NewRunOutputError(fmt.Errorf("typed data message must be an object, got %s", message.Raw))
+	}
+
+	types := map[string][]core.Type{}
+	for name, fields := range t.Types {
+		types[name] = fields
+	}
+	if _, ok := types["EIP712Domain"]; !ok {
+		fields := []core.Type{}
+		for _, field := range eip712DomainFields {
+			if t.Domain.Get(field.Name).Exists() {
+				fields = append(fields, field)
+			}
+		}
+		types["EIP712Domain"] = fields
+	}
+
+	typesJSON, err := json.Marshal(types)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	primaryType, err := json.Marshal(t.PrimaryType)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	raw := fmt.Sprintf(`{"types":%s,"primaryType":%s,"domain":%s,"message":%s}`,
+		typesJSON, primaryType, t.Domain.Raw, message.Raw)
+
+	typedData, err := parseTypedData(raw)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	if _, _, err := hashTypedData(typedData); err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputCompleteWithResult(json.RawMessage(raw))
+}
+
+// parseTypedData parses EIP-712 typed data, accepting a domain chainId given
+// as a JSON number as wallets do.
+func parseTypedData(raw string) (core.TypedData, error) {
+	if chainID := gjson.Get(raw, "domain.chainId"); chainID.Type == gjson.Number {
+		var err error
+		if raw, err = sjson.Set(raw, "domain.chainId", chainID.Raw); err != nil {
+			return core.TypedData{}, err
+		}
+	}
+	var typedData core.TypedData
+	if err := json.Unmarshal([]byte(raw), &typedData); err != nil {
+		return core.TypedData{}, errors.Wrap(err, "invalid EIP-712 typed data")
+	}
+	return typedData, nil
+}
+
+// hashTypedData returns the hashes of the domain and the message of typed
+// data, which fail to be computed when either does not match its type.
+func hashTypedData(typedData core.TypedData) (domainSeparator, structHash []byte, err error) {
+	for _, name := range []string{"EIP712Domain", typedData.PrimaryType} {
+		if _, ok := typedData.Types[name]; !ok {
+			return nil, nil, fmt.Errorf("EIP-712 typed data has no type %q", name)
+		}
+	}
+	domainSeparator, err = typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid EIP-712 domain")
+	}
+	structHash, err = typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid EIP-712 message")
+	}
+	return domainSeparator, structHash, nil
+}
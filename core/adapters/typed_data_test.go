@@ -0,0 +1,103 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mailTypedDataParams = `{
+	"types": {
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	}
+}`
+
+const mailMessage = `{
+	"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+	"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+	"contents": "Hello, Bob!"
+}`
+
+func TestTypedData_Perform(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, store.KeyStore.Unlock(cltest.Password))
+	account, err := store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+
+	adapter := adapters.TypedData{}
+	require.NoError(t, json.Unmarshal([]byte(mailTypedDataParams), &adapter))
+	result := adapter.Perform(cltest.NewRunInputWithString(t, `{"result":`+mailMessage+`}`), nil)
+	require.NoError(t, result.Error())
+
+	assert.Equal(t, "Mail", result.Result().Get("primaryType").String())
+	assert.Equal(t, "Hello, Bob!", result.Result().Get("message.contents").String())
+	assert.JSONEq(t, `[
+		{"name": "name", "type": "string"},
+		{"name": "version", "type": "string"},
+		{"name": "chainId", "type": "uint256"},
+		{"name": "verifyingContract", "type": "address"}
+	]`, result.Result().Get("types.EIP712Domain").Raw)
+
+	sign := adapters.Sign{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"scheme": "eip712",
+		"key": "`+account.Address.Hex()+`",
+		"domains": [{"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC", "chainId": 1}]
+	}`), &sign))
+	signed := sign.Perform(cltest.NewRunInputWithString(t, `{"result":`+result.Result().Raw+`}`), store)
+	require.NoError(t, signed.Error())
+	signature, err := hexutil.Decode(signed.Result().String())
+	require.NoError(t, err)
+	digest := hexutil.MustDecode("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2")
+	pubKey, err := crypto.SigToPub(digest, signature)
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestTypedData_Perform_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{"not an object", `"hello"`},
+		{"missing field", `{"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"}, "contents": "Hi"}`},
+		{"extra field", `{"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"}, "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"}, "contents": "Hi", "cc": "Alice"}`},
+		{"invalid address", `{"from": {"name": "Cow", "wallet": "cow"}, "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"}, "contents": "Hi"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adapter := adapters.TypedData{}
+			require.NoError(t, json.Unmarshal([]byte(mailTypedDataParams), &adapter))
+			result := adapter.Perform(cltest.NewRunInputWithString(t, `{"result":`+test.message+`}`), nil)
+			assert.Error(t, result.Error())
+		})
+	}
+}
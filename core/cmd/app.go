@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/store"
 
@@ -177,6 +178,49 @@ func NewApp(client *Client) *cli.App {
 					Name:   "create",
 					Usage:  "Create Job from a Job Specification JSON",
 					Action: client.CreateJobSpec,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "stopped",
+							Usage: "save the Job without starting it",
+						},
+					},
+				},
+				{
+					Name:   "export",
+					Usage:  "Export a Job and the state needed to move it to another node",
+					Action: client.ExportJobSpec,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "output, o",
+							Usage: "file to save the export to, instead of printing it",
+						},
+						cli.BoolFlag{
+							Name:  "spec",
+							Usage: "export only the Job's canonical specification, without its state",
+						},
+					},
+				},
+				{
+					Name:   "generate",
+					Usage:  "Generate the Job Specification of a common pattern of job, such as median-price-feed, from the pattern's parameters",
+					Action: client.GenerateJobSpec,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "output, o",
+							Usage: "file to save the Job Specification to, instead of printing it",
+						},
+					},
+				},
+				{
+					Name:   "import",
+					Usage:  "Import a Job exported from another node, along with its state",
+					Action: client.ImportJobSpec,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "stopped",
+							Usage: "save the Job without starting it",
+						},
+					},
 				},
 				{
 					Name:   "list",
@@ -189,11 +233,103 @@ func NewApp(client *Client) *cli.App {
 						},
 					},
 				},
+				{
+					Name:   "preview",
+					Usage:  "Perform the tasks of a Job Specification JSON once, without saving it or sending transactions",
+					Action: client.PreviewJobSpec,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "record-http",
+							Usage: "write the responses to the HTTP requests made to this file",
+						},
+						cli.StringFlag{
+							Name:  "replay-http",
+							Usage: "replay the responses recorded to this file instead of making HTTP requests",
+						},
+					},
+				},
 				{
 					Name:   "show",
 					Usage:  "Show a specific Job's details",
 					Action: client.ShowJobSpec,
 				},
+				{
+					Name:   "start",
+					Usage:  "Start a Job which was created with --stopped",
+					Action: client.StartJobSpec,
+				},
+				{
+					Name:   "pause",
+					Usage:  "Stop triggering runs for a Job until it is resumed",
+					Action: client.PauseJobSpec,
+				},
+				{
+					Name:   "resume",
+					Usage:  "Start triggering runs for a paused Job again",
+					Action: client.ResumeJobSpec,
+				},
+				{
+					Name:   "update",
+					Usage:  "Replace the specification of a Job with a Job Specification JSON, keeping its ID and runs",
+					Action: client.UpdateJobSpec,
+				},
+				{
+					Name:   "versions",
+					Usage:  "List every version of a Job's specification, latest first",
+					Action: client.ListJobSpecVersions,
+				},
+				{
+					Name:   "rollback",
+					Usage:  "Replace the specification of a Job with an earlier version of it",
+					Action: client.RollbackJobSpec,
+				},
+				{
+					Name:   "rotatesecret",
+					Usage:  "Replace the secret of each webhook initiator of a Job, printing the new secrets",
+					Action: client.RotateWebhookSecret,
+					Flags: []cli.Flag{
+						cli.DurationFlag{
+							Name:  "overlap",
+							Usage: "how long the previous secrets are still accepted, at most a week",
+							Value: time.Hour,
+						},
+						cli.Int64Flag{
+							Name:  "initiator",
+							Usage: "only replace the secret of the webhook initiator with this ID",
+						},
+					},
+				},
+			},
+		},
+
+		{
+			Name:   "loadtest",
+			Usage:  "Start synthetic runs of Jobs against a test node and report its throughput, latencies and growth",
+			Action: client.LoadTest,
+			Flags: []cli.Flag{
+				cli.StringSliceFlag{
+					Name:  "spec",
+					Usage: "Job Specification JSON or filepath to run, optionally followed by :weight to set its share of the runs; repeat for a mix of Jobs",
+				},
+				cli.Float64Flag{
+					Name:  "rate",
+					Usage: "runs to start per second",
+					Value: 1,
+				},
+				cli.DurationFlag{
+					Name:  "duration",
+					Usage: "how long to keep starting runs",
+					Value: time.Minute,
+				},
+				cli.DurationFlag{
+					Name:  "timeout",
+					Usage: "how long to wait for runs to finish once the last has started",
+					Value: time.Minute,
+				},
+				cli.BoolFlag{
+					Name:  "keep",
+					Usage: "keep the Jobs created for the test instead of archiving them",
+				},
 			},
 		},
 
@@ -215,6 +351,30 @@ func NewApp(client *Client) *cli.App {
 					Usage:   "Import a key file to use with the node",
 					Action:  client.ImportKey,
 				},
+				{
+					Name: "restore",
+					Usage: format(`Restore the jobs, bridges, external initiators, config
+               overrides and keys from a snapshot into an empty database`),
+					Flags:  flags("password, p"),
+					Action: client.RestoreNode,
+				},
+				{
+					Name: "snapshot",
+					Usage: format(`Save the node's jobs, bridges, external initiators, config
+               overrides and keys to a file, encrypted with the password from
+               the password file`),
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "password, p",
+							Usage: "text file holding the password used to encrypt the snapshot",
+						},
+						cli.StringFlag{
+							Name:  "file, f",
+							Usage: "path to save the snapshot to",
+						},
+					},
+					Action: client.SnapshotNode,
+				},
 				{
 					Name:   "setnextnonce",
 					Usage:  "Manually set the next nonce for a key. This should NEVER be necessary during normal operation. USE WITH CAUTION: Setting this incorrectly can break your node.",
@@ -416,12 +576,141 @@ func NewApp(client *Client) *cli.App {
 					Name:   "create",
 					Usage:  "Create an authentication key for a user of External Initiators",
 					Action: client.CreateExternalInitiator,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "protocol-version",
+							Usage: "version of the protocol the External Initiator speaks, 2 to sign requests instead of sending secrets",
+							Value: 1,
+						},
+					},
 				},
 				{
 					Name:   "destroy",
 					Usage:  "Remove an authentication key by name",
 					Action: client.DeleteExternalInitiator,
 				},
+				{
+					Name:   "rotate",
+					Usage:  "Replace the signing keys of an External Initiator speaking version 2 of the protocol",
+					Action: client.RotateExternalInitiatorKeys,
+					Flags: []cli.Flag{
+						cli.DurationFlag{
+							Name:  "overlap",
+							Usage: "how long requests signed with the previous key are still accepted",
+							Value: time.Hour,
+						},
+					},
+				},
+			},
+		},
+
+		{
+			Name:  "namespaces",
+			Usage: "Commands for managing the namespaces which partition jobs, bridges and keys. Set CLIENT_NAMESPACE to act on a namespace other than the default",
+			Subcommands: []cli.Command{
+				{
+					Name:   "addkey",
+					Usage:  "Move the node's key <address> into namespace <name>, so only its jobs may send transactions from it",
+					Action: client.AddNamespaceKey,
+				},
+				{
+					Name:   "create",
+					Usage:  "Create a namespace, optionally with quotas",
+					Action: client.CreateNamespace,
+					Flags:  namespaceQuotaFlags,
+				},
+				{
+					Name:   "list",
+					Usage:  "List all namespaces",
+					Action: client.ListNamespaces,
+				},
+				{
+					Name:   "setquotas",
+					Usage:  "Replace the quotas of a namespace, quotas which are not given become unlimited",
+					Action: client.UpdateNamespaceQuotas,
+					Flags:  namespaceQuotaFlags,
+				},
+				{
+					Name:   "setpolicy",
+					Usage:  "Replace the policy restricting the URLs, HTTP methods and task types the jobs of a namespace may use",
+					Action: client.UpdateNamespaceSpecPolicy,
+				},
+			},
+		},
+
+		{
+			Name:  "workergroups",
+			Usage: "Commands for managing the worker groups which cap how many runs of a set of jobs execute at once",
+			Subcommands: []cli.Command{
+				{
+					Name:   "addjob",
+					Usage:  "Move Job <id> into worker group <name>",
+					Action: client.AddWorkerGroupJob,
+				},
+				{
+					Name:   "create",
+					Usage:  "Create a worker group <name>",
+					Action: client.CreateWorkerGroup,
+					Flags:  workerGroupFlags,
+				},
+				{
+					Name:   "delete",
+					Usage:  "Delete a worker group, returning its jobs to the shared pool",
+					Action: client.DeleteWorkerGroup,
+				},
+				{
+					Name:   "list",
+					Usage:  "List all worker groups and their jobs",
+					Action: client.ListWorkerGroups,
+				},
+				{
+					Name:   "removejob",
+					Usage:  "Move Job <id> out of worker group <name>, back to the shared pool",
+					Action: client.RemoveWorkerGroupJob,
+				},
+				{
+					Name:   "update",
+					Usage:  "Change the concurrency of worker group <name>",
+					Action: client.UpdateWorkerGroup,
+					Flags:  workerGroupFlags,
+				},
+			},
+		},
+
+		{
+			Name:  "templates",
+			Usage: "Commands for managing the Job Specification templates which many similar Jobs can be created from",
+			Subcommands: []cli.Command{
+				{
+					Name:   "create",
+					Usage:  "Create a template <name> from a Job Specification JSON with {{variable}} placeholders, or the path of a file holding one",
+					Action: client.CreateJobSpecTemplate,
+				},
+				{
+					Name:   "delete",
+					Usage:  "Delete a template, keeping the Jobs created from it",
+					Action: client.DeleteJobSpecTemplate,
+				},
+				{
+					Name:   "list",
+					Usage:  "List all templates",
+					Action: client.ListJobSpecTemplates,
+				},
+				{
+					Name:   "use",
+					Usage:  "Create a Job from template <name>, giving the values of its variables with --var",
+					Action: client.CreateJobSpecFromTemplate,
+					Flags: []cli.Flag{
+						cli.StringSliceFlag{
+							Name:  "var",
+							Usage: "the value of a variable, as name=value",
+						},
+						cli.BoolFlag{
+							Name:  "stopped",
+							Usage: "save the Job without starting it",
+						},
+					},
+				},
 			},
 		},
 
@@ -435,6 +724,12 @@ func NewApp(client *Client) *cli.App {
 					Usage:       "Create a new Run for a Job given an Job ID and optional JSON body",
 					Description: "Takes a Job ID and a JSON string or path to a JSON file",
 					Action:      client.CreateJobRun,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "profile",
+							Usage: "profile the Run's execution, for download from /v2/runs/:RunID/profile",
+						},
+					},
 				},
 				{
 					Name:   "list",
@@ -462,6 +757,50 @@ func NewApp(client *Client) *cli.App {
 					Usage:  "Cancel a Run with a specified ID",
 					Action: client.CancelJobRun,
 				},
+				{
+					Name:   "replay",
+					Usage:  "Resume an errored Run from the task that errored",
+					Action: client.ReplayJobRun,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "confirm-transactions",
+							Usage: "replay the run even if it sends a transaction again",
+						},
+					},
+				},
+			},
+		},
+
+		{
+			Name:  "sweeps",
+			Usage: "Commands for sweeping the funds of retired accounts to the treasury",
+			Subcommands: []cli.Command{
+				{
+					Name:   "approve",
+					Usage:  "Approve a pending funds sweep, creating its transactions",
+					Action: client.ApproveFundsSweep,
+				},
+				{
+					Name:   "create",
+					Usage:  "Request a sweep of all ETH and LINK held by node account <address> to TREASURY_ADDRESS",
+					Action: client.CreateFundsSweep,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "reason",
+							Usage: "why the account is being retired",
+						},
+					},
+				},
+				{
+					Name:   "list",
+					Usage:  "List all funds sweeps",
+					Action: client.IndexFundsSweeps,
+				},
+				{
+					Name:   "reject",
+					Usage:  "Reject a pending funds sweep",
+					Action: client.RejectFundsSweep,
+				},
 			},
 		},
 
@@ -474,6 +813,33 @@ func NewApp(client *Client) *cli.App {
 					Usage:  "Send <amount> Eth from node ETH account <fromAddress> to destination <toAddress>.",
 					Action: client.SendEther,
 				},
+				{
+					Name:   "register",
+					Usage:  "Build the transactions for registration <method> on <contractAddress>: setFulfillmentPermission, setAuthorizedSenders, transferAdmin or acceptAdmin",
+					Action: client.CreateRegistration,
+					Flags: []cli.Flag{
+						cli.StringSliceFlag{
+							Name:  "senders",
+							Usage: "accounts to authorize, defaults to all of the node's accounts",
+						},
+						cli.StringFlag{
+							Name:  "oracle",
+							Usage: "oracle whose admin is being transferred, defaults to the node's first account",
+						},
+						cli.StringFlag{
+							Name:  "newAdmin",
+							Usage: "proposed admin for transferAdmin",
+						},
+						cli.BoolFlag{
+							Name:  "submit",
+							Usage: "send the transactions from the node instead of only printing them",
+						},
+						cli.StringFlag{
+							Name:  "from",
+							Usage: "node account to send from, defaults to the node's first account",
+						},
+					},
+				},
 				{
 					Name:   "list",
 					Usage:  "List the Ethereum Transactions in descending order",
@@ -505,3 +871,25 @@ func format(s string) string {
 
 // flags is an abbreviated way to express a CLI flag
 func flags(s string) []cli.Flag { return []cli.Flag{cli.StringFlag{Name: s}} }
+
+var namespaceQuotaFlags = []cli.Flag{
+	cli.Int64Flag{
+		Name:  "max-jobs",
+		Usage: "the most jobs the namespace may hold",
+	},
+	cli.Int64Flag{
+		Name:  "max-runs-per-minute",
+		Usage: "the most runs the namespace's jobs may create in any minute",
+	},
+	cli.Int64Flag{
+		Name:  "max-gas-per-day",
+		Usage: "the most gas the namespace's keys may queue transactions for in any 24 hours",
+	},
+}
+
+var workerGroupFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "concurrency",
+		Usage: "the most runs of the group's jobs which may execute at once",
+	},
+}
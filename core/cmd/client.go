@@ -203,6 +203,9 @@ func (h *authenticatedHTTPClient) doRequest(verb, path string, body io.Reader, h
 	}
 
 	request.Header.Set("Content-Type", "application/json")
+	if namespace := h.config.ClientNamespace(); namespace != "" {
+		request.Header.Set(web.NamespaceHeader, namespace)
+	}
 	for key, value := range headers {
 		request.Header.Add(key, value)
 	}
@@ -71,6 +71,9 @@ func (cli *Client) RunNode(c *clipkg.Context) error {
 	if err != nil {
 		return cli.errorOut(fmt.Errorf("error authenticating keystore: %+v", err))
 	}
+	if _, err = store.OCRKeyStore.Unlock(keyStorePwd); err != nil {
+		logger.Warnf("Unable to unlock all OCR key bundles with the keystore password: %v", err)
+	}
 	if len(c.String("vrfpassword")) != 0 {
 		vrfpwd, fileErr := passwordFromFile(c.String("vrfpassword"))
 		if fileErr != nil {
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/pkg/errors"
+	clipkg "github.com/urfave/cli"
+)
+
+// SnapshotNode writes an encrypted bundle of the node's jobs, bridges,
+// external initiators, config overrides and keys to the given file, protected
+// by the password in the password file.
+func (cli *Client) SnapshotNode(c *clipkg.Context) error {
+	return cli.errorOut(cli.snapshotNode(c))
+}
+
+// RestoreNode loads a bundle written by SnapshotNode into the node's
+// database, which should be empty.
+func (cli *Client) RestoreNode(c *clipkg.Context) error {
+	return cli.errorOut(cli.restoreNode(c))
+}
+
+func (cli *Client) snapshotNode(c *clipkg.Context) error {
+	if !c.IsSet("file") {
+		return errors.New("must specify file to write the snapshot to")
+	}
+	path := c.String("file")
+	_, err := os.Stat(path)
+	if err == nil {
+		return fmt.Errorf(
+			"refusing to overwrite existing file %s. Please move it or change the save path",
+			path)
+	}
+	if !os.IsNotExist(err) {
+		return errors.Wrapf(err, "while checking whether file %s exists", path)
+	}
+	password, err := getPassword(c)
+	if err != nil {
+		return err
+	}
+
+	cli.Config.Dialect = orm.DialectPostgresWithoutLock
+	store := cli.AppFactory.NewApplication(cli.Config).GetStore()
+	snapshot, err := store.Snapshot()
+	if err != nil {
+		return errors.Wrap(err, "while taking snapshot")
+	}
+	bundle, err := strpkg.EncryptSnapshot(snapshot, string(password))
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, bundle, 0600); err != nil {
+		return errors.Wrapf(err, "could not save snapshot to %s", path)
+	}
+	fmt.Printf("Saved snapshot of %d jobs, %d bridges, %d external initiators and %d keys to %s\n",
+		len(snapshot.Jobs), len(snapshot.Bridges), len(snapshot.ExternalInitiators), snapshotKeyCount(snapshot), path)
+	return nil
+}
+
+func (cli *Client) restoreNode(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return errors.New("must pass in the path to the snapshot")
+	}
+	bundle, err := ioutil.ReadFile(c.Args().First())
+	if err != nil {
+		return errors.Wrap(err, "could not read snapshot")
+	}
+	password, err := getPassword(c)
+	if err != nil {
+		return err
+	}
+	snapshot, err := strpkg.DecryptSnapshot(bundle, string(password))
+	if err != nil {
+		return err
+	}
+
+	store := cli.AppFactory.NewApplication(cli.Config).GetStore()
+	if err := store.Restore(snapshot); err != nil {
+		return errors.Wrap(err, "while restoring snapshot")
+	}
+	fmt.Printf("Restored %d jobs, %d bridges, %d external initiators and %d keys from snapshot taken at %s\n",
+		len(snapshot.Jobs), len(snapshot.Bridges), len(snapshot.ExternalInitiators), snapshotKeyCount(snapshot),
+		snapshot.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	return nil
+}
+
+func snapshotKeyCount(s *strpkg.Snapshot) int {
+	return len(s.Keys) + len(s.VRFKeys) + len(s.OCRKeyBundles) + len(s.P2PKeys)
+}
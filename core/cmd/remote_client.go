@@ -10,13 +10,16 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/smartcontractkit/chainlink/core/web"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/manyminds/api2go/jsonapi"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
@@ -83,6 +86,7 @@ func (cli *Client) CreateExternalInitiator(c *clipkg.Context) (err error) {
 
 	var request models.ExternalInitiatorRequest
 	request.Name = c.Args().Get(0)
+	request.ProtocolVersion = int32(c.Int("protocol-version"))
 
 	// process optional URL
 	if c.NArg() == 2 {
@@ -115,6 +119,35 @@ func (cli *Client) CreateExternalInitiator(c *clipkg.Context) (err error) {
 	return err
 }
 
+// RotateExternalInitiatorKeys replaces the signing keys of an external
+// initiator, printing the new ones.
+func (cli *Client) RotateExternalInitiatorKeys(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the name of the external initiator"))
+	}
+	overlap, err := models.MakeDuration(c.Duration("overlap"))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	requestData, err := json.Marshal(models.ExternalInitiatorKeyRotationRequest{Overlap: &overlap})
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/external_initiators/"+c.Args().First()+"/keys", bytes.NewBuffer(requestData))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var ei presenters.ExternalInitiatorAuthentication
+	return cli.renderAPIResponse(resp, &ei)
+}
+
 // DeleteExternalInitiator removes an external initiator
 func (cli *Client) DeleteExternalInitiator(c *clipkg.Context) (err error) {
 	if !c.Args().Present() {
@@ -130,16 +163,736 @@ func (cli *Client) DeleteExternalInitiator(c *clipkg.Context) (err error) {
 			err = multierr.Append(err, cerr)
 		}
 	}()
-	_, err = cli.parseResponse(resp)
-	return err
+	_, err = cli.parseResponse(resp)
+	return err
+}
+
+// ShowJobRun returns the status of the given Jobrun.
+func (cli *Client) ShowJobRun(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the RunID to show"))
+	}
+	resp, err := cli.HTTP.Get("/v2/runs/" + c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	var job presenters.JobRun
+	err = cli.renderAPIResponse(resp, &job)
+	return err
+}
+
+// IndexJobRuns returns the list of all job runs for a specific job
+// if no jobid is passed, defaults to returning all jobruns
+func (cli *Client) IndexJobRuns(c *clipkg.Context) error {
+	jobID := c.String("jobid")
+	if jobID != "" {
+		return cli.getPage("/v2/runs?jobSpecId="+jobID, c.Int("page"), &[]presenters.JobRun{})
+	}
+	return cli.getPage("/v2/runs", c.Int("page"), &[]presenters.JobRun{})
+}
+
+// ExportJobSpec saves a job and its state, for importing on another node,
+// to the file given by --output, or prints it. With --spec only the job's
+// canonical spec is exported.
+func (cli *Client) ExportJobSpec(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the job id to be exported"))
+	}
+	path := "/v2/specs/" + c.Args().First() + "/export"
+	if c.Bool("spec") {
+		path += "?format=spec"
+	}
+	resp, err := cli.HTTP.Get(path)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	b, err := parseResponse(resp)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	if output := c.String("output"); output != "" {
+		return cli.errorOut(ioutil.WriteFile(output, b, 0600))
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// GenerateJobSpec prints the spec generated for a common pattern of job from
+// the pattern's parameters, given as JSON or a filepath, without creating
+// the job.
+func (cli *Client) GenerateJobSpec(c *clipkg.Context) (err error) {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("Must pass the pattern, and its parameters as JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJSON(c.Args().Get(1))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	resp, err := cli.HTTP.Post("/v2/job_spec_generators/"+c.Args().First(), buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	b, err := parseResponse(resp)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	if output := c.String("output"); output != "" {
+		return cli.errorOut(ioutil.WriteFile(output, b, 0600))
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// ImportJobSpec creates a job exported from another node, along with its
+// state.
+func (cli *Client) ImportJobSpec(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJSON(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	path := "/v2/job_imports"
+	if c.Bool("stopped") {
+		path += "?start=false"
+	}
+
+	resp, err := cli.HTTP.Post(path, buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var js presenters.JobSpec
+	err = cli.renderAPIResponse(resp, &js)
+	return err
+}
+
+// ShowJobSpec returns the status of the given JobID.
+func (cli *Client) ShowJobSpec(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the job id to be shown"))
+	}
+	resp, err := cli.HTTP.Get("/v2/specs/" + c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	var job presenters.JobSpec
+	err = cli.renderAPIResponse(resp, &job)
+	return err
+}
+
+// IndexJobSpecs returns all job specs.
+func (cli *Client) IndexJobSpecs(c *clipkg.Context) error {
+	return cli.getPage("/v2/specs", c.Int("page"), &[]models.JobSpec{})
+}
+
+// CreateJobSpec creates a JobSpec based on JSON input
+func (cli *Client) CreateJobSpec(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJSON(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	path := "/v2/specs"
+	if c.Bool("stopped") {
+		path += "?start=false"
+	}
+
+	resp, err := cli.HTTP.Post(path, buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var js presenters.JobSpec
+	err = cli.renderAPIResponse(resp, &js)
+	return err
+}
+
+// UpdateJobSpec replaces the spec of an existing job with one based on JSON
+// input, keeping the job's ID and runs.
+func (cli *Client) UpdateJobSpec(c *clipkg.Context) (err error) {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("Must pass the job id and JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJSON(c.Args().Get(1))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Patch("/v2/specs/"+c.Args().First(), buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var js presenters.JobSpec
+	err = cli.renderAPIResponse(resp, &js)
+	return err
+}
+
+// PreviewJobSpec performs the tasks of a JobSpec based on JSON input once,
+// and prints the result of each task. The HTTP responses received may be
+// recorded to a file, and replayed from it by later previews.
+func (cli *Client) PreviewJobSpec(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJSON(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	recordFile, replayFile := c.String("record-http"), c.String("replay-http")
+	if recordFile != "" || replayFile != "" {
+		request := map[string]interface{}{}
+		if err = json.Unmarshal(buf.Bytes(), &request); err != nil {
+			return cli.errorOut(err)
+		}
+		request["recordHTTP"] = recordFile != ""
+		if replayFile != "" {
+			fixtures, ferr := fromFile(replayFile)
+			if ferr != nil {
+				return cli.errorOut(fmt.Errorf("error reading from file '%s': %v", replayFile, ferr))
+			}
+			request["httpFixtures"] = json.RawMessage(fixtures.Bytes())
+		}
+		b, merr := json.Marshal(request)
+		if merr != nil {
+			return cli.errorOut(merr)
+		}
+		buf = bytes.NewBuffer(b)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/job_spec_previews", buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	b, err := parseResponse(resp)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	fmt.Println(string(b))
+
+	if recordFile != "" {
+		preview := presenters.JobPreview{HTTPFixtures: []models.HTTPFixture{}}
+		if err = web.ParseJSONAPIResponse(b, &preview); err != nil {
+			return cli.errorOut(err)
+		}
+		fixtures, merr := json.MarshalIndent(preview.HTTPFixtures, "", "  ")
+		if merr != nil {
+			return cli.errorOut(merr)
+		}
+		if err = ioutil.WriteFile(recordFile, fixtures, 0600); err != nil {
+			return cli.errorOut(err)
+		}
+	}
+	return nil
+}
+
+// StartJobSpec starts a job which was created in the stopped state.
+func (cli *Client) StartJobSpec(c *clipkg.Context) error {
+	return cli.changeJobSpecStatus(c, "start", "started")
+}
+
+// PauseJobSpec stops triggering runs for a job until it is resumed.
+func (cli *Client) PauseJobSpec(c *clipkg.Context) error {
+	return cli.changeJobSpecStatus(c, "pause", "paused")
+}
+
+// ResumeJobSpec starts triggering runs for a paused job again.
+func (cli *Client) ResumeJobSpec(c *clipkg.Context) error {
+	return cli.changeJobSpecStatus(c, "resume", "resumed")
+}
+
+func (cli *Client) changeJobSpecStatus(c *clipkg.Context, action, done string) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(fmt.Errorf("Must pass the job id to be %s", done))
+	}
+	resp, err := cli.HTTP.Post("/v2/specs/"+c.Args().First()+"/"+action, nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	var js presenters.JobSpec
+	err = cli.renderAPIResponse(resp, &js)
+	return err
+}
+
+// ListJobSpecVersions prints every version of a job's spec, latest first.
+func (cli *Client) ListJobSpecVersions(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the job id"))
+	}
+	resp, err := cli.HTTP.Get("/v2/specs/" + c.Args().First() + "/versions")
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.printResponseBody(resp)
+}
+
+// RollbackJobSpec replaces a job's spec with an earlier version of it.
+func (cli *Client) RollbackJobSpec(c *clipkg.Context) (err error) {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("rollback expects two arguments: the job's ID and the version to roll back to"))
+	}
+	resp, err := cli.HTTP.Post("/v2/specs/"+c.Args().First()+"/versions/"+c.Args().Get(1)+"/rollback", nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	var js presenters.JobSpec
+	return cli.renderAPIResponse(resp, &js)
+}
+
+// RotateWebhookSecret gives each webhook initiator of a job, or only the one
+// given, a new secret, keeping the previous secrets valid for the overlap
+// given.
+func (cli *Client) RotateWebhookSecret(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the job id"))
+	}
+	overlap, err := models.MakeDuration(c.Duration("overlap"))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	request := models.WebhookSecretRotationRequest{Overlap: &overlap}
+	if c.IsSet("initiator") {
+		initiatorID := c.Int64("initiator")
+		request.InitiatorID = &initiatorID
+	}
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/specs/"+c.Args().First()+"/webhook_secret", bytes.NewBuffer(requestData))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.printResponseBody(resp)
+}
+
+// ArchiveJobSpec soft deletes a job and its associated runs.
+func (cli *Client) ArchiveJobSpec(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the job id to be archived"))
+	}
+	resp, err := cli.HTTP.Delete("/v2/specs/" + c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	_, err = cli.parseResponse(resp)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	return nil
+}
+
+// CreateJobRun creates job run based on SpecID and optional JSON
+func (cli *Client) CreateJobRun(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in SpecID [JSON blob | JSON filepath]"))
+	}
+
+	buf := bytes.NewBufferString("")
+	if c.NArg() > 1 {
+		var jbuf *bytes.Buffer
+		jbuf, err = getBufferFromJSON(c.Args().Get(1))
+		if err != nil {
+			return cli.errorOut(err)
+		}
+		buf = jbuf
+	}
+
+	path := "/v2/specs/" + c.Args().First() + "/runs"
+	if c.Bool("profile") {
+		path += "?profile=true"
+	}
+	resp, err := cli.HTTP.Post(path, buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	var run presenters.JobRun
+	err = cli.renderAPIResponse(resp, &run)
+	return err
+}
+
+// CreateBridge adds a new bridge to the chainlink node
+func (cli *Client) CreateBridge(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in the bridge's parameters [JSON blob | JSON filepath]"))
+	}
+
+	buf, err := getBufferFromJSON(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/bridge_types", buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var bridge models.BridgeTypeAuthentication
+	err = cli.renderAPIResponse(resp, &bridge)
+	return err
+}
+
+// IndexBridges returns all bridges.
+func (cli *Client) IndexBridges(c *clipkg.Context) (err error) {
+	return cli.getPage("/v2/bridge_types", c.Int("page"), &[]models.BridgeType{})
+}
+
+func (cli *Client) getPage(requestURI string, page int, model interface{}) (err error) {
+	uri, err := url.Parse(requestURI)
+	if err != nil {
+		return err
+	}
+	q := uri.Query()
+	if page > 0 {
+		q.Set("page", strconv.Itoa(page))
+	}
+	uri.RawQuery = q.Encode()
+
+	resp, err := cli.HTTP.Get(uri.String())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	err = cli.deserializeAPIResponse(resp, model, &jsonapi.Links{})
+	if err != nil {
+		return err
+	}
+	err = cli.errorOut(cli.Render(model))
+	return err
+}
+
+// ShowBridge returns the info for the given Bridge name.
+func (cli *Client) ShowBridge(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the name of the bridge to be shown"))
+	}
+	bridgeName := c.Args().First()
+	resp, err := cli.HTTP.Get("/v2/bridge_types/" + bridgeName)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	var bridge models.BridgeType
+	return cli.renderAPIResponse(resp, &bridge)
+}
+
+// RemoveBridge removes a specific Bridge by name.
+func (cli *Client) RemoveBridge(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the name of the bridge to be removed"))
+	}
+	bridgeName := c.Args().First()
+	resp, err := cli.HTTP.Delete("/v2/bridge_types/" + bridgeName)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	var bridge models.BridgeType
+	err = cli.renderAPIResponse(resp, &bridge)
+	return err
+}
+
+// RemoteLogin creates a cookie session to run remote commands.
+func (cli *Client) RemoteLogin(c *clipkg.Context) error {
+	sessionRequest, err := cli.buildSessionRequest(c.String("file"))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	_, err = cli.CookieAuthenticator.Authenticate(sessionRequest)
+	return cli.errorOut(err)
+}
+
+// SendEther transfers ETH from the node's account to a specified address.
+func (cli *Client) SendEther(c *clipkg.Context) (err error) {
+	if c.NArg() < 3 {
+		return cli.errorOut(errors.New("sendether expects three arguments: amount, fromAddress and toAddress"))
+	}
+
+	amount, err := assets.NewEthValueS(c.Args().Get(0))
+	if err != nil {
+		return cli.errorOut(multierr.Combine(
+			errors.New("while parsing ETH transfer amount"), err))
+	}
+
+	unparsedFromAddress := c.Args().Get(1)
+	fromAddress, err := utils.ParseEthereumAddress(unparsedFromAddress)
+	if err != nil {
+		return cli.errorOut(multierr.Combine(
+			fmt.Errorf("while parsing withdrawal source address %v",
+				unparsedFromAddress), err))
+	}
+
+	unparsedDestinationAddress := c.Args().Get(2)
+	destinationAddress, err := utils.ParseEthereumAddress(unparsedDestinationAddress)
+	if err != nil {
+		return cli.errorOut(multierr.Combine(
+			fmt.Errorf("while parsing withdrawal destination address %v",
+				unparsedDestinationAddress), err))
+	}
+
+	request := models.SendEtherRequest{
+		DestinationAddress: destinationAddress,
+		FromAddress:        fromAddress,
+		Amount:             amount,
+	}
+
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	buf := bytes.NewBuffer(requestData)
+
+	resp, err := cli.HTTP.Post("/v2/transfers", buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	err = cli.printResponseBody(resp)
+	return err
+}
+
+// CreateRegistration prints the transactions which register the node's
+// accounts with a contract, and sends them from the node when --submit is
+// given.
+func (cli *Client) CreateRegistration(c *clipkg.Context) (err error) {
+	if c.NArg() < 2 {
+		return cli.errorOut(errors.New("register expects two arguments: method and contract address"))
+	}
+
+	request := models.RegistrationRequest{
+		Method: c.Args().Get(0),
+		Submit: c.Bool("submit"),
+	}
+	addresses := []struct {
+		name  string
+		value string
+		dest  *common.Address
+	}{
+		{"contract", c.Args().Get(1), &request.Contract},
+		{"oracle", c.String("oracle"), &request.Oracle},
+		{"new admin", c.String("newAdmin"), &request.NewAdmin},
+		{"from", c.String("from"), &request.FromAddress},
+	}
+	for _, a := range addresses {
+		if a.value == "" {
+			continue
+		}
+		if *a.dest, err = utils.ParseEthereumAddress(a.value); err != nil {
+			return cli.errorOut(multierr.Combine(
+				fmt.Errorf("while parsing %s address %v", a.name, a.value), err))
+		}
+	}
+	for _, s := range c.StringSlice("senders") {
+		sender, err := utils.ParseEthereumAddress(s)
+		if err != nil {
+			return cli.errorOut(multierr.Combine(
+				fmt.Errorf("while parsing sender address %v", s), err))
+		}
+		request.Senders = append(request.Senders, sender)
+	}
+
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/registrations", bytes.NewBuffer(requestData))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.printResponseBody(resp)
+}
+
+// IndexFundsSweeps lists every requested funds sweep.
+func (cli *Client) IndexFundsSweeps(c *clipkg.Context) (err error) {
+	resp, err := cli.HTTP.Get("/v2/funds_sweeps")
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.printResponseBody(resp)
+}
+
+// CreateNamespace adds a namespace to the node.
+func (cli *Client) CreateNamespace(c *clipkg.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.errorOut(errors.New("create expects one argument: the name of the namespace"))
+	}
+	requestData, err := json.Marshal(models.NamespaceRequest{
+		Name:            c.Args().First(),
+		NamespaceQuotas: namespaceQuotasFromFlags(c),
+	})
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/namespaces", bytes.NewBuffer(requestData))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.printResponseBody(resp)
+}
+
+// UpdateNamespaceQuotas replaces the quotas of a namespace with those given
+// as flags. Quotas which are not given are removed.
+func (cli *Client) UpdateNamespaceQuotas(c *clipkg.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.errorOut(errors.New("setquotas expects one argument: the name of the namespace"))
+	}
+	requestData, err := json.Marshal(namespaceQuotasFromFlags(c))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Patch("/v2/namespaces/"+c.Args().First(), bytes.NewBuffer(requestData))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.printResponseBody(resp)
 }
 
-// ShowJobRun returns the status of the given Jobrun.
-func (cli *Client) ShowJobRun(c *clipkg.Context) (err error) {
-	if !c.Args().Present() {
-		return cli.errorOut(errors.New("Must pass the RunID to show"))
+// UpdateNamespaceSpecPolicy replaces the policy the jobs of a namespace must
+// satisfy with the policy given as a JSON string or path to a JSON file.
+func (cli *Client) UpdateNamespaceSpecPolicy(c *clipkg.Context) (err error) {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("setpolicy expects two arguments: the name of the namespace and the policy as JSON or a path to a JSON file"))
 	}
-	resp, err := cli.HTTP.Get("/v2/runs/" + c.Args().First())
+	buf, err := getBufferFromJSON(c.Args().Get(1))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Put("/v2/namespaces/"+c.Args().First()+"/spec_policy", buf)
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -148,27 +901,27 @@ func (cli *Client) ShowJobRun(c *clipkg.Context) (err error) {
 			err = multierr.Append(err, cerr)
 		}
 	}()
-	var job presenters.JobRun
-	err = cli.renderAPIResponse(resp, &job)
-	return err
+
+	return cli.printResponseBody(resp)
 }
 
-// IndexJobRuns returns the list of all job runs for a specific job
-// if no jobid is passed, defaults to returning all jobruns
-func (cli *Client) IndexJobRuns(c *clipkg.Context) error {
-	jobID := c.String("jobid")
-	if jobID != "" {
-		return cli.getPage("/v2/runs?jobSpecId="+jobID, c.Int("page"), &[]presenters.JobRun{})
+func namespaceQuotasFromFlags(c *clipkg.Context) models.NamespaceQuotas {
+	var quotas models.NamespaceQuotas
+	if c.IsSet("max-jobs") {
+		quotas.MaxJobs = clnull.Int64From(c.Int64("max-jobs"))
 	}
-	return cli.getPage("/v2/runs", c.Int("page"), &[]presenters.JobRun{})
+	if c.IsSet("max-runs-per-minute") {
+		quotas.MaxRunsPerMinute = clnull.Int64From(c.Int64("max-runs-per-minute"))
+	}
+	if c.IsSet("max-gas-per-day") {
+		quotas.MaxGasPerDay = clnull.Int64From(c.Int64("max-gas-per-day"))
+	}
+	return quotas
 }
 
-// ShowJobSpec returns the status of the given JobID.
-func (cli *Client) ShowJobSpec(c *clipkg.Context) (err error) {
-	if !c.Args().Present() {
-		return cli.errorOut(errors.New("Must pass the job id to be shown"))
-	}
-	resp, err := cli.HTTP.Get("/v2/specs/" + c.Args().First())
+// ListNamespaces lists the node's namespaces.
+func (cli *Client) ListNamespaces(c *clipkg.Context) (err error) {
+	resp, err := cli.HTTP.Get("/v2/namespaces")
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -177,28 +930,25 @@ func (cli *Client) ShowJobSpec(c *clipkg.Context) (err error) {
 			err = multierr.Append(err, cerr)
 		}
 	}()
-	var job presenters.JobSpec
-	err = cli.renderAPIResponse(resp, &job)
-	return err
-}
 
-// IndexJobSpecs returns all job specs.
-func (cli *Client) IndexJobSpecs(c *clipkg.Context) error {
-	return cli.getPage("/v2/specs", c.Int("page"), &[]models.JobSpec{})
+	return cli.printResponseBody(resp)
 }
 
-// CreateJobSpec creates a JobSpec based on JSON input
-func (cli *Client) CreateJobSpec(c *clipkg.Context) (err error) {
-	if !c.Args().Present() {
-		return cli.errorOut(errors.New("Must pass in JSON or filepath"))
+// AddNamespaceKey moves one of the node's keys into a namespace.
+func (cli *Client) AddNamespaceKey(c *clipkg.Context) (err error) {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("addkey expects two arguments: the namespace and the key's address"))
 	}
-
-	buf, err := getBufferFromJSON(c.Args().First())
+	address, err := models.NewEIP55Address(c.Args().Get(1))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	requestData, err := json.Marshal(models.NamespaceKeyRequest{Address: address})
 	if err != nil {
 		return cli.errorOut(err)
 	}
 
-	resp, err := cli.HTTP.Post("/v2/specs", buf)
+	resp, err := cli.HTTP.Post("/v2/namespaces/"+c.Args().First()+"/keys", bytes.NewBuffer(requestData))
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -208,44 +958,48 @@ func (cli *Client) CreateJobSpec(c *clipkg.Context) (err error) {
 		}
 	}()
 
-	var js presenters.JobSpec
-	err = cli.renderAPIResponse(resp, &js)
-	return err
+	return cli.printResponseBody(resp)
 }
 
-// ArchiveJobSpec soft deletes a job and its associated runs.
-func (cli *Client) ArchiveJobSpec(c *clipkg.Context) error {
-	if !c.Args().Present() {
-		return cli.errorOut(errors.New("Must pass the job id to be archived"))
+// CreateWorkerGroup adds a worker group with the concurrency given by the
+// --concurrency flag.
+func (cli *Client) CreateWorkerGroup(c *clipkg.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.errorOut(errors.New("create expects one argument: the name of the worker group"))
 	}
-	resp, err := cli.HTTP.Delete("/v2/specs/" + c.Args().First())
+	requestData, err := json.Marshal(models.WorkerGroupRequest{
+		Name:        c.Args().First(),
+		Concurrency: c.Int("concurrency"),
+	})
 	if err != nil {
 		return cli.errorOut(err)
 	}
-	_, err = cli.parseResponse(resp)
+
+	resp, err := cli.HTTP.Post("/v2/worker_groups", bytes.NewBuffer(requestData))
 	if err != nil {
 		return cli.errorOut(err)
 	}
-	return nil
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.printResponseBody(resp)
 }
 
-// CreateJobRun creates job run based on SpecID and optional JSON
-func (cli *Client) CreateJobRun(c *clipkg.Context) (err error) {
-	if !c.Args().Present() {
-		return cli.errorOut(errors.New("Must pass in SpecID [JSON blob | JSON filepath]"))
+// UpdateWorkerGroup changes the concurrency of a worker group to that given
+// by the --concurrency flag.
+func (cli *Client) UpdateWorkerGroup(c *clipkg.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.errorOut(errors.New("update expects one argument: the name of the worker group"))
 	}
-
-	buf := bytes.NewBufferString("")
-	if c.NArg() > 1 {
-		var jbuf *bytes.Buffer
-		jbuf, err = getBufferFromJSON(c.Args().Get(1))
-		if err != nil {
-			return cli.errorOut(err)
-		}
-		buf = jbuf
+	requestData, err := json.Marshal(models.WorkerGroupRequest{Concurrency: c.Int("concurrency")})
+	if err != nil {
+		return cli.errorOut(err)
 	}
 
-	resp, err := cli.HTTP.Post("/v2/specs/"+c.Args().First()+"/runs", buf)
+	resp, err := cli.HTTP.Patch("/v2/worker_groups/"+c.Args().First(), bytes.NewBuffer(requestData))
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -254,23 +1008,50 @@ func (cli *Client) CreateJobRun(c *clipkg.Context) (err error) {
 			err = multierr.Append(err, cerr)
 		}
 	}()
-	var run presenters.JobRun
-	err = cli.renderAPIResponse(resp, &run)
-	return err
+
+	return cli.printResponseBody(resp)
 }
 
-// CreateBridge adds a new bridge to the chainlink node
-func (cli *Client) CreateBridge(c *clipkg.Context) (err error) {
-	if !c.Args().Present() {
-		return cli.errorOut(errors.New("Must pass in the bridge's parameters [JSON blob | JSON filepath]"))
+// ListWorkerGroups prints the worker groups and their jobs.
+func (cli *Client) ListWorkerGroups(c *clipkg.Context) (err error) {
+	resp, err := cli.HTTP.Get("/v2/worker_groups")
+	if err != nil {
+		return cli.errorOut(err)
 	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
 
-	buf, err := getBufferFromJSON(c.Args().First())
+	return cli.printResponseBody(resp)
+}
+
+// DeleteWorkerGroup removes a worker group.
+func (cli *Client) DeleteWorkerGroup(c *clipkg.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.errorOut(errors.New("delete expects one argument: the name of the worker group"))
+	}
+	resp, err := cli.HTTP.Delete("/v2/worker_groups/" + c.Args().First())
 	if err != nil {
 		return cli.errorOut(err)
 	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
 
-	resp, err := cli.HTTP.Post("/v2/bridge_types", buf)
+	_, err = cli.parseResponse(resp)
+	return cli.errorOut(err)
+}
+
+// AddWorkerGroupJob moves a job into a worker group.
+func (cli *Client) AddWorkerGroupJob(c *clipkg.Context) (err error) {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("addjob expects two arguments: the worker group and the job's ID"))
+	}
+	resp, err := cli.HTTP.Put("/v2/worker_groups/"+c.Args().First()+"/specs/"+c.Args().Get(1), nil)
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -280,28 +1061,45 @@ func (cli *Client) CreateBridge(c *clipkg.Context) (err error) {
 		}
 	}()
 
-	var bridge models.BridgeTypeAuthentication
-	err = cli.renderAPIResponse(resp, &bridge)
-	return err
+	return cli.printResponseBody(resp)
 }
 
-// IndexBridges returns all bridges.
-func (cli *Client) IndexBridges(c *clipkg.Context) (err error) {
-	return cli.getPage("/v2/bridge_types", c.Int("page"), &[]models.BridgeType{})
+// RemoveWorkerGroupJob moves a job out of a worker group.
+func (cli *Client) RemoveWorkerGroupJob(c *clipkg.Context) (err error) {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("removejob expects two arguments: the worker group and the job's ID"))
+	}
+	resp, err := cli.HTTP.Delete("/v2/worker_groups/" + c.Args().First() + "/specs/" + c.Args().Get(1))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.printResponseBody(resp)
 }
 
-func (cli *Client) getPage(requestURI string, page int, model interface{}) (err error) {
-	uri, err := url.Parse(requestURI)
+// CreateJobSpecTemplate adds a template which jobs can be created from.
+func (cli *Client) CreateJobSpecTemplate(c *clipkg.Context) (err error) {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("create expects two arguments: the name of the template and its JSON or filepath"))
+	}
+	buf, err := getBufferFromJSON(c.Args().Get(1))
 	if err != nil {
-		return err
+		return cli.errorOut(err)
 	}
-	q := uri.Query()
-	if page > 0 {
-		q.Set("page", strconv.Itoa(page))
+	requestData, err := json.Marshal(models.JobSpecTemplateRequest{
+		Name:     c.Args().First(),
+		Template: buf.String(),
+	})
+	if err != nil {
+		return cli.errorOut(err)
 	}
-	uri.RawQuery = q.Encode()
 
-	resp, err := cli.HTTP.Get(uri.String())
+	resp, err := cli.HTTP.Post("/v2/job_spec_templates", bytes.NewBuffer(requestData))
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -311,21 +1109,12 @@ func (cli *Client) getPage(requestURI string, page int, model interface{}) (err
 		}
 	}()
 
-	err = cli.deserializeAPIResponse(resp, model, &jsonapi.Links{})
-	if err != nil {
-		return err
-	}
-	err = cli.errorOut(cli.Render(model))
-	return err
+	return cli.printResponseBody(resp)
 }
 
-// ShowBridge returns the info for the given Bridge name.
-func (cli *Client) ShowBridge(c *clipkg.Context) (err error) {
-	if !c.Args().Present() {
-		return cli.errorOut(errors.New("Must pass the name of the bridge to be shown"))
-	}
-	bridgeName := c.Args().First()
-	resp, err := cli.HTTP.Get("/v2/bridge_types/" + bridgeName)
+// ListJobSpecTemplates prints the templates jobs can be created from.
+func (cli *Client) ListJobSpecTemplates(c *clipkg.Context) (err error) {
+	resp, err := cli.HTTP.Get("/v2/job_spec_templates")
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -334,17 +1123,16 @@ func (cli *Client) ShowBridge(c *clipkg.Context) (err error) {
 			err = multierr.Append(err, cerr)
 		}
 	}()
-	var bridge models.BridgeType
-	return cli.renderAPIResponse(resp, &bridge)
+
+	return cli.printResponseBody(resp)
 }
 
-// RemoveBridge removes a specific Bridge by name.
-func (cli *Client) RemoveBridge(c *clipkg.Context) (err error) {
-	if !c.Args().Present() {
-		return cli.errorOut(errors.New("Must pass the name of the bridge to be removed"))
+// DeleteJobSpecTemplate removes a template.
+func (cli *Client) DeleteJobSpecTemplate(c *clipkg.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.errorOut(errors.New("delete expects one argument: the name of the template"))
 	}
-	bridgeName := c.Args().First()
-	resp, err := cli.HTTP.Delete("/v2/bridge_types/" + bridgeName)
+	resp, err := cli.HTTP.Delete("/v2/job_spec_templates/" + c.Args().First())
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -353,63 +1141,113 @@ func (cli *Client) RemoveBridge(c *clipkg.Context) (err error) {
 			err = multierr.Append(err, cerr)
 		}
 	}()
-	var bridge models.BridgeType
-	err = cli.renderAPIResponse(resp, &bridge)
-	return err
+
+	_, err = cli.parseResponse(resp)
+	return cli.errorOut(err)
 }
 
-// RemoteLogin creates a cookie session to run remote commands.
-func (cli *Client) RemoteLogin(c *clipkg.Context) error {
-	sessionRequest, err := cli.buildSessionRequest(c.String("file"))
+// CreateJobSpecFromTemplate creates a job from a template, with the values
+// of its variables given as --var name=value flags.
+func (cli *Client) CreateJobSpecFromTemplate(c *clipkg.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.errorOut(errors.New("use expects one argument: the name of the template"))
+	}
+	request := models.JobSpecFromTemplateRequest{Variables: map[string]string{}}
+	for _, v := range c.StringSlice("var") {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			return cli.errorOut(fmt.Errorf("invalid --var %q, must be name=value", v))
+		}
+		request.Variables[parts[0]] = parts[1]
+	}
+	requestData, err := json.Marshal(request)
 	if err != nil {
 		return cli.errorOut(err)
 	}
-	_, err = cli.CookieAuthenticator.Authenticate(sessionRequest)
-	return cli.errorOut(err)
-}
 
-// SendEther transfers ETH from the node's account to a specified address.
-func (cli *Client) SendEther(c *clipkg.Context) (err error) {
-	if c.NArg() < 3 {
-		return cli.errorOut(errors.New("sendether expects three arguments: amount, fromAddress and toAddress"))
+	path := "/v2/job_spec_templates/" + c.Args().First() + "/specs"
+	if c.Bool("stopped") {
+		path += "?start=false"
 	}
+	resp, err := cli.HTTP.Post(path, bytes.NewBuffer(requestData))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
 
-	amount, err := assets.NewEthValueS(c.Args().Get(0))
+	var js presenters.JobSpec
+	return cli.renderAPIResponse(resp, &js)
+}
+
+// CreateFundsSweep requests that the funds of one of the node's accounts be
+// swept to the configured treasury address.
+func (cli *Client) CreateFundsSweep(c *clipkg.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.errorOut(errors.New("create expects one argument: the address to sweep"))
+	}
+	address, err := utils.ParseEthereumAddress(c.Args().First())
 	if err != nil {
 		return cli.errorOut(multierr.Combine(
-			errors.New("while parsing ETH transfer amount"), err))
+			fmt.Errorf("while parsing sweep address %v", c.Args().First()), err))
 	}
 
-	unparsedFromAddress := c.Args().Get(1)
-	fromAddress, err := utils.ParseEthereumAddress(unparsedFromAddress)
+	requestData, err := json.Marshal(models.FundsSweepRequest{
+		Address: address,
+		Reason:  c.String("reason"),
+	})
 	if err != nil {
-		return cli.errorOut(multierr.Combine(
-			fmt.Errorf("while parsing withdrawal source address %v",
-				unparsedFromAddress), err))
+		return cli.errorOut(err)
 	}
 
-	unparsedDestinationAddress := c.Args().Get(2)
-	destinationAddress, err := utils.ParseEthereumAddress(unparsedDestinationAddress)
+	resp, err := cli.HTTP.Post("/v2/funds_sweeps", bytes.NewBuffer(requestData))
 	if err != nil {
-		return cli.errorOut(multierr.Combine(
-			fmt.Errorf("while parsing withdrawal destination address %v",
-				unparsedDestinationAddress), err))
+		return cli.errorOut(err)
 	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
 
-	request := models.SendEtherRequest{
-		DestinationAddress: destinationAddress,
-		FromAddress:        fromAddress,
-		Amount:             amount,
+	return cli.printResponseBody(resp)
+}
+
+// ApproveFundsSweep prompts for the user's password and approves a pending
+// funds sweep.
+func (cli *Client) ApproveFundsSweep(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the id of the funds sweep to approve"))
+	}
+	requestData, err := json.Marshal(models.FundsSweepReviewRequest{
+		Password: cli.PasswordPrompter.Prompt(),
+	})
+	if err != nil {
+		return cli.errorOut(err)
 	}
 
-	requestData, err := json.Marshal(request)
+	resp, err := cli.HTTP.Post("/v2/funds_sweeps/"+c.Args().First()+"/approve", bytes.NewBuffer(requestData))
 	if err != nil {
 		return cli.errorOut(err)
 	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
 
-	buf := bytes.NewBuffer(requestData)
+	return cli.printResponseBody(resp)
+}
 
-	resp, err := cli.HTTP.Post("/v2/transfers", buf)
+// RejectFundsSweep rejects a pending funds sweep.
+func (cli *Client) RejectFundsSweep(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the id of the funds sweep to reject"))
+	}
+	resp, err := cli.HTTP.Post("/v2/funds_sweeps/"+c.Args().First()+"/reject", nil)
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -419,8 +1257,7 @@ func (cli *Client) SendEther(c *clipkg.Context) (err error) {
 		}
 	}()
 
-	err = cli.printResponseBody(resp)
-	return err
+	return cli.printResponseBody(resp)
 }
 
 // ChangePassword prompts the user for the old password and a new one, then
@@ -683,3 +1520,28 @@ func (cli *Client) CancelJobRun(c *clipkg.Context) error {
 	}
 	return nil
 }
+
+// ReplayJobRun resumes an errored run from the task that errored, reusing the
+// results of the tasks before it.
+func (cli *Client) ReplayJobRun(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the run id to be replayed"))
+	}
+
+	path := fmt.Sprintf("/v2/runs/%s/replay", c.Args().First())
+	if c.Bool("confirm-transactions") {
+		path += "?confirmTransactions=true"
+	}
+	resp, err := cli.HTTP.Put(path, nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	var run presenters.JobRun
+	err = cli.renderAPIResponse(resp, &run)
+	return err
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/pkg/errors"
+	clipkg "github.com/urfave/cli"
+	"go.uber.org/multierr"
+)
+
+// defaultLoadTestSpec is the job run when no --spec is given. It does no
+// work, so measures the overhead of the node's run pipeline alone.
+const defaultLoadTestSpec = `{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]}`
+
+// loadTestPollInterval is how often the runs started by a load test are
+// checked for completion.
+const loadTestPollInterval = time.Second
+
+// LoadTestReport is the outcome of a load test. Latencies are from the
+// creation of a run to its completion, as recorded by the node. The
+// database growth and ethereum calls are the differences between the node's
+// stats before and after the test. Cancelled runs count as errored.
+type LoadTestReport struct {
+	RunsStarted       int             `json:"runsStarted"`
+	RunsNotStarted    int             `json:"runsNotStarted"`
+	RunsCompleted     int             `json:"runsCompleted"`
+	RunsErrored       int             `json:"runsErrored"`
+	RunsUnfinished    int             `json:"runsUnfinished"`
+	Elapsed           models.Duration `json:"elapsed"`
+	RunsPerSecond     float64         `json:"runsPerSecond"`
+	LatencyP50        models.Duration `json:"latencyP50"`
+	LatencyP90        models.Duration `json:"latencyP90"`
+	LatencyP99        models.Duration `json:"latencyP99"`
+	LatencyMax        models.Duration `json:"latencyMax"`
+	DatabaseGrowth    int64           `json:"databaseGrowth"`
+	EthRPCCalls       uint64          `json:"ethRPCCalls"`
+	FirstStartFailure string          `json:"firstStartFailure,omitempty"`
+}
+
+// loadTestJob is a job created for a load test, and its share of the runs.
+type loadTestJob struct {
+	ID     string
+	Weight int
+}
+
+// LoadTest creates the jobs of the --spec flags, starts runs of them at
+// --rate runs per second for --duration, then waits up to --timeout for the
+// runs to finish and reports how the node coped. The jobs are archived
+// afterwards unless --keep is given.
+func (cli *Client) LoadTest(c *clipkg.Context) (err error) {
+	rate := c.Float64("rate")
+	if rate <= 0 {
+		return cli.errorOut(errors.New("--rate must be positive"))
+	}
+	duration := c.Duration("duration")
+	if duration <= 0 {
+		return cli.errorOut(errors.New("--duration must be positive"))
+	}
+
+	specs := c.StringSlice("spec")
+	if len(specs) == 0 {
+		specs = []string{defaultLoadTestSpec}
+	}
+	var jobs []loadTestJob
+	defer func() {
+		if c.Bool("keep") {
+			return
+		}
+		for _, job := range jobs {
+			if aerr := cli.archiveLoadTestJob(job.ID); aerr != nil && err == nil {
+				err = cli.errorOut(aerr)
+			}
+		}
+	}()
+	for _, spec := range specs {
+		job, err := cli.createLoadTestJob(spec)
+		if err != nil {
+			return cli.errorOut(err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	before, err := cli.nodeStats()
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	report := LoadTestReport{}
+	start := time.Now()
+	runIDs := cli.startLoadTestRuns(jobs, rate, duration, &report)
+	latencies := cli.awaitLoadTestRuns(runIDs, start.Add(duration).Add(c.Duration("timeout")), &report)
+	elapsed := time.Since(start)
+
+	after, err := cli.nodeStats()
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	report.Elapsed = models.MustMakeDuration(elapsed)
+	report.RunsPerSecond = float64(report.RunsCompleted) / elapsed.Seconds()
+	if len(latencies) > 0 {
+		ps := utils.DurationPercentiles(latencies, 50, 90, 99, 100)
+		report.LatencyP50 = models.MustMakeDuration(ps[0])
+		report.LatencyP90 = models.MustMakeDuration(ps[1])
+		report.LatencyP99 = models.MustMakeDuration(ps[2])
+		report.LatencyMax = models.MustMakeDuration(ps[3])
+	}
+	report.DatabaseGrowth = after.DatabaseSize - before.DatabaseSize
+	report.EthRPCCalls = after.EthRPCCalls - before.EthRPCCalls
+	return cli.errorOut(cli.Render(&report))
+}
+
+// createLoadTestJob creates the job of a --spec flag, which is JSON or the
+// path of a JSON file, optionally followed by :weight.
+func (cli *Client) createLoadTestJob(spec string) (job loadTestJob, err error) {
+	job.Weight = 1
+	if i := strings.LastIndex(spec, ":"); i > 0 {
+		if weight, err := strconv.Atoi(spec[i+1:]); err == nil {
+			if weight < 1 {
+				return job, fmt.Errorf("weight of %s must be positive", spec[:i])
+			}
+			spec, job.Weight = spec[:i], weight
+		}
+	}
+
+	buf, err := getBufferFromJSON(spec)
+	if err != nil {
+		return job, err
+	}
+	resp, err := cli.HTTP.Post("/v2/specs", buf)
+	if err != nil {
+		return job, err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var js presenters.JobSpec
+	var links jsonapi.Links
+	if err = cli.deserializeAPIResponse(resp, &js, &links); err != nil {
+		return job, err
+	}
+	job.ID = js.ID.String()
+	return job, nil
+}
+
+func (cli *Client) archiveLoadTestJob(id string) error {
+	resp, err := cli.HTTP.Delete("/v2/specs/" + id)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = cli.parseResponse(resp)
+	return err
+}
+
+// startLoadTestRuns starts runs of the jobs at rate runs per second for
+// duration, sharing them out by weight, and returns the IDs of the runs
+// which were started.
+func (cli *Client) startLoadTestRuns(jobs []loadTestJob, rate float64, duration time.Duration, report *LoadTestReport) []string {
+	var slots []string
+	for _, job := range jobs {
+		for i := 0; i < job.Weight; i++ {
+			slots = append(slots, job.ID)
+		}
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		runIDs []string
+	)
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+	deadline := time.After(duration)
+	for n := 0; ; n++ {
+		select {
+		case <-deadline:
+			wg.Wait()
+			return runIDs
+		case <-ticker.C:
+			wg.Add(1)
+			go func(jobID string) {
+				defer wg.Done()
+				id, err := cli.startLoadTestRun(jobID)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					report.RunsNotStarted++
+					if report.FirstStartFailure == "" {
+						report.FirstStartFailure = err.Error()
+					}
+					return
+				}
+				report.RunsStarted++
+				runIDs = append(runIDs, id)
+			}(slots[n%len(slots)])
+		}
+	}
+}
+
+func (cli *Client) startLoadTestRun(jobID string) (id string, err error) {
+	resp, err := cli.HTTP.Post("/v2/specs/"+jobID+"/runs", bytes.NewBufferString(""))
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var run presenters.JobRun
+	var links jsonapi.Links
+	if err = cli.deserializeAPIResponse(resp, &run, &links); err != nil {
+		return "", err
+	}
+	return run.ID.String(), nil
+}
+
+// awaitLoadTestRuns polls the runs until they have all finished or deadline
+// has passed, counting them by outcome, and returns the latencies of those
+// which completed.
+func (cli *Client) awaitLoadTestRuns(runIDs []string, deadline time.Time, report *LoadTestReport) []time.Duration {
+	var latencies []time.Duration
+	pending := runIDs
+	for len(pending) > 0 && time.Now().Before(deadline) {
+		var unfinished []string
+		for _, id := range pending {
+			run, err := cli.fetchLoadTestRun(id)
+			if err != nil || !run.GetStatus().Finished() {
+				unfinished = append(unfinished, id)
+				continue
+			}
+			if run.GetStatus().Completed() {
+				report.RunsCompleted++
+				latencies = append(latencies, run.FinishedAt.Time.Sub(run.CreatedAt))
+			} else {
+				report.RunsErrored++
+			}
+		}
+		pending = unfinished
+		if len(pending) > 0 {
+			time.Sleep(loadTestPollInterval)
+		}
+	}
+	report.RunsUnfinished = len(pending)
+	return latencies
+}
+
+func (cli *Client) fetchLoadTestRun(id string) (run presenters.JobRun, err error) {
+	resp, err := cli.HTTP.Get("/v2/runs/" + id)
+	if err != nil {
+		return run, err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var links jsonapi.Links
+	err = cli.deserializeAPIResponse(resp, &run, &links)
+	return run, err
+}
+
+func (cli *Client) nodeStats() (stats models.NodeStats, err error) {
+	resp, err := cli.HTTP.Get("/v2/stats")
+	if err != nil {
+		return stats, err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	b, err := cli.parseResponse(resp)
+	if err != nil {
+		return stats, err
+	}
+	err = json.Unmarshal(b, &stats)
+	return stats, err
+}
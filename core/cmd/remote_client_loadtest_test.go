@@ -0,0 +1,63 @@
+package cmd_test
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/cmd"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func TestClient_LoadTest(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t,
+		cltest.LenientEthMock,
+		cltest.EthMockRegisterChainID,
+		cltest.EthMockRegisterGetBalance,
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("loadtest", 0)
+	set.Float64("rate", 20, "")
+	set.Duration("duration", 250*time.Millisecond, "")
+	set.Duration("timeout", 10*time.Second, "")
+	set.Bool("keep", false, "")
+	c := cli.NewContext(nil, set, nil)
+
+	require.NoError(t, client.LoadTest(c))
+
+	require.Len(t, r.Renders, 1)
+	report := r.Renders[0].(*cmd.LoadTestReport)
+	assert.Greater(t, report.RunsStarted, 0)
+	assert.Equal(t, 0, report.RunsNotStarted)
+	assert.Equal(t, report.RunsStarted, report.RunsCompleted)
+	assert.Equal(t, 0, report.RunsUnfinished)
+	assert.Greater(t, report.RunsPerSecond, float64(0))
+	assert.LessOrEqual(t, report.LatencyP50.Duration(), report.LatencyMax.Duration())
+
+	assert.Len(t, cltest.AllJobs(t, app.Store), 0, "jobs created for the test should be archived")
+}
+
+func TestClient_LoadTest_RejectsInvalidRate(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	client, _ := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("loadtest", 0)
+	set.Float64("rate", 0, "")
+	set.Duration("duration", time.Second, "")
+	c := cli.NewContext(nil, set, nil)
+
+	assert.Error(t, client.LoadTest(c))
+}
@@ -405,6 +405,37 @@ func TestClient_CreateJobSpec(t *testing.T) {
 	}
 }
 
+func TestClient_StartJobSpec(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t,
+		cltest.LenientEthMock,
+		cltest.EthMockRegisterChainID,
+		cltest.EthMockRegisterGetBalance,
+	)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	job := cltest.NewJob()
+	job.Status = models.JobSpecStatusStopped
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("start", 0)
+	set.Parse([]string{job.ID.String()})
+	c := cli.NewContext(nil, set, nil)
+
+	require.NoError(t, client.StartJobSpec(c))
+	require.Len(t, r.Renders, 1)
+
+	job, err := app.Store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.False(t, job.Stopped())
+
+	assert.Error(t, client.StartJobSpec(c))
+}
+
 func TestClient_ArchiveJobSpec(t *testing.T) {
 	t.Parallel()
 
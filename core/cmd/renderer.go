@@ -77,6 +77,8 @@ func (rt RendererTable) Render(v interface{}) error {
 		return rt.renderConfigPatchResponse(typed)
 	case *presenters.ConfigPrinter:
 		return rt.renderConfiguration(*typed)
+	case *LoadTestReport:
+		return rt.renderLoadTestReport(*typed)
 	default:
 		return fmt.Errorf("unable to render object of type %T: %v", typed, typed)
 	}
@@ -304,7 +306,7 @@ func (rt RendererTable) renderServiceAgreement(sa presenters.ServiceAgreement) e
 }
 
 func (rt RendererTable) renderExternalInitiatorAuthentication(eia presenters.ExternalInitiatorAuthentication) error {
-	table := rt.newTable([]string{"Name", "URL", "AccessKey", "Secret", "OutgoingToken", "OutgoingSecret"})
+	table := rt.newTable([]string{"Name", "URL", "AccessKey", "Secret", "OutgoingToken", "OutgoingSecret", "ProtocolVersion", "SigningKey"})
 	table.Append([]string{
 		eia.Name,
 		eia.URL.String(),
@@ -312,6 +314,8 @@ func (rt RendererTable) renderExternalInitiatorAuthentication(eia presenters.Ext
 		eia.Secret,
 		eia.OutgoingToken,
 		eia.OutgoingSecret,
+		strconv.Itoa(int(eia.ProtocolVersion)),
+		eia.IncomingSigningKey,
 	})
 	render("External Initiator Credentials:", table)
 	return nil
@@ -379,3 +383,26 @@ func (rt RendererTable) renderConfigPatchResponse(config *web.ConfigPatchRespons
 	render("Configuration Changes", table)
 	return nil
 }
+
+func (rt RendererTable) renderLoadTestReport(report LoadTestReport) error {
+	table := rt.newTable([]string{"Measure", "Value"})
+	table.Append([]string{"Runs Started", fmt.Sprint(report.RunsStarted)})
+	table.Append([]string{"Runs Not Started", fmt.Sprint(report.RunsNotStarted)})
+	table.Append([]string{"Runs Completed", fmt.Sprint(report.RunsCompleted)})
+	table.Append([]string{"Runs Errored", fmt.Sprint(report.RunsErrored)})
+	table.Append([]string{"Runs Unfinished", fmt.Sprint(report.RunsUnfinished)})
+	table.Append([]string{"Elapsed", report.Elapsed.String()})
+	table.Append([]string{"Completed Runs Per Second", strconv.FormatFloat(report.RunsPerSecond, 'f', 2, 64)})
+	table.Append([]string{"Latency p50", report.LatencyP50.String()})
+	table.Append([]string{"Latency p90", report.LatencyP90.String()})
+	table.Append([]string{"Latency p99", report.LatencyP99.String()})
+	table.Append([]string{"Latency Max", report.LatencyMax.String()})
+	table.Append([]string{"Database Growth (bytes)", fmt.Sprint(report.DatabaseGrowth)})
+	table.Append([]string{"Ethereum RPC Calls", fmt.Sprint(report.EthRPCCalls)})
+	if report.FirstStartFailure != "" {
+		table.Append([]string{"First Start Failure", report.FirstStartFailure})
+	}
+
+	render("Load Test", table)
+	return nil
+}
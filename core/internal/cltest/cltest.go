@@ -670,15 +670,22 @@ func CreateJobSpecViaWeb(t testing.TB, app *TestApplication, job models.JobSpec)
 
 	marshaled, err := json.Marshal(&job)
 	assert.NoError(t, err)
-	return CreateSpecViaWeb(t, app, string(marshaled))
+	// A marshaled JobSpec has keys, such as its ID, which are not part of a
+	// job spec
+	return createSpecViaWeb(t, app, "/v2/specs?strict=false", string(marshaled))
 }
 
 // CreateJobSpecViaWeb creates a jobspec via web using /v2/specs
 func CreateSpecViaWeb(t testing.TB, app *TestApplication, spec string) models.JobSpec {
 	t.Helper()
+	return createSpecViaWeb(t, app, "/v2/specs", spec)
+}
+
+func createSpecViaWeb(t testing.TB, app *TestApplication, path string, spec string) models.JobSpec {
+	t.Helper()
 
 	client := app.NewHTTPClient()
-	resp, cleanup := client.Post("/v2/specs", bytes.NewBufferString(spec))
+	resp, cleanup := client.Post(path, bytes.NewBufferString(spec))
 	defer cleanup()
 	AssertServerResponse(t, resp, http.StatusOK)
 
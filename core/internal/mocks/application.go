@@ -11,9 +11,13 @@ import (
 
 	packr "github.com/gobuffalo/packr"
 
+	services "github.com/smartcontractkit/chainlink/core/services"
+
 	store "github.com/smartcontractkit/chainlink/core/store"
 
 	synchronization "github.com/smartcontractkit/chainlink/core/services/synchronization"
+
+	time "time"
 )
 
 // Application is an autogenerated mock type for the Application type
@@ -35,6 +39,20 @@ func (_m *Application) AddJob(job models.JobSpec) error {
 	return r0
 }
 
+// AddJobs provides a mock function with given fields: jobs
+func (_m *Application) AddJobs(jobs []models.JobSpec) error {
+	ret := _m.Called(jobs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.JobSpec) error); ok {
+		r0 = rf(jobs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddServiceAgreement provides a mock function with given fields: _a0
 func (_m *Application) AddServiceAgreement(_a0 *models.ServiceAgreement) error {
 	ret := _m.Called(_a0)
@@ -132,6 +150,22 @@ func (_m *Application) CreateErrored(jobSpecID *models.ID, initiator models.Init
 	return r0, r1
 }
 
+// GetRunUpdateBroadcaster provides a mock function with given fields:
+func (_m *Application) GetRunUpdateBroadcaster() services.RunUpdateBroadcaster {
+	ret := _m.Called()
+
+	var r0 services.RunUpdateBroadcaster
+	if rf, ok := ret.Get(0).(func() services.RunUpdateBroadcaster); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.RunUpdateBroadcaster)
+		}
+	}
+
+	return r0
+}
+
 // GetStatsPusher provides a mock function with given fields:
 func (_m *Application) GetStatsPusher() synchronization.StatsPusher {
 	ret := _m.Called()
@@ -178,6 +212,43 @@ func (_m *Application) NewBox() packr.Box {
 	return r0
 }
 
+// PauseJob provides a mock function with given fields: _a0
+func (_m *Application) PauseJob(_a0 *models.ID) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Replay provides a mock function with given fields: runID
+func (_m *Application) Replay(runID *models.ID) (*models.JobRun, error) {
+	ret := _m.Called(runID)
+
+	var r0 *models.JobRun
+	if rf, ok := ret.Get(0).(func(*models.ID) *models.JobRun); ok {
+		r0 = rf(runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobRun)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.ID) error); ok {
+		r1 = rf(runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResumeAllPendingNextBlock provides a mock function with given fields: currentBlockHeight
 func (_m *Application) ResumeAllPendingNextBlock(currentBlockHeight *big.Int) error {
 	ret := _m.Called(currentBlockHeight)
@@ -192,6 +263,20 @@ func (_m *Application) ResumeAllPendingNextBlock(currentBlockHeight *big.Int) er
 	return r0
 }
 
+// ResumeAllPendingSleep provides a mock function with given fields: now
+func (_m *Application) ResumeAllPendingSleep(now time.Time) error {
+	ret := _m.Called(now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumeAllPendingConnection provides a mock function with given fields:
 func (_m *Application) ResumeAllPendingConnection() error {
 	ret := _m.Called()
@@ -220,6 +305,20 @@ func (_m *Application) ResumeAllInProgress() error {
 	return r0
 }
 
+// ResumeJob provides a mock function with given fields: _a0
+func (_m *Application) ResumeJob(_a0 *models.ID) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumePendingBridge provides a mock function with given fields: runID, input
 func (_m *Application) ResumePendingBridge(runID *models.ID, input models.BridgeRunResult) error {
 	ret := _m.Called(runID, input)
@@ -234,6 +333,34 @@ func (_m *Application) ResumePendingBridge(runID *models.ID, input models.Bridge
 	return r0
 }
 
+// RestoreJob provides a mock function with given fields: _a0
+func (_m *Application) RestoreJob(_a0 *models.ID) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetryJob provides a mock function with given fields: _a0
+func (_m *Application) RetryJob(_a0 *models.ID) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Start provides a mock function with given fields:
 func (_m *Application) Start() error {
 	ret := _m.Called()
@@ -248,6 +375,20 @@ func (_m *Application) Start() error {
 	return r0
 }
 
+// StartJob provides a mock function with given fields: _a0
+func (_m *Application) StartJob(_a0 *models.ID) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Stop provides a mock function with given fields:
 func (_m *Application) Stop() error {
 	ret := _m.Called()
@@ -262,6 +403,20 @@ func (_m *Application) Stop() error {
 	return r0
 }
 
+// UpdateJob provides a mock function with given fields: _a0
+func (_m *Application) UpdateJob(_a0 models.JobSpec) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.JobSpec) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WakeSessionReaper provides a mock function with given fields:
 func (_m *Application) WakeSessionReaper() {
 	_m.Called()
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	decimal "github.com/shopspring/decimal"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,20 +15,20 @@ type Fetcher struct {
 	mock.Mock
 }
 
-// Fetch provides a mock function with given fields: _a0
-func (_m *Fetcher) Fetch(_a0 map[string]interface{}) (decimal.Decimal, error) {
-	ret := _m.Called(_a0)
+// Fetch provides a mock function with given fields: _a0, _a1
+func (_m *Fetcher) Fetch(_a0 context.Context, _a1 map[string]interface{}) (decimal.Decimal, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 decimal.Decimal
-	if rf, ok := ret.Get(0).(func(map[string]interface{}) decimal.Decimal); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}) decimal.Decimal); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Get(0).(decimal.Decimal)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]interface{}) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
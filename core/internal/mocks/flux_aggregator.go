@@ -53,6 +53,27 @@ func (_m *FluxAggregator) Call(result interface{}, methodName string, args ...in
 	return r0
 }
 
+// Decimals provides a mock function with given fields:
+func (_m *FluxAggregator) Decimals() (uint8, error) {
+	ret := _m.Called()
+
+	var r0 uint8
+	if rf, ok := ret.Get(0).(func() uint8); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint8)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EncodeMessageCall provides a mock function with given fields: method, args
 func (_m *FluxAggregator) EncodeMessageCall(method string, args ...interface{}) ([]byte, error) {
 	var _ca []interface{}
@@ -102,6 +123,27 @@ func (_m *FluxAggregator) GetMethodID(method string) ([]byte, error) {
 	return r0, r1
 }
 
+// LatestRoundData provides a mock function with given fields:
+func (_m *FluxAggregator) LatestRoundData() (contracts.FluxAggregatorRoundData, error) {
+	ret := _m.Called()
+
+	var r0 contracts.FluxAggregatorRoundData
+	if rf, ok := ret.Get(0).(func() contracts.FluxAggregatorRoundData); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(contracts.FluxAggregatorRoundData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RoundState provides a mock function with given fields: oracle, roundID
 func (_m *FluxAggregator) RoundState(oracle common.Address, roundID uint32) (contracts.FluxAggregatorRoundState, error) {
 	ret := _m.Called(oracle, roundID)
@@ -197,6 +197,75 @@ func (_m *KeyStoreInterface) SignHash(hash common.Hash) (models.Signature, error
 	return r0, r1
 }
 
+// SignHashWithAccount provides a mock function with given fields: account, hash
+func (_m *KeyStoreInterface) SignHashWithAccount(account accounts.Account, hash common.Hash) (models.Signature, error) {
+	ret := _m.Called(account, hash)
+
+	var r0 models.Signature
+	if rf, ok := ret.Get(0).(func(accounts.Account, common.Hash) models.Signature); ok {
+		r0 = rf(account, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.Signature)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(accounts.Account, common.Hash) error); ok {
+		r1 = rf(account, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignPersonalMessageWithAccount provides a mock function with given fields: account, message
+func (_m *KeyStoreInterface) SignPersonalMessageWithAccount(account accounts.Account, message []byte) (models.Signature, error) {
+	ret := _m.Called(account, message)
+
+	var r0 models.Signature
+	if rf, ok := ret.Get(0).(func(accounts.Account, []byte) models.Signature); ok {
+		r0 = rf(account, message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.Signature)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(accounts.Account, []byte) error); ok {
+		r1 = rf(account, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignTypedDataWithAccount provides a mock function with given fields: account, domainSeparator, structHash
+func (_m *KeyStoreInterface) SignTypedDataWithAccount(account accounts.Account, domainSeparator common.Hash, structHash common.Hash) (models.Signature, error) {
+	ret := _m.Called(account, domainSeparator, structHash)
+
+	var r0 models.Signature
+	if rf, ok := ret.Get(0).(func(accounts.Account, common.Hash, common.Hash) models.Signature); ok {
+		r0 = rf(account, domainSeparator, structHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.Signature)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(accounts.Account, common.Hash, common.Hash) error); ok {
+		r1 = rf(account, domainSeparator, structHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignTx provides a mock function with given fields: account, tx, chainID
 func (_m *KeyStoreInterface) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
 	ret := _m.Called(account, tx, chainID)
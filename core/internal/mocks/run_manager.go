@@ -7,6 +7,8 @@ import (
 
 	models "github.com/smartcontractkit/chainlink/core/store/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // RunManager is an autogenerated mock type for the RunManager type
@@ -83,6 +85,29 @@ func (_m *RunManager) CreateErrored(jobSpecID *models.ID, initiator models.Initi
 	return r0, r1
 }
 
+// Replay provides a mock function with given fields: runID
+func (_m *RunManager) Replay(runID *models.ID) (*models.JobRun, error) {
+	ret := _m.Called(runID)
+
+	var r0 *models.JobRun
+	if rf, ok := ret.Get(0).(func(*models.ID) *models.JobRun); ok {
+		r0 = rf(runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobRun)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.ID) error); ok {
+		r1 = rf(runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResumeAllInProgress provides a mock function with given fields:
 func (_m *RunManager) ResumeAllInProgress() error {
 	ret := _m.Called()
@@ -125,6 +150,20 @@ func (_m *RunManager) ResumeAllPendingNextBlock(currentBlockHeight *big.Int) err
 	return r0
 }
 
+// ResumeAllPendingSleep provides a mock function with given fields: now
+func (_m *RunManager) ResumeAllPendingSleep(now time.Time) error {
+	ret := _m.Called(now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumePendingBridge provides a mock function with given fields: runID, input
 func (_m *RunManager) ResumePendingBridge(runID *models.ID, input models.BridgeRunResult) error {
 	ret := _m.Called(runID, input)
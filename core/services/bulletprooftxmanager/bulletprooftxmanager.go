@@ -5,9 +5,12 @@ import (
 	"context"
 	"database/sql"
 	"math/big"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated/link_token_interface"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
@@ -16,6 +19,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	gethAccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/jinzhu/gorm"
@@ -48,6 +52,160 @@ func SendEther(s *strpkg.Store, from, to gethCommon.Address, value assets.Eth) (
 	return etx, err
 }
 
+// CreateEthTransaction creates a transaction that calls the contract at to
+// with the given payload
+func CreateEthTransaction(s *strpkg.Store, from, to gethCommon.Address, payload []byte) (etx models.EthTx, err error) {
+	return CreateEthTransactionWithGasLimit(s, from, to, payload, s.Config.EthGasLimitDefault())
+}
+
+// CreateEthTransactionWithGasLimit creates a transaction that calls the
+// contract at to with the given payload and gas limit
+func CreateEthTransactionWithGasLimit(s *strpkg.Store, from, to gethCommon.Address, payload []byte, gasLimit uint64) (etx models.EthTx, err error) {
+	etx, err = newEthTransaction(from, to, payload, gasLimit)
+	if err != nil {
+		return etx, err
+	}
+	err = s.DB.Create(&etx).Error
+	return etx, err
+}
+
+// EthCall is a call of the contract at To with Payload.
+type EthCall struct {
+	To      gethCommon.Address
+	Payload []byte
+}
+
+// CreateEthTransactions creates a transaction for each of the calls, in a
+// single database transaction so that either all of them are sent or none.
+func CreateEthTransactions(s *strpkg.Store, from gethCommon.Address, calls []EthCall) ([]models.EthTx, error) {
+	etxs := make([]models.EthTx, len(calls))
+	for i, call := range calls {
+		etx, err := newEthTransaction(from, call.To, call.Payload, s.Config.EthGasLimitDefault())
+		if err != nil {
+			return nil, err
+		}
+		etxs[i] = etx
+	}
+	err := s.Transaction(func(tx *gorm.DB) error {
+		for i := range etxs {
+			if err := tx.Create(&etxs[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return etxs, nil
+}
+
+func newEthTransaction(from, to gethCommon.Address, payload []byte, gasLimit uint64) (models.EthTx, error) {
+	if to == utils.ZeroAddress {
+		return models.EthTx{}, errors.New("cannot send transaction to zero address")
+	}
+	return models.EthTx{
+		FromAddress:    from,
+		ToAddress:      to,
+		EncodedPayload: payload,
+		Value:          assets.NewEthValue(0),
+		GasLimit:       gasLimit,
+		State:          models.EthTxUnstarted,
+	}, nil
+}
+
+// ethTransferGasLimit is the gas used by a plain ETH transfer to an account
+// with no code
+const ethTransferGasLimit = 21000
+
+// SweepFunds creates transactions that transfer all of from's LINK, and all
+// of its ETH except what is needed to pay for those transactions at the
+// default gas price, to the address to. A transaction is omitted when there
+// is nothing to transfer.
+//
+// If either transaction is later bumped above the default gas price, the ETH
+// transfer may fail for lack of funds and leave a small balance behind.
+//
+// record is called with the transactions in the database transaction that
+// creates them, and neither is created if it returns an error.
+func SweepFunds(s *strpkg.Store, from, to gethCommon.Address, record func(tx *gorm.DB, ethTransfer, linkTransfer *models.EthTx) error) (ethTransfer, linkTransfer *models.EthTx, err error) {
+	if to == utils.ZeroAddress {
+		return nil, nil, errors.New("cannot sweep funds to zero address")
+	}
+
+	linkBalance, err := s.TxManager.GetLINKBalance(from)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "while fetching LINK balance")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), maxEthNodeRequestTime)
+	defer cancel()
+	ethBalance, err := s.EthClient.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "while fetching ETH balance")
+	}
+
+	gasPrice := s.Config.EthGasPriceDefault()
+	fees := new(big.Int).Mul(gasPrice, big.NewInt(ethTransferGasLimit))
+
+	err = s.Transaction(func(tx *gorm.DB) error {
+		if !linkBalance.IsZero() {
+			payload, err := linkTokenABI().Pack("transfer", to, linkBalance.ToInt())
+			if err != nil {
+				return errors.Wrap(err, "while encoding LINK transfer")
+			}
+			linkTransfer = &models.EthTx{
+				FromAddress:    from,
+				ToAddress:      gethCommon.HexToAddress(s.Config.LinkContractAddress()),
+				EncodedPayload: payload,
+				Value:          assets.NewEthValue(0),
+				GasLimit:       s.Config.EthGasLimitDefault(),
+				State:          models.EthTxUnstarted,
+			}
+			if err := tx.Create(linkTransfer).Error; err != nil {
+				return err
+			}
+			fees.Add(fees, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(linkTransfer.GasLimit)))
+		}
+
+		remaining := new(big.Int).Sub(ethBalance, fees)
+		if remaining.Sign() <= 0 {
+			return record(tx, nil, linkTransfer)
+		}
+		ethTransfer = &models.EthTx{
+			FromAddress:    from,
+			ToAddress:      to,
+			EncodedPayload: []byte{},
+			Value:          assets.Eth(*remaining),
+			GasLimit:       ethTransferGasLimit,
+			State:          models.EthTxUnstarted,
+		}
+		if err := tx.Create(ethTransfer).Error; err != nil {
+			return err
+		}
+		return record(tx, ethTransfer, linkTransfer)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return ethTransfer, linkTransfer, nil
+}
+
+var (
+	linkTokenABIOnce   sync.Once
+	linkTokenABIParsed abi.ABI
+)
+
+func linkTokenABI() abi.ABI {
+	linkTokenABIOnce.Do(func() {
+		var err error
+		linkTokenABIParsed, err = abi.JSON(strings.NewReader(link_token_interface.LinkTokenABI))
+		if err != nil {
+			panic(err)
+		}
+	})
+	return linkTokenABIParsed
+}
+
 func newAttempt(s *strpkg.Store, etx models.EthTx, gasPrice *big.Int) (models.EthTxAttempt, error) {
 	attempt := models.EthTxAttempt{}
 	account, err := s.KeyStore.GetAccountByAddress(etx.FromAddress)
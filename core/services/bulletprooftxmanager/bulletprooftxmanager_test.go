@@ -8,6 +8,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
@@ -143,3 +144,28 @@ func TestBulletproofTxManager_SendEther_DoesNotSendToZero(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, "cannot send ether to zero address")
 }
+
+func TestBulletproofTxManager_CreateEthTransactions(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	from := cltest.MustInsertRandomKey(t, store).Address.Address()
+	calls := []bulletprooftxmanager.EthCall{
+		{To: cltest.NewAddress(), Payload: []byte{1}},
+		{To: cltest.NewAddress(), Payload: []byte{2}},
+	}
+
+	etxs, err := bulletprooftxmanager.CreateEthTransactions(store, from, calls)
+	require.NoError(t, err)
+	require.Len(t, etxs, 2)
+	require.Equal(t, calls[1].To, etxs[1].ToAddress)
+	require.Equal(t, []byte{2}, etxs[1].EncodedPayload)
+
+	calls = append(calls, bulletprooftxmanager.EthCall{To: utils.ZeroAddress})
+	_, err = bulletprooftxmanager.CreateEthTransactions(store, from, calls)
+	require.EqualError(t, err, "cannot send transaction to zero address")
+
+	var count int
+	require.NoError(t, store.DB.Model(&models.EthTx{}).Count(&count).Error)
+	require.Equal(t, 2, count)
+}
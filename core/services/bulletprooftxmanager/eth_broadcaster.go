@@ -214,6 +214,14 @@ func (eb *ethBroadcaster) ProcessUnstartedEthTxs(key models.Key) error {
 // First handle any in_progress transactions left over from last time.
 // Then keep looking up unstarted transactions and processing them until there are none remaining.
 func (eb *ethBroadcaster) processUnstartedEthTxs(fromAddress gethCommon.Address) error {
+	// Transactions wait unsent while transmissions are halted, and are sent
+	// once they are resumed
+	if halted, err := eb.store.TransmissionsHalted(); err != nil {
+		return errors.Wrap(err, "processUnstartedEthTxs failed")
+	} else if halted {
+		return nil
+	}
+
 	var n uint = 0
 	mark := time.Now()
 	defer func() {
@@ -235,11 +243,24 @@ func (eb *ethBroadcaster) processUnstartedEthTxs(fromAddress gethCommon.Address)
 			return nil
 		}
 		n++
+		if err := store.CheckTransactionAllowed(eb.store.ORM, eb.config, etx.FromAddress, etx.ToAddress); errors.Cause(err) == store.ErrTransactionNotAllowed {
+			if err := saveRefusedTransaction(eb.store, etx, err); err != nil {
+				return errors.Wrap(err, "processUnstartedEthTxs failed")
+			}
+			continue
+		} else if err != nil {
+			return errors.Wrap(err, "processUnstartedEthTxs failed")
+		}
 		a, err := newAttempt(eb.store, *etx, eb.config.EthGasPriceDefault())
 		if err != nil {
 			return errors.Wrap(err, "processUnstartedEthTxs failed")
 		}
-		if err := eb.saveInProgressTransaction(etx, &a); err != nil {
+		if err := eb.saveInProgressTransaction(etx, &a); orm.IsQuotaExceeded(err) {
+			if err := saveRefusedTransaction(eb.store, etx, err); err != nil {
+				return errors.Wrap(err, "processUnstartedEthTxs failed")
+			}
+			continue
+		} else if err != nil {
 			return errors.Wrap(err, "processUnstartedEthTxs failed")
 		}
 
@@ -388,8 +409,13 @@ func (eb *ethBroadcaster) saveInProgressTransaction(etx *models.EthTx, attempt *
 	if attempt.State != models.EthTxAttemptInProgress {
 		return errors.New("attempt state must be in_progress")
 	}
-	etx.State = models.EthTxInProgress
 	return eb.store.Transaction(func(tx *gorm.DB) error {
+		// The gas quota of the key's namespace is enforced here, as every
+		// transaction is started here whichever task or service queued it
+		if err := orm.CheckBroadcastGasQuota(tx, *etx, time.Now()); err != nil {
+			return err
+		}
+		etx.State = models.EthTxInProgress
 		if err := tx.Create(attempt).Error; err != nil {
 			return errors.Wrap(err, "saveInProgressTransaction failed to create eth_tx_attempt")
 		}
@@ -476,6 +502,21 @@ func saveFatallyErroredTransaction(store *store.Store, etx *models.EthTx) error
 	})
 }
 
+// saveRefusedTransaction fatally errors a transaction which may not be sent,
+// as its contract is not on the transaction allowlist or it would exceed its
+// namespace's gas quota, without sending it, leaving its nonce to the next
+// transaction.
+func saveRefusedTransaction(store *store.Store, etx *models.EthTx, sendError error) error {
+	if etx.State != models.EthTxUnstarted {
+		return errors.Errorf("can only refuse unstarted transactions, transaction is currently %s", etx.State)
+	}
+	errString := sendError.Error()
+	etx.Error = &errString
+	etx.Nonce = nil
+	etx.State = models.EthTxFatalError
+	return errors.Wrap(store.DB.Save(etx).Error, "saveRefusedTransaction failed to save eth_tx")
+}
+
 // GetNextNonce returns keys.next_nonce for the given address
 func GetNextNonce(db *gorm.DB, address gethCommon.Address) (*int64, error) {
 	var nonce *int64
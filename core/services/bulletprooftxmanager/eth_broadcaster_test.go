@@ -12,6 +12,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -1141,6 +1142,157 @@ func TestEthBroadcaster_ProcessUnstartedEthTxs_KeystoreErrors(t *testing.T) {
 	ethClient.AssertExpectations(t)
 }
 
+func TestEthBroadcaster_ProcessUnstartedEthTxs_TransmissionsHalted(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	eb := bulletprooftxmanager.NewEthBroadcaster(store, config)
+
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	key := keys[0]
+
+	etx := models.EthTx{
+		FromAddress:    key.Address.Address(),
+		ToAddress:      gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411"),
+		EncodedPayload: []byte{42, 42, 0},
+		Value:          assets.NewEthValue(142),
+		GasLimit:       uint64(242),
+		CreatedAt:      time.Unix(0, 0),
+		State:          models.EthTxUnstarted,
+	}
+	require.NoError(t, store.DB.Save(&etx).Error)
+
+	_, err = store.HaltTransmissions("incident", "operator")
+	require.NoError(t, err)
+
+	// Nothing is sent while transmissions are halted
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+	require.NoError(t, store.DB.First(&etx, etx.ID).Error)
+	assert.Equal(t, models.EthTxUnstarted, etx.State)
+
+	_, err = store.ResumeTransmissions("resolved", "operator")
+	require.NoError(t, err)
+
+	ethClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+	require.NoError(t, store.DB.First(&etx, etx.ID).Error)
+	assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_TransactionAllowlist(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("TRANSACTION_ALLOWLIST_ENABLED", true)
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	eb := bulletprooftxmanager.NewEthBroadcaster(store, config)
+
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	key := keys[0]
+	toAddress := gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411")
+
+	newEthTx := func() models.EthTx {
+		etx := models.EthTx{
+			FromAddress:    key.Address.Address(),
+			ToAddress:      toAddress,
+			EncodedPayload: []byte{42, 42, 0},
+			Value:          assets.NewEthValue(142),
+			GasLimit:       uint64(242),
+			CreatedAt:      time.Unix(0, 0),
+			State:          models.EthTxUnstarted,
+		}
+		require.NoError(t, store.DB.Save(&etx).Error)
+		return etx
+	}
+
+	// Transactions to contracts which are not allowlisted are never sent
+	refused := newEthTx()
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+	require.NoError(t, store.DB.First(&refused, refused.ID).Error)
+	assert.Equal(t, models.EthTxFatalError, refused.State)
+	assert.Nil(t, refused.Nonce)
+	require.NotNil(t, refused.Error)
+	assert.Contains(t, *refused.Error, "not on the transaction allowlist")
+
+	_, err = store.AddToTransactionAllowlist(toAddress, "aggregator", "operator")
+	require.NoError(t, err)
+
+	allowed := newEthTx()
+	ethClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+	require.NoError(t, store.DB.First(&allowed, allowed.ID).Error)
+	assert.Equal(t, models.EthTxUnconfirmed, allowed.State)
+	assert.NotNil(t, allowed.Nonce)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_GasQuota(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	eb := bulletprooftxmanager.NewEthBroadcaster(store, config)
+
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	key := keys[0]
+	require.NoError(t, store.UpdateNamespaceQuotas(models.DefaultNamespace, models.NamespaceQuotas{MaxGasPerDay: clnull.Int64From(500)}))
+
+	newEthTx := func() models.EthTx {
+		etx := models.EthTx{
+			FromAddress:    key.Address.Address(),
+			ToAddress:      cltest.NewAddress(),
+			EncodedPayload: []byte{42, 42, 0},
+			Value:          assets.NewEthValue(0),
+			GasLimit:       uint64(300),
+			State:          models.EthTxUnstarted,
+		}
+		require.NoError(t, store.DB.Save(&etx).Error)
+		return etx
+	}
+
+	// Transactions queued without a quota check, as by a sweep or the OCR
+	// transmitter, are held to the quota when they are broadcast
+	sent := newEthTx()
+	refused := newEthTx()
+	ethClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+	require.NoError(t, store.DB.First(&sent, sent.ID).Error)
+	assert.Equal(t, models.EthTxUnconfirmed, sent.State)
+	require.NoError(t, store.DB.First(&refused, refused.ID).Error)
+	assert.Equal(t, models.EthTxFatalError, refused.State)
+	assert.Nil(t, refused.Nonce)
+	require.NotNil(t, refused.Error)
+	assert.Contains(t, *refused.Error, "maxGasPerDay")
+
+	ethClient.AssertExpectations(t)
+}
+
 func TestEthBroadcaster_ProcessUnstartedEthTxs_Locking(t *testing.T) {
 	store1, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -3,6 +3,7 @@ package chainlink
 import (
 	"context"
 	stderr "errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"sync"
@@ -13,11 +14,15 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/events"
+	"github.com/smartcontractkit/chainlink/core/services/fleetsync"
 	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor"
+	"github.com/smartcontractkit/chainlink/core/services/keeper"
 	"github.com/smartcontractkit/chainlink/core/services/synchronization"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/gobuffalo/packr"
 	"go.uber.org/multierr"
@@ -44,9 +49,17 @@ type Application interface {
 	Stop() error
 	GetStore() *strpkg.Store
 	GetStatsPusher() synchronization.StatsPusher
+	GetRunUpdateBroadcaster() services.RunUpdateBroadcaster
 	WakeSessionReaper()
 	AddJob(job models.JobSpec) error
+	AddJobs(jobs []models.JobSpec) error
+	StartJob(*models.ID) error
+	RetryJob(*models.ID) error
+	PauseJob(*models.ID) error
+	ResumeJob(*models.ID) error
 	ArchiveJob(*models.ID) error
+	RestoreJob(*models.ID) error
+	UpdateJob(models.JobSpec) error
 	AddServiceAgreement(*models.ServiceAgreement) error
 	NewBox() packr.Box
 	services.RunManager
@@ -64,9 +77,17 @@ type ChainlinkApplication struct {
 	JobSubscriber            services.JobSubscriber
 	GasUpdater               services.GasUpdater
 	EthBroadcaster           bulletprooftxmanager.EthBroadcaster
+	EventPublisher           events.Publisher
 	LogBroadcaster           eth.LogBroadcaster
 	FluxMonitor              fluxmonitor.Service
+	Keeper                   keeper.Service
+	FleetSyncer              fleetsync.Syncer
+	JobExpirer               services.JobExpirer
+	RunDeadlineSweeper       services.RunDeadlineSweeper
+	JobChainer               services.JobChainer
+	RunUpdateBroadcaster     services.RunUpdateBroadcaster
 	Scheduler                *services.Scheduler
+	SourceLatencySaver       services.SourceLatencySaver
 	Store                    *strpkg.Store
 	SessionReaper            services.SleeperTask
 	pendingConnectionResumer *pendingConnectionResumer
@@ -85,15 +106,16 @@ func NewApplication(config *orm.Config, onConnectCallbacks ...func(Application))
 	config.SetRuntimeStore(store.ORM)
 
 	statsPusher := synchronization.NewStatsPusher(
-		store.ORM, config.ExplorerURL(), config.ExplorerAccessKey(), config.ExplorerSecret(),
+		store.ORM, config.ExplorerURL(), config.ExplorerAccessKey(), config.ExplorerSecret(), config.ExplorerMaxBacklog(),
 	)
 	runExecutor := services.NewRunExecutor(store, statsPusher)
-	runQueue := services.NewRunQueue(runExecutor)
+	runQueue := services.NewRunQueue(runExecutor, store.ORM)
 	runManager := services.NewRunManager(runQueue, config, store.ORM, statsPusher, store.TxManager, store.Clock)
 	jobSubscriber := services.NewJobSubscriber(store, runManager)
 	gasUpdater := services.NewGasUpdater(store)
 	logBroadcaster := eth.NewLogBroadcaster(store.TxManager, store.ORM, store.Config.BlockBackfillDepth())
 	fluxMonitor := fluxmonitor.New(store, runManager, logBroadcaster)
+	keeperService := keeper.New(store)
 	ethBroadcaster := bulletprooftxmanager.NewEthBroadcaster(store, config)
 	ethConfirmer := bulletprooftxmanager.NewEthConfirmer(store, config)
 	balanceMonitor := services.NewBalanceMonitor(store)
@@ -106,11 +128,14 @@ func NewApplication(config *orm.Config, onConnectCallbacks ...func(Application))
 		JobSubscriber:            jobSubscriber,
 		GasUpdater:               gasUpdater,
 		EthBroadcaster:           ethBroadcaster,
+		EventPublisher:           events.NewPublisher(store.ORM, config),
 		LogBroadcaster:           logBroadcaster,
 		FluxMonitor:              fluxMonitor,
+		Keeper:                   keeperService,
 		StatsPusher:              statsPusher,
 		RunManager:               runManager,
 		RunQueue:                 runQueue,
+		RunUpdateBroadcaster:     services.NewRunUpdateBroadcaster(store.ORM),
 		Scheduler:                services.NewScheduler(store, runManager),
 		Store:                    store,
 		SessionReaper:            services.NewStoreReaper(store),
@@ -120,6 +145,12 @@ func NewApplication(config *orm.Config, onConnectCallbacks ...func(Application))
 		balanceMonitor:           balanceMonitor,
 	}
 
+	app.FleetSyncer = fleetsync.NewSyncer(store, app)
+	app.JobExpirer = services.NewJobExpirer(store, app, services.JobExpiryInterval)
+	app.RunDeadlineSweeper = services.NewRunDeadlineSweeper(store, services.RunDeadlineInterval)
+	app.JobChainer = services.NewJobChainer(store, runManager, app.RunUpdateBroadcaster, services.JobChainerInterval)
+	app.SourceLatencySaver = services.NewSourceLatencySaver(store, utils.SourceRequestLatencies, services.SourceLatencySaveInterval)
+
 	headTrackables := []strpkg.HeadTrackable{gasUpdater}
 
 	if store.Config.EnableBulletproofTxManager() {
@@ -133,6 +164,7 @@ func NewApplication(config *orm.Config, onConnectCallbacks ...func(Application))
 		jobSubscriber,
 		pendingConnectionResumer,
 		balanceMonitor,
+		keeperService,
 	)
 
 	for _, onConnectCallback := range onConnectCallbacks {
@@ -170,13 +202,21 @@ func (app *ChainlinkApplication) Start() error {
 	}
 
 	// XXX: Change to exit on first encountered error.
-	return multierr.Combine(
+	err := multierr.Combine(
 		app.Store.Start(),
+		// Loaded before any runs resume, so that their requests use the
+		// adaptive timeouts learnt before the node restarted
+		app.SourceLatencySaver.Start(),
 		app.StatsPusher.Start(),
+		app.EventPublisher.Start(),
+		app.RunUpdateBroadcaster.Start(),
+		// Follows runs before any resume, so that none complete unchained
+		app.JobChainer.Start(),
 		app.RunQueue.Start(),
 		app.RunManager.ResumeAllInProgress(),
 		startIf(ethEnabled, app.LogBroadcaster.Start),
 		startIf(ethEnabled, app.FluxMonitor.Start),
+		startIf(ethEnabled, app.Keeper.Start),
 		startIf(ethEnabled, app.EthBroadcaster.Start),
 
 		// HeadTracker deliberately started after
@@ -186,7 +226,30 @@ func (app *ChainlinkApplication) Start() error {
 		startIf(ethEnabled, app.HeadTracker.Start),
 
 		app.Scheduler.Start(),
+
+		// FleetSyncer adds jobs, so starts once they can be scheduled
+		app.FleetSyncer.Start(),
+		app.JobExpirer.Start(),
+		app.RunDeadlineSweeper.Start(),
 	)
+	app.reportQuarantinedJobs()
+	return err
+}
+
+// reportQuarantinedJobs logs the jobs which failed to start, so that operators
+// can find and retry them.
+func (app *ChainlinkApplication) reportQuarantinedJobs() {
+	jobs, err := app.Store.QuarantinedJobs()
+	if err != nil {
+		logger.Errorw("Unable to load quarantined jobs", "error", err)
+		return
+	}
+	for _, j := range jobs {
+		logger.Warnw("Job failed to start and is quarantined until retried",
+			"job", j.ID.String(),
+			"reason", j.StatusReason,
+		)
+	}
 }
 
 func startIf(condition bool, start func() error) error {
@@ -212,14 +275,22 @@ func (app *ChainlinkApplication) Stop() error {
 		}()
 		logger.Info("Gracefully exiting...")
 
+		merr = multierr.Append(merr, app.RunDeadlineSweeper.Stop())
+		merr = multierr.Append(merr, app.JobExpirer.Stop())
+		merr = multierr.Append(merr, app.FleetSyncer.Stop())
 		app.Scheduler.Stop()
 		merr = multierr.Append(merr, app.HeadTracker.Stop())
 		merr = multierr.Append(merr, app.balanceMonitor.Stop())
 		merr = multierr.Append(merr, app.JobSubscriber.Stop())
 		app.FluxMonitor.Stop()
+		app.Keeper.Stop()
 		merr = multierr.Append(merr, app.EthBroadcaster.Stop())
 		app.RunQueue.Stop()
+		merr = multierr.Append(merr, app.JobChainer.Stop())
+		merr = multierr.Append(merr, app.SourceLatencySaver.Stop())
 		merr = multierr.Append(merr, app.StatsPusher.Close())
+		merr = multierr.Append(merr, app.EventPublisher.Stop())
+		merr = multierr.Append(merr, app.RunUpdateBroadcaster.Stop())
 		merr = multierr.Append(merr, app.SessionReaper.Stop())
 		merr = multierr.Append(merr, app.Store.Close())
 	})
@@ -235,6 +306,11 @@ func (app *ChainlinkApplication) GetStatsPusher() synchronization.StatsPusher {
 	return app.StatsPusher
 }
 
+// GetRunUpdateBroadcaster returns the broadcaster of run status changes.
+func (app *ChainlinkApplication) GetRunUpdateBroadcaster() services.RunUpdateBroadcaster {
+	return app.RunUpdateBroadcaster
+}
+
 // WakeSessionReaper wakes up the reaper to do its reaping.
 func (app *ChainlinkApplication) WakeSessionReaper() {
 	app.SessionReaper.WakeUp()
@@ -242,25 +318,184 @@ func (app *ChainlinkApplication) WakeSessionReaper() {
 
 // AddJob adds a job to the store and the scheduler. If there was
 // an error from adding the job to the store, the job will not be
-// added to the scheduler.
+// added to the scheduler. Stopped jobs are only saved, see StartJob.
 func (app *ChainlinkApplication) AddJob(job models.JobSpec) error {
 	err := app.Store.CreateJob(&job)
 	if err != nil {
 		return err
 	}
+	if job.Stopped() {
+		return nil
+	}
+
+	logger.ErrorIf(app.startJob(job))
+	return nil
+}
+
+// AddJobs saves all of the jobs in a single transaction, then starts those
+// which are not stopped.
+func (app *ChainlinkApplication) AddJobs(jobs []models.JobSpec) error {
+	if err := app.Store.CreateJobs(jobs); err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if !job.Stopped() {
+			logger.ErrorIf(app.startJob(job))
+		}
+	}
+	return nil
+}
 
+// StartJob activates a job which was saved in the stopped state, handing its
+// initiators to the scheduler and subscribers.
+func (app *ChainlinkApplication) StartJob(ID *models.ID) error {
+	job, err := app.Store.FindJob(ID)
+	if err != nil {
+		return err
+	}
+	if !job.Stopped() {
+		return fmt.Errorf("job %s has already been started", ID)
+	}
+
+	if err := app.Store.UpdateJobSpecStatus(ID, models.JobSpecStatusActive); err != nil {
+		return err
+	}
+	job.Status = models.JobSpecStatusActive
+
+	logger.ErrorIf(app.startJob(job))
+	return nil
+}
+
+// RetryJob attempts to start a job which was quarantined after failing to
+// start on boot. If it fails again, whatever it started is stopped and it is
+// returned to quarantine with the new reason.
+func (app *ChainlinkApplication) RetryJob(ID *models.ID) error {
+	job, err := app.Store.FindJob(ID)
+	if err != nil {
+		return err
+	}
+	if !job.Quarantined() {
+		return fmt.Errorf("job %s has not failed to start", ID)
+	}
+
+	if err := app.Store.UpdateJobSpecStatus(ID, models.JobSpecStatusActive); err != nil {
+		return err
+	}
+	job.Status = models.JobSpecStatusActive
+
+	if err := app.startJob(job); err != nil {
+		_ = app.JobSubscriber.RemoveJob(ID)
+		app.FluxMonitor.RemoveJob(ID)
+		app.Keeper.RemoveJob(ID)
+		app.Scheduler.RemoveJob(ID)
+		return multierr.Append(err, app.Store.QuarantineJob(ID, err.Error()))
+	}
+	return nil
+}
+
+func (app *ChainlinkApplication) startJob(job models.JobSpec) error {
 	app.Scheduler.AddJob(job)
 
-	logger.ErrorIf(app.FluxMonitor.AddJob(job))
-	logger.ErrorIf(app.JobSubscriber.AddJob(job, nil))
+	return multierr.Combine(
+		app.FluxMonitor.AddJob(job),
+		app.Keeper.AddJob(job),
+		app.JobSubscriber.AddJob(job, nil),
+	)
+}
+
+// PauseJob stops creating runs for an active job until it is resumed. Its
+// runs, including those in progress, are left as they are.
+func (app *ChainlinkApplication) PauseJob(ID *models.ID) error {
+	job, err := app.Store.FindJob(ID)
+	if err != nil {
+		return err
+	}
+	if job.Status != models.JobSpecStatusActive {
+		return fmt.Errorf("job %s is %s, only active jobs can be paused", ID, job.Status)
+	}
+
+	if err := app.Store.UpdateJobSpecStatus(ID, models.JobSpecStatusPaused); err != nil {
+		return err
+	}
+	app.stopJob(ID)
+	return nil
+}
+
+// ResumeJob hands the initiators of a paused job back to the scheduler and
+// subscribers.
+func (app *ChainlinkApplication) ResumeJob(ID *models.ID) error {
+	job, err := app.Store.FindJob(ID)
+	if err != nil {
+		return err
+	}
+	if !job.Paused() {
+		return fmt.Errorf("job %s is not paused", ID)
+	}
+
+	if err := app.Store.UpdateJobSpecStatus(ID, models.JobSpecStatusActive); err != nil {
+		return err
+	}
+	job.Status = models.JobSpecStatusActive
+
+	logger.ErrorIf(app.startJob(job))
 	return nil
 }
 
 // ArchiveJob silences the job from the system, preventing future job runs.
 func (app *ChainlinkApplication) ArchiveJob(ID *models.ID) error {
+	app.stopJob(ID)
+	return app.Store.ArchiveJob(ID)
+}
+
+// RestoreJob brings back an archived job, starting it again unless it was
+// stopped or paused when archived.
+func (app *ChainlinkApplication) RestoreJob(ID *models.ID) error {
+	if err := app.Store.RestoreJob(ID); err != nil {
+		return err
+	}
+	job, err := app.Store.FindJob(ID)
+	if err != nil {
+		return err
+	}
+	if job.Status == models.JobSpecStatusActive {
+		logger.ErrorIf(app.startJob(job))
+	}
+	return nil
+}
+
+// UpdateJob replaces the spec of an existing job with that of job, which has
+// the same ID, keeping its runs. A job which had been started is restarted
+// with its new initiators.
+func (app *ChainlinkApplication) UpdateJob(job models.JobSpec) error {
+	existing, err := app.Store.FindJob(job.ID)
+	if err != nil {
+		return err
+	}
+
+	app.stopJob(job.ID)
+	if err := app.Store.ReplaceJobSpec(&job); err != nil {
+		if !existing.Stopped() && !existing.Paused() {
+			logger.ErrorIf(app.startJob(existing))
+		}
+		return err
+	}
+
+	updated, err := app.Store.FindJob(job.ID)
+	if err != nil {
+		return err
+	}
+	if !updated.Stopped() && !updated.Paused() {
+		logger.ErrorIf(app.startJob(updated))
+	}
+	return nil
+}
+
+// stopJob stops creating runs for the job, until it is started again.
+func (app *ChainlinkApplication) stopJob(ID *models.ID) {
 	_ = app.JobSubscriber.RemoveJob(ID)
 	app.FluxMonitor.RemoveJob(ID)
-	return app.Store.ArchiveJob(ID)
+	app.Keeper.RemoveJob(ID)
+	app.Scheduler.RemoveJob(ID)
 }
 
 // AddServiceAgreement adds a Service Agreement which includes a job that needs
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -18,8 +19,31 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+var (
+	promRPCCallsVec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "eth_rpc_calls",
+		Help: "The number of calls made to the ethereum node, by method",
+	},
+		[]string{"method"},
+	)
+	rpcCalls uint64
+)
+
+// RPCCalls returns the number of calls made to the ethereum node since the
+// node started.
+func RPCCalls() uint64 {
+	return atomic.LoadUint64(&rpcCalls)
+}
+
+func countRPCCall(method string) {
+	atomic.AddUint64(&rpcCalls, 1)
+	promRPCCallsVec.WithLabelValues(method).Inc()
+}
+
 //go:generate mockery --name Client --output ../../internal/mocks/ --case=underscore
 //go:generate mockery --name GethClient --output ../../internal/mocks/ --case=underscore
 //go:generate mockery --name RPCClient --output ../../internal/mocks/ --case=underscore
@@ -162,6 +186,7 @@ func (client *client) GetERC20Balance(address common.Address, contractAddress co
 		"address", address,
 		"contractAddress", contractAddress,
 	)
+	countRPCCall("eth_call")
 	result := ""
 	numLinkBigInt := new(big.Int)
 	functionSelector := models.HexToFunctionSelector("0x70a08231") // balanceOf(address)
@@ -183,6 +208,7 @@ func (client *client) SendRawTx(bytes []byte) (common.Hash, error) {
 	logger.Debugw("eth.Client#SendRawTx(...)",
 		"bytes", bytes,
 	)
+	countRPCCall("eth_sendRawTransaction")
 	result := common.Hash{}
 	err := client.RPCClient.Call(&result, "eth_sendRawTransaction", hexutil.Encode(bytes))
 	return result, err
@@ -194,6 +220,7 @@ func (client *client) TransactionReceipt(ctx context.Context, txHash common.Hash
 	logger.Debugw("eth.Client#TransactionReceipt(...)",
 		"txHash", txHash,
 	)
+	countRPCCall("eth_getTransactionReceipt")
 	receipt, err := client.GethClient.TransactionReceipt(ctx, txHash)
 	if err != nil && strings.Contains(err.Error(), "missing required field") {
 		return nil, ethereum.NotFound
@@ -203,6 +230,7 @@ func (client *client) TransactionReceipt(ctx context.Context, txHash common.Hash
 
 func (client *client) ChainID(ctx context.Context) (*big.Int, error) {
 	logger.Debugw("eth.Client#ChainID(...)")
+	countRPCCall("eth_chainId")
 	return client.GethClient.ChainID(ctx)
 }
 
@@ -211,6 +239,7 @@ func (client *client) SendTransaction(ctx context.Context, tx *types.Transaction
 	logger.Debugw("eth.Client#SendTransaction(...)",
 		"tx", tx,
 	)
+	countRPCCall("eth_sendRawTransaction")
 
 	if client.secondaryURL != "" {
 		// Parallel send to secondary node
@@ -241,6 +270,7 @@ func (client *client) PendingNonceAt(ctx context.Context, account common.Address
 	logger.Debugw("eth.Client#PendingNonceAt(...)",
 		"account", account,
 	)
+	countRPCCall("eth_getTransactionCount")
 	return client.GethClient.PendingNonceAt(ctx, account)
 }
 
@@ -248,6 +278,7 @@ func (client *client) BlockByNumber(ctx context.Context, number *big.Int) (*type
 	logger.Debugw("eth.Client#BlockByNumber(...)",
 		"number", number,
 	)
+	countRPCCall("eth_getBlockByNumber")
 	return client.GethClient.BlockByNumber(ctx, number)
 }
 
@@ -255,6 +286,7 @@ func (client *client) HeaderByNumber(ctx context.Context, number *big.Int) (*mod
 	logger.Debugw("eth.Client#HeaderByNumber(...)",
 		"number", number,
 	)
+	countRPCCall("eth_getBlockByNumber")
 	var head *models.Head
 	err := client.RPCClient.CallContext(ctx, &head, "eth_getBlockByNumber", toBlockNumArg(number), false)
 	if err == nil && head == nil {
@@ -275,11 +307,30 @@ type MaybeHeader struct {
 	Error  error
 }
 
+func (client *client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	logger.Debugw("eth.Client#CallContract(...)",
+		"msg", msg,
+		"blockNumber", blockNumber,
+	)
+	countRPCCall("eth_call")
+	return client.GethClient.CallContract(ctx, msg, blockNumber)
+}
+
+func (client *client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	logger.Debugw("eth.Client#CodeAt(...)",
+		"account", account,
+		"blockNumber", blockNumber,
+	)
+	countRPCCall("eth_getCode")
+	return client.GethClient.CodeAt(ctx, account, blockNumber)
+}
+
 func (client *client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
 	logger.Debugw("eth.Client#BalanceAt(...)",
 		"account", account,
 		"blockNumber", blockNumber,
 	)
+	countRPCCall("eth_getBalance")
 	return client.GethClient.BalanceAt(ctx, account, blockNumber)
 }
 
@@ -287,6 +338,7 @@ func (client *client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([
 	logger.Debugw("eth.Client#FilterLogs(...)",
 		"q", q,
 	)
+	countRPCCall("eth_getLogs")
 	return client.GethClient.FilterLogs(ctx, q)
 }
 
@@ -294,11 +346,13 @@ func (client *client) SubscribeFilterLogs(ctx context.Context, q ethereum.Filter
 	logger.Debugw("eth.Client#SubscribeFilterLogs(...)",
 		"q", q,
 	)
+	countRPCCall("eth_subscribe")
 	return client.GethClient.SubscribeFilterLogs(ctx, q, ch)
 }
 
 func (client *client) SubscribeNewHead(ctx context.Context, ch chan<- *models.Head) (ethereum.Subscription, error) {
 	logger.Debugw("eth.Client#SubscribeNewHead(...)")
+	countRPCCall("eth_subscribe")
 	return client.RPCClient.EthSubscribe(ctx, ch, "newHeads")
 }
 
@@ -316,6 +370,7 @@ func (client *client) Call(result interface{}, method string, args ...interface{
 		"method", method,
 		"args", args,
 	)
+	countRPCCall(method)
 	return client.RPCClient.Call(result, method, args...)
 }
 
@@ -324,5 +379,6 @@ func (client *client) CallContext(ctx context.Context, result interface{}, metho
 		"method", method,
 		"args", args,
 	)
+	countRPCCall(method)
 	return client.RPCClient.CallContext(ctx, result, method, args...)
 }
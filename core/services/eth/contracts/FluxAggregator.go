@@ -14,6 +14,8 @@ import (
 type FluxAggregator interface {
 	eth.ConnectedContract
 	RoundState(oracle common.Address, roundID uint32) (FluxAggregatorRoundState, error)
+	LatestRoundData() (FluxAggregatorRoundData, error)
+	Decimals() (uint8, error)
 }
 
 const (
@@ -95,3 +97,31 @@ func (fa *fluxAggregator) RoundState(oracle common.Address, roundID uint32) (Flu
 	}
 	return result, nil
 }
+
+// FluxAggregatorRoundData is the latest round of an aggregator, whose answer
+// is scaled up by the aggregator's decimals.
+type FluxAggregatorRoundData struct {
+	RoundID         *big.Int `abi:"roundId" json:"roundId"`
+	Answer          *big.Int `abi:"answer" json:"answer"`
+	StartedAt       *big.Int `abi:"startedAt" json:"startedAt"`
+	UpdatedAt       *big.Int `abi:"updatedAt" json:"updatedAt"`
+	AnsweredInRound *big.Int `abi:"answeredInRound" json:"answeredInRound"`
+}
+
+func (fa *fluxAggregator) LatestRoundData() (FluxAggregatorRoundData, error) {
+	var result FluxAggregatorRoundData
+	err := fa.Call(&result, "latestRoundData")
+	if err != nil {
+		return FluxAggregatorRoundData{}, errors.Wrap(err, "unable to call latestRoundData")
+	}
+	return result, nil
+}
+
+func (fa *fluxAggregator) Decimals() (uint8, error) {
+	var result uint8
+	err := fa.Call(&result, "decimals")
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to call decimals")
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package contracts
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// keeperRegistryABI holds only the methods keepers use to find and perform
+// the upkeeps of a registry.
+const keeperRegistryABI = `[
+{"type":"function","name":"getKeeperList","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address[]"}]},
+{"type":"function","name":"getUpkeepCount","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"checkUpkeep","stateMutability":"nonpayable","inputs":[{"name":"id","type":"uint256"},{"name":"from","type":"address"}],"outputs":[{"name":"performData","type":"bytes"},{"name":"maxLinkPayment","type":"uint256"},{"name":"gasLimit","type":"uint256"},{"name":"adjustedGasWei","type":"uint256"},{"name":"linkEth","type":"uint256"}]},
+{"type":"function","name":"performUpkeep","stateMutability":"nonpayable","inputs":[{"name":"id","type":"uint256"},{"name":"performData","type":"bytes"}],"outputs":[{"name":"success","type":"bool"}]}
+]`
+
+var keeperRegistryCodec = mustParseKeeperRegistryABI()
+
+func mustParseKeeperRegistryABI() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(keeperRegistryABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// ContractCaller makes read only calls to contracts.
+type ContractCaller interface {
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// KeeperRegistry is a keeper registry contract, which keeps the upkeeps
+// keepers take turns to perform.
+type KeeperRegistry interface {
+	Address() common.Address
+	Keepers(ctx context.Context) ([]common.Address, error)
+	UpkeepCount(ctx context.Context) (*big.Int, error)
+	CheckUpkeep(ctx context.Context, id *big.Int, from common.Address) (performData []byte, gasLimit uint64, needed bool, err error)
+	PerformUpkeepPayload(id *big.Int, performData []byte) ([]byte, error)
+}
+
+type keeperRegistry struct {
+	address common.Address
+	caller  ContractCaller
+}
+
+// NewKeeperRegistry returns the keeper registry at address, which is called
+// through caller.
+func NewKeeperRegistry(address common.Address, caller ContractCaller) KeeperRegistry {
+	return &keeperRegistry{address: address, caller: caller}
+}
+
+func (kr *keeperRegistry) Address() common.Address {
+	return kr.address
+}
+
+// Keepers returns the keepers of the registry, in the order they take turns.
+func (kr *keeperRegistry) Keepers(ctx context.Context) ([]common.Address, error) {
+	var keepers []common.Address
+	if err := kr.call(ctx, &keepers, "getKeeperList"); err != nil {
+		return nil, err
+	}
+	return keepers, nil
+}
+
+// UpkeepCount returns the number of upkeeps registered, whose IDs are
+// counted from zero.
+func (kr *keeperRegistry) UpkeepCount(ctx context.Context) (*big.Int, error) {
+	var count *big.Int
+	if err := kr.call(ctx, &count, "getUpkeepCount"); err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+type checkUpkeepResult struct {
+	PerformData    []byte
+	MaxLinkPayment *big.Int
+	GasLimit       *big.Int
+	AdjustedGasWei *big.Int
+	LinkEth        *big.Int
+}
+
+// CheckUpkeep simulates the upkeep's check off-chain, returning the data to
+// perform it with and the gas limit the upkeep was registered with if it is
+// needed. The registry reverts checks of upkeeps which are not needed, so a
+// reverted call is reported as not needed rather than as an error.
+func (kr *keeperRegistry) CheckUpkeep(ctx context.Context, id *big.Int, from common.Address) ([]byte, uint64, bool, error) {
+	var result checkUpkeepResult
+	err := kr.call(ctx, &result, "checkUpkeep", id, from)
+	if err != nil && isReverted(err) {
+		return nil, 0, false, nil
+	} else if err != nil {
+		return nil, 0, false, err
+	} else if result.GasLimit == nil || !result.GasLimit.IsUint64() {
+		return nil, 0, false, errors.Errorf("checkUpkeep returned invalid gas limit %v", result.GasLimit)
+	}
+	return result.PerformData, result.GasLimit.Uint64(), true, nil
+}
+
+// PerformUpkeepPayload returns the encoded performUpkeep call.
+func (kr *keeperRegistry) PerformUpkeepPayload(id *big.Int, performData []byte) ([]byte, error) {
+	data, err := keeperRegistryCodec.Pack("performUpkeep", id, performData)
+	return data, errors.Wrap(err, "while encoding performUpkeep")
+}
+
+// call makes calls from the zero address, as registries only allow checks
+// from it so that they cannot be made on-chain.
+func (kr *keeperRegistry) call(ctx context.Context, out interface{}, method string, args ...interface{}) error {
+	data, err := keeperRegistryCodec.Pack(method, args...)
+	if err != nil {
+		return errors.Wrapf(err, "while encoding %s", method)
+	}
+	to := kr.address
+	result, err := kr.caller.CallContract(ctx, ethereum.CallMsg{From: utils.ZeroAddress, To: &to, Data: data}, nil)
+	if err != nil {
+		return errors.Wrapf(err, "while calling %s", method)
+	}
+	if err := keeperRegistryCodec.Unpack(out, method, result); err != nil {
+		return errors.Wrapf(err, "while decoding %s", method)
+	}
+	return nil
+}
+
+func isReverted(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "revert")
+}
@@ -0,0 +1,51 @@
+package contracts_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeContractCaller struct {
+	result []byte
+	err    error
+}
+
+func (c fakeContractCaller) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return c.result, c.err
+}
+
+func TestKeeperRegistry_CheckUpkeep(t *testing.T) {
+	t.Parallel()
+
+	bytesType, err := abi.NewType("bytes", "", nil)
+	require.NoError(t, err)
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	require.NoError(t, err)
+	outputs := abi.Arguments{
+		{Type: bytesType}, {Type: uint256Type}, {Type: uint256Type}, {Type: uint256Type}, {Type: uint256Type},
+	}
+	result, err := outputs.Pack([]byte{1, 2}, big.NewInt(1), big.NewInt(250000), big.NewInt(1), big.NewInt(1))
+	require.NoError(t, err)
+
+	registry := contracts.NewKeeperRegistry(cltest.NewAddress(), fakeContractCaller{result: result})
+	performData, gasLimit, needed, err := registry.CheckUpkeep(context.Background(), big.NewInt(0), cltest.NewAddress())
+	require.NoError(t, err)
+	assert.True(t, needed)
+	assert.Equal(t, []byte{1, 2}, performData)
+	assert.Equal(t, uint64(250000), gasLimit)
+
+	registry = contracts.NewKeeperRegistry(cltest.NewAddress(), fakeContractCaller{err: errors.New("execution reverted")})
+	_, _, needed, err = registry.CheckUpkeep(context.Background(), big.NewInt(0), cltest.NewAddress())
+	require.NoError(t, err)
+	assert.False(t, needed)
+}
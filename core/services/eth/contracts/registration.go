@@ -0,0 +1,116 @@
+package contracts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SetFulfillmentPermissionMethod allows a node account to fulfill
+	// requests on an Oracle or Operator contract. Sent by the contract owner.
+	SetFulfillmentPermissionMethod = "setFulfillmentPermission"
+	// SetAuthorizedSendersMethod replaces the list of accounts allowed to
+	// fulfill requests on an Operator contract. Sent by the contract owner.
+	SetAuthorizedSendersMethod = "setAuthorizedSenders"
+	// TransferAdminMethod proposes a new admin for an oracle on a
+	// FluxAggregator. Sent by the oracle's current admin.
+	TransferAdminMethod = "transferAdmin"
+	// AcceptAdminMethod completes an admin transfer on a FluxAggregator.
+	// Sent by the proposed admin.
+	AcceptAdminMethod = "acceptAdmin"
+)
+
+// registrationABI holds only the administrative methods used to register a
+// node with oracle, operator and aggregator contracts.
+const registrationABI = `[
+{"type":"function","name":"setFulfillmentPermission","stateMutability":"nonpayable","inputs":[{"name":"node","type":"address"},{"name":"allowed","type":"bool"}],"outputs":[]},
+{"type":"function","name":"setAuthorizedSenders","stateMutability":"nonpayable","inputs":[{"name":"senders","type":"address[]"}],"outputs":[]},
+{"type":"function","name":"transferAdmin","stateMutability":"nonpayable","inputs":[{"name":"_oracle","type":"address"},{"name":"_newAdmin","type":"address"}],"outputs":[]},
+{"type":"function","name":"acceptAdmin","stateMutability":"nonpayable","inputs":[{"name":"_oracle","type":"address"}],"outputs":[]}
+]`
+
+var registrationCodec = mustParseRegistrationABI()
+
+func mustParseRegistrationABI() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(registrationABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// RegistrationCall is an unsigned call to an administrative contract method.
+type RegistrationCall struct {
+	Method string
+	To     common.Address
+	Data   []byte
+}
+
+// RegistrationParams describes the calls to build for a registration method.
+// Nodes are the node accounts being registered, Oracle and NewAdmin are only
+// used by the FluxAggregator admin flows.
+type RegistrationParams struct {
+	Method   string
+	Contract common.Address
+	Nodes    []common.Address
+	Oracle   common.Address
+	NewAdmin common.Address
+}
+
+// NewRegistrationCalls returns the calls needed to perform the given
+// registration method. setFulfillmentPermission produces one call per node
+// account, every other method produces a single call.
+func NewRegistrationCalls(p RegistrationParams) ([]RegistrationCall, error) {
+	if p.Contract == utils.ZeroAddress {
+		return nil, errors.New("contract address is required")
+	}
+
+	var calls []RegistrationCall
+	appendCall := func(args ...interface{}) error {
+		data, err := registrationCodec.Pack(p.Method, args...)
+		if err != nil {
+			return errors.Wrapf(err, "while encoding %s", p.Method)
+		}
+		calls = append(calls, RegistrationCall{Method: p.Method, To: p.Contract, Data: data})
+		return nil
+	}
+
+	switch p.Method {
+	case SetFulfillmentPermissionMethod:
+		if len(p.Nodes) == 0 {
+			return nil, errors.New("at least one node account is required")
+		}
+		for _, node := range p.Nodes {
+			if err := appendCall(node, true); err != nil {
+				return nil, err
+			}
+		}
+	case SetAuthorizedSendersMethod:
+		if len(p.Nodes) == 0 {
+			return nil, errors.New("at least one node account is required")
+		}
+		if err := appendCall(p.Nodes); err != nil {
+			return nil, err
+		}
+	case TransferAdminMethod:
+		if p.NewAdmin == utils.ZeroAddress {
+			return nil, errors.New("newAdmin is required for transferAdmin")
+		}
+		if err := appendCall(p.Oracle, p.NewAdmin); err != nil {
+			return nil, err
+		}
+	case AcceptAdminMethod:
+		if err := appendCall(p.Oracle); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported registration method %q", p.Method)
+	}
+	return calls, nil
+}
@@ -0,0 +1,108 @@
+package contracts_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistrationCalls(t *testing.T) {
+	t.Parallel()
+
+	contract := cltest.NewAddress()
+	node1, node2 := cltest.NewAddress(), cltest.NewAddress()
+	newAdmin := cltest.NewAddress()
+
+	selector := func(signature string) []byte {
+		hash, err := utils.Keccak256([]byte(signature))
+		require.NoError(t, err)
+		return hash[:4]
+	}
+	word := func(a common.Address) []byte {
+		return common.LeftPadBytes(a.Bytes(), 32)
+	}
+	concat := func(parts ...[]byte) []byte {
+		var out []byte
+		for _, p := range parts {
+			out = append(out, p...)
+		}
+		return out
+	}
+	trueWord := common.LeftPadBytes([]byte{1}, 32)
+
+	t.Run("setFulfillmentPermission produces a call per node", func(t *testing.T) {
+		calls, err := contracts.NewRegistrationCalls(contracts.RegistrationParams{
+			Method:   contracts.SetFulfillmentPermissionMethod,
+			Contract: contract,
+			Nodes:    []common.Address{node1, node2},
+		})
+		require.NoError(t, err)
+		require.Len(t, calls, 2)
+		for i, node := range []common.Address{node1, node2} {
+			assert.Equal(t, contract, calls[i].To)
+			assert.Equal(t, concat(selector("setFulfillmentPermission(address,bool)"), word(node), trueWord), calls[i].Data)
+		}
+	})
+
+	t.Run("setAuthorizedSenders sends every node in one call", func(t *testing.T) {
+		calls, err := contracts.NewRegistrationCalls(contracts.RegistrationParams{
+			Method:   contracts.SetAuthorizedSendersMethod,
+			Contract: contract,
+			Nodes:    []common.Address{node1, node2},
+		})
+		require.NoError(t, err)
+		require.Len(t, calls, 1)
+		assert.Equal(t, concat(
+			selector("setAuthorizedSenders(address[])"),
+			utils.EVMWordUint64(32),
+			utils.EVMWordUint64(2),
+			word(node1),
+			word(node2),
+		), calls[0].Data)
+	})
+
+	t.Run("transferAdmin and acceptAdmin", func(t *testing.T) {
+		calls, err := contracts.NewRegistrationCalls(contracts.RegistrationParams{
+			Method:   contracts.TransferAdminMethod,
+			Contract: contract,
+			Oracle:   node1,
+			NewAdmin: newAdmin,
+		})
+		require.NoError(t, err)
+		require.Len(t, calls, 1)
+		assert.Equal(t, concat(selector("transferAdmin(address,address)"), word(node1), word(newAdmin)), calls[0].Data)
+
+		calls, err = contracts.NewRegistrationCalls(contracts.RegistrationParams{
+			Method:   contracts.AcceptAdminMethod,
+			Contract: contract,
+			Oracle:   node1,
+		})
+		require.NoError(t, err)
+		require.Len(t, calls, 1)
+		assert.Equal(t, concat(selector("acceptAdmin(address)"), word(node1)), calls[0].Data)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			params contracts.RegistrationParams
+		}{
+			{"missing contract", contracts.RegistrationParams{Method: contracts.AcceptAdminMethod, Oracle: node1}},
+			{"no nodes", contracts.RegistrationParams{Method: contracts.SetAuthorizedSendersMethod, Contract: contract}},
+			{"missing new admin", contracts.RegistrationParams{Method: contracts.TransferAdminMethod, Contract: contract, Oracle: node1}},
+			{"unknown method", contracts.RegistrationParams{Method: "transferOwnership", Contract: contract}},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				_, err := contracts.NewRegistrationCalls(test.params)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
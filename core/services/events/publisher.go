@@ -0,0 +1,237 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/jpillora/backoff"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// pollInterval is how often the outbox is checked for events in case
+	// a notification from the database was missed
+	pollInterval = 5 * time.Second
+	// batchSize is the most events loaded from the outbox at once
+	batchSize = 100
+
+	// Postgres channel the node_events triggers notify on
+	postgresInsertOnNodeEvents = "insert_on_node_events"
+)
+
+var (
+	promEventsPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "node_events_published_total",
+		Help: "The number of node events delivered to the event sink, or discarded if there is none",
+	},
+		[]string{"type"},
+	)
+)
+
+// Sink receives the node's events. Publish must only return nil once the
+// event has been durably accepted, as it is then removed from the outbox.
+type Sink interface {
+	Publish(event models.NodeEvent) error
+}
+
+// NewSink returns a Sink for the given URL. Only http and https webhooks are
+// supported; a nil URL discards every event.
+func NewSink(u *url.URL, timeout time.Duration) (Sink, error) {
+	if u == nil {
+		return discardSink{}, nil
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return &webhookSink{url: *u, client: &http.Client{Timeout: timeout}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported event sink scheme %q, must be http or https", u.Scheme)
+	}
+}
+
+type discardSink struct{}
+
+func (discardSink) Publish(models.NodeEvent) error { return nil }
+
+// webhookSink POSTs each event as JSON, and considers it delivered on any 2xx
+// response.
+type webhookSink struct {
+	url    url.URL
+	client *http.Client
+}
+
+func (w *webhookSink) Publish(event models.NodeEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, w.url.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Chainlink-Event-Type", event.Type)
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer logger.ErrorIfCalling(response.Body.Close)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("event sink responded with %s", response.Status)
+	}
+	return nil
+}
+
+// Publisher delivers the events which the database records in node_events to
+// a Sink, oldest first. Delivery is at least once: an event whose delivery
+// errors is retried until it succeeds, so consumers should deduplicate on the
+// event ID.
+type Publisher interface {
+	Start() error
+	Stop() error
+}
+
+type publisher struct {
+	orm      *orm.ORM
+	sink     Sink
+	sinkErr  error
+	enabled  bool
+	listener *utils.PostgresEventListener
+	backoff  backoff.Backoff
+	started  bool
+
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewPublisher returns a Publisher for the configured event sink. Without
+// one, the database stops recording events, and any it recorded before are
+// discarded so that the outbox does not grow.
+func NewPublisher(orm *orm.ORM, config orm.ConfigReader) Publisher {
+	sink, err := NewSink(config.EventSinkURL(), config.DefaultHTTPTimeout().Duration())
+	p := newPublisher(orm, sink)
+	p.sinkErr = err
+	p.enabled = config.EventSinkURL() != nil
+	if p.enabled {
+		p.listener = &utils.PostgresEventListener{
+			URI:                  config.DatabaseURL(),
+			Event:                postgresInsertOnNodeEvents,
+			MinReconnectInterval: 1 * time.Second,
+			MaxReconnectDuration: 1 * time.Minute,
+		}
+	}
+	return p
+}
+
+// NewPublisherWithSink returns a Publisher which polls for events and
+// delivers them to sink.
+func NewPublisherWithSink(orm *orm.ORM, sink Sink) Publisher {
+	p := newPublisher(orm, sink)
+	p.enabled = true
+	return p
+}
+
+func newPublisher(orm *orm.ORM, sink Sink) *publisher {
+	return &publisher{
+		orm:  orm,
+		sink: sink,
+		backoff: backoff.Backoff{
+			Min: 1 * time.Second,
+			Max: 5 * time.Minute,
+		},
+		chStop: make(chan struct{}),
+	}
+}
+
+// Start begins delivering events.
+func (p *publisher) Start() error {
+	if p.sinkErr != nil {
+		return p.sinkErr
+	}
+	if err := p.orm.SetNodeEventsEnabled(p.enabled); err != nil {
+		return errors.Wrap(err, "could not enable node events")
+	}
+	var notifications <-chan string
+	if p.listener != nil {
+		if err := p.listener.Start(); err != nil {
+			return errors.Wrap(err, "could not listen for node events")
+		}
+		notifications = p.listener.Events()
+	}
+	p.wg.Add(1)
+	go p.run(notifications)
+	p.started = true
+	return nil
+}
+
+// Stop waits for the event being delivered, if any, and stops.
+func (p *publisher) Stop() error {
+	if !p.started {
+		return nil
+	}
+	close(p.chStop)
+	p.wg.Wait()
+	if p.listener != nil {
+		return p.listener.Stop()
+	}
+	return nil
+}
+
+func (p *publisher) run(notifications <-chan string) {
+	defer p.wg.Done()
+	for {
+		wait, wake := pollInterval, notifications
+		if err := p.publishAll(); err != nil {
+			// New events must not cut the backoff short while the sink is down
+			wait, wake = p.backoff.Duration(), nil
+			logger.Warnw("Failed to publish node events", "error", err, "retry_in", wait)
+		} else {
+			p.backoff.Reset()
+		}
+
+		select {
+		case <-p.chStop:
+			return
+		case <-wake:
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (p *publisher) publishAll() error {
+	for {
+		events, err := p.orm.UnpublishedNodeEvents(batchSize)
+		if err != nil {
+			return errors.Wrap(err, "could not load node events")
+		}
+		for _, event := range events {
+			select {
+			case <-p.chStop:
+				return nil
+			default:
+			}
+			if err := p.sink.Publish(event); err != nil {
+				return errors.Wrapf(err, "could not publish node event %d", event.ID)
+			}
+			promEventsPublished.WithLabelValues(event.Type).Inc()
+			if err := p.orm.DeleteNodeEvent(event.ID); err != nil {
+				return errors.Wrapf(err, "could not delete published node event %d", event.ID)
+			}
+		}
+		if len(events) < batchSize {
+			return nil
+		}
+	}
+}
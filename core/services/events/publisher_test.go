@@ -0,0 +1,144 @@
+package events_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/events"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	err    error
+	events []models.NodeEvent
+}
+
+func (s *recordingSink) Publish(event models.NodeEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingSink) Events() []models.NodeEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]models.NodeEvent{}, s.events...)
+}
+
+func TestPublisher_PublishesNodeEvents(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, store.SetNodeEventsEnabled(true))
+
+	key := cltest.MustInsertRandomKey(t, store)
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	// Tests run in a single transaction, so fire the triggers deferred to commit
+	require.NoError(t, store.DB.Exec("SET CONSTRAINTS ALL IMMEDIATE").Error)
+
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+	run.TaskRuns[0].Status = models.RunStatusCompleted
+	run.SetStatus(models.RunStatusCompleted)
+	require.NoError(t, store.SaveJobRun(&run))
+	require.NoError(t, store.ArchiveJob(job.ID))
+
+	sink := &recordingSink{}
+	publisher := events.NewPublisherWithSink(store.ORM, sink)
+	require.NoError(t, publisher.Start())
+	defer func() { assert.NoError(t, publisher.Stop()) }()
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() []models.NodeEvent { return sink.Events() }).Should(gomega.HaveLen(4))
+
+	published := sink.Events()
+	assert.Equal(t, models.NodeEventKeyCreated, published[0].Type)
+	assert.Equal(t, strings.ToLower(key.Address.Hex()), published[0].Payload.Get("address").String())
+	assert.Equal(t, models.NodeEventJobSpecCreated, published[1].Type)
+	assert.Equal(t, job.ID.String(), published[1].Payload.Get("id").String())
+	assert.Equal(t, string(adapters.TaskTypeNoOp), published[1].Payload.Get("tasks.0.type").String())
+	assert.Equal(t, models.NodeEventJobRunCompleted, published[2].Type)
+	assert.Equal(t, run.ID.String(), published[2].Payload.Get("id").String())
+	assert.Equal(t, models.NodeEventJobSpecDeleted, published[3].Type)
+	for _, event := range published {
+		assert.Equal(t, 1, event.Version)
+	}
+
+	g.Eventually(func() (int, error) { return store.CountOf(&models.NodeEvent{}) }).Should(gomega.Equal(0))
+}
+
+func TestPublisher_KeepsEventsUntilPublished(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, store.SetNodeEventsEnabled(true))
+
+	cltest.MustInsertRandomKey(t, store)
+
+	sink := &recordingSink{err: errors.New("sink unavailable")}
+	publisher := events.NewPublisherWithSink(store.ORM, sink)
+	require.NoError(t, publisher.Start())
+	defer func() { assert.NoError(t, publisher.Stop()) }()
+
+	gomega.NewGomegaWithT(t).Consistently(func() (int, error) {
+		return store.CountOf(&models.NodeEvent{})
+	}).Should(gomega.Equal(1))
+	assert.Empty(t, sink.Events())
+}
+
+func TestNodeEvents_JobSpecReplaced(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, store.SetNodeEventsEnabled(true))
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	require.NoError(t, store.DB.Exec("SET CONSTRAINTS ALL IMMEDIATE").Error)
+
+	replacement := cltest.NewJobWithWebInitiator()
+	replacement.ID = job.ID
+	replacement.Tasks = append(replacement.Tasks, models.TaskSpec{Type: adapters.TaskTypeNoOp})
+	require.NoError(t, store.ReplaceJobSpec(&replacement))
+
+	var updated []models.NodeEvent
+	require.NoError(t, store.DB.Where("type = ?", models.NodeEventJobSpecUpdated).Find(&updated).Error)
+	require.Len(t, updated, 1)
+	assert.Equal(t, job.ID.String(), updated[0].Payload.Get("id").String())
+	assert.Len(t, updated[0].Payload.Get("tasks").Array(), 2, "superseded tasks should be left out")
+	assert.Len(t, updated[0].Payload.Get("initiators").Array(), 1)
+}
+
+func TestNodeEvents_DisabledWithoutSink(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	cltest.MustInsertRandomKey(t, store)
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	require.NoError(t, store.DB.Exec("SET CONSTRAINTS ALL IMMEDIATE").Error)
+	require.NoError(t, store.ArchiveJob(job.ID))
+
+	count, err := store.CountOf(&models.NodeEvent{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,358 @@
+package fleetsync
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// Source returns the fleet bundle which the node keeps itself in sync with.
+type Source interface {
+	Bundle() (models.FleetBundle, error)
+}
+
+// NewSource returns a Source which fetches the bundle from u. u may be a
+// static file, or the /v2/fleet_bundle endpoint of the node which is the
+// fleet's source of truth, in which case an API access key and secret of
+// that node must be given.
+func NewSource(u url.URL, accessKey, secret string, timeout time.Duration) Source {
+	return &httpSource{
+		url:       u,
+		accessKey: accessKey,
+		secret:    secret,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+type httpSource struct {
+	url       url.URL
+	accessKey string
+	secret    string
+	client    *http.Client
+}
+
+func (h *httpSource) Bundle() (models.FleetBundle, error) {
+	var bundle models.FleetBundle
+	err := getJSON(h.client, h.url, h.accessKey, h.secret, &bundle)
+	return bundle, errors.Wrap(err, "fleet bundle source")
+}
+
+// getJSON decodes the JSON response to a GET of u, authenticated with the
+// API access key and secret of the node serving it if given.
+func getJSON(client *http.Client, u url.URL, accessKey, secret string, v interface{}) error {
+	request, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	if accessKey != "" {
+		request.Header.Set("X-API-KEY", accessKey)
+		request.Header.Set("X-API-SECRET", secret)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer logger.ErrorIfCalling(response.Body.Close)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("responded with %s", response.Status)
+	}
+	return errors.Wrap(json.NewDecoder(response.Body).Decode(v), "invalid response")
+}
+
+// JobManager adds, updates and archives the jobs of the fleet bundle.
+type JobManager interface {
+	AddJob(job models.JobSpec) error
+	UpdateJob(job models.JobSpec) error
+	ArchiveJob(ID *models.ID) error
+}
+
+// Syncer keeps the node's bridges, external initiators and jobs in sync with
+// a fleet bundle. Each sync adds what is new to the bundle, updates what has
+// changed, and removes what has left the bundle since it was synced. Resources created on the node itself are never
+// removed, but one which shares its name, or for jobs its ID, with one in the
+// bundle is taken over and kept in sync from then on.
+//
+// Synced resources belong to the default namespace. External initiators are
+// not notified of the jobs which fleet sync adds for them, as they already
+// know of those jobs through the node which is the source of truth.
+type Syncer interface {
+	Start() error
+	Stop() error
+	Sync() error
+}
+
+type syncer struct {
+	store    *store.Store
+	jobs     JobManager
+	source   Source
+	interval time.Duration
+	started  bool
+
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSyncer returns a Syncer for the configured FLEET_SYNC_URL. Without
+// one, the Syncer does nothing.
+func NewSyncer(store *store.Store, jobs JobManager) Syncer {
+	config := store.Config
+	var source Source
+	if u := config.FleetSyncURL(); u != nil {
+		source = NewSource(*u, config.FleetSyncAccessKey(), config.FleetSyncSecret(), config.DefaultHTTPTimeout().Duration())
+	}
+	return NewSyncerWithSource(store, jobs, source, config.FleetSyncInterval().Duration())
+}
+
+// NewSyncerWithSource returns a Syncer which syncs to the bundle from source
+// every interval.
+func NewSyncerWithSource(store *store.Store, jobs JobManager, source Source, interval time.Duration) Syncer {
+	return &syncer{
+		store:    store,
+		jobs:     jobs,
+		source:   source,
+		interval: interval,
+		chStop:   make(chan struct{}),
+	}
+}
+
+// Start syncs straight away, and then every interval.
+func (s *syncer) Start() error {
+	if s.source == nil {
+		return nil
+	}
+	if s.interval <= 0 {
+		return fmt.Errorf("fleet sync interval must be positive, got %s", s.interval)
+	}
+	s.wg.Add(1)
+	go s.run()
+	s.started = true
+	return nil
+}
+
+// Stop waits for the sync in progress, if any, and stops.
+func (s *syncer) Stop() error {
+	if !s.started {
+		return nil
+	}
+	close(s.chStop)
+	s.wg.Wait()
+	return nil
+}
+
+func (s *syncer) run() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sync(); err != nil {
+			logger.Warnw("Fleet sync failed", "error", err)
+		}
+		select {
+		case <-s.chStop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync fetches the bundle and applies it. A resource which cannot be synced
+// does not stop the others from being synced.
+func (s *syncer) Sync() error {
+	if s.source == nil {
+		return errors.New("fleet sync is not configured")
+	}
+	bundle, err := s.source.Bundle()
+	if err != nil {
+		return errors.Wrap(err, "could not fetch fleet bundle")
+	}
+
+	// Bridges and external initiators are added before the jobs which use
+	// them, and removed after those jobs are archived
+	var merr error
+	bridges := map[string]bool{}
+	for _, b := range bundle.Bridges {
+		bridges[b.Name.String()] = true
+		merr = multierr.Append(merr, errors.Wrapf(s.upsertBridge(b), "could not sync bridge %s", b.Name))
+	}
+	eis := map[string]bool{}
+	for _, e := range bundle.ExternalInitiators {
+		eis[e.Name] = true
+		merr = multierr.Append(merr, errors.Wrapf(s.upsertExternalInitiator(e), "could not sync external initiator %s", e.Name))
+	}
+	jobs := map[string]bool{}
+	for _, j := range bundle.Jobs {
+		if j.ID == nil {
+			merr = multierr.Append(merr, errors.New("could not sync job without an id"))
+			continue
+		}
+		jobs[j.ID.String()] = true
+		merr = multierr.Append(merr, errors.Wrapf(s.upsertJob(j), "could not sync job %s", j.ID))
+	}
+
+	return multierr.Combine(
+		merr,
+		s.removeUnbundled(models.FleetSyncKindJob, jobs, s.removeJob),
+		s.removeUnbundled(models.FleetSyncKindBridge, bridges, s.removeBridge),
+		s.removeUnbundled(models.FleetSyncKindExternalInitiator, eis, s.store.DeleteExternalInitiator),
+	)
+}
+
+func (s *syncer) upsertBridge(b models.FleetBridge) error {
+	bt, err := s.store.FindBridge(b.Name)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		bt = models.BridgeType{Namespace: models.DefaultNamespace}
+		b.Apply(&bt)
+		if err := s.store.CreateBridgeType(&bt); err != nil {
+			return err
+		}
+		logger.Infow("Fleet sync added bridge", "bridge", b.Name)
+	} else if err != nil {
+		return err
+	} else if bt.Namespace != models.DefaultNamespace {
+		return fmt.Errorf("bridge %s belongs to namespace %s", b.Name, bt.Namespace)
+	} else if !sameJSON(models.NewFleetBridge(bt), b) {
+		b.Apply(&bt)
+		if err := s.store.SaveBridgeType(&bt); err != nil {
+			return err
+		}
+		logger.Infow("Fleet sync updated bridge", "bridge", b.Name)
+	}
+	return s.store.TrackFleetSyncResource(models.FleetSyncKindBridge, b.Name.String())
+}
+
+func (s *syncer) upsertExternalInitiator(e models.FleetExternalInitiator) error {
+	ei, err := s.store.FindExternalInitiatorByName(e.Name)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		ei = models.ExternalInitiator{Namespace: models.DefaultNamespace}
+		e.Apply(&ei)
+		if err := s.store.CreateExternalInitiator(&ei); err != nil {
+			return err
+		}
+		logger.Infow("Fleet sync added external initiator", "name", e.Name)
+	} else if err != nil {
+		return err
+	} else if ei.Namespace != models.DefaultNamespace {
+		return fmt.Errorf("external initiator %s belongs to namespace %s", e.Name, ei.Namespace)
+	} else if !sameJSON(models.NewFleetExternalInitiator(ei), e) {
+		e.Apply(&ei)
+		if err := s.store.SaveExternalInitiator(&ei); err != nil {
+			return err
+		}
+		logger.Infow("Fleet sync updated external initiator", "name", e.Name)
+	}
+	return s.store.TrackFleetSyncResource(models.FleetSyncKindExternalInitiator, e.Name)
+}
+
+// upsertJob adds a job which the node does not have, and replaces the spec
+// of one it has whose canonical spec differs from the bundled one, keeping
+// its runs. A job which the node has archived is neither added again nor
+// updated.
+func (s *syncer) upsertJob(j models.FleetJob) error {
+	job := j.JobSpec()
+	job.Namespace = models.DefaultNamespace
+	existing, err := s.store.Unscoped().FindJob(j.ID)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		if err := services.ValidateJob(job, s.store); err != nil {
+			return err
+		}
+		if err := s.jobs.AddJob(job); err != nil {
+			return err
+		}
+		logger.Infow("Fleet sync added job", "job", j.ID.String())
+	} else if err != nil {
+		return err
+	} else if existing.Namespace != models.DefaultNamespace {
+		return fmt.Errorf("job %s belongs to namespace %s", j.ID, existing.Namespace)
+	} else if !existing.Archived() {
+		same, err := models.SameJobSpec(existing, job)
+		if err != nil {
+			return err
+		} else if !same {
+			if err := services.ValidateJob(job, s.store); err != nil {
+				return err
+			}
+			if err := s.jobs.UpdateJob(job); err != nil {
+				return err
+			}
+			logger.Infow("Fleet sync updated job", "job", j.ID.String())
+		}
+	}
+	return s.store.TrackFleetSyncResource(models.FleetSyncKindJob, j.ID.String())
+}
+
+func (s *syncer) removeJob(name string) error {
+	id, err := models.NewIDFromString(name)
+	if err != nil {
+		return err
+	}
+	if _, err := s.store.FindJob(id); errors.Cause(err) == orm.ErrorNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return s.jobs.ArchiveJob(id)
+}
+
+func (s *syncer) removeBridge(name string) error {
+	taskType, err := models.NewTaskType(name)
+	if err != nil {
+		return err
+	}
+	bt, err := s.store.FindBridge(taskType)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	inUse, err := s.store.AnyJobWithType(name)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.New("jobs still use it")
+	}
+	return s.store.DeleteBridgeType(&bt)
+}
+
+// removeUnbundled removes the resources of a kind which fleet sync manages
+// but are no longer in the bundle.
+func (s *syncer) removeUnbundled(kind string, bundled map[string]bool, remove func(name string) error) error {
+	names, err := s.store.FleetSyncResources(kind)
+	if err != nil {
+		return err
+	}
+	var merr error
+	for _, name := range names {
+		if bundled[name] {
+			continue
+		}
+		if err := remove(name); err != nil {
+			merr = multierr.Append(merr, errors.Wrapf(err, "could not remove %s %s", kind, name))
+			continue
+		}
+		merr = multierr.Append(merr, s.store.UntrackFleetSyncResource(kind, name))
+		logger.Infow("Fleet sync removed resource", "kind", kind, "name", name)
+	}
+	return merr
+}
+
+// sameJSON compares two resources as they are bundled, which sidesteps the
+// pointers and big numbers within them.
+func sameJSON(a, b interface{}) bool {
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+	return aerr == nil && berr == nil && string(aj) == string(bj)
+}
@@ -0,0 +1,176 @@
+package fleetsync_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/fleetsync"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticSource struct {
+	bundle models.FleetBundle
+	err    error
+}
+
+func (s *staticSource) Bundle() (models.FleetBundle, error) {
+	return s.bundle, s.err
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	store := app.Store
+
+	_, localBridge := cltest.NewBridgeType(t, "localbridge", "http://local.example.com")
+	require.NoError(t, store.CreateBridgeType(localBridge))
+
+	bridgeName, err := models.NewTaskType("fleetbridge")
+	require.NoError(t, err)
+	bridge := models.FleetBridge{
+		Name:          bridgeName,
+		URL:           cltest.WebURL(t, "http://bridge.example.com"),
+		Confirmations: 1,
+		OutgoingToken: "outgoing",
+	}
+	ei := models.FleetExternalInitiator{
+		Name:           "fleetei",
+		AccessKey:      "accesskey",
+		Salt:           "salt",
+		HashedSecret:   "hashedsecret",
+		OutgoingSecret: "outgoingsecret",
+		OutgoingToken:  "outgoingtoken",
+	}
+	job := models.FleetJob{
+		ID: models.NewID(),
+		Job: models.JobSpecRequest{
+			Initiators: []models.InitiatorRequest{{Type: models.InitiatorWeb}},
+			Tasks: []models.TaskSpecRequest{
+				{Type: bridgeName},
+				{Type: adapters.TaskTypeNoOp},
+			},
+		},
+	}
+	source := &staticSource{bundle: models.FleetBundle{
+		Bridges:            []models.FleetBridge{bridge},
+		ExternalInitiators: []models.FleetExternalInitiator{ei},
+		Jobs:               []models.FleetJob{job},
+	}}
+	syncer := fleetsync.NewSyncerWithSource(store, app, source, time.Minute)
+
+	require.NoError(t, syncer.Sync())
+
+	bt, err := store.FindBridge(bridgeName)
+	require.NoError(t, err)
+	assert.Equal(t, "http://bridge.example.com", bt.URL.String())
+	assert.Equal(t, models.DefaultNamespace, bt.Namespace)
+	exi, err := store.FindExternalInitiatorByName("fleetei")
+	require.NoError(t, err)
+	assert.Equal(t, "hashedsecret", exi.HashedSecret)
+	js, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobSpecStatusActive, js.Status)
+	require.Len(t, js.Tasks, 2)
+
+	// Syncing an unchanged bundle changes nothing
+	require.NoError(t, syncer.Sync())
+	jobs, err := store.FleetSyncResources(models.FleetSyncKindJob)
+	require.NoError(t, err)
+	assert.Equal(t, []string{job.ID.String()}, jobs)
+	versions, err := store.JobSpecVersions(job.ID)
+	require.NoError(t, err)
+	assert.Len(t, versions, 1)
+
+	// A job whose spec has changed in the bundle is updated
+	job.Job.Tasks = append(job.Job.Tasks, models.TaskSpecRequest{Type: adapters.TaskTypeNoOp})
+	source.bundle.Jobs = []models.FleetJob{job}
+	require.NoError(t, syncer.Sync())
+	js, err = store.FindJob(job.ID)
+	require.NoError(t, err)
+	require.Len(t, js.Tasks, 3)
+	versions, err = store.JobSpecVersions(job.ID)
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	bridge.URL = cltest.WebURL(t, "http://moved.example.com")
+	source.bundle.Bridges = []models.FleetBridge{bridge}
+	require.NoError(t, syncer.Sync())
+	bt, err = store.FindBridge(bridgeName)
+	require.NoError(t, err)
+	assert.Equal(t, "http://moved.example.com", bt.URL.String())
+
+	source.bundle = models.FleetBundle{}
+	require.NoError(t, syncer.Sync())
+
+	_, err = store.FindJob(job.ID)
+	assert.Equal(t, orm.ErrorNotFound, pkgerrors.Cause(err))
+	_, err = store.FindBridge(bridgeName)
+	assert.Equal(t, orm.ErrorNotFound, pkgerrors.Cause(err))
+	_, err = store.FindExternalInitiatorByName("fleetei")
+	assert.Equal(t, orm.ErrorNotFound, pkgerrors.Cause(err))
+	_, err = store.FindBridge(localBridge.Name)
+	assert.NoError(t, err, "bridges created on the node are not removed")
+
+	// A job archived on the node is not added back
+	source.bundle.Jobs = []models.FleetJob{job}
+	require.NoError(t, syncer.Sync())
+	_, err = store.FindJob(job.ID)
+	assert.Equal(t, orm.ErrorNotFound, pkgerrors.Cause(err))
+}
+
+func TestSyncer_Sync_OtherNamespace(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	require.NoError(t, store.CreateNamespace(&models.Namespace{Name: "team-a"}))
+	_, teamBridge := cltest.NewBridgeType(t, "teambridge", "http://team.example.com")
+	teamBridge.Namespace = "team-a"
+	require.NoError(t, store.CreateBridgeType(teamBridge))
+
+	source := &staticSource{bundle: models.FleetBundle{
+		Bridges: []models.FleetBridge{{
+			Name: teamBridge.Name,
+			URL:  cltest.WebURL(t, "http://bridge.example.com"),
+		}},
+	}}
+	syncer := fleetsync.NewSyncerWithSource(store, nil, source, time.Minute)
+
+	err := syncer.Sync()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "belongs to namespace team-a")
+
+	bt, err := store.FindBridge(teamBridge.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "http://team.example.com", bt.URL.String())
+	assert.Equal(t, "team-a", bt.Namespace)
+	bridges, err := store.FleetSyncResources(models.FleetSyncKindBridge)
+	require.NoError(t, err)
+	assert.Empty(t, bridges)
+}
+
+func TestSyncer_Sync_SourceError(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	source := &staticSource{err: errors.New("unreachable")}
+	syncer := fleetsync.NewSyncerWithSource(store, nil, source, time.Minute)
+
+	err := syncer.Sync()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unreachable")
+}
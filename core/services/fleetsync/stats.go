@@ -0,0 +1,56 @@
+package fleetsync
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// PeerStats are the stats of a peer node, or why they could not be fetched.
+type PeerStats struct {
+	URL   string            `json:"url"`
+	Stats *models.NodeStats `json:"stats,omitempty"`
+	Error string            `json:"error,omitempty"`
+}
+
+// FetchPeerStats fetches the stats of each peer from its /v2/stats
+// endpoint, all at once, authenticating with the API access key and secret
+// given as the username and password of its URL. The stats are returned in
+// the order of peers, with the credentials left out of their URLs.
+func FetchPeerStats(peers []url.URL, timeout time.Duration) []PeerStats {
+	client := &http.Client{Timeout: timeout}
+	results := make([]PeerStats, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(i int, peer url.URL) {
+			defer wg.Done()
+			results[i] = fetchPeerStats(client, peer)
+		}(i, peer)
+	}
+	wg.Wait()
+	return results
+}
+
+func fetchPeerStats(client *http.Client, peer url.URL) PeerStats {
+	var accessKey, secret string
+	if peer.User != nil {
+		accessKey = peer.User.Username()
+		secret, _ = peer.User.Password()
+		peer.User = nil
+	}
+	result := PeerStats{URL: peer.String()}
+
+	peer.Path = path.Join("/", peer.Path, "/v2/stats")
+	var stats models.NodeStats
+	if err := getJSON(client, peer, accessKey, secret, &stats); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Stats = &stats
+	return result
+}
@@ -2,16 +2,23 @@ package fluxmonitor
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/guregu/null"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -22,9 +29,9 @@ import (
 //go:generate mockery --name Fetcher --output ../../internal/mocks/ --case=underscore
 
 // Fetcher is the interface encapsulating all functionality needed to retrieve
-// a price.
+// a price. Fetching gives up once ctx is done.
 type Fetcher interface {
-	Fetch(map[string]interface{}) (decimal.Decimal, error)
+	Fetch(context.Context, map[string]interface{}) (decimal.Decimal, error)
 }
 
 // httpFetcher retrieves data via HTTP from an external price adapter source.
@@ -36,10 +43,14 @@ type httpFetcher struct {
 
 func newHTTPFetcher(
 	timeout models.Duration,
+	transport http.RoundTripper,
 	requestData map[string]interface{},
 	url *url.URL,
 ) Fetcher {
-	client := &http.Client{Timeout: timeout.Duration(), Transport: http.DefaultTransport}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client := &http.Client{Timeout: timeout.Duration(), Transport: transport}
 	client.Transport = promhttp.InstrumentRoundTripperDuration(promFMResponseTime, client.Transport)
 	client.Transport = instrumentRoundTripperReponseSize(promFMResponseSize, client.Transport)
 
@@ -50,14 +61,19 @@ func newHTTPFetcher(
 	}
 }
 
-func (p *httpFetcher) Fetch(meta map[string]interface{}) (decimal.Decimal, error) {
+func (p *httpFetcher) Fetch(ctx context.Context, meta map[string]interface{}) (decimal.Decimal, error) {
 	request := withIDAndMeta(p.requestData, meta)
 	body, err := json.Marshal(request)
 	if err != nil {
 		return decimal.Decimal{}, errors.Wrap(err, "error encoding request body as JSON")
 	}
 
-	r, err := p.client.Post(p.url.String(), "application/json", bytes.NewReader(body))
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url.String(), bytes.NewReader(body))
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, "error building request")
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	r, err := p.client.Do(httpRequest)
 	if err != nil {
 		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to fetch price from %s with payload '%s'", p.url.String(), p.requestData))
 	}
@@ -93,6 +109,65 @@ func (p *httpFetcher) String() string {
 	return fmt.Sprintf("http price fetcher: %s", p.url.String())
 }
 
+// Source returns the host of the fetcher's URL, which leaves out any
+// credentials in its path, query or user info.
+func (p *httpFetcher) Source() string {
+	return p.url.Host
+}
+
+// aggregatorFetcher reads the latest answer of another aggregator contract,
+// on the chain the node is connected to, so that a job can forward that
+// aggregator's answers to its own. Answers are scaled down by the
+// aggregator's decimals, as adapters' prices are. Answers last updated
+// longer ago than maxAge, if it is set, are stale and not forwarded.
+type aggregatorFetcher struct {
+	aggregator contracts.FluxAggregator
+	address    common.Address
+	maxAge     time.Duration
+}
+
+func newAggregatorFetcher(aggregator contracts.FluxAggregator, address common.Address, maxAge time.Duration) Fetcher {
+	return &aggregatorFetcher{aggregator: aggregator, address: address, maxAge: maxAge}
+}
+
+func (a *aggregatorFetcher) Fetch(context.Context, map[string]interface{}) (decimal.Decimal, error) {
+	roundData, err := a.aggregator.LatestRoundData()
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to fetch answer from aggregator %s", a.address.Hex()))
+	}
+	if roundData.UpdatedAt == nil || roundData.UpdatedAt.Sign() == 0 || roundData.Answer == nil {
+		return decimal.Decimal{}, fmt.Errorf("aggregator %s has no answer yet", a.address.Hex())
+	}
+	updatedAt := time.Unix(roundData.UpdatedAt.Int64(), 0)
+	if a.maxAge > 0 && time.Since(updatedAt) > a.maxAge {
+		return decimal.Decimal{}, fmt.Errorf("answer of aggregator %s is stale, it was last updated at %s", a.address.Hex(), updatedAt.UTC().Format(time.RFC3339))
+	}
+	decimals, err := a.aggregator.Decimals()
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to fetch decimals of aggregator %s", a.address.Hex()))
+	}
+
+	answer := decimal.NewFromBigInt(roundData.Answer, -int32(decimals))
+	answerFloat, _ := answer.Float64()
+	promFMIndividualReportedValue.WithLabelValues(a.address.Hex()).Set(answerFloat)
+	logger.Debugw(
+		fmt.Sprintf("fetched answer %v from aggregator %s", answer, a.address.Hex()),
+		"answer", answer,
+		"aggregator", a.address.Hex(),
+		"round", roundData.RoundID,
+	)
+	return answer, nil
+}
+
+func (a *aggregatorFetcher) String() string {
+	return fmt.Sprintf("aggregator fetcher: %s", a.address.Hex())
+}
+
+// Source returns the address of the aggregator.
+func (a *aggregatorFetcher) Source() string {
+	return a.address.Hex()
+}
+
 func withIDAndMeta(request, meta map[string]interface{}) map[string]interface{} {
 	output := make(map[string]interface{})
 	for k, v := range request {
@@ -118,31 +193,78 @@ func (pr adapterResponse) Result() *decimal.Decimal {
 	return pr.Data.Result
 }
 
+// partialFetcher is a Fetcher which may answer from a subset of its sources.
+type partialFetcher interface {
+	// FetchPartial returns the answer along with the sources excluded from
+	// it, as named by fetcherSource.
+	FetchPartial(context.Context, map[string]interface{}) (decimal.Decimal, []string, error)
+}
+
+// sourceFetcher is a Fetcher which can name its source without revealing
+// any credentials, such as API keys in a URL, which its String may include.
+type sourceFetcher interface {
+	Source() string
+}
+
+// fetcherSource names the source of fetcher, for recording in run requests,
+// metrics and logs.
+func fetcherSource(fetcher Fetcher) string {
+	if f, ok := fetcher.(sourceFetcher); ok {
+		return f.Source()
+	}
+	return fmt.Sprintf("%s", fetcher)
+}
+
 // medianFetcher fetches from all fetchers, and returns the median value, or
-// average if even number of results.
+// average if even number of results. Fetchers which have not answered by the
+// deadline of the context are dropped from the median, so that a slow source
+// cannot hold up the others.
+//
+// If minAnswers is positive, the median of the fetchers which answered is
+// returned when fetchers were dropped at the deadline, as long as at least
+// minAnswers fetchers answered and most of those which answered succeeded.
 type medianFetcher struct {
-	fetchers []Fetcher
+	fetchers   []Fetcher
+	minAnswers int
 }
 
 // newMedianFetcherFromURLs creates a median fetcher that retrieves a price
-// from all passed URLs using httpFetcher, and returns the median.
+// from all passed URLs using httpFetcher, and returns the median. Requests
+// are sent over transport, or the default transport if nil.
+//
+// If cache is non-nil, each httpFetcher shares its results with any other
+// fetcher using the same cache and making the same request. Any others are
+// included in the median along with the httpFetchers.
 func newMedianFetcherFromURLs(
 	timeout models.Duration,
+	transport http.RoundTripper,
 	requestData map[string]interface{},
 	priceURLs []*url.URL,
+	cache *fetchCache,
+	minAnswers int,
+	others ...Fetcher,
 ) (Fetcher, error) {
 	fetchers := []Fetcher{}
 	for _, url := range priceURLs {
-		ps := newHTTPFetcher(timeout, requestData, url)
+		ps := newHTTPFetcher(timeout, transport, requestData, url)
+		if cache != nil {
+			memoized, err := cache.memoize(ps, requestData, url)
+			if err != nil {
+				return nil, err
+			}
+			ps = memoized
+		}
 		fetchers = append(fetchers, ps)
 	}
+	fetchers = append(fetchers, others...)
 
-	medianFetcher, err := newMedianFetcher(fetchers...)
+	fetcher, err := newMedianFetcher(fetchers...)
 	if err != nil {
 		return nil, err
 	}
+	fetcher.(*medianFetcher).minAnswers = minAnswers
 
-	return medianFetcher, nil
+	return fetcher, nil
 }
 
 func newMedianFetcher(fetchers ...Fetcher) (Fetcher, error) {
@@ -154,44 +276,82 @@ func newMedianFetcher(fetchers ...Fetcher) (Fetcher, error) {
 	}, nil
 }
 
-func (m *medianFetcher) Fetch(meta map[string]interface{}) (decimal.Decimal, error) {
+func (m *medianFetcher) Fetch(ctx context.Context, meta map[string]interface{}) (decimal.Decimal, error) {
+	price, _, err := m.FetchPartial(ctx, meta)
+	return price, err
+}
+
+// FetchPartial returns the median along with the fetchers which errored or
+// were dropped at the deadline, and so were left out of it.
+func (m *medianFetcher) FetchPartial(ctx context.Context, meta map[string]interface{}) (decimal.Decimal, []string, error) {
 	prices := []decimal.Decimal{}
 	fetchErrors := []error{}
+	excluded := []string{}
 
 	type result struct {
+		index int
 		price decimal.Decimal
 		err   error
 	}
 
-	chResults := make(chan result)
-	for _, fetcher := range m.fetchers {
-		fetcher := fetcher
+	// Buffered so that fetchers answering after the deadline do not block
+	chResults := make(chan result, len(m.fetchers))
+	for i, fetcher := range m.fetchers {
+		i, fetcher := i, fetcher
 		go func() {
-			price, err := fetcher.Fetch(meta)
+			price, err := fetcher.Fetch(ctx, meta)
 			if err != nil {
 				logger.Error(err)
-				chResults <- result{err: err}
+				chResults <- result{index: i, err: err}
 			} else {
-				chResults <- result{price: price}
+				chResults <- result{index: i, price: price}
 			}
 		}()
 	}
 
+	answered := make([]bool, len(m.fetchers))
+collect:
 	for i := 0; i < len(m.fetchers); i++ {
-		r := <-chResults
-		if r.err != nil {
-			fetchErrors = append(fetchErrors, r.err)
-		} else {
-			prices = append(prices, r.price)
+		select {
+		case r := <-chResults:
+			if r.err == nil {
+				answered[r.index] = true
+				prices = append(prices, r.price)
+			} else if ctx.Err() == nil {
+				// Failing once the deadline has passed is taken to be
+				// the fetcher being cut off, so it counts as dropped
+				answered[r.index] = true
+				fetchErrors = append(fetchErrors, r.err)
+				excluded = append(excluded, fetcherSource(m.fetchers[r.index]))
+			}
+		case <-ctx.Done():
+			break collect
 		}
 	}
+	dropped := m.dropUnanswered(answered)
+	if len(dropped) > 0 {
+		fetchErrors = append(fetchErrors, fmt.Errorf("no answer before the deadline from %s", strings.Join(dropped, ", ")))
+		excluded = append(excluded, dropped...)
+	}
 
 	fetchersCount := len(m.fetchers)
-	fetchErrorsCount := len(fetchErrors)
+	fetchErrorsCount := fetchersCount - len(prices)
+	if len(dropped) > 0 && m.minAnswers > 0 && len(prices) >= m.minAnswers {
+		// Enough fetchers answered in time, so those cut off by the
+		// deadline do not count against the answer
+		fetchersCount -= len(dropped)
+		fetchErrorsCount -= len(dropped)
+	}
 	errorRate := float64(fetchErrorsCount) / float64(fetchersCount)
 	if errorRate >= 0.5 {
 		err := errors.Wrap(multierr.Combine(fetchErrors...), fmt.Sprintf("at least 50%% of the fetchers in median failed (%d/%d)", fetchErrorsCount, fetchersCount))
-		return decimal.Decimal{}, err
+		return decimal.Decimal{}, nil, err
+	}
+	if len(dropped) > 0 {
+		logger.Warnw("Answering from the price fetchers which answered before the deadline",
+			"answers", len(prices),
+			"excluded", excluded,
+		)
 	}
 
 	sort.Slice(prices, func(i, j int) bool {
@@ -199,9 +359,27 @@ func (m *medianFetcher) Fetch(meta map[string]interface{}) (decimal.Decimal, err
 	})
 	k := len(prices) / 2
 	if len(prices)%2 == 1 {
-		return prices[k], nil
+		return prices[k], excluded, nil
 	}
-	return prices[k].Add(prices[k-1]).Div(decimal.NewFromInt(2)), nil
+	return prices[k].Add(prices[k-1]).Div(decimal.NewFromInt(2)), excluded, nil
+}
+
+// dropUnanswered records the fetchers which did not answer in time, returning
+// their sources.
+func (m *medianFetcher) dropUnanswered(answered []bool) []string {
+	var dropped []string
+	for i, fetcher := range m.fetchers {
+		if answered[i] {
+			continue
+		}
+		source := fetcherSource(fetcher)
+		promFMFetchersDropped.WithLabelValues(source).Inc()
+		dropped = append(dropped, source)
+	}
+	if len(dropped) > 0 {
+		logger.Warnw("Dropped price fetchers which did not answer before the deadline", "dropped", dropped)
+	}
+	return dropped
 }
 
 func (m *medianFetcher) String() string {
@@ -211,3 +389,159 @@ func (m *medianFetcher) String() string {
 	}
 	return fmt.Sprintf("median fetcher: %s", strings.Join(fetcherDescriptions, ","))
 }
+
+// fetchCache holds recently fetched prices, keyed by a hash of the URL and
+// request data which produced them, so that jobs polling the same adapter
+// with the same request data within ttl of each other only hit it once.
+// Concurrent fetches for the same key share a single request.
+//
+// A shared request carries none of the meta of the job fetching it, such as
+// its latest answer, so that the price an adapter returns depends only on
+// what the key covers.
+type fetchCache struct {
+	ttl      time.Duration
+	mu       sync.Mutex
+	entries  map[string]fetchCacheEntry
+	inflight map[string]*sharedFetch
+}
+
+type fetchCacheEntry struct {
+	price     decimal.Decimal
+	expiresAt time.Time
+}
+
+// sharedFetch is a request shared by the concurrent fetches of a key. It is
+// made with a context of its own, which is only cancelled once every fetch
+// waiting for it has given up, so that it runs until the latest of their
+// deadlines rather than that of whichever fetch started it.
+type sharedFetch struct {
+	done    chan struct{}
+	price   decimal.Decimal
+	err     error
+	waiting int
+	cancel  context.CancelFunc
+}
+
+// newFetchCache returns a fetchCache holding prices for ttl, or nil if ttl is
+// not positive.
+func newFetchCache(ttl time.Duration) *fetchCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &fetchCache{
+		ttl:      ttl,
+		entries:  make(map[string]fetchCacheEntry),
+		inflight: make(map[string]*sharedFetch),
+	}
+}
+
+// memoize wraps fetcher so that its results are shared through the cache,
+// keyed by the URL and request data.
+func (c *fetchCache) memoize(fetcher Fetcher, requestData map[string]interface{}, url *url.URL) (Fetcher, error) {
+	body, err := json.Marshal(requestData)
+	if err != nil {
+		return nil, errors.Wrap(err, "error encoding request data as JSON")
+	}
+	digest := sha256.Sum256(append([]byte(url.String()+"\n"), body...))
+	return &memoizedFetcher{
+		fetcher: fetcher,
+		cache:   c,
+		key:     hex.EncodeToString(digest[:]),
+	}, nil
+}
+
+func (c *fetchCache) get(key string) (decimal.Decimal, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return decimal.Decimal{}, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return decimal.Decimal{}, false
+	}
+	return entry.price, true
+}
+
+func (c *fetchCache) set(key string, price decimal.Decimal) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = fetchCacheEntry{price: price, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// share joins the request in flight for key, starting one with fetch if
+// there is none, and returns its result, or ctx's error if ctx is done
+// first.
+func (c *fetchCache) share(ctx context.Context, key string, fetch func(context.Context) (decimal.Decimal, error)) (decimal.Decimal, error) {
+	c.mu.Lock()
+	shared, ok := c.inflight[key]
+	if !ok {
+		sharedCtx, cancel := context.WithCancel(context.Background())
+		shared = &sharedFetch{done: make(chan struct{}), cancel: cancel}
+		c.inflight[key] = shared
+		go func() {
+			price, err := fetch(sharedCtx)
+			if err == nil {
+				c.set(key, price)
+			}
+			c.mu.Lock()
+			if c.inflight[key] == shared {
+				delete(c.inflight, key)
+			}
+			shared.price, shared.err = price, err
+			close(shared.done)
+			c.mu.Unlock()
+			cancel()
+		}()
+	}
+	shared.waiting++
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		shared.waiting--
+		if shared.waiting == 0 {
+			// Later fetches start a request of their own
+			if c.inflight[key] == shared {
+				delete(c.inflight, key)
+			}
+			shared.cancel()
+		}
+		c.mu.Unlock()
+	}()
+	select {
+	case <-shared.done:
+		return shared.price, shared.err
+	case <-ctx.Done():
+		return decimal.Decimal{}, ctx.Err()
+	}
+}
+
+// memoizedFetcher returns a cached price for its key when one is fresh, and
+// otherwise fetches and caches a new one, without the meta of the fetch.
+// Errors are never cached.
+type memoizedFetcher struct {
+	fetcher Fetcher
+	cache   *fetchCache
+	key     string
+}
+
+func (m *memoizedFetcher) Fetch(ctx context.Context, _ map[string]interface{}) (decimal.Decimal, error) {
+	if price, ok := m.cache.get(m.key); ok {
+		promFMFetchCacheHits.Inc()
+		return price, nil
+	}
+	return m.cache.share(ctx, m.key, func(ctx context.Context) (decimal.Decimal, error) {
+		return m.fetcher.Fetch(ctx, map[string]interface{}{})
+	})
+}
+
+func (m *memoizedFetcher) String() string {
+	return fmt.Sprintf("memoized %v", m.fetcher)
+}
+
+// Source returns the source of the fetcher whose prices are cached.
+func (m *memoizedFetcher) Source() string {
+	return fetcherSource(m.fetcher)
+}
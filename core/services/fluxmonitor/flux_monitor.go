@@ -1,9 +1,11 @@
 package fluxmonitor
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/http"
 	"net/url"
 	"reflect"
 	"sync"
@@ -18,6 +20,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -85,6 +88,7 @@ func New(
 		checkerFactory: pollingDeviationCheckerFactory{
 			store:          store,
 			logBroadcaster: logBroadcaster,
+			fetchCache:     newFetchCache(store.Config.FluxMonitorFetchCacheTTL().Duration()),
 		},
 		chAdd:        make(chan addEntry),
 		chRemove:     make(chan models.ID),
@@ -118,7 +122,12 @@ func (fm *concreteFluxMonitor) Start() error {
 			defer wg.Done()
 
 			err := fm.AddJob(job)
-			if err != nil {
+			if models.IsInvalidSpecError(err) {
+				logger.Errorf("error adding FluxMonitor job, quarantining: %v", err)
+				logger.ErrorIf(fm.store.QuarantineJob(job.ID, err.Error()))
+			} else if err != nil {
+				// Transient errors, such as failing to reach the ethereum
+				// node, are not a reason to take the job out of service
 				logger.Errorf("error adding FluxMonitor job: %v", err)
 			}
 		}()
@@ -245,6 +254,18 @@ type DeviationCheckerFactory interface {
 type pollingDeviationCheckerFactory struct {
 	store          *store.Store
 	logBroadcaster eth.LogBroadcaster
+	fetchCache     *fetchCache
+}
+
+// transport returns the transport price requests are sent over, which keeps
+// them within the host limits, counts them towards provider quotas and
+// records the latencies of their sources along with the requests of the
+// node's other jobs.
+func (f pollingDeviationCheckerFactory) transport() http.RoundTripper {
+	config := f.store.Config
+	timed := utils.NewLatencyTrackingTransport(utils.SourceRequestLatencies, config.HTTPAdaptiveTimeoutMargin().Duration(), http.DefaultTransport)
+	counted := utils.NewProviderCountingTransport(utils.ProviderRequests, config.ProviderQuotas(), config.ProviderQuotaAlertPercent(), timed)
+	return utils.NewHostLimitedTransport(config.HTTPHostLimits(), counted)
 }
 
 func (f pollingDeviationCheckerFactory) New(
@@ -258,7 +279,7 @@ func (f pollingDeviationCheckerFactory) New(
 
 	if !initr.PollTimer.Disabled &&
 		initr.PollTimer.Period.Shorter(minimumPollingInterval) {
-		return nil, fmt.Errorf("pollTimer.period must be equal or greater than %s", minimumPollingInterval)
+		return nil, models.NewInvalidSpecError(fmt.Errorf("pollTimer.period must be equal or greater than %s", minimumPollingInterval))
 	}
 
 	urls, err := ExtractFeedURLs(initr.Feeds, orm)
@@ -266,17 +287,39 @@ func (f pollingDeviationCheckerFactory) New(
 		return nil, err
 	}
 
+	aggregators, err := ExtractFeedAggregators(initr.Feeds)
+	if err != nil {
+		return nil, models.NewInvalidSpecError(err)
+	}
+	// The answers of aggregators which have missed their heartbeat are stale
+	var maxAnswerAge time.Duration
+	if !initr.IdleTimer.Disabled {
+		maxAnswerAge = initr.IdleTimer.Duration.Duration()
+	}
+	var aggregatorFetchers []Fetcher
+	for _, address := range aggregators {
+		aggregator, err := contracts.NewFluxAggregator(address, f.store.EthClient, f.logBroadcaster)
+		if err != nil {
+			return nil, err
+		}
+		aggregatorFetchers = append(aggregatorFetchers, newAggregatorFetcher(aggregator, address, maxAnswerAge))
+	}
+
 	requestData, err := initr.RequestData.AsMap()
 	if err != nil {
-		return nil, err
+		return nil, models.NewInvalidSpecError(err)
 	}
 
 	fetcher, err := newMedianFetcherFromURLs(
 		timeout,
+		f.transport(),
 		requestData,
-		urls)
+		urls,
+		f.fetchCache,
+		int(initr.MinAnswers),
+		aggregatorFetchers...)
 	if err != nil {
-		return nil, err
+		return nil, models.NewInvalidSpecError(err)
 	}
 
 	f.logBroadcaster.AddDependents(1)
@@ -296,14 +339,17 @@ func (f pollingDeviationCheckerFactory) New(
 	)
 }
 
-// ExtractFeedURLs extracts a list of url.URLs from the feeds parameter of the initiator params
+// ExtractFeedURLs extracts a list of url.URLs from the feeds parameter of the
+// initiator params. Aggregator feeds, which are read on chain rather than
+// over HTTP, are skipped. Malformed feeds and unknown bridges are
+// InvalidSpecErrors.
 func ExtractFeedURLs(feeds models.Feeds, orm *orm.ORM) ([]*url.URL, error) {
 	var feedsData []interface{}
 	var urls []*url.URL
 
 	err := json.Unmarshal(feeds.Bytes(), &feedsData)
 	if err != nil {
-		return nil, err
+		return nil, models.NewInvalidSpecError(err)
 	}
 
 	for _, entry := range feedsData {
@@ -314,17 +360,24 @@ func ExtractFeedURLs(feeds models.Feeds, orm *orm.ORM) ([]*url.URL, error) {
 		case string: // feed url - ex: "http://example.com"
 			bridgeURL, err = url.ParseRequestURI(feed)
 		case map[string]interface{}: // named feed - ex: {"bridge": "bridgeName"}
+			if _, ok := feed["aggregator"]; ok {
+				continue
+			}
 			bridgeName, ok := feed["bridge"].(string)
 			if !ok {
-				return nil, errors.New("failed to convert bright type into string")
+				return nil, models.NewInvalidSpecError(errors.New("failed to convert bright type into string"))
 			}
 			bridgeURL, err = GetBridgeURLFromName(bridgeName, orm) // XXX: currently an n query
+			if err != nil && !gorm.IsRecordNotFoundError(err) {
+				// Failing to reach the database is not the spec's fault
+				return nil, err
+			}
 		default:
 			err = errors.New("unable to extract feed URLs from json")
 		}
 
 		if err != nil {
-			return nil, err
+			return nil, models.NewInvalidSpecError(err)
 		}
 		urls = append(urls, bridgeURL)
 	}
@@ -332,6 +385,31 @@ func ExtractFeedURLs(feeds models.Feeds, orm *orm.ORM) ([]*url.URL, error) {
 	return urls, nil
 }
 
+// ExtractFeedAggregators extracts the addresses of the aggregator feeds from
+// the feeds parameter of the initiator params, ex: {"aggregator": "0x..."}.
+// Their latest answers are read on the chain the node is connected to, so
+// that a job can forward the answers of one aggregator to another.
+func ExtractFeedAggregators(feeds models.Feeds) ([]common.Address, error) {
+	var feedsData []interface{}
+	if err := json.Unmarshal(feeds.Bytes(), &feedsData); err != nil {
+		return nil, err
+	}
+
+	var addresses []common.Address
+	for _, entry := range feedsData {
+		feed, ok := entry.(map[string]interface{})
+		if !ok || feed["aggregator"] == nil {
+			continue
+		}
+		address, ok := feed["aggregator"].(string)
+		if !ok || !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid aggregator address %v", feed["aggregator"])
+		}
+		addresses = append(addresses, common.HexToAddress(address))
+	}
+	return addresses, nil
+}
+
 // GetBridgeURLFromName looks up a bridge in the DB by name, then extracts the url
 func GetBridgeURLFromName(name string, orm *orm.ORM) (*url.URL, error) {
 	task := models.TaskType(name)
@@ -729,7 +807,9 @@ func (p *PollingDeviationChecker) respondToNewRoundLog(log contracts.LogNewRound
 		return
 	}
 
-	polledAnswer, err := p.fetcher.Fetch(request)
+	ctx, cancel := fetchContext(roundState, p.store.Config.FluxMonitorSubmissionMargin().Duration())
+	defer cancel()
+	polledAnswer, excluded, err := p.fetch(ctx, request)
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("unable to fetch median price: %v", err), p.loggerFieldsForNewRound(log)...)
 		return
@@ -742,7 +822,7 @@ func (p *PollingDeviationChecker) respondToNewRoundLog(log contracts.LogNewRound
 		payment = assets.Link(*roundState.PaymentAmount)
 	}
 
-	err = p.createJobRun(polledAnswer, logRoundID, &payment)
+	err = p.createJobRun(polledAnswer, excluded, logRoundID, &payment)
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("unable to create job run: %v", err), p.loggerFieldsForNewRound(log)...)
 		return
@@ -853,7 +933,9 @@ func (p *PollingDeviationChecker) pollIfEligible(thresholds DeviationThresholds)
 		return
 	}
 
-	polledAnswer, err := p.fetcher.Fetch(request)
+	ctx, cancel := fetchContext(roundState, p.store.Config.FluxMonitorSubmissionMargin().Duration())
+	defer cancel()
+	polledAnswer, excluded, err := p.fetch(ctx, request)
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("can't fetch answer: %v", err), loggerFields...)
 		p.store.UpsertErrorFor(p.JobID(), "Error polling")
@@ -886,7 +968,7 @@ func (p *PollingDeviationChecker) pollIfEligible(thresholds DeviationThresholds)
 		payment = assets.Link(*roundState.PaymentAmount)
 	}
 
-	err = p.createJobRun(polledAnswer, roundState.ReportableRoundID, &payment)
+	err = p.createJobRun(polledAnswer, excluded, roundState.ReportableRoundID, &payment)
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("can't create job run: %v", err), loggerFields...)
 		return
@@ -896,6 +978,28 @@ func (p *PollingDeviationChecker) pollIfEligible(thresholds DeviationThresholds)
 	promSetUint32(promFMReportedRound.WithLabelValues(jobSpecID), roundState.ReportableRoundID)
 }
 
+// fetch fetches an answer for the round, along with the sources left out of
+// it if the fetcher answered from a subset of its sources.
+func (p *PollingDeviationChecker) fetch(ctx context.Context, request map[string]interface{}) (decimal.Decimal, []string, error) {
+	if fetcher, ok := p.fetcher.(partialFetcher); ok {
+		return fetcher.FetchPartial(ctx, request)
+	}
+	answer, err := p.fetcher.Fetch(ctx, request)
+	return answer, nil, err
+}
+
+// fetchContext returns the context for fetching an answer to the round of
+// roundState. Once started, a round's context expires submissionMargin
+// before the round times out, so that the sources which answered in time are
+// still submitted, and mined, before it does.
+func fetchContext(roundState contracts.FluxAggregatorRoundState, submissionMargin time.Duration) (context.Context, context.CancelFunc) {
+	if roundState.StartedAt == 0 || roundState.Timeout == 0 {
+		return context.WithCancel(context.Background())
+	}
+	timesOutAt := time.Unix(int64(roundState.TimesOutAt()), 0)
+	return context.WithDeadline(context.Background(), timesOutAt.Add(-submissionMargin))
+}
+
 func (p *PollingDeviationChecker) roundState(roundID uint32) (contracts.FluxAggregatorRoundState, error) {
 	acct, err := p.store.KeyStore.GetFirstAccount()
 	if err != nil {
@@ -966,10 +1070,15 @@ type jobRunRequest struct {
 	Address          string          `json:"address"`
 	FunctionSelector string          `json:"functionSelector"`
 	DataPrefix       string          `json:"dataPrefix"`
+	// ExcludedSources are the sources left out of a Result answered from a
+	// subset of the feeds: the hosts of feed URLs, without any credentials
+	// in the rest of the URL, and the addresses of aggregators.
+	ExcludedSources []string `json:"excludedSources,omitempty"`
 }
 
 func (p *PollingDeviationChecker) createJobRun(
 	polledAnswer decimal.Decimal,
+	excludedSources []string,
 	roundID uint32,
 	paymentAmount *assets.Link,
 ) error {
@@ -985,6 +1094,7 @@ func (p *PollingDeviationChecker) createJobRun(
 		Address:          p.initr.Address.Hex(),
 		FunctionSelector: hexutil.Encode(methodID),
 		DataPrefix:       hexutil.Encode(roundIDData),
+		ExcludedSources:  excludedSources,
 	})
 	if err != nil {
 		return errors.Wrapf(err, "unable to encode Job Run request in JSON")
@@ -1,6 +1,7 @@
 package fluxmonitor_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -281,7 +282,7 @@ func TestPollingDeviationChecker_PollIfEligible(t *testing.T) {
 				fluxAggregator.On("RoundState", nodeAddr, uint32(0)).Return(roundState, nil).Maybe()
 
 				if test.expectedToPoll {
-					fetcher.On("Fetch", mock.Anything).Return(decimal.NewFromInt(test.polledAnswer), nil)
+					fetcher.On("Fetch", mock.Anything, mock.Anything).Return(decimal.NewFromInt(test.polledAnswer), nil)
 				}
 
 				if test.expectedToSubmit {
@@ -432,7 +433,7 @@ func TestPollingDeviationChecker_BuffersLogs(t *testing.T) {
 	fluxAggregator.On("RoundState", nodeAddr, uint32(4)).Return(makeRoundStateForRoundID(4), nil).Once()
 
 	fetcher := new(mocks.Fetcher)
-	fetcher.On("Fetch", mock.Anything).Return(decimal.NewFromInt(fetchedValue), nil)
+	fetcher.On("Fetch", mock.Anything, mock.Anything).Return(decimal.NewFromInt(fetchedValue), nil)
 
 	rm := new(mocks.RunManager)
 	run := cltest.NewJobRun(job)
@@ -841,7 +842,7 @@ func TestPollingDeviationChecker_RespondToNewRound(t *testing.T) {
 			}
 
 			if expectedToPoll {
-				fetcher.On("Fetch", mock.Anything).Return(decimal.NewFromInt(test.polledAnswer), nil).Once()
+				fetcher.On("Fetch", mock.Anything, mock.Anything).Return(decimal.NewFromInt(test.polledAnswer), nil).Once()
 			}
 
 			if expectedToSubmit {
@@ -1013,6 +1014,60 @@ func TestExtractFeedURLs(t *testing.T) {
 	}
 }
 
+func TestExtractFeedAggregators(t *testing.T) {
+	address := cltest.NewAddress()
+	feeds := cltest.JSONFromString(t, fmt.Sprintf(`["https://example.com", {"bridge": "testbridge"}, {"aggregator": "%s"}]`, address.Hex()))
+	addresses, err := fluxmonitor.ExtractFeedAggregators(feeds)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{address}, addresses)
+
+	_, err = fluxmonitor.ExtractFeedAggregators(cltest.JSONFromString(t, `[{"aggregator": "0xinvalid"}]`))
+	assert.Error(t, err)
+}
+
+func TestAggregatorFetcher(t *testing.T) {
+	address := cltest.NewAddress()
+	aggregator := new(mocks.FluxAggregator)
+	updatedAt := big.NewInt(time.Now().Add(-time.Minute).Unix())
+	aggregator.On("LatestRoundData").Return(contracts.FluxAggregatorRoundData{
+		RoundID:         big.NewInt(3),
+		Answer:          big.NewInt(12345678),
+		StartedAt:       updatedAt,
+		UpdatedAt:       updatedAt,
+		AnsweredInRound: big.NewInt(3),
+	}, nil).Once()
+	aggregator.On("Decimals").Return(uint8(4), nil).Once()
+
+	fetcher := fluxmonitor.ExportedNewAggregatorFetcher(aggregator, address, time.Hour)
+	answer, err := fetcher.Fetch(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1234.5678", answer.String())
+
+	// An answer older than the heartbeat is stale
+	staleAt := big.NewInt(time.Now().Add(-2 * time.Hour).Unix())
+	aggregator.On("LatestRoundData").Return(contracts.FluxAggregatorRoundData{
+		RoundID:         big.NewInt(3),
+		Answer:          big.NewInt(12345678),
+		StartedAt:       staleAt,
+		UpdatedAt:       staleAt,
+		AnsweredInRound: big.NewInt(3),
+	}, nil).Once()
+	_, err = fetcher.Fetch(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stale")
+
+	// An aggregator which has never answered has nothing to forward
+	aggregator.On("LatestRoundData").Return(contracts.FluxAggregatorRoundData{
+		RoundID:   big.NewInt(0),
+		Answer:    big.NewInt(0),
+		UpdatedAt: big.NewInt(0),
+	}, nil).Once()
+	_, err = fetcher.Fetch(context.Background(), nil)
+	assert.Error(t, err)
+
+	aggregator.AssertExpectations(t)
+}
+
 func TestPollingDeviationChecker_SufficientPayment(t *testing.T) {
 	t.Parallel()
 
@@ -1352,7 +1407,7 @@ func TestPollingDeviationChecker_DoesNotDoubleSubmit(t *testing.T) {
 				OracleCount:       1,
 			}, nil).
 			Once()
-		fetcher.On("Fetch", mock.Anything).
+		fetcher.On("Fetch", mock.Anything, mock.Anything).
 			Return(decimal.NewFromInt(answer), nil).
 			Once()
 		fluxAggregator.On("GetMethodID", "submit").
@@ -1434,7 +1489,7 @@ func TestPollingDeviationChecker_DoesNotDoubleSubmit(t *testing.T) {
 			}, nil).
 			Once()
 		meta := utils.MustUnmarshalToMap(`{"availableFunds":100000, "eligibleToSubmit":true, "latestAnswer":100, "oracleCount":1, "paymentAmount":100, "reportableRoundID":3, "startedAt":0, "timeout":0}`)
-		fetcher.On("Fetch", meta).
+		fetcher.On("Fetch", mock.Anything, meta).
 			Return(decimal.NewFromInt(answer), nil).
 			Once()
 		fluxAggregator.On("GetMethodID", "submit").
@@ -1,6 +1,7 @@
 package fluxmonitor
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -24,6 +26,10 @@ func ExportedSetCheckerFactory(fm Service, fac DeviationCheckerFactory) {
 	impl.checkerFactory = fac
 }
 
+func ExportedNewAggregatorFetcher(aggregator contracts.FluxAggregator, address common.Address, maxAge time.Duration) Fetcher {
+	return newAggregatorFetcher(aggregator, address, maxAge)
+}
+
 func (p *PollingDeviationChecker) ExportedPollIfEligible(threshold, absoluteThreshold float64) {
 	p.pollIfEligible(DeviationThresholds{Rel: threshold, Abs: absoluteThreshold})
 }
@@ -76,7 +82,7 @@ func newFixedPricedFetcher(price decimal.Decimal) *fixedFetcher {
 	return &fixedFetcher{price: price}
 }
 
-func (ps *fixedFetcher) Fetch(map[string]interface{}) (decimal.Decimal, error) {
+func (ps *fixedFetcher) Fetch(context.Context, map[string]interface{}) (decimal.Decimal, error) {
 	return ps.price, nil
 }
 
@@ -86,10 +92,24 @@ func newErroringPricedFetcher() *erroringFetcher {
 	return &erroringFetcher{}
 }
 
-func (*erroringFetcher) Fetch(map[string]interface{}) (decimal.Decimal, error) {
+func (*erroringFetcher) Fetch(context.Context, map[string]interface{}) (decimal.Decimal, error) {
 	return decimal.NewFromInt(0), errors.New("failed to fetch; I always error")
 }
 
+// stalledFetcher never answers until released, whatever its context
+type stalledFetcher struct {
+	release chan struct{}
+}
+
+func newStalledFetcher() *stalledFetcher {
+	return &stalledFetcher{release: make(chan struct{})}
+}
+
+func (s *stalledFetcher) Fetch(context.Context, map[string]interface{}) (decimal.Decimal, error) {
+	<-s.release
+	return decimal.NewFromInt(0), nil
+}
+
 type fetcherRequest struct {
 	Data interface{}            `json:"data"`
 	ID   string                 `json:"id"`
@@ -138,5 +158,5 @@ func (fm *concreteFluxMonitor) CreateJob(t *testing.T, jobSpecId *models.ID, pol
 	require.NoError(t, err, "could not create deviation checker")
 
 	payment := fm.store.Config.MinimumContractPayment()
-	return checker.(*PollingDeviationChecker).createJobRun(polledAnswer, uint32(nextRound.Uint64()), payment)
+	return checker.(*PollingDeviationChecker).createJobRun(polledAnswer, nil, uint32(nextRound.Uint64()), payment)
 }
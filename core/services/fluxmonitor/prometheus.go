@@ -61,6 +61,19 @@ var (
 			Buckets: prometheus.DefBuckets,
 		},
 	)
+	promFMFetchCacheHits = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "flux_monitor_fetch_cache_hits",
+			Help: "The number of prices served from the flux monitor fetch cache instead of a request",
+		},
+	)
+	promFMFetchersDropped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flux_monitor_fetchers_dropped",
+			Help: "The number of times a price source was left out of a median for not answering before the deadline",
+		},
+		[]string{"fetcher"},
+	)
 )
 
 func promSetDecimal(gauge prometheus.Gauge, arg decimal.Decimal) {
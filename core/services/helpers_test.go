@@ -1,5 +1,22 @@
 package services
 
+import (
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
 func (ht *HeadTracker) ExportedDone() chan struct{} {
 	return ht.done
 }
+
+func ExportedFormatsResult(run *models.JobRun, taskRun models.TaskRun) bool {
+	return formatsResult(run, taskRun)
+}
+
+// ExportedTaskMetrics returns whether the executions of a job's tasks of a
+// type were timed, and how many of them errored.
+func ExportedTaskMetrics(jobSpecID, taskType string) (timed bool, errored float64) {
+	errored = testutil.ToFloat64(promTaskErrorsVec.WithLabelValues(jobSpecID, taskType))
+	return promTaskExecutionTime.DeleteLabelValues(jobSpecID, taskType), errored
+}
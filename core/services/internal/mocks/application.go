@@ -14,6 +14,8 @@ import (
 	store "github.com/smartcontractkit/chainlink/core/store"
 
 	synchronization "github.com/smartcontractkit/chainlink/core/services/synchronization"
+
+	time "time"
 )
 
 // Application is an autogenerated mock type for the Application type
@@ -220,6 +222,20 @@ func (_m *Application) ResumeAllPendingNextBlock(currentBlockHeight *big.Int) er
 	return r0
 }
 
+// ResumeAllPendingSleep provides a mock function with given fields: now
+func (_m *Application) ResumeAllPendingSleep(now time.Time) error {
+	ret := _m.Called(now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumePendingBridge provides a mock function with given fields: runID, input
 func (_m *Application) ResumePendingBridge(runID *models.ID, input models.BridgeRunResult) error {
 	ret := _m.Called(runID, input)
@@ -234,6 +250,20 @@ func (_m *Application) ResumePendingBridge(runID *models.ID, input models.Bridge
 	return r0
 }
 
+// RetryJob provides a mock function with given fields: _a0
+func (_m *Application) RetryJob(_a0 *models.ID) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Start provides a mock function with given fields:
 func (_m *Application) Start() error {
 	ret := _m.Called()
@@ -248,6 +278,20 @@ func (_m *Application) Start() error {
 	return r0
 }
 
+// StartJob provides a mock function with given fields: _a0
+func (_m *Application) StartJob(_a0 *models.ID) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Stop provides a mock function with given fields:
 func (_m *Application) Stop() error {
 	ret := _m.Called()
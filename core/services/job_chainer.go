@@ -0,0 +1,203 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// JobChainerInterval is how often the job chainer checks for completed runs
+// whose next job has yet to be run, besides whenever a run completes.
+const JobChainerInterval = time.Second
+
+// jobChainerBatchSize is the most runs chained by each check.
+const jobChainerBatchSize = 100
+
+// JobChainer runs the next job of a job, see JobSpec.NextJobID, with the
+// result of each of the job's runs which completes. This lets a workflow be
+// split into stages, such as a job fetching and aggregating data feeding a
+// separate job which submits it, without anything outside the node passing
+// results between them.
+//
+// The database queues each run of a job with a next job in the transaction
+// which completes the run, and the run of the next job is created in the
+// transaction which takes the run off the queue. So a next job is run once
+// with each result, even if the node stops in between or the chainer falls
+// behind the runs completing.
+type JobChainer interface {
+	Start() error
+	Stop() error
+	Chain() error
+}
+
+type jobChainer struct {
+	store       *store.Store
+	runManager  RunManager
+	broadcaster RunUpdateBroadcaster
+	interval    time.Duration
+
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewJobChainer returns a JobChainer which chains the runs queued every
+// interval, and as runs complete through broadcaster.
+func NewJobChainer(store *store.Store, runManager RunManager, broadcaster RunUpdateBroadcaster, interval time.Duration) JobChainer {
+	return &jobChainer{
+		store:       store,
+		runManager:  runManager,
+		broadcaster: broadcaster,
+		interval:    interval,
+		chStop:      make(chan struct{}),
+	}
+}
+
+// Start chains the runs queued straight away, and then every interval and
+// whenever a run completes.
+func (jc *jobChainer) Start() error {
+	sub := jc.broadcaster.SubscribeEvents(nil)
+	jc.wg.Add(1)
+	go jc.run(sub)
+	return nil
+}
+
+// Stop waits for the next runs being created, if any, and stops.
+func (jc *jobChainer) Stop() error {
+	close(jc.chStop)
+	jc.wg.Wait()
+	return nil
+}
+
+func (jc *jobChainer) run(sub RunEventSubscription) {
+	defer jc.wg.Done()
+	ticker := time.NewTicker(jc.interval)
+	defer ticker.Stop()
+	for {
+		if err := jc.Chain(); err != nil {
+			logger.Errorw("Unable to run next jobs", "error", err)
+		}
+	wait:
+		for {
+			select {
+			case <-jc.chStop:
+				sub.Unsubscribe()
+				return
+			case <-ticker.C:
+				break wait
+			case event, ok := <-sub.Events():
+				if !ok {
+					// The runs completing meanwhile are still queued, and
+					// are chained now
+					sub = jc.broadcaster.SubscribeEvents(nil)
+					break wait
+				}
+				if event.Event == models.RunEventCompleted {
+					break wait
+				}
+			}
+		}
+	}
+}
+
+// Chain runs the next jobs of the runs queued. The runs whose next job
+// cannot be run are taken off the queue and the failure recorded against
+// their job; those which fail for any other reason are left to be chained
+// again.
+func (jc *jobChainer) Chain() error {
+	ids, err := jc.store.JobRunsToChain(jobChainerBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "finding runs to chain")
+	}
+	var merr error
+	for _, id := range ids {
+		err := jc.chain(id)
+		if _, ok := errors.Cause(err).(unchainableRunError); ok || isUnrunnable(err) {
+			logger.Errorw("Unable to run next job", "run", id.String(), "error", err)
+			if derr := jc.store.DeleteJobRunChain(id); derr != nil {
+				merr = multierr.Append(merr, errors.Wrapf(derr, "dequeuing run %s", id))
+			}
+		} else if err != nil {
+			merr = multierr.Append(merr, errors.Wrapf(err, "chaining run %s", id))
+		}
+	}
+	return merr
+}
+
+// unchainableRunError is returned when the next job of a run's job cannot
+// be run with the run's result.
+type unchainableRunError struct {
+	msg string
+}
+
+func (err unchainableRunError) Error() string {
+	return err.msg
+}
+
+// isUnrunnable returns whether err was returned by RunManager.Create because
+// the job cannot be run at all at the moment, rather than because of a
+// failure in the node.
+func isUnrunnable(err error) bool {
+	_, ok := errors.Cause(err).(RecurringScheduleJobError)
+	return ok || orm.IsQuotaExceeded(err)
+}
+
+// chain runs the next job of the run's job, if it has one, with the result
+// of the run. The result of a confidential run is only passed to a next job
+// which is confidential too, as the next job's runs would otherwise store it
+// in the clear.
+func (jc *jobChainer) chain(runID *models.ID) error {
+	run, err := jc.store.Unscoped().FindJobRun(runID)
+	if err != nil {
+		return err
+	}
+	job, err := jc.store.FindJob(run.JobSpecID)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		// The job has been archived since its run completed
+		return jc.store.DeleteJobRunChain(runID)
+	} else if err != nil {
+		return err
+	} else if job.NextJobID == nil {
+		return jc.store.DeleteJobRunChain(runID)
+	}
+
+	next, err := jc.store.FindJob(job.NextJobID)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return jc.unchainable(job, "next job %s not found", job.NextJobID)
+	} else if err != nil {
+		return err
+	}
+	initiators := next.InitiatorsFor(models.InitiatorWeb)
+	if len(initiators) == 0 {
+		return jc.unchainable(job, "next job %s has no web initiator", job.NextJobID)
+	}
+	if run.Confidential && !next.Confidential {
+		return jc.unchainable(job, "next job %s is not confidential, the results of confidential runs are not passed to it", job.NextJobID)
+	}
+
+	logger.Debugw("Running next job", "job", job.ID.String(), "run", run.ID.String(), "nextJob", next.ID.String())
+	runRequest := models.NewRunRequest(run.Result.Data)
+	runRequest.PreviousJobRunID = run.ID
+	_, err = jc.runManager.Create(next.ID, &initiators[0], nil, runRequest)
+	if errors.Cause(err) == orm.ErrJobRunChained {
+		return nil
+	} else if isUnrunnable(err) {
+		jc.store.UpsertErrorFor(job.ID, fmt.Sprintf("Unable to run next job: %v", err))
+	}
+	return err
+}
+
+// unchainable records against the job that its next job cannot be run, and
+// returns an unchainableRunError.
+func (jc *jobChainer) unchainable(job models.JobSpec, format string, args ...interface{}) error {
+	err := unchainableRunError{msg: fmt.Sprintf(format, args...)}
+	jc.store.UpsertErrorFor(job.ID, fmt.Sprintf("Unable to run next job: %v", err))
+	return err
+}
@@ -0,0 +1,134 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobChainer_RunsNextJobWithResult(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	store := app.Store
+
+	next := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&next))
+	job := cltest.NewJobWithWebInitiator()
+	job.NextJobID = next.ID
+	require.NoError(t, store.CreateJob(&job))
+	unchained := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&unchained))
+
+	jr := cltest.CreateJobRunViaWeb(t, app, job, `{"result":"100"}`)
+	cltest.WaitForJobRunToComplete(t, store, jr)
+
+	runs := cltest.WaitForRuns(t, next, store, 1)
+	nextRun := cltest.WaitForJobRunToComplete(t, store, runs[0])
+	assert.Equal(t, "100", nextRun.Result.Data.Get("result").String())
+	assert.Equal(t, models.InitiatorWeb, nextRun.Initiator.Type)
+
+	jr = cltest.CreateJobRunViaWeb(t, app, unchained)
+	cltest.WaitForJobRunToComplete(t, store, jr)
+	cltest.WaitForRuns(t, next, store, 1)
+}
+
+func TestJobChainer_RunsQueuedInDatabase(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	store := app.Store
+
+	next := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&next))
+	job := cltest.NewJobWithWebInitiator()
+	job.NextJobID = next.ID
+	require.NoError(t, store.CreateJob(&job))
+
+	// A run completed while the node was stopped is still chained, once
+	jr := cltest.NewJobRun(job)
+	jr.SetStatus(models.RunStatusCompleted)
+	jr.Result.Data = cltest.JSONFromString(t, `{"result":"100"}`)
+	require.NoError(t, store.CreateJobRun(&jr))
+	queued, err := store.JobRunsToChain(10)
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, jr.ID.String(), queued[0].String())
+
+	require.NoError(t, app.Start())
+	runs := cltest.WaitForRuns(t, next, store, 1)
+	nextRun := cltest.WaitForJobRunToComplete(t, store, runs[0])
+	assert.Equal(t, "100", nextRun.Result.Data.Get("result").String())
+	assert.Equal(t, jr.ID.String(), nextRun.RunRequest.PreviousJobRunID.String())
+
+	require.NoError(t, app.JobChainer.Chain())
+	runs, err = store.JobRunsFor(next.ID)
+	require.NoError(t, err)
+	assert.Len(t, runs, 1)
+	queued, err = store.JobRunsToChain(10)
+	require.NoError(t, err)
+	assert.Empty(t, queued)
+}
+
+func TestJobChainer_ConfidentialRuns(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig(t)
+	defer cfgCleanup()
+	config.Set("RUN_DATA_ENCRYPTION_KEY", "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	app, cleanup := cltest.NewApplicationWithConfig(t, config, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	store := app.Store
+
+	next := cltest.NewJobWithWebInitiator()
+	next.Confidential = true
+	require.NoError(t, store.CreateJob(&next))
+	job := cltest.NewJobWithWebInitiator()
+	job.Confidential = true
+	job.NextJobID = next.ID
+	require.NoError(t, store.CreateJob(&job))
+
+	jr := cltest.CreateJobRunViaWeb(t, app, job, `{"result":"secret"}`)
+	cltest.WaitForJobRunToComplete(t, store, jr)
+	runs := cltest.WaitForRuns(t, next, store, 1)
+	nextRun := cltest.WaitForJobRunToComplete(t, store, runs[0])
+	assert.True(t, nextRun.Confidential)
+	assert.Equal(t, "secret", nextRun.Result.Data.Get("result").String())
+
+	// The next run's data is stored encrypted
+	var params, result string
+	err := store.DB.Raw(`
+		SELECT run_requests.request_params, run_results.data FROM job_runs
+		JOIN run_requests ON run_requests.id = job_runs.run_request_id
+		JOIN run_results ON run_results.id = job_runs.result_id
+		WHERE job_runs.id = ?`, nextRun.ID).Row().Scan(&params, &result)
+	require.NoError(t, err)
+	for _, raw := range []string{params, result} {
+		data, err := models.ParseJSON([]byte(raw))
+		require.NoError(t, err)
+		assert.True(t, models.IsEncryptedRunData(data))
+		assert.NotContains(t, raw, "secret")
+	}
+
+	// A confidential run is not chained to a job which is no longer
+	// confidential
+	next.Confidential = false
+	require.NoError(t, store.ReplaceJobSpec(&next))
+	jr = cltest.CreateJobRunViaWeb(t, app, job, `{"result":"secret"}`)
+	cltest.WaitForJobRunToComplete(t, store, jr)
+	gomega.NewGomegaWithT(t).Eventually(func() []models.JobSpecError {
+		jobErrors, err := store.JobSpecErrorsFor(job.ID, false)
+		require.NoError(t, err)
+		return jobErrors
+	}, cltest.DBWaitTimeout, cltest.DBPollingInterval).Should(gomega.HaveLen(1))
+	cltest.WaitForRuns(t, next, store, 1)
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"go.uber.org/multierr"
+)
+
+// JobExpiryInterval is how often temporary jobs are checked for expiry.
+const JobExpiryInterval = time.Minute
+
+// JobArchiver archives jobs, stopping their initiators first.
+type JobArchiver interface {
+	ArchiveJob(ID *models.ID) error
+}
+
+// JobExpirer archives temporary jobs, such as the ephemeral copies made to
+// try out changes to a job, once their expiry has passed.
+type JobExpirer interface {
+	Start() error
+	Stop() error
+	Expire() error
+}
+
+type jobExpirer struct {
+	store    *store.Store
+	archiver JobArchiver
+	interval time.Duration
+
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewJobExpirer returns a JobExpirer which checks for expired jobs every
+// interval.
+func NewJobExpirer(store *store.Store, archiver JobArchiver, interval time.Duration) JobExpirer {
+	return &jobExpirer{
+		store:    store,
+		archiver: archiver,
+		interval: interval,
+		chStop:   make(chan struct{}),
+	}
+}
+
+// Start expires jobs straight away, and then every interval.
+func (je *jobExpirer) Start() error {
+	je.wg.Add(1)
+	go je.run()
+	return nil
+}
+
+// Stop waits for the expiry in progress, if any, and stops.
+func (je *jobExpirer) Stop() error {
+	close(je.chStop)
+	je.wg.Wait()
+	return nil
+}
+
+func (je *jobExpirer) run() {
+	defer je.wg.Done()
+	ticker := time.NewTicker(je.interval)
+	defer ticker.Stop()
+	for {
+		if err := je.Expire(); err != nil {
+			logger.Errorw("Unable to archive expired jobs", "error", err)
+		}
+		select {
+		case <-je.chStop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Expire archives every job whose expiry has passed.
+func (je *jobExpirer) Expire() error {
+	jobs, err := je.store.ExpiredJobs(time.Now())
+	if err != nil {
+		return err
+	}
+	var merr error
+	for _, job := range jobs {
+		logger.Infow("Archiving expired job", "job", job.ID.String(), "expiresAt", job.ExpiresAt.Time)
+		merr = multierr.Append(merr, je.archiver.ArchiveJob(job.ID))
+	}
+	return merr
+}
@@ -0,0 +1,44 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	null "gopkg.in/guregu/null.v3"
+)
+
+func TestJobExpirer_Expire(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	expired := cltest.NewJobWithWebInitiator()
+	expired.ExpiresAt = null.TimeFrom(time.Now().Add(-time.Minute))
+	require.NoError(t, app.AddJob(expired))
+	unexpired := cltest.NewJobWithWebInitiator()
+	unexpired.ExpiresAt = null.TimeFrom(time.Now().Add(time.Hour))
+	require.NoError(t, app.AddJob(unexpired))
+	permanent := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.AddJob(permanent))
+
+	expirer := services.NewJobExpirer(app.Store, app, time.Hour)
+	require.NoError(t, expirer.Expire())
+
+	_, err := app.Store.FindJob(expired.ID)
+	assert.Error(t, err)
+	_, err = app.Store.FindJob(unexpired.ID)
+	assert.NoError(t, err)
+	_, err = app.Store.FindJob(permanent.ID)
+	assert.NoError(t, err)
+
+	jobs, err := app.Store.ExpiredJobs(time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
@@ -139,12 +139,20 @@ func (js *jobSubscriber) addSubscription(sub JobSubscription) {
 	numberJobSubscriptions.Set(float64(len(js.jobSubscriptions)))
 }
 
-// Connect connects the jobs to the ethereum node by creating corresponding subscriptions.
+// Connect connects the jobs to the ethereum node by creating corresponding
+// subscriptions. Jobs whose subscriptions cannot be created because of their
+// spec are quarantined rather than failing the connection. Those failing for
+// other reasons are retried the next time the node connects.
 func (js *jobSubscriber) Connect(bn *models.Head) error {
 	var merr error
 	err := js.store.Jobs(
 		func(j *models.JobSpec) bool {
-			merr = multierr.Append(merr, js.AddJob(*j, bn))
+			if err := js.AddJob(*j, bn); models.IsInvalidSpecError(err) {
+				logger.Errorw("Quarantining job which failed to start", "job", j.ID.String(), "error", err)
+				merr = multierr.Append(merr, js.store.QuarantineJob(j.ID, err.Error()))
+			} else if err != nil {
+				logger.Errorw("Job failed to start", "job", j.ID.String(), "error", err)
+			}
 			return true
 		},
 		models.InitiatorEthLog,
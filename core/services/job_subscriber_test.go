@@ -10,6 +10,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -162,3 +163,44 @@ func TestJobSubscriber_Connect_Disconnect(t *testing.T) {
 
 	assert.Len(t, jobSubscriber.Jobs(), 0)
 }
+
+func TestJobSubscriber_Connect_QuarantinesInvalidJobs(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	runManager := new(mocks.RunManager)
+	jobSubscriber := services.NewJobSubscriber(store, runManager)
+	defer jobSubscriber.Stop()
+
+	eth := cltest.MockEthOnStore(t, store)
+	eth.RegisterOptional("eth_getLogs", []models.Log{})
+
+	// The valid job's log subscription fails as it is not registered, which
+	// is not the fault of its spec
+	valid := cltest.NewJobWithLogInitiator()
+	invalid := cltest.NewJobWithLogInitiator()
+	invalid.Initiators[0].FromBlock = utils.NewBigI(10)
+	invalid.Initiators[0].ToBlock = utils.NewBigI(5)
+	require.NoError(t, store.CreateJob(&valid))
+	require.NoError(t, store.CreateJob(&invalid))
+
+	require.NoError(t, jobSubscriber.Connect(cltest.Head(491)))
+	require.Len(t, jobSubscriber.Jobs(), 0)
+
+	failed, err := store.FindJob(invalid.ID)
+	require.NoError(t, err)
+	assert.True(t, failed.Quarantined())
+	assert.Contains(t, failed.StatusReason, "fromBlock >= toBlock")
+
+	notStarted, err := store.FindJob(valid.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobSpecStatusActive, notStarted.Status)
+
+	jobSubscriber.Disconnect()
+	eth.RegisterSubscription("logs")
+	require.NoError(t, jobSubscriber.Connect(cltest.Head(492)))
+	require.Len(t, jobSubscriber.Jobs(), 1, "quarantined jobs are not reconnected")
+	assert.Equal(t, valid.ID.String(), jobSubscriber.Jobs()[0].ID.String())
+}
@@ -0,0 +1,306 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/tevino/abool"
+)
+
+// Service performs the upkeeps of the registries watched by the keeper
+// initiators of added jobs. On each new head, every upkeep whose turn it is
+// for the node is checked off-chain, and performed if the check reports it is
+// needed.
+type Service interface {
+	AddJob(models.JobSpec) error
+	RemoveJob(*models.ID)
+	Start() error
+	Stop()
+	strpkg.HeadTrackable
+}
+
+type registryFactory func(address common.Address) contracts.KeeperRegistry
+
+// watchedRegistry is a registry watched by the keeper initiator of a job.
+type watchedRegistry struct {
+	jobID             models.ID
+	contract          contracts.KeeperRegistry
+	blockCountPerTurn int64
+}
+
+// upkeep identifies an upkeep of a registry.
+type upkeep struct {
+	registry common.Address
+	id       string
+}
+
+type concreteKeeper struct {
+	store       *strpkg.Store
+	newRegistry registryFactory
+	disabled    bool
+
+	mu         sync.Mutex
+	registries map[models.ID][]watchedRegistry
+
+	// performed holds the turn in which each upkeep was last performed, so
+	// that it is performed at most once per turn while its transaction is
+	// pending. Upkeeps are dropped once their registry has moved on to a
+	// later turn, or is no longer watched. Only used by the checking
+	// goroutine.
+	performed map[upkeep]int64
+	checking  *abool.AtomicBool
+	wg        sync.WaitGroup
+	chStop    chan struct{}
+}
+
+// New returns a Service which calls registries through the store's ethereum
+// client and submits performUpkeep transactions through the bulletproof tx
+// manager.
+func New(store *strpkg.Store) Service {
+	if store.Config.EthereumDisabled() {
+		return &concreteKeeper{disabled: true}
+	}
+	return &concreteKeeper{
+		store: store,
+		newRegistry: func(address common.Address) contracts.KeeperRegistry {
+			return contracts.NewKeeperRegistry(address, store.EthClient)
+		},
+		registries: make(map[models.ID][]watchedRegistry),
+		performed:  make(map[upkeep]int64),
+		checking:   abool.New(),
+		chStop:     make(chan struct{}),
+	}
+}
+
+// Start watches the registries of every job with a keeper initiator.
+func (k *concreteKeeper) Start() error {
+	if k.disabled {
+		logger.Info("Keeper disabled: skipping start")
+		return nil
+	}
+	return k.store.Jobs(func(j *models.JobSpec) bool {
+		if j == nil {
+			logger.Error("received nil job")
+			return true
+		}
+		if err := k.AddJob(*j); models.IsInvalidSpecError(err) {
+			logger.Errorf("error adding keeper job, quarantining: %v", err)
+			logger.ErrorIf(k.store.QuarantineJob(j.ID, err.Error()))
+		} else if err != nil {
+			logger.Errorf("error adding keeper job: %v", err)
+			k.store.UpsertErrorFor(j.ID, fmt.Sprintf("Unable to start job: %v", err))
+		}
+		return true
+	}, models.InitiatorKeeper)
+}
+
+// Stop waits for any checks in progress to finish.
+func (k *concreteKeeper) Stop() {
+	if k.disabled {
+		return
+	}
+	close(k.chStop)
+	k.wg.Wait()
+}
+
+// AddJob watches the registry of each keeper initiator of the job.
+func (k *concreteKeeper) AddJob(job models.JobSpec) error {
+	if k.disabled {
+		return nil
+	}
+	if job.ID == nil {
+		return errors.New("received job with nil ID")
+	}
+	var registries []watchedRegistry
+	for _, initr := range job.InitiatorsFor(models.InitiatorKeeper) {
+		if initr.BlockCountPerTurn <= 0 {
+			return models.NewInvalidSpecError(fmt.Errorf("keeper initiator of job %s has no blockCountPerTurn", job.ID))
+		}
+		registries = append(registries, watchedRegistry{
+			jobID:             *job.ID,
+			contract:          k.newRegistry(initr.Address),
+			blockCountPerTurn: int64(initr.BlockCountPerTurn),
+		})
+	}
+	if len(registries) == 0 {
+		return nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, ok := k.registries[*job.ID]; ok {
+		return fmt.Errorf("job %s has already been added to the keeper", job.ID)
+	}
+	k.registries[*job.ID] = registries
+	return nil
+}
+
+// RemoveJob stops watching the registries of the job.
+func (k *concreteKeeper) RemoveJob(id *models.ID) {
+	if k.disabled || id == nil {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.registries, *id)
+}
+
+func (k *concreteKeeper) Connect(*models.Head) error { return nil }
+
+func (k *concreteKeeper) Disconnect() {}
+
+// OnNewLongestChain checks the upkeeps of every watched registry in the
+// background. Heads which arrive while the upkeeps of an earlier head are
+// still being checked are skipped.
+func (k *concreteKeeper) OnNewLongestChain(_ context.Context, head models.Head) {
+	if k.disabled || !k.checking.SetToIf(false, true) {
+		return
+	}
+	k.wg.Add(1)
+	go func() {
+		defer k.wg.Done()
+		defer k.checking.UnSet()
+		k.checkUpkeeps(head.Number)
+	}()
+}
+
+func (k *concreteKeeper) checkUpkeeps(blockNumber int64) {
+	k.mu.Lock()
+	var registries []watchedRegistry
+	for _, rs := range k.registries {
+		registries = append(registries, rs...)
+	}
+	k.mu.Unlock()
+	k.forgetUnwatched(registries)
+	if len(registries) == 0 {
+		return
+	}
+
+	account, err := k.store.KeyStore.GetFirstAccount()
+	if err != nil {
+		logger.Errorw("Keeper has no account to perform upkeeps from", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-k.chStop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for _, registry := range registries {
+		if err := k.checkRegistry(ctx, registry, account.Address, blockNumber); err != nil {
+			logger.Errorw("Unable to check upkeeps",
+				"job", registry.jobID.String(),
+				"registry", registry.contract.Address().Hex(),
+				"error", err,
+			)
+			k.store.UpsertErrorFor(&registry.jobID, fmt.Sprintf("Unable to check upkeeps: %v", err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (k *concreteKeeper) checkRegistry(ctx context.Context, registry watchedRegistry, from common.Address, blockNumber int64) error {
+	keepers, err := registry.contract.Keepers(ctx)
+	if err != nil {
+		return err
+	}
+	count, err := registry.contract.UpkeepCount(ctx)
+	if err != nil {
+		return err
+	}
+
+	turn := blockNumber / registry.blockCountPerTurn
+	k.forgetEarlierTurns(registry.contract.Address(), turn)
+	for i := int64(0); i < count.Int64(); i++ {
+		id := big.NewInt(i)
+		if !IsTurn(keepers, from, turn, id) {
+			continue
+		}
+		key := upkeep{registry: registry.contract.Address(), id: id.String()}
+		if last, ok := k.performed[key]; ok && last == turn {
+			continue
+		}
+
+		performData, gasLimit, needed, err := registry.contract.CheckUpkeep(ctx, id, from)
+		if err != nil {
+			return errors.Wrapf(err, "while checking upkeep %s", id)
+		} else if !needed {
+			continue
+		}
+		payload, err := registry.contract.PerformUpkeepPayload(id, performData)
+		if err != nil {
+			return err
+		}
+		gasLimit += k.store.Config.KeeperRegistryPerformGasOverhead()
+		if _, err := bulletprooftxmanager.CreateEthTransactionWithGasLimit(k.store, from, registry.contract.Address(), payload, gasLimit); err != nil {
+			return errors.Wrapf(err, "while performing upkeep %s", id)
+		}
+		if k.store.NotifyNewEthTx != nil {
+			k.store.NotifyNewEthTx.Trigger()
+		}
+		k.performed[key] = turn
+		logger.Infow("Performing upkeep",
+			"job", registry.jobID.String(),
+			"registry", registry.contract.Address().Hex(),
+			"upkeep", id.String(),
+			"blockNumber", blockNumber,
+			"gasLimit", gasLimit,
+		)
+	}
+	return nil
+}
+
+// forgetEarlierTurns drops the upkeeps of the registry performed in turns
+// before the current one, which can be performed again.
+func (k *concreteKeeper) forgetEarlierTurns(registry common.Address, turn int64) {
+	for key, last := range k.performed {
+		if key.registry == registry && last < turn {
+			delete(k.performed, key)
+		}
+	}
+}
+
+// forgetUnwatched drops the upkeeps of the registries which are no longer
+// watched.
+func (k *concreteKeeper) forgetUnwatched(registries []watchedRegistry) {
+	watched := make(map[common.Address]bool, len(registries))
+	for _, registry := range registries {
+		watched[registry.contract.Address()] = true
+	}
+	for key := range k.performed {
+		if !watched[key.registry] {
+			delete(k.performed, key)
+		}
+	}
+}
+
+// IsTurn returns whether it is the turn of the keeper from to perform the
+// upkeep with the given ID. Keepers take turns in the order the registry
+// lists them, with each upkeep starting at a different keeper so that the
+// upkeeps of a turn are spread across all keepers.
+func IsTurn(keepers []common.Address, from common.Address, turn int64, upkeepID *big.Int) bool {
+	if len(keepers) == 0 {
+		return false
+	}
+	index := new(big.Int).Add(upkeepID, big.NewInt(turn))
+	index.Mod(index, big.NewInt(int64(len(keepers))))
+	return keepers[index.Int64()] == from
+}
@@ -0,0 +1,41 @@
+package keeper_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/keeper"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTurn(t *testing.T) {
+	t.Parallel()
+
+	keeper1, keeper2, keeper3 := cltest.NewAddress(), cltest.NewAddress(), cltest.NewAddress()
+	keepers := []common.Address{keeper1, keeper2, keeper3}
+
+	tests := []struct {
+		name    string
+		from    common.Address
+		turn    int64
+		upkeep  int64
+		expects bool
+	}{
+		{"first keeper, first upkeep", keeper1, 0, 0, true},
+		{"second keeper, first upkeep", keeper2, 0, 0, false},
+		{"upkeeps start at different keepers", keeper2, 0, 1, true},
+		{"turn passes to the next keeper", keeper2, 1, 0, true},
+		{"turns wrap around the keepers", keeper1, 2, 1, true},
+		{"unlisted keeper", cltest.NewAddress(), 0, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expects, keeper.IsTurn(keepers, test.from, test.turn, big.NewInt(test.upkeep)))
+		})
+	}
+
+	assert.False(t, keeper.IsTurn(nil, keeper1, 0, big.NewInt(0)))
+}
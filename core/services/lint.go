@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+)
+
+// LintJob checks job as ValidateJob does, returning every problem found
+// along with the graph of the job's initiators and tasks, both as JSON and in
+// the Graphviz DOT language.
+func LintJob(store *store.Store, job models.JobSpec) presenters.JobSpecLint {
+	lint := presenters.JobSpecLint{
+		ID:       job.ID,
+		Problems: []string{},
+		Warnings: []string{},
+		Graph:    jobGraph(job),
+	}
+	if err := ValidateJob(job, store); err != nil {
+		lint.Problems = append(lint.Problems, lintProblems(err)...)
+	}
+	lint.Valid = len(lint.Problems) == 0
+	lint.DOT = renderDOT(lint.Graph)
+	return lint
+}
+
+func lintProblems(err error) []string {
+	jsonErrs, ok := err.(*models.JSONAPIErrors)
+	if !ok {
+		return []string{err.Error()}
+	}
+	var problems []string
+	for _, e := range jsonErrs.Errors {
+		problems = append(problems, e.Detail)
+	}
+	return problems
+}
+
+// jobGraph returns the graph of the job's initiators and tasks.
+func jobGraph(job models.JobSpec) presenters.TaskGraph {
+	graph := presenters.TaskGraph{
+		Nodes: []presenters.TaskGraphNode{},
+		Edges: []presenters.TaskGraphEdge{},
+	}
+	for i, initr := range job.Initiators {
+		id := fmt.Sprintf("initiator%d", i)
+		graph.Nodes = append(graph.Nodes, presenters.TaskGraphNode{ID: id, Kind: "initiator", Type: initr.Type})
+		if len(job.Tasks) > 0 {
+			graph.Edges = append(graph.Edges, presenters.TaskGraphEdge{From: id, To: "task0", Kind: "input"})
+		}
+	}
+
+	named := map[string]string{}
+	for i, task := range job.Tasks {
+		id := fmt.Sprintf("task%d", i)
+		graph.Nodes = append(graph.Nodes, presenters.TaskGraphNode{ID: id, Kind: "task", Type: string(task.Type), Name: task.Name})
+		if i > 0 {
+			graph.Edges = append(graph.Edges, presenters.TaskGraphEdge{From: fmt.Sprintf("task%d", i-1), To: id, Kind: "input"})
+		}
+		for _, name := range models.TaskVariableReferences(task.Params) {
+			// References to unknown or later tasks are reported as problems
+			// by ValidateJob and left out of the graph
+			if from, ok := named[name]; ok {
+				graph.Edges = append(graph.Edges, presenters.TaskGraphEdge{From: from, To: id, Kind: "variable"})
+			}
+		}
+		if task.Name != "" {
+			if _, ok := named[task.Name]; !ok {
+				named[task.Name] = id
+			}
+		}
+	}
+	return graph
+}
+
+// renderDOT renders the graph in the Graphviz DOT language, with variable
+// references drawn as dashed edges.
+func renderDOT(graph presenters.TaskGraph) string {
+	var b strings.Builder
+	b.WriteString("digraph job {\n")
+	for _, node := range graph.Nodes {
+		label := node.Type
+		if node.Name != "" {
+			label = fmt.Sprintf("%s (%s)", node.Name, node.Type)
+		}
+		shape := "box"
+		if node.Kind == "initiator" {
+			shape = "ellipse"
+		}
+		fmt.Fprintf(&b, "  %s [label=%q shape=%s];\n", node.ID, label, shape)
+	}
+	for _, edge := range graph.Edges {
+		if edge.Kind == "variable" {
+			fmt.Fprintf(&b, "  %s -> %s [style=dashed];\n", edge.From, edge.To)
+		} else {
+			fmt.Fprintf(&b, "  %s -> %s;\n", edge.From, edge.To)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
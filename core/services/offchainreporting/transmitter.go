@@ -6,6 +6,7 @@ import (
 
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/store"
 )
 
 type transmitter struct {
@@ -23,8 +24,19 @@ func NewTransmitter(sqldb *sql.DB, fromAddress gethCommon.Address, gasLimit uint
 	}
 }
 
+// CreateEthTransaction queues a transmission of payload to toAddress. Reports
+// are refused rather than queued while transmissions are halted, as they
+// would be stale by the time transmissions are resumed.
 func (t *transmitter) CreateEthTransaction(ctx context.Context, toAddress gethCommon.Address, payload []byte) error {
-	_, err := t.db.ExecContext(ctx, `
+	var halted bool
+	err := t.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM transmission_halts WHERE resumed_at IS NULL)`).Scan(&halted)
+	if err != nil {
+		return errors.Wrap(err, "failed to check for transmission halts")
+	} else if halted {
+		return store.ErrTransmissionsHalted
+	}
+
+	_, err = t.db.ExecContext(ctx, `
 INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, state, created_at)
 VALUES ($1,$2,$3,$4,$5,'unstarted',NOW())
 `, t.fromAddress, toAddress, payload, 0, t.gasLimit)
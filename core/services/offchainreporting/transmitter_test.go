@@ -8,6 +8,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/stretchr/testify/require"
 )
@@ -36,3 +37,19 @@ func Test_Transmitter_CreateEthTransaction(t *testing.T) {
 	require.Equal(t, payload, etx.EncodedPayload)
 	require.Equal(t, assets.NewEthValue(0), etx.Value)
 }
+
+func Test_Transmitter_CreateEthTransaction_Halted(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	_, err := store.HaltTransmissions("incident", "operator")
+	require.NoError(t, err)
+
+	transmitter := offchainreporting.NewTransmitter(store.DB.DB(), gethCommon.HexToAddress(cltest.DefaultKey), 1000)
+	err = transmitter.CreateEthTransaction(context.Background(), cltest.NewAddress(), []byte{1})
+	require.Equal(t, strpkg.ErrTransmissionsHalted, err)
+
+	var count int
+	require.NoError(t, store.ORM.DB.Model(&models.EthTx{}).Count(&count).Error)
+	require.Equal(t, 0, count)
+}
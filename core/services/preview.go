@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// previewPureTasks are the task types which a preview performs: those which
+// only compute their result from their input, and HTTP GETs, which only
+// read. Any other task is skipped, so that a preview cannot send a
+// transaction, sign with the node's keys, change anything an endpoint holds
+// or block the request.
+var previewPureTasks = map[models.TaskType]bool{
+	adapters.TaskTypeAggregate:    true,
+	adapters.TaskTypeAssert:       true,
+	adapters.TaskTypeCompare:      true,
+	adapters.TaskTypeConvertUnits: true,
+	adapters.TaskTypeCopy:         true,
+	adapters.TaskTypeDecode:       true,
+	adapters.TaskTypeEncode:       true,
+	adapters.TaskTypeEncodePacked: true,
+	adapters.TaskTypeEthBool:      true,
+	adapters.TaskTypeEthBytes32:   true,
+	adapters.TaskTypeEthInt256:    true,
+	adapters.TaskTypeEthUint256:   true,
+	adapters.TaskTypeHash:         true,
+	adapters.TaskTypeJSONParse:    true,
+	adapters.TaskTypeMap:          true,
+	adapters.TaskTypeMerge:        true,
+	adapters.TaskTypeMerkle:       true,
+	adapters.TaskTypeMultiply:     true,
+	adapters.TaskTypeNoOp:         true,
+	adapters.TaskTypeParseNumber:  true,
+	adapters.TaskTypePluck:        true,
+	adapters.TaskTypeQuotient:     true,
+	adapters.TaskTypeReduce:       true,
+	adapters.TaskTypeTypedData:    true,
+	adapters.TaskTypeWasm:         true,
+
+	adapters.TaskTypeHTTPGet:                              true,
+	adapters.TaskTypeHTTPGetWithUnrestrictedNetworkAccess: true,
+}
+
+// previewPerforms returns whether a preview performs task. HTTP POSTs and
+// bridges are only performed when the preview records or replays their
+// responses, which the caller asks for in order to exercise them.
+func previewPerforms(task models.TaskSpec, fixtures *adapters.HTTPFixtures) bool {
+	if previewPureTasks[task.Type] {
+		return true
+	}
+	if fixtures == nil {
+		return false
+	}
+	switch task.Type {
+	case adapters.TaskTypeHTTPPost, adapters.TaskTypeHTTPPostWithUnrestrictedNetworkAccess:
+		return true
+	}
+	return adapters.FindNativeAdapterFor(task) == nil
+}
+
+// PreviewJob performs the tasks of job once, in order, passing each result on
+// to the next task as a real run would. Neither the job nor its run is saved.
+//
+// Tasks which are not performed, see previewPerforms, are reported as skipped
+// and their input is passed through unchanged. The preview stops at the
+// first task which errors or does not complete synchronously, such as a
+// bridge returning pending.
+//
+// If fixtures are given, the HTTP and bridge tasks record their responses to
+// them or replay them.
+//
+// The preview includes an estimate of the job's cost, see estimateJobCost.
+func PreviewJob(store *store.Store, job models.JobSpec, requestParams models.JSON, fixtures *adapters.HTTPFixtures) presenters.JobPreview {
+	preview := presenters.JobPreview{
+		ID:     job.ID,
+		Status: models.RunStatusCompleted,
+		Tasks:  make([]presenters.TaskPreview, len(job.Tasks)),
+	}
+	runID := models.NewID()
+	if fixtures != nil {
+		defer adapters.UseHTTPFixtures(runID, fixtures)()
+	}
+	previous := models.JSON{}
+	results := map[string]models.JSON{}
+	var elapsed time.Duration
+
+	for i, task := range job.Tasks {
+		taskPreview := &preview.Tasks[i]
+		taskPreview.Type = task.Type
+
+		if preview.Status != models.RunStatusCompleted || !previewPerforms(task, fixtures) {
+			taskPreview.Skipped = true
+			continue
+		}
+
+		start := time.Now()
+		output := previewTask(store, task, runID, requestParams, previous, results)
+		elapsed += time.Since(start)
+		taskPreview.Status = output.Status()
+		taskPreview.Result = output.Data()
+		if output.HasError() {
+			taskPreview.Error = output.Error().Error()
+		}
+		if !output.Status().Completed() {
+			preview.Status = output.Status()
+			continue
+		}
+		previous = output.Data()
+		if task.Name != "" {
+			results[task.Name] = previous
+		}
+	}
+	preview.Estimate = estimateJobCost(store, job, elapsed)
+	return preview
+}
+
+func previewTask(
+	store *store.Store,
+	task models.TaskSpec,
+	runID *models.ID,
+	requestParams, previous models.JSON,
+	results map[string]models.JSON,
+) models.RunOutput {
+	specParams, err := models.InterpolateTaskVariables(task.Params, results)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	params, err := models.Merge(requestParams, specParams)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	task.Params = params
+
+	data, err := models.Merge(requestParams, previous)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	adapter, err := adapters.For(task, store.Config, store.ORM)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+
+	input := *models.NewRunInput(runID, *models.NewID(), data, models.RunStatusInProgress)
+	return adapter.Perform(input, store)
+}
+
+// ethTxRPCCalls is the number of calls made to the ethereum node for each
+// transaction sent, to send it and to get its receipt.
+const ethTxRPCCalls = 2
+
+// networkGasPriceTimeout bounds how long a preview waits for the ethereum
+// node's gas price.
+const networkGasPriceTimeout = 5 * time.Second
+
+// estimateJobCost works out the cost of each run of job from its tasks, and
+// how many runs it makes a day from its initiators. The gas of transactions
+// is priced at the network's current gas price, unless their task sets its
+// own. A run takes as long as its tasks took to preview, plus any delay they
+// add.
+func estimateJobCost(store *store.Store, job models.JobSpec, previewed time.Duration) presenters.JobCostEstimate {
+	gasPrice := networkGasPrice(store)
+	estimate := presenters.JobCostEstimate{GasPrice: utils.NewBig(gasPrice)}
+	wei := new(big.Int)
+	addTx := func(gasLimit uint64, price *utils.Big) {
+		if gasLimit == 0 {
+			gasLimit = store.Config.EthGasLimitDefault()
+		}
+		txPrice := gasPrice
+		if price != nil {
+			txPrice = price.ToInt()
+		}
+		estimate.RPCCallsPerRun += ethTxRPCCalls
+		estimate.GasPerRun += gasLimit
+		wei.Add(wei, new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), txPrice))
+	}
+
+	runDuration := previewed
+	for _, task := range job.Tasks {
+		adapter, err := adapters.For(task, store.Config, store.ORM)
+		if err != nil {
+			continue
+		}
+		switch a := adapter.BaseAdapter.(type) {
+		case *adapters.EthTx:
+			addTx(a.GasLimit, a.GasPrice)
+		case *adapters.EthTxABIEncode:
+			addTx(a.GasLimit, a.GasPrice)
+		case *adapters.EthTxCommitReveal:
+			addTx(a.GasLimit, nil)
+			addTx(a.GasLimit, nil)
+			runDuration += a.RevealDelay.Duration()
+		case *adapters.Delay:
+			runDuration += a.Duration.Duration() + a.Jitter.Duration()
+		case *adapters.HTTPGet, *adapters.HTTPPost, *adapters.Bridge:
+			estimate.ExternalCallsPerRun++
+		}
+	}
+	estimate.WeiPerRun = utils.NewBig(wei)
+	estimate.RunDuration = models.MustMakeDuration(runDuration)
+
+	estimate.RunsPerDay = runsPerDay(job, time.Now())
+	estimate.ExternalCallsPerDay = estimate.RunsPerDay * estimate.ExternalCallsPerRun
+	return estimate
+}
+
+// networkGasPrice returns the gas price the ethereum node suggests, falling
+// back to ETH_GAS_PRICE_DEFAULT when the node cannot be asked.
+func networkGasPrice(store *store.Store) *big.Int {
+	ctx, cancel := context.WithTimeout(context.Background(), networkGasPriceTimeout)
+	defer cancel()
+	var gasPrice hexutil.Big
+	if err := store.EthClient.CallContext(ctx, &gasPrice, "eth_gasPrice"); err != nil {
+		logger.Warnw("Could not get the network gas price, estimating job cost with ETH_GAS_PRICE_DEFAULT", "err", err)
+		return store.Config.EthGasPriceDefault()
+	}
+	return gasPrice.ToInt()
+}
+
+// runsPerDay counts the runs the cron and flux monitor initiators of job
+// start in the day from now. Flux monitors are counted as starting a run at
+// each poll, though they only do so when the answer has deviated.
+func runsPerDay(job models.JobSpec, now time.Time) int {
+	day := now.Add(24 * time.Hour)
+	runs := 0
+	for _, initr := range job.Initiators {
+		switch initr.Type {
+		case models.InitiatorCron:
+			schedule, err := models.CronParser.Parse(string(initr.Schedule))
+			if err != nil {
+				continue
+			}
+			for next := schedule.Next(now); !next.IsZero() && next.Before(day); next = schedule.Next(next) {
+				runs++
+			}
+		case models.InitiatorFluxMonitor:
+			period := initr.PollTimer.Period.Duration()
+			if !initr.PollTimer.Disabled && period > 0 {
+				runs += int(24 * time.Hour / period)
+			}
+		}
+	}
+	return runs
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// RunDeadlineInterval is how often waiting runs are checked for having passed
+// their deadline.
+const RunDeadlineInterval = time.Minute
+
+// RunDeadlineSweeper errors the runs which are still waiting on a bridge or
+// on incoming confirmations once their job's MaxRunDuration has passed. Runs
+// which are executed after their deadline are errored by the run executor,
+// but a run waiting on a bridge which never answers is not executed again.
+// Runs waiting on the confirmation of a transaction they have sent are not
+// errored, as the transaction may still be mined.
+type RunDeadlineSweeper interface {
+	Start() error
+	Stop() error
+	Sweep() error
+}
+
+type runDeadlineSweeper struct {
+	store    *store.Store
+	interval time.Duration
+
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewRunDeadlineSweeper returns a RunDeadlineSweeper which checks for overdue
+// runs every interval.
+func NewRunDeadlineSweeper(store *store.Store, interval time.Duration) RunDeadlineSweeper {
+	return &runDeadlineSweeper{
+		store:    store,
+		interval: interval,
+		chStop:   make(chan struct{}),
+	}
+}
+
+// Start sweeps overdue runs straight away, and then every interval.
+func (rds *runDeadlineSweeper) Start() error {
+	rds.wg.Add(1)
+	go rds.run()
+	return nil
+}
+
+// Stop waits for the sweep in progress, if any, and stops.
+func (rds *runDeadlineSweeper) Stop() error {
+	close(rds.chStop)
+	rds.wg.Wait()
+	return nil
+}
+
+func (rds *runDeadlineSweeper) run() {
+	defer rds.wg.Done()
+	ticker := time.NewTicker(rds.interval)
+	defer ticker.Stop()
+	for {
+		if err := rds.Sweep(); err != nil {
+			logger.Errorw("Unable to error overdue runs", "error", err)
+		}
+		select {
+		case <-rds.chStop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep errors every waiting run whose deadline has passed. A run which is
+// resumed while it is being swept is left to the run executor.
+func (rds *runDeadlineSweeper) Sweep() error {
+	runs, err := rds.store.OverdueJobRuns(time.Now())
+	if err != nil {
+		return err
+	}
+	var merr error
+	for i := range runs {
+		run := &runs[i]
+		job, err := rds.store.Unscoped().FindJob(run.JobSpecID)
+		if err != nil {
+			merr = multierr.Append(merr, errors.Wrapf(err, "finding job of run %s", run.ID))
+			continue
+		}
+		deadlineExceeded := models.NewRunOutputError(runDeadlineError(job))
+		if taskRun := run.NextTaskRun(); taskRun != nil {
+			taskRun.ApplyOutput(deadlineExceeded)
+		}
+		run.ApplyOutput(deadlineExceeded)
+		err = rds.store.SaveJobRun(run)
+		if errors.Cause(err) == orm.ErrOptimisticUpdateConflict {
+			continue
+		} else if err != nil {
+			merr = multierr.Append(merr, errors.Wrapf(err, "erroring run %s", run.ID))
+			continue
+		}
+		logger.Warnw("Run exceeded its deadline while waiting", run.ForLogger("maxRunDuration", job.MaxRunDuration)...)
+	}
+	return merr
+}
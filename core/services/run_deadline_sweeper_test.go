@@ -0,0 +1,63 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDeadlineSweeper_Sweep(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.MaxRunDuration = models.MustMakeDuration(time.Minute)
+	require.NoError(t, store.CreateJob(&job))
+	unlimited := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&unlimited))
+
+	overdue := cltest.NewJobRunPendingBridge(job)
+	overdue.CreatedAt = time.Now().Add(-2 * time.Minute)
+	require.NoError(t, store.CreateJobRun(&overdue))
+	onTime := cltest.NewJobRunPendingBridge(job)
+	require.NoError(t, store.CreateJobRun(&onTime))
+	sent := cltest.NewJobRun(job)
+	sent.CreatedAt = time.Now().Add(-2 * time.Minute)
+	sent.SetStatus(models.RunStatusPendingOutgoingConfirmations)
+	sent.TaskRuns[0].Status = models.RunStatusPendingOutgoingConfirmations
+	require.NoError(t, store.CreateJobRun(&sent))
+	waitingForever := cltest.NewJobRunPendingBridge(unlimited)
+	waitingForever.CreatedAt = time.Now().Add(-time.Hour)
+	require.NoError(t, store.CreateJobRun(&waitingForever))
+
+	sweeper := services.NewRunDeadlineSweeper(store, time.Hour)
+	require.NoError(t, sweeper.Sweep())
+
+	run, err := store.FindJobRun(overdue.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusErrored, run.GetStatus())
+	assert.Equal(t, models.RunStatusErrored, run.TaskRuns[0].Status)
+	assert.Equal(t, "run timed out after exceeding the job's maxRunDuration of 1m0s", run.Result.ErrorMessage.String)
+
+	run, err = store.FindJobRun(onTime.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusPendingBridge, run.GetStatus())
+	run, err = store.FindJobRun(sent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusPendingOutgoingConfirmations, run.GetStatus())
+	run, err = store.FindJobRun(waitingForever.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusPendingBridge, run.GetStatus())
+
+	runs, err := store.OverdueJobRuns(time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+}
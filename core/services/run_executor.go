@@ -1,7 +1,13 @@
 package services
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"runtime"
+	"runtime/pprof"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/adapters"
@@ -23,6 +29,19 @@ var (
 	},
 		[]string{"job_spec_id", "task_type", "status"},
 	)
+	promTaskExecutionTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "task_execution_duration_seconds",
+		Help:    "How long each task took to execute, including failed executions",
+		Buckets: prometheus.DefBuckets,
+	},
+		[]string{"job_spec_id", "task_type"},
+	)
+	promTaskErrorsVec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "task_execution_errors_total",
+		Help: "The total number of task executions which ended in an error",
+	},
+		[]string{"job_spec_id", "task_type"},
+	)
 )
 
 //go:generate mockery --name RunExecutor --output ../internal/mocks/ --case=underscore
@@ -51,6 +70,22 @@ func (re *runExecutor) Execute(runID *models.ID) error {
 	if err != nil {
 		return errors.Wrapf(err, "error finding run %s", runID)
 	}
+	job, err := re.store.Unscoped().FindJob(run.JobSpecID)
+	if err != nil {
+		return errors.Wrapf(err, "error finding job %s of run %s", run.JobSpecID, runID)
+	}
+
+	if run.Profile {
+		defer re.profileExecution(&run)()
+	}
+
+	ctx := context.Background()
+	if !job.MaxRunDuration.IsInstant() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, run.CreatedAt.Add(job.MaxRunDuration.Duration()))
+		defer cancel()
+		defer adapters.WithRunContext(run.ID, ctx)()
+	}
 
 	for taskIndex := range run.TaskRuns {
 		taskRun := &run.TaskRuns[taskIndex]
@@ -63,14 +98,34 @@ func (re *runExecutor) Execute(runID *models.ID) error {
 			continue
 		}
 
-		if meetsMinRequiredIncomingConfirmations(&run, taskRun, run.ObservedHeight) {
+		// A task whose transaction has been sent is left to see it confirmed
+		if ctx.Err() != nil && taskRun.Status != models.RunStatusPendingOutgoingConfirmations {
+			deadlineExceeded := models.NewRunOutputError(runDeadlineError(job))
+			taskRun.ApplyOutput(deadlineExceeded)
+			run.ApplyOutput(deadlineExceeded)
+			logger.Warnw("Run exceeded its deadline", run.ForLogger("task", taskRun.ID.String(), "maxRunDuration", job.MaxRunDuration)...)
+		} else if meetsMinRequiredIncomingConfirmations(&run, taskRun, run.ObservedHeight) {
 			start := time.Now()
 
 			// NOTE: adapters may define and return the new job run status in here
-			result := re.executeTask(&run, *taskRun)
+			result := re.executeTask(&run, job, *taskRun)
+			if result.HasError() && ctx.Err() != nil {
+				// The task's requests were cancelled by the deadline
+				result = models.NewRunOutputError(runDeadlineError(job))
+			}
+			limit := taskRun.TaskSpec.ResultSizeLimit(re.store.Config.MaxTaskResultSize())
+			if truncated, ok := result.TruncateResult(limit); ok {
+				logger.Warnw("Truncated task result larger than the task's result size limit", run.ForLogger("task", taskRun.ID.String(), "size", len(result.Data().Raw), "limit", limit)...)
+				result = truncated
+			}
 
 			taskRun.ApplyOutput(result)
 			run.ApplyOutput(result)
+			if run.GetStatus().Completed() {
+				if err := re.completeRun(&run, job); err != nil {
+					run.SetError(err)
+				}
+			}
 
 			elapsed := time.Since(start).Seconds()
 
@@ -105,10 +160,122 @@ func (re *runExecutor) Execute(runID *models.ID) error {
 	return nil
 }
 
-func (re *runExecutor) executeTask(run *models.JobRun, taskRun models.TaskRun) models.RunOutput {
+// profileExecution starts profiling the CPU, unless another CPU profile is
+// being taken, and returns a function which stops it and saves it along with
+// a heap profile as the run's profiles.
+func (re *runExecutor) profileExecution(run *models.JobRun) func() {
+	cpu := new(bytes.Buffer)
+	cpuErr := pprof.StartCPUProfile(cpu)
+	if cpuErr != nil {
+		logger.Warnw("Cannot profile the CPU use of run", run.ForLogger("error", cpuErr)...)
+	}
+	return func() {
+		profile := models.RunProfile{JobRunID: run.ID, CreatedAt: time.Now()}
+		if cpuErr == nil {
+			pprof.StopCPUProfile()
+			profile.CPU = cpu.Bytes()
+		}
+		// Collect garbage so that the heap profile is up to date
+		runtime.GC()
+		heap := new(bytes.Buffer)
+		if err := pprof.Lookup("heap").WriteTo(heap, 0); err != nil {
+			logger.Errorw("Error profiling the heap after run", run.ForLogger("error", err)...)
+			return
+		}
+		profile.Heap = heap.Bytes()
+		if err := re.store.SaveRunProfile(&profile); err != nil {
+			logger.Errorw("Error saving run profile", run.ForLogger("error", err)...)
+		}
+	}
+}
+
+// runDeadlineError is the error of a run which took longer than its job's
+// MaxRunDuration.
+func runDeadlineError(job models.JobSpec) error {
+	return fmt.Errorf("run timed out after exceeding the job's maxRunDuration of %s", job.MaxRunDuration)
+}
+
+// completeRun attests the result of a run which has just completed,
+// discards the results of its intermediate tasks if its job only keeps the
+// final result, and clears the errors its job had before it began.
+func (re *runExecutor) completeRun(run *models.JobRun, job models.JobSpec) error {
+	if err := re.attestResult(run, job); err != nil {
+		return errors.Wrap(err, "attesting run result")
+	}
+	if job.FinalResultOnly {
+		run.DiscardIntermediateResults()
+	}
+	// Errors which occurred before this run began have been recovered from
+	if err := re.store.ClearJobSpecErrorsBefore(job.ID, run.CreatedAt); err != nil {
+		logger.Errorw("Error clearing job spec errors", run.ForLogger("error", err)...)
+	}
+	return nil
+}
+
+// attestResult adds an attestation of the run's result to its data, if its
+// job has an attestation key.
+func (re *runExecutor) attestResult(run *models.JobRun, job models.JobSpec) error {
+	if job.AttestationKey == nil {
+		return nil
+	}
+
+	// The value is compacted as it will be when the attestation is encoded, so
+	// that consumers can verify the signature over the value they receive
+	value := new(bytes.Buffer)
+	if result := run.Result.Data.Get("result"); result.Exists() {
+		if err := json.Compact(value, []byte(result.Raw)); err != nil {
+			return err
+		}
+	} else {
+		value.WriteString("null")
+	}
+	attestation := models.Attestation{
+		JobSpecID: job.ID,
+		Timestamp: time.Now().Unix(),
+		Value:     value.Bytes(),
+		Signer:    *job.AttestationKey,
+	}
+	digest, err := attestation.Digest()
+	if err != nil {
+		return err
+	}
+	account, err := re.store.KeyStore.GetAccountByAddress(job.AttestationKey.Address())
+	if err != nil {
+		return err
+	}
+	attestation.Signature, err = re.store.KeyStore.SignHashWithAccount(account, digest)
+	if err != nil {
+		return err
+	}
+
+	run.Result.Data, err = run.Result.Data.Add("attestation", attestation)
+	return err
+}
+
+// executeTask performs a single task, recording its duration and whether it
+// errored. Tasks which fail before reaching their adapter, for example on bad
+// params, are counted under the type named in their spec.
+func (re *runExecutor) executeTask(run *models.JobRun, job models.JobSpec, taskRun models.TaskRun) models.RunOutput {
+	start := time.Now()
+	result := re.performTask(run, job, taskRun)
+
+	jobSpecID := run.JobSpecID.String()
+	taskType := string(taskRun.TaskSpec.Type)
+	promTaskExecutionTime.WithLabelValues(jobSpecID, taskType).Observe(time.Since(start).Seconds())
+	if result.HasError() {
+		promTaskErrorsVec.WithLabelValues(jobSpecID, taskType).Inc()
+	}
+	return result
+}
+
+func (re *runExecutor) performTask(run *models.JobRun, job models.JobSpec, taskRun models.TaskRun) models.RunOutput {
 	taskSpec := taskRun.TaskSpec
 
-	params, err := models.Merge(run.RunRequest.RequestParams, taskSpec.Params)
+	specParams, err := models.InterpolateTaskVariables(taskSpec.Params, run.NamedTaskResults())
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	params, err := models.Merge(run.RunRequest.RequestParams, specParams)
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
@@ -126,7 +293,24 @@ func (re *runExecutor) executeTask(run *models.JobRun, taskRun models.TaskRun) m
 		previousTaskInput = previousTaskRun.Result.Data
 	}
 
-	data, err := models.Merge(run.RunRequest.RequestParams, previousTaskInput, taskRun.Result.Data)
+	data, err := models.Merge(run.RunRequest.RequestParams, previousTaskInput)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	if resultEncodingTaskTypes[taskSpec.Type] && models.IsTruncated(data) {
+		return models.NewRunOutputError(fmt.Errorf(
+			"refusing to encode a result truncated to %d of its %d bytes",
+			data.Get("truncated.limit").Int(), data.Get("truncated.size").Int(),
+		))
+	}
+	if job.ResultFormat != nil && formatsResult(run, taskRun) {
+		data, err = job.ResultFormat.FormatData(data, numericABIArguments(taskSpec))
+		if err != nil {
+			return models.NewRunOutputError(err)
+		}
+	}
+	// The task's own data, if it is resuming, already has its formatted result
+	data, err = models.Merge(data, taskRun.Result.Data)
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
@@ -137,3 +321,48 @@ func (re *runExecutor) executeTask(run *models.JobRun, taskRun models.TaskRun) m
 
 	return result
 }
+
+// resultEncodingTaskTypes are the types of task which encode the result of a
+// run for the chain.
+var resultEncodingTaskTypes = map[models.TaskType]bool{
+	adapters.TaskTypeEthInt256:         true,
+	adapters.TaskTypeEthUint256:        true,
+	adapters.TaskTypeEthTx:             true,
+	adapters.TaskTypeEthTxABIEncode:    true,
+	adapters.TaskTypeEthTxCommitReveal: true,
+}
+
+// numericABIArguments returns the names of the integer arguments of an
+// ethtxabiencode task's function, the arguments of its result object which
+// the job's ResultFormat formats.
+func numericABIArguments(taskSpec models.TaskSpec) []string {
+	if taskSpec.Type != adapters.TaskTypeEthTxABIEncode {
+		return nil
+	}
+	var names []string
+	for _, input := range taskSpec.Params.Get("functionABI.inputs").Array() {
+		if numericABIType.MatchString(input.Get("type").String()) {
+			names = append(names, input.Get("name").String())
+		}
+	}
+	return names
+}
+
+var numericABIType = regexp.MustCompile(`^u?int[0-9]*$`)
+
+// formatsResult returns whether the task is the first of its run to encode
+// the run's result for the chain, and so is given the result formatted by
+// the job's ResultFormat.
+func formatsResult(run *models.JobRun, taskRun models.TaskRun) bool {
+	if !resultEncodingTaskTypes[taskRun.TaskSpec.Type] {
+		return false
+	}
+	for _, tr := range run.TaskRuns {
+		if tr.ID.String() == taskRun.ID.String() {
+			return true
+		} else if resultEncodingTaskTypes[tr.TaskSpec.Type] {
+			return false
+		}
+	}
+	return false
+}
@@ -1,8 +1,11 @@
 package services_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 	"time"
@@ -13,9 +16,14 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/services"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -57,6 +65,38 @@ func TestRunExecutor_Execute(t *testing.T) {
 	assert.Equal(t, assets.NewLink(9117), actual)
 }
 
+func TestRunExecutor_Execute_RecordsTaskMetrics(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+	runExecutor := services.NewRunExecutor(store, pusher)
+
+	for _, test := range []struct {
+		taskType string
+		errored  float64
+	}{
+		{"noop", 0},
+		{"nonexistentadapter", 1},
+	} {
+		j := models.NewJob()
+		j.Initiators = []models.Initiator{{Type: models.InitiatorWeb}}
+		j.Tasks = []models.TaskSpec{cltest.NewTask(t, test.taskType)}
+		require.NoError(t, store.CreateJob(&j))
+		run := cltest.NewJobRun(j)
+		require.NoError(t, store.CreateJobRun(&run))
+
+		require.NoError(t, runExecutor.Execute(run.ID))
+
+		timed, errored := services.ExportedTaskMetrics(j.ID.String(), test.taskType)
+		assert.True(t, timed, test.taskType)
+		assert.Equal(t, test.errored, errored, test.taskType)
+	}
+}
+
 func TestRunExecutor_Execute_PendingOutgoing(t *testing.T) {
 	t.Parallel()
 
@@ -223,3 +263,371 @@ func TestJobRunner_prioritizeSpecParamsOverRequestParams(t *testing.T) {
 	expected := strconv.FormatUint(uint64(requestBase*specParameter), 10)
 	assert.Equal(t, expected, actual)
 }
+
+func TestRunExecutor_Execute_MergesNamedTaskResults(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+	j := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		{Name: "doubled", Type: adapters.TaskTypeMultiply, Params: cltest.JSONFromString(t, `{"times": 2}`)},
+		{Name: "quadrupled", Type: adapters.TaskTypeMultiply, Params: cltest.JSONFromString(t, `{"times": 2}`)},
+		{Type: adapters.TaskTypeMerge, Params: cltest.JSONFromString(t, `{"values": {"double": "$(doubled.result)", "quadruple": "$(quadrupled.result)"}}`)},
+	}
+	assert.NoError(t, store.CreateJob(&j))
+	run := cltest.NewJobRun(j)
+	run.RunRequest.RequestParams = cltest.JSONFromString(t, `{"result": 3}`)
+	assert.NoError(t, store.CreateJobRun(&run))
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+	run = cltest.WaitForJobRunToComplete(t, store, run)
+
+	assert.JSONEq(t, `{"double": "6", "quadruple": "12"}`, run.Result.Data.Get("result").Raw)
+}
+
+func TestRunExecutor_Execute_AttestsResult(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, store.KeyStore.Unlock(cltest.Password))
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+	key := cltest.MustGetFixtureKey(t, store)
+	j := cltest.NewJobWithWebInitiator()
+	j.AttestationKey = &key.Address
+	j.Tasks = []models.TaskSpec{
+		{Type: adapters.TaskTypeMultiply, Params: cltest.JSONFromString(t, `{"times": 2}`)},
+	}
+	assert.NoError(t, store.CreateJob(&j))
+	run := cltest.NewJobRun(j)
+	run.RunRequest.RequestParams = cltest.JSONFromString(t, `{"result": 21}`)
+	assert.NoError(t, store.CreateJobRun(&run))
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+	run = cltest.WaitForJobRunToComplete(t, store, run)
+
+	var attestation models.Attestation
+	require.NoError(t, json.Unmarshal([]byte(run.Result.Data.Get("attestation").Raw), &attestation))
+	assert.Equal(t, j.ID, attestation.JobSpecID)
+	assert.Equal(t, key.Address, attestation.Signer)
+	assert.JSONEq(t, `"42"`, string(attestation.Value))
+
+	digest, err := attestation.Digest()
+	require.NoError(t, err)
+	prefixed, err := utils.Keccak256(append([]byte(strpkg.EthereumMessageHashPrefix), digest.Bytes()...))
+	require.NoError(t, err)
+	pubKey, err := crypto.SigToPub(prefixed, attestation.Signature.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, key.Address.Address(), crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestRunExecutor_Execute_TruncatesLargeResults(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+	j := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		{Type: adapters.TaskTypeNoOp, MaxResultSize: null.Int64From(16)},
+	}
+	assert.NoError(t, store.CreateJob(&j))
+	run := cltest.NewJobRun(j)
+	run.RunRequest.RequestParams = cltest.JSONFromString(t, `{"result": "0123456789abcdefghijklmnopqrstuvwxyz"}`)
+	assert.NoError(t, store.CreateJobRun(&run))
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+	run = cltest.WaitForJobRunToComplete(t, store, run)
+
+	assert.Equal(t, "0123456789abcdef", run.TaskRuns[0].Result.Data.Get("result").String())
+	assert.Equal(t, int64(16), run.TaskRuns[0].Result.Data.Get("truncated.limit").Int())
+}
+
+func TestRunExecutor_Execute_RefusesToEncodeTruncatedResults(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+	j := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		{Type: adapters.TaskTypeNoOp, MaxResultSize: null.Int64From(16)},
+		{Type: adapters.TaskTypeEthUint256},
+	}
+	assert.NoError(t, store.CreateJob(&j))
+	run := cltest.NewJobRun(j)
+	run.RunRequest.RequestParams = cltest.JSONFromString(t, `{"result": "123456789012345678901234567890"}`)
+	assert.NoError(t, store.CreateJobRun(&run))
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+	run, err := store.FindJobRun(run.ID)
+	require.NoError(t, err)
+
+	assert.True(t, run.GetStatus().Errored())
+	assert.Equal(t, "1234567890123456", run.TaskRuns[0].Result.Data.Get("result").String())
+	assert.Contains(t, run.TaskRuns[1].Result.ErrorMessage.String, "refusing to encode a result truncated")
+}
+
+func TestRunExecutor_Execute_FormatsResultBeforeEncoding(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+	multiplier := decimal.NewFromInt(100)
+	j := cltest.NewJobWithWebInitiator()
+	j.ResultFormat = &models.ResultFormat{Multiplier: &multiplier, Rounding: models.ResultRoundingTruncate}
+	j.Tasks = []models.TaskSpec{
+		{Type: adapters.TaskTypeMultiply, Params: cltest.JSONFromString(t, `{"times": 10}`)},
+		{Type: adapters.TaskTypeEthUint256},
+	}
+	assert.NoError(t, store.CreateJob(&j))
+	run := cltest.NewJobRun(j)
+	run.RunRequest.RequestParams = cltest.JSONFromString(t, `{"result": "1.23456"}`)
+	assert.NoError(t, store.CreateJobRun(&run))
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+	run = cltest.WaitForJobRunToComplete(t, store, run)
+
+	// 1.23456 * 10 * 100, truncated
+	assert.Equal(t, "12.3456", run.TaskRuns[0].Result.Data.Get("result").String())
+	assert.Equal(t, "0x00000000000000000000000000000000000000000000000000000000000004d2", run.Result.Data.Get("result").String())
+}
+
+func TestRunExecutor_Execute_FormatsABIEncodeArguments(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		cltest.LenientEthMock,
+		cltest.EthMockRegisterGetBalance,
+	)
+	defer cleanup()
+	store := app.Store
+
+	app.EthMock.Context("app.Start()", func(meth *cltest.EthMock) {
+		meth.Register("eth_getTransactionCount", "0x1")
+		meth.Register("eth_chainId", store.Config.ChainID())
+	})
+	require.NoError(t, app.StartAndConnect())
+
+	// Only the decimal uint256 argument is formatted: the hex argument and
+	// the bytes are encoded as they are
+	selector := utils.MustHash("set(uint256,uint256,bytes)")
+	expected := hexutil.Encode(selector[:4]) +
+		"000000000000000000000000000000000000000000000000000000000000007b" +
+		"0000000000000000000000000000000000000000000000000000000000000010" +
+		"0000000000000000000000000000000000000000000000000000000000000060" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"1234000000000000000000000000000000000000000000000000000000000000"
+	hash := cltest.NewHash()
+	app.EthMock.Register("eth_sendRawTransaction", hash,
+		func(_ interface{}, data ...interface{}) error {
+			rlp := data[0].([]interface{})[0].(string)
+			tx, err := utils.DecodeEthereumTx(rlp)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, hexutil.Encode(tx.Data()))
+			return nil
+		})
+	app.EthMock.Register("eth_getTransactionReceipt", &gethTypes.Receipt{TxHash: hash, BlockNumber: big.NewInt(1)})
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+	runExecutor := services.NewRunExecutor(store, pusher)
+
+	multiplier := decimal.NewFromInt(100)
+	j := cltest.NewJobWithWebInitiator()
+	j.ResultFormat = &models.ResultFormat{Multiplier: &multiplier}
+	j.Tasks = []models.TaskSpec{{
+		Type: adapters.TaskTypeEthTxABIEncode,
+		Params: cltest.JSONFromString(t, `{
+			"address": "0xdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			"functionABI": {
+				"name": "set",
+				"inputs": [
+					{"name": "price", "type": "uint256"},
+					{"name": "round", "type": "uint256"},
+					{"name": "note", "type": "bytes"}
+				]
+			}
+		}`),
+	}}
+	require.NoError(t, store.CreateJob(&j))
+	run := cltest.NewJobRun(j)
+	run.RunRequest.RequestParams = cltest.JSONFromString(t, `{"result": {"price": "1.2345", "round": "0x10", "note": "0x1234"}}`)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+	app.EthMock.EventuallyAllCalled(t)
+
+	run, err := store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	assert.False(t, run.HasError())
+}
+
+func TestRunExecutor_FormatsResultOfFirstEncodingTask(t *testing.T) {
+	t.Parallel()
+
+	taskTypes := []models.TaskType{
+		adapters.TaskTypeEthInt256,
+		adapters.TaskTypeEthUint256,
+		adapters.TaskTypeEthTx,
+		adapters.TaskTypeEthTxABIEncode,
+		adapters.TaskTypeEthTxCommitReveal,
+	}
+	for _, taskType := range taskTypes {
+		t.Run(string(taskType), func(t *testing.T) {
+			j := cltest.NewJobWithWebInitiator()
+			j.Tasks = []models.TaskSpec{
+				{Type: adapters.TaskTypeMultiply},
+				{Type: taskType},
+				{Type: adapters.TaskTypeEthTx},
+			}
+			run := cltest.NewJobRun(j)
+
+			assert.False(t, services.ExportedFormatsResult(&run, run.TaskRuns[0]))
+			assert.True(t, services.ExportedFormatsResult(&run, run.TaskRuns[1]))
+			assert.False(t, services.ExportedFormatsResult(&run, run.TaskRuns[2]))
+		})
+	}
+}
+
+func TestRunExecutor_Execute_FinalResultOnly(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+	j := cltest.NewJobWithWebInitiator()
+	j.FinalResultOnly = true
+	j.Tasks = []models.TaskSpec{
+		{Type: adapters.TaskTypeMultiply, Params: cltest.JSONFromString(t, `{"times": 2}`)},
+		{Type: adapters.TaskTypeMultiply, Params: cltest.JSONFromString(t, `{"times": 3}`)},
+	}
+	assert.NoError(t, store.CreateJob(&j))
+	run := cltest.NewJobRun(j)
+	run.RunRequest.RequestParams = cltest.JSONFromString(t, `{"result": 7}`)
+	assert.NoError(t, store.CreateJobRun(&run))
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+	run = cltest.WaitForJobRunToComplete(t, store, run)
+
+	require.Len(t, run.TaskRuns, 2)
+	assert.False(t, run.TaskRuns[0].Result.Data.Get("result").Exists())
+	assert.Equal(t, "42", run.TaskRuns[1].Result.Data.Get("result").String())
+	assert.Equal(t, "42", run.Result.Data.Get("result").String())
+}
+
+func TestRunExecutor_Execute_ClearsJobSpecErrors(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+	j := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{{Type: adapters.TaskTypeNoOp}}
+	require.NoError(t, store.CreateJob(&j))
+	store.UpsertErrorFor(j.ID, "before the run")
+	require.NoError(t, store.DB.Exec(`UPDATE job_spec_errors SET updated_at = ?`, time.Now().Add(-time.Hour)).Error)
+
+	run := cltest.NewJobRun(j)
+	require.NoError(t, store.CreateJobRun(&run))
+	store.UpsertErrorFor(j.ID, "during the run")
+
+	require.NoError(t, runExecutor.Execute(run.ID))
+	cltest.WaitForJobRunToComplete(t, store, run)
+
+	jses, err := store.JobSpecErrorsFor(j.ID, true)
+	require.NoError(t, err)
+	require.Len(t, jses, 1)
+	assert.Equal(t, "during the run", jses[0].Description)
+}
+
+func TestRunExecutor_Execute_MaxRunDuration(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runExecutor := services.NewRunExecutor(store, pusher)
+
+	t.Run("cancels the requests in flight when the deadline passes", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+		defer server.Close()
+
+		j := cltest.NewJobWithWebInitiator()
+		j.MaxRunDuration = models.MustMakeDuration(200 * time.Millisecond)
+		j.Tasks = []models.TaskSpec{
+			{Type: adapters.TaskTypeHTTPGetWithUnrestrictedNetworkAccess, Params: cltest.JSONFromString(t, `{"get": "`+server.URL+`"}`)},
+			cltest.NewTask(t, "noop"),
+		}
+		require.NoError(t, store.CreateJob(&j))
+		run := cltest.NewJobRun(j)
+		require.NoError(t, store.CreateJobRun(&run))
+
+		start := time.Now()
+		require.NoError(t, runExecutor.Execute(run.ID))
+		assert.True(t, time.Since(start) < 5*time.Second)
+
+		run, err := store.FindJobRun(run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RunStatusErrored, run.GetStatus())
+		assert.Equal(t, "run timed out after exceeding the job's maxRunDuration of 200ms", run.Result.ErrorMessage.String)
+		assert.Equal(t, models.RunStatusUnstarted, run.TaskRuns[1].Status)
+	})
+
+	t.Run("errors a run resumed after its deadline", func(t *testing.T) {
+		j := cltest.NewJobWithWebInitiator()
+		j.MaxRunDuration = models.MustMakeDuration(time.Minute)
+		j.Tasks = []models.TaskSpec{cltest.NewTask(t, "noop")}
+		require.NoError(t, store.CreateJob(&j))
+		run := cltest.NewJobRun(j)
+		run.CreatedAt = time.Now().Add(-2 * time.Minute)
+		require.NoError(t, store.CreateJobRun(&run))
+
+		require.NoError(t, runExecutor.Execute(run.ID))
+
+		run, err := store.FindJobRun(run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RunStatusErrored, run.GetStatus())
+		assert.Equal(t, models.RunStatusErrored, run.TaskRuns[0].Status)
+		assert.Contains(t, run.Result.ErrorMessage.String, "maxRunDuration of 1m0s")
+	})
+}
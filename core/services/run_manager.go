@@ -46,10 +46,12 @@ type RunManager interface {
 		runID *models.ID,
 		input models.BridgeRunResult) error
 	Cancel(runID *models.ID) (*models.JobRun, error)
+	Replay(runID *models.ID) (*models.JobRun, error)
 
 	ResumeAllInProgress() error
 	ResumeAllPendingNextBlock(currentBlockHeight *big.Int) error
 	ResumeAllPendingConnection() error
+	ResumeAllPendingSleep(now time.Time) error
 }
 
 // runManager implements RunManager
@@ -180,6 +182,24 @@ func (rm *runManager) CreateErrored(
 	return &run, rm.orm.CreateJobRun(&run)
 }
 
+// inactiveJobReason returns why the job cannot be run at now, or "" if it
+// can.
+func inactiveJobReason(job models.JobSpec, now time.Time) string {
+	switch {
+	case job.Archived():
+		return "archived"
+	case job.Stopped():
+		return "stopped"
+	case job.Paused():
+		return "paused"
+	case job.Quarantined():
+		return "quarantined after failing to start"
+	case job.Ended(now):
+		return "past its end time"
+	}
+	return ""
+}
+
 // Create immediately persists a JobRun and sends it to the RunQueue for
 // execution.
 func (rm *runManager) Create(
@@ -204,6 +224,24 @@ func (rm *runManager) Create(
 		}
 	}
 
+	if job.Stopped() {
+		return nil, RecurringScheduleJobError{
+			msg: fmt.Sprintf("Trying to run stopped job %s", job.ID),
+		}
+	}
+
+	if job.Paused() {
+		return nil, RecurringScheduleJobError{
+			msg: fmt.Sprintf("Trying to run paused job %s", job.ID),
+		}
+	}
+
+	if job.Quarantined() {
+		return nil, RecurringScheduleJobError{
+			msg: fmt.Sprintf("Trying to run job %s which failed to start", job.ID),
+		}
+	}
+
 	now := rm.clock.Now()
 	if !job.Started(now) {
 		return nil, RecurringScheduleJobError{
@@ -225,7 +263,9 @@ func (rm *runManager) Create(
 	runCost := runCost(&job, rm.config, adapters)
 	ValidateRun(run, runCost)
 
-	if err := rm.orm.CreateJobRun(run); err != nil {
+	if err := rm.orm.CreateJobRunWithinQuota(&job, run, now); orm.IsQuotaExceeded(err) {
+		return nil, err
+	} else if err != nil {
 		return nil, errors.Wrap(err, "CreateJobRun failed")
 	}
 	rm.statsPusher.PushNow()
@@ -292,6 +332,30 @@ func (rm *runManager) ResumeAllPendingConnection() error {
 		models.RunStatusPendingConnection, models.RunStatusPendingOutgoingConfirmations)
 }
 
+// ResumeAllPendingSleep wakes up all runs suspended by a Delay task whose
+// delay has passed at now.
+func (rm *runManager) ResumeAllPendingSleep(now time.Time) error {
+	return rm.orm.UnscopedJobRunsWithStatus(func(run *models.JobRun) {
+		currentTaskRun := run.NextTaskRun()
+		if currentTaskRun == nil {
+			err := rm.updateWithError(run, "Attempting to resume sleeping run with no remaining tasks %s", run.ID)
+			logger.ErrorIf(err, "failed when run manager updates with error")
+			return
+		}
+		if !adapters.DelayElapsed(currentTaskRun.Result.Data, now) {
+			return
+		}
+
+		logger.Debugw("Delay elapsed, resuming run", run.ForLogger()...)
+		run.SetStatus(models.RunStatusInProgress)
+
+		err := rm.saveAndResumeIfInProgress(run)
+		if err != nil {
+			logger.Errorw("Error saving run", run.ForLogger("error", err)...)
+		}
+	}, models.RunStatusPendingSleep)
+}
+
 // ResumePendingBridgeTask wakes up a task that required a response from a bridge adapter.
 func (rm *runManager) ResumePendingBridge(
 	runID *models.ID,
@@ -313,6 +377,12 @@ func (rm *runManager) ResumePendingBridge(
 		return rm.updateWithError(&run, "Attempting to resume pending run with no remaining tasks %s", run.ID)
 	}
 
+	limit := currentTaskRun.TaskSpec.ResultSizeLimit(rm.config.MaxTaskResultSize())
+	if data, truncated := models.TruncateResultData(input.Data, limit); truncated {
+		logger.Warnw("Truncated bridge result larger than the task's result size limit", run.ForLogger("task", currentTaskRun.ID.String(), "size", len(input.Data.Raw), "limit", limit)...)
+		input.Data = data
+	}
+
 	data, err := models.Merge(run.RunRequest.RequestParams, input.Data)
 	if err != nil {
 		return rm.updateWithError(&run, "Error while merging onto RequestParams for run %s", run.ID)
@@ -355,6 +425,33 @@ func (rm *runManager) Cancel(runID *models.ID) (*models.JobRun, error) {
 	return &run, rm.orm.SaveJobRun(&run)
 }
 
+// Replay resumes an errored run from the task that errored, reusing the
+// results of the tasks which completed before it. The runs of jobs which
+// could not be run now, such as archived, paused or quarantined jobs, are
+// not replayed.
+func (rm *runManager) Replay(runID *models.ID) (*models.JobRun, error) {
+	run, err := rm.orm.FindJobRun(runID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := rm.orm.Unscoped().FindJob(run.JobSpecID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find job spec")
+	}
+	if reason := inactiveJobReason(job, rm.clock.Now()); reason != "" {
+		return nil, RecurringScheduleJobError{
+			msg: fmt.Sprintf("Cannot replay run %s of job %s, which is %s", run.ID, job.ID, reason),
+		}
+	}
+
+	if err := run.Replay(); err != nil {
+		return nil, err
+	}
+	logger.Infow("Replaying run from errored task", run.ForLogger("task", run.NextTaskRun().TaskSpec.Type)...)
+	return &run, rm.saveAndResumeIfInProgress(&run)
+}
+
 func (rm *runManager) updateWithError(run *models.JobRun, msg string, args ...interface{}) error {
 	run.SetError(fmt.Errorf(msg, args...))
 	logger.Error(fmt.Sprintf(msg, args...))
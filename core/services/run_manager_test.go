@@ -227,6 +227,50 @@ func TestRunManager_ResumeAllPendingConnection(t *testing.T) {
 	})
 }
 
+func TestRunManager_ResumeAllPendingSleep(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	pusher := new(mocks.StatsPusher)
+	pusher.On("PushNow").Return(nil)
+
+	runQueue := new(mocks.RunQueue)
+	runQueue.On("Run", mock.Anything).Maybe().Return(nil)
+
+	runManager := services.NewRunManager(runQueue, store.Config, store.ORM, pusher, store.TxManager, store.Clock)
+
+	now := time.Now()
+	createDelayedRun := func(until time.Time) models.JobRun {
+		run := makeJobRunWithInitiator(t, store, cltest.NewJob())
+		run.SetStatus(models.RunStatusPendingSleep)
+
+		job, err := store.FindJob(run.JobSpecID)
+		require.NoError(t, err)
+		data := cltest.JSONFromString(t, `{"delayUntil": %q}`, until.UTC().Format(time.RFC3339Nano))
+		run.TaskRuns = []models.TaskRun{models.TaskRun{
+			ID:         models.NewID(),
+			TaskSpecID: job.Tasks[0].ID,
+			Status:     models.RunStatusPendingSleep,
+			Result:     models.RunResult{Data: data},
+		}}
+		require.NoError(t, store.CreateJobRun(&run))
+		return run
+	}
+
+	due := createDelayedRun(now.Add(-time.Second))
+	waiting := createDelayedRun(now.Add(time.Minute))
+
+	require.NoError(t, runManager.ResumeAllPendingSleep(now))
+
+	run, err := store.FindJobRun(due.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusInProgress, run.GetStatus())
+
+	run, err = store.FindJobRun(waiting.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusPendingSleep, run.GetStatus())
+}
+
 func TestRunManager_ResumeAllPendingConnection_NotEnoughConfirmations(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t,
@@ -20,6 +20,12 @@ var (
 		Name: "run_queue_queue_size",
 		Help: "The size of the run queue",
 	})
+	numberWorkerGroupBusy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "run_queue_worker_group_busy",
+		Help: "The number of runs of each worker group which are executing",
+	},
+		[]string{"group"},
+	)
 )
 
 //go:generate mockery --name RunQueue --output ../internal/mocks/ --case=underscore
@@ -33,19 +39,43 @@ type RunQueue interface {
 	WorkerCount() int
 }
 
+// WorkerGroupFinder looks up the worker group of a job.
+type WorkerGroupFinder interface {
+	FindJobWorkerGroup(jobID *models.ID) (*models.WorkerGroup, error)
+}
+
 type runQueue struct {
 	workersMutex  sync.RWMutex
 	workers       map[string]int
 	workersWg     sync.WaitGroup
 	stopRequested bool
+	stop          chan struct{}
+
+	poolsMutex  sync.Mutex
+	pools       map[string]*workerPool
+	groupFinder WorkerGroupFinder
 
 	runExecutor RunExecutor
 }
 
-// NewRunQueue initializes a RunQueue.
-func NewRunQueue(runExecutor RunExecutor) RunQueue {
+// workerPool holds a slot for each run of a worker group which may execute
+// at once.
+type workerPool struct {
+	name        string
+	concurrency int
+	slots       chan struct{}
+}
+
+// NewRunQueue initializes a RunQueue. Runs of jobs in a worker group, as
+// found by groupFinder, execute no more than the group's concurrency at a
+// time; with a nil groupFinder every run executes at once. The runs of every
+// group execute with runExecutor, and so share its database connections.
+func NewRunQueue(runExecutor RunExecutor, groupFinder WorkerGroupFinder) RunQueue {
 	return &runQueue{
 		workers:     make(map[string]int),
+		stop:        make(chan struct{}),
+		pools:       make(map[string]*workerPool),
+		groupFinder: groupFinder,
 		runExecutor: runExecutor,
 	}
 }
@@ -58,7 +88,10 @@ func (rq *runQueue) Start() error {
 // Stop closes all open worker channels.
 func (rq *runQueue) Stop() {
 	rq.workersMutex.Lock()
-	rq.stopRequested = true
+	if !rq.stopRequested {
+		rq.stopRequested = true
+		close(rq.stop)
+	}
 	rq.workersMutex.Unlock()
 	rq.workersWg.Wait()
 }
@@ -107,9 +140,16 @@ func (rq *runQueue) Run(run *models.JobRun) {
 		defer rq.workersWg.Done()
 
 		for {
+			release, ok := rq.acquire(run)
+			if !ok {
+				// Stopped while waiting for its worker group; the run is
+				// still in progress, so resumes when the node restarts
+				return
+			}
 			if err := rq.runExecutor.Execute(run.ID); err != nil {
 				logger.Errorw(fmt.Sprint("Error executing run ", runID), "error", err)
 			}
+			release()
 
 			if rq.decrementQueue(runID) {
 				return
@@ -118,6 +158,56 @@ func (rq *runQueue) Run(run *models.JobRun) {
 	}()
 }
 
+// acquire waits until the run may execute within the concurrency of its
+// job's worker group, and returns the function which frees its slot. It
+// returns false if the queue is stopped while waiting.
+func (rq *runQueue) acquire(run *models.JobRun) (func(), bool) {
+	pool := rq.workerPool(run.JobSpecID)
+	if pool == nil {
+		return func() {}, true
+	}
+	select {
+	case pool.slots <- struct{}{}:
+		numberWorkerGroupBusy.WithLabelValues(pool.name).Inc()
+		return func() {
+			<-pool.slots
+			numberWorkerGroupBusy.WithLabelValues(pool.name).Dec()
+		}, true
+	case <-rq.stop:
+		return nil, false
+	}
+}
+
+// workerPool returns the pool of the job's worker group, or nil if the job
+// is in no group. A group's pool is replaced when its concurrency changes;
+// runs already holding slots of the old pool finish in it, so the group may
+// briefly execute more runs than its new concurrency.
+func (rq *runQueue) workerPool(jobID *models.ID) *workerPool {
+	if rq.groupFinder == nil || jobID == nil {
+		return nil
+	}
+	group, err := rq.groupFinder.FindJobWorkerGroup(jobID)
+	if err != nil {
+		logger.Errorw("Error finding worker group, executing run in the shared pool", "jobID", jobID.String(), "error", err)
+		return nil
+	} else if group == nil {
+		return nil
+	}
+
+	rq.poolsMutex.Lock()
+	defer rq.poolsMutex.Unlock()
+	pool, ok := rq.pools[group.Name]
+	if !ok || pool.concurrency != group.Concurrency {
+		pool = &workerPool{
+			name:        group.Name,
+			concurrency: group.Concurrency,
+			slots:       make(chan struct{}, group.Concurrency),
+		}
+		rq.pools[group.Name] = pool
+	}
+	return pool
+}
+
 // WorkerCount returns the number of workers currently processing a job run
 func (rq *runQueue) WorkerCount() int {
 	rq.workersMutex.RLock()
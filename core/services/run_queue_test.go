@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"sync/atomic"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
@@ -9,6 +10,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models"
 
 	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -17,7 +19,7 @@ func TestRunQueue(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	runExecutor := new(mocks.RunExecutor)
-	runQueue := services.NewRunQueue(runExecutor)
+	runQueue := services.NewRunQueue(runExecutor, nil)
 
 	executeJobChannel := make(chan struct{})
 
@@ -52,7 +54,7 @@ func TestRunQueue_OneWorkerPerRun(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	runExecutor := new(mocks.RunExecutor)
-	runQueue := services.NewRunQueue(runExecutor)
+	runQueue := services.NewRunQueue(runExecutor, nil)
 
 	executeJobChannel := make(chan struct{})
 
@@ -89,7 +91,7 @@ func TestRunQueue_OneWorkerForSameRunTriggeredMultipleTimes(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	runExecutor := new(mocks.RunExecutor)
-	runQueue := services.NewRunQueue(runExecutor)
+	runQueue := services.NewRunQueue(runExecutor, nil)
 
 	executeJobChannel := make(chan struct{})
 
@@ -125,3 +127,71 @@ func TestRunQueue_OneWorkerForSameRunTriggeredMultipleTimes(t *testing.T) {
 		return runQueue.WorkerCount()
 	}).Should(gomega.Equal(0))
 }
+
+type workerGroupFinderStub map[string]*models.WorkerGroup
+
+func (f workerGroupFinderStub) FindJobWorkerGroup(jobID *models.ID) (*models.WorkerGroup, error) {
+	return f[jobID.String()], nil
+}
+
+func TestRunQueue_WorkerGroupConcurrency(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	groupedJobID, sharedJobID := models.NewID(), models.NewID()
+	finder := workerGroupFinderStub{
+		groupedJobID.String(): {Name: "webhooks", Concurrency: 1},
+	}
+	runExecutor := new(mocks.RunExecutor)
+	runQueue := services.NewRunQueue(runExecutor, finder)
+
+	runQueue.Start()
+	defer runQueue.Stop()
+
+	var busy, maxBusy int32
+	groupedRuns := map[string]bool{}
+	sharedRunStarted := make(chan struct{})
+	release := make(chan struct{})
+	runExecutor.On("Execute", mock.Anything).
+		Return(nil, nil).
+		Run(func(args mock.Arguments) {
+			if !groupedRuns[args.Get(0).(*models.ID).String()] {
+				close(sharedRunStarted)
+				return
+			}
+			n := atomic.AddInt32(&busy, 1)
+			for {
+				max := atomic.LoadInt32(&maxBusy)
+				if n <= max || atomic.CompareAndSwapInt32(&maxBusy, max, n) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt32(&busy, -1)
+		})
+
+	runs := []*models.JobRun{}
+	for i := 0; i < 3; i++ {
+		run := &models.JobRun{ID: models.NewID(), JobSpecID: groupedJobID}
+		groupedRuns[run.ID.String()] = true
+		runs = append(runs, run)
+	}
+	runs = append(runs, &models.JobRun{ID: models.NewID(), JobSpecID: sharedJobID})
+	for _, run := range runs {
+		runQueue.Run(run)
+	}
+
+	// Runs outside the group are not held up by it
+	cltest.CallbackOrTimeout(t, "Execute shared run", func() {
+		<-sharedRunStarted
+	})
+	g.Eventually(func() int32 { return atomic.LoadInt32(&busy) }).Should(gomega.Equal(int32(1)))
+	g.Consistently(func() int32 { return atomic.LoadInt32(&busy) }).Should(gomega.Equal(int32(1)))
+
+	close(release)
+	g.Eventually(func() int {
+		return runQueue.WorkerCount()
+	}).Should(gomega.Equal(0))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxBusy))
+	runExecutor.AssertNumberOfCalls(t, "Execute", 4)
+}
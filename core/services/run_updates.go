@@ -0,0 +1,358 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	runUpdateCreateCallbackName = "runupdates:run_after_create"
+	runUpdateUpdateCallbackName = "runupdates:run_after_update"
+
+	// runUpdateBufferSize is how many updates a subscriber may fall behind by
+	// before it is unsubscribed
+	runUpdateBufferSize = 100
+)
+
+// runUpdateCallbacksMutex guards the registration of gorm callbacks, which
+// gorm does not synchronise with the queries that run them
+var runUpdateCallbacksMutex = new(sync.Mutex)
+
+// RunUpdateBroadcaster relays the status changes of a job's runs, and of
+// their task runs, to subscribers once each save of a run commits. Only the
+// runs of jobs with subscribers are followed; the first update a subscriber
+// sees for a run which was already in progress carries the status of each of
+// its task runs.
+//
+// Subscribers to events are only told of runs being created, completing and
+// erroring, but of those of every job unless they name the jobs they want.
+type RunUpdateBroadcaster interface {
+	Start() error
+	Stop() error
+	Subscribe(jobID *models.ID) RunUpdateSubscription
+	SubscribeEvents(jobIDs []*models.ID) RunEventSubscription
+}
+
+// RunUpdateSubscription receives the run updates of a job. The channel is
+// closed when the subscription ends, either by Unsubscribe, by the
+// broadcaster stopping, or by the subscriber falling too far behind.
+type RunUpdateSubscription interface {
+	Updates() <-chan models.RunUpdate
+	Unsubscribe()
+}
+
+// RunEventSubscription receives run events. The channel is closed when the
+// subscription ends, as for a RunUpdateSubscription.
+type RunEventSubscription interface {
+	Events() <-chan models.RunEvent
+	Unsubscribe()
+}
+
+type runUpdateBroadcaster struct {
+	orm    *orm.ORM
+	mu     sync.Mutex
+	jobs   map[string]*jobRunUpdates
+	events map[*runEventSubscription]struct{}
+}
+
+// jobRunUpdates holds the subscribers of a job, and the last status seen of
+// each of its unfinished runs and their task runs.
+type jobRunUpdates struct {
+	subscribers map[*runUpdateSubscription]struct{}
+	runs        map[string]map[string]models.RunStatus
+}
+
+// NewRunUpdateBroadcaster returns a RunUpdateBroadcaster for the runs saved
+// through orm.
+func NewRunUpdateBroadcaster(orm *orm.ORM) RunUpdateBroadcaster {
+	return &runUpdateBroadcaster{
+		orm:    orm,
+		jobs:   make(map[string]*jobRunUpdates),
+		events: make(map[*runEventSubscription]struct{}),
+	}
+}
+
+// Start follows runs as they are saved.
+func (b *runUpdateBroadcaster) Start() error {
+	runUpdateCallbacksMutex.Lock()
+	defer runUpdateCallbacksMutex.Unlock()
+	return b.orm.RawDB(func(db *gorm.DB) error {
+		db.Callback().Create().After("gorm:commit_or_rollback_transaction").Register(runUpdateCreateCallbackName, b.afterCreate)
+		db.Callback().Update().After("gorm:commit_or_rollback_transaction").Register(runUpdateUpdateCallbackName, b.afterUpdate)
+		return nil
+	})
+}
+
+// Stop ends every subscription.
+func (b *runUpdateBroadcaster) Stop() error {
+	runUpdateCallbacksMutex.Lock()
+	err := b.orm.RawDB(func(db *gorm.DB) error {
+		db.Callback().Create().Remove(runUpdateCreateCallbackName)
+		db.Callback().Update().Remove(runUpdateUpdateCallbackName)
+		return nil
+	})
+	runUpdateCallbacksMutex.Unlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for jobID, job := range b.jobs {
+		for sub := range job.subscribers {
+			close(sub.chUpdates)
+		}
+		delete(b.jobs, jobID)
+	}
+	for sub := range b.events {
+		close(sub.chEvents)
+		delete(b.events, sub)
+	}
+	return err
+}
+
+// Subscribe returns a subscription to the updates of the job's runs.
+func (b *runUpdateBroadcaster) Subscribe(jobID *models.ID) RunUpdateSubscription {
+	sub := &runUpdateSubscription{
+		broadcaster: b,
+		jobID:       jobID.String(),
+		chUpdates:   make(chan models.RunUpdate, runUpdateBufferSize),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.followLocked(sub.jobID).subscribers[sub] = struct{}{}
+	return sub
+}
+
+// SubscribeEvents returns a subscription to the events of the given jobs'
+// runs, or of every run if jobIDs is empty.
+func (b *runUpdateBroadcaster) SubscribeEvents(jobIDs []*models.ID) RunEventSubscription {
+	sub := &runEventSubscription{
+		broadcaster: b,
+		jobIDs:      make(map[string]struct{}),
+		chEvents:    make(chan models.RunEvent, runUpdateBufferSize),
+	}
+	for _, id := range jobIDs {
+		sub.jobIDs[id.String()] = struct{}{}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events[sub] = struct{}{}
+	return sub
+}
+
+// followLocked returns the runs followed of the job, starting to follow them
+// if they were not.
+func (b *runUpdateBroadcaster) followLocked(jobID string) *jobRunUpdates {
+	job, ok := b.jobs[jobID]
+	if !ok {
+		job = &jobRunUpdates{
+			subscribers: make(map[*runUpdateSubscription]struct{}),
+			runs:        make(map[string]map[string]models.RunStatus),
+		}
+		b.jobs[jobID] = job
+	}
+	return job
+}
+
+func (b *runUpdateBroadcaster) unsubscribe(sub *runUpdateSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeLocked(sub)
+}
+
+// removeLocked ends a subscription, forgetting the job's runs once it has
+// no subscribers left.
+func (b *runUpdateBroadcaster) removeLocked(sub *runUpdateSubscription) {
+	job, ok := b.jobs[sub.jobID]
+	if !ok {
+		return
+	}
+	if _, ok := job.subscribers[sub]; !ok {
+		return
+	}
+	delete(job.subscribers, sub)
+	close(sub.chUpdates)
+	if len(job.subscribers) == 0 && len(b.events) == 0 {
+		delete(b.jobs, sub.jobID)
+	}
+}
+
+func (b *runUpdateBroadcaster) unsubscribeEvents(sub *runEventSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeEventsLocked(sub)
+}
+
+// removeEventsLocked ends an event subscription, forgetting the runs of jobs
+// without subscribers once no event subscriptions are left.
+func (b *runUpdateBroadcaster) removeEventsLocked(sub *runEventSubscription) {
+	if _, ok := b.events[sub]; !ok {
+		return
+	}
+	delete(b.events, sub)
+	close(sub.chEvents)
+	if len(b.events) > 0 {
+		return
+	}
+	for jobID, job := range b.jobs {
+		if len(job.subscribers) == 0 {
+			delete(b.jobs, jobID)
+		}
+	}
+}
+
+func (b *runUpdateBroadcaster) afterCreate(scope *gorm.Scope) {
+	b.afterSave(scope, true)
+}
+
+func (b *runUpdateBroadcaster) afterUpdate(scope *gorm.Scope) {
+	b.afterSave(scope, false)
+}
+
+func (b *runUpdateBroadcaster) afterSave(scope *gorm.Scope, created bool) {
+	if scope.HasError() || scope.TableName() != "job_runs" {
+		return
+	}
+	// An optimistic update which lost to a concurrent save changed nothing
+	if scope.DB().RowsAffected == 0 {
+		return
+	}
+	run, ok := scope.Value.(*models.JobRun)
+	if !ok || run.JobSpecID == nil {
+		return
+	}
+	// The run is copied as it is saved, as the caller may change it before
+	// the transaction saving it commits; a rolled back save is not published
+	saved := *run
+	saved.TaskRuns = append([]models.TaskRun(nil), run.TaskRuns...)
+	b.orm.AfterCommit(scope, func() {
+		b.publish(saved, created)
+	})
+}
+
+// publish sends the job's subscribers each status of run which has changed
+// since the run was last saved, task runs first, and the event subscribers
+// the run's creation, completion or failure.
+func (b *runUpdateBroadcaster) publish(run models.JobRun, created bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	job, ok := b.jobs[run.JobSpecID.String()]
+	if !ok {
+		if len(b.events) == 0 {
+			return
+		}
+		job = b.followLocked(run.JobSpecID.String())
+	}
+
+	runID := run.ID.String()
+	seen, ok := job.runs[runID]
+	if !ok {
+		seen = make(map[string]models.RunStatus)
+		job.runs[runID] = seen
+	}
+	var updates []models.RunUpdate
+	for _, tr := range run.TaskRuns {
+		if status, ok := seen[tr.ID.String()]; !ok || status != tr.Status {
+			seen[tr.ID.String()] = tr.Status
+			updates = append(updates, models.NewTaskRunUpdate(run, tr))
+		}
+	}
+	statusChanged := false
+	if status, ok := seen[runID]; !ok || status != run.Status {
+		seen[runID] = run.Status
+		statusChanged = true
+		updates = append(updates, models.NewRunUpdate(run))
+	}
+	if run.Status.Finished() {
+		delete(job.runs, runID)
+	}
+
+	for sub := range job.subscribers {
+		for _, update := range updates {
+			select {
+			case sub.chUpdates <- update:
+			default:
+				logger.Warnw("Run update subscriber fell behind and was unsubscribed", "job", run.JobSpecID.String())
+				b.removeLocked(sub)
+			}
+			if _, ok := job.subscribers[sub]; !ok {
+				break
+			}
+		}
+	}
+
+	events := runEventsFor(run, created, statusChanged)
+	for sub := range b.events {
+		if !sub.wants(run.JobSpecID) {
+			continue
+		}
+		for _, event := range events {
+			select {
+			case sub.chEvents <- event:
+			default:
+				logger.Warnw("Run event subscriber fell behind and was unsubscribed", "job", run.JobSpecID.String())
+				b.removeEventsLocked(sub)
+			}
+			if _, ok := b.events[sub]; !ok {
+				break
+			}
+		}
+	}
+}
+
+// runEventsFor returns the events of a run having been saved: its creation,
+// and its completion or failure.
+func runEventsFor(run models.JobRun, created, statusChanged bool) []models.RunEvent {
+	var events []models.RunEvent
+	if created {
+		events = append(events, models.RunEvent{Event: models.RunEventCreated, RunUpdate: models.NewRunUpdate(run)})
+	}
+	if statusChanged && run.Status.Completed() {
+		events = append(events, models.RunEvent{Event: models.RunEventCompleted, RunUpdate: models.NewRunUpdate(run)})
+	} else if statusChanged && run.Status.Errored() {
+		events = append(events, models.RunEvent{Event: models.RunEventErrored, RunUpdate: models.NewRunUpdate(run)})
+	}
+	return events
+}
+
+type runUpdateSubscription struct {
+	broadcaster *runUpdateBroadcaster
+	jobID       string
+	chUpdates   chan models.RunUpdate
+}
+
+func (s *runUpdateSubscription) Updates() <-chan models.RunUpdate {
+	return s.chUpdates
+}
+
+func (s *runUpdateSubscription) Unsubscribe() {
+	s.broadcaster.unsubscribe(s)
+}
+
+type runEventSubscription struct {
+	broadcaster *runUpdateBroadcaster
+	jobIDs      map[string]struct{}
+	chEvents    chan models.RunEvent
+}
+
+// wants returns true if the subscriber follows the runs of the job.
+func (s *runEventSubscription) wants(jobID *models.ID) bool {
+	if len(s.jobIDs) == 0 {
+		return true
+	}
+	_, ok := s.jobIDs[jobID.String()]
+	return ok
+}
+
+func (s *runEventSubscription) Events() <-chan models.RunEvent {
+	return s.chEvents
+}
+
+func (s *runEventSubscription) Unsubscribe() {
+	s.broadcaster.unsubscribeEvents(s)
+}
@@ -0,0 +1,208 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receiveRunUpdates(t *testing.T, sub services.RunUpdateSubscription, n int) []models.RunUpdate {
+	t.Helper()
+	var updates []models.RunUpdate
+	for i := 0; i < n; i++ {
+		select {
+		case update := <-sub.Updates():
+			updates = append(updates, update)
+		default:
+			t.Fatalf("expected %d run updates, got %d", n, len(updates))
+		}
+	}
+	select {
+	case update := <-sub.Updates():
+		t.Fatalf("unexpected run update %v", update)
+	default:
+	}
+	return updates
+}
+
+func TestRunUpdateBroadcaster_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	broadcaster := services.NewRunUpdateBroadcaster(store.ORM)
+	require.NoError(t, broadcaster.Start())
+	defer func() { assert.NoError(t, broadcaster.Stop()) }()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	otherJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&otherJob))
+
+	sub := broadcaster.Subscribe(job.ID)
+	defer sub.Unsubscribe()
+
+	otherRun := cltest.NewJobRun(otherJob)
+	require.NoError(t, store.CreateJobRun(&otherRun))
+	receiveRunUpdates(t, sub, 0)
+
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+	updates := receiveRunUpdates(t, sub, 2)
+	assert.Equal(t, run.TaskRuns[0].ID, updates[0].TaskRunID)
+	assert.Equal(t, job.Tasks[0].Type, updates[0].TaskType)
+	assert.Nil(t, updates[1].TaskRunID)
+	assert.Equal(t, run.ID, updates[1].JobRunID)
+	assert.Equal(t, models.RunStatusInProgress, updates[1].Status)
+
+	// Saving without a change in status sends nothing
+	require.NoError(t, store.SaveJobRun(&run))
+	receiveRunUpdates(t, sub, 0)
+
+	run.TaskRuns[0].ApplyOutput(models.NewRunOutputCompleteWithResult("ok"))
+	run.SetStatus(models.RunStatusCompleted)
+	require.NoError(t, store.SaveJobRun(&run))
+	updates = receiveRunUpdates(t, sub, 2)
+	assert.Equal(t, models.RunStatusCompleted, updates[0].Status)
+	assert.Equal(t, run.TaskRuns[0].ID, updates[0].TaskRunID)
+	assert.Equal(t, models.RunStatusCompleted, updates[1].Status)
+	assert.Nil(t, updates[1].TaskRunID)
+}
+
+func TestRunUpdateBroadcaster_PublishesOnCommit(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	broadcaster := services.NewRunUpdateBroadcaster(store.ORM)
+	require.NoError(t, broadcaster.Start())
+	defer func() { assert.NoError(t, broadcaster.Stop()) }()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	sub := broadcaster.Subscribe(job.ID)
+	defer sub.Unsubscribe()
+
+	rolledBack := cltest.NewJobRun(job)
+	err := store.ORM.Transaction(func(dbtx *gorm.DB) error {
+		require.NoError(t, dbtx.Create(&rolledBack).Error)
+		return errors.New("rolled back")
+	})
+	require.Error(t, err)
+	receiveRunUpdates(t, sub, 0)
+
+	run := cltest.NewJobRun(job)
+	err = store.ORM.Transaction(func(dbtx *gorm.DB) error {
+		require.NoError(t, dbtx.Create(&run).Error)
+		receiveRunUpdates(t, sub, 0)
+		return nil
+	})
+	require.NoError(t, err)
+	updates := receiveRunUpdates(t, sub, 2)
+	assert.Equal(t, run.ID, updates[1].JobRunID)
+}
+
+func TestRunUpdateBroadcaster_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	broadcaster := services.NewRunUpdateBroadcaster(store.ORM)
+	require.NoError(t, broadcaster.Start())
+	defer func() { assert.NoError(t, broadcaster.Stop()) }()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+
+	sub := broadcaster.Subscribe(job.ID)
+	sub.Unsubscribe()
+	_, open := <-sub.Updates()
+	assert.False(t, open)
+
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+}
+
+func TestRunUpdateBroadcaster_SubscribeEvents(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	broadcaster := services.NewRunUpdateBroadcaster(store.ORM)
+	require.NoError(t, broadcaster.Start())
+	defer func() { assert.NoError(t, broadcaster.Stop()) }()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	otherJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&otherJob))
+
+	all := broadcaster.SubscribeEvents(nil)
+	defer all.Unsubscribe()
+	filtered := broadcaster.SubscribeEvents([]*models.ID{job.ID})
+	defer filtered.Unsubscribe()
+
+	receive := func(sub services.RunEventSubscription) *models.RunEvent {
+		select {
+		case event := <-sub.Events():
+			return &event
+		default:
+			return nil
+		}
+	}
+
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+	otherRun := cltest.NewJobRun(otherJob)
+	require.NoError(t, store.CreateJobRun(&otherRun))
+
+	event := receive(all)
+	require.NotNil(t, event)
+	assert.Equal(t, models.RunEventCreated, event.Event)
+	assert.Equal(t, run.ID, event.JobRunID)
+	event = receive(all)
+	require.NotNil(t, event)
+	assert.Equal(t, models.RunEventCreated, event.Event)
+	assert.Equal(t, otherRun.ID, event.JobRunID)
+
+	event = receive(filtered)
+	require.NotNil(t, event)
+	assert.Equal(t, run.ID, event.JobRunID)
+	assert.Nil(t, receive(filtered))
+
+	// Progress short of finishing is not an event
+	run.TaskRuns[0].ApplyOutput(models.NewRunOutputCompleteWithResult("ok"))
+	require.NoError(t, store.SaveJobRun(&run))
+	assert.Nil(t, receive(all))
+
+	run.SetStatus(models.RunStatusCompleted)
+	require.NoError(t, store.SaveJobRun(&run))
+	event = receive(filtered)
+	require.NotNil(t, event)
+	assert.Equal(t, models.RunEventCompleted, event.Event)
+	assert.Equal(t, models.RunStatusCompleted, event.Status)
+	assert.NotNil(t, receive(all))
+
+	otherRun.SetError(errors.New("oops"))
+	require.NoError(t, store.SaveJobRun(&otherRun))
+	event = receive(all)
+	require.NotNil(t, event)
+	assert.Equal(t, models.RunEventErrored, event.Event)
+	assert.Equal(t, "oops", event.Error)
+	assert.Nil(t, receive(filtered))
+
+	filtered.Unsubscribe()
+	_, open := <-filtered.Events()
+	assert.False(t, open)
+}
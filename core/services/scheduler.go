@@ -20,6 +20,7 @@ import (
 type Scheduler struct {
 	Recurring    *Recurring
 	OneTime      *OneTime
+	Delayed      *Delayed
 	store        *store.Store
 	runManager   RunManager
 	startedMutex sync.RWMutex
@@ -29,13 +30,19 @@ type Scheduler struct {
 // NewScheduler initializes the Scheduler instances with both Recurring
 // and OneTime fields since jobs can contain tasks which utilize both.
 func NewScheduler(store *store.Store, runManager RunManager) *Scheduler {
+	recurring := NewRecurring(runManager)
+	recurring.Runs = store
 	return &Scheduler{
-		Recurring: NewRecurring(runManager),
+		Recurring: recurring,
 		OneTime: &OneTime{
 			Store:      store,
 			Clock:      store.Clock,
 			RunManager: runManager,
 		},
+		Delayed: &Delayed{
+			Clock:      store.Clock,
+			RunManager: runManager,
+		},
 		store:      store,
 		runManager: runManager,
 	}
@@ -57,6 +64,9 @@ func (s *Scheduler) Start() error {
 	if err := s.Recurring.Start(); err != nil {
 		return err
 	}
+	if err := s.Delayed.Start(); err != nil {
+		return err
+	}
 	s.started = true
 
 	return s.store.Jobs(func(j *models.JobSpec) bool {
@@ -73,6 +83,7 @@ func (s *Scheduler) Stop() {
 	if s.started {
 		s.Recurring.Stop()
 		s.OneTime.Stop()
+		s.Delayed.Stop()
 		s.started = false
 	}
 }
@@ -93,19 +104,37 @@ func (s *Scheduler) AddJob(job models.JobSpec) {
 	s.addJob(&job)
 }
 
+// RemoveJob stops scheduling runs for the job with the given ID.
+func (s *Scheduler) RemoveJob(ID *models.ID) {
+	s.startedMutex.RLock()
+	defer s.startedMutex.RUnlock()
+	if !s.started {
+		return
+	}
+	s.Recurring.RemoveJob(ID)
+	s.OneTime.RemoveJob(ID)
+}
+
 // Recurring is used for runs that need to execute on a schedule,
 // and is configured with cron.
 // Instances of Recurring must be initialized using NewRecurring().
 type Recurring struct {
-	Cron       Cron
-	Clock      utils.Nower
+	Cron  Cron
+	Clock utils.Nower
+	// Runs, when set, is used to skip a scheduled run while the run previously
+	// created by the same initiator is still unfinished.
+	Runs       JobRunFinder
 	runManager RunManager
+
+	entriesMutex sync.Mutex
+	entries      map[string][]cron.EntryID
 }
 
 // NewRecurring create a new instance of Recurring, ready to use.
 func NewRecurring(runManager RunManager) *Recurring {
 	return &Recurring{
 		runManager: runManager,
+		entries:    make(map[string][]cron.EntryID),
 	}
 }
 
@@ -125,24 +154,68 @@ func (r *Recurring) Stop() {
 }
 
 // AddJob looks for "cron" initiators, adds them to cron's schedule
-// for execution when specified.
+// for execution when specified. Initiators with SkipOverlapping set do not
+// create a run while the run they created last is unfinished.
 func (r *Recurring) AddJob(job models.JobSpec) {
 	for _, initr := range job.InitiatorsFor(models.InitiatorCron) {
-		_, err := r.Cron.AddFunc(string(initr.Schedule), func() {
+		initr := initr
+		var (
+			lastRunMutex sync.Mutex
+			lastRunID    *models.ID
+		)
+		id, err := r.Cron.AddFunc(string(initr.Schedule), func() {
 			now := time.Now()
 			if !job.Started(now) || job.Ended(now) {
 				return
 			}
 
-			_, err := r.runManager.Create(job.ID, &initr, nil, &models.RunRequest{})
+			lastRunMutex.Lock()
+			defer lastRunMutex.Unlock()
+			if initr.SkipOverlapping && r.unfinished(lastRunID) {
+				logger.Infow("Skipping scheduled run as the previous run has not finished",
+					"job", job.ID.String(), "run", lastRunID.String())
+				return
+			}
+
+			run, err := r.runManager.Create(job.ID, &initr, nil, &models.RunRequest{})
 			if err != nil && !ExpectedRecurringScheduleJobError(err) {
 				logger.Errorw(err.Error())
 			}
+			if run != nil {
+				lastRunID = run.ID
+			}
 		})
 		if err != nil {
 			logger.Error(err)
+			continue
 		}
+		r.entriesMutex.Lock()
+		r.entries[job.ID.String()] = append(r.entries[job.ID.String()], id)
+		r.entriesMutex.Unlock()
+	}
+}
+
+// unfinished returns true if the run with the given ID exists and has not yet
+// completed, errored or been cancelled.
+func (r *Recurring) unfinished(runID *models.ID) bool {
+	if r.Runs == nil || runID == nil {
+		return false
 	}
+	run, err := r.Runs.FindJobRun(runID)
+	if err != nil {
+		return false
+	}
+	return !run.GetStatus().Finished()
+}
+
+// RemoveJob removes the schedules of the job's "cron" initiators.
+func (r *Recurring) RemoveJob(ID *models.ID) {
+	r.entriesMutex.Lock()
+	defer r.entriesMutex.Unlock()
+	for _, id := range r.entries[ID.String()] {
+		r.Cron.Remove(id)
+	}
+	delete(r.entries, ID.String())
 }
 
 // OneTime represents runs that are to be executed only once.
@@ -151,6 +224,9 @@ type OneTime struct {
 	Clock      utils.Afterer
 	RunManager RunManager
 	done       chan struct{}
+
+	removedMutex sync.Mutex
+	removed      map[string]chan struct{}
 }
 
 // Start allocates a channel for the "done" field with an empty struct.
@@ -161,13 +237,39 @@ func (ot *OneTime) Start() error {
 
 // AddJob runs the job at the time specified for the "runat" initiator.
 func (ot *OneTime) AddJob(job models.JobSpec) {
-	for _, initiator := range job.InitiatorsFor(models.InitiatorRunAt) {
+	initiators := job.InitiatorsFor(models.InitiatorRunAt)
+	if len(initiators) == 0 {
+		return
+	}
+
+	ot.removedMutex.Lock()
+	if ot.removed == nil {
+		ot.removed = make(map[string]chan struct{})
+	}
+	removed, ok := ot.removed[job.ID.String()]
+	if !ok {
+		removed = make(chan struct{})
+		ot.removed[job.ID.String()] = removed
+	}
+	ot.removedMutex.Unlock()
+
+	for _, initiator := range initiators {
 		if !initiator.Time.Valid {
 			logger.Errorf("RunJobAt: JobSpec %s must have initiator with valid run at time: %v", job.ID, initiator)
 			continue
 		}
 
-		go ot.RunJobAt(initiator, job)
+		go ot.runJobAt(initiator, job, removed)
+	}
+}
+
+// RemoveJob cancels the runs scheduled for the job's "runat" initiators.
+func (ot *OneTime) RemoveJob(ID *models.ID) {
+	ot.removedMutex.Lock()
+	defer ot.removedMutex.Unlock()
+	if removed, ok := ot.removed[ID.String()]; ok {
+		close(removed)
+		delete(ot.removed, ID.String())
 	}
 }
 
@@ -179,8 +281,13 @@ func (ot *OneTime) Stop() {
 // RunJobAt wait until the Stop() function has been called on the run
 // or the specified time for the run is after the present time.
 func (ot *OneTime) RunJobAt(initiator models.Initiator, job models.JobSpec) {
+	ot.runJobAt(initiator, job, nil)
+}
+
+func (ot *OneTime) runJobAt(initiator models.Initiator, job models.JobSpec, removed chan struct{}) {
 	select {
 	case <-ot.done:
+	case <-removed:
 	case <-ot.Clock.After(utils.DurationFromNow(initiator.Time.Time)):
 		now := time.Now()
 		if !job.Started(now) || job.Ended(now) {
@@ -208,8 +315,56 @@ func ExpectedRecurringScheduleJobError(err error) bool {
 	}
 }
 
+// JobRunFinder finds job runs by their ID.
+type JobRunFinder interface {
+	FindJobRun(*models.ID) (models.JobRun, error)
+}
+
 type Cron interface {
 	Start()
 	Stop() context.Context
 	AddFunc(string, func()) (cron.EntryID, error)
+	Remove(cron.EntryID)
+}
+
+// delayResumeInterval is how often Delayed looks for runs whose delay has
+// passed.
+const delayResumeInterval = time.Second
+
+// Delayed resumes the runs suspended by a Delay task once their delay has
+// passed.
+type Delayed struct {
+	Clock      utils.AfterNower
+	RunManager RunManager
+	done       chan struct{}
+	stopped    chan struct{}
+}
+
+// Start begins periodically resuming delayed runs.
+func (d *Delayed) Start() error {
+	d.done = make(chan struct{})
+	d.stopped = make(chan struct{})
+	go d.run()
+	return nil
+}
+
+// Stop stops resuming delayed runs and waits for a resumption in progress to
+// finish.
+func (d *Delayed) Stop() {
+	close(d.done)
+	<-d.stopped
+}
+
+func (d *Delayed) run() {
+	defer close(d.stopped)
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-d.Clock.After(delayResumeInterval):
+			if err := d.RunManager.ResumeAllPendingSleep(d.Clock.Now()); err != nil {
+				logger.Errorw("Error resuming delayed runs", "error", err)
+			}
+		}
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +35,7 @@ func TestScheduler_Start_LoadingRecurringJobs(t *testing.T) {
 		Run(func(mock.Arguments) {
 			executeJobChannel <- struct{}{}
 		})
+	runManager.On("ResumeAllPendingSleep", mock.Anything).Return(nil).Maybe()
 
 	sched := services.NewScheduler(store, runManager)
 	require.NoError(t, sched.Start())
@@ -82,6 +84,26 @@ func TestRecurring_AddJob(t *testing.T) {
 	runManager.AssertExpectations(t)
 }
 
+func TestRecurring_RemoveJob(t *testing.T) {
+	runManager := new(mocks.RunManager)
+
+	r := services.NewRecurring(runManager)
+	cron := cltest.NewMockCron()
+	r.Cron = cron
+
+	removed := cltest.NewJobWithSchedule("* * * * *")
+	kept := cltest.NewJobWithSchedule("*/5 * * * *")
+	r.AddJob(removed)
+	r.AddJob(kept)
+	require.Len(t, cron.Entries, 2)
+
+	r.RemoveJob(removed.ID)
+	require.Len(t, cron.Entries, 1)
+	assert.Equal(t, "*/5 * * * *", cron.Entries[0].Schedule)
+
+	r.Stop()
+}
+
 func TestRecurring_AddJob_PastEnd(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -132,6 +154,41 @@ func TestRecurring_AddJob_BeforeStart(t *testing.T) {
 	runManager.AssertExpectations(t)
 }
 
+func TestRecurring_AddJob_SkipsWhilePreviousRunUnfinished(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	j := cltest.NewJobWithSchedule("* * * * *")
+	j.Initiators[0].SkipOverlapping = true
+	require.NoError(t, store.CreateJob(&j))
+	run := cltest.NewJobRun(j)
+	run.SetStatus(models.RunStatusInProgress)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	runManager := new(mocks.RunManager)
+	runManager.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&run, nil).
+		Twice()
+
+	r := services.NewRecurring(runManager)
+	r.Runs = store
+	cron := cltest.NewMockCron()
+	r.Cron = cron
+
+	r.AddJob(j)
+	cron.RunEntries()
+	cron.RunEntries()
+	runManager.AssertNumberOfCalls(t, "Create", 1)
+
+	run.SetStatus(models.RunStatusCompleted)
+	require.NoError(t, store.SaveJobRun(&run))
+	cron.RunEntries()
+
+	r.Stop()
+
+	runManager.AssertExpectations(t)
+}
+
 func TestOneTime_AddJob(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -244,3 +301,32 @@ func TestExpectedRecurringScheduleJobError(t *testing.T) {
 	assert.True(t, services.ExpectedRecurringScheduleJobError(services.RecurringScheduleJobError{}))
 	assert.False(t, services.ExpectedRecurringScheduleJobError(errors.New("recurring scheduler job error, but wrong type")))
 }
+
+func TestRecurring_AddJob_OverlapsUnlessSkipOverlapping(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	j := cltest.NewJobWithSchedule("* * * * *")
+	require.NoError(t, store.CreateJob(&j))
+	run := cltest.NewJobRun(j)
+	run.SetStatus(models.RunStatusInProgress)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	runManager := new(mocks.RunManager)
+	runManager.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&run, nil).
+		Twice()
+
+	r := services.NewRecurring(runManager)
+	r.Runs = store
+	cron := cltest.NewMockCron()
+	r.Cron = cron
+
+	r.AddJob(j)
+	cron.RunEntries()
+	cron.RunEntries()
+
+	r.Stop()
+
+	runManager.AssertExpectations(t)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+)
+
+// SourceLatencySaveInterval is how often the latencies of external sources are
+// saved.
+const SourceLatencySaveInterval = time.Minute
+
+// SourceLatencySaver saves the latencies of the requests sent to external
+// sources, and loads them back when the node starts, so that the timeouts
+// derived from them do not have to be relearnt after every restart.
+type SourceLatencySaver interface {
+	Start() error
+	Stop() error
+	Save() error
+}
+
+type sourceLatencySaver struct {
+	store     *store.Store
+	latencies *utils.SourceLatencies
+	interval  time.Duration
+	// unsaved holds the changes which have yet to be saved
+	unsaved map[string][]time.Duration
+
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSourceLatencySaver returns a SourceLatencySaver which saves latencies
+// every interval.
+func NewSourceLatencySaver(store *store.Store, latencies *utils.SourceLatencies, interval time.Duration) SourceLatencySaver {
+	return &sourceLatencySaver{
+		store:     store,
+		latencies: latencies,
+		interval:  interval,
+		unsaved:   make(map[string][]time.Duration),
+		chStop:    make(chan struct{}),
+	}
+}
+
+// Start loads the saved latencies and then saves them every interval.
+func (sls *sourceLatencySaver) Start() error {
+	if err := sls.load(); err != nil {
+		return errors.Wrap(err, "unable to load source latencies")
+	}
+	sls.wg.Add(1)
+	go sls.run()
+	return nil
+}
+
+// Stop stops saving latencies, saving them one last time.
+func (sls *sourceLatencySaver) Stop() error {
+	close(sls.chStop)
+	sls.wg.Wait()
+	return sls.Save()
+}
+
+func (sls *sourceLatencySaver) run() {
+	defer sls.wg.Done()
+	ticker := time.NewTicker(sls.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sls.chStop:
+			return
+		case <-ticker.C:
+			if err := sls.Save(); err != nil {
+				logger.Errorw("Unable to save source latencies", "error", err)
+			}
+		}
+	}
+}
+
+func (sls *sourceLatencySaver) load() error {
+	saved, err := sls.store.SourceLatencies()
+	if err != nil {
+		return err
+	}
+	snapshot := make(map[string][]time.Duration, len(saved))
+	for _, latency := range saved {
+		var samples []time.Duration
+		for _, sample := range latency.Samples.Array() {
+			samples = append(samples, time.Duration(sample.Int()))
+		}
+		snapshot[latency.Source] = samples
+	}
+	sls.latencies.Load(snapshot)
+	return nil
+}
+
+// Save saves the latencies of the sources which have recorded any since they
+// were last saved. Only the latencies of the sources kept in memory are kept
+// saved.
+func (sls *sourceLatencySaver) Save() error {
+	for source, samples := range sls.latencies.Changes() {
+		sls.unsaved[source] = samples
+	}
+	now := time.Now()
+	var latencies []models.SourceLatency
+	for source, samples := range sls.unsaved {
+		encoded, err := json.Marshal(samples)
+		if err != nil {
+			return err
+		}
+		parsed, err := models.ParseJSON(encoded)
+		if err != nil {
+			return err
+		}
+		latencies = append(latencies, models.SourceLatency{Source: source, Samples: parsed, UpdatedAt: now})
+	}
+	if err := sls.store.SaveSourceLatencies(latencies, utils.MaxLatencySources); err != nil {
+		return err
+	}
+	sls.unsaved = make(map[string][]time.Duration)
+	return nil
+}
@@ -0,0 +1,61 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceLatencySaver_SavesAndLoads(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	latencies := utils.NewSourceLatencies()
+	latencies.Record("api.example.org", 10*time.Millisecond)
+	latencies.Record("api.example.org", 30*time.Millisecond)
+	saver := services.NewSourceLatencySaver(store, latencies, time.Hour)
+	require.NoError(t, saver.Start())
+	latencies.Record("api.example.org", 20*time.Millisecond)
+	require.NoError(t, saver.Stop())
+
+	restored := utils.NewSourceLatencies()
+	saver = services.NewSourceLatencySaver(store, restored, time.Hour)
+	require.NoError(t, saver.Start())
+	defer func() { assert.NoError(t, saver.Stop()) }()
+
+	assert.Equal(t, latencies.Snapshot(), restored.Snapshot())
+}
+
+func TestSourceLatencySaver_SavesChangesOnly(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	latencies := utils.NewSourceLatencies()
+	latencies.Record("api.example.org", 10*time.Millisecond)
+	saver := services.NewSourceLatencySaver(store, latencies, time.Hour)
+	require.NoError(t, saver.Save())
+
+	saved, err := store.SourceLatencies()
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	savedAt := saved[0].UpdatedAt
+
+	latencies.Record("other.example.org", 10*time.Millisecond)
+	require.NoError(t, saver.Save())
+
+	saved, err = store.SourceLatencies()
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, "api.example.org", saved[0].Source)
+	assert.True(t, savedAt.Equal(saved[0].UpdatedAt))
+}
@@ -96,7 +96,7 @@ func NewInitiatorSubscription(
 
 	filter, err := models.FilterQueryFactory(initr, nextHead, config.OperatorContractAddress())
 	if err != nil {
-		return InitiatorSubscription{}, errors.Wrap(err, "NewInitiatorSubscription#FilterQueryFactory")
+		return InitiatorSubscription{}, models.NewInvalidSpecError(errors.Wrap(err, "NewInitiatorSubscription#FilterQueryFactory"))
 	}
 
 	sub := InitiatorSubscription{
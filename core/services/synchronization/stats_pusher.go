@@ -24,6 +24,14 @@ var (
 		Name: "stats_pusher_events_sent",
 		Help: "The number of events pushed up to explorer",
 	})
+	numberEventsDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stats_pusher_events_dropped",
+		Help: "The number of events dropped from the backlog of events waiting to be pushed up to explorer, because it was full",
+	})
+	backlogSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "stats_pusher_backlog",
+		Help: "The number of events waiting to be pushed up to explorer",
+	})
 
 	gormCallbacksMutex *sync.RWMutex
 )
@@ -37,6 +45,10 @@ func init() {
 // StatsPusher polls for events and pushes them via a WebSocketClient. Events
 // are consumed by the Explorer. Currently there is only one event type: an
 // encoding of a JobRun.
+//
+// Events are stored in the database until the Explorer accepts them, so that
+// they are pushed once it can be reached again. The backlog of events is
+// capped, beyond which the oldest are dropped.
 type StatsPusher interface {
 	Start() error
 	Close() error
@@ -49,6 +61,7 @@ type statsPusher struct {
 	ORM            *orm.ORM
 	WSClient       WebSocketClient
 	Period         time.Duration
+	MaxBacklog     uint64
 	cancel         context.CancelFunc
 	clock          utils.Afterer
 	backoffSleeper backoff.Backoff
@@ -60,8 +73,9 @@ const (
 	updateCallbackName = "sync:run_after_update"
 )
 
-// NewStatsPusher returns a new event queuer
-func NewStatsPusher(orm *orm.ORM, url *url.URL, accessKey, secret string, afters ...utils.Afterer) StatsPusher {
+// NewStatsPusher returns a new event queuer, which keeps at most maxBacklog
+// events waiting to be pushed, or every one if it is zero.
+func NewStatsPusher(orm *orm.ORM, url *url.URL, accessKey, secret string, maxBacklog uint64, afters ...utils.Afterer) StatsPusher {
 	var clock utils.Afterer
 	if len(afters) == 0 {
 		clock = utils.Clock{}
@@ -70,10 +84,11 @@ func NewStatsPusher(orm *orm.ORM, url *url.URL, accessKey, secret string, afters
 	}
 
 	sp := &statsPusher{
-		ORM:      orm,
-		WSClient: noopWebSocketClient{},
-		Period:   30 * time.Minute,
-		clock:    clock,
+		ORM:        orm,
+		WSClient:   noopWebSocketClient{},
+		Period:     30 * time.Minute,
+		MaxBacklog: maxBacklog,
+		clock:      clock,
 		backoffSleeper: backoff.Backoff{
 			Min: 1 * time.Second,
 			Max: 5 * time.Minute,
@@ -183,6 +198,8 @@ func (sp *statsPusher) pusherLoop(parentCtx context.Context) error {
 func (sp *statsPusher) pushEvents() error {
 	gormCallbacksMutex.RLock()
 	defer gormCallbacksMutex.RUnlock()
+	sp.trimBacklog()
+	defer sp.measureBacklog()
 	err := sp.ORM.AllSyncEvents(func(event models.SyncEvent) error {
 		return sp.syncEvent(event)
 	})
@@ -195,6 +212,31 @@ func (sp *statsPusher) pushEvents() error {
 	return nil
 }
 
+// trimBacklog drops the oldest events when more than MaxBacklog are waiting.
+func (sp *statsPusher) trimBacklog() {
+	if sp.MaxBacklog == 0 {
+		return
+	}
+	dropped, err := sp.ORM.TrimSyncEvents(sp.MaxBacklog)
+	if err != nil {
+		logger.Errorw("Failed to trim stats pusher backlog", "error", err)
+		return
+	}
+	if dropped > 0 {
+		numberEventsDropped.Add(float64(dropped))
+		logger.Warnw("Dropped the oldest events waiting to be pushed to explorer, as the backlog is full", "dropped", dropped, "maxBacklog", sp.MaxBacklog)
+	}
+}
+
+func (sp *statsPusher) measureBacklog() {
+	count, err := sp.ORM.CountOf(&models.SyncEvent{})
+	if err != nil {
+		logger.Errorw("Failed to count stats pusher backlog", "error", err)
+		return
+	}
+	backlogSize.Set(float64(count))
+}
+
 func (sp *statsPusher) syncEvent(event models.SyncEvent) error {
 	sp.WSClient.Send([]byte(event.Body))
 	numberEventsSent.Inc()
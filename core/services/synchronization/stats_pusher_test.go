@@ -20,7 +20,7 @@ func TestStatsPusher(t *testing.T) {
 	wsserver, wscleanup := cltest.NewEventWebSocketServer(t)
 	defer wscleanup()
 
-	pusher := synchronization.NewStatsPusher(store.ORM, wsserver.URL, "", "")
+	pusher := synchronization.NewStatsPusher(store.ORM, wsserver.URL, "", "", 0)
 	pusher.Start()
 	defer pusher.Close()
 
@@ -36,6 +36,32 @@ func TestStatsPusher(t *testing.T) {
 	cltest.WaitForSyncEventCount(t, store.ORM, 0)
 }
 
+func TestStatsPusher_DropsOldestEventsBeyondMaxBacklog(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	wsserver, wscleanup := cltest.NewEventWebSocketServer(t)
+	defer wscleanup()
+
+	// Queued while explorer could not be reached
+	for _, body := range []string{`{"n": 1}`, `{"n": 2}`, `{"n": 3}`} {
+		require.NoError(t, store.ORM.RawDB(func(db *gorm.DB) error { return db.Create(&models.SyncEvent{Body: body}).Error }))
+	}
+
+	pusher := synchronization.NewStatsPusher(store.ORM, wsserver.URL, "", "", 2)
+	pusher.Start()
+	defer pusher.Close()
+	pusher.PushNow()
+
+	for _, want := range []string{`{"n": 2}`, `{"n": 3}`} {
+		cltest.CallbackOrTimeout(t, "ws server receives queued event", func() {
+			assert.Equal(t, want, <-wsserver.Received)
+			assert.NoError(t, wsserver.Broadcast(`{"status": 201}`))
+		})
+	}
+	cltest.WaitForSyncEventCount(t, store.ORM, 0)
+}
+
 func TestStatsPusher_ClockTrigger(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -44,7 +70,7 @@ func TestStatsPusher_ClockTrigger(t *testing.T) {
 	defer wscleanup()
 
 	clock := cltest.NewTriggerClock(t)
-	pusher := synchronization.NewStatsPusher(store.ORM, wsserver.URL, "", "", clock)
+	pusher := synchronization.NewStatsPusher(store.ORM, wsserver.URL, "", "", 0, clock)
 	pusher.Start()
 	defer pusher.Close()
 
@@ -69,7 +95,7 @@ func TestStatsPusher_NoAckLeavesEvent(t *testing.T) {
 	wsserver, wscleanup := cltest.NewEventWebSocketServer(t)
 	defer wscleanup()
 
-	pusher := synchronization.NewStatsPusher(store.ORM, wsserver.URL, "", "")
+	pusher := synchronization.NewStatsPusher(store.ORM, wsserver.URL, "", "", 0)
 	pusher.Start()
 	defer pusher.Close()
 
@@ -91,7 +117,7 @@ func TestStatsPusher_BadSyncLeavesEvent(t *testing.T) {
 	defer wscleanup()
 
 	clock := cltest.NewTriggerClock(t)
-	pusher := synchronization.NewStatsPusher(store.ORM, wsserver.URL, "", "", clock)
+	pusher := synchronization.NewStatsPusher(store.ORM, wsserver.URL, "", "", 0, clock)
 	pusher.Start()
 	defer pusher.Close()
 
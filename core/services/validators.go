@@ -3,6 +3,7 @@ package services
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
@@ -13,9 +14,11 @@ import (
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/vrfkey"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
@@ -28,7 +31,9 @@ func ValidateJob(j models.JobSpec, store *store.Store) error {
 	if j.StartAt.Valid && j.EndAt.Valid && j.StartAt.Time.After(j.EndAt.Time) {
 		fe.Add("StartAt cannot be before EndAt")
 	}
-	if len(j.Initiators) < 1 || len(j.Tasks) < 1 {
+	// Keeper jobs perform upkeeps themselves, so have no tasks
+	isKeeper := len(j.InitiatorsFor(models.InitiatorKeeper)) > 0
+	if len(j.Initiators) < 1 || (len(j.Tasks) < 1 && !isKeeper) {
 		fe.Add("Must have at least one Initiator and one Task")
 	}
 	for _, i := range j.Initiators {
@@ -37,20 +42,132 @@ func ValidateJob(j models.JobSpec, store *store.Store) error {
 		}
 	}
 	for _, task := range j.Tasks {
+		if task.MaxResultSize.Valid && task.MaxResultSize.Int64 < 0 {
+			fe.Add(fmt.Sprintf("%s task maxResultSize cannot be negative", task.Type))
+		}
 		if err := validateTask(task, store); err != nil {
 			fe.Merge(err)
+		} else if err := validateTaskNamespace(task, j.Namespace, store); err != nil {
+			fe.Merge(err)
+		}
+	}
+	validateSpecPolicy(j, store, fe)
+	validateTaskVariables(j, fe)
+	if j.AttestationKey != nil {
+		if err := validateAttestationKey(*j.AttestationKey, j.Namespace, store); err != nil {
+			fe.Merge(err)
+		}
+	}
+	if j.Confidential && store.Config.RunDataEncryptionKey() == "" {
+		fe.Add("Confidential jobs require RUN_DATA_ENCRYPTION_KEY to be set")
+	}
+	if j.NextJobID != nil {
+		if err := validateNextJob(j, store); err != nil {
+			fe.Merge(err)
+		}
+	}
+	if j.ResultFormat != nil {
+		if err := j.ResultFormat.Validate(); err != nil {
+			fe.Merge(err)
 		}
 	}
 	return fe.CoerceEmptyToNil()
 }
 
+// validateNextJob checks that the job a job is chained to exists in the same
+// namespace, can be run with the job's results through a web initiator, is
+// confidential if the job is, so that its results are not stored in the
+// clear, and does not lead back to the job, which would run the jobs in a
+// loop forever.
+func validateNextJob(j models.JobSpec, store *store.Store) error {
+	namespace := j.Namespace
+	if namespace == "" {
+		namespace = models.DefaultNamespace
+	}
+	next, err := store.FindJob(j.NextJobID)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && next.Namespace != namespace) {
+		return fmt.Errorf("next job %s not found", j.NextJobID)
+	} else if err != nil {
+		return err
+	}
+	if len(next.InitiatorsFor(models.InitiatorWeb)) == 0 {
+		return fmt.Errorf("next job %s must have a web initiator", j.NextJobID)
+	}
+	if j.Confidential && !next.Confidential {
+		return fmt.Errorf("next job %s must be confidential, as it is run with the results of this confidential job", j.NextJobID)
+	}
+
+	loop := fmt.Errorf("next job %s runs this job in turn, chaining them in a loop", j.NextJobID)
+	if j.NextJobID.String() == j.ID.String() {
+		return loop
+	}
+	seen := map[string]bool{j.NextJobID.String(): true}
+	for next.NextJobID != nil {
+		id := next.NextJobID
+		if id.String() == j.ID.String() {
+			return loop
+		} else if seen[id.String()] {
+			// A loop which this job is not part of cannot be made any worse
+			return nil
+		}
+		seen[id.String()] = true
+		if next, err = store.FindJob(id); errors.Cause(err) == orm.ErrorNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAttestationKey checks that the node holds the key which is to sign
+// the job's results, and that it belongs to the job's namespace.
+func validateAttestationKey(address models.EIP55Address, namespace string, store *store.Store) error {
+	if namespace == "" {
+		namespace = models.DefaultNamespace
+	}
+	if _, err := store.KeyStore.GetAccountByAddress(address.Address()); err != nil {
+		return fmt.Errorf("attestation key %s is not in the keystore", address)
+	}
+	key, err := store.KeyByAddress(address.Address())
+	if err != nil {
+		return fmt.Errorf("attestation key %s is not in the keystore", address)
+	}
+	if key.Namespace != namespace {
+		return fmt.Errorf("attestation key %s is not in namespace %s", address, namespace)
+	}
+	return nil
+}
+
+// validateTaskVariables checks that task names are valid and unique, and
+// that every $(name.result) refers to an earlier task.
+func validateTaskVariables(j models.JobSpec, fe *models.JSONAPIErrors) {
+	earlier := map[string]bool{}
+	for i, task := range j.Tasks {
+		for _, name := range models.TaskVariableReferences(task.Params) {
+			if !earlier[name] {
+				fe.Add(fmt.Sprintf("task %d refers to $(%s.result), but no earlier task is named %s", i, name, name))
+			}
+		}
+		if task.Name == "" {
+			continue
+		}
+		if err := models.ValidateTaskName(task.Name); err != nil {
+			fe.Add(err.Error())
+		} else if earlier[task.Name] {
+			fe.Add(fmt.Sprintf("task name %s is used more than once", task.Name))
+		}
+		earlier[task.Name] = true
+	}
+}
+
 // ValidateBridgeTypeNotExist checks that a bridge has not already been created
 func ValidateBridgeTypeNotExist(bt *models.BridgeTypeRequest, store *store.Store) error {
 	fe := models.NewJSONAPIErrors()
-	bridge, err := store.ORM.FindBridge(bt.Name)
+	_, err := store.ORM.FindBridge(bt.Name)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		fe.Add(fmt.Sprintf("Error determining if bridge type %v already exists", bt.Name))
-	} else if (bridge != models.BridgeType{}) {
+	} else if err == nil {
 		fe.Add(fmt.Sprintf("Bridge Type %v already exists", bt.Name))
 	}
 	return fe.CoerceEmptyToNil()
@@ -70,6 +187,11 @@ func ValidateBridgeType(bt *models.BridgeTypeRequest, store *store.Store) error
 	if len(strings.TrimSpace(u)) == 0 {
 		fe.Add("URL must be present")
 	}
+	for _, failover := range bt.FailoverURLs {
+		if failover.String() == "" {
+			fe.Add("Failover URLs must not be empty")
+		}
+	}
 	if bt.MinimumContractPayment != nil &&
 		bt.MinimumContractPayment.Cmp(assets.NewLink(0)) < 0 {
 		fe.Add("MinimumContractPayment must be positive")
@@ -101,6 +223,9 @@ func ValidateExternalInitiator(
 	} else if err != orm.ErrorNotFound {
 		return errors.Wrap(err, "validating external initiator")
 	}
+	if exi.ProtocolVersion < 0 || exi.ProtocolVersion > models.ExternalInitiatorProtocolV2 {
+		fe.Add(fmt.Sprintf("Unknown protocol version %d, must be %d or %d", exi.ProtocolVersion, models.ExternalInitiatorProtocolV1, models.ExternalInitiatorProtocolV2))
+	}
 	return fe.CoerceEmptyToNil()
 }
 
@@ -124,7 +249,11 @@ func ValidateInitiator(i models.Initiator, j models.JobSpec, store *store.Store)
 	case models.InitiatorEthLog:
 		return nil
 	case models.InitiatorRandomnessLog:
-		return validateRandomnessLogInitiator(i, j)
+		return validateRandomnessLogInitiator(i, j, store)
+	case models.InitiatorWebhook:
+		return validateWebhookInitiator(i, j)
+	case models.InitiatorKeeper:
+		return validateKeeperInitiator(i, j, store)
 	default:
 		return models.NewJSONAPIErrorsWith(fmt.Sprintf("type %v does not exist", i.Type))
 	}
@@ -192,6 +321,11 @@ func validateFluxMonitor(i models.Initiator, j models.JobSpec, store *store.Stor
 	if err := validateFeeds(i.Feeds, store); err != nil {
 		fe.Add(err.Error())
 	}
+	if i.MinAnswers < 0 {
+		fe.Add("minAnswers must be nonnegative")
+	} else if int(i.MinAnswers) > len(i.Feeds.Array()) {
+		fe.Add("minAnswers must not exceed the number of feeds")
+	}
 
 	return fe.CoerceEmptyToNil()
 }
@@ -213,6 +347,15 @@ func validateFeeds(feeds models.Feeds, store *store.Store) error {
 				return err
 			}
 		case map[string]interface{}: // named feed - ex: {"bridge": "bridgeName"}
+			if address, ok := feed["aggregator"]; ok { // aggregator feed - ex: {"aggregator": "0x..."}
+				addressString, ok := address.(string)
+				if len(feed) != 1 {
+					return errors.New("Unsupported keys in feed JSON")
+				} else if !ok || !common.IsHexAddress(addressString) {
+					return errors.New("Invalid aggregator address in feed JSON")
+				}
+				continue
+			}
 			bridgeName := feed["bridge"]
 			bridgeNameString, ok := bridgeName.(string)
 			if bridgeName == nil {
@@ -294,6 +437,47 @@ func validateExternalInitiator(i models.Initiator) error {
 	return nil
 }
 
+// minWebhookSecretLength is the shortest secret a webhook initiator may sign
+// its requests with.
+const minWebhookSecretLength = 16
+
+func validateWebhookInitiator(i models.Initiator, j models.JobSpec) error {
+	fe := models.NewJSONAPIErrors()
+	if j.ExternalJobID == nil {
+		fe.Add("Webhook jobs must have an externalJobID, which identifies them in the webhook's URL")
+	}
+	if len(i.WebhookSecret) < minWebhookSecretLength {
+		fe.Add(fmt.Sprintf("Webhook must have a secret of at least %d characters", minWebhookSecretLength))
+	}
+	if i.WebhookRateLimit < 0 {
+		fe.Add("Webhook rateLimit must not be negative")
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+func validateKeeperInitiator(i models.Initiator, j models.JobSpec, store *store.Store) error {
+	fe := models.NewJSONAPIErrors()
+	if store.Config.EthereumDisabled() {
+		fe.Add("cannot add keeper jobs when ethereum is disabled")
+	}
+	if !store.Config.EnableBulletproofTxManager() {
+		fe.Add("keeper jobs require the bulletproof tx manager to be enabled")
+	}
+	if len(j.Initiators) != 1 {
+		fe.Add("keeper jobs must have exactly one initiator")
+	}
+	if len(j.Tasks) != 0 {
+		fe.Add("keeper jobs perform upkeeps themselves and cannot have tasks")
+	}
+	if i.Address == utils.ZeroAddress {
+		fe.Add("keeper must specify the address of its registry")
+	}
+	if i.BlockCountPerTurn <= 0 {
+		fe.Add("keeper must have a positive blockCountPerTurn")
+	}
+	return fe.CoerceEmptyToNil()
+}
+
 func validateServiceAgreementInitiator(i models.Initiator, j models.JobSpec) error {
 	fe := models.NewJSONAPIErrors()
 	if len(j.Initiators) != 1 {
@@ -302,7 +486,7 @@ func validateServiceAgreementInitiator(i models.Initiator, j models.JobSpec) err
 	return fe.CoerceEmptyToNil()
 }
 
-func validateRandomnessLogInitiator(i models.Initiator, j models.JobSpec) error {
+func validateRandomnessLogInitiator(i models.Initiator, j models.JobSpec, store *store.Store) error {
 	fe := models.NewJSONAPIErrors()
 	if len(j.Initiators) != 1 {
 		fe.Add("randomness log must have exactly one initiator")
@@ -310,9 +494,43 @@ func validateRandomnessLogInitiator(i models.Initiator, j models.JobSpec) error
 	if i.Address == utils.ZeroAddress {
 		fe.Add("randomness log must specify address of expected emmitter")
 	}
+	validateRandomTasks(j, store, fe)
 	return fe.CoerceEmptyToNil()
 }
 
+// validateRandomTasks checks that a randomness log job generates its VRF
+// proofs with a random task, and that each of its random tasks uses a VRF key
+// registered with the node.
+func validateRandomTasks(j models.JobSpec, store *store.Store, fe *models.JSONAPIErrors) {
+	var found bool
+	for _, task := range j.Tasks {
+		if task.Type != adapters.TaskTypeRandom {
+			continue
+		}
+		found = true
+		adapter, err := adapters.For(task, store.Config, store.ORM)
+		if err != nil {
+			// Reported when the task itself is validated
+			continue
+		}
+		random, ok := adapter.BaseAdapter.(*adapters.Random)
+		if !ok {
+			continue
+		}
+		key, err := vrfkey.NewPublicKeyFromHex(random.PublicKey)
+		if err != nil {
+			fe.Add(fmt.Sprintf("random task has an invalid publicKey: %v", err))
+			continue
+		}
+		if _, err := store.VRFKeyStore.GetSpecificKey(key); err != nil {
+			fe.Add(fmt.Sprintf("random task's publicKey %s is not a VRF key registered with the node", key))
+		}
+	}
+	if !found {
+		fe.Add("randomness log jobs must have a random task, which generates the VRF proof")
+	}
+}
+
 func validateTask(task models.TaskSpec, store *store.Store) error {
 	adapter, err := adapters.For(task, store.Config, store.ORM)
 	if err != nil {
@@ -326,9 +544,153 @@ func validateTask(task models.TaskSpec, store *store.Store) error {
 			return errors.New("EthTxABIEncode Adapter is not implemented yet")
 		}
 	}
+	if e, ok := adapter.BaseAdapter.(*adapters.EthTx); ok {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	if a, ok := adapter.BaseAdapter.(*adapters.Assert); ok {
+		if err := a.Validate(); err != nil {
+			return err
+		}
+	}
+	if m, ok := adapter.BaseAdapter.(*adapters.Map); ok {
+		if err := m.Validate(); err != nil {
+			return err
+		}
+		for _, subtask := range m.TaskSpecs() {
+			if err := validateTask(subtask, store); err != nil {
+				return errors.Wrap(err, "invalid map task")
+			}
+		}
+	}
+	return nil
+}
+
+// validateTaskNamespace checks that a task only uses the bridges and keys of
+// its job's namespace. Jobs outside the default namespace must name the keys
+// their transactions are sent from, since the node otherwise picks one of the
+// default namespace's keys.
+func validateTaskNamespace(task models.TaskSpec, namespace string, store *store.Store) error {
+	if namespace == "" {
+		namespace = models.DefaultNamespace
+	}
+	adapter, err := adapters.For(task, store.Config, store.ORM)
+	if err != nil {
+		return err
+	}
+
+	switch a := adapter.BaseAdapter.(type) {
+	case *adapters.Map:
+		for _, subtask := range a.TaskSpecs() {
+			if err := validateTaskNamespace(subtask, namespace, store); err != nil {
+				return err
+			}
+		}
+	case *adapters.Bridge:
+		if a.Namespace != namespace {
+			return fmt.Errorf("bridge %s is not in namespace %s", a.Name, namespace)
+		}
+	case *adapters.EthTx:
+		addresses := a.FromAddresses
+		if len(addresses) == 0 && a.FromAddress != utils.ZeroAddress {
+			addresses = []common.Address{a.FromAddress}
+		}
+		if len(addresses) == 0 && namespace != models.DefaultNamespace {
+			return fmt.Errorf("%s tasks in namespace %s must specify fromAddresses", task.Type, namespace)
+		}
+		for _, address := range addresses {
+			key, err := store.KeyByAddress(address)
+			if err != nil && namespace == models.DefaultNamespace {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("no key %s in the keystore", address.Hex())
+			}
+			if key.Namespace != namespace {
+				return fmt.Errorf("key %s is not in namespace %s", address.Hex(), namespace)
+			}
+		}
+	}
 	return nil
 }
 
+// validateSpecPolicy checks that every task of the job satisfies the spec
+// policy of the job's namespace.
+func validateSpecPolicy(j models.JobSpec, store *store.Store, fe *models.JSONAPIErrors) {
+	namespace := j.Namespace
+	if namespace == "" {
+		namespace = models.DefaultNamespace
+	}
+	ns, err := store.FindNamespace(namespace)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		// Namespaces are checked when the job is created
+		return
+	} else if err != nil {
+		fe.Add(fmt.Sprintf("unable to load the spec policy of namespace %s: %v", namespace, err))
+		return
+	}
+	for _, task := range j.Tasks {
+		if err := validateTaskPolicy(task, ns.Name, ns.SpecPolicy, store); err != nil {
+			fe.Add(err.Error())
+		}
+	}
+}
+
+// validateTaskPolicy checks that the task's type, and the URLs and HTTP
+// method it calls, are allowed by the policy. Tasks which take their URL from
+// the run's input must name it in the spec when URLs are restricted, since
+// the URL cannot be checked until the run.
+func validateTaskPolicy(task models.TaskSpec, namespace string, policy models.NamespaceSpecPolicy, store *store.Store) error {
+	if !policy.AllowsTaskType(task.Type) {
+		return fmt.Errorf("%s tasks are not allowed in namespace %s", task.Type, namespace)
+	}
+	adapter, err := adapters.For(task, store.Config, store.ORM)
+	if err != nil {
+		// Reported by validateTask
+		return nil
+	}
+
+	var method string
+	var urls []string
+	switch a := adapter.BaseAdapter.(type) {
+	case *adapters.Map:
+		for _, subtask := range a.TaskSpecs() {
+			if err := validateTaskPolicy(subtask, namespace, policy, store); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *adapters.Bridge:
+		method, urls = http.MethodPost, append([]string{a.URL.String()}, webURLStrings(a.FailoverURLs)...)
+	case *adapters.HTTPGet:
+		method, urls = http.MethodGet, append([]string{a.GetURL()}, webURLStrings(a.FailoverURLs)...)
+	case *adapters.HTTPPost:
+		method, urls = http.MethodPost, append([]string{a.GetURL()}, webURLStrings(a.FailoverURLs)...)
+	default:
+		return nil
+	}
+
+	if !policy.AllowsMethod(method) {
+		return fmt.Errorf("%s task calls with %s, which is not allowed in namespace %s", task.Type, method, namespace)
+	}
+	for _, rawURL := range urls {
+		if rawURL == "" && len(policy.AllowedURLs) > 0 {
+			return fmt.Errorf("%s tasks in namespace %s must specify their URL", task.Type, namespace)
+		} else if !policy.AllowsURL(rawURL) {
+			return fmt.Errorf("%s task calls %s, which is not allowed in namespace %s", task.Type, rawURL, namespace)
+		}
+	}
+	return nil
+}
+
+func webURLStrings(webURLs models.WebURLs) []string {
+	urls := make([]string, len(webURLs))
+	for i, webURL := range webURLs {
+		urls[i] = webURL.String()
+	}
+	return urls
+}
+
 // ValidateServiceAgreement checks the ServiceAgreement for any application logic errors.
 func ValidateServiceAgreement(sa models.ServiceAgreement, store *store.Store) error {
 	fe := models.NewJSONAPIErrors()
@@ -11,8 +11,10 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/vrfkey"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -75,6 +77,21 @@ func TestValidateJob(t *testing.T) {
 			cltest.MustReadFile(t, "testdata/runlog_2_ethlogs_job.json"),
 			models.NewJSONAPIErrorsWith("Cannot RunLog initiated jobs cannot have more than one EthTx Task"),
 		},
+		{
+			"task variable referring to a later task",
+			cltest.MustReadFile(t, "testdata/task_variable_later_task_job.json"),
+			models.NewJSONAPIErrorsWith("task 0 refers to $(fetch.result), but no earlier task is named fetch"),
+		},
+		{
+			"duplicate task names",
+			cltest.MustReadFile(t, "testdata/task_variable_duplicate_name_job.json"),
+			models.NewJSONAPIErrorsWith("task name fetch is used more than once"),
+		},
+		{
+			"map with an ethtx task",
+			cltest.MustReadFile(t, "testdata/map_ethtx_job.json"),
+			models.NewJSONAPIErrorsWith("ethtx tasks cannot be performed within a map"),
+		},
 	}
 
 	store, cleanup := cltest.NewStore(t)
@@ -104,6 +121,41 @@ func TestValidateJob_RejectsSleepAdapterWhenExperimentalAdaptersAreDisabled(t *t
 	assert.Error(t, services.ValidateJob(sleepingJob, store))
 }
 
+func TestValidateJob_NextJob(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	next := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&next))
+	job := cltest.NewJobWithWebInitiator()
+	job.NextJobID = next.ID
+	assert.NoError(t, services.ValidateJob(job, store))
+
+	job.NextJobID = models.NewID()
+	assert.EqualError(t, services.ValidateJob(job, store), fmt.Sprintf("next job %s not found", job.NextJobID))
+
+	cron := cltest.NewJobWithSchedule("* * * * *")
+	require.NoError(t, store.CreateJob(&cron))
+	job.NextJobID = cron.ID
+	assert.EqualError(t, services.ValidateJob(job, store), fmt.Sprintf("next job %s must have a web initiator", cron.ID))
+
+	// A confidential job's results are only passed to a confidential job
+	store.Config.Set("RUN_DATA_ENCRYPTION_KEY", "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	job.NextJobID = next.ID
+	job.Confidential = true
+	assert.EqualError(t, services.ValidateJob(job, store), fmt.Sprintf("next job %s must be confidential, as it is run with the results of this confidential job", next.ID))
+	job.Confidential = false
+
+	// Chaining the next job back to the job would run them in a loop
+	job.NextJobID = next.ID
+	require.NoError(t, store.CreateJob(&job))
+	next.NextJobID = job.ID
+	assert.EqualError(t, services.ValidateJob(next, store), fmt.Sprintf("next job %s runs this job in turn, chaining them in a loop", job.ID))
+	job.NextJobID = job.ID
+	assert.Error(t, services.ValidateJob(job, store))
+}
+
 func TestValidateBridgeType(t *testing.T) {
 	t.Parallel()
 
@@ -172,6 +224,24 @@ func TestValidateBridgeType(t *testing.T) {
 			},
 			models.NewJSONAPIErrorsWith("MinimumContractPayment must be positive"),
 		},
+		{
+			"invalid with blank failover url",
+			models.BridgeTypeRequest{
+				Name:         "adapterwithfailover",
+				URL:          cltest.WebURL(t, "https://us.denergy.eth"),
+				FailoverURLs: models.WebURLs{cltest.WebURL(t, "")},
+			},
+			models.NewJSONAPIErrorsWith("Failover URLs must not be empty"),
+		},
+		{
+			"valid failover url",
+			models.BridgeTypeRequest{
+				Name:         "adapterwithfailover",
+				URL:          cltest.WebURL(t, "https://us.denergy.eth"),
+				FailoverURLs: models.WebURLs{cltest.WebURL(t, "https://eu.denergy.eth")},
+			},
+			nil,
+		},
 		{
 			"existing core adapter",
 			models.BridgeTypeRequest{
@@ -415,6 +485,8 @@ func TestValidateInitiator_FluxMonitorErrors(t *testing.T) {
 		{"pollTimer enabled, but no period specified", cltest.MustJSONDel(t, validInitiator, "params.pollTimer.period")},
 		{"period must be equal or greater than 15s", cltest.MustJSONSet(t, validInitiator, "params.pollTimer.period", "1s")},
 		{"idleTimer.duration must be >= than pollTimer.period", cltest.MustJSONSet(t, validInitiator, "params.idleTimer.duration", "30s")},
+		{"minAnswers must be nonnegative", cltest.MustJSONSet(t, validInitiator, "params.minAnswers", -1)},
+		{"minAnswers must not exceed the number of feeds", cltest.MustJSONSet(t, validInitiator, "params.minAnswers", 4)},
 	}
 	for _, test := range tests {
 		t.Run("bad "+test.Field, func(t *testing.T) {
@@ -427,6 +499,102 @@ func TestValidateInitiator_FluxMonitorErrors(t *testing.T) {
 	}
 }
 
+func TestValidateInitiator_Webhook(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	externalJobID := uuid.NewV4()
+	job := cltest.NewJob()
+	initr := models.Initiator{
+		Type:            models.InitiatorWebhook,
+		InitiatorParams: models.InitiatorParams{WebhookSecret: "0123456789abcdef"},
+	}
+
+	err := services.ValidateInitiator(initr, job, store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "externalJobID")
+
+	job.ExternalJobID = &externalJobID
+	require.NoError(t, services.ValidateInitiator(initr, job, store))
+
+	initr.WebhookSecret = "short"
+	err = services.ValidateInitiator(initr, job, store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "secret")
+
+	initr.WebhookSecret = "0123456789abcdef"
+	initr.WebhookRateLimit = -1
+	err = services.ValidateInitiator(initr, job, store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rateLimit")
+}
+
+func TestValidateInitiator_Keeper(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	initr := models.Initiator{
+		Type:            models.InitiatorKeeper,
+		InitiatorParams: models.InitiatorParams{Address: cltest.NewAddress(), BlockCountPerTurn: 3},
+	}
+	job := cltest.NewJob()
+	job.Initiators = []models.Initiator{initr}
+	job.Tasks = nil
+
+	err := services.ValidateInitiator(initr, job, store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bulletproof tx manager")
+
+	config.Set("ENABLE_BULLETPROOF_TX_MANAGER", true)
+	require.NoError(t, services.ValidateInitiator(initr, job, store))
+	require.NoError(t, services.ValidateJob(job, store))
+
+	initr.BlockCountPerTurn = 0
+	err = services.ValidateInitiator(initr, job, store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blockCountPerTurn")
+
+	initr.BlockCountPerTurn = 3
+	job.Tasks = []models.TaskSpec{{Type: adapters.TaskTypeNoOp}}
+	err = services.ValidateInitiator(initr, job, store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot have tasks")
+}
+
+func TestValidateInitiator_RandomnessLog(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key, err := store.VRFKeyStore.CreateKey(cltest.Password, vrfkey.FastScryptParams)
+	require.NoError(t, err)
+
+	job := cltest.NewJobWithRandomnessLog()
+	initr := job.Initiators[0]
+	err = services.ValidateInitiator(initr, job, store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must have a random task")
+
+	job.Tasks = []models.TaskSpec{{
+		Type:   adapters.TaskTypeRandom,
+		Params: cltest.JSONFromString(t, fmt.Sprintf(`{"publicKey": "%s"}`, key.String())),
+	}}
+	require.NoError(t, services.ValidateInitiator(initr, job, store))
+
+	unregistered := vrfkey.CreateKey().PublicKey
+	job.Tasks[0].Params = cltest.JSONFromString(t, fmt.Sprintf(`{"publicKey": "%s"}`, unregistered.String()))
+	err = services.ValidateInitiator(initr, job, store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a VRF key registered")
+}
+
 func TestValidateInitiator_FluxMonitor_EthereumDisabled(t *testing.T) {
 	t.Parallel()
 
@@ -463,6 +631,20 @@ func TestValidateInitiator_FeedsHappy(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestValidateInitiator_AggregatorFeedsHappy(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJob()
+	var initr models.Initiator
+	require.NoError(t, json.Unmarshal([]byte(validInitiator), &initr))
+	initr.Feeds = cltest.JSONFromString(t, `[{"aggregator": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"}]`)
+	err := services.ValidateInitiator(initr, job, store)
+	require.NoError(t, err)
+}
+
 func TestValidateInitiator_FeedsErrors(t *testing.T) {
 	t.Parallel()
 
@@ -489,6 +671,8 @@ func TestValidateInitiator_FeedsErrors(t *testing.T) {
 		{"missing bridge", `[{"bridgeName": "doesnotexist"}]`},
 		{"unsupported bridge properties", `[{"bridge": "testbridge", "foo": "bar"}]`},
 		{"invalid entry", `["http://example.com", {"bridge": "testbridge"}, 1]`},
+		{"invalid aggregator address", `[{"aggregator": "0xinvalid"}]`},
+		{"unsupported aggregator properties", `[{"aggregator": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "foo": "bar"}]`},
 	}
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
@@ -30,6 +30,9 @@ type KeyStoreInterface interface {
 	Unlock(phrase string) error
 	NewAccount(passphrase string) (accounts.Account, error)
 	SignHash(hash common.Hash) (models.Signature, error)
+	SignHashWithAccount(account accounts.Account, hash common.Hash) (models.Signature, error)
+	SignPersonalMessageWithAccount(account accounts.Account, message []byte) (models.Signature, error)
+	SignTypedDataWithAccount(account accounts.Account, domainSeparator, structHash common.Hash) (models.Signature, error)
 	Import(keyJSON []byte, passphrase, newPassphrase string) (accounts.Account, error)
 	Export(a accounts.Account, passphrase, newPassphrase string) ([]byte, error)
 	GetAccounts() []accounts.Account
@@ -101,12 +104,22 @@ func (ks *KeyStore) SignTx(account accounts.Account, tx *types.Transaction, chai
 // This method adds an ethereum message prefix to the message before signing it,
 // invalidating any would-be valid Ethereum transactions
 func (ks *KeyStore) SignHash(hash common.Hash) (models.Signature, error) {
+	account, err := ks.GetFirstAccount()
+	if err != nil {
+		return models.Signature{}, err
+	}
+	return ks.SignHashWithAccount(account, hash)
+}
+
+// SignHashWithAccount signs a precomputed digest with the given account's
+// private key, adding the same ethereum message prefix as SignHash.
+func (ks *KeyStore) SignHashWithAccount(account accounts.Account, hash common.Hash) (models.Signature, error) {
 	prefixedMessageBytes, err := utils.Keccak256(append([]byte(EthereumMessageHashPrefix), hash.Bytes()...))
 	if err != nil {
 		return models.Signature{}, err
 	}
 
-	signature, err := ks.unsafeSignHash(common.BytesToHash(prefixedMessageBytes))
+	signature, err := ks.unsafeSignHash(account, common.BytesToHash(prefixedMessageBytes))
 	if err != nil {
 		return models.Signature{}, err
 	}
@@ -114,16 +127,36 @@ func (ks *KeyStore) SignHash(hash common.Hash) (models.Signature, error) {
 	return signature, nil
 }
 
-// unsafeSignHash signs a precomputed digest, using the first account's private
+// SignPersonalMessageWithAccount signs message with the given account's
+// private key as personal_sign does, following EIP-191 version 0x45: the
+// message is prefixed with its length before being hashed.
+func (ks *KeyStore) SignPersonalMessageWithAccount(account accounts.Account, message []byte) (models.Signature, error) {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+	hash, err := utils.Keccak256(append([]byte(prefix), message...))
+	if err != nil {
+		return models.Signature{}, err
+	}
+	return ks.unsafeSignHash(account, common.BytesToHash(hash))
+}
+
+// SignTypedDataWithAccount signs EIP-712 typed data, given the hashes of its
+// domain and message, with the given account's private key. Like the other
+// prefixes, EIP-712's 0x1901 prefix keeps the digest from being that of a
+// transaction.
+func (ks *KeyStore) SignTypedDataWithAccount(account accounts.Account, domainSeparator, structHash common.Hash) (models.Signature, error) {
+	digest, err := utils.Keccak256(append(append([]byte{0x19, 0x01}, domainSeparator.Bytes()...), structHash.Bytes()...))
+	if err != nil {
+		return models.Signature{}, err
+	}
+	return ks.unsafeSignHash(account, common.BytesToHash(digest))
+}
+
+// unsafeSignHash signs a precomputed digest, using the given account's private
 // key
 // NOTE: Do not use this method to sign arbitrary message hashes, it may be an
 // Ethereum transaction in disguise! Use SignHashSafe instead unless this is
 // strictly needed
-func (ks *KeyStore) unsafeSignHash(hash common.Hash) (models.Signature, error) {
-	account, err := ks.GetFirstAccount()
-	if err != nil {
-		return models.Signature{}, err
-	}
+func (ks *KeyStore) unsafeSignHash(account accounts.Account, hash common.Hash) (models.Signature, error) {
 	output, err := ks.KeyStore.SignHash(account, hash.Bytes())
 	if err != nil {
 		return models.Signature{}, err
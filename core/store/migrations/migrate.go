@@ -72,6 +72,49 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1600881493"
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1601294261"
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1601459029"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602050339"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602136814"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602225143"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602310000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602395000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602480000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602565000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602650000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602735000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602820000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602905000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1602990000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603075000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603160000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603245000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603330000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603415000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603500000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603585000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603590000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603595000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603600000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603605000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603610000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603615000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603620000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603625000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603630000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603635000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603640000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603645000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603650000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603655000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603660000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603665000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603670000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603675000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603680000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603685000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603690000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603695000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603700000"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1603705000"
 
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -359,6 +402,178 @@ func init() {
 			ID:      "1601294261",
 			Migrate: migration1601294261.Migrate,
 		},
+		{
+			ID:      "1602050339",
+			Migrate: migration1602050339.Migrate,
+		},
+		{
+			ID:      "1602136814",
+			Migrate: migration1602136814.Migrate,
+		},
+		{
+			ID:      "1602225143",
+			Migrate: migration1602225143.Migrate,
+		},
+		{
+			ID:      "1602310000",
+			Migrate: migration1602310000.Migrate,
+		},
+		{
+			ID:      "1602395000",
+			Migrate: migration1602395000.Migrate,
+		},
+		{
+			ID:      "1602480000",
+			Migrate: migration1602480000.Migrate,
+		},
+		{
+			ID:      "1602565000",
+			Migrate: migration1602565000.Migrate,
+		},
+		{
+			ID:      "1602650000",
+			Migrate: migration1602650000.Migrate,
+		},
+		{
+			ID:      "1602735000",
+			Migrate: migration1602735000.Migrate,
+		},
+		{
+			ID:      "1602820000",
+			Migrate: migration1602820000.Migrate,
+		},
+		{
+			ID:      "1602905000",
+			Migrate: migration1602905000.Migrate,
+		},
+		{
+			ID:      "1602990000",
+			Migrate: migration1602990000.Migrate,
+		},
+		{
+			ID:      "1603075000",
+			Migrate: migration1603075000.Migrate,
+		},
+		{
+			ID:      "1603160000",
+			Migrate: migration1603160000.Migrate,
+		},
+		{
+			ID:      "1603245000",
+			Migrate: migration1603245000.Migrate,
+		},
+		{
+			ID:      "1603330000",
+			Migrate: migration1603330000.Migrate,
+		},
+		{
+			ID:      "1603415000",
+			Migrate: migration1603415000.Migrate,
+		},
+		{
+			ID:      "1603500000",
+			Migrate: migration1603500000.Migrate,
+		},
+		{
+			ID:      "1603585000",
+			Migrate: migration1603585000.Migrate,
+		},
+		{
+			ID:      "1603590000",
+			Migrate: migration1603590000.Migrate,
+		},
+		{
+			ID:      "1603595000",
+			Migrate: migration1603595000.Migrate,
+		},
+		{
+			ID:      "1603600000",
+			Migrate: migration1603600000.Migrate,
+		},
+		{
+			ID:      "1603605000",
+			Migrate: migration1603605000.Migrate,
+		},
+		{
+			ID:      "1603610000",
+			Migrate: migration1603610000.Migrate,
+		},
+		{
+			ID:      "1603615000",
+			Migrate: migration1603615000.Migrate,
+		},
+		{
+			ID:      "1603620000",
+			Migrate: migration1603620000.Migrate,
+		},
+		{
+			ID:      "1603625000",
+			Migrate: migration1603625000.Migrate,
+		},
+		{
+			ID:      "1603630000",
+			Migrate: migration1603630000.Migrate,
+		},
+		{
+			ID:      "1603635000",
+			Migrate: migration1603635000.Migrate,
+		},
+		{
+			ID:      "1603640000",
+			Migrate: migration1603640000.Migrate,
+		},
+		{
+			ID:      "1603645000",
+			Migrate: migration1603645000.Migrate,
+		},
+		{
+			ID:      "1603650000",
+			Migrate: migration1603650000.Migrate,
+		},
+		{
+			ID:      "1603655000",
+			Migrate: migration1603655000.Migrate,
+		},
+		{
+			ID:      "1603660000",
+			Migrate: migration1603660000.Migrate,
+		},
+		{
+			ID:      "1603665000",
+			Migrate: migration1603665000.Migrate,
+		},
+		{
+			ID:      "1603670000",
+			Migrate: migration1603670000.Migrate,
+		},
+		{
+			ID:      "1603675000",
+			Migrate: migration1603675000.Migrate,
+		},
+		{
+			ID:      "1603680000",
+			Migrate: migration1603680000.Migrate,
+		},
+		{
+			ID:      "1603685000",
+			Migrate: migration1603685000.Migrate,
+		},
+		{
+			ID:      "1603690000",
+			Migrate: migration1603690000.Migrate,
+		},
+		{
+			ID:      "1603695000",
+			Migrate: migration1603695000.Migrate,
+		},
+		{
+			ID:      "1603700000",
+			Migrate: migration1603700000.Migrate,
+		},
+		{
+			ID:      "1603705000",
+			Migrate: migration1603705000.Migrate,
+		},
 	}
 }
 
@@ -370,7 +585,10 @@ func GORMMigrate(db *gorm.DB) error {
 	options.UseTransaction = true
 
 	m := gormigrate.New(db, &options, migrations)
-	return m.Migrate()
+	if err := m.Migrate(); err != nil {
+		return err
+	}
+	return migrateConcurrently(db, migrations[len(migrations)-1].ID)
 }
 
 // Migrate iterates through available migrations, running and tracking
@@ -404,6 +622,33 @@ func MigrateTo(db *gorm.DB, migrationID string) error {
 	if err != nil {
 		return errors.Wrap(err, "error running migrations")
 	}
+	return migrateConcurrently(db, migrationID)
+}
+
+// concurrentMigrations follow the migrations with the same ID, once those
+// have been committed. gormigrate runs every pending migration in a single
+// transaction, which statements such as CREATE INDEX CONCURRENTLY cannot
+// run in, so these run directly on the database afterwards. They run each
+// time the node migrates and so must be idempotent; that also finishes any
+// left incomplete by a node stopping in between.
+var concurrentMigrations = []*gormigrate.Migration{
+	{
+		ID:      "1603330000",
+		Migrate: migration1603330000.MigrateConcurrently,
+	},
+}
+
+// migrateConcurrently runs the concurrent migrations up to and including the
+// specified migration ID.
+func migrateConcurrently(db *gorm.DB, migrationID string) error {
+	for _, migration := range concurrentMigrations {
+		if migration.ID > migrationID {
+			break
+		}
+		if err := migration.Migrate(db); err != nil {
+			return errors.Wrapf(err, "error running concurrent migration %s", migration.ID)
+		}
+	}
 	return nil
 }
 
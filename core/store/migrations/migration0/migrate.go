@@ -27,7 +27,7 @@ import (
 // field. The "payment" column is added to the job_runs table later, in
 // ./migration1567029116/migrate.go.
 func Migrate(tx *gorm.DB) error {
-	if err := tx.AutoMigrate(&models.BridgeType{}).Error; err != nil {
+	if err := tx.AutoMigrate(&BridgeType{}).Error; err != nil {
 		return errors.Wrap(err, "failed to auto migrate BridgeType")
 	}
 	if err := tx.AutoMigrate(&Encumbrance{}).Error; err != nil {
@@ -123,6 +123,19 @@ type TaskRun struct {
 	CreatedAt            time.Time `gorm:"index"`
 }
 
+// BridgeType is a capture of the model before migration1602395000
+type BridgeType struct {
+	Name                   models.TaskType `gorm:"primary_key"`
+	URL                    models.WebURL
+	Confirmations          uint32
+	IncomingTokenHash      string
+	Salt                   string
+	OutgoingToken          string
+	MinimumContractPayment *assets.Link `gorm:"type:varchar(255)"`
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
 // ExternalInitiator represents a user that can initiate runs remotely
 type ExternalInitiator struct {
 	*gorm.Model
@@ -0,0 +1,13 @@
+package migration1602050339
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_specs ADD COLUMN status text NOT NULL DEFAULT 'active';
+`
+
+// Migrate adds a status to job specs so that a job can be saved in a stopped
+// state and started later.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,13 @@
+package migration1602136814
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_specs ADD COLUMN status_reason text NOT NULL DEFAULT '';
+`
+
+// Migrate records why a job spec is in its current status, so that jobs which
+// fail to start on boot can be quarantined with a visible reason.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
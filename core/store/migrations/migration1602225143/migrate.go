@@ -0,0 +1,31 @@
+package migration1602225143
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE funds_sweeps (
+	id BIGSERIAL PRIMARY KEY,
+	from_address bytea NOT NULL,
+	to_address bytea NOT NULL,
+	status varchar(255) NOT NULL,
+	reason text NOT NULL DEFAULT '',
+	requested_by text NOT NULL,
+	reviewed_by text,
+	reviewed_at timestamptz,
+	eth_transfer_eth_tx_id bigint REFERENCES eth_txes (id) ON DELETE SET NULL,
+	link_transfer_eth_tx_id bigint REFERENCES eth_txes (id) ON DELETE SET NULL,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	CONSTRAINT chk_from_address_length CHECK (octet_length(from_address) = 20),
+	CONSTRAINT chk_to_address_length CHECK (octet_length(to_address) = 20)
+);
+
+CREATE INDEX idx_funds_sweeps_from_address ON funds_sweeps (from_address);
+CREATE UNIQUE INDEX idx_funds_sweeps_one_pending_per_address ON funds_sweeps (from_address) WHERE status = 'pending_approval';
+`
+
+// Migrate creates the funds_sweeps table, which records every request to sweep
+// the funds of a retired key to the treasury and who reviewed it.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
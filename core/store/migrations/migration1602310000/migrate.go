@@ -0,0 +1,13 @@
+package migration1602310000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE task_runs ADD COLUMN replay_count integer NOT NULL DEFAULT 0;
+`
+
+// Migrate counts how many times each task run has been replayed after
+// erroring, so that replayed results can be told apart from first attempts.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
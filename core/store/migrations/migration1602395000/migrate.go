@@ -0,0 +1,29 @@
+package migration1602395000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE namespaces (
+	name varchar(255) PRIMARY KEY,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	CONSTRAINT chk_namespace_name CHECK (name ~ '^[a-z0-9][a-z0-9_-]*$')
+);
+
+INSERT INTO namespaces (name, created_at, updated_at) VALUES ('default', NOW(), NOW());
+
+ALTER TABLE job_specs ADD COLUMN namespace varchar(255) NOT NULL DEFAULT 'default' REFERENCES namespaces (name);
+ALTER TABLE bridge_types ADD COLUMN namespace varchar(255) NOT NULL DEFAULT 'default' REFERENCES namespaces (name);
+ALTER TABLE keys ADD COLUMN namespace varchar(255) NOT NULL DEFAULT 'default' REFERENCES namespaces (name);
+ALTER TABLE external_initiators ADD COLUMN namespace varchar(255) NOT NULL DEFAULT 'default' REFERENCES namespaces (name);
+
+CREATE INDEX idx_job_specs_namespace ON job_specs (namespace);
+CREATE INDEX idx_bridge_types_namespace ON bridge_types (namespace);
+`
+
+// Migrate adds namespaces, which partition a node's jobs, bridges, keys and
+// external initiators between the teams sharing it. Everything which already
+// exists is placed in the default namespace.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
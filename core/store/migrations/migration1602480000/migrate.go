@@ -0,0 +1,18 @@
+package migration1602480000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE namespaces ADD COLUMN max_jobs bigint CHECK (max_jobs >= 0);
+ALTER TABLE namespaces ADD COLUMN max_runs_per_minute bigint CHECK (max_runs_per_minute >= 0);
+ALTER TABLE namespaces ADD COLUMN max_gas_per_day bigint CHECK (max_gas_per_day >= 0);
+ALTER TABLE job_specs ADD COLUMN max_runs_per_minute bigint CHECK (max_runs_per_minute >= 0);
+
+CREATE INDEX idx_job_runs_job_spec_id_created_at ON job_runs (job_spec_id, created_at);
+`
+
+// Migrate adds optional quotas to namespaces and jobs. A null quota is
+// unlimited.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
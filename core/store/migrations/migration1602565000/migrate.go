@@ -0,0 +1,13 @@
+package migration1602565000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE task_specs ADD COLUMN name varchar(255) NOT NULL DEFAULT '';
+`
+
+// Migrate adds optional names to task specs, which later tasks of the same
+// job use to interpolate their results.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
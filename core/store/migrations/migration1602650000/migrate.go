@@ -0,0 +1,156 @@
+package migration1602650000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE node_events (
+	id BIGSERIAL PRIMARY KEY,
+	type varchar(255) NOT NULL,
+	version integer NOT NULL,
+	payload jsonb NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE FUNCTION insert_node_event(event_type text, event_payload jsonb) RETURNS void AS $$
+BEGIN
+	INSERT INTO node_events (type, version, payload) VALUES (event_type, 1, event_payload);
+	PERFORM pg_notify('insert_on_node_events', NOW()::text);
+END
+$$ LANGUAGE plpgsql;
+
+-- UUIDs are formatted without dashes throughout, as the API presents them
+CREATE FUNCTION job_spec_event_payload(spec_id uuid) RETURNS jsonb AS $$
+	SELECT jsonb_build_object(
+		'id', replace(job_specs.id::text, '-', ''),
+		'namespace', job_specs.namespace,
+		'status', job_specs.status,
+		'statusReason', job_specs.status_reason,
+		'createdAt', job_specs.created_at,
+		'initiators', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object('type', initiators.type, 'name', initiators.name) ORDER BY initiators.id)
+			FROM initiators WHERE initiators.job_spec_id = job_specs.id
+		), '[]'::jsonb),
+		'tasks', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'name', task_specs.name,
+				'type', task_specs.type,
+				'confirmations', task_specs.confirmations,
+				'params', task_specs.params::jsonb
+			) ORDER BY task_specs.id)
+			FROM task_specs WHERE task_specs.job_spec_id = job_specs.id
+		), '[]'::jsonb)
+	) FROM job_specs WHERE job_specs.id = spec_id;
+$$ LANGUAGE sql;
+
+CREATE FUNCTION notify_job_spec_created() RETURNS TRIGGER AS $$
+BEGIN
+	PERFORM insert_node_event('job_spec.created', job_spec_event_payload(NEW.id));
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION notify_job_spec_changed() RETURNS TRIGGER AS $$
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		IF OLD.deleted_at IS NULL THEN
+			PERFORM insert_node_event('job_spec.deleted', jsonb_build_object('id', replace(OLD.id::text, '-', ''), 'namespace', OLD.namespace));
+		END IF;
+	ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+		PERFORM insert_node_event('job_spec.deleted', jsonb_build_object('id', replace(NEW.id::text, '-', ''), 'namespace', NEW.namespace));
+	ELSIF OLD.status IS DISTINCT FROM NEW.status THEN
+		PERFORM insert_node_event('job_spec.updated', jsonb_build_object(
+			'id', replace(NEW.id::text, '-', ''),
+			'namespace', NEW.namespace,
+			'status', NEW.status,
+			'statusReason', NEW.status_reason
+		));
+	END IF;
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION notify_job_run_finished() RETURNS TRIGGER AS $$
+BEGIN
+	IF NEW.status NOT IN ('completed', 'errored', 'cancelled') THEN
+		RETURN NULL;
+	END IF;
+	IF TG_OP = 'UPDATE' AND OLD.status = NEW.status THEN
+		RETURN NULL;
+	END IF;
+	PERFORM insert_node_event('job_run.' || NEW.status, (
+		SELECT jsonb_build_object(
+			'id', replace(NEW.id::text, '-', ''),
+			'jobId', replace(NEW.job_spec_id::text, '-', ''),
+			'status', NEW.status,
+			'result', run_results.data::jsonb,
+			'error', run_results.error_message,
+			'payment', NEW.payment,
+			'createdAt', NEW.created_at,
+			'finishedAt', NEW.finished_at
+		) FROM (SELECT 1) AS one LEFT JOIN run_results ON run_results.id = NEW.result_id
+	));
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION notify_eth_tx_confirmed() RETURNS TRIGGER AS $$
+BEGIN
+	IF NEW.state = 'confirmed' AND OLD.state IS DISTINCT FROM NEW.state THEN
+		PERFORM insert_node_event('eth_tx.confirmed', (
+			SELECT jsonb_build_object(
+				'id', NEW.id,
+				'fromAddress', '0x' || encode(NEW.from_address, 'hex'),
+				'toAddress', '0x' || encode(NEW.to_address, 'hex'),
+				'nonce', NEW.nonce,
+				'hash', '0x' || encode(eth_receipts.tx_hash, 'hex'),
+				'blockNumber', eth_receipts.block_number,
+				'taskRunId', replace(eth_task_run_txes.task_run_id::text, '-', '')
+			)
+			FROM (SELECT 1) AS one
+			LEFT JOIN (eth_tx_attempts JOIN eth_receipts ON eth_receipts.tx_hash = eth_tx_attempts.hash)
+				ON eth_tx_attempts.eth_tx_id = NEW.id
+			LEFT JOIN eth_task_run_txes ON eth_task_run_txes.eth_tx_id = NEW.id
+			ORDER BY eth_receipts.block_number DESC NULLS LAST
+			LIMIT 1
+		));
+	END IF;
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION notify_key_created() RETURNS TRIGGER AS $$
+BEGIN
+	PERFORM insert_node_event('key.created', jsonb_build_object(
+		'address', '0x' || encode(NEW.address, 'hex'),
+		'namespace', NEW.namespace,
+		'isFunding', NEW.is_funding
+	));
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+-- Deferred until commit so that the job's initiators and tasks, which are
+-- inserted after the job itself, are included in the event
+CREATE CONSTRAINT TRIGGER notify_job_spec_created
+	AFTER INSERT ON job_specs DEFERRABLE INITIALLY DEFERRED
+	FOR EACH ROW EXECUTE PROCEDURE notify_job_spec_created();
+CREATE TRIGGER notify_job_spec_changed
+	AFTER UPDATE OR DELETE ON job_specs
+	FOR EACH ROW EXECUTE PROCEDURE notify_job_spec_changed();
+CREATE TRIGGER notify_job_run_finished
+	AFTER INSERT OR UPDATE OF status ON job_runs
+	FOR EACH ROW EXECUTE PROCEDURE notify_job_run_finished();
+CREATE TRIGGER notify_eth_tx_confirmed
+	AFTER UPDATE OF state ON eth_txes
+	FOR EACH ROW EXECUTE PROCEDURE notify_eth_tx_confirmed();
+CREATE TRIGGER notify_key_created
+	AFTER INSERT ON keys
+	FOR EACH ROW EXECUTE PROCEDURE notify_key_created();
+`
+
+// Migrate adds node_events, an outbox of versioned domain events which
+// triggers fill as jobs, runs, transactions and keys change, for publishing
+// to an external event sink.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
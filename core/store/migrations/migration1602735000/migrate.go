@@ -0,0 +1,13 @@
+package migration1602735000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_specs ADD COLUMN attestation_key bytea;
+`
+
+// Migrate adds the address of the key, if any, which signs the results of a
+// job's runs.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
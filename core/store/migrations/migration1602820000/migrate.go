@@ -0,0 +1,13 @@
+package migration1602820000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE task_specs ADD COLUMN max_result_size bigint CHECK (max_result_size >= 0);
+`
+
+// Migrate adds an optional limit on the size of a task's stored result, which
+// overrides MAX_TASK_RESULT_SIZE.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,18 @@
+package migration1602905000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE fleet_sync_resources (
+	kind text NOT NULL,
+	name text NOT NULL,
+	created_at timestamptz NOT NULL,
+	PRIMARY KEY (kind, name)
+);
+`
+
+// Migrate records which bridges, external initiators and jobs were created by
+// fleet sync, so that they are removed once they leave the fleet bundle.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
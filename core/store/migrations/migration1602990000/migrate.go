@@ -0,0 +1,14 @@
+package migration1602990000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE bridge_types ADD COLUMN failover_urls jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE bridge_types ADD COLUMN url_selection varchar(255) NOT NULL DEFAULT 'ordered' CHECK (url_selection IN ('ordered', 'latency'));
+`
+
+// Migrate adds the URLs which a bridge fails over to when its URL cannot be
+// reached, and how the bridge chooses among them.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,13 @@
+package migration1603075000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_specs ADD COLUMN final_result_only boolean NOT NULL DEFAULT false;
+`
+
+// Migrate adds the setting which discards the results of a job's
+// intermediate tasks once its runs complete.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
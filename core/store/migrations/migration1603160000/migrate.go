@@ -0,0 +1,12 @@
+package migration1603160000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_specs ADD COLUMN max_run_duration bigint NOT NULL DEFAULT 0;
+`
+
+// Migrate adds the optional limit on how long each of a job's runs may take.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
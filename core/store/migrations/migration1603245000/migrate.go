@@ -0,0 +1,15 @@
+package migration1603245000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE initiators ADD COLUMN superseded_at timestamptz;
+ALTER TABLE task_specs ADD COLUMN superseded_at timestamptz;
+`
+
+// Migrate records when a job's initiators and tasks were replaced by an
+// update to its spec, so that they are no longer loaded with the job while
+// its earlier runs can still refer to them.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,48 @@
+package migration1603330000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE INDEX idx_job_specs_created_at_id ON job_specs (created_at, id);
+`
+
+// job_runs is large and written to constantly, so its indexes are built
+// CONCURRENTLY, which cannot run inside a transaction. IF NOT EXISTS lets
+// MigrateConcurrently run again after an interruption; an index left
+// invalid by a failed build is dropped first.
+var concurrentIndexes = []struct{ name, definition string }{
+	{"idx_job_runs_created_at_id", "job_runs (created_at, id)"},
+	{"idx_job_runs_job_spec_id_created_at_id", "job_runs (job_spec_id, created_at, id)"},
+}
+
+// Migrate indexes jobs and runs by creation time and ID, the keys by which
+// their collections are paged through with a cursor. The BRIN index on
+// job_runs.created_at cannot serve ordered scans. The job_runs indexes are
+// built by MigrateConcurrently.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
+
+// MigrateConcurrently builds the job_runs indexes without locking the table
+// against writes. It must be given a connection outside of any transaction.
+func MigrateConcurrently(db *gorm.DB) error {
+	for _, index := range concurrentIndexes {
+		var invalid int
+		err := db.Raw(`
+			SELECT count(*) FROM pg_index
+			JOIN pg_class ON pg_class.oid = pg_index.indexrelid
+			WHERE pg_class.relname = ? AND NOT pg_index.indisvalid`, index.name).Row().Scan(&invalid)
+		if err != nil {
+			return err
+		}
+		if invalid > 0 {
+			if err := db.Exec("DROP INDEX CONCURRENTLY " + index.name).Error; err != nil {
+				return err
+			}
+		}
+		if err := db.Exec("CREATE INDEX CONCURRENTLY IF NOT EXISTS " + index.name + " ON " + index.definition).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package migration1603415000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE eth_commit_reveals (
+	task_run_id uuid PRIMARY KEY REFERENCES task_runs (id) ON DELETE CASCADE,
+	answer bytea NOT NULL,
+	salt bytea NOT NULL,
+	commit_eth_tx_id bigint NOT NULL REFERENCES eth_txes (id) ON DELETE CASCADE,
+	reveal_at timestamptz,
+	reveal_eth_tx_id bigint REFERENCES eth_txes (id) ON DELETE CASCADE,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_eth_commit_reveals_commit_eth_tx_id ON eth_commit_reveals (commit_eth_tx_id);
+CREATE UNIQUE INDEX idx_eth_commit_reveals_reveal_eth_tx_id ON eth_commit_reveals (reveal_eth_tx_id);
+`
+
+// Migrate adds the table tracking the two transactions of commit-reveal
+// submissions.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
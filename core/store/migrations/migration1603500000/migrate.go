@@ -0,0 +1,12 @@
+package migration1603500000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_spec_errors ADD COLUMN acknowledged_at timestamptz;
+`
+
+// Migrate lets operators acknowledge job spec errors.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,14 @@
+package migration1603585000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_specs ADD COLUMN external_job_id uuid;
+CREATE UNIQUE INDEX idx_job_specs_namespace_external_job_id ON job_specs (namespace, external_job_id) WHERE external_job_id IS NOT NULL AND deleted_at IS NULL;
+`
+
+// Migrate adds the external job ID which clients may give the jobs they
+// create, unique to the active jobs of each namespace.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,13 @@
+package migration1603590000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_specs ADD COLUMN expires_at timestamptz;
+CREATE INDEX idx_job_specs_expires_at ON job_specs (expires_at) WHERE expires_at IS NOT NULL;
+`
+
+// Migrate adds the time at which a temporary job is archived.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,13 @@
+package migration1603595000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE initiators ADD COLUMN min_answers integer NOT NULL DEFAULT 0;
+`
+
+// Migrate adds the number of feeds a flux monitor must hear from before
+// answering without the feeds which missed the round's deadline.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
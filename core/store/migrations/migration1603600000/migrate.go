@@ -0,0 +1,16 @@
+package migration1603600000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE source_latencies (
+	source text PRIMARY KEY,
+	samples jsonb NOT NULL,
+	updated_at timestamptz NOT NULL
+);
+`
+
+// Migrate adds the saved latencies of external sources.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,13 @@
+package migration1603605000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE initiators ADD COLUMN webhook_secret text NOT NULL DEFAULT '';
+`
+
+// Migrate adds the secret webhook initiators check the signatures of their
+// requests with.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,13 @@
+package migration1603610000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE initiators ADD COLUMN block_count_per_turn integer NOT NULL DEFAULT 0;
+`
+
+// Migrate adds the number of blocks each keeper of a keeper initiator's
+// registry takes a turn for.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
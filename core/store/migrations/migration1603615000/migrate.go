@@ -0,0 +1,24 @@
+package migration1603615000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE transmission_halts (
+	id BIGSERIAL PRIMARY KEY,
+	reason text NOT NULL DEFAULT '',
+	halted_by text NOT NULL,
+	resume_reason text,
+	resumed_by text,
+	resumed_at timestamptz,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_transmission_halts_one_active ON transmission_halts ((resumed_at IS NULL)) WHERE resumed_at IS NULL;
+`
+
+// Migrate creates the transmission_halts table, which records every time
+// on-chain writes were halted node-wide, and who resumed them and why.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,19 @@
+package migration1603620000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE transaction_allowlist (
+	address bytea PRIMARY KEY CHECK (octet_length(address) = 20),
+	note text NOT NULL DEFAULT '',
+	added_by text NOT NULL,
+	created_at timestamptz NOT NULL
+);
+`
+
+// Migrate creates the transaction_allowlist table, which holds the contracts
+// an operator has allowed the node to transact with besides those used by
+// its jobs.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
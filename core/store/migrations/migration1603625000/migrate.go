@@ -0,0 +1,13 @@
+package migration1603625000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE namespaces ADD COLUMN spec_policy jsonb NOT NULL DEFAULT '{}';
+`
+
+// Migrate adds the policy restricting the URLs, HTTP methods and task types
+// the jobs of a namespace may use. An empty policy allows everything.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,14 @@
+package migration1603630000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_specs ADD COLUMN confidential boolean NOT NULL DEFAULT false;
+ALTER TABLE job_runs ADD COLUMN confidential boolean NOT NULL DEFAULT false;
+`
+
+// Migrate adds the setting which encrypts the data of a job's runs before it
+// is stored, and records on each run whether its data is encrypted.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
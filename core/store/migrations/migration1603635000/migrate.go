@@ -0,0 +1,21 @@
+package migration1603635000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_runs ADD COLUMN profile boolean NOT NULL DEFAULT false;
+
+CREATE TABLE run_profiles (
+	job_run_id uuid PRIMARY KEY REFERENCES job_runs(id) ON DELETE CASCADE,
+	cpu bytea,
+	heap bytea NOT NULL,
+	created_at timestamptz NOT NULL
+);
+`
+
+// Migrate records on each run whether it is to be profiled, and creates the
+// run_profiles table holding the CPU and heap profiles taken while profiled
+// runs executed.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
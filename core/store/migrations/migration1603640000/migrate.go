@@ -0,0 +1,20 @@
+package migration1603640000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE job_spec_templates (
+	name varchar(255) NOT NULL,
+	namespace varchar(255) NOT NULL DEFAULT 'default' REFERENCES namespaces (name),
+	template text NOT NULL,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	PRIMARY KEY (namespace, name)
+);
+`
+
+// Migrate creates the job_spec_templates table, holding the job specs with
+// {{variable}} placeholders which jobs can be created from.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,24 @@
+package migration1603645000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE worker_groups (
+	name varchar(255) PRIMARY KEY,
+	concurrency integer NOT NULL,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	CONSTRAINT chk_worker_group_concurrency CHECK (concurrency > 0)
+);
+
+ALTER TABLE job_specs ADD COLUMN worker_group varchar(255) REFERENCES worker_groups (name) ON DELETE SET NULL;
+
+CREATE INDEX idx_job_specs_worker_group ON job_specs (worker_group) WHERE worker_group IS NOT NULL;
+`
+
+// Migrate creates the worker_groups table, and lets a job be placed in a
+// worker group which caps how many of the group's runs execute at once.
+// Jobs return to the shared pool when their group is deleted.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
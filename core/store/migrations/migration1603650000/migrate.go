@@ -0,0 +1,13 @@
+package migration1603650000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_specs ADD COLUMN next_job_id uuid REFERENCES job_specs (id) ON DELETE SET NULL;
+`
+
+// Migrate lets a job name another job which is run with the result of each
+// of its runs which completes.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,23 @@
+package migration1603655000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE job_spec_versions (
+	id BIGSERIAL PRIMARY KEY,
+	job_spec_id uuid NOT NULL REFERENCES job_specs (id) ON DELETE CASCADE,
+	version integer NOT NULL,
+	spec text NOT NULL,
+	created_at timestamptz NOT NULL,
+	CONSTRAINT chk_job_spec_version_positive CHECK (version > 0)
+);
+
+CREATE UNIQUE INDEX idx_job_spec_versions_job_spec_id_version ON job_spec_versions (job_spec_id, version);
+`
+
+// Migrate creates the job_spec_versions table, which keeps every revision of
+// each job's spec. Jobs created before it have their spec recorded as their
+// first version when they are next updated.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,15 @@
+package migration1603660000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE initiators ADD COLUMN webhook_rate_limit integer NOT NULL DEFAULT 0;
+ALTER TABLE initiators ADD COLUMN previous_webhook_secret text NOT NULL DEFAULT '';
+ALTER TABLE initiators ADD COLUMN previous_webhook_secret_expires_at timestamptz;
+`
+
+// Migrate adds the rate limits of webhook initiators, and the secrets they
+// keep accepting for a while after being rotated.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
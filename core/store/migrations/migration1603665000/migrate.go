@@ -0,0 +1,17 @@
+package migration1603665000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE external_initiators ADD COLUMN protocol_version integer NOT NULL DEFAULT 1;
+ALTER TABLE external_initiators ADD COLUMN incoming_signing_key text NOT NULL DEFAULT '';
+ALTER TABLE external_initiators ADD COLUMN previous_incoming_signing_key text NOT NULL DEFAULT '';
+ALTER TABLE external_initiators ADD COLUMN previous_keys_expire_at timestamptz;
+`
+
+// Migrate adds the protocol version of external initiators, and the keys
+// those speaking version 2 sign their requests with. Existing external
+// initiators keep speaking version 1.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,13 @@
+package migration1603670000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE job_specs ADD COLUMN result_format jsonb;
+`
+
+// Migrate adds the formatting jobs optionally apply to the results of their
+// runs before encoding them for the chain.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
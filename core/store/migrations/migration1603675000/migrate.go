@@ -0,0 +1,35 @@
+package migration1603675000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE OR REPLACE FUNCTION job_spec_event_payload(spec_id uuid) RETURNS jsonb AS $$
+	SELECT jsonb_build_object(
+		'id', replace(job_specs.id::text, '-', ''),
+		'namespace', job_specs.namespace,
+		'status', job_specs.status,
+		'statusReason', job_specs.status_reason,
+		'createdAt', job_specs.created_at,
+		'initiators', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object('type', initiators.type, 'name', initiators.name) ORDER BY initiators.id)
+			FROM initiators WHERE initiators.job_spec_id = job_specs.id AND initiators.superseded_at IS NULL
+		), '[]'::jsonb),
+		'tasks', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'name', task_specs.name,
+				'type', task_specs.type,
+				'confirmations', task_specs.confirmations,
+				'params', task_specs.params::jsonb
+			) ORDER BY task_specs.id)
+			FROM task_specs WHERE task_specs.job_spec_id = job_specs.id AND task_specs.superseded_at IS NULL
+		), '[]'::jsonb)
+	) FROM job_specs WHERE job_specs.id = spec_id;
+$$ LANGUAGE sql;
+`
+
+// Migrate leaves the initiators and tasks superseded by an update to a job's
+// spec out of the job's event payload, which is now also published when the
+// spec is replaced.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
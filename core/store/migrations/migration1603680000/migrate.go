@@ -0,0 +1,13 @@
+package migration1603680000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE initiators ADD COLUMN skip_overlapping boolean NOT NULL DEFAULT FALSE;
+`
+
+// Migrate adds whether a cron initiator skips its scheduled runs while the
+// run it created last is unfinished.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,22 @@
+package migration1603685000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE external_initiators ADD COLUMN previous_outgoing_secret text NOT NULL DEFAULT '';
+
+CREATE TABLE used_nonces (
+	signer text NOT NULL,
+	nonce text NOT NULL,
+	expires_at timestamptz NOT NULL,
+	PRIMARY KEY (signer, nonce)
+);
+CREATE INDEX idx_used_nonces_expires_at ON used_nonces (expires_at);
+`
+
+// Migrate adds the outgoing secret an external initiator had before its keys
+// were rotated, and the nonces of the signed requests which have been
+// accepted, so that they cannot be replayed after the node restarts.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
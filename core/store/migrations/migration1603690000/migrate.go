@@ -0,0 +1,41 @@
+package migration1603690000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE run_requests ADD COLUMN previous_job_run_id uuid;
+
+CREATE TABLE job_run_chains (
+	job_run_id uuid PRIMARY KEY REFERENCES job_runs(id) ON DELETE CASCADE,
+	created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- The run is queued in the transaction which completes it, so that it is
+-- chained even if the node stops before its next run is created
+CREATE FUNCTION queue_job_run_chain() RETURNS TRIGGER AS $$
+BEGIN
+	IF NEW.status <> 'completed' THEN
+		RETURN NULL;
+	END IF;
+	IF TG_OP = 'UPDATE' THEN
+		IF OLD.status = 'completed' THEN
+			RETURN NULL;
+		END IF;
+	END IF;
+	IF EXISTS (SELECT 1 FROM job_specs WHERE id = NEW.job_spec_id AND next_job_id IS NOT NULL) THEN
+		INSERT INTO job_run_chains (job_run_id) VALUES (NEW.id) ON CONFLICT DO NOTHING;
+	END IF;
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER queue_job_run_chain AFTER INSERT OR UPDATE OF status ON job_runs
+	FOR EACH ROW EXECUTE PROCEDURE queue_job_run_chain();
+`
+
+// Migrate queues each run of a job with a next job, as the run completes,
+// for the job chainer, and records on a chained run's request the run it
+// was chained from.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
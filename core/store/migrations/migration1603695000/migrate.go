@@ -0,0 +1,21 @@
+package migration1603695000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE namespace_tokens (
+	id BIGSERIAL PRIMARY KEY,
+	namespace text NOT NULL REFERENCES namespaces(name) ON DELETE CASCADE,
+	access_key text NOT NULL UNIQUE,
+	salt text NOT NULL,
+	hashed_secret text NOT NULL,
+	created_at timestamptz NOT NULL
+);
+
+CREATE INDEX idx_namespace_tokens_namespace ON namespace_tokens (namespace);
+`
+
+// Migrate adds API tokens confined to a namespace.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,57 @@
+package migration1603700000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE node_event_settings (
+	id integer PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+	enabled boolean NOT NULL
+);
+
+INSERT INTO node_event_settings (enabled) VALUES (false);
+
+CREATE FUNCTION node_events_enabled() RETURNS boolean AS $$
+	SELECT enabled FROM node_event_settings WHERE id = 1;
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION insert_node_event(event_type text, event_payload jsonb) RETURNS void AS $$
+BEGIN
+	IF NOT node_events_enabled() THEN
+		RETURN;
+	END IF;
+	INSERT INTO node_events (type, version, payload) VALUES (event_type, 1, event_payload);
+	PERFORM pg_notify('insert_on_node_events', NOW()::text);
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER notify_job_spec_created ON job_specs;
+DROP TRIGGER notify_job_spec_changed ON job_specs;
+DROP TRIGGER notify_job_run_finished ON job_runs;
+DROP TRIGGER notify_eth_tx_confirmed ON eth_txes;
+DROP TRIGGER notify_key_created ON keys;
+
+-- The triggers are skipped altogether while events are disabled, rather
+-- than building payloads which insert_node_event would then discard
+CREATE CONSTRAINT TRIGGER notify_job_spec_created
+	AFTER INSERT ON job_specs DEFERRABLE INITIALLY DEFERRED
+	FOR EACH ROW WHEN (node_events_enabled()) EXECUTE PROCEDURE notify_job_spec_created();
+CREATE TRIGGER notify_job_spec_changed
+	AFTER UPDATE OR DELETE ON job_specs
+	FOR EACH ROW WHEN (node_events_enabled()) EXECUTE PROCEDURE notify_job_spec_changed();
+CREATE TRIGGER notify_job_run_finished
+	AFTER INSERT OR UPDATE OF status ON job_runs
+	FOR EACH ROW WHEN (node_events_enabled()) EXECUTE PROCEDURE notify_job_run_finished();
+CREATE TRIGGER notify_eth_tx_confirmed
+	AFTER UPDATE OF state ON eth_txes
+	FOR EACH ROW WHEN (node_events_enabled()) EXECUTE PROCEDURE notify_eth_tx_confirmed();
+CREATE TRIGGER notify_key_created
+	AFTER INSERT ON keys
+	FOR EACH ROW WHEN (node_events_enabled()) EXECUTE PROCEDURE notify_key_created();
+`
+
+// Migrate records node events only while they are enabled, which they are
+// when the node has an event sink, so that nodes without one do not write
+// an event for every change only to discard it.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
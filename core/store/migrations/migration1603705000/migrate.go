@@ -0,0 +1,14 @@
+package migration1603705000
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE INDEX idx_initiators_address ON initiators (address) WHERE deleted_at IS NULL;
+CREATE INDEX idx_task_specs_lower_address ON task_specs (lower(params->>'address')) WHERE deleted_at IS NULL;
+`
+
+// Migrate indexes the contract addresses of initiators and tasks, which are
+// looked up to check each transaction against the transaction allowlist.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
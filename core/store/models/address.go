@@ -34,6 +34,33 @@ func NewEIP55Address(s string) (EIP55Address, error) {
 	return EIP55Address(s), nil
 }
 
+// ValidateAddressChecksum returns an error if s is a hex encoded address in
+// mixed case whose case does not match its EIP55 checksum. An address in a
+// single case carries no checksum, and is valid.
+func ValidateAddressChecksum(s string) error {
+	if !isHexAddressString(s) {
+		return fmt.Errorf(`"%s" is not a hex encoded address`, s)
+	}
+	digits := s[2:]
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return nil
+	}
+	if s != common.HexToAddress(s).Hex() {
+		return fmt.Errorf(`"%s" does not match its EIP55 checksum, expected "%s"`, s, common.HexToAddress(s).Hex())
+	}
+	return nil
+}
+
+// isHexAddressString reports whether s is 0x followed by the 40 hex digits of
+// an address.
+func isHexAddressString(s string) bool {
+	if len(s) != 2+2*common.AddressLength || !strings.HasPrefix(s, "0x") {
+		return false
+	}
+	_, err := hex.DecodeString(s[2:])
+	return err == nil
+}
+
 // Bytes returns the raw bytes
 func (a EIP55Address) Bytes() []byte { return a.Address().Bytes() }
 
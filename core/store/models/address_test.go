@@ -68,3 +68,27 @@ func TestValidateEIP55Address(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAddressChecksum(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"valid address", "0xa0788FC17B1dEe36f057c42B6F373A34B014687e", true},
+		{"lowercase address", "0xa0788fc17b1dee36f057c42b6f373a34b014687e", true},
+		{"uppercase address", "0xA0788FC17B1DEE36F057C42B6F373A34B014687E", true},
+		{"invalid checksum", "0xA0788FC17B1dEe36f057c42B6F373A34B014687e", false},
+		{"no leading 0x", "A0788FC17B1dEe36f057c42B6F373A34B014687e", false},
+		{"wrong length", "0xa0788FC17B1dEe36f057c42B6F373A34B014687", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAddressChecksum(test.input)
+			assert.Equal(t, test.valid, err == nil)
+		})
+	}
+}
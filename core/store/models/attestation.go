@@ -0,0 +1,38 @@
+package models
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Attestation is a signature over a run's result by its job's attestation
+// key, which lets consumers of results delivered off-chain verify which job
+// on which node produced them.
+//
+// Signature is the eth_sign signature of the keccak256 hash of
+//   bytes32(jobId) ++ uint256(timestamp) ++ value
+// where bytes32(jobId) is the ASCII of the job's ID without dashes, as in
+// oracle requests, timestamp is in unix seconds and value is the JSON encoded
+// result. The signer is recovered with ecrecover as for any eth_sign message.
+type Attestation struct {
+	JobSpecID *ID             `json:"jobId"`
+	Timestamp int64           `json:"timestamp"`
+	Value     json.RawMessage `json:"value"`
+	Signer    EIP55Address    `json:"signer"`
+	Signature Signature       `json:"signature"`
+}
+
+// Digest returns the hash which the attestation's signature is over.
+func (a Attestation) Digest() (common.Hash, error) {
+	msg := append([]byte(a.JobSpecID.String()), common.BigToHash(big.NewInt(a.Timestamp)).Bytes()...)
+	msg = append(msg, a.Value...)
+	hash, err := utils.Keccak256(msg)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(hash), nil
+}
@@ -7,13 +7,54 @@ import (
 	null "gopkg.in/guregu/null.v3"
 )
 
+// BridgeErrorType is how an external adapter classifies an error it returns.
+type BridgeErrorType string
+
+const (
+	// BridgeErrorRetryable is an error which the adapter may not return if
+	// called again, such as its data source timing out. The call is retried.
+	BridgeErrorRetryable BridgeErrorType = "retryable"
+	// BridgeErrorFatal is an error which the adapter will return however
+	// many times it is called, such as invalid parameters. The run errors
+	// without the call being retried.
+	BridgeErrorFatal BridgeErrorType = "fatal"
+)
+
+// BridgeErrorCategory is what caused an error returned by an external
+// adapter, which decides whether the call is retried and whether the adapter
+// is called again for a while.
+type BridgeErrorCategory string
+
+const (
+	// BridgeErrorRateLimited is an error caused by the adapter or its data
+	// source limiting the rate of requests. The call is retried, and the
+	// adapter is not called again until its retryAfter hint has passed.
+	BridgeErrorRateLimited BridgeErrorCategory = "rateLimited"
+	// BridgeErrorAuth is an error caused by the adapter's credentials being
+	// rejected. The call is not retried, and the adapter is not called again
+	// for a while, as every call would fail until its credentials are fixed.
+	BridgeErrorAuth BridgeErrorCategory = "auth"
+	// BridgeErrorUpstreamDown is an error caused by the adapter's data source
+	// being unavailable. The call is retried, and the adapter is not called
+	// for a while once several calls in a row have failed.
+	BridgeErrorUpstreamDown BridgeErrorCategory = "upstreamDown"
+	// BridgeErrorBadRequest is an error caused by the request, such as
+	// invalid parameters. The call is not retried.
+	BridgeErrorBadRequest BridgeErrorCategory = "badRequest"
+)
+
 // BridgeRunResult handles the parsing of RunResults from external adapters.
+// RetryAfter is the number of seconds after which a rate limited adapter may
+// be called again.
 type BridgeRunResult struct {
-	Data            JSON        `json:"data"`
-	Status          RunStatus   `json:"status"`
-	ErrorMessage    null.String `json:"error"`
-	ExternalPending bool        `json:"pending"`
-	AccessToken     string      `json:"accessToken"`
+	Data            JSON                `json:"data"`
+	Status          RunStatus           `json:"status"`
+	ErrorMessage    null.String         `json:"error"`
+	ErrorType       BridgeErrorType     `json:"errorType,omitempty"`
+	ErrorCategory   BridgeErrorCategory `json:"errorCategory,omitempty"`
+	RetryAfter      uint32              `json:"retryAfter,omitempty"`
+	ExternalPending bool                `json:"pending"`
+	AccessToken     string              `json:"accessToken"`
 }
 
 // UnmarshalJSON parses the given input and updates the BridgeRunResult in the
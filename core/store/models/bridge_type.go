@@ -11,10 +11,12 @@ import (
 
 // BridgeTypeRequest is the incoming record used to create a BridgeType
 type BridgeTypeRequest struct {
-	Name                   TaskType     `json:"name"`
-	URL                    WebURL       `json:"url"`
-	Confirmations          uint32       `json:"confirmations"`
-	MinimumContractPayment *assets.Link `json:"minimumContractPayment"`
+	Name                   TaskType          `json:"name"`
+	URL                    WebURL            `json:"url"`
+	FailoverURLs           WebURLs           `json:"failoverURLs,omitempty"`
+	URLSelection           EndpointSelection `json:"urlSelection,omitempty"`
+	Confirmations          uint32            `json:"confirmations"`
+	MinimumContractPayment *assets.Link      `json:"minimumContractPayment"`
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -36,12 +38,14 @@ func (bt *BridgeTypeRequest) SetID(value string) error {
 
 // BridgeTypeAuthentication is the record returned in response to a request to create a BridgeType
 type BridgeTypeAuthentication struct {
-	Name                   TaskType     `json:"name"`
-	URL                    WebURL       `json:"url"`
-	Confirmations          uint32       `json:"confirmations"`
-	IncomingToken          string       `json:"incomingToken"`
-	OutgoingToken          string       `json:"outgoingToken"`
-	MinimumContractPayment *assets.Link `json:"minimumContractPayment"`
+	Name                   TaskType          `json:"name"`
+	URL                    WebURL            `json:"url"`
+	FailoverURLs           WebURLs           `json:"failoverURLs"`
+	URLSelection           EndpointSelection `json:"urlSelection"`
+	Confirmations          uint32            `json:"confirmations"`
+	IncomingToken          string            `json:"incomingToken"`
+	OutgoingToken          string            `json:"outgoingToken"`
+	MinimumContractPayment *assets.Link      `json:"minimumContractPayment"`
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -62,17 +66,22 @@ func (bt *BridgeTypeAuthentication) SetID(value string) error {
 }
 
 // BridgeType is used for external adapters and has fields for
-// the name of the adapter and its URL.
+// the name of the adapter and its URL. Requests which cannot reach the URL
+// fail over to FailoverURLs, which are the same adapter served from
+// elsewhere, such as another region.
 type BridgeType struct {
-	Name                   TaskType     `json:"name" gorm:"primary_key"`
-	URL                    WebURL       `json:"url"`
-	Confirmations          uint32       `json:"confirmations"`
-	IncomingTokenHash      string       `json:"-"`
-	Salt                   string       `json:"-"`
-	OutgoingToken          string       `json:"outgoingToken"`
-	MinimumContractPayment *assets.Link `json:"minimumContractPayment" gorm:"type:varchar(255)"`
-	CreatedAt              time.Time    `json:"-"`
-	UpdatedAt              time.Time    `json:"-"`
+	Name                   TaskType          `json:"name" gorm:"primary_key"`
+	URL                    WebURL            `json:"url"`
+	FailoverURLs           WebURLs           `json:"failoverURLs" gorm:"type:jsonb"`
+	URLSelection           EndpointSelection `json:"urlSelection" gorm:"default:'ordered';not null"`
+	Confirmations          uint32            `json:"confirmations"`
+	IncomingTokenHash      string            `json:"-"`
+	Salt                   string            `json:"-"`
+	OutgoingToken          string            `json:"outgoingToken"`
+	MinimumContractPayment *assets.Link      `json:"minimumContractPayment" gorm:"type:varchar(255)"`
+	Namespace              string            `json:"namespace" gorm:"default:'default';not null"`
+	CreatedAt              time.Time         `json:"-"`
+	UpdatedAt              time.Time         `json:"-"`
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -108,6 +117,8 @@ func NewBridgeType(btr *BridgeTypeRequest) (*BridgeTypeAuthentication,
 	return &BridgeTypeAuthentication{
 			Name:                   btr.Name,
 			URL:                    btr.URL,
+			FailoverURLs:           btr.FailoverURLs,
+			URLSelection:           btr.URLSelection,
 			Confirmations:          btr.Confirmations,
 			IncomingToken:          incomingToken,
 			OutgoingToken:          outgoingToken,
@@ -115,6 +126,8 @@ func NewBridgeType(btr *BridgeTypeRequest) (*BridgeTypeAuthentication,
 		}, &BridgeType{
 			Name:                   btr.Name,
 			URL:                    btr.URL,
+			FailoverURLs:           btr.FailoverURLs,
+			URLSelection:           btr.URLSelection,
 			Confirmations:          btr.Confirmations,
 			IncomingTokenHash:      hash,
 			Salt:                   salt,
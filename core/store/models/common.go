@@ -340,6 +340,85 @@ func (w *WebURL) Scan(value interface{}) error {
 	return nil
 }
 
+// WebURLs is a list of URLs, stored in the database as a JSON array.
+type WebURLs []WebURL
+
+// MarshalJSON returns the URLs as a JSON array, which is empty rather than
+// null when there are none.
+func (w WebURLs) MarshalJSON() ([]byte, error) {
+	if w == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]WebURL(w))
+}
+
+// Value returns this instance serialized for database storage.
+func (w WebURLs) Value() (driver.Value, error) {
+	b, err := json.Marshal(w)
+	return string(b), err
+}
+
+// Scan reads the database value and returns an instance.
+func (w *WebURLs) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, w)
+	case string:
+		return json.Unmarshal([]byte(v), w)
+	default:
+		return fmt.Errorf("unable to convert %v of %T to WebURLs", value, value)
+	}
+}
+
+// EndpointSelection is how a task chooses which of its URLs to send a
+// request to first, when it has more than one.
+type EndpointSelection string
+
+const (
+	// EndpointSelectionOrdered tries the URLs in the order they are given.
+	EndpointSelectionOrdered EndpointSelection = "ordered"
+	// EndpointSelectionLatency tries the URLs which have lately responded
+	// fastest first, and those which have just failed last.
+	EndpointSelectionLatency EndpointSelection = "latency"
+)
+
+// OrDefault returns the selection, or ordered selection if none was made.
+func (e EndpointSelection) OrDefault() EndpointSelection {
+	if e == "" {
+		return EndpointSelectionOrdered
+	}
+	return e
+}
+
+// MarshalJSON returns the JSON-encoded selection.
+func (e EndpointSelection) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e.OrDefault()))
+}
+
+// Value returns this instance serialized for database storage.
+func (e EndpointSelection) Value() (driver.Value, error) {
+	return string(e.OrDefault()), nil
+}
+
+// UnmarshalJSON parses and validates an EndpointSelection.
+func (e *EndpointSelection) UnmarshalJSON(input []byte) error {
+	var s string
+	if err := json.Unmarshal(input, &s); err != nil {
+		return err
+	}
+	switch EndpointSelection(strings.ToLower(s)) {
+	case "":
+		*e = ""
+	case EndpointSelectionOrdered:
+		*e = EndpointSelectionOrdered
+	case EndpointSelectionLatency:
+		*e = EndpointSelectionLatency
+	default:
+		return fmt.Errorf("unknown URL selection %q, must be %q or %q", s, EndpointSelectionOrdered, EndpointSelectionLatency)
+	}
+	return nil
+}
+
 // AnyTime holds a common field for time, and serializes it as
 // a json number.
 type AnyTime struct {
@@ -555,6 +634,20 @@ type SendEtherRequest struct {
 	Amount             assets.Eth     `json:"amount"`
 }
 
+// RegistrationRequest represents a request to build, and optionally send, the
+// transactions registering the node's accounts with an oracle, operator or
+// aggregator contract. Senders defaults to all of the node's accounts, and
+// Oracle to the node's first account.
+type RegistrationRequest struct {
+	Method      string           `json:"method"`
+	Contract    common.Address   `json:"contract"`
+	Senders     []common.Address `json:"senders"`
+	Oracle      common.Address   `json:"oracle"`
+	NewAdmin    common.Address   `json:"newAdmin"`
+	Submit      bool             `json:"submit"`
+	FromAddress common.Address   `json:"from"`
+}
+
 // CreateKeyRequest represents a request to add an ethereum key.
 type CreateKeyRequest struct {
 	CurrentPassword string `json:"current_password"`
@@ -276,6 +276,53 @@ func TestWebURL_String_HasNilURL(t *testing.T) {
 	assert.Equal(t, "", w.String())
 }
 
+func TestWebURLs_ValueAndScan(t *testing.T) {
+	t.Parallel()
+
+	var empty models.WebURLs
+	b, err := json.Marshal(empty)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(b))
+
+	u, _ := url.Parse("https://eu.example.com/api")
+	urls := models.WebURLs{models.WebURL(*u)}
+	value, err := urls.Value()
+	require.NoError(t, err)
+
+	var scanned models.WebURLs
+	require.NoError(t, scanned.Scan(value))
+	require.Len(t, scanned, 1)
+	assert.Equal(t, "https://eu.example.com/api", scanned[0].String())
+}
+
+func TestEndpointSelection_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  models.EndpointSelection
+		error bool
+	}{
+		{`""`, "", false},
+		{`"ordered"`, models.EndpointSelectionOrdered, false},
+		{`"LATENCY"`, models.EndpointSelectionLatency, false},
+		{`"random"`, "", true},
+	}
+	assert.Equal(t, models.EndpointSelectionOrdered, models.EndpointSelection("").OrDefault())
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			var selection models.EndpointSelection
+			err := json.Unmarshal([]byte(test.input), &selection)
+			if test.error {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.want, selection)
+			}
+		})
+	}
+}
+
 func TestAnyTime_UnmarshalJSON_Valid(t *testing.T) {
 	tests := []struct {
 		name  string
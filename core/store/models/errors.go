@@ -3,6 +3,9 @@ package models
 import (
 	"fmt"
 	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 )
 
 // DatabaseAccessError is an error that occurs during database access.
@@ -29,6 +32,39 @@ func NewValidationError(msg string, values ...interface{}) error {
 	return &ValidationError{msg: fmt.Sprintf(msg, values...)}
 }
 
+// InvalidSpecError is an error starting a job which is caused by its spec,
+// such as a malformed initiator param, and so recurs every time the job is
+// started. Jobs failing to start with one are quarantined, unlike those
+// failing with a transient error such as an unreachable ethereum node.
+type InvalidSpecError struct {
+	err error
+}
+
+func (e *InvalidSpecError) Error() string { return e.err.Error() }
+
+// NewInvalidSpecError marks err as caused by a job's spec. A nil err is
+// returned as is.
+func NewInvalidSpecError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &InvalidSpecError{err}
+}
+
+// IsInvalidSpecError returns whether err, or any of the errors combined in
+// it, is an InvalidSpecError.
+func IsInvalidSpecError(err error) bool {
+	for _, e := range multierr.Errors(err) {
+		cause := errors.Cause(e)
+		if _, ok := cause.(*InvalidSpecError); ok {
+			return true
+		} else if cause != e && IsInvalidSpecError(cause) {
+			return true
+		}
+	}
+	return false
+}
+
 // JSONAPIErrors holds errors conforming to the JSONAPI spec.
 type JSONAPIErrors struct {
 	Errors []JSONAPIError `json:"errors"`
@@ -36,7 +72,8 @@ type JSONAPIErrors struct {
 
 // JSONAPIError is an individual JSONAPI Error.
 type JSONAPIError struct {
-	Detail string `json:"detail"`
+	Detail string                 `json:"detail"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
 }
 
 // NewJSONAPIErrors creates an instance of JSONAPIErrors, with the intention
@@ -77,6 +114,10 @@ func (jae *JSONAPIErrors) Merge(e error) {
 	switch typed := e.(type) {
 	case *JSONAPIErrors:
 		jae.Errors = append(jae.Errors, typed.Errors...)
+	case *JobSpecSyntaxError:
+		jae.Errors = append(jae.Errors, typed.JSONAPIError())
+	case *UnknownKeysError:
+		jae.Errors = append(jae.Errors, typed.JSONAPIErrors()...)
 	default:
 		jae.Add(e.Error())
 	}
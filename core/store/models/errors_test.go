@@ -0,0 +1,28 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/multierr"
+)
+
+func TestIsInvalidSpecError(t *testing.T) {
+	t.Parallel()
+
+	invalid := models.NewInvalidSpecError(errors.New("bad feeds"))
+	transient := errors.New("connection refused")
+
+	assert.True(t, models.IsInvalidSpecError(invalid))
+	assert.True(t, models.IsInvalidSpecError(pkgerrors.Wrap(invalid, "creating checker")))
+	assert.True(t, models.IsInvalidSpecError(multierr.Combine(transient, invalid)))
+	assert.True(t, models.IsInvalidSpecError(pkgerrors.Wrap(multierr.Combine(transient, invalid), "subscribing")))
+	assert.False(t, models.IsInvalidSpecError(transient))
+	assert.False(t, models.IsInvalidSpecError(nil))
+	assert.Nil(t, models.NewInvalidSpecError(nil))
+	assert.Equal(t, "bad feeds", invalid.Error())
+}
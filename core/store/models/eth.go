@@ -44,6 +44,22 @@ type EthTaskRunTx struct {
 	EthTx     EthTx
 }
 
+// EthCommitReveal tracks a task run's answer through a commit-reveal
+// submission: the commitment to the answer is sent first, and the answer
+// with the salt it was committed with is revealed once RevealAt has passed.
+type EthCommitReveal struct {
+	TaskRunID     uuid.UUID `gorm:"primary_key"`
+	Answer        []byte
+	Salt          []byte
+	CommitEthTxID int64
+	CommitEthTx   EthTx `gorm:"association_autoupdate:false;association_autocreate:false"`
+	RevealAt      *time.Time
+	RevealEthTxID *int64
+	RevealEthTx   *EthTx `gorm:"association_autoupdate:false;association_autocreate:false"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
 type EthTx struct {
 	ID             int64
 	Nonce          *int64
@@ -1,20 +1,59 @@
 package models
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/guregu/null"
 	"github.com/pkg/errors"
 )
 
+const (
+	// ExternalInitiatorProtocolV1 authenticates requests in both directions
+	// by sending an access key and secret along with them.
+	ExternalInitiatorProtocolV1 = 1
+	// ExternalInitiatorProtocolV2 authenticates requests in both directions
+	// by signing them, along with a timestamp and a nonce so that they
+	// cannot be replayed, see ExternalInitiatorSignature. Secrets are never
+	// sent.
+	ExternalInitiatorProtocolV2 = 2
+)
+
+// ExternalInitiatorSignatureMaxAge is how far the timestamp of a signed
+// request may be from the time it is received.
+const ExternalInitiatorSignatureMaxAge = 5 * time.Minute
+
+// MaxExternalInitiatorRequestSize is the largest body, in bytes, of a signed
+// request which is read to check its signature.
+const MaxExternalInitiatorRequestSize = 1024 * 1024
+
+// DefaultExternalInitiatorKeyOverlap is how long an external initiator's
+// previous signing key is still accepted after its keys are rotated, unless
+// the rotation says otherwise.
+const DefaultExternalInitiatorKeyOverlap = time.Hour
+
+// ExternalInitiatorKeyRotationRequest is a request to rotate the keys of an
+// external initiator. Overlap is how long the previous signing key is still
+// accepted, DefaultExternalInitiatorKeyOverlap if not given.
+type ExternalInitiatorKeyRotationRequest struct {
+	Overlap *Duration `json:"overlap"`
+}
+
 // ExternalInitiatorRequest is the incoming record used to create an ExternalInitiator.
 type ExternalInitiatorRequest struct {
 	Name string  `json:"name"`
 	URL  *WebURL `json:"url,omitempty"`
+	// ProtocolVersion is the version of the protocol the external initiator
+	// speaks, ExternalInitiatorProtocolV1 if not given.
+	ProtocolVersion int32 `json:"protocolVersion,omitempty"`
 }
 
 // ExternalInitiator represents a user that can initiate runs remotely
@@ -27,6 +66,21 @@ type ExternalInitiator struct {
 	HashedSecret   string  `gorm:"not null"`
 	OutgoingSecret string  `gorm:"not null"`
 	OutgoingToken  string  `gorm:"not null"`
+	Namespace      string  `gorm:"default:'default';not null"`
+
+	// ProtocolVersion is the version of the protocol the external initiator
+	// speaks. Under version 2 it signs its requests with IncomingSigningKey,
+	// and the node signs its notifications with OutgoingSecret.
+	ProtocolVersion    int32  `gorm:"not null;default:1"`
+	IncomingSigningKey string `gorm:"not null;default:''"`
+	// PreviousIncomingSigningKey is the signing key before the keys were
+	// last rotated, which is still accepted until PreviousKeysExpireAt.
+	// PreviousOutgoingSecret is the outgoing secret before then, which the
+	// node's notifications are still signed with until PreviousKeysExpireAt,
+	// so that the external initiator can take on the new secret meanwhile.
+	PreviousIncomingSigningKey string `gorm:"not null;default:''"`
+	PreviousOutgoingSecret     string `gorm:"not null;default:''"`
+	PreviousKeysExpireAt       null.Time
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -38,6 +92,10 @@ func NewExternalInitiator(
 	eia *auth.Token,
 	eir *ExternalInitiatorRequest,
 ) (*ExternalInitiator, error) {
+	protocolVersion := eir.ProtocolVersion
+	if protocolVersion == 0 {
+		protocolVersion = ExternalInitiatorProtocolV1
+	}
 	salt := utils.NewSecret(utils.DefaultSecretSize)
 	hashedSecret, err := auth.HashedSecret(eia, salt)
 	if err != nil {
@@ -52,9 +110,62 @@ func NewExternalInitiator(
 		Salt:           salt,
 		OutgoingToken:  utils.NewSecret(utils.DefaultSecretSize),
 		OutgoingSecret: utils.NewSecret(utils.DefaultSecretSize),
+
+		ProtocolVersion:    protocolVersion,
+		IncomingSigningKey: utils.NewSecret(utils.DefaultSecretSize),
 	}, nil
 }
 
+// RotateKeys replaces the external initiator's incoming signing key and
+// outgoing secret. For the overlap given, requests signed with the previous
+// signing key are still accepted and notifications are still signed with the
+// previous outgoing secret.
+func (ei *ExternalInitiator) RotateKeys(overlap time.Duration) {
+	ei.PreviousIncomingSigningKey = ei.IncomingSigningKey
+	ei.PreviousOutgoingSecret = ei.ActiveOutgoingSecret()
+	ei.PreviousKeysExpireAt = null.TimeFrom(time.Now().Add(overlap))
+	ei.IncomingSigningKey = utils.NewSecret(utils.DefaultSecretSize)
+	ei.OutgoingSecret = utils.NewSecret(utils.DefaultSecretSize)
+}
+
+// previousKeysValid returns whether the keys before the last rotation are
+// still in use.
+func (ei ExternalInitiator) previousKeysValid() bool {
+	return ei.PreviousKeysExpireAt.Valid && time.Now().Before(ei.PreviousKeysExpireAt.Time)
+}
+
+// IncomingSigningKeys returns the keys the external initiator's requests may
+// be signed with, the current key first.
+func (ei ExternalInitiator) IncomingSigningKeys() []string {
+	keys := []string{ei.IncomingSigningKey}
+	if ei.PreviousIncomingSigningKey != "" && ei.previousKeysValid() {
+		keys = append(keys, ei.PreviousIncomingSigningKey)
+	}
+	return keys
+}
+
+// ActiveOutgoingSecret returns the secret the node's notifications to the
+// external initiator are signed with, or sent with under version 1: the
+// previous outgoing secret until the overlap of the last rotation ends, and
+// the current one after that.
+func (ei ExternalInitiator) ActiveOutgoingSecret() string {
+	if ei.PreviousOutgoingSecret != "" && ei.previousKeysValid() {
+		return ei.PreviousOutgoingSecret
+	}
+	return ei.OutgoingSecret
+}
+
+// ExternalInitiatorSignature returns the hex encoded HMAC-SHA256 of a request
+// of the version 2 protocol, made with key over the request's timestamp in
+// unix seconds, nonce, method, path and body, each but the body followed by
+// a newline.
+func ExternalInitiatorSignature(key string, timestamp int64, nonce, method, path string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	fmt.Fprintf(mac, "%d\n%s\n%s\n%s\n", timestamp, nonce, method, path)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // AuthenticateExternalInitiator compares an auth against an initiator and
 // returns true if the password hashes match
 func AuthenticateExternalInitiator(eia *auth.Token, ea *ExternalInitiator) (bool, error) {
@@ -2,12 +2,14 @@ package models_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewExternalInitiator(t *testing.T) {
@@ -24,4 +26,60 @@ func TestNewExternalInitiator(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEqual(t, ei.HashedSecret, eia.Secret)
 	assert.Equal(t, ei.AccessKey, eia.AccessKey)
+	assert.Equal(t, int32(models.ExternalInitiatorProtocolV1), ei.ProtocolVersion)
+}
+
+func TestExternalInitiator_RotateKeys(t *testing.T) {
+	ei, err := models.NewExternalInitiator(auth.NewToken(), &models.ExternalInitiatorRequest{
+		Name:            "bitcoin",
+		ProtocolVersion: models.ExternalInitiatorProtocolV2,
+	})
+	require.NoError(t, err)
+	signingKey, outgoingSecret := ei.IncomingSigningKey, ei.OutgoingSecret
+	assert.Equal(t, []string{signingKey}, ei.IncomingSigningKeys())
+
+	assert.Equal(t, outgoingSecret, ei.ActiveOutgoingSecret())
+
+	ei.RotateKeys(time.Hour)
+	assert.NotEqual(t, signingKey, ei.IncomingSigningKey)
+	assert.NotEqual(t, outgoingSecret, ei.OutgoingSecret)
+	assert.Equal(t, []string{ei.IncomingSigningKey, signingKey}, ei.IncomingSigningKeys())
+	// Notifications are signed with the previous secret during the overlap
+	assert.Equal(t, outgoingSecret, ei.ActiveOutgoingSecret())
+
+	ei.RotateKeys(0)
+	assert.Equal(t, []string{ei.IncomingSigningKey}, ei.IncomingSigningKeys())
+	assert.Equal(t, ei.OutgoingSecret, ei.ActiveOutgoingSecret())
+}
+
+func TestFleetExternalInitiator_PreviousKeys(t *testing.T) {
+	ei, err := models.NewExternalInitiator(auth.NewToken(), &models.ExternalInitiatorRequest{
+		Name:            "bitcoin",
+		ProtocolVersion: models.ExternalInitiatorProtocolV2,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, models.NewFleetExternalInitiator(*ei).PreviousKeysExpireAt)
+
+	outgoingSecret := ei.OutgoingSecret
+	ei.RotateKeys(time.Hour)
+	bundled := models.NewFleetExternalInitiator(*ei)
+	assert.Equal(t, ei.PreviousIncomingSigningKey, bundled.PreviousIncomingSigningKey)
+	assert.Equal(t, outgoingSecret, bundled.PreviousOutgoingSecret)
+	require.NotNil(t, bundled.PreviousKeysExpireAt)
+
+	var applied models.ExternalInitiator
+	bundled.Apply(&applied)
+	assert.Equal(t, ei.IncomingSigningKeys(), applied.IncomingSigningKeys())
+	assert.Equal(t, outgoingSecret, applied.ActiveOutgoingSecret())
+	assert.True(t, ei.PreviousKeysExpireAt.Time.Equal(applied.PreviousKeysExpireAt.Time))
+}
+
+func TestExternalInitiatorSignature(t *testing.T) {
+	body := []byte(`{"result":"100"}`)
+	signature := models.ExternalInitiatorSignature("key", 1600000000, "nonce", "POST", "/v2/specs", body)
+	assert.Len(t, signature, 64)
+	assert.Equal(t, signature, models.ExternalInitiatorSignature("key", 1600000000, "nonce", "POST", "/v2/specs", body))
+	assert.NotEqual(t, signature, models.ExternalInitiatorSignature("key", 1600000001, "nonce", "POST", "/v2/specs", body))
+	assert.NotEqual(t, signature, models.ExternalInitiatorSignature("key", 1600000000, "other", "POST", "/v2/specs", body))
+	assert.NotEqual(t, signature, models.ExternalInitiatorSignature("other", 1600000000, "nonce", "POST", "/v2/specs", body))
 }
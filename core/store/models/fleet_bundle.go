@@ -0,0 +1,166 @@
+package models
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+
+	"github.com/guregu/null"
+)
+
+// FleetBundle holds the bridges, external initiators and jobs which every
+// node of a fleet should have. Nodes with FLEET_SYNC_URL set fetch a bundle
+// on an interval and apply the difference from what they last applied.
+//
+// Credentials are carried as stored, hashed where the node hashes them, so
+// that every node of the fleet accepts and presents the same ones.
+type FleetBundle struct {
+	Bridges            []FleetBridge            `json:"bridges"`
+	ExternalInitiators []FleetExternalInitiator `json:"externalInitiators"`
+	Jobs               []FleetJob               `json:"jobs"`
+}
+
+// FleetBridge is a bridge in a FleetBundle.
+type FleetBridge struct {
+	Name                   TaskType          `json:"name"`
+	URL                    WebURL            `json:"url"`
+	FailoverURLs           WebURLs           `json:"failoverURLs"`
+	URLSelection           EndpointSelection `json:"urlSelection"`
+	Confirmations          uint32            `json:"confirmations"`
+	IncomingTokenHash      string            `json:"incomingTokenHash"`
+	Salt                   string            `json:"salt"`
+	OutgoingToken          string            `json:"outgoingToken"`
+	MinimumContractPayment *assets.Link      `json:"minimumContractPayment"`
+}
+
+// NewFleetBridge returns the bundled form of a bridge.
+func NewFleetBridge(bt BridgeType) FleetBridge {
+	return FleetBridge{
+		Name:                   bt.Name,
+		URL:                    bt.URL,
+		FailoverURLs:           bt.FailoverURLs,
+		URLSelection:           bt.URLSelection,
+		Confirmations:          bt.Confirmations,
+		IncomingTokenHash:      bt.IncomingTokenHash,
+		Salt:                   bt.Salt,
+		OutgoingToken:          bt.OutgoingToken,
+		MinimumContractPayment: bt.MinimumContractPayment,
+	}
+}
+
+// Apply copies the bundled bridge onto bt.
+func (b FleetBridge) Apply(bt *BridgeType) {
+	bt.Name = b.Name
+	bt.URL = b.URL
+	bt.FailoverURLs = b.FailoverURLs
+	bt.URLSelection = b.URLSelection
+	bt.Confirmations = b.Confirmations
+	bt.IncomingTokenHash = b.IncomingTokenHash
+	bt.Salt = b.Salt
+	bt.OutgoingToken = b.OutgoingToken
+	bt.MinimumContractPayment = b.MinimumContractPayment
+}
+
+// FleetExternalInitiator is an external initiator in a FleetBundle.
+type FleetExternalInitiator struct {
+	Name           string  `json:"name"`
+	URL            *WebURL `json:"url,omitempty"`
+	AccessKey      string  `json:"accessKey"`
+	Salt           string  `json:"salt"`
+	HashedSecret   string  `json:"hashedSecret"`
+	OutgoingSecret string  `json:"outgoingSecret"`
+	OutgoingToken  string  `json:"outgoingToken"`
+	// ProtocolVersion is zero for external initiators speaking version 1, so
+	// that bundles made before version 2 still match the nodes they made.
+	ProtocolVersion    int32  `json:"protocolVersion,omitempty"`
+	IncomingSigningKey string `json:"incomingSigningKey,omitempty"`
+	// The keys before the last rotation are bundled while they are still in
+	// use, so that every node of the fleet keeps the same overlap.
+	PreviousIncomingSigningKey string     `json:"previousIncomingSigningKey,omitempty"`
+	PreviousOutgoingSecret     string     `json:"previousOutgoingSecret,omitempty"`
+	PreviousKeysExpireAt       *time.Time `json:"previousKeysExpireAt,omitempty"`
+}
+
+// NewFleetExternalInitiator returns the bundled form of an external initiator.
+func NewFleetExternalInitiator(ei ExternalInitiator) FleetExternalInitiator {
+	e := FleetExternalInitiator{
+		Name:           ei.Name,
+		URL:            ei.URL,
+		AccessKey:      ei.AccessKey,
+		Salt:           ei.Salt,
+		HashedSecret:   ei.HashedSecret,
+		OutgoingSecret: ei.OutgoingSecret,
+		OutgoingToken:  ei.OutgoingToken,
+
+		IncomingSigningKey: ei.IncomingSigningKey,
+	}
+	if ei.ProtocolVersion != ExternalInitiatorProtocolV1 {
+		e.ProtocolVersion = ei.ProtocolVersion
+	}
+	if ei.previousKeysValid() {
+		e.PreviousIncomingSigningKey = ei.PreviousIncomingSigningKey
+		e.PreviousOutgoingSecret = ei.PreviousOutgoingSecret
+		expireAt := ei.PreviousKeysExpireAt.Time.UTC()
+		e.PreviousKeysExpireAt = &expireAt
+	}
+	return e
+}
+
+// Apply copies the bundled external initiator onto ei.
+func (e FleetExternalInitiator) Apply(ei *ExternalInitiator) {
+	ei.Name = e.Name
+	ei.URL = e.URL
+	ei.AccessKey = e.AccessKey
+	ei.Salt = e.Salt
+	ei.HashedSecret = e.HashedSecret
+	ei.OutgoingSecret = e.OutgoingSecret
+	ei.OutgoingToken = e.OutgoingToken
+	ei.ProtocolVersion = e.ProtocolVersion
+	if ei.ProtocolVersion == 0 {
+		ei.ProtocolVersion = ExternalInitiatorProtocolV1
+	}
+	ei.IncomingSigningKey = e.IncomingSigningKey
+	ei.PreviousIncomingSigningKey = e.PreviousIncomingSigningKey
+	ei.PreviousOutgoingSecret = e.PreviousOutgoingSecret
+	ei.PreviousKeysExpireAt = null.TimeFromPtr(e.PreviousKeysExpireAt)
+}
+
+// FleetJob is a job in a FleetBundle. Jobs keep their ID across the fleet.
+// As jobs cannot be changed once created, a job is only ever added to or
+// archived from the nodes of the fleet.
+type FleetJob struct {
+	ID  *ID            `json:"id"`
+	Job JobSpecRequest `json:"job"`
+	// WebhookSecrets are the secrets of the job's webhook initiators, which
+	// are not part of its spec, so that every node of the fleet accepts the
+	// same ones.
+	WebhookSecrets []InitiatorWebhookSecret `json:"webhookSecrets,omitempty"`
+}
+
+// NewFleetJob returns the bundled form of a job.
+func NewFleetJob(job JobSpec) FleetJob {
+	return FleetJob{ID: job.ID, Job: job.Request(), WebhookSecrets: WebhookSecretsOf(job)}
+}
+
+// JobSpec returns the bundled job, with its ID.
+func (j FleetJob) JobSpec() JobSpec {
+	job := newJobFromRequestWithID(j.Job, j.ID)
+	ApplyWebhookSecrets(&job, j.WebhookSecrets)
+	return job
+}
+
+// Kinds of resource which fleet sync creates.
+const (
+	FleetSyncKindBridge            = "bridge"
+	FleetSyncKindExternalInitiator = "external_initiator"
+	FleetSyncKindJob               = "job"
+)
+
+// FleetSyncResource records that a resource was created, or taken over, by
+// fleet sync. Only these resources are removed when they leave the bundle;
+// those created on the node itself are left alone.
+type FleetSyncResource struct {
+	Kind      string `gorm:"primary_key"`
+	Name      string `gorm:"primary_key"`
+	CreatedAt time.Time
+}
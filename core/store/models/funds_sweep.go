@@ -0,0 +1,75 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	null "gopkg.in/guregu/null.v3"
+)
+
+// FundsSweepStatus is the state of a FundsSweep in its approval workflow.
+type FundsSweepStatus string
+
+const (
+	// FundsSweepStatusPending is a sweep waiting to be approved or rejected.
+	FundsSweepStatusPending = FundsSweepStatus("pending_approval")
+	// FundsSweepStatusExecuted is an approved sweep whose transactions have
+	// been created.
+	FundsSweepStatusExecuted = FundsSweepStatus("executed")
+	// FundsSweepStatusRejected is a sweep which will never be executed.
+	FundsSweepStatusRejected = FundsSweepStatus("rejected")
+)
+
+// FundsSweep moves the remaining ETH and LINK held by one of the node's
+// accounts to the configured treasury address. Sweeps are kept after they are
+// executed or rejected as an audit record of who requested and reviewed them.
+type FundsSweep struct {
+	ID                  int64            `json:"-" gorm:"primary_key"`
+	FromAddress         common.Address   `json:"from" gorm:"not null"`
+	ToAddress           common.Address   `json:"to" gorm:"not null"`
+	Status              FundsSweepStatus `json:"status" gorm:"not null"`
+	Reason              string           `json:"reason" gorm:"not null"`
+	RequestedBy         string           `json:"requestedBy" gorm:"not null"`
+	ReviewedBy          null.String      `json:"reviewedBy"`
+	ReviewedAt          null.Time        `json:"reviewedAt"`
+	EthTransferEthTxID  null.Int         `json:"ethTransferEthTxId"`
+	LinkTransferEthTxID null.Int         `json:"linkTransferEthTxId"`
+	CreatedAt           time.Time        `json:"createdAt"`
+	UpdatedAt           time.Time        `json:"updatedAt"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (s FundsSweep) GetID() string {
+	return strconv.FormatInt(s.ID, 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi
+// serialization.
+func (s FundsSweep) GetName() string {
+	return "funds_sweeps"
+}
+
+// SetID is used to set the ID of this structure when deserializing from
+// jsonapi documents.
+func (s *FundsSweep) SetID(value string) error {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	s.ID = id
+	return nil
+}
+
+// FundsSweepRequest is a request to sweep the funds of one of the node's
+// accounts.
+type FundsSweepRequest struct {
+	Address common.Address `json:"address"`
+	Reason  string         `json:"reason"`
+}
+
+// FundsSweepReviewRequest approves or rejects a pending FundsSweep. The
+// user's password must be supplied again to approve a sweep.
+type FundsSweepReviewRequest struct {
+	Password string `json:"password"`
+}
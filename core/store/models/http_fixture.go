@@ -0,0 +1,11 @@
+package models
+
+// HTTPFixture is a response to an HTTP request sent by an HTTP or bridge task,
+// recorded so that it can be replayed in place of sending the request again.
+type HTTPFixture struct {
+	Method       string `json:"method"`
+	URL          string `json:"url"`
+	RequestBody  string `json:"requestBody,omitempty"`
+	StatusCode   int    `json:"statusCode"`
+	ResponseBody string `json:"responseBody"`
+}
@@ -0,0 +1,190 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/tidwall/sjson"
+)
+
+// JobExportVersion is the version of the JobExport format written by this
+// node. Imports of other versions are rejected.
+const JobExportVersion = 1
+
+// JobExport is a job along with the state another node needs to carry on
+// running it without missing or reprocessing work: the logs the job has
+// consumed, and the flux monitor rounds it has seen and submitted to. The
+// job keeps its ID, which oracle requests refer to.
+//
+// LastProcessedBlock is the block of the latest log the job consumed. If the
+// importing node's chain head is past it, the node can be started with
+// REPLAY_FROM_BLOCK to pick up logs emitted during the move; those already
+// consumed are skipped.
+type JobExport struct {
+	Version            int                  `json:"version"`
+	ID                 *ID                  `json:"id"`
+	Job                JobSpecRequest       `json:"job"`
+	LastProcessedBlock uint64               `json:"lastProcessedBlock"`
+	LogConsumptions    []ExportedLog        `json:"logConsumptions"`
+	FluxMonitorRounds  []ExportedRoundStats `json:"fluxMonitorRounds"`
+	// WebhookSecrets are the secrets of the job's webhook initiators, which
+	// are not part of its spec.
+	WebhookSecrets []InitiatorWebhookSecret `json:"webhookSecrets,omitempty"`
+}
+
+// ExportedLog identifies a log which the exported job has consumed.
+type ExportedLog struct {
+	BlockHash   common.Hash `json:"blockHash"`
+	LogIndex    uint        `json:"logIndex"`
+	BlockNumber uint64      `json:"blockNumber"`
+}
+
+// ExportedRoundStats are the flux monitor round stats of one of the exported
+// job's aggregators.
+type ExportedRoundStats struct {
+	Aggregator      common.Address `json:"aggregator"`
+	RoundID         uint32         `json:"roundId"`
+	NumNewRoundLogs uint64         `json:"numNewRoundLogs"`
+	NumSubmissions  uint64         `json:"numSubmissions"`
+}
+
+// NewJobExport returns the export of a job and its state.
+func NewJobExport(job JobSpec, logs []LogConsumption, rounds []FluxMonitorRoundStats) JobExport {
+	export := JobExport{
+		Version:           JobExportVersion,
+		ID:                job.ID,
+		Job:               job.Request(),
+		WebhookSecrets:    WebhookSecretsOf(job),
+		LogConsumptions:   []ExportedLog{},
+		FluxMonitorRounds: []ExportedRoundStats{},
+	}
+	for _, log := range logs {
+		export.LogConsumptions = append(export.LogConsumptions, ExportedLog{
+			BlockHash:   log.BlockHash,
+			LogIndex:    log.LogIndex,
+			BlockNumber: log.BlockNumber,
+		})
+		if log.BlockNumber > export.LastProcessedBlock {
+			export.LastProcessedBlock = log.BlockNumber
+		}
+	}
+	for _, round := range rounds {
+		export.FluxMonitorRounds = append(export.FluxMonitorRounds, ExportedRoundStats{
+			Aggregator:      round.Aggregator,
+			RoundID:         round.RoundID,
+			NumNewRoundLogs: round.NumNewRoundLogs,
+			NumSubmissions:  round.NumSubmissions,
+		})
+	}
+	return export
+}
+
+// Validate returns an error if the export cannot be imported by this node.
+func (e JobExport) Validate() error {
+	if e.Version != JobExportVersion {
+		return fmt.Errorf("unsupported job export version %d, expected %d", e.Version, JobExportVersion)
+	}
+	if e.ID == nil {
+		return fmt.Errorf("job export is missing the job's id")
+	}
+	aggregators := map[common.Address]bool{}
+	for _, initr := range e.Job.Initiators {
+		if strings.ToLower(initr.Type) == InitiatorFluxMonitor {
+			aggregators[initr.Address] = true
+		}
+	}
+	for _, round := range e.FluxMonitorRounds {
+		if !aggregators[round.Aggregator] {
+			return fmt.Errorf("flux monitor round %d is for aggregator %s, which the job does not monitor", round.RoundID, round.Aggregator.Hex())
+		}
+	}
+	return nil
+}
+
+// JobSpec returns the exported job, with its original ID.
+func (e JobExport) JobSpec() JobSpec {
+	job := newJobFromRequestWithID(e.Job, e.ID)
+	ApplyWebhookSecrets(&job, e.WebhookSecrets)
+	return job
+}
+
+// CanonicalJobSpec returns the spec the job runs, defaults included, as
+// indented JSON with its keys sorted, so that it can be compared with the
+// spec which was submitted, or submitted again.
+func CanonicalJobSpec(job JobSpec) ([]byte, error) {
+	request, err := json.Marshal(job.Request())
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(request))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(generic, "", "  ")
+}
+
+// SameJobSpec returns whether two jobs run the same spec, comparing their
+// canonical specs. Their times are compared in UTC, to the microsecond, as
+// they are stored, so that a job loaded from the database and the spec it
+// was created from are the same.
+func SameJobSpec(a, b JobSpec) (bool, error) {
+	aspec, err := CanonicalJobSpec(normalizeJobSpecTimes(a))
+	if err != nil {
+		return false, err
+	}
+	bspec, err := CanonicalJobSpec(normalizeJobSpecTimes(b))
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(aspec, bspec), nil
+}
+
+func normalizeJobSpecTimes(job JobSpec) JobSpec {
+	if job.StartAt.Valid {
+		job.StartAt.Time = job.StartAt.Time.UTC().Round(time.Microsecond)
+	}
+	if job.EndAt.Valid {
+		job.EndAt.Time = job.EndAt.Time.UTC().Round(time.Microsecond)
+	}
+	return job
+}
+
+// DuplicateJobSpecRequest returns the request for a copy of job, with the
+// values at the paths of overrides replaced. Paths address the job's spec as
+// it is submitted, in sjson's dotted syntax, such as
+// "initiators.0.params.address". Overrides which leave the spec with an
+// unknown key are rejected. The copy keeps no external job ID unless one is
+// given as an override, since it would otherwise be taken for the original.
+func DuplicateJobSpecRequest(job JobSpec, overrides map[string]json.RawMessage) (JobSpecRequest, error) {
+	request := job.Request()
+	request.ExternalJobID = nil
+	spec, err := json.Marshal(request)
+	if err != nil {
+		return JobSpecRequest{}, err
+	}
+	paths := make([]string, 0, len(overrides))
+	for path := range overrides {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if spec, err = sjson.SetRawBytes(spec, path, overrides[path]); err != nil {
+			return JobSpecRequest{}, errors.Wrapf(err, "overriding %s", path)
+		}
+	}
+
+	var jsr JobSpecRequest
+	unknown, err := DecodeJobSpecRequest(spec, &jsr)
+	if err == nil && len(unknown) > 0 {
+		err = &UnknownKeysError{Keys: unknown}
+	}
+	return jsr, err
+}
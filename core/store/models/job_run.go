@@ -10,6 +10,8 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	null "gopkg.in/guregu/null.v3"
@@ -44,20 +46,27 @@ type JobRun struct {
 	ObservedHeight *utils.Big   `json:"observedHeight"`
 	DeletedAt      null.Time    `json:"-"`
 	Payment        *assets.Link `json:"payment,omitempty"`
+	// Confidential is copied from the run's job when the run is created, so
+	// that the run's data stays encrypted if the job is later changed.
+	Confidential bool `json:"confidential,omitempty" gorm:"not null"`
+	// Profile has the executions of the run profiled, see RunProfile.
+	Profile bool `json:"profile,omitempty" gorm:"not null"`
 }
 
 // MakeJobRun returns a new JobRun copy
 func MakeJobRun(job *JobSpec, now time.Time, initiator *Initiator, currentHeight *big.Int, runRequest *RunRequest) JobRun {
 	run := JobRun{
-		ID:          NewID(),
-		JobSpecID:   job.ID,
-		CreatedAt:   now,
-		UpdatedAt:   now,
-		Initiator:   *initiator,
-		InitiatorID: initiator.ID,
-		TaskRuns:    make([]TaskRun, len(job.Tasks)),
-		RunRequest:  *runRequest,
-		Payment:     runRequest.Payment,
+		ID:           NewID(),
+		JobSpecID:    job.ID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Initiator:    *initiator,
+		InitiatorID:  initiator.ID,
+		TaskRuns:     make([]TaskRun, len(job.Tasks)),
+		RunRequest:   *runRequest,
+		Payment:      runRequest.Payment,
+		Confidential: job.Confidential,
+		Profile:      runRequest.Profile,
 	}
 	if currentHeight != nil {
 		run.CreationHeight = utils.NewBig(currentHeight)
@@ -174,6 +183,18 @@ func (jr *JobRun) PreviousTaskRun() *TaskRun {
 	return nil
 }
 
+// NamedTaskResults returns the result data of each completed task run whose
+// task has a name, for interpolation into the params of later tasks.
+func (jr *JobRun) NamedTaskResults() map[string]JSON {
+	results := map[string]JSON{}
+	for _, tr := range jr.TaskRuns {
+		if tr.TaskSpec.Name != "" && tr.Status.Completed() {
+			results[tr.TaskSpec.Name] = tr.Result.Data
+		}
+	}
+	return results
+}
+
 // TasksRemain returns true if there are unfinished tasks left for this job run
 func (jr *JobRun) TasksRemain() bool {
 	_, runnable := jr.NextTaskRunIndex()
@@ -195,9 +216,131 @@ func (jr *JobRun) Cancel() {
 	jr.SetStatus(RunStatusCancelled)
 }
 
+// Replay resets an errored run so that it resumes from the task which
+// errored. Completed tasks keep their results, which are used as the input to
+// the replayed task as they were on the first attempt.
+func (jr *JobRun) Replay() error {
+	if !jr.Status.Errored() {
+		return fmt.Errorf("cannot replay run %s with status %s", jr.ID, jr.Status)
+	}
+
+	failed := jr.erroredTaskRunIndex()
+	if failed == -1 {
+		return fmt.Errorf("run %s has no errored task to replay from", jr.ID)
+	}
+
+	jr.TaskRuns[failed].ReplayCount++
+	for i := failed; i < len(jr.TaskRuns); i++ {
+		jr.TaskRuns[i].Status = RunStatusUnstarted
+		jr.TaskRuns[i].Result.Data = JSON{}
+		jr.TaskRuns[i].Result.ErrorMessage = null.String{}
+	}
+	jr.Result.ErrorMessage = null.String{}
+	jr.FinishedAt = null.Time{}
+	jr.SetStatus(RunStatusInProgress)
+	return nil
+}
+
+// ReplayedTaskRuns returns the task runs which replaying the run performs
+// again, those from the task which errored onwards.
+func (jr *JobRun) ReplayedTaskRuns() []TaskRun {
+	failed := jr.erroredTaskRunIndex()
+	if failed == -1 {
+		return nil
+	}
+	return jr.TaskRuns[failed:]
+}
+
+func (jr *JobRun) erroredTaskRunIndex() int {
+	for i, tr := range jr.TaskRuns {
+		if tr.Status.Errored() {
+			return i
+		}
+	}
+	return -1
+}
+
+// DiscardIntermediateResults clears the result data of every task run but
+// the last, for jobs which only keep the final result of their runs. It is
+// only called once the run has completed, as later tasks read the results of
+// earlier ones, and errored runs keep them so that they can be replayed.
+func (jr *JobRun) DiscardIntermediateResults() {
+	for i := 0; i < len(jr.TaskRuns)-1; i++ {
+		jr.TaskRuns[i].Result.Data = JSON{}
+	}
+}
+
+// BeforeSave encrypts the data of a confidential run before it is stored.
+// The data is decrypted again by AfterSave, as the run keeps executing.
+func (jr *JobRun) BeforeSave(scope *gorm.Scope) error {
+	if !jr.Confidential {
+		return nil
+	}
+	c := runDataCipher(scope)
+	if c == nil {
+		return errors.New("cannot store the data of a confidential run without a run data encryption key")
+	}
+	return jr.mapData(func(data JSON) (JSON, error) {
+		return c.Encrypt(jr.JobSpecID, data)
+	})
+}
+
+// AfterSave decrypts the data encrypted by BeforeSave.
+func (jr *JobRun) AfterSave(scope *gorm.Scope) error {
+	return jr.decryptData(scope)
+}
+
+// AfterFind decrypts the data of a confidential run once it is loaded. The
+// data is left encrypted if the node has no run data encryption key.
+func (jr *JobRun) AfterFind(scope *gorm.Scope) error {
+	return jr.decryptData(scope)
+}
+
+func (jr *JobRun) decryptData(scope *gorm.Scope) error {
+	c := runDataCipher(scope)
+	if !jr.Confidential || c == nil {
+		return nil
+	}
+	return jr.mapData(func(data JSON) (JSON, error) {
+		return c.Decrypt(jr.JobSpecID, data)
+	})
+}
+
+// mapData replaces the request params of the run, and the result data of the
+// run and of each of its task runs, with the output of f.
+func (jr *JobRun) mapData(f func(JSON) (JSON, error)) error {
+	var err error
+	if jr.RunRequest.RequestParams, err = f(jr.RunRequest.RequestParams); err != nil {
+		return err
+	}
+	if jr.Result.Data, err = f(jr.Result.Data); err != nil {
+		return err
+	}
+	for i := range jr.TaskRuns {
+		if jr.TaskRuns[i].Result.Data, err = f(jr.TaskRuns[i].Result.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Redacted returns a copy of the run without the data of a confidential run,
+// for API clients which are not allowed to read it.
+func (jr JobRun) Redacted() JobRun {
+	if !jr.Confidential {
+		return jr
+	}
+	jr.TaskRuns = append([]TaskRun{}, jr.TaskRuns...)
+	_ = jr.mapData(func(JSON) (JSON, error) { return JSON{}, nil })
+	return jr
+}
+
 // ApplyOutput updates the JobRun's Result and Status
 func (jr *JobRun) ApplyOutput(result RunOutput) {
 	if result.HasError() {
+		if result.Data().Exists() {
+			jr.Result.Data = result.Data()
+		}
 		jr.SetError(result.Error())
 		return
 	}
@@ -229,6 +372,12 @@ type RunRequest struct {
 	CreatedAt     time.Time
 	Payment       *assets.Link
 	RequestParams JSON `gorm:"default: '{}';not null"`
+	// PreviousJobRunID is the run of the previous job whose result a run of
+	// a next job was created with, see JobSpec.NextJobID.
+	PreviousJobRunID *ID
+	// Profile asks for the run to be profiled. It is kept on the run rather
+	// than the request, see JobRun.Profile.
+	Profile bool `gorm:"-"`
 }
 
 // NewRunRequest returns a new RunRequest instance.
@@ -248,6 +397,7 @@ type TaskRun struct {
 	TaskSpecID                       int64         `json:"-"`
 	MinRequiredIncomingConfirmations clnull.Uint32 `json:"minimumConfirmations" gorm:"column:minimum_confirmations"`
 	ObservedIncomingConfirmations    clnull.Uint32 `json:"confirmations" gorm:"column:confirmations"`
+	ReplayCount                      uint32        `json:"replayCount"`
 	CreatedAt                        time.Time     `json:"-"`
 	UpdatedAt                        time.Time     `json:"-"`
 }
@@ -275,6 +425,9 @@ func (tr *TaskRun) ApplyBridgeRunResult(result BridgeRunResult) {
 // ApplyOutput updates the TaskRun's Result and Status
 func (tr *TaskRun) ApplyOutput(result RunOutput) {
 	if result.HasError() {
+		if result.Data().Exists() {
+			tr.Result.Data = result.Data()
+		}
 		tr.SetError(result.Error())
 		return
 	}
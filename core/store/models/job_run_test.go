@@ -61,7 +61,7 @@ func TestJobRun_SavesASyncEvent(t *testing.T) {
 	t.Parallel()
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
-	pusher := synchronization.NewStatsPusher(store.ORM, cltest.MustParseURL("http://localhost:4201"), "", "")
+	pusher := synchronization.NewStatsPusher(store.ORM, cltest.MustParseURL("http://localhost:4201"), "", "", 0)
 	defer pusher.Close()
 
 	job := cltest.NewJobWithWebInitiator()
@@ -206,3 +206,48 @@ func TestJobRun_ApplyOutput_ErrorSetsFinishedAt(t *testing.T) {
 	jobRun.ApplyOutput(result)
 	assert.True(t, jobRun.FinishedAt.Valid)
 }
+
+func TestJobRun_ApplyOutput_ErrorWithData(t *testing.T) {
+	t.Parallel()
+
+	job := cltest.NewJobWithWebInitiator()
+	jobRun := cltest.NewJobRun(job)
+	jobRun.ApplyOutput(models.NewRunOutputCompleteWithResult("upstream"))
+
+	jobRun.ApplyOutput(models.NewRunOutputError(errors.New("oh futz")))
+	assert.Equal(t, "upstream", jobRun.Result.Data.Get("result").String())
+
+	data := cltest.JSONFromString(t, `{"result": "upstream", "assertion": {"actual": "upstream"}}`)
+	jobRun.TaskRuns[0].ApplyOutput(models.NewRunOutputErrorWithData(errors.New("oh futz"), data))
+	jobRun.ApplyOutput(models.NewRunOutputErrorWithData(errors.New("oh futz"), data))
+	assert.Equal(t, "upstream", jobRun.TaskRuns[0].Result.Data.Get("assertion.actual").String())
+	assert.Equal(t, "upstream", jobRun.Result.Data.Get("assertion.actual").String())
+	assert.Equal(t, "oh futz", jobRun.ErrorString())
+}
+
+func TestJobRun_Replay(t *testing.T) {
+	t.Parallel()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{{Type: "noop"}, {Type: "bridge"}, {Type: "noop"}}
+	jobRun := cltest.NewJobRun(job)
+	require.Error(t, jobRun.Replay(), "unstarted runs cannot be replayed")
+
+	jobRun.TaskRuns[0].ApplyOutput(models.NewRunOutputCompleteWithResult("upstream"))
+	jobRun.TaskRuns[1].SetError(errors.New("bridge unavailable"))
+	jobRun.SetError(errors.New("bridge unavailable"))
+
+	require.NoError(t, jobRun.Replay())
+	assert.Equal(t, models.RunStatusInProgress, jobRun.GetStatus())
+	assert.False(t, jobRun.FinishedAt.Valid)
+	assert.Empty(t, jobRun.ErrorString())
+
+	assert.Equal(t, models.RunStatusCompleted, jobRun.TaskRuns[0].Status)
+	assert.Equal(t, "upstream", jobRun.TaskRuns[0].Result.Data.Get("result").String())
+	assert.Zero(t, jobRun.TaskRuns[0].ReplayCount)
+
+	assert.Equal(t, models.RunStatusUnstarted, jobRun.TaskRuns[1].Status)
+	assert.False(t, jobRun.TaskRuns[1].Result.ErrorMessage.Valid)
+	assert.Equal(t, uint32(1), jobRun.TaskRuns[1].ReplayCount)
+	assert.Equal(t, &jobRun.TaskRuns[1], jobRun.NextTaskRun())
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
 	null "gopkg.in/guregu/null.v3"
 )
 
@@ -24,6 +25,43 @@ type JobSpecRequest struct {
 	StartAt    null.Time          `json:"startAt"`
 	EndAt      null.Time          `json:"endAt"`
 	MinPayment *assets.Link       `json:"minPayment,omitempty"`
+	// MaxRunsPerMinute optionally limits how many runs the job may create
+	// in any minute, on top of its namespace's quota.
+	MaxRunsPerMinute clnull.Int64 `json:"maxRunsPerMinute"`
+	// AttestationKey is the address of a key which signs the results of the
+	// job's runs, see Attestation.
+	AttestationKey *EIP55Address `json:"attestationKey,omitempty"`
+	// FinalResultOnly discards the results of all but the last task of each
+	// run once the run completes, see JobRun.DiscardIntermediateResults.
+	FinalResultOnly bool `json:"finalResultOnly"`
+	// Confidential encrypts the request params and results of the job's runs
+	// before they are stored, see RunDataCipher.
+	Confidential bool `json:"confidential,omitempty"`
+	// MaxRunDuration optionally limits how long each run may take, from its
+	// creation, before it is errored and its outstanding requests cancelled.
+	MaxRunDuration *Duration `json:"maxRunDuration,omitempty"`
+	// ExternalJobID optionally identifies the job to the client creating
+	// it, which gets the job back instead of a new one if it creates a job
+	// with the same ExternalJobID and spec again.
+	ExternalJobID *uuid.UUID `json:"externalJobID,omitempty"`
+	// NextJobID optionally names a job in the same namespace which is run,
+	// through its web initiator, with the result of each run of this job
+	// which completes, chaining the jobs into a workflow.
+	NextJobID *ID `json:"nextJobId,omitempty"`
+	// ResultFormat optionally formats the result of each run before it is
+	// encoded for the chain, see ResultFormat.
+	ResultFormat *ResultFormat `json:"resultFormat,omitempty"`
+}
+
+// JobSpecPreviewRequest is a JobSpecRequest along with the request params used
+// when previewing its tasks. The responses to the HTTP requests sent by its
+// tasks are returned with the preview if RecordHTTP is set, and if
+// HTTPFixtures are given they are replayed instead of sending any requests.
+type JobSpecPreviewRequest struct {
+	JobSpecRequest
+	Params       JSON          `json:"params"`
+	RecordHTTP   bool          `json:"recordHTTP"`
+	HTTPFixtures []HTTPFixture `json:"httpFixtures"`
 }
 
 // InitiatorRequest represents a schema for incoming initiator requests as used by the API.
@@ -34,8 +72,10 @@ type InitiatorRequest struct {
 
 // TaskSpecRequest represents a schema for incoming TaskSpec requests as used by the API.
 type TaskSpecRequest struct {
+	Name                             string        `json:"name,omitempty"`
 	Type                             TaskType      `json:"type"`
 	MinRequiredIncomingConfirmations clnull.Uint32 `json:"confirmations"`
+	MaxResultSize                    clnull.Int64  `json:"maxResultSize"`
 	Params                           JSON          `json:"params"`
 }
 
@@ -43,17 +83,49 @@ type TaskSpecRequest struct {
 // for a given contract. It contains the Initiators, Tasks (which are the
 // individual steps to be carried out), StartAt, EndAt, and CreatedAt fields.
 type JobSpec struct {
-	ID         *ID            `json:"id,omitempty" gorm:"primary_key;not null"`
-	CreatedAt  time.Time      `json:"createdAt" gorm:"index"`
-	Initiators []Initiator    `json:"initiators"`
-	MinPayment *assets.Link   `json:"minPayment,omitempty" gorm:"type:varchar(255)"`
-	Tasks      []TaskSpec     `json:"tasks"`
-	StartAt    null.Time      `json:"startAt" gorm:"index"`
-	EndAt      null.Time      `json:"endAt" gorm:"index"`
-	DeletedAt  null.Time      `json:"-" gorm:"index"`
-	UpdatedAt  time.Time      `json:"-"`
-	Errors     []JobSpecError `json:"-" gorm:"foreignkey:JobSpecID;association_autoupdate:false;association_autocreate:false"`
-}
+	ID               *ID            `json:"id,omitempty" gorm:"primary_key;not null"`
+	CreatedAt        time.Time      `json:"createdAt" gorm:"index"`
+	Initiators       []Initiator    `json:"initiators"`
+	MinPayment       *assets.Link   `json:"minPayment,omitempty" gorm:"type:varchar(255)"`
+	Tasks            []TaskSpec     `json:"tasks"`
+	StartAt          null.Time      `json:"startAt" gorm:"index"`
+	EndAt            null.Time      `json:"endAt" gorm:"index"`
+	Status           JobSpecStatus  `json:"status" gorm:"default:'active';not null"`
+	StatusReason     string         `json:"statusReason,omitempty" gorm:"not null"`
+	Namespace        string         `json:"namespace" gorm:"default:'default';not null"`
+	MaxRunsPerMinute clnull.Int64   `json:"maxRunsPerMinute"`
+	AttestationKey   *EIP55Address  `json:"attestationKey,omitempty"`
+	FinalResultOnly  bool           `json:"finalResultOnly" gorm:"not null"`
+	Confidential     bool           `json:"confidential,omitempty" gorm:"not null"`
+	MaxRunDuration   Duration       `json:"maxRunDuration" gorm:"not null"`
+	ExternalJobID    *uuid.UUID     `json:"externalJobID,omitempty" gorm:"type:uuid"`
+	NextJobID        *ID            `json:"nextJobId,omitempty"`
+	ResultFormat     *ResultFormat  `json:"resultFormat,omitempty" gorm:"type:jsonb"`
+	ExpiresAt        null.Time      `json:"expiresAt"`
+	DeletedAt        null.Time      `json:"-" gorm:"index"`
+	UpdatedAt        time.Time      `json:"-"`
+	Errors           []JobSpecError `json:"-" gorm:"foreignkey:JobSpecID;association_autoupdate:false;association_autocreate:false"`
+}
+
+// JobSpecStatus describes whether a JobSpec's initiators have been handed to
+// the node's services, and so whether new runs can be triggered for it.
+type JobSpecStatus string
+
+const (
+	// JobSpecStatusActive is the default status of a job, its initiators are
+	// listening for triggers.
+	JobSpecStatusActive JobSpecStatus = "active"
+	// JobSpecStatusStopped is the status of a job which has been saved but
+	// not yet started, no runs will be created for it.
+	JobSpecStatusStopped JobSpecStatus = "stopped"
+	// JobSpecStatusFailed is the status of a job whose initiators could not
+	// be started when the node booted. The job is quarantined until it is
+	// retried, and StatusReason holds the error that caused it.
+	JobSpecStatusFailed JobSpecStatus = "failed"
+	// JobSpecStatusPaused is the status of a job which was active until it
+	// was paused. No runs are created for it until it is resumed.
+	JobSpecStatusPaused JobSpecStatus = "paused"
+)
 
 // GetID returns the ID of this structure for jsonapi serialization.
 func (j JobSpec) GetID() string {
@@ -76,6 +148,7 @@ func NewJob() JobSpec {
 	return JobSpec{
 		ID:        NewID(),
 		CreatedAt: time.Now(),
+		Status:    JobSpecStatusActive,
 	}
 }
 
@@ -90,8 +163,10 @@ func NewJobFromRequest(jsr JobSpecRequest) JobSpec {
 	for _, task := range jsr.Tasks {
 		jobSpec.Tasks = append(jobSpec.Tasks, TaskSpec{
 			JobSpecID:                        jobSpec.ID,
+			Name:                             task.Name,
 			Type:                             task.Type,
 			MinRequiredIncomingConfirmations: task.MinRequiredIncomingConfirmations,
+			MaxResultSize:                    task.MaxResultSize,
 			Params:                           task.Params,
 		})
 	}
@@ -99,14 +174,103 @@ func NewJobFromRequest(jsr JobSpecRequest) JobSpec {
 	jobSpec.EndAt = jsr.EndAt
 	jobSpec.StartAt = jsr.StartAt
 	jobSpec.MinPayment = jsr.MinPayment
+	jobSpec.MaxRunsPerMinute = jsr.MaxRunsPerMinute
+	jobSpec.AttestationKey = jsr.AttestationKey
+	jobSpec.FinalResultOnly = jsr.FinalResultOnly
+	jobSpec.Confidential = jsr.Confidential
+	if jsr.MaxRunDuration != nil {
+		jobSpec.MaxRunDuration = *jsr.MaxRunDuration
+	}
+	jobSpec.ExternalJobID = jsr.ExternalJobID
+	jobSpec.NextJobID = jsr.NextJobID
+	jobSpec.ResultFormat = jsr.ResultFormat
 	return jobSpec
 }
 
+// newJobFromRequestWithID creates a JobSpec from a JobSpecRequest, keeping
+// an ID assigned to the job elsewhere.
+func newJobFromRequestWithID(jsr JobSpecRequest, id *ID) JobSpec {
+	job := NewJobFromRequest(jsr)
+	job.ID = id
+	for i := range job.Initiators {
+		job.Initiators[i].JobSpecID = id
+	}
+	for i := range job.Tasks {
+		job.Tasks[i].JobSpecID = id
+	}
+	return job
+}
+
+// Request returns the JobSpecRequest which would create a job like j.
+func (j JobSpec) Request() JobSpecRequest {
+	jsr := JobSpecRequest{
+		StartAt:          j.StartAt,
+		EndAt:            j.EndAt,
+		MinPayment:       j.MinPayment,
+		MaxRunsPerMinute: j.MaxRunsPerMinute,
+		AttestationKey:   j.AttestationKey,
+		FinalResultOnly:  j.FinalResultOnly,
+		Confidential:     j.Confidential,
+		ExternalJobID:    j.ExternalJobID,
+		NextJobID:        j.NextJobID,
+		ResultFormat:     j.ResultFormat,
+	}
+	if !j.MaxRunDuration.IsInstant() {
+		maxRunDuration := j.MaxRunDuration
+		jsr.MaxRunDuration = &maxRunDuration
+	}
+	for _, initr := range j.Initiators {
+		jsr.Initiators = append(jsr.Initiators, InitiatorRequest{
+			Type:            initr.Type,
+			InitiatorParams: initr.InitiatorParams,
+		})
+	}
+	for _, task := range j.Tasks {
+		jsr.Tasks = append(jsr.Tasks, TaskSpecRequest{
+			Name:                             task.Name,
+			Type:                             task.Type,
+			MinRequiredIncomingConfirmations: task.MinRequiredIncomingConfirmations,
+			MaxResultSize:                    task.MaxResultSize,
+			Params:                           task.Params,
+		})
+	}
+	return jsr
+}
+
 // Archived returns true if the job spec has been soft deleted
 func (j JobSpec) Archived() bool {
 	return j.DeletedAt.Valid
 }
 
+// Ephemeral returns true if the job spec is a temporary one, which is archived
+// once it expires
+func (j JobSpec) Ephemeral() bool {
+	return j.ExpiresAt.Valid
+}
+
+// Expired returns true if the job spec is an ephemeral one which has expired
+// by now
+func (j JobSpec) Expired(now time.Time) bool {
+	return j.ExpiresAt.Valid && !j.ExpiresAt.Time.After(now)
+}
+
+// Stopped returns true if the job spec has been saved without being started
+func (j JobSpec) Stopped() bool {
+	return j.Status == JobSpecStatusStopped
+}
+
+// Quarantined returns true if the job spec failed to start and is waiting to
+// be retried
+func (j JobSpec) Quarantined() bool {
+	return j.Status == JobSpecStatusFailed
+}
+
+// Paused returns true if the job spec has been paused and is waiting to be
+// resumed
+func (j JobSpec) Paused() bool {
+	return j.Status == JobSpecStatusPaused
+}
+
 // InitiatorsFor returns an array of Initiators for the given list of
 // Initiator types.
 func (j JobSpec) InitiatorsFor(types ...string) []Initiator {
@@ -184,6 +348,13 @@ const (
 	InitiatorFluxMonitor = "fluxmonitor"
 	// InitiatorRandomnessLog for tasks from a VRF specific contract
 	InitiatorRandomnessLog = "randomnesslog"
+	// InitiatorWebhook for tasks in a job to be run by a request signed
+	// with the initiator's secret, taking the request's body as input.
+	InitiatorWebhook = "webhook"
+	// InitiatorKeeper for performing the upkeeps of a keeper registry
+	// contract when checkUpkeep reports they are needed and it is the
+	// node's turn.
+	InitiatorKeeper = "keeper"
 )
 
 // Initiator could be thought of as a trigger, defines how a Job can be
@@ -226,6 +397,35 @@ type InitiatorParams struct {
 	AbsoluteThreshold float32         `json:"absoluteThreshold" gorm:"type:float;not null"`
 	PollTimer         PollTimerConfig `json:"pollTimer,omitempty" gorm:"type:jsonb"`
 	IdleTimer         IdleTimerConfig `json:"idleTimer,omitempty" gorm:"type:jsonb"`
+	// MinAnswers is the number of feeds which must answer before a round's
+	// deadline for a fluxmonitored value to be reported without the feeds
+	// which missed it. Zero requires most feeds to answer.
+	MinAnswers int32 `json:"minAnswers,omitempty" gorm:"not null;default:0"`
+
+	// WebhookSecret is the key of the HMAC-SHA256 signatures of the requests
+	// running a job with a webhook initiator. It is generated by the node
+	// and is not part of the job's spec; it is returned when the job is
+	// created, and carried alongside the spec where the job is recreated,
+	// see InitiatorWebhookSecret.
+	WebhookSecret string `json:"-"`
+	// WebhookRateLimit is the most runs per minute a webhook initiator
+	// creates, further requests being refused until the minute is up. Zero
+	// leaves the webhook unlimited.
+	WebhookRateLimit int32 `json:"rateLimit,omitempty" gorm:"not null;default:0"`
+	// PreviousWebhookSecret is the secret a webhook initiator had before it
+	// was last rotated, which is still accepted until
+	// PreviousWebhookSecretExpiresAt so that callers can switch to the new
+	// secret without downtime. Neither is part of the job's spec.
+	PreviousWebhookSecret          string    `json:"-" gorm:"not null;default:''"`
+	PreviousWebhookSecretExpiresAt null.Time `json:"-"`
+
+	// BlockCountPerTurn is the number of blocks each keeper of a registry
+	// performs an upkeep for before the turn passes to the next keeper.
+	BlockCountPerTurn int32 `json:"blockCountPerTurn,omitempty" gorm:"not null;default:0"`
+
+	// SkipOverlapping keeps a cron initiator from creating a run while the
+	// run it created last has not finished.
+	SkipOverlapping bool `json:"skipOverlapping,omitempty" gorm:"not null;default:false"`
 }
 
 type PollTimerConfig struct {
@@ -332,6 +532,11 @@ func NewInitiatorFromRequest(
 		Type:            strings.ToLower(initr.Type),
 		InitiatorParams: initr.InitiatorParams,
 	}
+	// A webhook's secret is not part of its spec, so each new webhook
+	// initiator, including those of duplicated jobs, gets its own
+	if ret.Type == InitiatorWebhook && ret.WebhookSecret == "" {
+		ret.WebhookSecret = NewWebhookSecret()
+	}
 	return ret
 }
 
@@ -346,23 +551,38 @@ func (i Initiator) IsLogInitiated() bool {
 }
 
 // Feeds holds the json of the feeds parameter in the job spec. It is an array of
-// URL strings and/or objects containing the names of bridges
+// URL strings and/or objects containing the names of bridges or the addresses
+// of aggregator contracts whose latest answers are read on chain
 type Feeds = JSON
 
 // TaskSpec is the definition of work to be carried out. The
 // Type will be an adapter, and the Params will contain any
 // additional information that adapter would need to operate.
+// A named task's result can be interpolated into the Params of later
+// tasks with $(name.result). MaxResultSize, when set, overrides the node's
+// MAX_TASK_RESULT_SIZE for the task.
 type TaskSpec struct {
 	ID                               int64         `gorm:"primary_key"`
 	JobSpecID                        *ID           `json:"-"`
+	Name                             string        `json:"name,omitempty" gorm:"not null"`
 	Type                             TaskType      `json:"type" gorm:"index;not null"`
 	MinRequiredIncomingConfirmations clnull.Uint32 `json:"confirmations" gorm:"column:confirmations"`
+	MaxResultSize                    clnull.Int64  `json:"maxResultSize"`
 	Params                           JSON          `json:"params" gorm:"type:text"`
 	CreatedAt                        time.Time
 	UpdatedAt                        time.Time
 	DeletedAt                        *time.Time
 }
 
+// ResultSizeLimit returns the largest result, in bytes, which the task may
+// store, given the node's default limit.
+func (t TaskSpec) ResultSizeLimit(defaultLimit int64) int64 {
+	if t.MaxResultSize.Valid {
+		return t.MaxResultSize.Int64
+	}
+	return defaultLimit
+}
+
 // TaskType defines what Adapter a TaskSpec will use.
 type TaskType string
 
@@ -0,0 +1,319 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// MaxJobSpecSize is the largest job spec, in bytes, which is accepted.
+	MaxJobSpecSize = 512 * 1024
+	// MaxJobSpecDepth is the deepest nesting of objects and arrays allowed in
+	// a job spec, task params included.
+	MaxJobSpecDepth = 32
+	// MaxJobSpecBatchSize is the largest batch of job specs, in bytes, which
+	// is accepted.
+	MaxJobSpecBatchSize = 4 * 1024 * 1024
+)
+
+// UnknownKeysError is returned when a job spec decoded strictly has keys
+// which do not belong to it, for instance because they are misspelt, and
+// would be ignored.
+type UnknownKeysError struct {
+	Keys []string
+}
+
+func (e *UnknownKeysError) Error() string {
+	return fmt.Sprintf("unknown keys in job spec: %s", strings.Join(e.Keys, ", "))
+}
+
+// JSONAPIErrors returns an error for each unknown key, with the key in its
+// meta, so that clients can point to each in the spec.
+func (e *UnknownKeysError) JSONAPIErrors() []JSONAPIError {
+	errs := make([]JSONAPIError, len(e.Keys))
+	for i, key := range e.Keys {
+		errs[i] = JSONAPIError{
+			Detail: fmt.Sprintf("unknown key in job spec: %s", key),
+			Meta:   map[string]interface{}{"key": key},
+		}
+	}
+	return errs
+}
+
+// JobSpecSyntaxError is returned when a job spec is not valid JSON, or has a
+// value of the wrong type, giving the line and column of the spec at which
+// the error was found, and for a value of the wrong type its key.
+type JobSpecSyntaxError struct {
+	Line   int
+	Column int
+	Key    string
+	Err    error
+}
+
+func newJobSpecSyntaxError(input []byte, offset int64, key string, err error) *JobSpecSyntaxError {
+	if offset > int64(len(input)) {
+		offset = int64(len(input))
+	}
+	before := input[:offset]
+	return &JobSpecSyntaxError{
+		Line:   bytes.Count(before, []byte("\n")) + 1,
+		Column: len(before) - bytes.LastIndexByte(before, '\n') - 1,
+		Key:    key,
+		Err:    err,
+	}
+}
+
+func (e *JobSpecSyntaxError) Error() string {
+	if e.Key != "" {
+		return fmt.Sprintf("invalid job spec at line %d, column %d, key %s: %v", e.Line, e.Column, e.Key, e.Err)
+	}
+	return fmt.Sprintf("invalid job spec at line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+// Cause returns the error of the JSON decoder.
+func (e *JobSpecSyntaxError) Cause() error {
+	return e.Err
+}
+
+// JSONAPIError returns the error with its location in its meta, so that
+// clients can point to it in the spec.
+func (e *JobSpecSyntaxError) JSONAPIError() JSONAPIError {
+	meta := map[string]interface{}{"line": e.Line, "column": e.Column}
+	if e.Key != "" {
+		meta["key"] = e.Key
+	}
+	return JSONAPIError{Detail: e.Error(), Meta: meta}
+}
+
+// syntaxError locates err, returned by the JSON decoder for input, in the
+// spec, if it is a syntax or type error.
+func syntaxError(input []byte, err error) error {
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		return newJobSpecSyntaxError(input, e.Offset, "", err)
+	case *json.UnmarshalTypeError:
+		return newJobSpecSyntaxError(input, e.Offset, e.Field, err)
+	}
+	if err == io.ErrUnexpectedEOF {
+		return newJobSpecSyntaxError(input, int64(len(input)), "", err)
+	}
+	return err
+}
+
+// DecodeJobSpecRequest decodes input into request, which is a JobSpecRequest
+// or a request embedding one. Unlike json.Unmarshal it fails on inputs larger
+// than MaxJobSpecSize or nested deeper than MaxJobSpecDepth, and on mixed
+// case addresses with bad EIP55 checksums in the address, to and fromAddress
+// params of tasks and the address of initiators. The other addresses in
+// those params are checksummed.
+//
+// Keys which match none of the request's fields are ignored, as
+// json.Unmarshal ignores them, and returned so that the caller can warn about
+// them or reject the spec with an UnknownKeysError. Task params are
+// free-form, so only their depth and known addresses are checked.
+func DecodeJobSpecRequest(input []byte, request interface{}) ([]string, error) {
+	if len(input) > MaxJobSpecSize {
+		return nil, fmt.Errorf("job spec is %d bytes, which is more than the limit of %d", len(input), MaxJobSpecSize)
+	}
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, syntaxError(input, err)
+	}
+	if depth := jsonDepth(generic); depth > MaxJobSpecDepth {
+		return nil, fmt.Errorf("job spec is nested %d levels deep, which is more than the limit of %d", depth, MaxJobSpecDepth)
+	}
+	var unknown []string
+	collectUnknownKeys(generic, reflect.TypeOf(request), "", &unknown)
+	sort.Strings(unknown)
+	var badChecksums []string
+	checksumAddresses(generic, &badChecksums)
+	if len(badChecksums) > 0 {
+		sort.Strings(badChecksums)
+		return nil, fmt.Errorf("addresses with invalid EIP55 checksums in job spec: %s", strings.Join(badChecksums, ", "))
+	}
+	normalized, err := json.Marshal(generic)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(normalized, request)
+	if _, ok := err.(*json.UnmarshalTypeError); ok {
+		// The offset of the error in the normalized spec is of no use to
+		// the user, so the spec they gave is decoded again to locate it
+		retry := reflect.New(reflect.TypeOf(request).Elem()).Interface()
+		if retryErr := json.Unmarshal(input, retry); retryErr != nil {
+			return nil, syntaxError(input, retryErr)
+		}
+	}
+	return unknown, err
+}
+
+var (
+	// initiatorAddressParams are the params of initiators which hold
+	// addresses, and are checksummed
+	initiatorAddressParams = []string{"address"}
+	// taskAddressParams are the params of tasks which hold addresses, and
+	// are checksummed
+	taskAddressParams = []string{"address", "to", "fromAddress"}
+)
+
+// checksumAddresses replaces each address given as one of the known address
+// params of the spec's initiators and tasks with its EIP55 checksummed form.
+// The path and value of each mixed case address whose checksum is wrong are
+// appended to bad instead, since its case suggests it was mistyped. Other
+// params are left as they are, since a value which looks like an address
+// need not be one.
+func checksumAddresses(spec interface{}, bad *[]string) {
+	object, ok := spec.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for key, value := range object {
+		switch strings.ToLower(key) {
+		case "initiators":
+			checksumAddressParams(value, key, initiatorAddressParams, bad)
+		case "tasks":
+			checksumAddressParams(value, key, taskAddressParams, bad)
+		}
+	}
+}
+
+func checksumAddressParams(list interface{}, path string, names []string, bad *[]string) {
+	items, _ := list.([]interface{})
+	for i, item := range items {
+		object, _ := item.(map[string]interface{})
+		for key, value := range object {
+			params, ok := value.(map[string]interface{})
+			if !ok || !strings.EqualFold(key, "params") {
+				continue
+			}
+			for _, name := range names {
+				address, ok := params[name].(string)
+				if !ok || !isHexAddressString(address) {
+					continue
+				}
+				if err := ValidateAddressChecksum(address); err != nil {
+					*bad = append(*bad, fmt.Sprintf("%s[%d].%s.%s (%s)", path, i, key, name, address))
+					continue
+				}
+				params[name] = common.HexToAddress(address).Hex()
+			}
+		}
+	}
+}
+
+func jsonDepth(value interface{}) int {
+	deepest := 0
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, child := range v {
+			if depth := jsonDepth(child); depth > deepest {
+				deepest = depth
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if depth := jsonDepth(child); depth > deepest {
+				deepest = depth
+			}
+		}
+	default:
+		return 0
+	}
+	return deepest + 1
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// collectUnknownKeys appends the path of each key of value which the type t
+// would not decode. Types which decode themselves are not checked.
+func collectUnknownKeys(value interface{}, t reflect.Type, path string, unknown *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for key, child := range object {
+			field, ok := lookupJSONField(fields, key)
+			if !ok {
+				*unknown = append(*unknown, path+key)
+				continue
+			}
+			collectUnknownKeys(child, field, path+key+".", unknown)
+		}
+	case reflect.Slice, reflect.Array:
+		array, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+		for i, child := range array {
+			collectUnknownKeys(child, t.Elem(), fmt.Sprintf("%s[%d].", strings.TrimSuffix(path, "."), i), unknown)
+		}
+	}
+}
+
+// jsonFields returns the type of each field of t by the key it is decoded
+// from, including the fields of embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, ft := range jsonFields(embedded) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = ft
+					}
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// lookupJSONField matches key to a field as encoding/json does, preferring an
+// exact match over a case insensitive one.
+func lookupJSONField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if field, ok := fields[key]; ok {
+		return field, true
+	}
+	for name, field := range fields {
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return nil, false
+}
@@ -1,17 +1,23 @@
 package models
 
 import (
+	"strconv"
 	"time"
+
+	null "gopkg.in/guregu/null.v3"
 )
 
-// JobSpecError represents an asynchronous error caused by a JobSpec
+// JobSpecError represents an asynchronous error caused by a JobSpec. An
+// operator may acknowledge an error to mark it as known, until it occurs
+// again.
 type JobSpecError struct {
-	ID          int64     `json:"id"`
-	JobSpecID   *ID       `json:"-"`
-	Description string    `json:"description"`
-	Occurrences uint      `json:"occurrences"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID             int64     `json:"id"`
+	JobSpecID      *ID       `json:"-"`
+	Description    string    `json:"description"`
+	Occurrences    uint      `json:"occurrences"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+	AcknowledgedAt null.Time `json:"acknowledgedAt"`
 }
 
 // NewJobSpecError creates a new JobSpecError struct
@@ -22,3 +28,25 @@ func NewJobSpecError(jobSpecID *ID, description string) JobSpecError {
 		Occurrences: 1,
 	}
 }
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (jse JobSpecError) GetID() string {
+	return strconv.FormatInt(jse.ID, 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi
+// serialization.
+func (jse JobSpecError) GetName() string {
+	return "job_spec_errors"
+}
+
+// SetID is used to set the ID of this structure when deserializing from
+// jsonapi documents.
+func (jse *JobSpecError) SetID(value string) error {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	jse.ID = id
+	return nil
+}
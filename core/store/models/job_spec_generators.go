@@ -0,0 +1,197 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// jobSpecGenerators generate the specs of the patterns of job known to
+// GenerateJobSpec, by name.
+var jobSpecGenerators = map[string]func(params []byte) (JobSpecRequest, error){
+	"median-price-feed": generateMedianPriceFeed,
+}
+
+// JobSpecPatterns returns the names of the patterns of job GenerateJobSpec
+// can generate the specs of, in order.
+func JobSpecPatterns() []string {
+	patterns := make([]string, 0, len(jobSpecGenerators))
+	for pattern := range jobSpecGenerators {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// GenerateJobSpec returns the spec of a job following one of the common
+// patterns of JobSpecPatterns, given the pattern's parameters as JSON. The
+// spec settles the choices operators new to the pattern are most likely to
+// get wrong, such as how many sources must answer and how often to report,
+// so that they need only give what is particular to their job.
+func GenerateJobSpec(pattern string, params []byte) (JobSpecRequest, error) {
+	generate, ok := jobSpecGenerators[pattern]
+	if !ok {
+		return JobSpecRequest{}, fmt.Errorf("unknown pattern %q, must be one of %s", pattern, strings.Join(JobSpecPatterns(), ", "))
+	}
+	return generate(params)
+}
+
+// decodeJobSpecGeneratorParams decodes the parameters of a pattern, rejecting
+// those the pattern does not have, which catches misspelt names.
+func decodeJobSpecGeneratorParams(params []byte, dst interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(params))
+	decoder.DisallowUnknownFields()
+	return errors.Wrap(decoder.Decode(dst), "invalid parameters")
+}
+
+// MedianPriceFeedParams are the parameters of the median-price-feed pattern,
+// a flux monitor job reporting the median of the prices given by several
+// sources to a FluxAggregator contract. Only the contract's address and the
+// sources are required.
+type MedianPriceFeedParams struct {
+	// Address is that of the FluxAggregator contract.
+	Address common.Address `json:"address"`
+	// Sources are the URLs of the sources, the names of the bridges to them,
+	// or the addresses of aggregators whose answers are forwarded.
+	Sources []string `json:"sources"`
+	// RequestData is posted to each source, and defaults to {}.
+	RequestData *JSON `json:"requestData"`
+	// Threshold is the relative change in the median which is reported
+	// straight away, and defaults to 0.5.
+	Threshold *float32 `json:"threshold"`
+	// AbsoluteThreshold is the absolute change in the median which must
+	// also be exceeded for it to be reported.
+	AbsoluteThreshold float32 `json:"absoluteThreshold"`
+	// Precision is the number of decimal places of the price reported, and
+	// defaults to 8.
+	Precision *int32 `json:"precision"`
+	// MinAnswers is how many sources must answer for a price to be reported,
+	// and defaults to a majority of them, so that no minority of failing
+	// sources can move the median.
+	MinAnswers int32 `json:"minAnswers"`
+	// Heartbeat is how long a price is reported after the last one however
+	// little it has changed, and defaults to an hour.
+	Heartbeat Duration `json:"heartbeat"`
+	// PollInterval is how often the sources are polled, and defaults to a
+	// minute.
+	PollInterval Duration `json:"pollInterval"`
+}
+
+func generateMedianPriceFeed(params []byte) (JobSpecRequest, error) {
+	var p MedianPriceFeedParams
+	if err := decodeJobSpecGeneratorParams(params, &p); err != nil {
+		return JobSpecRequest{}, err
+	}
+	if p.Address == utils.ZeroAddress {
+		return JobSpecRequest{}, errors.New("address of the FluxAggregator contract is required")
+	}
+	feeds, err := medianPriceFeedFeeds(p.Sources)
+	if err != nil {
+		return JobSpecRequest{}, err
+	}
+
+	requestData, err := ParseJSON([]byte("{}"))
+	if err != nil {
+		return JobSpecRequest{}, err
+	}
+	if p.RequestData != nil {
+		requestData = *p.RequestData
+	}
+	threshold := float32(0.5)
+	if p.Threshold != nil {
+		threshold = *p.Threshold
+	}
+	if threshold <= 0 || p.AbsoluteThreshold < 0 {
+		return JobSpecRequest{}, errors.New("threshold must be positive and absoluteThreshold must not be negative")
+	}
+	precision := int32(8)
+	if p.Precision != nil {
+		precision = *p.Precision
+	}
+	if precision < 0 || precision > 18 {
+		return JobSpecRequest{}, fmt.Errorf("precision must be from 0 to 18, got %d", precision)
+	}
+	minAnswers := p.MinAnswers
+	if minAnswers == 0 {
+		minAnswers = int32(len(p.Sources)/2 + 1)
+	} else if minAnswers < 0 || int(minAnswers) > len(p.Sources) {
+		return JobSpecRequest{}, fmt.Errorf("minAnswers must be from 1 to the %d sources, got %d", len(p.Sources), minAnswers)
+	}
+	heartbeat, pollInterval := p.Heartbeat, p.PollInterval
+	if heartbeat.IsInstant() {
+		heartbeat = MustMakeDuration(time.Hour)
+	}
+	if pollInterval.IsInstant() {
+		pollInterval = MustMakeDuration(time.Minute)
+	}
+
+	times, err := ParseJSON([]byte(fmt.Sprintf(`{"times":%q}`, decimal.New(1, precision).String())))
+	if err != nil {
+		return JobSpecRequest{}, err
+	}
+	return JobSpecRequest{
+		Initiators: []InitiatorRequest{{
+			Type: InitiatorFluxMonitor,
+			InitiatorParams: InitiatorParams{
+				Address:           p.Address,
+				RequestData:       requestData,
+				Feeds:             feeds,
+				Threshold:         threshold,
+				AbsoluteThreshold: p.AbsoluteThreshold,
+				Precision:         precision,
+				MinAnswers:        minAnswers,
+				IdleTimer:         IdleTimerConfig{Duration: heartbeat},
+				PollTimer:         PollTimerConfig{Period: pollInterval},
+			},
+		}},
+		Tasks: []TaskSpecRequest{
+			{Type: MustNewTaskType("multiply"), Params: times},
+			{Type: MustNewTaskType("ethint256")},
+			{Type: MustNewTaskType("ethtx")},
+		},
+	}, nil
+}
+
+// medianPriceFeedFeeds returns the feeds of a flux monitor initiator polling
+// the sources, which are URLs, addresses of aggregators or else the names of
+// bridges.
+func medianPriceFeedFeeds(sources []string) (Feeds, error) {
+	if len(sources) == 0 {
+		return Feeds{}, errors.New("at least one source is required")
+	}
+	seen := map[string]bool{}
+	feeds := make([]interface{}, len(sources))
+	for i, source := range sources {
+		if seen[source] {
+			return Feeds{}, fmt.Errorf("source %s is given more than once, which would weigh it twice in the median", source)
+		}
+		seen[source] = true
+		if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+			if _, err := url.ParseRequestURI(source); err != nil {
+				return Feeds{}, fmt.Errorf("invalid source URL %s: %v", source, err)
+			}
+			feeds[i] = source
+		} else if common.IsHexAddress(source) {
+			feeds[i] = map[string]string{"aggregator": source}
+		} else if _, err := NewTaskType(source); err != nil {
+			return Feeds{}, fmt.Errorf("source %s is neither a URL, an aggregator address nor the name of a bridge", source)
+		} else {
+			feeds[i] = map[string]string{"bridge": source}
+		}
+	}
+	b, err := json.Marshal(feeds)
+	if err != nil {
+		return Feeds{}, err
+	}
+	return ParseJSON(b)
+}
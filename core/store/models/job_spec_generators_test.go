@@ -0,0 +1,67 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateJobSpec_MedianPriceFeed(t *testing.T) {
+	t.Parallel()
+
+	jsr, err := models.GenerateJobSpec("median-price-feed", []byte(`{
+		"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42",
+		"sources": ["https://example.com/eth", "coingecko", "https://example.org/eth"],
+		"precision": 2
+	}`))
+	require.NoError(t, err)
+	require.Len(t, jsr.Initiators, 1)
+	params := jsr.Initiators[0].InitiatorParams
+	assert.Equal(t, models.InitiatorFluxMonitor, jsr.Initiators[0].Type)
+	assert.JSONEq(t, `["https://example.com/eth", {"bridge": "coingecko"}, "https://example.org/eth"]`, params.Feeds.String())
+	assert.Equal(t, int32(2), params.MinAnswers)
+	assert.Equal(t, float32(0.5), params.Threshold)
+	assert.Equal(t, "{}", params.RequestData.String())
+	assert.Equal(t, time.Hour, params.IdleTimer.Duration.Duration())
+	assert.Equal(t, time.Minute, params.PollTimer.Period.Duration())
+	require.Len(t, jsr.Tasks, 3)
+	assert.Equal(t, "100", jsr.Tasks[0].Params.Get("times").String())
+	assert.Equal(t, "ethint256", jsr.Tasks[1].Type.String())
+	assert.Equal(t, "ethtx", jsr.Tasks[2].Type.String())
+
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"no address", `{"sources": ["https://example.com"]}`},
+		{"no sources", `{"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"}`},
+		{"repeated source", `{"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "sources": ["a", "a"]}`},
+		{"too many minAnswers", `{"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "sources": ["a"], "minAnswers": 2}`},
+		{"misspelt parameter", `{"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "sources": ["a"], "treshold": 1}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := models.GenerateJobSpec("median-price-feed", []byte(test.params))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err = models.GenerateJobSpec("mean-price-feed", []byte(`{}`))
+	assert.EqualError(t, err, `unknown pattern "mean-price-feed", must be one of median-price-feed`)
+}
+
+func TestGenerateJobSpec_MedianPriceFeedForwardingAggregator(t *testing.T) {
+	t.Parallel()
+
+	jsr, err := models.GenerateJobSpec("median-price-feed", []byte(`{
+		"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42",
+		"sources": ["0x2aD9B7b9386c2f45223dDFc4A4d81C2957bAE19A"]
+	}`))
+	require.NoError(t, err)
+	require.Len(t, jsr.Initiators, 1)
+	assert.JSONEq(t, `[{"aggregator": "0x2aD9B7b9386c2f45223dDFc4A4d81C2957bAE19A"}]`, jsr.Initiators[0].InitiatorParams.Feeds.String())
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	jobSpecTemplateNameRegex     = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+	jobSpecTemplateVariableRegex = regexp.MustCompile(`{{\s*([A-Za-z_][A-Za-z0-9_]*)\s*}}`)
+)
+
+// JobSpecTemplate is a job spec with {{variable}} placeholders, from which
+// many similar jobs can be created by giving the values of its variables.
+// Values are substituted as the contents of JSON strings, so placeholders
+// are usually quoted, as in "address": "{{address}}", but may stand alone
+// where a number is expected.
+type JobSpecTemplate struct {
+	Name      string    `json:"name" gorm:"primary_key"`
+	Namespace string    `json:"namespace" gorm:"primary_key"`
+	Template  string    `json:"template"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NewJobSpecTemplate returns a JobSpecTemplate, or an error if its name is
+// not alphanumeric with dashes and underscores, or if it is not a JSON
+// document once its variables are given values.
+func NewJobSpecTemplate(name, template string) (JobSpecTemplate, error) {
+	if !jobSpecTemplateNameRegex.MatchString(name) || len(name) > 255 {
+		return JobSpecTemplate{}, fmt.Errorf("invalid template name %q, must be alphanumeric with dashes and underscores", name)
+	}
+	t := JobSpecTemplate{Name: name, Template: template}
+	sample := map[string]string{}
+	for _, variable := range t.Variables() {
+		sample[variable] = "0"
+	}
+	rendered, err := t.Render(sample)
+	if err != nil {
+		return JobSpecTemplate{}, err
+	}
+	if !json.Valid(rendered) {
+		return JobSpecTemplate{}, errors.New("template is not a JSON document once its variables are substituted")
+	}
+	return t, nil
+}
+
+// Variables returns the names of the template's variables, in order.
+func (t JobSpecTemplate) Variables() []string {
+	seen := map[string]bool{}
+	variables := []string{}
+	for _, match := range jobSpecTemplateVariableRegex.FindAllStringSubmatch(t.Template, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			variables = append(variables, match[1])
+		}
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+// Render substitutes the values of the template's variables, returning the
+// body of a job spec request. Every variable must be given a value, and
+// values must not be given for variables the template does not have, which
+// catches misspelt names.
+func (t JobSpecTemplate) Render(values map[string]string) ([]byte, error) {
+	var missing, unknown []string
+	variables := t.Variables()
+	for _, variable := range variables {
+		if _, ok := values[variable]; !ok {
+			missing = append(missing, variable)
+		}
+	}
+	for name := range values {
+		if i := sort.SearchStrings(variables, name); i == len(variables) || variables[i] != name {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no values given for variables %s", strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("template %s has no variables %s", t.Name, strings.Join(unknown, ", "))
+	}
+
+	rendered := jobSpecTemplateVariableRegex.ReplaceAllStringFunc(t.Template, func(placeholder string) string {
+		name := jobSpecTemplateVariableRegex.FindStringSubmatch(placeholder)[1]
+		quoted, _ := json.Marshal(values[name])
+		return string(quoted[1 : len(quoted)-1])
+	})
+	return []byte(rendered), nil
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (t JobSpecTemplate) GetID() string {
+	return t.Name
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (t JobSpecTemplate) GetName() string {
+	return "job_spec_templates"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (t *JobSpecTemplate) SetID(value string) error {
+	t.Name = value
+	return nil
+}
+
+// JobSpecTemplateRequest is a request to create a JobSpecTemplate.
+type JobSpecTemplateRequest struct {
+	Name     string `json:"name"`
+	Template string `json:"template"`
+}
+
+// JobSpecFromTemplateRequest is a request to create a job from a
+// JobSpecTemplate, giving the values of its variables.
+type JobSpecFromTemplateRequest struct {
+	Variables map[string]string `json:"variables"`
+}
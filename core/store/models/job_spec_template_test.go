@@ -0,0 +1,51 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJobSpecTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{"quoted placeholders", `{"address": "{{address}}"}`, false},
+		{"bare placeholders", `{"times": {{ times }}}`, false},
+		{"no placeholders", `{"tasks": []}`, false},
+		{"invalid JSON", `{"address": {{address}`, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := models.NewJobSpecTemplate("feed", test.template)
+			assert.Equal(t, test.wantErr, err != nil)
+		})
+	}
+
+	_, err := models.NewJobSpecTemplate("price feed", `{}`)
+	assert.Error(t, err)
+}
+
+func TestJobSpecTemplate_Render(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := models.NewJobSpecTemplate("feed", `{"name": "{{name}}", "address": "{{address}}", "again": "{{ name }}"}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"address", "name"}, tmpl.Variables())
+
+	rendered, err := tmpl.Render(map[string]string{"name": `eth "usd"`, "address": "0x01"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "eth \"usd\"", "address": "0x01", "again": "eth \"usd\""}`, string(rendered))
+
+	_, err = tmpl.Render(map[string]string{"name": "eth"})
+	assert.EqualError(t, err, "no values given for variables address")
+	_, err = tmpl.Render(map[string]string{"name": "eth", "address": "0x01", "adress": "0x01"})
+	assert.EqualError(t, err, "template feed has no variables adress")
+}
@@ -2,6 +2,7 @@ package models_test
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -288,6 +289,17 @@ func TestJobSpec_Started(t *testing.T) {
 	}
 }
 
+func TestJobSpec_Stopped(t *testing.T) {
+	t.Parallel()
+
+	job := cltest.NewJob()
+	assert.Equal(t, models.JobSpecStatusActive, job.Status)
+	assert.False(t, job.Stopped())
+
+	job.Status = models.JobSpecStatusStopped
+	assert.True(t, job.Stopped())
+}
+
 func TestNewTaskType(t *testing.T) {
 	t.Parallel()
 
@@ -315,3 +327,118 @@ func TestNewTaskType(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeJobSpecRequest(t *testing.T) {
+	t.Parallel()
+
+	var jsr models.JobSpecRequest
+	unknown, err := models.DecodeJobSpecRequest([]byte(`{
+		"Initiators": [{"type": "runlog", "params": {"address": "0x356a04bCe728ba4c62A30294A55E6A8600a320B3"}}],
+		"tasks": [{"type": "httpget", "params": {"anything": {"goes": "here"}}}],
+		"finalResultOnly": true
+	}`), &jsr)
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+	assert.Len(t, jsr.Initiators, 1)
+	assert.Equal(t, "0x356a04bCe728ba4c62A30294A55E6A8600a320B3", jsr.Initiators[0].Address.Hex())
+	assert.True(t, jsr.FinalResultOnly)
+
+	var preview models.JobSpecPreviewRequest
+	_, err = models.DecodeJobSpecRequest([]byte(`{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}], "params": {"a": 1}, "recordHTTP": true}`), &preview)
+	require.NoError(t, err)
+	assert.True(t, preview.RecordHTTP)
+
+	jsr = models.JobSpecRequest{}
+	unknown, err = models.DecodeJobSpecRequest([]byte(`{
+		"initiators": [{"type": "runlog", "params": {"adress": "0x356a04bCe728ba4c62A30294A55E6A8600a320B3"}}],
+		"tasks": [{"type": "noop", "confirmation": 3}],
+		"finalresultsonly": true,
+		"_comment": "not a field"
+	}`), &jsr)
+	require.NoError(t, err, "unknown keys are ignored")
+	assert.Equal(t, []string{"_comment", "finalresultsonly", "initiators[0].params.adress", "tasks[0].confirmation"}, unknown)
+	require.Len(t, jsr.Tasks, 1)
+	assert.Equal(t, "noop", jsr.Tasks[0].Type.String())
+
+	deep := strings.Repeat(`{"a":`, models.MaxJobSpecDepth) + "1" + strings.Repeat("}", models.MaxJobSpecDepth)
+	_, err = models.DecodeJobSpecRequest([]byte(`{"tasks": [{"type": "noop", "params": `+deep+`}]}`), &jsr)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "nested")
+
+	large := `{"tasks": [{"type": "noop", "params": {"a": "` + strings.Repeat("x", models.MaxJobSpecSize) + `"}}]}`
+	_, err = models.DecodeJobSpecRequest([]byte(large), &jsr)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "bytes")
+}
+
+func TestDecodeJobSpecRequest_SyntaxErrors(t *testing.T) {
+	t.Parallel()
+
+	var jsr models.JobSpecRequest
+	_, err := models.DecodeJobSpecRequest([]byte("{\n  \"initiators\": [{\"type\": \"web\"}],\n  \"tasks\": [{\"type\": \"noop\"},]\n}"), &jsr)
+	require.IsType(t, &models.JobSpecSyntaxError{}, err)
+	syntaxErr := err.(*models.JobSpecSyntaxError)
+	assert.Equal(t, 3, syntaxErr.Line)
+	assert.Equal(t, 30, syntaxErr.Column)
+	assert.Empty(t, syntaxErr.Key)
+
+	_, err = models.DecodeJobSpecRequest([]byte("{\n  \"tasks\": [{\"type\": \"noop\"}],\n  \"finalResultOnly\": \"yes\"\n}"), &jsr)
+	require.IsType(t, &models.JobSpecSyntaxError{}, err)
+	syntaxErr = err.(*models.JobSpecSyntaxError)
+	assert.Equal(t, 3, syntaxErr.Line)
+	assert.Equal(t, "finalResultOnly", syntaxErr.Key)
+	assert.Contains(t, err.Error(), "line 3")
+
+	_, err = models.DecodeJobSpecRequest([]byte("{\n  \"tasks\": ["), &jsr)
+	require.IsType(t, &models.JobSpecSyntaxError{}, err)
+	assert.Equal(t, 2, err.(*models.JobSpecSyntaxError).Line)
+}
+
+func TestDecodeJobSpecRequest_Addresses(t *testing.T) {
+	t.Parallel()
+
+	var jsr models.JobSpecRequest
+	_, err := models.DecodeJobSpecRequest([]byte(`{
+		"initiators": [{"type": "runlog", "params": {"address": "0xa0788fc17b1dee36f057c42b6f373a34b014687e"}}],
+		"tasks": [
+			{"type": "ethtx", "params": {"address": "0xA0788FC17B1DEE36F057C42B6F373A34B014687E", "functionSelector": "0x609ff1bd"}},
+			{"type": "httppost", "params": {"body": {"id": "0xa0788fc17b1dee36f057c42b6f373a34B014687E"}, "to": "0xa0788fc17b1dee36f057c42b6f373a34b014687e"}}
+		]
+	}`), &jsr)
+	require.NoError(t, err)
+	assert.Equal(t, "0xa0788FC17B1dEe36f057c42B6F373A34B014687e", jsr.Initiators[0].Address.Hex())
+	assert.Equal(t, "0xa0788FC17B1dEe36f057c42B6F373A34B014687e", jsr.Tasks[0].Params.Get("address").String())
+	assert.Equal(t, "0x609ff1bd", jsr.Tasks[0].Params.Get("functionSelector").String())
+	assert.Equal(t, "0xa0788FC17B1dEe36f057c42B6F373A34B014687e", jsr.Tasks[1].Params.Get("to").String())
+	assert.Equal(t, "0xa0788fc17b1dee36f057c42b6f373a34B014687E", jsr.Tasks[1].Params.Get("body.id").String(), "values which are not known addresses are left alone")
+
+	_, err = models.DecodeJobSpecRequest([]byte(`{
+		"initiators": [{"type": "runlog", "params": {"address": "0xA0788FC17B1dEe36f057c42B6F373A34B014687e"}}],
+		"tasks": [{"type": "ethtx", "params": {"address": "0xa0788FC17B1dEe36f057c42B6F373A34B014687e"}}]
+	}`), &jsr)
+	require.Error(t, err)
+	assert.Equal(t, "addresses with invalid EIP55 checksums in job spec: initiators[0].params.address (0xA0788FC17B1dEe36f057c42B6F373A34B014687e)", err.Error())
+}
+
+func TestSameJobSpec(t *testing.T) {
+	t.Parallel()
+
+	startAt := time.Date(2030, 1, 1, 12, 0, 0, 123456789, time.FixedZone("CET", 3600))
+	job := cltest.NewJobWithWebInitiator()
+	job.StartAt = null.TimeFrom(startAt)
+
+	stored := job
+	stored.ID = models.NewID()
+	stored.Status = models.JobSpecStatusPaused
+	stored.StartAt = null.TimeFrom(startAt.UTC().Round(time.Microsecond))
+	same, err := models.SameJobSpec(job, stored)
+	require.NoError(t, err)
+	assert.True(t, same, "the jobs' state and the zones of their times should not matter")
+
+	changed := job
+	changed.Tasks = append([]models.TaskSpec{}, job.Tasks...)
+	changed.Tasks = append(changed.Tasks, models.TaskSpec{Type: adapters.TaskTypeNoOp})
+	same, err = models.SameJobSpec(job, changed)
+	require.NoError(t, err)
+	assert.False(t, same)
+}
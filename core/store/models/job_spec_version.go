@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// JobSpecVersion is a revision of a job's spec, as it was created or last
+// updated, kept so that operators can see what changed and when, and roll
+// the job back to an earlier revision. Versions are numbered from 1 in the
+// order they were made, and a rollback makes a new version rather than
+// discarding the later ones.
+type JobSpecVersion struct {
+	ID        int64     `json:"-" gorm:"primary_key"`
+	JobSpecID *ID       `json:"jobSpecId"`
+	Version   int32     `json:"version"`
+	Spec      JSON      `json:"spec"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewJobSpecVersion returns the version of the job's spec as it is now, to
+// be numbered when it is saved.
+func NewJobSpecVersion(job JobSpec) (JobSpecVersion, error) {
+	spec, err := CanonicalJobSpec(job)
+	if err != nil {
+		return JobSpecVersion{}, err
+	}
+	data, err := ParseJSON(spec)
+	if err != nil {
+		return JobSpecVersion{}, err
+	}
+	return JobSpecVersion{JobSpecID: job.ID, Spec: data, CreatedAt: time.Now()}, nil
+}
+
+// Request returns the request which recreates this version of the spec.
+func (v JobSpecVersion) Request() (JobSpecRequest, error) {
+	var jsr JobSpecRequest
+	_, err := DecodeJobSpecRequest(v.Spec.Bytes(), &jsr)
+	return jsr, err
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (v JobSpecVersion) GetID() string {
+	return strconv.FormatInt(int64(v.Version), 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (v JobSpecVersion) GetName() string {
+	return "job_spec_versions"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (v *JobSpecVersion) SetID(value string) error {
+	version, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return err
+	}
+	v.Version = int32(version)
+	return nil
+}
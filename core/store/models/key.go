@@ -31,6 +31,8 @@ type Key struct {
 	// IsFunding marks the address as being used for rescuing the  node and the pending transactions
 	// Only one key can be IsFunding=true at a time.
 	IsFunding bool
+	// Namespace is the namespace whose jobs may send transactions from this key
+	Namespace string `gorm:"default:'default';not null"`
 }
 
 // NewKeyFromFile creates an instance in memory from a key file on disk.
@@ -0,0 +1,258 @@
+package models
+
+import (
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultNamespace holds everything created without naming a namespace,
+// including all jobs, bridges and keys which predate namespaces.
+const DefaultNamespace = "default"
+
+var namespaceNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Namespace partitions the jobs, bridges, keys and external initiators of a
+// node which is shared by several teams. Jobs may only use the bridges and
+// keys of their own namespace, and external initiators may only trigger runs
+// of jobs in their own namespace.
+//
+// A namespace's quotas keep one team from starving the others; an unset
+// quota is unlimited.
+type Namespace struct {
+	Name      string    `json:"name" gorm:"primary_key"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	NamespaceQuotas
+	SpecPolicy NamespaceSpecPolicy `json:"specPolicy" gorm:"type:jsonb"`
+}
+
+// NamespaceQuotas limit the work the jobs of a namespace may create.
+type NamespaceQuotas struct {
+	// MaxJobs is the number of unarchived jobs the namespace may hold.
+	MaxJobs clnull.Int64 `json:"maxJobs"`
+	// MaxRunsPerMinute is the number of runs the namespace's jobs may create
+	// between them in any minute.
+	MaxRunsPerMinute clnull.Int64 `json:"maxRunsPerMinute"`
+	// MaxGasPerDay is the total gas limit of the transactions which the
+	// namespace's keys may queue in any 24 hours.
+	MaxGasPerDay clnull.Int64 `json:"maxGasPerDay"`
+}
+
+// Validate returns an error if any quota is negative.
+func (q NamespaceQuotas) Validate() error {
+	for name, quota := range map[string]clnull.Int64{
+		"maxJobs":          q.MaxJobs,
+		"maxRunsPerMinute": q.MaxRunsPerMinute,
+		"maxGasPerDay":     q.MaxGasPerDay,
+	} {
+		if quota.Valid && quota.Int64 < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// NamespaceSpecPolicy restricts what the jobs of a namespace may do, so that
+// the operators of a namespace can deploy jobs without being able to send
+// arbitrary transactions or reach the node's internal network. Jobs are
+// checked against the policy when they are created; an empty list leaves
+// that part of a job unrestricted.
+type NamespaceSpecPolicy struct {
+	// AllowedURLs are the URLs tasks and bridges may call, for example
+	// https://*.example.com/*. The scheme, host and path of a URL are
+	// matched separately: a * in the host matches a single label, and a *
+	// in the path, with its query, matches any run of characters.
+	AllowedURLs []string `json:"allowedURLs,omitempty"`
+	// AllowedMethods are the HTTP methods tasks and bridges may call with.
+	AllowedMethods []string `json:"allowedMethods,omitempty"`
+	// AllowedTaskTypes are the task types jobs may use, including the names
+	// of bridges.
+	AllowedTaskTypes []TaskType `json:"allowedTaskTypes,omitempty"`
+}
+
+// Validate returns an error if any URL pattern is empty or any method is not
+// an HTTP method tasks call with.
+func (p NamespaceSpecPolicy) Validate() error {
+	for _, pattern := range p.AllowedURLs {
+		if strings.TrimSpace(pattern) == "" {
+			return errors.New("allowedURLs must not contain empty patterns")
+		} else if !urlPatternRegex.MatchString(strings.TrimSpace(pattern)) {
+			return fmt.Errorf("allowedURLs pattern %q must have a scheme and a host", pattern)
+		}
+	}
+	for _, method := range p.AllowedMethods {
+		if method != http.MethodGet && method != http.MethodPost {
+			return fmt.Errorf("allowedMethods must be %s or %s, got %q", http.MethodGet, http.MethodPost, method)
+		}
+	}
+	return nil
+}
+
+// urlPatternRegex splits an allowed URL pattern into its scheme, host and
+// path.
+var urlPatternRegex = regexp.MustCompile(`^([^:/?#]+)://([^/?#]+)(.*)$`)
+
+// AllowsURL returns whether the policy allows calls to rawURL. URLs with a
+// user are never allowed, as the host of a pattern could otherwise be
+// passed off as one.
+func (p NamespaceSpecPolicy) AllowsURL(rawURL string) bool {
+	if len(p.AllowedURLs) == 0 {
+		return true
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User != nil || u.Opaque != "" || u.Host == "" {
+		return false
+	}
+	path := u.EscapedPath()
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	for _, pattern := range p.AllowedURLs {
+		parts := urlPatternRegex.FindStringSubmatch(strings.TrimSpace(pattern))
+		if parts == nil || !strings.EqualFold(parts[1], u.Scheme) {
+			continue
+		}
+		if wildcardRegexp(strings.ToLower(parts[2]), `[^./@:]+`).MatchString(strings.ToLower(u.Host)) &&
+			wildcardRegexp(parts[3], `.*`).MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardRegexp returns a regexp matching pattern, with each * in it
+// matching wildcard.
+func wildcardRegexp(pattern, wildcard string) *regexp.Regexp {
+	return regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, wildcard) + "$")
+}
+
+// AllowsMethod returns whether the policy allows calls with the HTTP method.
+func (p NamespaceSpecPolicy) AllowsMethod(method string) bool {
+	if len(p.AllowedMethods) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsTaskType returns whether the policy allows tasks of the given type.
+func (p NamespaceSpecPolicy) AllowsTaskType(taskType TaskType) bool {
+	if len(p.AllowedTaskTypes) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedTaskTypes {
+		if allowed == taskType {
+			return true
+		}
+	}
+	return false
+}
+
+// Value stores the policy as JSONB.
+func (p NamespaceSpecPolicy) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan reads the policy from JSONB.
+func (p *NamespaceSpecPolicy) Scan(value interface{}) error {
+	if value == nil {
+		*p = NamespaceSpecPolicy{}
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("invalid Scan Source")
+	}
+	return json.Unmarshal(b, p)
+}
+
+// NewNamespace returns a Namespace with the given name, or an error if the
+// name is not lowercase alphanumeric with dashes and underscores.
+func NewNamespace(name string) (Namespace, error) {
+	if !namespaceNameRegex.MatchString(name) || len(name) > 255 {
+		return Namespace{}, fmt.Errorf("invalid namespace name %q, must be lowercase alphanumeric with dashes and underscores", name)
+	}
+	return Namespace{Name: name}, nil
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (ns Namespace) GetID() string {
+	return ns.Name
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (ns Namespace) GetName() string {
+	return "namespaces"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (ns *Namespace) SetID(value string) error {
+	ns.Name = value
+	return nil
+}
+
+// NamespaceToken is an API token confined to a namespace, which lets a team
+// sharing the node use the API without reaching the jobs, bridges and keys
+// of the other namespaces, or the node's own settings.
+type NamespaceToken struct {
+	ID           int64     `json:"-" gorm:"primary_key"`
+	Namespace    string    `json:"namespace"`
+	AccessKey    string    `json:"accessKey"`
+	Salt         string    `json:"-"`
+	HashedSecret string    `json:"-"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// NewNamespaceToken returns a NamespaceToken for token, storing only a salted
+// hash of its secret.
+func NewNamespaceToken(namespace string, token *auth.Token) (NamespaceToken, error) {
+	salt := utils.NewSecret(utils.DefaultSecretSize)
+	hashedSecret, err := auth.HashedSecret(token, salt)
+	if err != nil {
+		return NamespaceToken{}, errors.Wrap(err, "error hashing secret for namespace token")
+	}
+	return NamespaceToken{
+		Namespace:    namespace,
+		AccessKey:    token.AccessKey,
+		Salt:         salt,
+		HashedSecret: hashedSecret,
+	}, nil
+}
+
+// Authenticate returns whether token is the token nt was created for.
+func (nt NamespaceToken) Authenticate(token *auth.Token) (bool, error) {
+	hashedSecret, err := auth.HashedSecret(token, nt.Salt)
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare([]byte(hashedSecret), []byte(nt.HashedSecret)) == 1, nil
+}
+
+// NamespaceRequest is a request to create a Namespace.
+type NamespaceRequest struct {
+	Name string `json:"name"`
+	NamespaceQuotas
+	SpecPolicy NamespaceSpecPolicy `json:"specPolicy"`
+}
+
+// NamespaceKeyRequest moves one of the node's keys into a Namespace.
+type NamespaceKeyRequest struct {
+	Address EIP55Address `json:"address"`
+}
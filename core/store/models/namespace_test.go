@@ -0,0 +1,58 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceSpecPolicy_AllowsURL(t *testing.T) {
+	t.Parallel()
+
+	policy := models.NamespaceSpecPolicy{AllowedURLs: []string{
+		"https://api.example.com/*",
+		"https://prices.example.org/v1/eth?usd=1",
+		"https://*.feeds.example.net/*",
+	}}
+
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://api.example.com/price", true},
+		{"https://api.example.com/", true},
+		{"https://api.example.com", false},
+		{"https://api.example.com.evil.io/price", false},
+		{"http://api.example.com/price", false},
+		{"https://prices.example.org/v1/eth?usd=1", true},
+		{"https://prices.example.org/v1/eth?usd=10", false},
+		{"https://pricesXexample.org/v1/eth?usd=1", false},
+		{"https://eth.feeds.example.net/price", true},
+		{"HTTPS://ETH.feeds.example.net/price", true},
+		{"https://evil.io/.feeds.example.net/price", false},
+		{"https://evil.io?.feeds.example.net/price", false},
+		{"https://a.b.feeds.example.net/price", false},
+		{"https://user@eth.feeds.example.net/price", false},
+		{"https://api.example.com@evil.io/price", false},
+		{"https://api.example.com:8080/price", false},
+		{"https://evil.io/https://api.example.com/price", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.allowed, policy.AllowsURL(test.url), test.url)
+	}
+
+	assert.True(t, models.NamespaceSpecPolicy{}.AllowsURL("http://10.0.0.1/"))
+}
+
+func TestNamespaceSpecPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, models.NamespaceSpecPolicy{}.Validate())
+	assert.NoError(t, models.NamespaceSpecPolicy{AllowedMethods: []string{"GET", "POST"}}.Validate())
+	assert.Error(t, models.NamespaceSpecPolicy{AllowedMethods: []string{"get"}}.Validate())
+	assert.Error(t, models.NamespaceSpecPolicy{AllowedURLs: []string{" "}}.Validate())
+	assert.Error(t, models.NamespaceSpecPolicy{AllowedURLs: []string{"*"}}.Validate())
+	assert.NoError(t, models.NamespaceSpecPolicy{AllowedURLs: []string{"https://*.example.com/*"}}.Validate())
+}
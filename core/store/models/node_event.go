@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// NodeEvent is a domain event recorded by the database as the node's state
+// changes, waiting to be published to the configured event sink. Each Type
+// has its own Payload schema, identified by Version.
+type NodeEvent struct {
+	ID        int64     `json:"id" gorm:"primary_key"`
+	Type      string    `json:"type"`
+	Version   int       `json:"version"`
+	Payload   JSON      `json:"payload" gorm:"type:jsonb"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// The types of NodeEvent.
+const (
+	NodeEventJobSpecCreated  = "job_spec.created"
+	NodeEventJobSpecUpdated  = "job_spec.updated"
+	NodeEventJobSpecDeleted  = "job_spec.deleted"
+	NodeEventJobRunCompleted = "job_run.completed"
+	NodeEventJobRunErrored   = "job_run.errored"
+	NodeEventJobRunCancelled = "job_run.cancelled"
+	NodeEventEthTxConfirmed  = "eth_tx.confirmed"
+	NodeEventKeyCreated      = "key.created"
+)
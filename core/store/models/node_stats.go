@@ -0,0 +1,40 @@
+package models
+
+// NodeStats summarises the health of a node for its operators: its jobs by
+// status, the runs started recently by status, the errors of its jobs which
+// are yet to be acknowledged, and the latest head it has seen. The size of
+// its database and the calls it has made to its ethereum node since it
+// started show the load it is under.
+type NodeStats struct {
+	Version       string                `json:"version"`
+	Jobs          map[JobSpecStatus]int `json:"jobs"`
+	RecentRuns    map[RunStatus]int     `json:"recentRuns"`
+	JobSpecErrors int                   `json:"jobSpecErrors"`
+	LatestHead    *int64                `json:"latestHead"`
+	DatabaseSize  int64                 `json:"databaseSize"`
+	EthRPCCalls   uint64                `json:"ethRPCCalls"`
+}
+
+// Add adds the counts of other to those of s. The latest head becomes the
+// highest of the two, and the version is kept.
+func (s *NodeStats) Add(other NodeStats) {
+	if s.Jobs == nil {
+		s.Jobs = map[JobSpecStatus]int{}
+	}
+	for status, count := range other.Jobs {
+		s.Jobs[status] += count
+	}
+	if s.RecentRuns == nil {
+		s.RecentRuns = map[RunStatus]int{}
+	}
+	for status, count := range other.RecentRuns {
+		s.RecentRuns[status] += count
+	}
+	s.JobSpecErrors += other.JobSpecErrors
+	s.DatabaseSize += other.DatabaseSize
+	s.EthRPCCalls += other.EthRPCCalls
+	if other.LatestHead != nil && (s.LatestHead == nil || *other.LatestHead > *s.LatestHead) {
+		head := *other.LatestHead
+		s.LatestHead = &head
+	}
+}
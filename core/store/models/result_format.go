@@ -0,0 +1,142 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// ResultRoundingRound rounds a formatted result half away from zero.
+	ResultRoundingRound = "round"
+	// ResultRoundingTruncate truncates a formatted result towards zero.
+	ResultRoundingTruncate = "truncate"
+)
+
+// ResultFormat is the final formatting a job applies to the result of its
+// runs before the result is encoded for the chain, by the first ethint256,
+// ethuint256, ethtx, ethtxabiencode or ethtxcommitreveal task of the job.
+// Settling the formatting once per job keeps it from being spread,
+// inconsistently, across multiply tasks, flux monitor precisions and
+// consumer contracts.
+//
+// The result is multiplied by Multiplier, if given, and then rounded or
+// truncated, as Rounding says, to Decimals decimal places. Decimals
+// defaults to 0, the integer the encodings need, and Rounding to round.
+// Only numeric results are formatted; see FormatData.
+type ResultFormat struct {
+	Multiplier *decimal.Decimal `json:"multiplier,omitempty"`
+	Decimals   int32            `json:"decimals,omitempty"`
+	Rounding   string           `json:"rounding,omitempty"`
+}
+
+// Validate returns an error if the format's decimals are negative or its
+// rounding is unknown.
+func (f ResultFormat) Validate() error {
+	if f.Decimals < 0 {
+		return fmt.Errorf("resultFormat decimals cannot be negative, got %d", f.Decimals)
+	}
+	switch f.Rounding {
+	case "", ResultRoundingRound, ResultRoundingTruncate:
+	default:
+		return fmt.Errorf("unknown resultFormat rounding %q, must be %s or %s", f.Rounding, ResultRoundingRound, ResultRoundingTruncate)
+	}
+	return nil
+}
+
+// Format returns the result formatted as a decimal string, or an error if
+// the result is not a number.
+func (f ResultFormat) Format(result gjson.Result) (string, error) {
+	value, err := DecimalValue(result)
+	if err != nil {
+		return "", errors.Wrap(err, "formatting result")
+	}
+	if f.Multiplier != nil {
+		value = value.Mul(*f.Multiplier)
+	}
+	if f.Rounding == ResultRoundingTruncate {
+		value = value.Truncate(f.Decimals)
+	} else {
+		value = value.Round(f.Decimals)
+	}
+	return value.String(), nil
+}
+
+// FormatData returns data with its result formatted. A numeric result, a
+// number or a decimal string, is formatted in place. A result object, the
+// arguments an ethtxabiencode task encodes, has only its numericArgs
+// formatted. Anything else, including hex strings that may well be bytes,
+// is left as it is.
+func (f ResultFormat) FormatData(data JSON, numericArgs []string) (JSON, error) {
+	result := data.Get("result")
+	if result.IsObject() {
+		args, ok := result.Value().(map[string]interface{})
+		if !ok {
+			return data, nil
+		}
+		values := result.Map()
+		for _, name := range numericArgs {
+			arg, ok := values[name]
+			if !ok || !isNumericResult(arg) {
+				continue
+			}
+			formatted, err := f.Format(arg)
+			if err != nil {
+				return data, errors.Wrapf(err, "formatting argument %s", name)
+			}
+			args[name] = formatted
+		}
+		return data.Add("result", args)
+	}
+	if !isNumericResult(result) {
+		return data, nil
+	}
+	formatted, err := f.Format(result)
+	if err != nil {
+		return data, err
+	}
+	return data.Add("result", formatted)
+}
+
+// isNumericResult returns whether value is a JSON number or a decimal
+// string.
+func isNumericResult(value gjson.Result) bool {
+	switch value.Type {
+	case gjson.Number:
+		return true
+	case gjson.String:
+		text := strings.TrimSpace(value.Str)
+		if utils.HasHexPrefix(text) {
+			return false
+		}
+		_, err := decimal.NewFromString(text)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+// Value stores the format as JSONB.
+func (f ResultFormat) Value() (driver.Value, error) {
+	return json.Marshal(f)
+}
+
+// Scan reads the format from JSONB.
+func (f *ResultFormat) Scan(value interface{}) error {
+	if value == nil {
+		*f = ResultFormat{}
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("invalid Scan Source")
+	}
+	return json.Unmarshal(b, f)
+}
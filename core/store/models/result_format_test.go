@@ -0,0 +1,81 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestResultFormat_Format(t *testing.T) {
+	t.Parallel()
+
+	hundred := decimal.NewFromInt(100)
+	tests := []struct {
+		name   string
+		format models.ResultFormat
+		result string
+		want   string
+	}{
+		{"defaults round to an integer", models.ResultFormat{}, `"12.5"`, "13"},
+		{"multiplier", models.ResultFormat{Multiplier: &hundred}, `1.2345`, "123"},
+		{"truncate", models.ResultFormat{Multiplier: &hundred, Rounding: models.ResultRoundingTruncate}, `1.2399`, "123"},
+		{"truncate negative", models.ResultFormat{Rounding: models.ResultRoundingTruncate}, `-1.9`, "-1"},
+		{"decimals", models.ResultFormat{Decimals: 2}, `"3.14159"`, "3.14"},
+		{"hex", models.ResultFormat{Multiplier: &hundred}, `"0x10"`, "1600"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, test.format.Validate())
+			formatted, err := test.format.Format(gjson.Parse(test.result))
+			require.NoError(t, err)
+			assert.Equal(t, test.want, formatted)
+		})
+	}
+
+	_, err := models.ResultFormat{}.Format(gjson.Parse(`"abc"`))
+	assert.Error(t, err)
+}
+
+func TestResultFormat_FormatData(t *testing.T) {
+	t.Parallel()
+
+	hundred := decimal.NewFromInt(100)
+	format := models.ResultFormat{Multiplier: &hundred}
+	tests := []struct {
+		name        string
+		data        string
+		numericArgs []string
+		want        string
+	}{
+		{"number", `{"result": 1.2345}`, nil, `"123"`},
+		{"decimal string", `{"result": "1.2345"}`, nil, `"123"`},
+		{"hex left as bytes", `{"result": "0x1234"}`, nil, `"0x1234"`},
+		{"text left alone", `{"result": "abc"}`, nil, `"abc"`},
+		{"named numeric arguments", `{"result": {"a": "1.5", "b": "0x10", "c": "2"}}`, []string{"a", "b"}, `{"a":"150","b":"0x10","c":"2"}`},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			data, err := format.FormatData(cltest.JSONFromString(t, test.data), test.numericArgs)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.want, data.Get("result").Raw)
+		})
+	}
+}
+
+func TestResultFormat_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, models.ResultFormat{Decimals: -1}.Validate())
+	assert.Error(t, models.ResultFormat{Rounding: "ceil"}.Validate())
+	assert.NoError(t, models.ResultFormat{Decimals: 8, Rounding: models.ResultRoundingRound}.Validate())
+}
@@ -0,0 +1,206 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// ValueType is a type which a task may require of its input's result. Results
+// are passed between tasks as JSON, and each task coerces the result into the
+// type it needs with the functions below, which follow these rules:
+//
+//   decimal: a JSON number, read exactly from its text rather than as a
+//            float64, or a string holding a decimal, scientific or 0x
+//            prefixed hex number
+//   int:     as decimal, with an error if there is a fractional part
+//   bytes:   a 0x prefixed hex string is decoded, any other string is taken
+//            as its UTF-8 bytes
+//   string:  a JSON string, or the text of a number or bool
+//   bool:    a JSON bool, or the strings "true" and "false"
+//   array:   a JSON array
+//   map:     a JSON object
+//
+// Any other conversion, for example of a bool to a decimal or of an object to
+// a string, is a *TypeMismatchError rather than a guess.
+type ValueType string
+
+const (
+	// ValueTypeDecimal is an arbitrary precision decimal number.
+	ValueTypeDecimal ValueType = "decimal"
+	// ValueTypeInt is an arbitrary precision integer.
+	ValueTypeInt ValueType = "int"
+	// ValueTypeBytes is a byte array.
+	ValueTypeBytes ValueType = "bytes"
+	// ValueTypeString is a UTF-8 string.
+	ValueTypeString ValueType = "string"
+	// ValueTypeBool is a boolean.
+	ValueTypeBool ValueType = "bool"
+	// ValueTypeArray is a JSON array.
+	ValueTypeArray ValueType = "array"
+	// ValueTypeMap is a JSON object.
+	ValueTypeMap ValueType = "map"
+)
+
+// TypeMismatchError is returned when a result cannot be coerced into the
+// type a task requires.
+type TypeMismatchError struct {
+	Want   ValueType
+	Value  gjson.Result
+	Reason string
+}
+
+func (e *TypeMismatchError) Error() string {
+	msg := fmt.Sprintf("cannot use %s %s as %s", jsonKind(e.Value), abbreviate(e.Value.Raw), e.Want)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+func typeMismatch(want ValueType, value gjson.Result, reason string) error {
+	return &TypeMismatchError{Want: want, Value: value, Reason: reason}
+}
+
+// DecimalValue coerces value into a decimal.
+func DecimalValue(value gjson.Result) (decimal.Decimal, error) {
+	var text string
+	switch value.Type {
+	case gjson.Number:
+		text = value.Raw
+		if text == "" {
+			return decimal.NewFromFloat(value.Num), nil
+		}
+	case gjson.String:
+		text = strings.TrimSpace(value.Str)
+		if utils.HasHexPrefix(text) {
+			i, ok := new(big.Int).SetString(utils.RemoveHexPrefix(text), 16)
+			if !ok {
+				return decimal.Decimal{}, typeMismatch(ValueTypeDecimal, value, "invalid hex number")
+			}
+			return decimal.NewFromBigInt(i, 0), nil
+		}
+	default:
+		return decimal.Decimal{}, typeMismatch(ValueTypeDecimal, value, "")
+	}
+
+	d, err := decimal.NewFromString(text)
+	if err != nil {
+		return decimal.Decimal{}, typeMismatch(ValueTypeDecimal, value, "not a number")
+	}
+	return d, nil
+}
+
+// IntValue coerces value into an integer.
+func IntValue(value gjson.Result) (*big.Int, error) {
+	d, err := DecimalValue(value)
+	if err != nil {
+		if mismatch, ok := err.(*TypeMismatchError); ok {
+			mismatch.Want = ValueTypeInt
+		}
+		return nil, err
+	}
+	if !d.Equal(d.Truncate(0)) {
+		return nil, typeMismatch(ValueTypeInt, value, "has a fractional part")
+	}
+	return d.BigInt(), nil
+}
+
+// BytesValue coerces value into a byte array.
+func BytesValue(value gjson.Result) ([]byte, error) {
+	if value.Type != gjson.String {
+		return nil, typeMismatch(ValueTypeBytes, value, "")
+	}
+	if !utils.HasHexPrefix(value.Str) {
+		return []byte(value.Str), nil
+	}
+	b, err := hexutil.Decode(value.Str)
+	if err != nil {
+		return nil, typeMismatch(ValueTypeBytes, value, err.Error())
+	}
+	return b, nil
+}
+
+// StringValue coerces value into a string.
+func StringValue(value gjson.Result) (string, error) {
+	switch value.Type {
+	case gjson.String:
+		return value.Str, nil
+	case gjson.Number, gjson.True, gjson.False:
+		return value.Raw, nil
+	default:
+		return "", typeMismatch(ValueTypeString, value, "")
+	}
+}
+
+// BoolValue coerces value into a bool.
+func BoolValue(value gjson.Result) (bool, error) {
+	switch value.Type {
+	case gjson.True:
+		return true, nil
+	case gjson.False:
+		return false, nil
+	case gjson.String:
+		switch strings.ToLower(strings.TrimSpace(value.Str)) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return false, typeMismatch(ValueTypeBool, value, `only "true" and "false" are bools`)
+	default:
+		return false, typeMismatch(ValueTypeBool, value, "")
+	}
+}
+
+// ArrayValue coerces value into an array.
+func ArrayValue(value gjson.Result) ([]gjson.Result, error) {
+	if !value.IsArray() {
+		return nil, typeMismatch(ValueTypeArray, value, "")
+	}
+	return value.Array(), nil
+}
+
+// MapValue coerces value into a map.
+func MapValue(value gjson.Result) (map[string]gjson.Result, error) {
+	if !value.IsObject() {
+		return nil, typeMismatch(ValueTypeMap, value, "")
+	}
+	return value.Map(), nil
+}
+
+func jsonKind(value gjson.Result) string {
+	switch value.Type {
+	case gjson.Null:
+		if !value.Exists() {
+			return "missing value"
+		}
+		return "null"
+	case gjson.False, gjson.True:
+		return "bool"
+	case gjson.Number:
+		return "number"
+	case gjson.String:
+		return "string"
+	default:
+		if value.IsArray() {
+			return "array"
+		}
+		return "object"
+	}
+}
+
+const maxMismatchValueLength = 64
+
+func abbreviate(raw string) string {
+	if len(raw) <= maxMismatchValueLength {
+		return raw
+	}
+	return fmt.Sprintf("%s... (%d more bytes)", raw[:maxMismatchValueLength], len(raw)-maxMismatchValueLength)
+}
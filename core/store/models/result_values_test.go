@@ -0,0 +1,119 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestDecimalValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		json  string
+		want  string
+		error string
+	}{
+		{"number", `1.23`, "1.23", ""},
+		{"large number", `123456789012345678901234567890.5`, "123456789012345678901234567890.5", ""},
+		{"scientific", `1.68e+4`, "16800", ""},
+		{"string", `"-99.994"`, "-99.994", ""},
+		{"hex string", `"0x7b"`, "123", ""},
+		{"not a number", `"ETH"`, "", `cannot use string "ETH" as decimal: not a number`},
+		{"bool", `true`, "", `cannot use bool true as decimal`},
+		{"object", `{"price": 1}`, "", `cannot use object {"price": 1} as decimal`},
+		{"null", `null`, "", `cannot use null null as decimal`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := models.DecimalValue(gjson.Parse(test.json))
+			if test.error != "" {
+				assert.EqualError(t, err, test.error)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, d.String())
+		})
+	}
+}
+
+func TestIntValue(t *testing.T) {
+	t.Parallel()
+
+	i, err := models.IntValue(gjson.Parse(`"12345678901234567890123"`))
+	require.NoError(t, err)
+	assert.Equal(t, "12345678901234567890123", i.String())
+
+	i, err = models.IntValue(gjson.Parse(`1.2e+2`))
+	require.NoError(t, err)
+	assert.Equal(t, "120", i.String())
+
+	_, err = models.IntValue(gjson.Parse(`123.99`))
+	assert.EqualError(t, err, "cannot use number 123.99 as int: has a fractional part")
+
+	_, err = models.IntValue(gjson.Parse(`false`))
+	assert.EqualError(t, err, "cannot use bool false as int")
+}
+
+func TestBytesValue(t *testing.T) {
+	t.Parallel()
+
+	b, err := models.BytesValue(gjson.Parse(`"0xdeadbeef"`))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, b)
+
+	b, err = models.BytesValue(gjson.Parse(`"hello"`))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), b)
+
+	_, err = models.BytesValue(gjson.Parse(`"0xabc"`))
+	assert.Error(t, err)
+
+	_, err = models.BytesValue(gjson.Parse(`123`))
+	assert.EqualError(t, err, "cannot use number 123 as bytes")
+}
+
+func TestStringAndBoolValues(t *testing.T) {
+	t.Parallel()
+
+	s, err := models.StringValue(gjson.Parse(`1.50`))
+	require.NoError(t, err)
+	assert.Equal(t, "1.50", s)
+
+	_, err = models.StringValue(gjson.Parse(`["a"]`))
+	assert.EqualError(t, err, `cannot use array ["a"] as string`)
+
+	b, err := models.BoolValue(gjson.Parse(`"True"`))
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	_, err = models.BoolValue(gjson.Parse(`1`))
+	assert.EqualError(t, err, "cannot use number 1 as bool")
+
+	_, err = models.BoolValue(gjson.Parse(`"yes"`))
+	assert.EqualError(t, err, `cannot use string "yes" as bool: only "true" and "false" are bools`)
+}
+
+func TestArrayAndMapValues(t *testing.T) {
+	t.Parallel()
+
+	elements, err := models.ArrayValue(gjson.Parse(`[1, "a"]`))
+	require.NoError(t, err)
+	assert.Len(t, elements, 2)
+
+	_, err = models.ArrayValue(gjson.Parse(`{}`))
+	assert.EqualError(t, err, "cannot use object {} as array")
+
+	values, err := models.MapValue(gjson.Parse(`{"a": 1}`))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), values["a"].Int())
+
+	_, err = models.MapValue(gjson.Parse(`"a"`))
+	assert.EqualError(t, err, `cannot use string "a" as map`)
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// RunDataCipherSetting is the name of the gorm setting holding the
+// RunDataCipher which the runs of confidential jobs are encrypted with.
+const RunDataCipherSetting = "chainlink:run_data_cipher"
+
+// confidentialDataKey is the only key of the JSON which the encrypted data of
+// a confidential run is stored as.
+const confidentialDataKey = "confidential"
+
+// RunDataCipher encrypts the request params and results of the runs of
+// confidential jobs before they are stored, so that access to the database
+// does not imply access to the data. Each job's data is encrypted with its
+// own key, derived from the node's key and the job's ID.
+type RunDataCipher struct {
+	key []byte
+}
+
+// NewRunDataCipher returns a RunDataCipher deriving job keys from key, which
+// must be 32 bytes long.
+func NewRunDataCipher(key []byte) (*RunDataCipher, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("run data encryption key must be 32 bytes, got %d", len(key))
+	}
+	return &RunDataCipher{key: key}, nil
+}
+
+// NewRunDataCipherFromHex returns a RunDataCipher for the hex encoded key.
+func NewRunDataCipherFromHex(key string) (*RunDataCipher, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "run data encryption key must be hex encoded")
+	}
+	return NewRunDataCipher(b)
+}
+
+func (c *RunDataCipher) aead(jobID *ID) (cipher.AEAD, error) {
+	if jobID == nil {
+		return nil, errors.New("cannot derive the run data key of a run without a job")
+	}
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write(jobID.Bytes())
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt returns data encrypted with the key of the job. Empty data is
+// returned as is.
+func (c *RunDataCipher) Encrypt(jobID *ID, data JSON) (JSON, error) {
+	plaintext := data.Bytes()
+	if len(plaintext) == 0 || IsEncryptedRunData(data) {
+		return data, nil
+	}
+	aead, err := c.aead(jobID)
+	if err != nil {
+		return JSON{}, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return JSON{}, err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, jobID.Bytes())
+	return JSON{}.Add(confidentialDataKey, base64.StdEncoding.EncodeToString(sealed))
+}
+
+// Decrypt reverses Encrypt. Data which is not encrypted is returned as is.
+func (c *RunDataCipher) Decrypt(jobID *ID, data JSON) (JSON, error) {
+	if !IsEncryptedRunData(data) {
+		return data, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(data.Get(confidentialDataKey).String())
+	if err != nil {
+		return JSON{}, errors.Wrap(err, "while decoding run data")
+	}
+	aead, err := c.aead(jobID)
+	if err != nil {
+		return JSON{}, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return JSON{}, errors.New("encrypted run data is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, jobID.Bytes())
+	if err != nil {
+		return JSON{}, errors.Wrap(err, "while decrypting run data")
+	}
+	return ParseJSON(plaintext)
+}
+
+// IsEncryptedRunData returns whether data is the encrypted data of a
+// confidential run.
+func IsEncryptedRunData(data JSON) bool {
+	if !data.IsObject() {
+		return false
+	}
+	fields := data.Map()
+	_, ok := fields[confidentialDataKey]
+	return ok && len(fields) == 1
+}
+
+// runDataCipher returns the RunDataCipher set on the scope's database, if
+// any.
+func runDataCipher(scope *gorm.Scope) *RunDataCipher {
+	value, ok := scope.Get(RunDataCipherSetting)
+	if !ok {
+		return nil
+	}
+	c, _ := value.(*RunDataCipher)
+	return c
+}
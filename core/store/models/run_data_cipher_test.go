@@ -0,0 +1,84 @@
+package models_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDataCipher_EncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	c, err := models.NewRunDataCipher(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	jobID := models.NewID()
+	data := models.JSON{}
+	data, err = data.Add("price", "123.45")
+	require.NoError(t, err)
+
+	encrypted, err := c.Encrypt(jobID, data)
+	require.NoError(t, err)
+	assert.True(t, models.IsEncryptedRunData(encrypted))
+	assert.NotContains(t, encrypted.String(), "123.45")
+
+	decrypted, err := c.Decrypt(jobID, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "123.45", decrypted.Get("price").String())
+
+	_, err = c.Decrypt(models.NewID(), encrypted)
+	assert.Error(t, err, "data of one job must not decrypt with the key of another")
+}
+
+func TestRunDataCipher_PassesThroughEmptyAndPlainData(t *testing.T) {
+	t.Parallel()
+
+	c, err := models.NewRunDataCipherFromHex("0x" + string(bytes.Repeat([]byte("ab"), 32)))
+	require.NoError(t, err)
+
+	encrypted, err := c.Encrypt(models.NewID(), models.JSON{})
+	require.NoError(t, err)
+	assert.Empty(t, encrypted.Bytes())
+
+	plain := models.JSON{}
+	plain, err = plain.Add("a", 1)
+	require.NoError(t, err)
+	decrypted, err := c.Decrypt(models.NewID(), plain)
+	require.NoError(t, err)
+	assert.Equal(t, plain, decrypted)
+}
+
+func TestNewRunDataCipher_RejectsShortKeys(t *testing.T) {
+	t.Parallel()
+
+	_, err := models.NewRunDataCipher([]byte{1, 2, 3})
+	assert.Error(t, err)
+	_, err = models.NewRunDataCipherFromHex("not hex")
+	assert.Error(t, err)
+}
+
+func TestJobRun_Redacted(t *testing.T) {
+	t.Parallel()
+
+	params := models.JSON{}
+	params, err := params.Add("secret", "value")
+	require.NoError(t, err)
+	jr := models.JobRun{
+		Confidential: true,
+		RunRequest:   models.RunRequest{RequestParams: params},
+		Result:       models.RunResult{Data: params},
+		TaskRuns:     []models.TaskRun{{Result: models.RunResult{Data: params}}},
+	}
+
+	redacted := jr.Redacted()
+	assert.Empty(t, redacted.Result.Data.Bytes())
+	assert.Empty(t, redacted.TaskRuns[0].Result.Data.Bytes())
+	assert.Equal(t, "value", jr.TaskRuns[0].Result.Data.Get("secret").String())
+
+	jr.Confidential = false
+	assert.Equal(t, "value", jr.Redacted().Result.Data.Get("secret").String())
+}
@@ -2,8 +2,10 @@ package models
 
 import (
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
 )
 
 // RunOutput represents the result of performing a Task
@@ -21,6 +23,16 @@ func NewRunOutputError(err error) RunOutput {
 	}
 }
 
+// NewRunOutputErrorWithData returns a new RunOutput with an error, and data
+// describing it for the run's result
+func NewRunOutputErrorWithData(err error, data JSON) RunOutput {
+	return RunOutput{
+		status: RunStatusErrored,
+		data:   data,
+		err:    err,
+	}
+}
+
 // NewRunOutputCompleteWithResult returns a new RunOutput that is complete and
 // contains a result
 func NewRunOutputCompleteWithResult(resultVal interface{}) RunOutput {
@@ -57,6 +69,13 @@ func NewRunOutputPendingConnectionWithData(data JSON) RunOutput {
 	return RunOutput{status: RunStatusPendingConnection, data: data}
 }
 
+// NewRunOutputPendingSleepWithData returns a new RunOutput that indicates
+// the task is waiting for some time to pass, with data that needs to be fed in
+// on next invocation
+func NewRunOutputPendingSleepWithData(data JSON) RunOutput {
+	return RunOutput{status: RunStatusPendingSleep, data: data}
+}
+
 // NewRunOutputInProgress returns a new RunOutput that indicates the
 // task is still in progress
 func NewRunOutputInProgress(data JSON) RunOutput {
@@ -94,6 +113,49 @@ func (ro RunOutput) Data() JSON {
 	return ro.data
 }
 
+// TruncateResult returns the output with its data replaced by a truncated
+// result if the data is larger than limit bytes, see TruncateResultData.
+func (ro RunOutput) TruncateResult(limit int64) (RunOutput, bool) {
+	data, truncated := TruncateResultData(ro.data, limit)
+	ro.data = data
+	return ro, truncated
+}
+
+// TruncateResultData returns data unchanged if it is no larger than limit
+// bytes, or if limit is zero. Otherwise it returns only the first limit bytes
+// of the text of its result, marked as truncated along with the original
+// size of the data. The other keys of data are dropped:
+//   {"result": "<prefix>", "truncated": {"size": 52428800, "limit": 1048576}}
+// A truncated result is never encoded for the chain, see IsTruncated.
+func TruncateResultData(data JSON, limit int64) (JSON, bool) {
+	size := int64(len(data.Raw))
+	if limit <= 0 || size <= limit {
+		return data, false
+	}
+
+	prefix := data.Get("result").String()
+	if int64(len(prefix)) > limit {
+		prefix = prefix[:limit]
+	}
+	for len(prefix) > 0 && !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	raw, err := sjson.Set(`{}`, "result", prefix)
+	if err == nil {
+		raw, err = sjson.Set(raw, "truncated", map[string]int64{"size": size, "limit": limit})
+	}
+	if err != nil {
+		panic(fmt.Sprintf("invariant violated, set should not fail on a JSON object %v", err))
+	}
+	return JSON{Result: gjson.Parse(raw)}, true
+}
+
+// IsTruncated returns whether data holds a result truncated by
+// TruncateResultData.
+func IsTruncated(data JSON) bool {
+	return data.Get("truncated.limit").Exists()
+}
+
 // Status returns the status returned from a task
 func (ro RunOutput) Status() RunStatus {
 	return ro.status
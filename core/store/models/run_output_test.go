@@ -0,0 +1,60 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateResultData(t *testing.T) {
+	t.Parallel()
+
+	data := cltest.JSONFromString(t, `{"result": "`+strings.Repeat("é", 20)+`"}`)
+
+	unchanged, truncated := models.TruncateResultData(data, 0)
+	assert.False(t, truncated)
+	assert.Equal(t, data, unchanged)
+
+	unchanged, truncated = models.TruncateResultData(data, int64(len(data.Raw)))
+	assert.False(t, truncated)
+	assert.Equal(t, data, unchanged)
+
+	limited, truncated := models.TruncateResultData(data, 9)
+	assert.True(t, truncated)
+	assert.True(t, models.IsTruncated(limited))
+	assert.False(t, models.IsTruncated(data))
+	assert.Equal(t, "éééé", limited.Get("result").String())
+	assert.Equal(t, int64(len(data.Raw)), limited.Get("truncated.size").Int())
+	assert.Equal(t, int64(9), limited.Get("truncated.limit").Int())
+
+	// The whole of the data is measured, and only the start of the result kept
+	withOthers := cltest.JSONFromString(t, `{"result": "`+strings.Repeat("a", 20)+`", "other": "`+strings.Repeat("b", 100)+`", "big": 123456789012345678901}`)
+	unchanged, truncated = models.TruncateResultData(withOthers, int64(len(withOthers.Raw)))
+	assert.False(t, truncated)
+	assert.Equal(t, withOthers, unchanged)
+
+	limited, truncated = models.TruncateResultData(withOthers, 30)
+	assert.True(t, truncated)
+	assert.Equal(t, strings.Repeat("a", 20), limited.Get("result").String())
+	assert.False(t, limited.Get("other").Exists())
+	assert.False(t, limited.Get("big").Exists())
+	assert.Equal(t, int64(len(withOthers.Raw)), limited.Get("truncated.size").Int())
+}
+
+func TestRunOutput_TruncateResult(t *testing.T) {
+	t.Parallel()
+
+	output := models.NewRunOutputCompleteWithResult(strings.Repeat("a", 100))
+	limited, truncated := output.TruncateResult(10)
+	assert.True(t, truncated)
+	assert.Equal(t, models.RunStatusCompleted, limited.Status())
+	assert.Equal(t, strings.Repeat("a", 10), limited.Result().String())
+
+	errored := models.NewRunOutputError(assert.AnError)
+	_, truncated = errored.TruncateResult(10)
+	assert.False(t, truncated)
+}
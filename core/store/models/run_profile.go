@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// RunProfile holds the pprof profiles taken while a run, created with
+// ?profile=true, last executed. The CPU profile covers the whole node during
+// the execution, and is missing if another CPU profile was being taken at
+// the time. The heap profile is taken once the execution finished.
+type RunProfile struct {
+	JobRunID  *ID `gorm:"primary_key"`
+	CPU       []byte
+	Heap      []byte
+	CreatedAt time.Time
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// RunUpdate is a change in the status of a job run, or of one of its task
+// runs when TaskRunID is set.
+type RunUpdate struct {
+	JobID     *ID       `json:"jobId"`
+	JobRunID  *ID       `json:"jobRunId"`
+	TaskRunID *ID       `json:"taskRunId,omitempty"`
+	TaskName  string    `json:"taskName,omitempty"`
+	TaskType  TaskType  `json:"taskType,omitempty"`
+	Status    RunStatus `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NewRunUpdate returns the update for the status of run.
+func NewRunUpdate(run JobRun) RunUpdate {
+	return RunUpdate{
+		JobID:     run.JobSpecID,
+		JobRunID:  run.ID,
+		Status:    run.Status,
+		Error:     run.Result.ErrorMessage.String,
+		UpdatedAt: run.UpdatedAt,
+	}
+}
+
+// NewTaskRunUpdate returns the update for the status of one of run's task
+// runs.
+func NewTaskRunUpdate(run JobRun, tr TaskRun) RunUpdate {
+	return RunUpdate{
+		JobID:     run.JobSpecID,
+		JobRunID:  run.ID,
+		TaskRunID: tr.ID,
+		TaskName:  tr.TaskSpec.Name,
+		TaskType:  tr.TaskSpec.Type,
+		Status:    tr.Status,
+		Error:     tr.Result.ErrorMessage.String,
+		UpdatedAt: tr.UpdatedAt,
+	}
+}
+
+const (
+	// RunEventCreated is the event of a run being created.
+	RunEventCreated = "created"
+	// RunEventCompleted is the event of a run completing.
+	RunEventCompleted = "completed"
+	// RunEventErrored is the event of a run erroring.
+	RunEventErrored = "errored"
+)
+
+// RunEvent is a milestone in the life of a job run: its creation, or its
+// completion or failure.
+type RunEvent struct {
+	Event string `json:"event"`
+	RunUpdate
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// SourceLatency holds the most recent latencies of the requests sent to an
+// external source, in nanoseconds and oldest first, so that the adaptive
+// timeouts derived from them survive the node restarting.
+type SourceLatency struct {
+	Source    string    `gorm:"primary_key"`
+	Samples   JSON      `gorm:"type:jsonb"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+var (
+	taskNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+	// taskVariableRegex matches $(name.result), optionally followed by a
+	// gjson path into the result such as $(name.result.prices.0)
+	taskVariableRegex = regexp.MustCompile(`\$\(([a-zA-Z][a-zA-Z0-9_]*)\.result((?:\.[^)]+)?)\)`)
+)
+
+// ValidateTaskName returns an error if name cannot be referred to by the
+// $(name.result) variables of later tasks.
+func ValidateTaskName(name string) error {
+	if !taskNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid task name %q, must start with a letter and contain only letters, digits and underscores", name)
+	}
+	return nil
+}
+
+// TaskVariableReferences returns the names of the tasks whose results are
+// interpolated into params.
+func TaskVariableReferences(params JSON) []string {
+	var names []string
+	for _, match := range taskVariableRegex.FindAllStringSubmatch(params.String(), -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// InterpolateTaskVariables replaces every $(name.result) in the string values
+// of params with the result of the named task. A string which consists of
+// nothing but a variable is replaced by the result's JSON value, so that
+// numbers stay numbers; variables within longer strings are substituted as
+// text.
+func InterpolateTaskVariables(params JSON, results map[string]JSON) (JSON, error) {
+	if !params.Exists() || !taskVariableRegex.MatchString(params.String()) {
+		return params, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(params.Bytes()))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return JSON{}, err
+	}
+	interpolated, err := interpolateValue(value, results)
+	if err != nil {
+		return JSON{}, err
+	}
+	b, err := json.Marshal(interpolated)
+	if err != nil {
+		return JSON{}, err
+	}
+	return ParseJSON(b)
+}
+
+func interpolateValue(value interface{}, results map[string]JSON) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			interpolated, err := interpolateValue(elem, results)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = interpolated
+		}
+		return v, nil
+	case []interface{}:
+		for i, elem := range v {
+			interpolated, err := interpolateValue(elem, results)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = interpolated
+		}
+		return v, nil
+	case string:
+		return interpolateString(v, results)
+	default:
+		return v, nil
+	}
+}
+
+func interpolateString(s string, results map[string]JSON) (interface{}, error) {
+	if match := taskVariableRegex.FindStringSubmatchIndex(s); match != nil && match[0] == 0 && match[1] == len(s) {
+		result, err := lookupTaskVariable(s[match[2]:match[3]], s[match[4]:match[5]], results)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(result.Raw), nil
+	}
+
+	var err error
+	interpolated := taskVariableRegex.ReplaceAllStringFunc(s, func(variable string) string {
+		submatches := taskVariableRegex.FindStringSubmatch(variable)
+		result, lookupErr := lookupTaskVariable(submatches[1], submatches[2], results)
+		if lookupErr != nil {
+			err = lookupErr
+			return variable
+		}
+		return result.String()
+	})
+	return interpolated, err
+}
+
+func lookupTaskVariable(name, path string, results map[string]JSON) (gjson.Result, error) {
+	data, ok := results[name]
+	if !ok {
+		return gjson.Result{}, fmt.Errorf("no result from task %q to interpolate, it must be a completed earlier task", name)
+	}
+	result := data.Get("result")
+	if path != "" {
+		result = result.Get(strings.TrimPrefix(path, "."))
+	}
+	if !result.Exists() {
+		return gjson.Result{}, fmt.Errorf("task %q has no result at $(%s.result%s)", name, name, path)
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateTaskVariables(t *testing.T) {
+	t.Parallel()
+
+	results := map[string]models.JSON{
+		"fetch":  cltest.JSONFromString(t, `{"result": {"price": 1234567890123456789, "url": "https://example.com"}}`),
+		"factor": cltest.JSONFromString(t, `{"result": "100"}`),
+	}
+
+	tests := []struct {
+		name    string
+		params  string
+		want    string
+		wantErr bool
+	}{
+		{"no variables", `{"times": 100}`, `{"times": 100}`, false},
+		{"whole value keeps its type", `{"value": "$(fetch.result.price)"}`, `{"value": 1234567890123456789}`, false},
+		{"whole result", `{"value": "$(fetch.result)"}`, `{"value": {"price": 1234567890123456789, "url": "https://example.com"}}`, false},
+		{"within a string", `{"get": "$(fetch.result.url)/price?times=$(factor.result)"}`, `{"get": "https://example.com/price?times=100"}`, false},
+		{"nested", `{"body": {"values": ["$(factor.result)", 1]}}`, `{"body": {"values": ["100", 1]}}`, false},
+		{"unknown task", `{"times": "$(missing.result)"}`, ``, true},
+		{"missing path", `{"times": "$(fetch.result.volume)"}`, ``, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			interpolated, err := models.InterpolateTaskVariables(cltest.JSONFromString(t, test.params), results)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, test.want, interpolated.String())
+		})
+	}
+}
+
+func TestTaskVariableReferences(t *testing.T) {
+	t.Parallel()
+
+	params := cltest.JSONFromString(t, `{"get": "$(a.result)/$(b_2.result.x)", "times": "$(a.result)"}`)
+	assert.ElementsMatch(t, []string{"a", "b_2", "a"}, models.TaskVariableReferences(params))
+	assert.Empty(t, models.TaskVariableReferences(cltest.JSONFromString(t, `{"times": 100}`)))
+}
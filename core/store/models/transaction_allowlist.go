@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TransactionAllowlistEntry allows the node to transact with a contract which
+// none of its jobs use, when the transaction allowlist is enabled. The
+// contracts addressed by the initiators and tasks of the node's jobs are
+// always allowed.
+type TransactionAllowlistEntry struct {
+	Address   common.Address `json:"address" gorm:"primary_key"`
+	Note      string         `json:"note" gorm:"not null"`
+	AddedBy   string         `json:"addedBy" gorm:"not null"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// TableName returns the name of the table entries are stored in.
+func (TransactionAllowlistEntry) TableName() string { return "transaction_allowlist" }
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (e TransactionAllowlistEntry) GetID() string {
+	return e.Address.Hex()
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi
+// serialization.
+func (e TransactionAllowlistEntry) GetName() string {
+	return "transaction_allowlist_entries"
+}
+
+// SetID is used to set the ID of this structure when deserializing from
+// jsonapi documents.
+func (e *TransactionAllowlistEntry) SetID(value string) error {
+	if !common.IsHexAddress(value) {
+		return fmt.Errorf("%s is not a valid address", value)
+	}
+	e.Address = common.HexToAddress(value)
+	return nil
+}
+
+// TransactionAllowlistRequest adds a contract to the transaction allowlist,
+// with a note on why the node transacts with it.
+type TransactionAllowlistRequest struct {
+	Address common.Address `json:"address"`
+	Note    string         `json:"note"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	null "gopkg.in/guregu/null.v3"
+)
+
+// TransmissionHalt stops the node from broadcasting any new transaction,
+// including OCR report transmissions, until it is resumed. Runs still
+// execute, but the transactions they create wait unsent. Halts are kept after
+// they are resumed as an audit record of who halted and resumed
+// transmissions and why.
+type TransmissionHalt struct {
+	ID           int64       `json:"-" gorm:"primary_key"`
+	Reason       string      `json:"reason" gorm:"not null"`
+	HaltedBy     string      `json:"haltedBy" gorm:"not null"`
+	ResumeReason null.String `json:"resumeReason"`
+	ResumedBy    null.String `json:"resumedBy"`
+	ResumedAt    null.Time   `json:"resumedAt"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	UpdatedAt    time.Time   `json:"updatedAt"`
+}
+
+// Active returns whether the halt has not been resumed yet.
+func (h TransmissionHalt) Active() bool {
+	return !h.ResumedAt.Valid
+}
+
+// Resume records that the halt was resumed by resumedBy at the given time.
+func (h *TransmissionHalt) Resume(reason, resumedBy string, at time.Time) {
+	h.ResumeReason = null.StringFrom(reason)
+	h.ResumedBy = null.StringFrom(resumedBy)
+	h.ResumedAt = null.TimeFrom(at)
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (h TransmissionHalt) GetID() string {
+	return strconv.FormatInt(h.ID, 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi
+// serialization.
+func (h TransmissionHalt) GetName() string {
+	return "transmission_halts"
+}
+
+// SetID is used to set the ID of this structure when deserializing from
+// jsonapi documents.
+func (h *TransmissionHalt) SetID(value string) error {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	h.ID = id
+	return nil
+}
+
+// TransmissionHaltRequest halts transmissions, giving the reason why.
+type TransmissionHaltRequest struct {
+	Reason string `json:"reason"`
+}
+
+// TransmissionResumeRequest resumes halted transmissions. The user's
+// password must be supplied again, along with the reason it is safe to.
+type TransmissionResumeRequest struct {
+	Password string `json:"password"`
+	Reason   string `json:"reason"`
+}
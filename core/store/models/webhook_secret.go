@@ -0,0 +1,142 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	null "gopkg.in/guregu/null.v3"
+)
+
+// DefaultWebhookSecretOverlap is how long a webhook's previous secret is
+// still accepted after it is rotated, unless the rotation says otherwise.
+const DefaultWebhookSecretOverlap = time.Hour
+
+// WebhookSignatureMaxAge is how far the timestamp of a signed webhook request
+// may be from the time it is received.
+const WebhookSignatureMaxAge = 5 * time.Minute
+
+// MaxWebhookSecretOverlap is the longest a webhook's previous secret may
+// still be accepted after it is rotated.
+const MaxWebhookSecretOverlap = 7 * 24 * time.Hour
+
+// WebhookSecretRotationRequest is a request to rotate the secrets of a job's
+// webhook initiators, or of only the one with InitiatorID if given. Overlap
+// is how long the previous secrets are still accepted,
+// DefaultWebhookSecretOverlap if not given.
+type WebhookSecretRotationRequest struct {
+	Overlap     *Duration `json:"overlap"`
+	InitiatorID *int64    `json:"initiatorId,omitempty"`
+}
+
+// OverlapOrDefault returns the overlap requested, or
+// DefaultWebhookSecretOverlap if none was. It errors if the overlap is
+// negative or longer than MaxWebhookSecretOverlap.
+func (r WebhookSecretRotationRequest) OverlapOrDefault() (time.Duration, error) {
+	if r.Overlap == nil {
+		return DefaultWebhookSecretOverlap, nil
+	}
+	overlap := r.Overlap.Duration()
+	if overlap < 0 || overlap > MaxWebhookSecretOverlap {
+		return 0, fmt.Errorf("overlap must be between 0s and %s, got %s", MaxWebhookSecretOverlap, overlap)
+	}
+	return overlap, nil
+}
+
+// WebhookSecretRotation is the new secret of each webhook initiator rotated,
+// shown only once, and when their previous secrets stop being accepted.
+type WebhookSecretRotation struct {
+	JobSpecID               *ID                    `json:"jobSpecId"`
+	Secrets                 []RotatedWebhookSecret `json:"secrets"`
+	PreviousSecretExpiresAt time.Time              `json:"previousSecretExpiresAt"`
+}
+
+// RotatedWebhookSecret is the new secret of one of a job's webhook
+// initiators. Each initiator gets a secret of its own, so that a secret
+// which leaks from one caller does not let it sign for another.
+type RotatedWebhookSecret struct {
+	InitiatorID int64  `json:"initiatorId"`
+	Secret      string `json:"secret"`
+}
+
+// NewWebhookSecret returns a random secret for a webhook initiator.
+func NewWebhookSecret() string {
+	return utils.NewSecret(32)
+}
+
+// WebhookSignature returns the hex encoded HMAC-SHA256, keyed by secret, of
+// the timestamp and body of a webhook request. Signing the timestamp keeps a
+// request which is intercepted from being replayed once it is too old.
+func WebhookSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d\n", timestamp)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// InitiatorWebhookSecret is the secret of one of a job's webhook initiators,
+// and the secret it had before it was last rotated. As the secrets are not
+// part of the job's spec, they are carried alongside it in snapshots,
+// exports and fleet bundles, so that callers keep working wherever the job
+// is recreated.
+type InitiatorWebhookSecret struct {
+	Secret                  string    `json:"secret"`
+	PreviousSecret          string    `json:"previousSecret,omitempty"`
+	PreviousSecretExpiresAt null.Time `json:"previousSecretExpiresAt"`
+}
+
+// WebhookSecretsOf returns the secrets of the job's webhook initiators, in
+// the order of the initiators.
+func WebhookSecretsOf(job JobSpec) []InitiatorWebhookSecret {
+	var secrets []InitiatorWebhookSecret
+	for _, initr := range job.InitiatorsFor(InitiatorWebhook) {
+		secrets = append(secrets, InitiatorWebhookSecret{
+			Secret:                  initr.WebhookSecret,
+			PreviousSecret:          initr.PreviousWebhookSecret,
+			PreviousSecretExpiresAt: initr.PreviousWebhookSecretExpiresAt,
+		})
+	}
+	return secrets
+}
+
+// ApplyWebhookSecrets gives the job's webhook initiators, in order, the
+// secrets returned by WebhookSecretsOf. Initiators beyond the secrets given
+// keep their own.
+func ApplyWebhookSecrets(job *JobSpec, secrets []InitiatorWebhookSecret) {
+	for i := range job.Initiators {
+		if len(secrets) == 0 {
+			return
+		}
+		if job.Initiators[i].Type != InitiatorWebhook {
+			continue
+		}
+		job.Initiators[i].WebhookSecret = secrets[0].Secret
+		job.Initiators[i].PreviousWebhookSecret = secrets[0].PreviousSecret
+		job.Initiators[i].PreviousWebhookSecretExpiresAt = secrets[0].PreviousSecretExpiresAt
+		secrets = secrets[1:]
+	}
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (r WebhookSecretRotation) GetID() string {
+	return r.JobSpecID.String()
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (r WebhookSecretRotation) GetName() string {
+	return "webhook_secrets"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (r *WebhookSecretRotation) SetID(value string) error {
+	id, err := NewIDFromString(value)
+	if err != nil {
+		return err
+	}
+	r.JobSpecID = id
+	return nil
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var workerGroupNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// WorkerGroup isolates the runs of a set of jobs from those of the node's
+// other jobs. At most Concurrency runs of the group's jobs execute at once,
+// so a burst of runs in one group, such as a flood of webhook triggers, does
+// not starve the others of goroutines. Jobs in no group execute without a
+// limit, as all jobs did before worker groups.
+//
+// Groups do not have database connections of their own: every run uses the
+// node's single connection pool, of which a group can only take as many
+// connections as it has runs executing. A group's Concurrency is therefore
+// what bounds its share of the pool.
+type WorkerGroup struct {
+	Name        string    `json:"name" gorm:"primary_key"`
+	Concurrency int       `json:"concurrency"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	JobSpecIDs  []string  `json:"jobSpecIds" gorm:"-"`
+}
+
+// NewWorkerGroup returns a WorkerGroup, or an error if its name is not
+// lowercase alphanumeric with dashes and underscores or its concurrency is
+// not positive.
+func NewWorkerGroup(name string, concurrency int) (WorkerGroup, error) {
+	if !workerGroupNameRegex.MatchString(name) || len(name) > 255 {
+		return WorkerGroup{}, fmt.Errorf("invalid worker group name %q, must be lowercase alphanumeric with dashes and underscores", name)
+	}
+	if err := ValidateWorkerGroupConcurrency(concurrency); err != nil {
+		return WorkerGroup{}, err
+	}
+	return WorkerGroup{Name: name, Concurrency: concurrency}, nil
+}
+
+// ValidateWorkerGroupConcurrency returns an error unless concurrency is
+// positive.
+func ValidateWorkerGroupConcurrency(concurrency int) error {
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be positive, got %d", concurrency)
+	}
+	return nil
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (wg WorkerGroup) GetID() string {
+	return wg.Name
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (wg WorkerGroup) GetName() string {
+	return "worker_groups"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (wg *WorkerGroup) SetID(value string) error {
+	wg.Name = value
+	return nil
+}
+
+// WorkerGroupRequest is a request to create or update a WorkerGroup.
+type WorkerGroupRequest struct {
+	Name        string `json:"name"`
+	Concurrency int    `json:"concurrency"`
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+)
+
+// OCRKeyStore keeps decrypted OCR key bundles in memory, so that their
+// off-chain ed25519 keys can be used for signing without the caller holding
+// the secret key.
+type OCRKeyStore struct {
+	lock  sync.RWMutex
+	keys  map[string]*ocrkey.KeyBundle
+	store *Store
+}
+
+// NewOCRKeyStore returns an empty OCRKeyStore
+func NewOCRKeyStore(store *Store) *OCRKeyStore {
+	return &OCRKeyStore{
+		lock:  sync.RWMutex{},
+		keys:  make(map[string]*ocrkey.KeyBundle),
+		store: store,
+	}
+}
+
+// Unlock tries to decrypt each OCR key bundle in the db using the given pass
+// phrase, and returns the IDs of the bundles it manages to unlock, and any
+// errors which result.
+func (ks *OCRKeyStore) Unlock(phrase string) (keysUnlocked []string, merr error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	encryptedKeys, err := ks.store.FindEncryptedOCRKeyBundles()
+	if err != nil {
+		return nil, errors.Wrap(err, "while retrieving OCR key bundles from db")
+	}
+	for _, ek := range encryptedKeys {
+		key, err := ek.Decrypt(phrase)
+		if err != nil {
+			merr = multierr.Append(merr, err)
+			continue
+		}
+		ks.keys[key.ID] = key
+		keysUnlocked = append(keysUnlocked, key.ID)
+	}
+	return keysUnlocked, merr
+}
+
+// SignOffChain returns the ed25519 signature on msg by the off-chain key of
+// the bundle with the given ID. The bundle must already have been unlocked.
+func (ks *OCRKeyStore) SignOffChain(id string, msg []byte) ([]byte, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	key, found := ks.keys[id]
+	if !found {
+		return nil, fmt.Errorf("OCR key bundle %s has not been unlocked", id)
+	}
+	return key.SignOffChain(msg)
+}
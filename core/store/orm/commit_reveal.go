@@ -0,0 +1,87 @@
+package orm
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// FindEthCommitReveal returns the commit-reveal submission of the task run,
+// with its transactions, or nil if it has not committed yet.
+func (orm *ORM) FindEthCommitReveal(taskRunID uuid.UUID) (*models.EthCommitReveal, error) {
+	orm.MustEnsureAdvisoryLock()
+	cr := &models.EthCommitReveal{}
+	err := orm.DB.
+		Preload("CommitEthTx").
+		Preload("RevealEthTx").
+		First(cr, "task_run_id = ?", taskRunID).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	return cr, err
+}
+
+// InsertEthCommit queues the commit transaction of the task run's
+// commit-reveal submission of answer, committed to with salt.
+func (orm *ORM) InsertEthCommit(taskRunID uuid.UUID, answer, salt []byte, fromAddress, toAddress common.Address, encodedPayload []byte, gasLimit uint64) error {
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		etx := newUnstartedEthTx(fromAddress, toAddress, encodedPayload, gasLimit)
+		if err := dbtx.Save(&etx).Error; err != nil {
+			return errors.Wrap(err, "InsertEthCommit failed to save commit transaction")
+		}
+		cr := models.EthCommitReveal{
+			TaskRunID:     taskRunID,
+			Answer:        answer,
+			Salt:          salt,
+			CommitEthTxID: etx.ID,
+		}
+		return errors.Wrap(dbtx.Create(&cr).Error, "InsertEthCommit failed")
+	})
+}
+
+// ScheduleEthReveal sets the time after which the task run's answer is
+// revealed.
+func (orm *ORM) ScheduleEthReveal(taskRunID uuid.UUID, revealAt time.Time) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Model(&models.EthCommitReveal{}).
+		Where("task_run_id = ? AND reveal_at IS NULL", taskRunID).
+		Update("reveal_at", revealAt).Error
+}
+
+// InsertEthReveal queues the reveal transaction of the task run's
+// commit-reveal submission, unless it has been already.
+func (orm *ORM) InsertEthReveal(taskRunID uuid.UUID, fromAddress, toAddress common.Address, encodedPayload []byte, gasLimit uint64) error {
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		etx := newUnstartedEthTx(fromAddress, toAddress, encodedPayload, gasLimit)
+		if err := dbtx.Save(&etx).Error; err != nil {
+			return errors.Wrap(err, "InsertEthReveal failed to save reveal transaction")
+		}
+		res := dbtx.Model(&models.EthCommitReveal{}).
+			Where("task_run_id = ? AND reveal_eth_tx_id IS NULL", taskRunID).
+			Update("reveal_eth_tx_id", etx.ID)
+		if res.Error != nil {
+			return errors.Wrap(res.Error, "InsertEthReveal failed")
+		}
+		if res.RowsAffected == 0 {
+			return errors.Errorf("InsertEthReveal failed: task run %s has already revealed or never committed", taskRunID)
+		}
+		return nil
+	})
+}
+
+func newUnstartedEthTx(fromAddress, toAddress common.Address, encodedPayload []byte, gasLimit uint64) models.EthTx {
+	return models.EthTx{
+		FromAddress:    fromAddress,
+		ToAddress:      toAddress,
+		EncodedPayload: encodedPayload,
+		Value:          assets.NewEthValue(0),
+		GasLimit:       gasLimit,
+		State:          models.EthTxUnstarted,
+	}
+}
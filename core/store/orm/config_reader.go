@@ -7,6 +7,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/contrib/sessions"
@@ -16,18 +17,28 @@ import (
 type ConfigReader interface {
 	AllowOrigins() string
 	BlockBackfillDepth() uint64
+	BridgeMaxRetryAfter() models.Duration
 	BridgeResponseURL() *url.URL
 	ChainID() *big.Int
+	ClientNamespace() string
 	ClientNodeURL() string
 	DatabaseTimeout() models.Duration
 	DatabaseURL() string
 	DefaultMaxHTTPAttempts() uint
 	DefaultHTTPLimit() int64
 	DefaultHTTPTimeout() models.Duration
+	HTTPHostLimits() utils.HostLimits
+	HTTPAdaptiveTimeoutMargin() models.Duration
+	JSONDepthLimit() int
 	Dev() bool
 	FeatureExternalInitiators() bool
 	FeatureFluxMonitor() bool
+	FleetSyncInterval() models.Duration
+	FluxMonitorFetchCacheTTL() models.Duration
+	FluxMonitorSubmissionMargin() models.Duration
+	FundsSweepApprovalDelay() models.Duration
 	MaximumServiceDuration() models.Duration
+	MaxTaskResultSize() int64
 	MinimumServiceDuration() models.Duration
 	EnableExperimentalAdapters() bool
 	EnableBulletproofTxManager() bool
@@ -49,11 +60,20 @@ type ConfigReader interface {
 	GasUpdaterBlockHistorySize() uint16
 	GasUpdaterTransactionPercentile() uint16
 	JSONConsole() bool
+	KeeperRegistryPerformGasOverhead() uint64
 	LinkContractAddress() string
+	EventSinkURL() *url.URL
+	FleetPeers() []url.URL
+	FleetSyncURL() *url.URL
+	FleetSyncAccessKey() string
+	FleetSyncSecret() string
 	ExplorerURL() *url.URL
 	ExplorerAccessKey() string
 	ExplorerSecret() string
+	ExplorerMaxBacklog() uint64
 	OperatorContractAddress() common.Address
+	TransactionAllowlistEnabled() bool
+	TreasuryAddress() common.Address
 	LogLevel() LogLevel
 	LogToDisk() bool
 	LogSQLStatements() bool
@@ -63,8 +83,11 @@ type ConfigReader interface {
 	MinimumRequestExpiration() uint64
 	MigrateDatabase() bool
 	Port() uint16
+	ProviderQuotas() utils.ProviderQuotas
+	ProviderQuotaAlertPercent() uint64
 	ReaperExpiration() models.Duration
 	RootDir() string
+	RunDataEncryptionKey() string
 	SecureCookies() bool
 	SessionTimeout() models.Duration
 	TLSCertPath() string
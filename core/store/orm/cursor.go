@@ -0,0 +1,249 @@
+package orm
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// Cursor marks the position of a record in a collection sorted by creation
+// time, for paging through it without offsets, which grow slower the further
+// into a large table they reach and skip or repeat records inserted while
+// paging. Ties in CreatedAt are broken by ID.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        *models.ID
+}
+
+// String encodes the cursor into an opaque string.
+func (c Cursor) String() string {
+	raw := fmt.Sprintf("%d:%s", c.CreatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a cursor encoded by String.
+func ParseCursor(input string) (Cursor, error) {
+	invalid := errors.New("invalid cursor")
+	raw, err := base64.RawURLEncoding.DecodeString(input)
+	if err != nil {
+		return Cursor{}, invalid
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return Cursor{}, invalid
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return Cursor{}, invalid
+	}
+	id, err := models.NewIDFromString(parts[1])
+	if err != nil {
+		return Cursor{}, invalid
+	}
+	return Cursor{CreatedAt: time.Unix(0, nanos), ID: id}, nil
+}
+
+// afterCursor selects the records after cursor in the given order, sorts
+// them and fetches one more than limit, so that it is known whether there is
+// a further page.
+func afterCursor(db *gorm.DB, table string, cursor *Cursor, sort SortType, limit int) *gorm.DB {
+	if cursor != nil {
+		comparison := ">"
+		if sort == Descending {
+			comparison = "<"
+		}
+		db = db.Where(fmt.Sprintf("(%s.created_at, %s.id) %s (?, ?)", table, table, comparison), cursor.CreatedAt, cursor.ID)
+	}
+	return db.
+		Order(fmt.Sprintf("%s.created_at %s, %s.id %s", table, sort, table, sort)).
+		Limit(limit + 1)
+}
+
+// nextCursor returns the cursor of the last of the first limit records, if
+// there are more than limit, and otherwise nil.
+func nextCursor(count, limit int, last func(int) Cursor) *Cursor {
+	if count <= limit {
+		return nil
+	}
+	cursor := last(limit - 1)
+	return &cursor
+}
+
+// JobsAfter returns up to limit of the JobSpecs selected by filter which
+// follow cursor, or the first of them if cursor is nil, along with the cursor
+// of the next page if there is one.
+func (orm *ORM) JobsAfter(filter JobSpecFilter, sort SortType, cursor *Cursor, limit int) ([]models.JobSpec, *Cursor, error) {
+	orm.MustEnsureAdvisoryLock()
+	var jobs []models.JobSpec
+	db := filter.scope(orm.preloadFilteredJobs(filter))
+	if err := afterCursor(db, "job_specs", cursor, sort, limit).Find(&jobs).Error; err != nil {
+		return nil, nil, err
+	}
+	next := nextCursor(len(jobs), limit, func(i int) Cursor {
+		return Cursor{CreatedAt: jobs[i].CreatedAt, ID: jobs[i].ID}
+	})
+	if next != nil {
+		jobs = jobs[:limit]
+	}
+	return jobs, next, nil
+}
+
+// JobRunsAfter returns up to limit job runs, of the given job spec or of all
+// job specs in the namespace if jobSpecID is nil, which follow cursor, or the
+// first of them if cursor is nil, along with the cursor of the next page if
+// there is one.
+func (orm *ORM) JobRunsAfter(namespace string, jobSpecID *models.ID, sort SortType, cursor *Cursor, limit int) ([]models.JobRun, *Cursor, error) {
+	orm.MustEnsureAdvisoryLock()
+	var runs []models.JobRun
+	db := jobRunsInNamespace(orm.preloadJobRuns(), namespace)
+	if jobSpecID != nil {
+		db = db.Where("job_runs.job_spec_id = ?", jobSpecID)
+	}
+	if err := afterCursor(db, "job_runs", cursor, sort, limit).Find(&runs).Error; err != nil {
+		return nil, nil, err
+	}
+	next := nextCursor(len(runs), limit, func(i int) Cursor {
+		return Cursor{CreatedAt: runs[i].CreatedAt, ID: runs[i].ID}
+	})
+	if next != nil {
+		runs = runs[:limit]
+	}
+	return runs, next, nil
+}
+
+// EachJobRunBetween calls cb with each run of the job created at or after
+// from and before to, oldest first, loading them in batches by keyset so
+// that a job with many runs is never held in memory at once. A zero from or
+// to leaves that end of the range open.
+//
+// The batches are read from a single snapshot of the database, so an export
+// taking minutes neither skips nor repeats runs, nor mixes the states of runs
+// from before and after they changed, however many runs are created or
+// updated meanwhile.
+func (orm *ORM) EachJobRunBetween(jobSpecID *models.ID, from, to time.Time, cb func(*models.JobRun) error) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.snapshotTransaction(func(tx *gorm.DB) error {
+		db := tx.Preload("Result").Where("job_spec_id = ?", jobSpecID)
+		if !from.IsZero() {
+			db = db.Where("job_runs.created_at >= ?", from)
+		}
+		if !to.IsZero() {
+			db = db.Where("job_runs.created_at < ?", to)
+		}
+
+		var cursor *Cursor
+		for {
+			var runs []models.JobRun
+			if err := afterCursor(db, "job_runs", cursor, Ascending, BatchSize).Find(&runs).Error; err != nil {
+				return err
+			}
+			for i := range runs {
+				if i == BatchSize {
+					break
+				}
+				if err := cb(&runs[i]); err != nil {
+					return err
+				}
+			}
+			cursor = nextCursor(len(runs), BatchSize, func(i int) Cursor {
+				return Cursor{CreatedAt: runs[i].CreatedAt, ID: runs[i].ID}
+			})
+			if cursor == nil {
+				return nil
+			}
+		}
+	})
+}
+
+// EachTransactionBetween calls legacy with each transaction of the legacy
+// tx manager, and then bulletproof with each of the bulletproof tx manager,
+// created at or after from and before to and sent from a key of the
+// namespace. Each is called in the order the transactions were created, and
+// the transactions are loaded in batches from a single snapshot of the
+// database as EachJobRunBetween does. The attempts of bulletproof
+// transactions are loaded newest first, along with their receipts. A zero
+// from or to leaves that end of the range open.
+func (orm *ORM) EachTransactionBetween(namespace string, from, to time.Time, legacy func(*models.Tx) error, bulletproof func(*models.EthTx) error) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.snapshotTransaction(func(tx *gorm.DB) error {
+		db := createdBetween(tx.Select("txes.*").
+			Joins(`JOIN keys ON keys.address = txes."from"`).
+			Where("keys.namespace = ?", namespace), "txes", from, to)
+		var lastID uint64
+		for {
+			var txs []models.Tx
+			if err := db.Where("txes.id > ?", lastID).Order("txes.id asc").Limit(BatchSize).Find(&txs).Error; err != nil {
+				return err
+			}
+			for i := range txs {
+				if err := legacy(&txs[i]); err != nil {
+					return err
+				}
+			}
+			if len(txs) < BatchSize {
+				break
+			}
+			lastID = txs[len(txs)-1].ID
+		}
+
+		db = createdBetween(tx.Select("eth_txes.*").
+			Joins("JOIN keys ON keys.address = eth_txes.from_address").
+			Where("keys.namespace = ?", namespace), "eth_txes", from, to).
+			Preload("EthTxAttempts", func(db *gorm.DB) *gorm.DB {
+				return db.Order("eth_tx_attempts.id desc")
+			}).
+			Preload("EthTxAttempts.EthReceipts")
+		var lastEthTxID int64
+		for {
+			var etxs []models.EthTx
+			if err := db.Where("eth_txes.id > ?", lastEthTxID).Order("eth_txes.id asc").Limit(BatchSize).Find(&etxs).Error; err != nil {
+				return err
+			}
+			for i := range etxs {
+				if err := bulletproof(&etxs[i]); err != nil {
+					return err
+				}
+			}
+			if len(etxs) < BatchSize {
+				return nil
+			}
+			lastEthTxID = etxs[len(etxs)-1].ID
+		}
+	})
+}
+
+// createdBetween limits db to the records of table created at or after from
+// and before to, leaving an end of the range open if it is zero.
+func createdBetween(db *gorm.DB, table string, from, to time.Time) *gorm.DB {
+	if !from.IsZero() {
+		db = db.Where(table+".created_at >= ?", from)
+	}
+	if !to.IsZero() {
+		db = db.Where(table+".created_at < ?", to)
+	}
+	return db
+}
+
+// snapshotTransaction calls fc in a read only transaction at the repeatable
+// read isolation level, so that every query of fc sees the database as it
+// was at the first of them, however long fc takes. The transaction wrapped
+// databases of tests already run every statement in one serializable
+// transaction, whose isolation level cannot be changed, so there fc is
+// simply called within it.
+func (orm *ORM) snapshotTransaction(fc func(tx *gorm.DB) error) error {
+	return orm.Transaction(func(tx *gorm.DB) error {
+		if !orm.transactionWrapped {
+			if err := tx.Exec(`SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY`).Error; err != nil {
+				return err
+			}
+		}
+		return fc(tx)
+	})
+}
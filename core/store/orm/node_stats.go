@@ -0,0 +1,73 @@
+package orm
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/jinzhu/gorm"
+)
+
+// NodeStats counts the jobs of the given namespace by status, their runs
+// created since the given time by status, and their job spec errors which
+// have not been acknowledged. Archived jobs and their runs are left out. The
+// size of the database is given in bytes.
+func (orm *ORM) NodeStats(namespace string, since time.Time) (models.NodeStats, error) {
+	stats := models.NodeStats{
+		Jobs:       map[models.JobSpecStatus]int{},
+		RecentRuns: map[models.RunStatus]int{},
+	}
+	err := orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		var jobs []struct {
+			Status models.JobSpecStatus
+			Count  int
+		}
+		err := dbtx.Raw(`SELECT status, count(*) FROM job_specs WHERE deleted_at IS NULL AND namespace = ? GROUP BY status`, namespace).Scan(&jobs).Error
+		if err != nil {
+			return err
+		}
+		for _, row := range jobs {
+			stats.Jobs[row.Status] = row.Count
+		}
+
+		var runs []struct {
+			Status models.RunStatus
+			Count  int
+		}
+		err = dbtx.Raw(`SELECT job_runs.status, count(*) FROM job_runs
+			JOIN job_specs ON job_specs.id = job_runs.job_spec_id AND job_specs.deleted_at IS NULL
+			WHERE job_runs.deleted_at IS NULL AND job_runs.created_at >= ? AND job_specs.namespace = ?
+			GROUP BY job_runs.status`, since, namespace).Scan(&runs).Error
+		if err != nil {
+			return err
+		}
+		for _, row := range runs {
+			stats.RecentRuns[row.Status] = row.Count
+		}
+
+		err = dbtx.Model(&models.JobSpecError{}).
+			Joins("JOIN job_specs ON job_specs.id = job_spec_errors.job_spec_id AND job_specs.deleted_at IS NULL").
+			Where("job_spec_errors.acknowledged_at IS NULL AND job_specs.namespace = ?", namespace).
+			Count(&stats.JobSpecErrors).Error
+		if err != nil {
+			return err
+		}
+
+		var size struct{ Size int64 }
+		err = dbtx.Raw(`SELECT pg_database_size(current_database()) AS size`).Scan(&size).Error
+		if err != nil {
+			return err
+		}
+		stats.DatabaseSize = size.Size
+
+		var head models.Head
+		err = dbtx.Order("number DESC").First(&head).Error
+		if err == nil {
+			stats.LatestHead = &head.Number
+		} else if !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+		return nil
+	})
+	return stats, err
+}
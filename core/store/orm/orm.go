@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"go.uber.org/multierr"
+	"gopkg.in/guregu/null.v3"
 )
 
 var (
@@ -44,6 +46,9 @@ var (
 	// because another update occurred while the model was in memory and the
 	// differences must be reconciled.
 	ErrOptimisticUpdateConflict = errors.New("conflict while updating record")
+	// ErrJobRunChained is returned when a run of a next job is created from
+	// a run whose next job has already been run.
+	ErrJobRunChained = errors.New("next job has already been run with the result of the run")
 )
 
 // ORM contains the database object used by Chainlink.
@@ -53,6 +58,8 @@ type ORM struct {
 	advisoryLockTimeout models.Duration
 	closeOnce           sync.Once
 	shutdownSignal      gracefulpanic.Signal
+	transactionWrapped  bool
+	commitHooks         *commitHooks
 }
 
 // NewORM initializes a new database file at the configured uri.
@@ -72,6 +79,8 @@ func NewORM(uri string, timeout models.Duration, shutdownSignal gracefulpanic.Si
 		lockingStrategy:     lockingStrategy,
 		advisoryLockTimeout: timeout,
 		shutdownSignal:      shutdownSignal,
+		transactionWrapped:  ct.transactionWrapped,
+		commitHooks:         newCommitHooks(),
 	}
 	orm.MustEnsureAdvisoryLock()
 
@@ -116,6 +125,12 @@ func (orm *ORM) SetLogging(enabled bool) {
 	orm.DB.LogMode(enabled)
 }
 
+// SetRunDataCipher sets the cipher which the runs of confidential jobs are
+// encrypted with as they are stored, and decrypted with as they are loaded.
+func (orm *ORM) SetRunDataCipher(c *models.RunDataCipher) {
+	orm.DB = orm.DB.Set(models.RunDataCipherSetting, c)
+}
+
 // Close closes the underlying database connection.
 func (orm *ORM) Close() error {
 	var err error
@@ -133,6 +148,7 @@ func (orm *ORM) Unscoped() *ORM {
 	return &ORM{
 		DB:              orm.DB.Unscoped(),
 		lockingStrategy: orm.lockingStrategy,
+		commitHooks:     orm.commitHooks,
 	}
 }
 
@@ -174,6 +190,14 @@ func (orm *ORM) FindJob(id *models.ID) (models.JobSpec, error) {
 	return job, orm.preloadJobs().First(&job, "id = ?", id).Error
 }
 
+// FindJobByExternalID looks up the active Job of the namespace which has the
+// given external job ID.
+func (orm *ORM) FindJobByExternalID(namespace string, externalJobID uuid.UUID) (models.JobSpec, error) {
+	orm.MustEnsureAdvisoryLock()
+	var job models.JobSpec
+	return job, orm.preloadJobs().First(&job, "namespace = ? AND external_job_id = ?", namespace, externalJobID).Error
+}
+
 // FindJobWithErrors looks up a Job by its ID and preloads JobSpecErrors.
 func (orm *ORM) FindJobWithErrors(id *models.ID) (models.JobSpec, error) {
 	var job models.JobSpec
@@ -195,13 +219,15 @@ func (orm *ORM) FindInitiator(ID int64) (models.Initiator, error) {
 		First(&initr, "id = ?", ID).Error
 }
 
+// preloadJobs loads the initiators and tasks of jobs, including those of
+// archived jobs but not those superseded by an update to a job's spec.
 func (orm *ORM) preloadJobs() *gorm.DB {
 	return orm.DB.
 		Preload("Initiators", func(db *gorm.DB) *gorm.DB {
-			return db.Unscoped().Order(`"id" asc`)
+			return db.Unscoped().Where("superseded_at IS NULL").Order(`"id" asc`)
 		}).
 		Preload("Tasks", func(db *gorm.DB) *gorm.DB {
-			return db.Unscoped().Order("id asc")
+			return db.Unscoped().Where("superseded_at IS NULL").Order("id asc")
 		})
 }
 
@@ -258,15 +284,80 @@ func (orm *ORM) AllSyncEvents(cb func(models.SyncEvent) error) error {
 	})
 }
 
+// TrimSyncEvents deletes the oldest sync events beyond the newest max,
+// returning how many were deleted.
+func (orm *ORM) TrimSyncEvents(max uint64) (int64, error) {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Exec(`
+		DELETE FROM sync_events WHERE id <= (
+			SELECT id FROM sync_events ORDER BY id DESC OFFSET ? LIMIT 1
+		)`, max)
+	return result.RowsAffected, result.Error
+}
+
+// UnpublishedNodeEvents returns up to limit of the oldest node events, which
+// are deleted once published.
+func (orm *ORM) UnpublishedNodeEvents(limit int) ([]models.NodeEvent, error) {
+	orm.MustEnsureAdvisoryLock()
+	var events []models.NodeEvent
+	return events, orm.DB.Order("id asc").Limit(limit).Find(&events).Error
+}
+
+// SetNodeEventsEnabled sets whether the database records node events. They
+// are disabled unless the node has an event sink to publish them to.
+func (orm *ORM) SetNodeEventsEnabled(enabled bool) error {
+	orm.MustEnsureAdvisoryLock()
+	// Left alone when unchanged, so that the row is not locked by every node
+	// starting with the same setting
+	return orm.DB.Exec("UPDATE node_event_settings SET enabled = ? WHERE enabled <> ?", enabled, enabled).Error
+}
+
+// DeleteNodeEvent removes a node event once it has been published.
+func (orm *ORM) DeleteNodeEvent(id int64) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Exec("DELETE FROM node_events WHERE id = ?", id).Error
+}
+
+// SourceLatencies returns the saved latencies of each external source.
+func (orm *ORM) SourceLatencies() ([]models.SourceLatency, error) {
+	orm.MustEnsureAdvisoryLock()
+	var latencies []models.SourceLatency
+	return latencies, orm.DB.Order("source asc").Find(&latencies).Error
+}
+
+// SaveSourceLatencies saves the latencies of each of the sources, replacing
+// those saved before, and then deletes all but the keep most recently saved
+// sources.
+func (orm *ORM) SaveSourceLatencies(latencies []models.SourceLatency, keep int) error {
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		for i := range latencies {
+			err := dbtx.
+				Set("gorm:insert_option", "ON CONFLICT (source) DO UPDATE SET samples = excluded.samples, updated_at = excluded.updated_at").
+				Create(&latencies[i]).
+				Error
+			if err != nil {
+				return err
+			}
+		}
+		return dbtx.Exec(`
+			DELETE FROM source_latencies WHERE source NOT IN (
+				SELECT source FROM source_latencies ORDER BY updated_at DESC, source ASC LIMIT ?
+			)`, keep).Error
+	})
+}
+
 // NOTE: Copied verbatim from gorm master
 // Transaction start a transaction as a block,
 // return error will rollback, otherwise to commit.
 func (orm *ORM) Transaction(fc func(tx *gorm.DB) error) (err error) {
 	tx := orm.DB.Begin()
+	sqlTx, _ := tx.CommonDB().(*sql.Tx)
+	orm.commitHooks.begin(sqlTx)
 	defer func() {
 		if r := recover(); r != nil {
 			err = errors.Errorf("%s", r)
 			tx.Rollback()
+			orm.commitHooks.end(sqlTx, false)
 			return
 		}
 	}()
@@ -281,9 +372,72 @@ func (orm *ORM) Transaction(fc func(tx *gorm.DB) error) (err error) {
 	if err != nil {
 		tx.Rollback()
 	}
+	orm.commitHooks.end(sqlTx, err == nil)
 	return
 }
 
+// AfterCommit calls fn once the transaction begun by Transaction which scope
+// is saving in has committed, and never if it rolls back. fn is called
+// straight away if scope is not in such a transaction, as from a gorm
+// callback running after gorm's own transaction has committed.
+func (orm *ORM) AfterCommit(scope *gorm.Scope, fn func()) {
+	if sqlTx, ok := scope.SQLDB().(*sql.Tx); ok && orm.commitHooks.add(sqlTx, fn) {
+		return
+	}
+	fn()
+}
+
+// commitHooks holds the functions to call once each open transaction begun
+// by ORM.Transaction commits. It is shared by an ORM and its unscoped
+// copies.
+type commitHooks struct {
+	mu      sync.Mutex
+	pending map[*sql.Tx][]func()
+}
+
+func newCommitHooks() *commitHooks {
+	return &commitHooks{pending: make(map[*sql.Tx][]func())}
+}
+
+func (h *commitHooks) begin(tx *sql.Tx) {
+	if h == nil || tx == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pending[tx] = nil
+}
+
+// add holds fn until tx ends, returning false if tx is not open.
+func (h *commitHooks) add(tx *sql.Tx, fn func()) bool {
+	if h == nil {
+		return false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fns, ok := h.pending[tx]
+	if !ok {
+		return false
+	}
+	h.pending[tx] = append(fns, fn)
+	return true
+}
+
+func (h *commitHooks) end(tx *sql.Tx, committed bool) {
+	if h == nil || tx == nil {
+		return
+	}
+	h.mu.Lock()
+	fns := h.pending[tx]
+	delete(h.pending, tx)
+	h.mu.Unlock()
+	if committed {
+		for _, fn := range fns {
+			fn()
+		}
+	}
+}
+
 // convenientTransaction handles setup and teardown for a gorm database
 // transaction, handing off the database transaction to the callback parameter.
 // Encourages the use of transactions for gorm calls that translate
@@ -313,6 +467,20 @@ func (orm *ORM) SaveJobRun(run *models.JobRun) error {
 	})
 }
 
+// SaveRunProfile saves the profiles of a run, replacing those of its previous
+// execution.
+func (orm *ORM) SaveRunProfile(profile *models.RunProfile) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Save(profile).Error
+}
+
+// FindRunProfile returns the profiles of the run with the given ID.
+func (orm *ORM) FindRunProfile(runID *models.ID) (models.RunProfile, error) {
+	orm.MustEnsureAdvisoryLock()
+	var profile models.RunProfile
+	return profile, orm.DB.Where("job_run_id = ?", runID).First(&profile).Error
+}
+
 // CreateJobRun inserts a new JobRun
 func (orm *ORM) CreateJobRun(run *models.JobRun) error {
 	orm.MustEnsureAdvisoryLock()
@@ -341,14 +509,14 @@ func (orm *ORM) LinkEarnedFor(spec *models.JobSpec) (*assets.Link, error) {
 }
 
 // UpsertErrorFor upserts a JobSpecError record, incrementing the occurrences counter by 1
-// if the record is found
+// and clearing any acknowledgement if the record is found
 func (orm *ORM) UpsertErrorFor(jobID *models.ID, description string) {
 	jse := models.NewJobSpecError(jobID, description)
 	err := orm.DB.
 		Set(
 			"gorm:insert_option",
 			`ON CONFLICT (job_spec_id, description)
-			DO UPDATE SET occurrences = job_spec_errors.occurrences + 1, updated_at = excluded.updated_at`,
+			DO UPDATE SET occurrences = job_spec_errors.occurrences + 1, updated_at = excluded.updated_at, acknowledged_at = NULL`,
 		).
 		Create(&jse).
 		Error
@@ -365,9 +533,47 @@ func (orm *ORM) FindJobSpecError(jobID *models.ID, description string) (*models.
 	return jobSpecErr, err
 }
 
-// DeleteJobSpecError removes a JobSpecError
-func (orm *ORM) DeleteJobSpecError(ID int64) error {
-	result := orm.DB.Exec("DELETE FROM job_spec_errors WHERE id = ?", ID)
+// JobSpecErrorsFor returns the errors of the job, oldest first, leaving out
+// those which have been acknowledged unless includeAcknowledged is set.
+func (orm *ORM) JobSpecErrorsFor(jobID *models.ID, includeAcknowledged bool) ([]models.JobSpecError, error) {
+	orm.MustEnsureAdvisoryLock()
+	var jobSpecErrs []models.JobSpecError
+	db := orm.DB.Where("job_spec_id = ?", jobID)
+	if !includeAcknowledged {
+		db = db.Where("acknowledged_at IS NULL")
+	}
+	return jobSpecErrs, db.Order("id asc").Find(&jobSpecErrs).Error
+}
+
+// AcknowledgeJobSpecError marks a JobSpecError of a job in the given
+// namespace as known to the operator at the given time, keeping it until it
+// is deleted or cleared.
+func (orm *ORM) AcknowledgeJobSpecError(namespace string, ID int64, at time.Time) (models.JobSpecError, error) {
+	var jobSpecErr models.JobSpecError
+	err := orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		result := dbtx.Exec(`UPDATE job_spec_errors SET acknowledged_at = ? WHERE id = ?
+			AND job_spec_id IN (SELECT id FROM job_specs WHERE namespace = ?)`, at, ID, namespace)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return dbtx.First(&jobSpecErr, "id = ?", ID).Error
+	})
+	return jobSpecErr, err
+}
+
+// ClearJobSpecErrorsBefore deletes the errors of the job which last occurred
+// before the given time, as a run of the job started since has succeeded.
+func (orm *ORM) ClearJobSpecErrorsBefore(jobID *models.ID, before time.Time) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Exec("DELETE FROM job_spec_errors WHERE job_spec_id = ? AND updated_at < ?", jobID, before).Error
+}
+
+// DeleteJobSpecError removes a JobSpecError of a job in the given namespace
+func (orm *ORM) DeleteJobSpecError(namespace string, ID int64) error {
+	result := orm.DB.Exec(`DELETE FROM job_spec_errors WHERE id = ?
+		AND job_spec_id IN (SELECT id FROM job_specs WHERE namespace = ?)`, ID, namespace)
 	if result.Error != nil {
 		return result.Error
 	} else if result.RowsAffected == 0 {
@@ -383,11 +589,17 @@ func (orm *ORM) CreateExternalInitiator(externalInitiator *models.ExternalInitia
 	return err
 }
 
+// SaveExternalInitiator saves every field of an external initiator, creating
+// it if it does not exist.
+func (orm *ORM) SaveExternalInitiator(externalInitiator *models.ExternalInitiator) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Save(externalInitiator).Error
+}
+
 // DeleteExternalInitiator removes an external initiator
 func (orm *ORM) DeleteExternalInitiator(name string) error {
 	orm.MustEnsureAdvisoryLock()
-	err := orm.DB.Delete(&models.ExternalInitiator{Name: name}).Error
-	return err
+	return orm.DB.Where("name = ?", name).Delete(&models.ExternalInitiator{}).Error
 }
 
 // FindExternalInitiator finds an external initiator given an authentication request
@@ -404,6 +616,24 @@ func (orm *ORM) FindExternalInitiator(
 	return initiator, nil
 }
 
+// UseNonce records the nonce of a signed request of signer, keeping it until
+// expiresAt, when the request's signature stops being accepted anyway. It
+// returns false if the nonce has already been used. Expired nonces are
+// deleted as new ones are recorded.
+func (orm *ORM) UseNonce(signer, nonce string, expiresAt time.Time) (bool, error) {
+	orm.MustEnsureAdvisoryLock()
+	if err := orm.DB.Exec("DELETE FROM used_nonces WHERE expires_at < NOW()").Error; err != nil {
+		return false, err
+	}
+	result := orm.DB.Exec(`
+		INSERT INTO used_nonces (signer, nonce, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT (signer, nonce) DO NOTHING`, signer, nonce, expiresAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 // FindExternalInitiatorByName finds an external initiator given an authentication request
 func (orm *ORM) FindExternalInitiatorByName(iname string) (models.ExternalInitiator, error) {
 	orm.MustEnsureAdvisoryLock()
@@ -411,6 +641,163 @@ func (orm *ORM) FindExternalInitiatorByName(iname string) (models.ExternalInitia
 	return exi, orm.DB.First(&exi, "lower(name) = lower(?)", iname).Error
 }
 
+// CreateFundsSweep records a new request to sweep the funds of an account.
+func (orm *ORM) CreateFundsSweep(sweep *models.FundsSweep) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Create(sweep).Error
+}
+
+// FindFundsSweep looks up a FundsSweep by its ID.
+func (orm *ORM) FindFundsSweep(id int64) (models.FundsSweep, error) {
+	orm.MustEnsureAdvisoryLock()
+	var sweep models.FundsSweep
+	return sweep, orm.DB.First(&sweep, "id = ?", id).Error
+}
+
+// FundsSweeps returns every FundsSweep, most recent first.
+func (orm *ORM) FundsSweeps() ([]models.FundsSweep, error) {
+	orm.MustEnsureAdvisoryLock()
+	var sweeps []models.FundsSweep
+	return sweeps, orm.DB.Order("created_at desc, id desc").Find(&sweeps).Error
+}
+
+// ReviewFundsSweep saves the review and transactions of a FundsSweep, provided
+// it is still pending approval, and returns ErrOptimisticUpdateConflict
+// otherwise.
+func (orm *ORM) ReviewFundsSweep(sweep *models.FundsSweep) error {
+	orm.MustEnsureAdvisoryLock()
+	return ReviewFundsSweep(orm.DB, sweep)
+}
+
+// ReviewFundsSweep saves the review of a FundsSweep using db, which may be the
+// transaction creating the sweep's transactions, so that they are only
+// created by the one review that finds the sweep still pending approval.
+func ReviewFundsSweep(db *gorm.DB, sweep *models.FundsSweep) error {
+	result := db.Model(sweep).
+		Where("status = ?", models.FundsSweepStatusPending).
+		Updates(map[string]interface{}{
+			"status":                  sweep.Status,
+			"reviewed_by":             sweep.ReviewedBy,
+			"reviewed_at":             sweep.ReviewedAt,
+			"eth_transfer_eth_tx_id":  sweep.EthTransferEthTxID,
+			"link_transfer_eth_tx_id": sweep.LinkTransferEthTxID,
+		})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return ErrOptimisticUpdateConflict
+	}
+	return nil
+}
+
+// HaltTransmissions records a new halt of transmissions, unless they are
+// already halted, returning the active halt.
+func (orm *ORM) HaltTransmissions(reason, haltedBy string) (models.TransmissionHalt, error) {
+	orm.MustEnsureAdvisoryLock()
+	halt, err := orm.ActiveTransmissionHalt()
+	if err != nil {
+		return models.TransmissionHalt{}, err
+	} else if halt != nil {
+		return *halt, nil
+	}
+	created := models.TransmissionHalt{Reason: reason, HaltedBy: haltedBy}
+	return created, orm.DB.Create(&created).Error
+}
+
+// ActiveTransmissionHalt returns the halt transmissions are stopped by, or
+// nil if they are not halted.
+func (orm *ORM) ActiveTransmissionHalt() (*models.TransmissionHalt, error) {
+	orm.MustEnsureAdvisoryLock()
+	var halt models.TransmissionHalt
+	err := orm.DB.First(&halt, "resumed_at IS NULL").Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &halt, nil
+}
+
+// TransmissionsHalted returns whether transmissions are halted.
+func (orm *ORM) TransmissionsHalted() (bool, error) {
+	halt, err := orm.ActiveTransmissionHalt()
+	return halt != nil, err
+}
+
+// ResumeTransmissions resumes the active halt of transmissions.
+func (orm *ORM) ResumeTransmissions(reason, resumedBy string) (models.TransmissionHalt, error) {
+	orm.MustEnsureAdvisoryLock()
+	halt, err := orm.ActiveTransmissionHalt()
+	if err != nil {
+		return models.TransmissionHalt{}, err
+	} else if halt == nil {
+		return models.TransmissionHalt{}, ErrorNotFound
+	}
+	halt.Resume(reason, resumedBy, time.Now())
+	return *halt, orm.DB.Save(halt).Error
+}
+
+// TransmissionHalts returns every TransmissionHalt, most recent first.
+func (orm *ORM) TransmissionHalts() ([]models.TransmissionHalt, error) {
+	orm.MustEnsureAdvisoryLock()
+	var halts []models.TransmissionHalt
+	return halts, orm.DB.Order("created_at desc, id desc").Find(&halts).Error
+}
+
+// AddToTransactionAllowlist allows transactions to the contract at address,
+// returning the existing entry if it is already allowlisted.
+func (orm *ORM) AddToTransactionAllowlist(address common.Address, note, addedBy string) (models.TransactionAllowlistEntry, error) {
+	orm.MustEnsureAdvisoryLock()
+	entry := models.TransactionAllowlistEntry{Address: address, Note: note, AddedBy: addedBy}
+	err := orm.DB.Where("address = ?", address).FirstOrCreate(&entry).Error
+	return entry, err
+}
+
+// RemoveFromTransactionAllowlist removes the contract at address from the
+// transaction allowlist.
+func (orm *ORM) RemoveFromTransactionAllowlist(address common.Address) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Delete(models.TransactionAllowlistEntry{}, "address = ?", address)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// TransactionAllowlist returns every entry added to the transaction
+// allowlist, most recent first.
+func (orm *ORM) TransactionAllowlist() ([]models.TransactionAllowlistEntry, error) {
+	orm.MustEnsureAdvisoryLock()
+	var entries []models.TransactionAllowlistEntry
+	return entries, orm.DB.Order("created_at desc").Find(&entries).Error
+}
+
+// TransactionAllowed returns whether the node may transact with the contract
+// at to from the key at from: it must have been added to the transaction
+// allowlist, or be the address of an initiator or task of a job which has
+// not been archived, in the namespace of the key.
+func (orm *ORM) TransactionAllowed(from, to common.Address) (bool, error) {
+	orm.MustEnsureAdvisoryLock()
+	var allowed bool
+	row := orm.DB.Raw(`
+		SELECT EXISTS (
+			SELECT 1 FROM transaction_allowlist WHERE address = ?
+		) OR EXISTS (
+			SELECT 1 FROM initiators
+			JOIN job_specs ON job_specs.id = initiators.job_spec_id
+			JOIN keys ON keys.namespace = job_specs.namespace
+			WHERE initiators.address = ? AND initiators.deleted_at IS NULL AND job_specs.deleted_at IS NULL AND keys.address = ?
+		) OR EXISTS (
+			SELECT 1 FROM task_specs
+			JOIN job_specs ON job_specs.id = task_specs.job_spec_id
+			JOIN keys ON keys.namespace = job_specs.namespace
+			WHERE lower(task_specs.params->>'address') = lower(?) AND task_specs.deleted_at IS NULL AND job_specs.deleted_at IS NULL AND keys.address = ?
+		)`, to, to, from, to.Hex(), from).Row()
+	return allowed, row.Scan(&allowed)
+}
+
 // FindServiceAgreement looks up a ServiceAgreement by its ID.
 func (orm *ORM) FindServiceAgreement(id string) (models.ServiceAgreement, error) {
 	orm.MustEnsureAdvisoryLock()
@@ -418,7 +805,8 @@ func (orm *ORM) FindServiceAgreement(id string) (models.ServiceAgreement, error)
 	return sa, orm.DB.Set("gorm:auto_preload", true).First(&sa, "id = ?", id).Error
 }
 
-// Jobs fetches all jobs.
+// Jobs fetches all active jobs, skipping those which are archived, stopped
+// or quarantined.
 func (orm *ORM) Jobs(cb func(*models.JobSpec) bool, initrTypes ...string) error {
 	orm.MustEnsureAdvisoryLock()
 	return Batch(BatchSize, func(offset, limit uint) (uint, error) {
@@ -448,7 +836,7 @@ func (orm *ORM) Jobs(cb func(*models.JobSpec) bool, initrTypes ...string) error
 		}
 		for _, j := range jobs {
 			temp := j
-			if temp.DeletedAt.Valid {
+			if temp.DeletedAt.Valid || temp.Stopped() || temp.Quarantined() || temp.Paused() {
 				continue
 			}
 			if !cb(&temp) {
@@ -534,13 +922,278 @@ func (orm *ORM) CreateJob(job *models.JobSpec) error {
 	})
 }
 
+// CreateJobs saves all of the jobs, or none of them if one cannot be saved.
+func (orm *ORM) CreateJobs(jobs []models.JobSpec) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		for i := range jobs {
+			if err := orm.createJob(dbtx, &jobs[i]); err != nil {
+				return errors.Wrapf(err, "failed to save job %s", jobs[i].ID)
+			}
+		}
+		return nil
+	})
+}
+
 func (orm *ORM) createJob(tx *gorm.DB, job *models.JobSpec) error {
 	orm.MustEnsureAdvisoryLock()
 	for i := range job.Initiators {
 		job.Initiators[i].JobSpecID = job.ID
 	}
+	if job.Namespace == "" {
+		job.Namespace = models.DefaultNamespace
+	}
+	if err := checkJobsQuota(tx, job.Namespace, 1); err != nil {
+		return err
+	}
 
-	return tx.Create(job).Error
+	if err := tx.Create(job).Error; err != nil {
+		return err
+	}
+	return createJobSpecVersion(tx, *job)
+}
+
+// ReplaceJobSpec replaces the spec of an existing job with that of job, which
+// has the same ID. The job's status, namespace and runs are kept, as are its
+// externalJobID, unless job has another, and its expiry. Its former
+// initiators and tasks are superseded rather than deleted, as its earlier
+// runs still refer to them.
+func (orm *ORM) ReplaceJobSpec(job *models.JobSpec) error {
+	orm.MustEnsureAdvisoryLock()
+	if err := orm.versionUnversionedJob(job.ID); err != nil {
+		return err
+	}
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		var existing models.JobSpec
+		err := dbtx.Select("external_job_id, expires_at").Where("id = ?", job.ID).First(&existing).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return ErrorNotFound
+		} else if err != nil {
+			return err
+		}
+		if job.ExternalJobID == nil {
+			job.ExternalJobID = existing.ExternalJobID
+		}
+		// An ephemeral job still ends once it expires
+		job.ExpiresAt = existing.ExpiresAt
+		if job.ExpiresAt.Valid && (!job.EndAt.Valid || job.EndAt.Time.After(job.ExpiresAt.Time)) {
+			job.EndAt = null.TimeFrom(job.ExpiresAt.Time)
+		}
+
+		result := dbtx.Model(&models.JobSpec{}).
+			Where("id = ?", job.ID).
+			Updates(map[string]interface{}{
+				"start_at":            job.StartAt,
+				"end_at":              job.EndAt,
+				"min_payment":         job.MinPayment,
+				"max_runs_per_minute": job.MaxRunsPerMinute,
+				"attestation_key":     job.AttestationKey,
+				"final_result_only":   job.FinalResultOnly,
+				"confidential":        job.Confidential,
+				"max_run_duration":    job.MaxRunDuration,
+				"next_job_id":         job.NextJobID,
+				"result_format":       job.ResultFormat,
+				"external_job_id":     job.ExternalJobID,
+				"expires_at":          job.ExpiresAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			return ErrorNotFound
+		}
+
+		if err := keepWebhookSecrets(dbtx, job); err != nil {
+			return err
+		}
+		err = multierr.Combine(
+			dbtx.Exec("UPDATE initiators SET deleted_at = NOW(), superseded_at = NOW() WHERE job_spec_id = ? AND superseded_at IS NULL", job.ID).Error,
+			dbtx.Exec("UPDATE task_specs SET deleted_at = NOW(), superseded_at = NOW() WHERE job_spec_id = ? AND superseded_at IS NULL", job.ID).Error,
+		)
+		if err != nil {
+			return err
+		}
+		for i := range job.Initiators {
+			job.Initiators[i].ID = 0
+			job.Initiators[i].JobSpecID = job.ID
+			if err := dbtx.Create(&job.Initiators[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range job.Tasks {
+			job.Tasks[i].ID = 0
+			job.Tasks[i].JobSpecID = job.ID
+			if err := dbtx.Create(&job.Tasks[i]).Error; err != nil {
+				return err
+			}
+		}
+		if err := createJobSpecVersion(dbtx, *job); err != nil {
+			return err
+		}
+		// The job's row may not change, so its trigger cannot tell that the
+		// spec has been replaced
+		return dbtx.Exec("SELECT insert_node_event(?, job_spec_event_payload(?))", models.NodeEventJobSpecUpdated, job.ID).Error
+	})
+}
+
+// keepWebhookSecrets gives the webhook initiators of a job's new spec the
+// secrets of the webhook initiators they replace, in order, as the secrets
+// are not part of the spec and callers already sign their requests with
+// them.
+func keepWebhookSecrets(tx *gorm.DB, job *models.JobSpec) error {
+	var current models.JobSpec
+	err := tx.
+		Where("job_spec_id = ? AND type = ? AND superseded_at IS NULL", job.ID, models.InitiatorWebhook).
+		Order("id asc").
+		Find(&current.Initiators).Error
+	if err != nil {
+		return err
+	}
+	models.ApplyWebhookSecrets(job, models.WebhookSecretsOf(current))
+	return nil
+}
+
+// createJobSpecVersion saves the job's spec, as it is now, as its next
+// version.
+func createJobSpecVersion(tx *gorm.DB, job models.JobSpec) error {
+	version, err := models.NewJobSpecVersion(job)
+	if err != nil {
+		return err
+	}
+	return tx.Exec(`
+		INSERT INTO job_spec_versions (job_spec_id, version, spec, created_at)
+		SELECT ?, COALESCE(MAX(version), 0) + 1, ?, ? FROM job_spec_versions WHERE job_spec_id = ?`,
+		version.JobSpecID, version.Spec, version.CreatedAt, version.JobSpecID,
+	).Error
+}
+
+// versionUnversionedJob saves the spec of a job created before specs were
+// versioned as its first version, so that it can be rolled back to once the
+// job is updated.
+func (orm *ORM) versionUnversionedJob(id *models.ID) error {
+	var count int
+	if err := orm.DB.Model(&models.JobSpecVersion{}).Where("job_spec_id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	existing, err := orm.FindJob(id)
+	if err != nil {
+		return err
+	}
+	return createJobSpecVersion(orm.DB, existing)
+}
+
+// JobSpecVersions returns every version of the job's spec, latest first.
+func (orm *ORM) JobSpecVersions(jobID *models.ID) ([]models.JobSpecVersion, error) {
+	orm.MustEnsureAdvisoryLock()
+	var versions []models.JobSpecVersion
+	err := orm.DB.Where("job_spec_id = ?", jobID).Order("version desc").Find(&versions).Error
+	return versions, err
+}
+
+// FindJobSpecVersion returns a version of the job's spec.
+func (orm *ORM) FindJobSpecVersion(jobID *models.ID, version int32) (models.JobSpecVersion, error) {
+	orm.MustEnsureAdvisoryLock()
+	var v models.JobSpecVersion
+	err := orm.DB.Where("job_spec_id = ? AND version = ?", jobID, version).First(&v).Error
+	return v, err
+}
+
+// QuarantinedJobs returns the jobs which failed to start and have not been
+// retried.
+func (orm *ORM) QuarantinedJobs() ([]models.JobSpec, error) {
+	orm.MustEnsureAdvisoryLock()
+	var jobs []models.JobSpec
+	err := orm.DB.Where("status = ?", models.JobSpecStatusFailed).Order("created_at asc").Find(&jobs).Error
+	return jobs, err
+}
+
+// ExpiredJobs returns the temporary jobs whose expiry is at or before now.
+func (orm *ORM) ExpiredJobs(now time.Time) ([]models.JobSpec, error) {
+	orm.MustEnsureAdvisoryLock()
+	var jobs []models.JobSpec
+	err := orm.DB.Where("expires_at <= ?", now).Order("expires_at asc").Find(&jobs).Error
+	return jobs, err
+}
+
+// OverdueJobRuns returns the runs waiting on a bridge or on incoming
+// confirmations whose job's MaxRunDuration has passed since they were
+// created. Runs waiting on the confirmation of a transaction they have sent
+// are left to finish, as the transaction cannot be taken back.
+func (orm *ORM) OverdueJobRuns(now time.Time) ([]models.JobRun, error) {
+	orm.MustEnsureAdvisoryLock()
+	var runs []models.JobRun
+	err := orm.preloadJobRuns().
+		Joins("JOIN job_specs ON job_specs.id = job_runs.job_spec_id").
+		Where("job_runs.status IN (?)", []models.RunStatus{
+			models.RunStatusPendingBridge,
+			models.RunStatusPendingIncomingConfirmations,
+		}).
+		Where("job_specs.max_run_duration > 0").
+		Where("job_runs.created_at + job_specs.max_run_duration / 1000 * interval '1 microsecond' < ?", now).
+		Order("job_runs.created_at asc").
+		Find(&runs).Error
+	return runs, err
+}
+
+// JobRunsToChain returns the IDs of up to limit completed runs, oldest first,
+// whose job's next job has yet to be run with their result. Runs are queued
+// for chaining by the database, in the transaction which completes them.
+func (orm *ORM) JobRunsToChain(limit int) ([]*models.ID, error) {
+	orm.MustEnsureAdvisoryLock()
+	var ids []*models.ID
+	err := orm.DB.Table("job_run_chains").
+		Order("created_at asc, job_run_id asc").
+		Limit(limit).
+		Pluck("job_run_id", &ids).Error
+	return ids, err
+}
+
+// DeleteJobRunChain removes a run from those to chain, when its next job
+// cannot be run with its result.
+func (orm *ORM) DeleteJobRunChain(runID *models.ID) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Exec("DELETE FROM job_run_chains WHERE job_run_id = ?", runID).Error
+}
+
+// dequeueJobRunChain removes a run from those to chain as the run of its
+// next job is created, or returns ErrJobRunChained if it had already been
+// removed.
+func dequeueJobRunChain(db *gorm.DB, runID *models.ID) error {
+	result := db.Exec("DELETE FROM job_run_chains WHERE job_run_id = ?", runID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrJobRunChained
+	}
+	return nil
+}
+
+// UpdateJobSpecStatus sets the status of the job with the given ID, clearing
+// any previously recorded reason.
+func (orm *ORM) UpdateJobSpecStatus(ID *models.ID, status models.JobSpecStatus) error {
+	return orm.updateJobSpecStatus(ID, status, "")
+}
+
+// QuarantineJob marks the job with the given ID as failed to start, recording
+// the reason so that it can be inspected and retried.
+func (orm *ORM) QuarantineJob(ID *models.ID, reason string) error {
+	return orm.updateJobSpecStatus(ID, models.JobSpecStatusFailed, reason)
+}
+
+func (orm *ORM) updateJobSpecStatus(ID *models.ID, status models.JobSpecStatus, reason string) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Model(&models.JobSpec{}).
+		Where("id = ?", ID).
+		Updates(map[string]interface{}{"status": status, "status_reason": reason})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
 }
 
 // ArchiveJob soft deletes the job, job_runs and its initiator.
@@ -561,6 +1214,33 @@ func (orm *ORM) ArchiveJob(ID *models.ID) error {
 	})
 }
 
+// RestoreJob undoes ArchiveJob, restoring the job, its job_runs and the
+// initiators and tasks of its current version, or returns a
+// QuotaExceededError if its namespace cannot hold another job.
+func (orm *ORM) RestoreJob(ID *models.ID) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		var job models.JobSpec
+		if err := dbtx.Unscoped().Select("namespace").First(&job, "id = ? AND deleted_at IS NOT NULL", ID).Error; err != nil {
+			return err
+		}
+		if err := checkJobsQuota(dbtx, job.Namespace, 1); err != nil {
+			return err
+		}
+		result := dbtx.Exec("UPDATE job_specs SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", ID)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			return ErrorNotFound
+		}
+		return multierr.Combine(
+			dbtx.Exec("UPDATE initiators SET deleted_at = NULL WHERE job_spec_id = ? AND superseded_at IS NULL", ID).Error,
+			dbtx.Exec("UPDATE task_specs SET deleted_at = NULL WHERE job_spec_id = ? AND superseded_at IS NULL", ID).Error,
+			dbtx.Exec("UPDATE job_runs SET deleted_at = NULL WHERE job_spec_id = ?", ID).Error,
+		)
+	})
+}
+
 // CreateServiceAgreement saves a Service Agreement, its JobSpec and its
 // associations to the database.
 func (orm *ORM) CreateServiceAgreement(sa *models.ServiceAgreement) error {
@@ -954,18 +1634,80 @@ func (orm *ORM) ClearNonCurrentSessions(sessionID string) error {
 	return orm.DB.Where("id <> ?", sessionID).Delete(models.Session{}).Error
 }
 
-// JobsSorted returns many JobSpecs sorted by CreatedAt from the store adhering
-// to the passed parameters.
-func (orm *ORM) JobsSorted(sort SortType, offset int, limit int) ([]models.JobSpec, int, error) {
+// JobsSorted returns many JobSpecs in the given namespace sorted by CreatedAt
+// from the store adhering to the passed parameters.
+func (orm *ORM) JobsSorted(namespace string, sort SortType, offset int, limit int) ([]models.JobSpec, int, error) {
+	return orm.JobsFiltered(JobSpecFilter{Namespace: namespace}, sort, offset, limit)
+}
+
+// JobSpecFilter selects the jobs listed by JobsFiltered. Fields left empty
+// select every job.
+type JobSpecFilter struct {
+	Namespace     string
+	Status        models.JobSpecStatus
+	InitiatorType string
+	// TaskType selects jobs with a task of the type, such as a bridge's name
+	TaskType      string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Search selects jobs whose ID or one of whose task names contains it
+	Search string
+	// IncludeArchived selects archived jobs as well as the others
+	IncludeArchived bool
+}
+
+func (f JobSpecFilter) scope(db *gorm.DB) *gorm.DB {
+	if f.IncludeArchived {
+		db = db.Unscoped()
+	}
+	db = db.Where("namespace = ?", f.Namespace)
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+	if f.InitiatorType != "" {
+		db = db.Where("id IN (SELECT job_spec_id FROM initiators WHERE lower(type) = lower(?) AND deleted_at IS NULL)", f.InitiatorType)
+	}
+	if f.TaskType != "" {
+		db = db.Where("id IN (SELECT job_spec_id FROM task_specs WHERE lower(type) = lower(?) AND deleted_at IS NULL)", f.TaskType)
+	}
+	if !f.CreatedAfter.IsZero() {
+		db = db.Where("created_at >= ?", f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		db = db.Where("created_at < ?", f.CreatedBefore)
+	}
+	if f.Search != "" {
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(f.Search) + "%"
+		db = db.Where(`replace(id::text, '-', '') ILIKE ? OR id IN (SELECT job_spec_id FROM task_specs WHERE name ILIKE ? AND deleted_at IS NULL)`, pattern, pattern)
+	}
+	return db
+}
+
+// preloadFilteredJobs preloads the associations of the jobs selected by
+// filter. Those of archived jobs are soft deleted with them, which
+// auto_preload would leave out.
+func (orm *ORM) preloadFilteredJobs(filter JobSpecFilter) *gorm.DB {
+	if filter.IncludeArchived {
+		return orm.preloadJobs()
+	}
+	return orm.DB.Set("gorm:auto_preload", true)
+}
+
+// JobsFiltered returns a page of the JobSpecs selected by filter, sorted by
+// CreatedAt, and the number of JobSpecs selected.
+func (orm *ORM) JobsFiltered(filter JobSpecFilter, sort SortType, offset int, limit int) ([]models.JobSpec, int, error) {
 	orm.MustEnsureAdvisoryLock()
-	count, err := orm.CountOf(&models.JobSpec{})
+	var count int
+	err := filter.scope(orm.DB.Model(&models.JobSpec{})).Count(&count).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
 	var jobs []models.JobSpec
-	order := fmt.Sprintf("created_at %s", sort.String())
-	err = orm.getRecords(&jobs, order, offset, limit)
+	err = filter.scope(orm.preloadFilteredJobs(filter)).
+		Order(fmt.Sprintf("created_at %s", sort.String())).
+		Limit(limit).Offset(offset).
+		Find(&jobs).Error
 	return jobs, count, err
 }
 
@@ -1018,20 +1760,33 @@ func (orm *ORM) UnconfirmedTxAttempts() ([]models.TxAttempt, error) {
 	return items, err
 }
 
-// JobRunsSorted returns job runs ordered and filtered by the passed params.
-func (orm *ORM) JobRunsSorted(sort SortType, offset int, limit int) ([]models.JobRun, int, error) {
+// JobRunsSorted returns the job runs of the jobs in the given namespace
+// ordered and filtered by the passed params.
+func (orm *ORM) JobRunsSorted(namespace string, sort SortType, offset int, limit int) ([]models.JobRun, int, error) {
 	orm.MustEnsureAdvisoryLock()
-	count, err := orm.CountOf(&models.JobRun{})
+	var count int
+	err := jobRunsInNamespace(orm.DB.Model(&models.JobRun{}), namespace).Count(&count).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
 	var runs []models.JobRun
-	order := fmt.Sprintf("created_at %s", sort.String())
-	err = orm.getRecords(&runs, order, offset, limit)
+	err = jobRunsInNamespace(orm.preloadJobRuns(), namespace).
+		Order(fmt.Sprintf("job_runs.created_at %s", sort.String())).
+		Limit(limit).
+		Offset(offset).
+		Find(&runs).Error
 	return runs, count, err
 }
 
+// jobRunsInNamespace limits a query of job runs to those of the jobs in the
+// given namespace, archived or not.
+func jobRunsInNamespace(db *gorm.DB, namespace string) *gorm.DB {
+	return db.
+		Joins("JOIN job_specs ON job_specs.id = job_runs.job_spec_id").
+		Where("job_specs.namespace = ?", namespace)
+}
+
 // JobRunsSortedFor returns job runs for a specific job spec ordered and
 // filtered by the passed params.
 func (orm *ORM) JobRunsSortedFor(id *models.ID, order SortType, offset int, limit int) ([]models.JobRun, int, error) {
@@ -1051,20 +1806,277 @@ func (orm *ORM) JobRunsSortedFor(id *models.ID, order SortType, offset int, limi
 	return runs, count, err
 }
 
-// BridgeTypes returns bridge types ordered by name filtered limited by the
-// passed params.
-func (orm *ORM) BridgeTypes(offset int, limit int) ([]models.BridgeType, int, error) {
+// BridgeTypes returns the bridge types in the given namespace ordered by name
+// filtered limited by the passed params.
+func (orm *ORM) BridgeTypes(namespace string, offset int, limit int) ([]models.BridgeType, int, error) {
 	orm.MustEnsureAdvisoryLock()
-	count, err := orm.CountOf(&models.BridgeType{})
+	var count int
+	err := orm.DB.Model(&models.BridgeType{}).Where("namespace = ?", namespace).Count(&count).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
 	var bridges []models.BridgeType
-	err = orm.getRecords(&bridges, "name asc", offset, limit)
+	err = orm.DB.
+		Where("namespace = ?", namespace).
+		Order("name asc").
+		Limit(limit).Offset(offset).
+		Find(&bridges).Error
 	return bridges, count, err
 }
 
+// CreateNamespace saves a new namespace.
+func (orm *ORM) CreateNamespace(ns *models.Namespace) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Create(ns).Error
+}
+
+// FindNamespace looks up a namespace by its name.
+func (orm *ORM) FindNamespace(name string) (models.Namespace, error) {
+	orm.MustEnsureAdvisoryLock()
+	var ns models.Namespace
+	return ns, orm.DB.First(&ns, "name = ?", name).Error
+}
+
+// Namespaces returns every namespace ordered by name.
+func (orm *ORM) Namespaces() ([]models.Namespace, error) {
+	orm.MustEnsureAdvisoryLock()
+	var namespaces []models.Namespace
+	return namespaces, orm.DB.Order("name asc").Find(&namespaces).Error
+}
+
+// CreateNamespaceToken saves a new API token confined to a namespace.
+func (orm *ORM) CreateNamespaceToken(token *models.NamespaceToken) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Create(token).Error
+}
+
+// FindNamespaceToken looks up a namespace's API token by its access key.
+func (orm *ORM) FindNamespaceToken(accessKey string) (models.NamespaceToken, error) {
+	orm.MustEnsureAdvisoryLock()
+	var token models.NamespaceToken
+	return token, orm.DB.First(&token, "access_key = ?", accessKey).Error
+}
+
+// DeleteNamespaceToken removes an API token of a namespace.
+func (orm *ORM) DeleteNamespaceToken(namespace, accessKey string) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Where("namespace = ? AND access_key = ?", namespace, accessKey).Delete(&models.NamespaceToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// CreateJobSpecTemplate saves a new job spec template.
+func (orm *ORM) CreateJobSpecTemplate(t *models.JobSpecTemplate) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Create(t).Error
+}
+
+// FindJobSpecTemplate looks up a job spec template of a namespace by its name.
+func (orm *ORM) FindJobSpecTemplate(namespace, name string) (models.JobSpecTemplate, error) {
+	orm.MustEnsureAdvisoryLock()
+	var t models.JobSpecTemplate
+	return t, orm.DB.First(&t, "namespace = ? AND name = ?", namespace, name).Error
+}
+
+// JobSpecTemplates returns the job spec templates of a namespace ordered by
+// name.
+func (orm *ORM) JobSpecTemplates(namespace string) ([]models.JobSpecTemplate, error) {
+	orm.MustEnsureAdvisoryLock()
+	var templates []models.JobSpecTemplate
+	return templates, orm.DB.Where("namespace = ?", namespace).Order("name asc").Find(&templates).Error
+}
+
+// DeleteJobSpecTemplate removes a job spec template of a namespace. Jobs
+// created from it are kept.
+func (orm *ORM) DeleteJobSpecTemplate(namespace, name string) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Where("namespace = ? AND name = ?", namespace, name).Delete(&models.JobSpecTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// CreateWorkerGroup saves a new worker group.
+func (orm *ORM) CreateWorkerGroup(wg *models.WorkerGroup) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Create(wg).Error
+}
+
+// FindWorkerGroup looks up a worker group by its name, along with the IDs of
+// its active jobs.
+func (orm *ORM) FindWorkerGroup(name string) (models.WorkerGroup, error) {
+	orm.MustEnsureAdvisoryLock()
+	var wg models.WorkerGroup
+	if err := orm.DB.First(&wg, "name = ?", name).Error; err != nil {
+		return wg, err
+	}
+	err := orm.DB.Model(&models.JobSpec{}).Where("worker_group = ?", name).Order("created_at asc").Pluck("id", &wg.JobSpecIDs).Error
+	return wg, err
+}
+
+// WorkerGroups returns every worker group ordered by name, along with the
+// IDs of their active jobs.
+func (orm *ORM) WorkerGroups() ([]models.WorkerGroup, error) {
+	orm.MustEnsureAdvisoryLock()
+	var groups []models.WorkerGroup
+	if err := orm.DB.Order("name asc").Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		err := orm.DB.Model(&models.JobSpec{}).Where("worker_group = ?", groups[i].Name).Order("created_at asc").Pluck("id", &groups[i].JobSpecIDs).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// UpdateWorkerGroupConcurrency changes how many runs of a worker group's
+// jobs may execute at once.
+func (orm *ORM) UpdateWorkerGroupConcurrency(name string, concurrency int) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Model(&models.WorkerGroup{}).Where("name = ?", name).Update("concurrency", concurrency)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// DeleteWorkerGroup removes a worker group. Its jobs return to the shared
+// pool.
+func (orm *ORM) DeleteWorkerGroup(name string) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Where("name = ?", name).Delete(&models.WorkerGroup{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// SetJobWorkerGroup places a job in a worker group, or in the shared pool if
+// group is nil.
+func (orm *ORM) SetJobWorkerGroup(jobID *models.ID, group *string) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Exec(`UPDATE job_specs SET worker_group = ? WHERE id = ? AND deleted_at IS NULL`, group, jobID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// RotateWebhookSecrets gives each of a job's webhook initiators, or only
+// the one with initiatorID if given, a new secret of its own, keeping the
+// secret it replaces valid until expiresAt. It returns the new secrets, or
+// ErrorNotFound if there is no such initiator.
+func (orm *ORM) RotateWebhookSecrets(jobID *models.ID, initiatorID *int64, expiresAt time.Time) ([]models.RotatedWebhookSecret, error) {
+	orm.MustEnsureAdvisoryLock()
+	var secrets []models.RotatedWebhookSecret
+	err := orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		query := dbtx.Where("job_spec_id = ? AND type = ? AND deleted_at IS NULL", jobID, models.InitiatorWebhook)
+		if initiatorID != nil {
+			query = query.Where("id = ?", *initiatorID)
+		}
+		var initiators []models.Initiator
+		if err := query.Order("id asc").Find(&initiators).Error; err != nil {
+			return err
+		}
+		if len(initiators) == 0 {
+			return ErrorNotFound
+		}
+		for _, initr := range initiators {
+			secret := models.NewWebhookSecret()
+			err := dbtx.Exec(`
+				UPDATE initiators
+				SET previous_webhook_secret = webhook_secret, previous_webhook_secret_expires_at = ?, webhook_secret = ?
+				WHERE id = ?`,
+				expiresAt, secret, initr.ID).Error
+			if err != nil {
+				return err
+			}
+			secrets = append(secrets, models.RotatedWebhookSecret{InitiatorID: initr.ID, Secret: secret})
+		}
+		return nil
+	})
+	return secrets, err
+}
+
+// FindJobWorkerGroup returns the worker group of a job, or nil if the job
+// is in the shared pool. The group's job IDs are not loaded.
+func (orm *ORM) FindJobWorkerGroup(jobID *models.ID) (*models.WorkerGroup, error) {
+	orm.MustEnsureAdvisoryLock()
+	var groups []models.WorkerGroup
+	err := orm.DB.
+		Joins("JOIN job_specs ON job_specs.worker_group = worker_groups.name").
+		Where("job_specs.id = ?", jobID).
+		Find(&groups).Error
+	if err != nil || len(groups) == 0 {
+		return nil, err
+	}
+	return &groups[0], nil
+}
+
+// UpdateNamespaceQuotas replaces the quotas of a namespace.
+func (orm *ORM) UpdateNamespaceQuotas(name string, quotas models.NamespaceQuotas) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Model(&models.Namespace{}).Where("name = ?", name).Updates(map[string]interface{}{
+		"max_jobs":            quotas.MaxJobs,
+		"max_runs_per_minute": quotas.MaxRunsPerMinute,
+		"max_gas_per_day":     quotas.MaxGasPerDay,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// UpdateNamespaceSpecPolicy replaces the spec policy of a namespace.
+func (orm *ORM) UpdateNamespaceSpecPolicy(name string, policy models.NamespaceSpecPolicy) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Model(&models.Namespace{}).Where("name = ?", name).Update("spec_policy", policy)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// SetKeyNamespace moves the key with the given address into a namespace.
+func (orm *ORM) SetKeyNamespace(address common.Address, namespace string) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.DB.Model(&models.Key{}).Where("address = ?", address).Update("namespace", namespace)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
 // SaveUser saves the user.
 func (orm *ORM) SaveUser(user *models.User) error {
 	orm.MustEnsureAdvisoryLock()
@@ -1089,10 +2101,19 @@ func (orm *ORM) CreateBridgeType(bt *models.BridgeType) error {
 	return orm.DB.Create(bt).Error
 }
 
+// SaveBridgeType saves every field of the bridge type, creating it if it
+// does not exist.
+func (orm *ORM) SaveBridgeType(bt *models.BridgeType) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Save(bt).Error
+}
+
 // UpdateBridgeType updates the bridge type.
 func (orm *ORM) UpdateBridgeType(bt *models.BridgeType, btr *models.BridgeTypeRequest) error {
 	orm.MustEnsureAdvisoryLock()
 	bt.URL = btr.URL
+	bt.FailoverURLs = btr.FailoverURLs
+	bt.URLSelection = btr.URLSelection
 	bt.Confirmations = btr.Confirmations
 	bt.MinimumContractPayment = btr.MinimumContractPayment
 	return orm.DB.Save(bt).Error
@@ -1343,7 +2364,7 @@ func (orm *ORM) DeleteEncryptedOCRKeyBundle(key *ocrkey.EncryptedKeyBundle) (err
 
 // GetRoundRobinAddress queries the database for the address of a random ethereum key derived from the id.
 // This takes an optional param for a slice of addresses it should pick from. Leave empty to pick from all
-// addresses in the database.
+// addresses in the default namespace.
 // NOTE: We can add more advanced logic here later such as sorting by priority
 // etc
 func (orm *ORM) GetRoundRobinAddress(addresses ...common.Address) (address common.Address, err error) {
@@ -1352,6 +2373,8 @@ func (orm *ORM) GetRoundRobinAddress(addresses ...common.Address) (address commo
 		q = q.Where("is_funding = FALSE")
 		if len(addresses) > 0 {
 			q = q.Where("address in (?)", addresses)
+		} else {
+			q = q.Where("namespace = ?", models.DefaultNamespace)
 		}
 		keys := make([]models.Key, 0)
 		err = q.Find(&keys).Error
@@ -1445,6 +2468,138 @@ func (orm *ORM) UpdateFluxMonitorRoundStats(aggregator common.Address, roundID u
     `, aggregator, roundID, jobRunID).Error
 }
 
+// JobExportState returns the logs consumed by a job, and the flux monitor
+// round stats of the aggregators it monitors, for export to another node.
+func (orm *ORM) JobExportState(job models.JobSpec) ([]models.LogConsumption, []models.FluxMonitorRoundStats, error) {
+	orm.MustEnsureAdvisoryLock()
+	var logs []models.LogConsumption
+	err := orm.DB.Where("job_id = ?", job.ID).Order("block_number asc, log_index asc").Find(&logs).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var aggregators [][]byte
+	for _, initr := range job.InitiatorsFor(models.InitiatorFluxMonitor) {
+		aggregators = append(aggregators, initr.Address.Bytes())
+	}
+	rounds := []models.FluxMonitorRoundStats{}
+	if len(aggregators) > 0 {
+		err = orm.DB.Where("aggregator IN (?)", aggregators).Order("aggregator asc, round_id asc").Find(&rounds).Error
+	}
+	return logs, rounds, err
+}
+
+// ImportJobState records the logs consumed and flux monitor rounds seen by an
+// imported job, so that it neither reprocesses those logs nor submits to
+// those rounds again. Rounds which this node has also seen keep the larger
+// of the two counts.
+func (orm *ORM) ImportJobState(export models.JobExport) error {
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		return importJobState(dbtx, export)
+	})
+}
+
+// CreateImportedJob saves an imported job and its state, see
+// ImportJobState, or neither if either cannot be saved.
+func (orm *ORM) CreateImportedJob(job *models.JobSpec, export models.JobExport) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		if err := orm.createJob(dbtx, job); err != nil {
+			return err
+		}
+		return errors.Wrap(importJobState(dbtx, export), "importing job state")
+	})
+}
+
+func importJobState(dbtx *gorm.DB, export models.JobExport) error {
+	for _, log := range export.LogConsumptions {
+		err := dbtx.Exec(`
+			INSERT INTO log_consumptions (block_hash, log_index, job_id, block_number, created_at)
+			VALUES (?, ?, ?, ?, NOW())
+			ON CONFLICT (job_id, block_hash, log_index) DO NOTHING
+		`, log.BlockHash, log.LogIndex, export.ID, log.BlockNumber).Error
+		if err != nil {
+			return err
+		}
+	}
+	for _, round := range export.FluxMonitorRounds {
+		err := dbtx.Exec(`
+			INSERT INTO flux_monitor_round_stats (aggregator, round_id, num_new_round_logs, num_submissions)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (aggregator, round_id) DO UPDATE SET
+				num_new_round_logs = GREATEST(flux_monitor_round_stats.num_new_round_logs, EXCLUDED.num_new_round_logs),
+				num_submissions = GREATEST(flux_monitor_round_stats.num_submissions, EXCLUDED.num_submissions)
+		`, round.Aggregator, round.RoundID, round.NumNewRoundLogs, round.NumSubmissions).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FleetBundle returns the bridges, external initiators and unarchived jobs
+// of a namespace, for the other nodes of a fleet to sync to.
+func (orm *ORM) FleetBundle(namespace string) (models.FleetBundle, error) {
+	orm.MustEnsureAdvisoryLock()
+	bundle := models.FleetBundle{
+		Bridges:            []models.FleetBridge{},
+		ExternalInitiators: []models.FleetExternalInitiator{},
+		Jobs:               []models.FleetJob{},
+	}
+
+	var bridges []models.BridgeType
+	if err := orm.DB.Where("namespace = ?", namespace).Order("name asc").Find(&bridges).Error; err != nil {
+		return bundle, err
+	}
+	for _, bt := range bridges {
+		bundle.Bridges = append(bundle.Bridges, models.NewFleetBridge(bt))
+	}
+
+	var eis []models.ExternalInitiator
+	if err := orm.DB.Where("namespace = ?", namespace).Order("name asc").Find(&eis).Error; err != nil {
+		return bundle, err
+	}
+	for _, ei := range eis {
+		bundle.ExternalInitiators = append(bundle.ExternalInitiators, models.NewFleetExternalInitiator(ei))
+	}
+
+	var jobs []models.JobSpec
+	if err := orm.preloadJobs().Where("namespace = ?", namespace).Order("created_at asc").Find(&jobs).Error; err != nil {
+		return bundle, err
+	}
+	for _, job := range jobs {
+		bundle.Jobs = append(bundle.Jobs, models.NewFleetJob(job))
+	}
+	return bundle, nil
+}
+
+// FleetSyncResources returns the names of the resources of the given kind
+// which fleet sync manages.
+func (orm *ORM) FleetSyncResources(kind string) ([]string, error) {
+	orm.MustEnsureAdvisoryLock()
+	var names []string
+	return names, orm.DB.Model(&models.FleetSyncResource{}).
+		Where("kind = ?", kind).
+		Order("name asc").
+		Pluck("name", &names).Error
+}
+
+// TrackFleetSyncResource records that fleet sync manages a resource.
+func (orm *ORM) TrackFleetSyncResource(kind, name string) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Exec(`
+		INSERT INTO fleet_sync_resources (kind, name, created_at) VALUES (?, ?, NOW())
+		ON CONFLICT (kind, name) DO NOTHING
+	`, kind, name).Error
+}
+
+// UntrackFleetSyncResource records that fleet sync no longer manages a
+// resource.
+func (orm *ORM) UntrackFleetSyncResource(kind, name string) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Where("kind = ? AND name = ?", kind, name).Delete(&models.FleetSyncResource{}).Error
+}
+
 // ClobberDiskKeyStoreWithDBKeys writes all keys stored in the orm to
 // the keys folder on disk, deleting anything there prior.
 func (orm *ORM) ClobberDiskKeyStoreWithDBKeys(keysDir string) error {
@@ -1,11 +1,13 @@
 package orm_test
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -55,6 +57,59 @@ func TestORM_CreateJob(t *testing.T) {
 	assert.Equal(t, j2.ID, j2.Initiators[0].JobSpecID)
 }
 
+func TestORM_JobsFiltered(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	web := cltest.NewJobWithWebInitiator()
+	web.CreatedAt = time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	web.Tasks[0].Name = "fetch_price"
+	require.NoError(t, store.CreateJob(&web))
+
+	runLog := cltest.NewJobWithRunLogInitiator()
+	runLog.CreatedAt = time.Date(2020, 10, 5, 0, 0, 0, 0, time.UTC)
+	runLog.Tasks = []models.TaskSpec{{Type: models.MustNewTaskType("coinmarketcap")}}
+	require.NoError(t, store.CreateJob(&runLog))
+
+	stopped := cltest.NewJobWithWebInitiator()
+	stopped.CreatedAt = time.Date(2020, 10, 9, 0, 0, 0, 0, time.UTC)
+	stopped.Status = models.JobSpecStatusStopped
+	require.NoError(t, store.CreateJob(&stopped))
+
+	tests := []struct {
+		name   string
+		filter orm.JobSpecFilter
+		want   []*models.ID
+	}{
+		{"all", orm.JobSpecFilter{}, []*models.ID{web.ID, runLog.ID, stopped.ID}},
+		{"status", orm.JobSpecFilter{Status: models.JobSpecStatusStopped}, []*models.ID{stopped.ID}},
+		{"initiator type", orm.JobSpecFilter{InitiatorType: "RunLog"}, []*models.ID{runLog.ID}},
+		{"task type", orm.JobSpecFilter{TaskType: "coinmarketcap"}, []*models.ID{runLog.ID}},
+		{"created after", orm.JobSpecFilter{CreatedAfter: runLog.CreatedAt}, []*models.ID{runLog.ID, stopped.ID}},
+		{"created before", orm.JobSpecFilter{CreatedBefore: runLog.CreatedAt}, []*models.ID{web.ID}},
+		{"search task name", orm.JobSpecFilter{Search: "PRICE"}, []*models.ID{web.ID}},
+		{"search id", orm.JobSpecFilter{Search: stopped.ID.String()[:12]}, []*models.ID{stopped.ID}},
+		{"search wildcard is literal", orm.JobSpecFilter{Search: "%"}, []*models.ID{}},
+		{"combined", orm.JobSpecFilter{InitiatorType: "web", Status: models.JobSpecStatusActive}, []*models.ID{web.ID}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := test.filter
+			filter.Namespace = models.DefaultNamespace
+			jobs, count, err := store.JobsFiltered(filter, orm.Ascending, 0, 10)
+			require.NoError(t, err)
+			assert.Equal(t, len(test.want), count)
+			ids := []*models.ID{}
+			for _, job := range jobs {
+				ids = append(ids, job.ID)
+			}
+			assert.Equal(t, test.want, ids)
+		})
+	}
+}
+
 func TestORM_Unscoped(t *testing.T) {
 	t.Parallel()
 	store, cleanup := cltest.NewStore(t)
@@ -113,6 +168,30 @@ func TestORM_CreateExternalInitiator(t *testing.T) {
 	require.Equal(t, store.CreateExternalInitiator(exi).Error(), `pq: duplicate key value violates unique constraint "external_initiators_name_key"`)
 }
 
+func TestORM_UseNonce(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	expiresAt := time.Now().Add(time.Minute)
+	fresh, err := store.UseNonce("external_initiator/1", "nonce", expiresAt)
+	require.NoError(t, err)
+	assert.True(t, fresh)
+	fresh, err = store.UseNonce("external_initiator/1", "nonce", expiresAt)
+	require.NoError(t, err)
+	assert.False(t, fresh)
+	fresh, err = store.UseNonce("external_initiator/2", "nonce", expiresAt)
+	require.NoError(t, err)
+	assert.True(t, fresh)
+
+	// An expired nonce is deleted, as its signature is no longer accepted
+	fresh, err = store.UseNonce("external_initiator/1", "expired", time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	assert.True(t, fresh)
+	fresh, err = store.UseNonce("external_initiator/1", "expired", expiresAt)
+	require.NoError(t, err)
+	assert.True(t, fresh)
+}
+
 func TestORM_DeleteExternalInitiator(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -158,6 +237,41 @@ func TestORM_ArchiveJob(t *testing.T) {
 	require.NoError(t, utils.JustError(orm.FindJobRun(run.ID)))
 }
 
+func TestORM_RestoreJob(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithSchedule("* * * * *")
+	require.NoError(t, store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	assert.Equal(t, orm.ErrorNotFound, store.RestoreJob(job.ID))
+
+	require.NoError(t, store.ArchiveJob(job.ID))
+	filter := orm.JobSpecFilter{Namespace: models.DefaultNamespace}
+	_, count, err := store.JobsFiltered(filter, orm.Ascending, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	filter.IncludeArchived = true
+	jobs, count, err := store.JobsFiltered(filter, orm.Ascending, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, jobs, 1)
+	assert.True(t, jobs[0].Archived())
+	assert.Len(t, jobs[0].Initiators, 1)
+
+	require.NoError(t, store.RestoreJob(job.ID))
+
+	restored, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.False(t, restored.Archived())
+	assert.Len(t, restored.Initiators, 1)
+	assert.Len(t, restored.Tasks, len(job.Tasks))
+	require.NoError(t, utils.JustError(store.FindJobRun(run.ID)))
+}
+
 func TestORM_CreateJobRun_CreatesRunRequest(t *testing.T) {
 	t.Parallel()
 	store, cleanup := cltest.NewStore(t)
@@ -346,6 +460,151 @@ func TestORM_JobRunsSortedFor(t *testing.T) {
 	assert.Equal(t, []*models.ID{jr2.ID, jr1.ID}, actual)
 }
 
+func TestORM_JobsAfter(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	createdAt := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	var ids []*models.ID
+	for i := 0; i < 5; i++ {
+		job := cltest.NewJobWithWebInitiator()
+		// The last two share a creation time, so are ordered by ID.
+		job.CreatedAt = createdAt.Add(time.Duration(i-i/4) * time.Hour)
+		require.NoError(t, store.CreateJob(&job))
+		ids = append(ids, job.ID)
+	}
+	if ids[3].String() > ids[4].String() {
+		ids[3], ids[4] = ids[4], ids[3]
+	}
+
+	filter := orm.JobSpecFilter{Namespace: models.DefaultNamespace}
+	var pages [][]*models.ID
+	var cursor *orm.Cursor
+	for {
+		jobs, next, err := store.JobsAfter(filter, orm.Ascending, cursor, 2)
+		require.NoError(t, err)
+		var page []*models.ID
+		for _, job := range jobs {
+			page = append(page, job.ID)
+		}
+		pages = append(pages, page)
+		if next == nil {
+			break
+		}
+		parsed, err := orm.ParseCursor(next.String())
+		require.NoError(t, err)
+		cursor = &parsed
+	}
+	assert.Equal(t, [][]*models.ID{ids[0:2], ids[2:4], ids[4:5]}, pages)
+
+	jobs, next, err := store.JobsAfter(filter, orm.Descending, nil, 3)
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+	assert.Equal(t, []*models.ID{ids[4], ids[3], ids[2]}, []*models.ID{jobs[0].ID, jobs[1].ID, jobs[2].ID})
+	require.NotNil(t, next)
+	jobs, next, err = store.JobsAfter(filter, orm.Descending, next, 3)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, []*models.ID{ids[1], ids[0]}, []*models.ID{jobs[0].ID, jobs[1].ID})
+	assert.Nil(t, next)
+}
+
+func TestORM_JobRunsAfter(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	includedJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&includedJob))
+	excludedJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&excludedJob))
+
+	jr1 := cltest.NewJobRun(includedJob)
+	jr1.CreatedAt = time.Now().AddDate(0, 0, -2)
+	require.NoError(t, store.CreateJobRun(&jr1))
+	excludedJobRun := cltest.NewJobRun(excludedJob)
+	excludedJobRun.CreatedAt = time.Now().AddDate(0, 0, -1)
+	require.NoError(t, store.CreateJobRun(&excludedJobRun))
+	jr2 := cltest.NewJobRun(includedJob)
+	jr2.CreatedAt = time.Now()
+	require.NoError(t, store.CreateJobRun(&jr2))
+
+	runs, next, err := store.JobRunsAfter(models.DefaultNamespace, includedJob.ID, orm.Ascending, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, jr1.ID, runs[0].ID)
+	require.NotNil(t, next)
+
+	runs, next, err = store.JobRunsAfter(models.DefaultNamespace, includedJob.ID, orm.Ascending, next, 1)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, jr2.ID, runs[0].ID)
+	assert.Nil(t, next)
+
+	runs, next, err = store.JobRunsAfter(models.DefaultNamespace, nil, orm.Ascending, nil, 10)
+	require.NoError(t, err)
+	assert.Len(t, runs, 3)
+	assert.Nil(t, next)
+
+	runs, next, err = store.JobRunsAfter("team-a", nil, orm.Ascending, nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+	assert.Nil(t, next)
+}
+
+func TestORM_EachJobRunBetween(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	otherJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&otherJob))
+
+	start := time.Now().AddDate(0, 0, -1)
+	var runIDs []*models.ID
+	for i := 0; i < orm.BatchSize+2; i++ {
+		run := cltest.NewJobRun(job)
+		run.CreatedAt = start.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, store.CreateJobRun(&run))
+		runIDs = append(runIDs, run.ID)
+	}
+	otherRun := cltest.NewJobRun(otherJob)
+	require.NoError(t, store.CreateJobRun(&otherRun))
+
+	each := func(from, to time.Time) []*models.ID {
+		var ids []*models.ID
+		require.NoError(t, store.EachJobRunBetween(job.ID, from, to, func(run *models.JobRun) error {
+			ids = append(ids, run.ID)
+			return nil
+		}))
+		return ids
+	}
+
+	assert.Equal(t, runIDs, each(time.Time{}, time.Time{}))
+	assert.Equal(t, runIDs[2:5], each(start.Add(2*time.Minute), start.Add(5*time.Minute)))
+	assert.Empty(t, each(time.Now(), time.Time{}))
+
+	err := store.EachJobRunBetween(job.ID, time.Time{}, time.Time{}, func(*models.JobRun) error {
+		return errors.New("stop")
+	})
+	assert.EqualError(t, err, "stop")
+}
+
+func TestParseCursor_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"not base64!", "bm9jb2xvbg", "eDpub3RhbklE"} {
+		_, err := orm.ParseCursor(input)
+		assert.Error(t, err, input)
+	}
+}
+
 func TestORM_UnscopedJobRunsWithStatus_Happy(t *testing.T) {
 	t.Parallel()
 	store, cleanup := cltest.NewStore(t)
@@ -996,7 +1255,7 @@ func TestORM_AllSyncEvents(t *testing.T) {
 	defer cleanup()
 
 	orm := store.ORM
-	synchronization.NewStatsPusher(orm, cltest.MustParseURL("http://localhost"), "", "")
+	synchronization.NewStatsPusher(orm, cltest.MustParseURL("http://localhost"), "", "", 0)
 
 	// Create two events via job run callback
 	job := cltest.NewJobWithWebInitiator()
@@ -1024,6 +1283,32 @@ func TestORM_AllSyncEvents(t *testing.T) {
 	assert.Greater(t, events[1].ID, events[0].ID)
 }
 
+func TestORM_TrimSyncEvents(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, store.DB.Create(&models.SyncEvent{Body: fmt.Sprintf(`{"n": %d}`, i)}).Error)
+	}
+
+	dropped, err := store.TrimSyncEvents(5)
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+
+	dropped, err = store.TrimSyncEvents(2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), dropped)
+
+	var events []models.SyncEvent
+	require.NoError(t, store.AllSyncEvents(func(event models.SyncEvent) error {
+		events = append(events, event)
+		return nil
+	}))
+	require.Len(t, events, 2)
+	assert.Equal(t, `{"n": 3}`, events[0].Body)
+	assert.Equal(t, `{"n": 4}`, events[1].Body)
+}
+
 func TestBulkDeleteRuns(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -1539,6 +1824,135 @@ func TestJobs_ScopedInitiator(t *testing.T) {
 	assert.ElementsMatch(t, expectation, actual)
 }
 
+func TestJobs_SkipsStoppedAndQuarantined(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	activeJob := cltest.NewJobWithFluxMonitorInitiator()
+	stoppedJob := cltest.NewJobWithFluxMonitorInitiator()
+	stoppedJob.Status = models.JobSpecStatusStopped
+	quarantinedJob := cltest.NewJobWithFluxMonitorInitiator()
+
+	require.NoError(t, store.CreateJob(&activeJob))
+	require.NoError(t, store.CreateJob(&stoppedJob))
+	require.NoError(t, store.CreateJob(&quarantinedJob))
+	require.NoError(t, store.QuarantineJob(quarantinedJob.ID, "unable to create deviation checker"))
+
+	var actual []string
+	err := store.Jobs(func(j *models.JobSpec) bool {
+		actual = append(actual, j.ID.String())
+		return true
+	}, models.InitiatorFluxMonitor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{activeJob.ID.String()}, actual)
+}
+
+func TestORM_QuarantineJob(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithLogInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	require.Equal(t, orm.ErrorNotFound, store.QuarantineJob(models.NewID(), "missing"))
+
+	require.NoError(t, store.QuarantineJob(job.ID, "unable to subscribe"))
+	job, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.True(t, job.Quarantined())
+	assert.Equal(t, "unable to subscribe", job.StatusReason)
+
+	quarantined, err := store.QuarantinedJobs()
+	require.NoError(t, err)
+	require.Len(t, quarantined, 1)
+	assert.Equal(t, job.ID, quarantined[0].ID)
+
+	require.NoError(t, store.UpdateJobSpecStatus(job.ID, models.JobSpecStatusActive))
+	job, err = store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobSpecStatusActive, job.Status)
+	assert.Empty(t, job.StatusReason)
+
+	quarantined, err = store.QuarantinedJobs()
+	require.NoError(t, err)
+	assert.Empty(t, quarantined)
+}
+
+func TestORM_SaveSourceLatencies_KeepsMostRecent(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	now := time.Now()
+	require.NoError(t, store.SaveSourceLatencies([]models.SourceLatency{
+		{Source: "old.example.org", Samples: cltest.JSONFromString(t, `[1]`), UpdatedAt: now.Add(-time.Minute)},
+		{Source: "api.example.org", Samples: cltest.JSONFromString(t, `[1]`), UpdatedAt: now},
+	}, 2))
+	require.NoError(t, store.SaveSourceLatencies([]models.SourceLatency{
+		{Source: "new.example.org", Samples: cltest.JSONFromString(t, `[2]`), UpdatedAt: now},
+	}, 2))
+
+	saved, err := store.SourceLatencies()
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, "api.example.org", saved[0].Source)
+	assert.Equal(t, "new.example.org", saved[1].Source)
+}
+
+func TestORM_TransactionAllowed(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	from := cltest.MustInsertRandomKey(t, store).Address.Address()
+	other := cltest.MustInsertRandomKey(t, store)
+	require.NoError(t, store.CreateNamespace(&models.Namespace{Name: "other"}))
+	require.NoError(t, store.DB.Model(&models.Key{}).Where("address = ?", other.Address).Update("namespace", "other").Error)
+
+	assertAllowedFrom := func(from, address common.Address, expected bool) {
+		allowed, err := store.TransactionAllowed(from, address)
+		require.NoError(t, err)
+		assert.Equal(t, expected, allowed, address.Hex())
+	}
+	assertAllowed := func(address common.Address, expected bool) {
+		assertAllowedFrom(from, address, expected)
+	}
+
+	job := cltest.NewJobWithRunLogInitiator()
+	taskAddress := cltest.NewAddress()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "ethtx", fmt.Sprintf(`{"address": "%s"}`, strings.ToLower(taskAddress.Hex())))}
+	require.NoError(t, store.CreateJob(&job))
+	initiatorAddress := job.Initiators[0].Address
+
+	manualAddress := cltest.NewAddress()
+	assertAllowed(initiatorAddress, true)
+	assertAllowed(taskAddress, true)
+	assertAllowed(manualAddress, false)
+
+	// Jobs only allow their contracts to the keys of their namespace
+	assertAllowedFrom(other.Address.Address(), initiatorAddress, false)
+	assertAllowedFrom(other.Address.Address(), taskAddress, false)
+
+	_, err := store.AddToTransactionAllowlist(manualAddress, "multisig", "operator")
+	require.NoError(t, err)
+	assertAllowed(manualAddress, true)
+	assertAllowedFrom(other.Address.Address(), manualAddress, true)
+
+	entries, err := store.TransactionAllowlist()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, manualAddress, entries[0].Address)
+
+	require.NoError(t, store.RemoveFromTransactionAllowlist(manualAddress))
+	assertAllowed(manualAddress, false)
+	assert.Equal(t, orm.ErrorNotFound, store.RemoveFromTransactionAllowlist(manualAddress))
+
+	// Archived jobs no longer allow their contracts
+	require.NoError(t, store.ArchiveJob(job.ID))
+	assertAllowed(initiatorAddress, false)
+	assertAllowed(taskAddress, false)
+}
+
 // TestJobs_SQLiteBatchSizeIntegrity verifies the BatchSize is safe for SQLite
 // to handle.  Problems were experienced earlier with a size of 1001.
 func TestJobs_SQLiteBatchSizeIntegrity(t *testing.T) {
@@ -1874,6 +2288,75 @@ func TestORM_UpsertErrorFor_Error(t *testing.T) {
 	}
 }
 
+func TestORM_AcknowledgeJobSpecError(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJob()
+	require.NoError(t, store.CreateJob(&job))
+	store.UpsertErrorFor(job.ID, "acknowledged")
+	store.UpsertErrorFor(job.ID, "unacknowledged")
+	jse, err := store.FindJobSpecError(job.ID, "acknowledged")
+	require.NoError(t, err)
+
+	now := time.Now()
+	acknowledged, err := store.AcknowledgeJobSpecError(models.DefaultNamespace, jse.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, jse.ID, acknowledged.ID)
+	require.True(t, acknowledged.AcknowledgedAt.Valid)
+	assert.WithinDuration(t, now, acknowledged.AcknowledgedAt.Time, time.Millisecond)
+
+	jses, err := store.JobSpecErrorsFor(job.ID, false)
+	require.NoError(t, err)
+	require.Len(t, jses, 1)
+	assert.Equal(t, "unacknowledged", jses[0].Description)
+
+	jses, err = store.JobSpecErrorsFor(job.ID, true)
+	require.NoError(t, err)
+	require.Len(t, jses, 2)
+
+	// Occurring again clears the acknowledgement
+	store.UpsertErrorFor(job.ID, "acknowledged")
+	jses, err = store.JobSpecErrorsFor(job.ID, false)
+	require.NoError(t, err)
+	assert.Len(t, jses, 2)
+
+	_, err = store.AcknowledgeJobSpecError(models.DefaultNamespace, jse.ID+1000, now)
+	assert.Equal(t, orm.ErrorNotFound, err)
+	_, err = store.AcknowledgeJobSpecError("team-a", jse.ID, now)
+	assert.Equal(t, orm.ErrorNotFound, err)
+}
+
+func TestORM_ClearJobSpecErrorsBefore(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job1 := cltest.NewJob()
+	require.NoError(t, store.CreateJob(&job1))
+	job2 := cltest.NewJob()
+	require.NoError(t, store.CreateJob(&job2))
+
+	store.UpsertErrorFor(job1.ID, "old")
+	store.UpsertErrorFor(job2.ID, "old")
+	require.NoError(t, store.DB.Exec(`UPDATE job_spec_errors SET updated_at = ?`, time.Now().Add(-time.Hour)).Error)
+	store.UpsertErrorFor(job1.ID, "new")
+
+	require.NoError(t, store.ClearJobSpecErrorsBefore(job1.ID, time.Now().Add(-time.Minute)))
+
+	jses, err := store.JobSpecErrorsFor(job1.ID, true)
+	require.NoError(t, err)
+	require.Len(t, jses, 1)
+	assert.Equal(t, "new", jses[0].Description)
+
+	jses, err = store.JobSpecErrorsFor(job2.ID, true)
+	require.NoError(t, err)
+	assert.Len(t, jses, 1)
+}
+
 func TestORM_FindOrCreateFluxMonitorRoundStats(t *testing.T) {
 	t.Parallel()
 
@@ -2041,3 +2524,34 @@ func TestORM_GetRoundRobinAddress(t *testing.T) {
 		require.Equal(t, "no keys available", err.Error())
 	})
 }
+
+func TestORM_ReviewFundsSweep(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	sweep := models.FundsSweep{
+		FromAddress: cltest.NewAddress(),
+		ToAddress:   cltest.NewAddress(),
+		Status:      models.FundsSweepStatusPending,
+		RequestedBy: "requester",
+	}
+	require.NoError(t, store.CreateFundsSweep(&sweep))
+
+	approval := sweep
+	approval.Status = models.FundsSweepStatusExecuted
+	approval.ReviewedBy = null.StringFrom("approver")
+	approval.ReviewedAt = null.TimeFrom(time.Now())
+	require.NoError(t, store.ReviewFundsSweep(&approval))
+
+	// A concurrent review which read the sweep while it was still pending
+	rejection := sweep
+	rejection.Status = models.FundsSweepStatusRejected
+	rejection.ReviewedBy = null.StringFrom("rejecter")
+	assert.Equal(t, orm.ErrOptimisticUpdateConflict, store.ReviewFundsSweep(&rejection))
+
+	found, err := store.FindFundsSweep(sweep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.FundsSweepStatusExecuted, found.Status)
+	assert.Equal(t, "approver", found.ReviewedBy.String)
+}
@@ -0,0 +1,238 @@
+package orm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	promQuotaExhausted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "quota_exhausted_total",
+		Help: "The number of jobs, runs and transactions rejected because a namespace or job quota was used up",
+	},
+		[]string{"namespace", "quota"},
+	)
+)
+
+// QuotaExceededError is returned when creating a job, run or transaction
+// would take a namespace or job past one of its quotas.
+// JobSpecID is only set when the quota belongs to the job rather than its
+// namespace.
+type QuotaExceededError struct {
+	Namespace string
+	JobSpecID *models.ID
+	Quota     string
+	Limit     int64
+}
+
+// Error returns the error message naming the quota which was exhausted.
+func (e QuotaExceededError) Error() string {
+	if e.JobSpecID != nil {
+		return fmt.Sprintf("job %s has exhausted its %s quota of %d", e.JobSpecID, e.Quota, e.Limit)
+	}
+	return fmt.Sprintf("namespace %s has exhausted its %s quota of %d", e.Namespace, e.Quota, e.Limit)
+}
+
+// IsQuotaExceeded returns true if err was caused by an exhausted quota.
+func IsQuotaExceeded(err error) bool {
+	_, ok := errors.Cause(err).(QuotaExceededError)
+	return ok
+}
+
+func quotaExceeded(err QuotaExceededError) error {
+	label := err.Quota
+	if err.JobSpecID != nil {
+		label = "job:" + label
+	}
+	promQuotaExhausted.WithLabelValues(err.Namespace, label).Inc()
+	logger.Errorw(err.Error(), "namespace", err.Namespace, "job", err.JobSpecID, "quota", err.Quota, "limit", err.Limit)
+	return err
+}
+
+// CheckJobQuota returns a QuotaExceededError if the namespace cannot hold
+// another job.
+func (orm *ORM) CheckJobQuota(namespace string) error {
+	return orm.CheckJobsQuota(namespace, 1)
+}
+
+// CheckJobsQuota returns a QuotaExceededError if the namespace cannot hold n
+// more jobs. The quota is checked again, under lock, as each job is created.
+func (orm *ORM) CheckJobsQuota(namespace string, n int) error {
+	orm.MustEnsureAdvisoryLock()
+	return checkJobsQuota(orm.DB, namespace, n)
+}
+
+func checkJobsQuota(db *gorm.DB, namespace string, n int) error {
+	ns, err := lockNamespaceQuota(db, namespace, func(ns models.Namespace) clnull.Int64 { return ns.MaxJobs })
+	if err != nil {
+		return errors.Wrap(err, "CheckJobQuota failed to find namespace")
+	}
+	if !ns.MaxJobs.Valid {
+		return nil
+	}
+
+	var count int64
+	err = db.Model(&models.JobSpec{}).Where("namespace = ?", namespace).Count(&count).Error
+	if err != nil {
+		return errors.Wrap(err, "CheckJobQuota failed to count jobs")
+	}
+	if count+int64(n) > ns.MaxJobs.Int64 {
+		return quotaExceeded(QuotaExceededError{Namespace: namespace, Quota: "maxJobs", Limit: ns.MaxJobs.Int64})
+	}
+	return nil
+}
+
+// CreateJobRunWithinQuota saves a new run of the job created at the given
+// time, or returns a QuotaExceededError if it would exceed the job's or its
+// namespace's runs per minute. The job and its namespace are locked while
+// their runs are counted, so that runs created concurrently cannot together
+// exceed their quotas. A run of a next job is created in the same
+// transaction which takes the run it was chained from off those to chain,
+// so that a next job is run exactly once with each result.
+func (orm *ORM) CreateJobRunWithinQuota(job *models.JobSpec, run *models.JobRun, now time.Time) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		if previous := run.RunRequest.PreviousJobRunID; previous != nil {
+			if err := dequeueJobRunChain(dbtx, previous); err != nil {
+				return err
+			}
+		}
+		if err := checkRunQuota(dbtx, job, now); err != nil {
+			return err
+		}
+		return dbtx.Create(run).Error
+	})
+}
+
+// CheckRunQuota returns a QuotaExceededError if creating a run for the job at
+// the given time would exceed the job's or its namespace's runs per minute.
+func (orm *ORM) CheckRunQuota(job *models.JobSpec, now time.Time) error {
+	orm.MustEnsureAdvisoryLock()
+	return checkRunQuota(orm.DB, job, now)
+}
+
+func checkRunQuota(db *gorm.DB, job *models.JobSpec, now time.Time) error {
+	since := now.Add(-time.Minute)
+	if job.MaxRunsPerMinute.Valid {
+		err := db.Set("gorm:query_option", "FOR UPDATE").First(&models.JobSpec{}, "id = ?", job.ID).Error
+		if err != nil {
+			return errors.Wrap(err, "CheckRunQuota failed to lock job")
+		}
+		var count int64
+		err = db.Model(&models.JobRun{}).Unscoped().
+			Where("job_spec_id = ? AND created_at > ?", job.ID, since).
+			Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "CheckRunQuota failed to count runs")
+		}
+		if count >= job.MaxRunsPerMinute.Int64 {
+			return quotaExceeded(QuotaExceededError{
+				Namespace: job.Namespace,
+				JobSpecID: job.ID,
+				Quota:     "maxRunsPerMinute",
+				Limit:     job.MaxRunsPerMinute.Int64,
+			})
+		}
+	}
+
+	ns, err := lockNamespaceQuota(db, job.Namespace, func(ns models.Namespace) clnull.Int64 { return ns.MaxRunsPerMinute })
+	if err != nil {
+		return errors.Wrap(err, "CheckRunQuota failed to find namespace")
+	}
+	if !ns.MaxRunsPerMinute.Valid {
+		return nil
+	}
+	var count int64
+	err = db.Model(&models.JobRun{}).Unscoped().
+		Joins("JOIN job_specs ON job_specs.id = job_runs.job_spec_id").
+		Where("job_specs.namespace = ? AND job_runs.created_at > ?", job.Namespace, since).
+		Count(&count).Error
+	if err != nil {
+		return errors.Wrap(err, "CheckRunQuota failed to count runs")
+	}
+	if count >= ns.MaxRunsPerMinute.Int64 {
+		return quotaExceeded(QuotaExceededError{Namespace: job.Namespace, Quota: "maxRunsPerMinute", Limit: ns.MaxRunsPerMinute.Int64})
+	}
+	return nil
+}
+
+// CheckGasQuota returns a QuotaExceededError if queueing a transaction with
+// the given gas limit from the address would take the namespace owning that
+// key past its gas per day. Addresses which are not the node's own keys have
+// no quota.
+//
+// This lets a task refuse a transaction up front; the quota is enforced by
+// CheckBroadcastGasQuota when the transaction is broadcast.
+func (orm *ORM) CheckGasQuota(fromAddress common.Address, gasLimit uint64, now time.Time) error {
+	orm.MustEnsureAdvisoryLock()
+	return checkGasQuota(orm.DB, fromAddress, gasLimit, now, `eth_txes.state <> 'fatal_error'`)
+}
+
+// CheckBroadcastGasQuota returns a QuotaExceededError if broadcasting the
+// unstarted transaction would take the namespace owning its key past its gas
+// per day, counting the transactions which have been broadcast. It must be
+// called in the transaction db which starts the transaction, as the
+// namespace is locked so that transactions broadcast concurrently from its
+// keys cannot together exceed its quota.
+func CheckBroadcastGasQuota(db *gorm.DB, etx models.EthTx, now time.Time) error {
+	return checkGasQuota(db, etx.FromAddress, etx.GasLimit, now, `eth_txes.state NOT IN ('unstarted', 'fatal_error')`)
+}
+
+func checkGasQuota(db *gorm.DB, fromAddress common.Address, gasLimit uint64, now time.Time, counted string) error {
+	var key models.Key
+	err := db.First(&key, "address = ?", fromAddress).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "CheckGasQuota failed to find key %s", fromAddress.Hex())
+	}
+	ns, err := lockNamespaceQuota(db, key.Namespace, func(ns models.Namespace) clnull.Int64 { return ns.MaxGasPerDay })
+	if err != nil {
+		return errors.Wrap(err, "CheckGasQuota failed to find namespace")
+	}
+	if !ns.MaxGasPerDay.Valid {
+		return nil
+	}
+
+	var used struct{ Gas int64 }
+	err = db.Raw(`
+		SELECT COALESCE(SUM(eth_txes.gas_limit), 0) AS gas FROM eth_txes
+		JOIN keys ON keys.address = eth_txes.from_address
+		WHERE keys.namespace = ? AND eth_txes.created_at > ? AND `+counted,
+		ns.Name, now.Add(-24*time.Hour),
+	).Scan(&used).Error
+	if err != nil {
+		return errors.Wrap(err, "CheckGasQuota failed to sum gas")
+	}
+	if used.Gas+int64(gasLimit) > ns.MaxGasPerDay.Int64 {
+		return quotaExceeded(QuotaExceededError{Namespace: ns.Name, Quota: "maxGasPerDay", Limit: ns.MaxGasPerDay.Int64})
+	}
+	return nil
+}
+
+// lockNamespaceQuota loads the namespace and, if it has the quota, locks it
+// until the end of the transaction db, so that the quota is checked and used
+// up by one transaction at a time. A namespace without the quota is not
+// locked, so that the jobs, runs and transactions of namespaces without
+// quotas are not created one at a time for nothing.
+func lockNamespaceQuota(db *gorm.DB, name string, quota func(models.Namespace) clnull.Int64) (models.Namespace, error) {
+	var ns models.Namespace
+	err := db.First(&ns, "name = ?", name).Error
+	if err != nil || !quota(ns).Valid {
+		return ns, err
+	}
+	// Loaded again under lock, as the quota may have changed meanwhile
+	var locked models.Namespace
+	err = db.Set("gorm:query_option", "FOR UPDATE").First(&locked, "name = ?", name).Error
+	return locked, err
+}
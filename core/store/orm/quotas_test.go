@@ -0,0 +1,193 @@
+package orm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestORM_CheckJobQuota(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	assert.NoError(t, store.CheckJobQuota(models.DefaultNamespace), "unset quotas should be unlimited")
+
+	require.NoError(t, store.UpdateNamespaceQuotas(models.DefaultNamespace, models.NamespaceQuotas{MaxJobs: clnull.Int64From(1)}))
+	assert.NoError(t, store.CheckJobQuota(models.DefaultNamespace))
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	err := store.CheckJobQuota(models.DefaultNamespace)
+	require.Error(t, err)
+	assert.True(t, orm.IsQuotaExceeded(err))
+
+	// Archived jobs do not count towards the quota
+	require.NoError(t, store.ArchiveJob(job.ID))
+	assert.NoError(t, store.CheckJobQuota(models.DefaultNamespace))
+}
+
+func TestORM_CheckRunQuota(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	otherJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&otherJob))
+
+	now := time.Now()
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+	assert.NoError(t, store.CheckRunQuota(&job, now))
+
+	job.MaxRunsPerMinute = clnull.Int64From(1)
+	err := store.CheckRunQuota(&job, now)
+	assert.True(t, orm.IsQuotaExceeded(err))
+	assert.NoError(t, store.CheckRunQuota(&otherJob, now), "job quotas should not affect other jobs")
+	assert.NoError(t, store.CheckRunQuota(&job, now.Add(time.Minute)), "runs older than a minute should not count")
+
+	require.NoError(t, store.UpdateNamespaceQuotas(models.DefaultNamespace, models.NamespaceQuotas{MaxRunsPerMinute: clnull.Int64From(1)}))
+	err = store.CheckRunQuota(&otherJob, now)
+	assert.True(t, orm.IsQuotaExceeded(err), "namespace quotas should be shared between its jobs")
+}
+
+func TestORM_CheckGasQuota(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertRandomKey(t, store)
+	etx := cltest.NewEthTx(t, store, key.Address.Address())
+	etx.GasLimit = 600000
+	etx.State = models.EthTxUnstarted
+	require.NoError(t, store.DB.Create(&etx).Error)
+
+	now := time.Now()
+	assert.NoError(t, store.CheckGasQuota(key.Address.Address(), 600000, now))
+
+	require.NoError(t, store.UpdateNamespaceQuotas(models.DefaultNamespace, models.NamespaceQuotas{MaxGasPerDay: clnull.Int64From(1000000)}))
+	assert.NoError(t, store.CheckGasQuota(key.Address.Address(), 400000, now))
+	err := store.CheckGasQuota(key.Address.Address(), 400001, now)
+	assert.True(t, orm.IsQuotaExceeded(err))
+	assert.NoError(t, store.CheckGasQuota(key.Address.Address(), 400001, now.Add(25*time.Hour)), "transactions older than a day should not count")
+	assert.NoError(t, store.CheckGasQuota(cltest.NewAddress(), 400001, now), "addresses which are not keys should have no quota")
+}
+
+func TestORM_CreateJob_Quota(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	require.NoError(t, store.UpdateNamespaceQuotas(models.DefaultNamespace, models.NamespaceQuotas{MaxJobs: clnull.Int64From(1)}))
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+
+	// Creating a job checks the quota itself, under lock
+	job = cltest.NewJobWithWebInitiator()
+	err := store.CreateJob(&job)
+	assert.True(t, orm.IsQuotaExceeded(err))
+
+	jobs := []models.JobSpec{cltest.NewJobWithWebInitiator()}
+	err = store.CreateJobs(jobs)
+	assert.True(t, orm.IsQuotaExceeded(err))
+}
+
+func TestORM_CreateJobRunWithinQuota(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.MaxRunsPerMinute = clnull.Int64From(1)
+	require.NoError(t, store.CreateJob(&job))
+
+	now := time.Now()
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRunWithinQuota(&job, &run, now))
+
+	run = cltest.NewJobRun(job)
+	err := store.CreateJobRunWithinQuota(&job, &run, now)
+	assert.True(t, orm.IsQuotaExceeded(err))
+	count, err := store.JobRunsCountFor(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestORM_CreateJobRunWithinQuota_LocksNamespaceWithQuota(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+
+	lockNamespace := func() *gorm.DB {
+		tx := store.DB.Begin()
+		require.NoError(t, tx.Exec(`SELECT * FROM namespaces WHERE name = ? FOR UPDATE`, models.DefaultNamespace).Error)
+		return tx
+	}
+	create := func() chan error {
+		done := make(chan error, 1)
+		go func() {
+			run := cltest.NewJobRun(job)
+			done <- store.CreateJobRunWithinQuota(&job, &run, time.Now())
+		}()
+		return done
+	}
+
+	// Runs of a namespace without a quota are not created one at a time
+	tx := lockNamespace()
+	select {
+	case err := <-create():
+		require.NoError(t, err)
+	case <-time.After(cltest.DBWaitTimeout):
+		t.Fatal("run creation waited for the namespace lock although the namespace has no quota")
+	}
+	require.NoError(t, tx.Rollback().Error)
+
+	require.NoError(t, store.UpdateNamespaceQuotas(models.DefaultNamespace, models.NamespaceQuotas{MaxRunsPerMinute: clnull.Int64From(10)}))
+	tx = lockNamespace()
+	done := create()
+	select {
+	case <-done:
+		t.Fatal("run creation did not wait for the namespace lock although the namespace has a quota")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, tx.Rollback().Error)
+	require.NoError(t, <-done)
+}
+
+func TestORM_CheckBroadcastGasQuota(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	key := cltest.MustInsertRandomKey(t, store)
+	require.NoError(t, store.UpdateNamespaceQuotas(models.DefaultNamespace, models.NamespaceQuotas{MaxGasPerDay: clnull.Int64From(1000000)}))
+
+	unstarted := cltest.NewEthTx(t, store, key.Address.Address())
+	unstarted.GasLimit = 600000
+	unstarted.State = models.EthTxUnstarted
+	require.NoError(t, store.DB.Create(&unstarted).Error)
+
+	now := time.Now()
+	next := models.EthTx{FromAddress: key.Address.Address(), GasLimit: 600000}
+	assert.NoError(t, orm.CheckBroadcastGasQuota(store.DB, next, now), "unstarted transactions should not count")
+
+	nonce := int64(0)
+	unstarted.State = models.EthTxInProgress
+	unstarted.Nonce = &nonce
+	require.NoError(t, store.DB.Save(&unstarted).Error)
+	err := orm.CheckBroadcastGasQuota(store.DB, next, now)
+	assert.True(t, orm.IsQuotaExceeded(err))
+}
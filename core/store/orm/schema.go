@@ -16,18 +16,31 @@ import (
 type ConfigSchema struct {
 	AllowOrigins                     string          `env:"ALLOW_ORIGINS" default:"http://localhost:3000,http://localhost:6688"`
 	BlockBackfillDepth               string          `env:"BLOCK_BACKFILL_DEPTH" default:"10"`
+	BridgeMaxRetryAfter              models.Duration `env:"BRIDGE_MAX_RETRY_AFTER" default:"10m"`
 	BridgeResponseURL                url.URL         `env:"BRIDGE_RESPONSE_URL"`
 	ChainID                          big.Int         `env:"ETH_CHAIN_ID" default:"1"`
+	ClientNamespace                  string          `env:"CLIENT_NAMESPACE"`
 	ClientNodeURL                    string          `env:"CLIENT_NODE_URL" default:"http://localhost:6688"`
 	DatabaseTimeout                  models.Duration `env:"DATABASE_TIMEOUT" default:"500ms"`
 	DatabaseURL                      string          `env:"DATABASE_URL"`
 	DefaultHTTPLimit                 int64           `env:"DEFAULT_HTTP_LIMIT" default:"32768"`
 	DefaultHTTPTimeout               models.Duration `env:"DEFAULT_HTTP_TIMEOUT" default:"15s"`
+	HTTPHostLimits                   string          `env:"HTTP_HOST_LIMITS"`
+	HTTPAdaptiveTimeoutMargin        models.Duration `env:"HTTP_ADAPTIVE_TIMEOUT_MARGIN" default:"0s"`
+	JSONDepthLimit                   int             `env:"JSON_DEPTH_LIMIT" default:"64"`
 	Dev                              bool            `env:"CHAINLINK_DEV" default:"false"`
 	EnableExperimentalAdapters       bool            `env:"ENABLE_EXPERIMENTAL_ADAPTERS" default:"false"`
 	EnableBulletproofTxManager       bool            `env:"ENABLE_BULLETPROOF_TX_MANAGER" default:"false"`
 	FeatureExternalInitiators        bool            `env:"FEATURE_EXTERNAL_INITIATORS" default:"false"`
 	FeatureFluxMonitor               bool            `env:"FEATURE_FLUX_MONITOR" default:"false"`
+	FleetPeers                       string          `env:"FLEET_PEERS"`
+	FleetSyncAccessKey               string          `env:"FLEET_SYNC_ACCESS_KEY"`
+	FleetSyncInterval                models.Duration `env:"FLEET_SYNC_INTERVAL" default:"5m"`
+	FleetSyncSecret                  string          `env:"FLEET_SYNC_SECRET"`
+	FleetSyncURL                     *url.URL        `env:"FLEET_SYNC_URL"`
+	FluxMonitorFetchCacheTTL         models.Duration `env:"FLUX_MONITOR_FETCH_CACHE_TTL" default:"0s"`
+	FluxMonitorSubmissionMargin      models.Duration `env:"FLUX_MONITOR_SUBMISSION_MARGIN" default:"15s"`
+	FundsSweepApprovalDelay          models.Duration `env:"FUNDS_SWEEP_APPROVAL_DELAY" default:"0s"`
 	MaximumServiceDuration           models.Duration `env:"MAXIMUM_SERVICE_DURATION" default:"8760h" `
 	MinimumServiceDuration           models.Duration `env:"MINIMUM_SERVICE_DURATION" default:"0s" `
 	EthGasBumpThreshold              uint64          `env:"ETH_GAS_BUMP_THRESHOLD" default:"3" `
@@ -49,10 +62,13 @@ type ConfigSchema struct {
 	GasUpdaterTransactionPercentile  uint16          `env:"GAS_UPDATER_TRANSACTION_PERCENTILE" default:"60"`
 	GasUpdaterEnabled                bool            `env:"GAS_UPDATER_ENABLED" default:"false"`
 	JSONConsole                      bool            `env:"JSON_CONSOLE" default:"false"`
+	KeeperRegistryPerformGasOverhead uint64          `env:"KEEPER_REGISTRY_PERFORM_GAS_OVERHEAD" default:"150000"`
 	LinkContractAddress              string          `env:"LINK_CONTRACT_ADDRESS" default:"0x514910771AF9Ca656af840dff83E8264EcF986CA"`
+	EventSinkURL                     *url.URL        `env:"EVENT_SINK_URL"`
 	ExplorerURL                      *url.URL        `env:"EXPLORER_URL"`
 	ExplorerAccessKey                string          `env:"EXPLORER_ACCESS_KEY"`
 	ExplorerSecret                   string          `env:"EXPLORER_SECRET"`
+	ExplorerMaxBacklog               uint64          `env:"EXPLORER_MAX_BACKLOG" default:"100000"`
 	LogLevel                         LogLevel        `env:"LOG_LEVEL" default:"info"`
 	LogToDisk                        bool            `env:"LOG_TO_DISK" default:"true"`
 	LogSQLStatements                 bool            `env:"LOG_SQL" default:"false"`
@@ -64,11 +80,15 @@ type ConfigSchema struct {
 	MinimumContractPayment           assets.Link     `env:"MINIMUM_CONTRACT_PAYMENT" default:"1000000000000000000"`
 	MinimumRequestExpiration         uint64          `env:"MINIMUM_REQUEST_EXPIRATION" default:"300"`
 	MaxRPCCallsPerSecond             uint64          `env:"MAX_RPC_CALLS_PER_SECOND" default:"500"`
+	MaxTaskResultSize                int64           `env:"MAX_TASK_RESULT_SIZE" default:"1048576"`
 	OperatorContractAddress          common.Address  `env:"OPERATOR_CONTRACT_ADDRESS"`
 	Port                             uint16          `env:"CHAINLINK_PORT" default:"6688"`
+	ProviderQuotaAlertPercent        uint64          `env:"PROVIDER_QUOTA_ALERT_PERCENT" default:"80"`
+	ProviderQuotas                   string          `env:"PROVIDER_QUOTAS"`
 	ReaperExpiration                 models.Duration `env:"REAPER_EXPIRATION" default:"240h"`
 	ReplayFromBlock                  int64           `env:"REPLAY_FROM_BLOCK" default:"-1"`
 	RootDir                          string          `env:"ROOT" default:"~/.chainlink"`
+	RunDataEncryptionKey             string          `env:"RUN_DATA_ENCRYPTION_KEY"`
 	SecureCookies                    bool            `env:"SECURE_COOKIES" default:"true"`
 	SessionTimeout                   models.Duration `env:"SESSION_TIMEOUT" default:"15m"`
 	TLSCertPath                      string          `env:"TLS_CERT_PATH" `
@@ -76,6 +96,8 @@ type ConfigSchema struct {
 	TLSKeyPath                       string          `env:"TLS_KEY_PATH" `
 	TLSPort                          uint16          `env:"CHAINLINK_TLS_PORT" default:"6689"`
 	TLSRedirect                      bool            `env:"CHAINLINK_TLS_REDIRECT" default:"false"`
+	TransactionAllowlistEnabled      bool            `env:"TRANSACTION_ALLOWLIST_ENABLED" default:"false"`
+	TreasuryAddress                  common.Address  `env:"TREASURY_ADDRESS"`
 	TxAttemptLimit                   uint16          `env:"CHAINLINK_TX_ATTEMPT_LIMIT" default:"10"`
 }
 
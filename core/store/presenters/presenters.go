@@ -132,12 +132,17 @@ type ConfigPrinter struct {
 type EnvPrinter struct {
 	AllowOrigins                     string          `json:"allowOrigins"`
 	BlockBackfillDepth               uint64          `json:"blockBackfillDepth"`
+	BridgeMaxRetryAfter              models.Duration `json:"bridgeMaxRetryAfter"`
 	BridgeResponseURL                string          `json:"bridgeResponseURL,omitempty"`
 	ChainID                          *big.Int        `json:"ethChainId"`
+	ClientNamespace                  string          `json:"clientNamespace"`
 	ClientNodeURL                    string          `json:"clientNodeUrl"`
 	DatabaseTimeout                  models.Duration `json:"databaseTimeout"`
 	DefaultHTTPLimit                 int64           `json:"defaultHttpLimit"`
 	DefaultHTTPTimeout               models.Duration `json:"defaultHttpTimeout"`
+	HTTPHostLimits                   string          `json:"httpHostLimits"`
+	HTTPAdaptiveTimeoutMargin        models.Duration `json:"httpAdaptiveTimeoutMargin"`
+	JSONDepthLimit                   int             `json:"jsonDepthLimit"`
 	Dev                              bool            `json:"chainlinkDev"`
 	EnableBulletproofTxManager       bool            `json:"enableBulletproofTxManager"`
 	EnableExperimentalAdapters       bool            `json:"enableExperimentalAdapters"`
@@ -153,20 +158,30 @@ type EnvPrinter struct {
 	EthHeadTrackerMaxBufferSize      uint            `json:"ethHeadTrackerMaxBufferSize"`
 	EthMaxGasPriceWei                *big.Int        `json:"ethMaxGasPriceWei"`
 	EthereumURL                      string          `json:"ethUrl"`
+	EventSinkURL                     string          `json:"eventSinkUrl"`
 	ExplorerURL                      string          `json:"explorerUrl"`
+	ExplorerMaxBacklog               uint64          `json:"explorerMaxBacklog"`
 	FeatureExternalInitiators        bool            `json:"featureExternalInitiators"`
 	FeatureFluxMonitor               bool            `json:"featureFluxMonitor"`
+	FleetPeers                       []string        `json:"fleetPeers"`
+	FleetSyncInterval                models.Duration `json:"fleetSyncInterval"`
+	FleetSyncURL                     string          `json:"fleetSyncUrl"`
+	FluxMonitorFetchCacheTTL         models.Duration `json:"fluxMonitorFetchCacheTTL"`
+	FluxMonitorSubmissionMargin      models.Duration `json:"fluxMonitorSubmissionMargin"`
+	FundsSweepApprovalDelay          models.Duration `json:"fundsSweepApprovalDelay"`
 	GasUpdaterBlockDelay             uint16          `json:"gasUpdaterBlockDelay"`
 	GasUpdaterBlockHistorySize       uint16          `json:"gasUpdaterBlockHistorySize"`
 	GasUpdaterEnabled                bool            `json:"gasUpdaterEnabled"`
 	GasUpdaterTransactionPercentile  uint16          `json:"gasUpdaterTransactionPercentile"`
 	JSONConsole                      bool            `json:"jsonConsole"`
+	KeeperRegistryPerformGasOverhead uint64          `json:"keeperRegistryPerformGasOverhead"`
 	LinkContractAddress              string          `json:"linkContractAddress"`
 	LogLevel                         orm.LogLevel    `json:"logLevel"`
 	LogSQLMigrations                 bool            `json:"logSqlMigrations"`
 	LogSQLStatements                 bool            `json:"logSqlStatements"`
 	LogToDisk                        bool            `json:"logToDisk"`
 	MaxRPCCallsPerSecond             uint64          `json:"maxRPCCallsPerSecond"`
+	MaxTaskResultSize                int64           `json:"maxTaskResultSize"`
 	MaximumServiceDuration           models.Duration `json:"maximumServiceDuration"`
 	MinIncomingConfirmations         uint32          `json:"minIncomingConfirmations"`
 	MinRequiredOutgoingConfirmations uint64          `json:"minOutgoingConfirmations"`
@@ -175,6 +190,8 @@ type EnvPrinter struct {
 	MinimumRequestExpiration         uint64          `json:"minimumRequestExpiration"`
 	OperatorContractAddress          common.Address  `json:"oracleContractAddress"`
 	Port                             uint16          `json:"chainlinkPort"`
+	ProviderQuotaAlertPercent        uint64          `json:"providerQuotaAlertPercent"`
+	ProviderQuotas                   string          `json:"providerQuotas"`
 	ReaperExpiration                 models.Duration `json:"reaperExpiration"`
 	ReplayFromBlock                  int64           `json:"replayFromBlock"`
 	RootDir                          string          `json:"root"`
@@ -183,6 +200,8 @@ type EnvPrinter struct {
 	TLSHost                          string          `json:"chainlinkTLSHost"`
 	TLSPort                          uint16          `json:"chainlinkTLSPort"`
 	TLSRedirect                      bool            `json:"chainlinkTLSRedirect"`
+	TransactionAllowlistEnabled      bool            `json:"transactionAllowlistEnabled"`
+	TreasuryAddress                  common.Address  `json:"treasuryAddress"`
 	TxAttemptLimit                   uint16          `json:"txAttemptLimit"`
 }
 
@@ -198,17 +217,34 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 	if config.ExplorerURL() != nil {
 		explorerURL = config.ExplorerURL().String()
 	}
+	eventSinkURL := ""
+	if config.EventSinkURL() != nil {
+		eventSinkURL = config.EventSinkURL().Redacted()
+	}
+	fleetSyncURL := ""
+	if config.FleetSyncURL() != nil {
+		fleetSyncURL = config.FleetSyncURL().Redacted()
+	}
+	fleetPeers := []string{}
+	for _, peer := range config.FleetPeers() {
+		fleetPeers = append(fleetPeers, peer.Redacted())
+	}
 	return ConfigPrinter{
 		AccountAddress: account.Address.Hex(),
 		EnvPrinter: EnvPrinter{
 			AllowOrigins:                     config.AllowOrigins(),
 			BlockBackfillDepth:               config.BlockBackfillDepth(),
+			BridgeMaxRetryAfter:              config.BridgeMaxRetryAfter(),
 			BridgeResponseURL:                config.BridgeResponseURL().String(),
 			ChainID:                          config.ChainID(),
+			ClientNamespace:                  config.ClientNamespace(),
 			ClientNodeURL:                    config.ClientNodeURL(),
 			DatabaseTimeout:                  config.DatabaseTimeout(),
 			DefaultHTTPLimit:                 config.DefaultHTTPLimit(),
 			DefaultHTTPTimeout:               config.DefaultHTTPTimeout(),
+			HTTPHostLimits:                   config.HTTPHostLimits().String(),
+			HTTPAdaptiveTimeoutMargin:        config.HTTPAdaptiveTimeoutMargin(),
+			JSONDepthLimit:                   config.JSONDepthLimit(),
 			Dev:                              config.Dev(),
 			EnableBulletproofTxManager:       config.EnableBulletproofTxManager(),
 			EnableExperimentalAdapters:       config.EnableExperimentalAdapters(),
@@ -224,20 +260,30 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 			EthHeadTrackerMaxBufferSize:      config.EthHeadTrackerMaxBufferSize(),
 			EthMaxGasPriceWei:                config.EthMaxGasPriceWei(),
 			EthereumURL:                      config.EthereumURL(),
+			EventSinkURL:                     eventSinkURL,
 			ExplorerURL:                      explorerURL,
+			ExplorerMaxBacklog:               config.ExplorerMaxBacklog(),
 			FeatureExternalInitiators:        config.FeatureExternalInitiators(),
 			FeatureFluxMonitor:               config.FeatureFluxMonitor(),
+			FleetPeers:                       fleetPeers,
+			FleetSyncInterval:                config.FleetSyncInterval(),
+			FleetSyncURL:                     fleetSyncURL,
+			FluxMonitorFetchCacheTTL:         config.FluxMonitorFetchCacheTTL(),
+			FluxMonitorSubmissionMargin:      config.FluxMonitorSubmissionMargin(),
+			FundsSweepApprovalDelay:          config.FundsSweepApprovalDelay(),
 			GasUpdaterBlockDelay:             config.GasUpdaterBlockDelay(),
 			GasUpdaterBlockHistorySize:       config.GasUpdaterBlockHistorySize(),
 			GasUpdaterEnabled:                config.GasUpdaterEnabled(),
 			GasUpdaterTransactionPercentile:  config.GasUpdaterTransactionPercentile(),
 			JSONConsole:                      config.JSONConsole(),
+			KeeperRegistryPerformGasOverhead: config.KeeperRegistryPerformGasOverhead(),
 			LinkContractAddress:              config.LinkContractAddress(),
 			LogLevel:                         config.LogLevel(),
 			LogSQLMigrations:                 config.LogSQLMigrations(),
 			LogSQLStatements:                 config.LogSQLStatements(),
 			LogToDisk:                        config.LogToDisk(),
 			MaxRPCCallsPerSecond:             config.MaxRPCCallsPerSecond(),
+			MaxTaskResultSize:                config.MaxTaskResultSize(),
 			MaximumServiceDuration:           config.MaximumServiceDuration(),
 			MinIncomingConfirmations:         config.MinIncomingConfirmations(),
 			MinRequiredOutgoingConfirmations: config.MinRequiredOutgoingConfirmations(),
@@ -246,6 +292,8 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 			MinimumRequestExpiration:         config.MinimumRequestExpiration(),
 			OperatorContractAddress:          config.OperatorContractAddress(),
 			Port:                             config.Port(),
+			ProviderQuotaAlertPercent:        config.ProviderQuotaAlertPercent(),
+			ProviderQuotas:                   config.ProviderQuotas().String(),
 			ReaperExpiration:                 config.ReaperExpiration(),
 			ReplayFromBlock:                  config.ReplayFromBlock(),
 			RootDir:                          config.RootDir(),
@@ -254,6 +302,8 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 			TLSHost:                          config.TLSHost(),
 			TLSPort:                          config.TLSPort(),
 			TLSRedirect:                      config.TLSRedirect(),
+			TransactionAllowlistEnabled:      config.TransactionAllowlistEnabled(),
+			TreasuryAddress:                  config.TreasuryAddress(),
 			TxAttemptLimit:                   config.TxAttemptLimit(),
 		},
 	}, nil
@@ -314,6 +364,19 @@ type JobSpec struct {
 	models.JobSpec
 	Errors   []models.JobSpecError `json:"errors"`
 	Earnings *assets.Link          `json:"earnings"`
+	// WebhookSecrets are the secrets of the job's webhook initiators, in
+	// order, which are only returned once, when the job is created.
+	WebhookSecrets []string `json:"webhookSecrets,omitempty"`
+}
+
+// NewCreatedJobSpec returns the presentation of a job which has just been
+// created, along with the secrets of its webhook initiators.
+func NewCreatedJobSpec(job models.JobSpec) JobSpec {
+	presented := JobSpec{JobSpec: job}
+	for _, secret := range models.WebhookSecretsOf(job) {
+		presented.WebhookSecrets = append(presented.WebhookSecrets, secret.Secret)
+	}
+	return presented
 }
 
 // MarshalJSON returns the JSON data of the Job and its Initiators.
@@ -404,12 +467,13 @@ func (i Initiator) MarshalJSON() ([]byte, error) {
 
 func initiatorParams(i Initiator) (interface{}, error) {
 	switch i.Type {
-	case models.InitiatorWeb:
+	case models.InitiatorWeb, models.InitiatorWebhook:
 		return struct{}{}, nil
 	case models.InitiatorCron:
 		return struct {
-			Schedule models.Cron `json:"schedule"`
-		}{i.Schedule}, nil
+			Schedule        models.Cron `json:"schedule"`
+			SkipOverlapping bool        `json:"skipOverlapping,omitempty"`
+		}{i.Schedule, i.SkipOverlapping}, nil
 	case models.InitiatorRunAt:
 		return struct {
 			Time models.AnyTime `json:"time"`
@@ -435,10 +499,16 @@ func initiatorParams(i Initiator) (interface{}, error) {
 			Precision         int32                  `json:"precision"`
 			PollTimer         models.PollTimerConfig `json:"pollTimer,omitempty"`
 			IdleTimer         models.IdleTimerConfig `json:"idleTimer,omitempty"`
+			MinAnswers        int32                  `json:"minAnswers,omitempty"`
 		}{i.Address, i.RequestData, i.Feeds, i.Threshold, i.AbsoluteThreshold,
-			i.Precision, i.PollTimer, i.IdleTimer}, nil
+			i.Precision, i.PollTimer, i.IdleTimer, i.MinAnswers}, nil
 	case models.InitiatorRandomnessLog:
 		return struct{ Address common.Address }{i.Address}, nil
+	case models.InitiatorKeeper:
+		return struct {
+			Address           common.Address `json:"address"`
+			BlockCountPerTurn int32          `json:"blockCountPerTurn"`
+		}{i.Address, i.BlockCountPerTurn}, nil
 	default:
 		return nil, fmt.Errorf("cannot marshal unsupported initiator type '%v'", i.Type)
 	}
@@ -612,6 +682,56 @@ func (a NewAccount) GetName() string {
 	return "keys"
 }
 
+// NodeIdentity is a jsonapi wrapper for the node's public identities, signed
+// by the node's first account so that counterparties can check which node
+// they are talking to. SignedPayload is the exact message signed, so that it
+// can be verified without re-encoding the identities.
+type NodeIdentity struct {
+	Identity
+	SignedPayload string           `json:"signedPayload"`
+	Signer        common.Address   `json:"signer"`
+	Signature     models.Signature `json:"signature"`
+}
+
+// Identity lists the public keys held by the node. Its JSON encoding is the
+// message signed in a NodeIdentity.
+type Identity struct {
+	AccountAddresses   []common.Address `json:"accountAddresses"`
+	OCRKeyBundles      []OCRKeyIdentity `json:"ocrKeyBundles"`
+	P2PKeys            []P2PKeyIdentity `json:"p2pKeys"`
+	TLSCertFingerprint string           `json:"tlsCertFingerprint,omitempty"`
+}
+
+// OCRKeyIdentity holds the public parts of an OCR key bundle.
+type OCRKeyIdentity struct {
+	ID                    string         `json:"id"`
+	OnChainSigningAddress common.Address `json:"onChainSigningAddress"`
+	OffChainPublicKey     hexutil.Bytes  `json:"offChainPublicKey"`
+}
+
+// P2PKeyIdentity holds the public parts of a P2P key.
+type P2PKeyIdentity struct {
+	PeerID    string        `json:"peerId"`
+	PublicKey hexutil.Bytes `json:"publicKey"`
+}
+
+// GetID returns the jsonapi ID.
+func (i NodeIdentity) GetID() string {
+	return i.Signer.Hex()
+}
+
+// GetName returns the collection name for jsonapi.
+func (i NodeIdentity) GetName() string {
+	return "identities"
+}
+
+// SetID is used to conform to the UnmarshallIdentifier interface for
+// deserializing from jsonapi documents.
+func (i *NodeIdentity) SetID(value string) error {
+	i.Signer = common.HexToAddress(value)
+	return nil
+}
+
 // Tx is a jsonapi wrapper for an Ethereum Transaction.
 type Tx struct {
 	Confirmed bool            `json:"confirmed,omitempty"`
@@ -683,14 +803,159 @@ func (t *Tx) SetID(hex string) error {
 	return nil
 }
 
+// JobPreview holds the outcome of performing each task of a job spec once,
+// without saving the job or its run.
+type JobPreview struct {
+	ID           *models.ID           `json:"-"`
+	Status       models.RunStatus     `json:"status"`
+	Tasks        []TaskPreview        `json:"tasks"`
+	HTTPFixtures []models.HTTPFixture `json:"httpFixtures,omitempty"`
+	Estimate     JobCostEstimate      `json:"estimate"`
+}
+
+// JobCostEstimate is what running a job is expected to cost, worked out from
+// its spec and the time its tasks took to preview. Runs per day only count
+// the runs of cron and flux monitor initiators, as the others run when an
+// event occurs.
+type JobCostEstimate struct {
+	RPCCallsPerRun      int             `json:"rpcCallsPerRun"`
+	GasPerRun           uint64          `json:"gasPerRun"`
+	GasPrice            *utils.Big      `json:"gasPrice"`
+	WeiPerRun           *utils.Big      `json:"weiPerRun"`
+	RunDuration         models.Duration `json:"runDuration"`
+	ExternalCallsPerRun int             `json:"externalCallsPerRun"`
+	RunsPerDay          int             `json:"runsPerDay"`
+	ExternalCallsPerDay int             `json:"externalCallsPerDay"`
+}
+
+// TaskPreview is the outcome of a single task in a JobPreview. Tasks which
+// would send a transaction are skipped, as are the tasks following a task
+// which errored or did not complete synchronously.
+type TaskPreview struct {
+	Type    models.TaskType  `json:"type"`
+	Status  models.RunStatus `json:"status,omitempty"`
+	Result  models.JSON      `json:"result"`
+	Error   string           `json:"error,omitempty"`
+	Skipped bool             `json:"skipped,omitempty"`
+}
+
+// GetID returns the jsonapi ID.
+func (p JobPreview) GetID() string {
+	return p.ID.String()
+}
+
+// GetName returns the collection name for jsonapi.
+func (JobPreview) GetName() string {
+	return "previews"
+}
+
+// SetID is used to conform to the UnmarshallIdentifier interface for
+// deserializing from jsonapi documents.
+func (p *JobPreview) SetID(value string) error {
+	id, err := models.NewIDFromString(value)
+	if err != nil {
+		return err
+	}
+	p.ID = id
+	return nil
+}
+
+// JobSpecLint holds the problems found in a job spec which was checked
+// without being created, along with the graph of its initiators and tasks.
+// Warnings do not make the spec invalid.
+type JobSpecLint struct {
+	ID       *models.ID `json:"-"`
+	Valid    bool       `json:"valid"`
+	Problems []string   `json:"problems"`
+	Warnings []string   `json:"warnings"`
+	Graph    TaskGraph  `json:"graph"`
+	DOT      string     `json:"dot"`
+}
+
+// GetID returns the jsonapi ID.
+func (l JobSpecLint) GetID() string {
+	return l.ID.String()
+}
+
+// GetName returns the collection name for jsonapi.
+func (JobSpecLint) GetName() string {
+	return "lints"
+}
+
+// SetID is used to conform to the UnmarshallIdentifier interface for
+// deserializing from jsonapi documents.
+func (l *JobSpecLint) SetID(value string) error {
+	id, err := models.NewIDFromString(value)
+	if err != nil {
+		return err
+	}
+	l.ID = id
+	return nil
+}
+
+// TaskGraph is the graph of a job spec, in which runs flow from each
+// initiator into the first task and from each task into the next, and the
+// results of named tasks flow into the tasks referring to them.
+type TaskGraph struct {
+	Nodes []TaskGraphNode `json:"nodes"`
+	Edges []TaskGraphEdge `json:"edges"`
+}
+
+// TaskGraphNode is an initiator or task of a TaskGraph.
+type TaskGraphNode struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+// TaskGraphEdge connects two nodes of a TaskGraph. Its kind is "input" for
+// the input of a task, or "variable" for a $(name.result) reference.
+type TaskGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// RegistrationTx is an unsigned registration transaction, along with the
+// transaction that was created for it when the node was asked to send it.
+type RegistrationTx struct {
+	ID      string          `json:"-"`
+	Method  string          `json:"method"`
+	From    *common.Address `json:"from,omitempty"`
+	To      common.Address  `json:"to"`
+	Data    hexutil.Bytes   `json:"data"`
+	EthTxID int64           `json:"ethTxId,omitempty"`
+	TxHash  *common.Hash    `json:"txHash,omitempty"`
+}
+
+// GetID returns the jsonapi ID.
+func (r RegistrationTx) GetID() string {
+	return r.ID
+}
+
+// GetName returns the collection name for jsonapi.
+func (RegistrationTx) GetName() string {
+	return "registrations"
+}
+
+// SetID is used to conform to the UnmarshallIdentifier interface for
+// deserializing from jsonapi documents.
+func (r *RegistrationTx) SetID(value string) error {
+	r.ID = value
+	return nil
+}
+
 // ExternalInitiatorAuthentication includes initiator and authentication details.
 type ExternalInitiatorAuthentication struct {
-	Name           string        `json:"name,omitempty"`
-	URL            models.WebURL `json:"url,omitempty"`
-	AccessKey      string        `json:"incomingAccessKey,omitempty"`
-	Secret         string        `json:"incomingSecret,omitempty"`
-	OutgoingToken  string        `json:"outgoingToken,omitempty"`
-	OutgoingSecret string        `json:"outgoingSecret,omitempty"`
+	Name               string        `json:"name,omitempty"`
+	URL                models.WebURL `json:"url,omitempty"`
+	AccessKey          string        `json:"incomingAccessKey,omitempty"`
+	Secret             string        `json:"incomingSecret,omitempty"`
+	OutgoingToken      string        `json:"outgoingToken,omitempty"`
+	OutgoingSecret     string        `json:"outgoingSecret,omitempty"`
+	ProtocolVersion    int32         `json:"protocolVersion,omitempty"`
+	IncomingSigningKey string        `json:"incomingSigningKey,omitempty"`
 }
 
 // NewExternalInitiatorAuthentication creates an instance of ExternalInitiatorAuthentication.
@@ -699,11 +964,15 @@ func NewExternalInitiatorAuthentication(
 	eia auth.Token,
 ) *ExternalInitiatorAuthentication {
 	var result = &ExternalInitiatorAuthentication{
-		Name:           ei.Name,
-		AccessKey:      ei.AccessKey,
-		Secret:         eia.Secret,
-		OutgoingToken:  ei.OutgoingToken,
-		OutgoingSecret: ei.OutgoingSecret,
+		Name:            ei.Name,
+		AccessKey:       ei.AccessKey,
+		Secret:          eia.Secret,
+		OutgoingToken:   ei.OutgoingToken,
+		OutgoingSecret:  ei.OutgoingSecret,
+		ProtocolVersion: ei.ProtocolVersion,
+	}
+	if ei.ProtocolVersion >= models.ExternalInitiatorProtocolV2 {
+		result.IncomingSigningKey = ei.IncomingSigningKey
 	}
 	if ei.URL != nil {
 		result.URL = *ei.URL
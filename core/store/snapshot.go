@@ -0,0 +1,231 @@
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/vrfkey"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// SnapshotVersion is the format version of snapshots written by this node.
+const SnapshotVersion = 1
+
+// Snapshot holds everything needed to rebuild a node on an empty database:
+// its jobs, job spec templates, bridges, external initiators, config
+// overrides and keys. Keys remain encrypted with their own passwords, and the
+// snapshot as a whole is encrypted again by EncryptSnapshot.
+type Snapshot struct {
+	Version            int                         `json:"version"`
+	CreatedAt          time.Time                   `json:"createdAt"`
+	Namespaces         []models.Namespace          `json:"namespaces"`
+	Jobs               []SnapshotJob               `json:"jobs"`
+	JobSpecTemplates   []models.JobSpecTemplate    `json:"jobSpecTemplates"`
+	Bridges            []SnapshotBridge            `json:"bridges"`
+	ExternalInitiators []models.ExternalInitiator  `json:"externalInitiators"`
+	Configurations     []models.Configuration      `json:"configurations"`
+	Keys               []models.Key                `json:"keys"`
+	VRFKeys            []*vrfkey.EncryptedVRFKey   `json:"vrfKeys"`
+	OCRKeyBundles      []ocrkey.EncryptedKeyBundle `json:"ocrKeyBundles"`
+	P2PKeys            []p2pkey.EncryptedP2PKey    `json:"p2pKeys"`
+}
+
+// SnapshotBridge is a bridge along with the incoming token hash which is
+// omitted from its usual JSON representation.
+type SnapshotBridge struct {
+	models.BridgeType
+	IncomingTokenHash string `json:"incomingTokenHash"`
+	Salt              string `json:"salt"`
+}
+
+// SnapshotJob is a job along with the secrets of its webhook initiators,
+// which are omitted from its usual JSON representation.
+type SnapshotJob struct {
+	models.JobSpec
+	WebhookSecrets []models.InitiatorWebhookSecret `json:"webhookSecrets,omitempty"`
+}
+
+// Snapshot returns the node's current namespaces, jobs, job spec templates,
+// bridges, external initiators, config overrides and keys. Archived jobs are
+// not included.
+func (s *Store) Snapshot() (*Snapshot, error) {
+	snapshot := Snapshot{Version: SnapshotVersion, CreatedAt: time.Now()}
+
+	var err error
+	if snapshot.Namespaces, err = s.Namespaces(); err != nil {
+		return nil, errors.Wrap(err, "while loading namespaces")
+	}
+
+	var jobs []models.JobSpec
+	err = s.DB.Preload("Initiators").Preload("Tasks", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Order("id asc")
+	}).Order("created_at asc").Find(&jobs).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading jobs")
+	}
+	for _, job := range jobs {
+		snapshot.Jobs = append(snapshot.Jobs, SnapshotJob{
+			JobSpec:        job,
+			WebhookSecrets: models.WebhookSecretsOf(job),
+		})
+	}
+	if err = s.DB.Order("namespace asc, name asc").Find(&snapshot.JobSpecTemplates).Error; err != nil {
+		return nil, errors.Wrap(err, "while loading job spec templates")
+	}
+
+	var bridges []models.BridgeType
+	if err = s.DB.Order("name asc").Find(&bridges).Error; err != nil {
+		return nil, errors.Wrap(err, "while loading bridges")
+	}
+	for _, bt := range bridges {
+		snapshot.Bridges = append(snapshot.Bridges, SnapshotBridge{
+			BridgeType:        bt,
+			IncomingTokenHash: bt.IncomingTokenHash,
+			Salt:              bt.Salt,
+		})
+	}
+
+	if err = s.DB.Order("id asc").Find(&snapshot.ExternalInitiators).Error; err != nil {
+		return nil, errors.Wrap(err, "while loading external initiators")
+	}
+	if err = s.DB.Order("id asc").Find(&snapshot.Configurations).Error; err != nil {
+		return nil, errors.Wrap(err, "while loading config overrides")
+	}
+	if snapshot.Keys, err = s.AllKeys(); err != nil {
+		return nil, errors.Wrap(err, "while loading keys")
+	}
+	if snapshot.VRFKeys, err = s.FindEncryptedSecretVRFKeys(); err != nil {
+		return nil, errors.Wrap(err, "while loading VRF keys")
+	}
+	if snapshot.OCRKeyBundles, err = s.FindEncryptedOCRKeyBundles(); err != nil {
+		return nil, errors.Wrap(err, "while loading OCR key bundles")
+	}
+	if snapshot.P2PKeys, err = s.FindEncryptedP2PKeys(); err != nil {
+		return nil, errors.Wrap(err, "while loading P2P keys")
+	}
+	return &snapshot, nil
+}
+
+// Restore inserts the contents of snapshot in a single transaction, failing
+// if any of them already exist. It is meant to be run against the empty
+// database of a standby node before it is started.
+func (s *Store) Restore(snapshot *Snapshot) error {
+	if snapshot.Version != SnapshotVersion {
+		return errors.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+
+	err := s.Transaction(func(tx *gorm.DB) error {
+		for _, ns := range snapshot.Namespaces {
+			if ns.Name == models.DefaultNamespace {
+				continue
+			}
+			if err := tx.Create(&ns).Error; err != nil {
+				return errors.Wrapf(err, "while restoring namespace %s", ns.Name)
+			}
+		}
+		for _, k := range snapshot.Keys {
+			k.ID = 0
+			if err := tx.Create(&k).Error; err != nil {
+				return errors.Wrapf(err, "while restoring key %s", k.Address)
+			}
+		}
+		for _, k := range snapshot.VRFKeys {
+			if err := tx.Create(k).Error; err != nil {
+				return errors.Wrapf(err, "while restoring VRF key %s", k.PublicKey)
+			}
+		}
+		for _, k := range snapshot.OCRKeyBundles {
+			if err := tx.Create(&k).Error; err != nil {
+				return errors.Wrapf(err, "while restoring OCR key bundle %s", k.ID)
+			}
+		}
+		for _, k := range snapshot.P2PKeys {
+			k.ID = 0
+			if err := tx.Create(&k).Error; err != nil {
+				return errors.Wrapf(err, "while restoring P2P key %s", k.PeerID)
+			}
+		}
+		for _, c := range snapshot.Configurations {
+			c.ID = 0
+			if err := tx.Create(&c).Error; err != nil {
+				return errors.Wrapf(err, "while restoring config override %s", c.Name)
+			}
+		}
+		for _, b := range snapshot.Bridges {
+			bt := b.BridgeType
+			bt.IncomingTokenHash = b.IncomingTokenHash
+			bt.Salt = b.Salt
+			if err := tx.Create(&bt).Error; err != nil {
+				return errors.Wrapf(err, "while restoring bridge %s", bt.Name)
+			}
+		}
+		for _, ei := range snapshot.ExternalInitiators {
+			ei.ID = 0
+			if err := tx.Create(&ei).Error; err != nil {
+				return errors.Wrapf(err, "while restoring external initiator %s", ei.Name)
+			}
+		}
+		for _, j := range snapshot.Jobs {
+			job := j.JobSpec
+			models.ApplyWebhookSecrets(&job, j.WebhookSecrets)
+			for i := range job.Initiators {
+				job.Initiators[i].ID = 0
+				job.Initiators[i].JobSpecID = job.ID
+			}
+			for i := range job.Tasks {
+				job.Tasks[i].ID = 0
+				job.Tasks[i].JobSpecID = job.ID
+			}
+			if err := tx.Create(&job).Error; err != nil {
+				return errors.Wrapf(err, "while restoring job %s", job.ID)
+			}
+		}
+		for _, t := range snapshot.JobSpecTemplates {
+			if err := tx.Create(&t).Error; err != nil {
+				return errors.Wrapf(err, "while restoring job spec template %s", t.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.ClobberDiskKeyStoreWithDBKeys(s.Config.KeysDir())
+}
+
+// EncryptSnapshot serializes snapshot and encrypts it with password, using
+// the same scrypt and AES-128-CTR scheme as the node's key files.
+func EncryptSnapshot(snapshot *Snapshot, password string) ([]byte, error) {
+	plaintext, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	cryptoJSON, err := keystore.EncryptDataV3(plaintext, []byte(password), keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, errors.Wrap(err, "while encrypting snapshot")
+	}
+	return json.Marshal(cryptoJSON)
+}
+
+// DecryptSnapshot reverses EncryptSnapshot.
+func DecryptSnapshot(bundle []byte, password string) (*Snapshot, error) {
+	var cryptoJSON keystore.CryptoJSON
+	if err := json.Unmarshal(bundle, &cryptoJSON); err != nil {
+		return nil, errors.Wrap(err, "snapshot is not a valid bundle")
+	}
+	plaintext, err := keystore.DecryptDataV3(cryptoJSON, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "while decrypting snapshot")
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(plaintext, &snapshot); err != nil {
+		return nil, errors.Wrap(err, "while parsing snapshot")
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,93 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_SnapshotAndRestore(t *testing.T) {
+	t.Parallel()
+
+	primary, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, primary.CreateJob(&job))
+	externalJobID := uuid.NewV4()
+	webhookJob := cltest.NewJob()
+	webhookJob.ExternalJobID = &externalJobID
+	webhookJob.Initiators = []models.Initiator{{
+		Type:            models.InitiatorWebhook,
+		InitiatorParams: models.InitiatorParams{WebhookSecret: "0123456789abcdef"},
+	}}
+	require.NoError(t, primary.CreateJob(&webhookJob))
+	bta, bt := cltest.NewBridgeType(t, "snapshotbridge")
+	require.NoError(t, primary.CreateBridgeType(bt))
+	eia := auth.NewToken()
+	ei, err := models.NewExternalInitiator(eia, &models.ExternalInitiatorRequest{Name: "snapshotei"})
+	require.NoError(t, err)
+	require.NoError(t, primary.CreateExternalInitiator(ei))
+	require.NoError(t, primary.DB.Create(&models.Configuration{Name: "ETH_GAS_BUMP_WEI", Value: "1000"}).Error)
+	key := cltest.MustInsertRandomKey(t, primary)
+
+	snapshot, err := primary.Snapshot()
+	require.NoError(t, err)
+	bundle, err := strpkg.EncryptSnapshot(snapshot, "p4ssword")
+	require.NoError(t, err)
+
+	_, err = strpkg.DecryptSnapshot(bundle, "wrong")
+	assert.Error(t, err)
+	restored, err := strpkg.DecryptSnapshot(bundle, "p4ssword")
+	require.NoError(t, err)
+
+	standby, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	require.NoError(t, standby.Restore(restored))
+
+	restoredJob, err := standby.FindJob(job.ID)
+	require.NoError(t, err)
+	require.Len(t, restoredJob.Initiators, 1)
+	assert.Equal(t, models.InitiatorWeb, restoredJob.Initiators[0].Type)
+	assert.Equal(t, len(job.Tasks), len(restoredJob.Tasks))
+
+	// Webhook secrets are not part of the job's spec, but are restored
+	restoredWebhookJob, err := standby.FindJob(webhookJob.ID)
+	require.NoError(t, err)
+	require.Len(t, restoredWebhookJob.Initiators, 1)
+	assert.Equal(t, "0123456789abcdef", restoredWebhookJob.Initiators[0].WebhookSecret)
+
+	restoredBridge, err := standby.FindBridge(bt.Name)
+	require.NoError(t, err)
+	ok, err := models.AuthenticateBridgeType(&restoredBridge, bta.IncomingToken)
+	require.NoError(t, err)
+	assert.True(t, ok, "restored bridge should accept its original token")
+
+	restoredEI, err := standby.FindExternalInitiator(eia)
+	require.NoError(t, err)
+	ok, err = models.AuthenticateExternalInitiator(eia, restoredEI)
+	require.NoError(t, err)
+	assert.True(t, ok, "restored external initiator should accept its original credentials")
+
+	keys, err := standby.AllKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.Address, keys[0].Address)
+
+	var config models.Configuration
+	require.NoError(t, standby.DB.First(&config, "name = ?", "ETH_GAS_BUMP_WEI").Error)
+	assert.Equal(t, "1000", config.Value)
+
+	// Restoring twice fails without changing anything
+	assert.Error(t, standby.Restore(restored))
+	count, err := standby.CountOf(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
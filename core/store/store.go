@@ -38,6 +38,7 @@ type Store struct {
 	Clock          utils.AfterNower
 	KeyStore       KeyStoreInterface
 	VRFKeyStore    *VRFKeyStore
+	OCRKeyStore    *OCRKeyStore
 	TxManager      TxManager
 	EthClient      eth.Client
 	NotifyNewEthTx NotifyNewEthTx
@@ -90,6 +91,7 @@ func newStoreWithKeyStore(
 		closeOnce: &sync.Once{},
 	}
 	store.VRFKeyStore = NewVRFKeyStore(store)
+	store.OCRKeyStore = NewOCRKeyStore(store)
 	return store
 }
 
@@ -178,5 +180,12 @@ func initializeORM(config *orm.Config, shutdownSignal gracefulpanic.Signal) (*or
 		}
 	}
 	orm.SetLogging(config.LogSQLStatements())
+	if key := config.RunDataEncryptionKey(); key != "" {
+		c, err := models.NewRunDataCipherFromHex(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "initializeORM#NewRunDataCipher")
+		}
+		orm.SetRunDataCipher(c)
+	}
 	return orm, nil
 }
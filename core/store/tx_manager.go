@@ -40,6 +40,12 @@ const (
 var (
 	// ErrPendingConnection is the error returned if TxManager is not connected.
 	ErrPendingConnection = errors.New("Cannot talk to chain, pending connection")
+	// ErrTransmissionsHalted is the error returned when a transaction is
+	// created while transmissions are halted node-wide.
+	ErrTransmissionsHalted = errors.New("transmissions are halted, no new transactions can be sent")
+	// ErrTransactionNotAllowed is the error returned when a transaction is
+	// sent to a contract which is not on the transaction allowlist.
+	ErrTransactionNotAllowed = errors.New("contract is not on the transaction allowlist")
 
 	promNumGasBumps = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "tx_manager_num_gas_bumps",
@@ -258,6 +264,28 @@ func normalizeGasParams(gasPriceWei *big.Int, gasLimit uint64, config orm.Config
 	return config.EthGasPriceDefault(), config.EthGasLimitDefault()
 }
 
+// CheckTransactionAllowed returns ErrTransactionNotAllowed, alerting the
+// operator, if the transaction allowlist is enabled and the node may not
+// transact with the contract at to from the key at from. Besides the
+// contracts allowed by the ORM, funds may always be swept to the treasury.
+func CheckTransactionAllowed(db *orm.ORM, config orm.ConfigReader, from, to common.Address) error {
+	if !config.TransactionAllowlistEnabled() {
+		return nil
+	}
+	treasury := config.TreasuryAddress()
+	if treasury != (common.Address{}) && to == treasury {
+		return nil
+	}
+	allowed, err := db.TransactionAllowed(from, to)
+	if err != nil {
+		return errors.Wrap(err, "while checking the transaction allowlist")
+	} else if !allowed {
+		logger.Errorw("Refused a transaction to a contract which is not on the transaction allowlist", "from", from.Hex(), "to", to.Hex())
+		return errors.Wrap(ErrTransactionNotAllowed, to.Hex())
+	}
+	return nil
+}
+
 // createTx creates an ethereum transaction, and retries to submit the
 // transaction if a nonce too low error is returned
 func (txm *EthTxManager) createTx(
@@ -269,6 +297,15 @@ func (txm *EthTxManager) createTx(
 	gasLimit uint64,
 	value *assets.Eth) (*models.Tx, error) {
 
+	if halted, err := txm.orm.TransmissionsHalted(); err != nil {
+		return nil, errors.Wrap(err, "createTx failed")
+	} else if halted {
+		return nil, ErrTransmissionsHalted
+	}
+	if err := CheckTransactionAllowed(txm.orm, txm.config, ma.Address, to); err != nil {
+		return nil, err
+	}
+
 	for nrc := 0; nrc < nonceReloadLimit+1; nrc++ {
 		tx, err := txm.sendInitialTx(surrogateID, ma, to, data, gasPriceWei, gasLimit, value)
 		if err == nil {
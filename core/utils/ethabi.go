@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 )
 
@@ -147,7 +148,13 @@ func parseJSONAsEVMWord(value gjson.Result) (*big.Int, error) {
 		}
 
 	case gjson.Number:
-		output.SetInt64(int64(value.Num))
+		// Read the number from its text, as large integers lose precision
+		// as a float64
+		d, err := decimal.NewFromString(value.Raw)
+		if err != nil {
+			d = decimal.NewFromFloat(value.Num)
+		}
+		output = d.Truncate(0).BigInt()
 
 	case gjson.Null:
 
@@ -182,6 +189,38 @@ func EVMTranscodeInt256(value gjson.Result) ([]byte, error) {
 	return EVMWordSignedBigInt(output)
 }
 
+// EVMTranscodeBytes32 converts a json input to a single EVM word. Hex strings
+// are left padded, as by common.HexToHash, and other strings right padded, as
+// by the ethbytes32 adapter. Numbers are encoded as int256 and booleans as
+// bool. Values which do not fit in a word are an error.
+func EVMTranscodeBytes32(value gjson.Result) ([]byte, error) {
+	switch value.Type {
+	case gjson.String:
+		if HasHexPrefix(value.Str) {
+			b, err := hex.DecodeString(RemoveHexPrefix(value.Str))
+			if err != nil {
+				return nil, err
+			}
+			if len(b) > EVMWordByteLen {
+				return nil, fmt.Errorf("%s is longer than 32 bytes", value.Str)
+			}
+			return common.LeftPadBytes(b, EVMWordByteLen), nil
+		}
+		if len(value.Str) > EVMWordByteLen {
+			return nil, fmt.Errorf("%q is longer than 32 bytes", value.Str)
+		}
+		return common.RightPadBytes([]byte(value.Str), EVMWordByteLen), nil
+	case gjson.Number:
+		return EVMTranscodeInt256(value)
+	case gjson.True, gjson.False:
+		return EVMTranscodeBool(value)
+	case gjson.Null:
+		return make([]byte, EVMWordByteLen), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding for value: %s", value.Type)
+	}
+}
+
 // EVMTranscodeJSONWithFormat given a JSON input and a format specifier, encode the
 // value for use by the EVM
 func EVMTranscodeJSONWithFormat(value gjson.Result, format string) ([]byte, error) {
@@ -3,6 +3,7 @@ package utils
 import (
 	"math"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -516,6 +517,34 @@ func TestEVMTranscodeJSONWithFormat(t *testing.T) {
 	}
 }
 
+func TestEVMTranscodeBytes32(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		output string
+	}{
+		{"hex", `"0x1234"`, "0x0000000000000000000000000000000000000000000000000000000000001234"},
+		{"text", `"ETH-USD"`, "0x4554482d55534400000000000000000000000000000000000000000000000000"},
+		{"number", `-1`, "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{"bool", `true`, "0x0000000000000000000000000000000000000000000000000000000000000001"},
+		{"null", `null`, "0x0000000000000000000000000000000000000000000000000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			out, err := EVMTranscodeBytes32(gjson.Parse(test.input))
+			require.NoError(t, err)
+			assert.Equal(t, test.output, hexutil.Encode(out))
+		})
+	}
+
+	for _, input := range []string{`"0x` + strings.Repeat("ab", 33) + `"`, `"` + strings.Repeat("a", 33) + `"`, `{}`} {
+		_, err := EVMTranscodeBytes32(gjson.Parse(input))
+		assert.Error(t, err, input)
+	}
+}
+
 func TestEVMTranscodeJSONWithFormat_UnsupportedEncoding(t *testing.T) {
 	_, err := EVMTranscodeJSONWithFormat(gjson.Result{}, "burgh")
 	assert.Error(t, err)
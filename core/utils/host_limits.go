@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HostLimit is the most requests per minute, and the most requests at once,
+// which the node sends to a domain and its subdomains. Zero means no limit.
+type HostLimit struct {
+	Domain            string
+	RequestsPerMinute uint64
+	MaxConcurrent     uint64
+}
+
+// HostLimits are the limits on requests to external hosts, most specific
+// domain first.
+type HostLimits []HostLimit
+
+// ParseHostLimits parses a comma separated list of limits of the form
+// domain=requestsPerMinute[/maxConcurrent], for instance
+// "api.example.com=60/5,example.org=120".
+func ParseHostLimits(input string) (HostLimits, error) {
+	limits := HostLimits{}
+	for _, entry := range strings.Split(input, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, "=")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid host limit %q, expected domain=requestsPerMinute[/maxConcurrent]", entry)
+		}
+		limit := HostLimit{Domain: strings.ToLower(strings.TrimSpace(parts[0]))}
+		values := strings.Split(parts[1], "/")
+		if len(values) > 2 {
+			return nil, fmt.Errorf("invalid host limit %q, expected domain=requestsPerMinute[/maxConcurrent]", entry)
+		}
+		var err error
+		if limit.RequestsPerMinute, err = strconv.ParseUint(strings.TrimSpace(values[0]), 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid requests per minute in host limit %q: %v", entry, err)
+		}
+		if len(values) == 2 {
+			if limit.MaxConcurrent, err = strconv.ParseUint(strings.TrimSpace(values[1]), 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid max concurrent requests in host limit %q: %v", entry, err)
+			}
+		}
+		limits = append(limits, limit)
+	}
+	sort.SliceStable(limits, func(i, j int) bool {
+		return len(limits[i].Domain) > len(limits[j].Domain)
+	})
+	return limits, nil
+}
+
+// String returns the limits in the form parsed by ParseHostLimits.
+func (hl HostLimits) String() string {
+	entries := make([]string, len(hl))
+	for i, limit := range hl {
+		entries[i] = fmt.Sprintf("%s=%d", limit.Domain, limit.RequestsPerMinute)
+		if limit.MaxConcurrent > 0 {
+			entries[i] += fmt.Sprintf("/%d", limit.MaxConcurrent)
+		}
+	}
+	return strings.Join(entries, ",")
+}
+
+// For returns the limit on requests to host, which is that of the most
+// specific domain which is either the host or one of its parents.
+func (hl HostLimits) For(host string) (HostLimit, bool) {
+	host = strings.ToLower(host)
+	for _, limit := range hl {
+		if host == limit.Domain || strings.HasSuffix(host, "."+limit.Domain) {
+			return limit, true
+		}
+	}
+	return HostLimit{}, false
+}
+
+// hostLimiter enforces a HostLimit. Requests are spread out evenly over each
+// minute, so that there are never more than the limit in any minute.
+type hostLimiter struct {
+	limit HostLimit
+	slots chan struct{}
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newHostLimiter(limit HostLimit) *hostLimiter {
+	l := &hostLimiter{limit: limit}
+	if limit.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, limit.MaxConcurrent)
+	}
+	return l
+}
+
+// acquire waits until a request may be sent, returning a function which must
+// be called once the request is done. It fails immediately if the request
+// could not be sent before the context's deadline.
+func (l *hostLimiter) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			release = func() { <-l.slots }
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for one of the %d concurrent requests allowed to %s: %v", l.limit.MaxConcurrent, l.limit.Domain, ctx.Err())
+		}
+	}
+	if l.limit.RequestsPerMinute == 0 {
+		return release, nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+		l.mu.Unlock()
+		release()
+		return nil, fmt.Errorf("rate limit of %d requests per minute to %s would be exceeded", l.limit.RequestsPerMinute, l.limit.Domain)
+	}
+	l.next = l.next.Add(time.Minute / time.Duration(l.limit.RequestsPerMinute))
+	l.mu.Unlock()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// hostLimiters holds the limiter of each limited domain, which every request
+// to the domain shares, whichever job it is made for.
+var hostLimiters = struct {
+	sync.Mutex
+	byDomain map[string]*hostLimiter
+}{byDomain: make(map[string]*hostLimiter)}
+
+func limiterFor(limit HostLimit) *hostLimiter {
+	hostLimiters.Lock()
+	defer hostLimiters.Unlock()
+	l, ok := hostLimiters.byDomain[limit.Domain]
+	if !ok || l.limit != limit {
+		l = newHostLimiter(limit)
+		hostLimiters.byDomain[limit.Domain] = l
+	}
+	return l
+}
+
+// NewHostLimitedTransport returns a RoundTripper which holds requests to the
+// limited hosts back as needed to keep within their limits, before passing
+// them on to transport.
+func NewHostLimitedTransport(limits HostLimits, transport http.RoundTripper) http.RoundTripper {
+	if len(limits) == 0 {
+		return transport
+	}
+	return &hostLimitedTransport{limits: limits, transport: transport}
+}
+
+type hostLimitedTransport struct {
+	limits    HostLimits
+	transport http.RoundTripper
+}
+
+func (t *hostLimitedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	limit, ok := t.limits.For(request.URL.Hostname())
+	if !ok {
+		return t.transport.RoundTrip(request)
+	}
+	release, err := limiterFor(limit).acquire(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	response, err := t.transport.RoundTrip(request)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// The request counts towards the concurrency limit until its response
+	// has been read
+	response.Body = &releasingBody{ReadCloser: response.Body, release: release}
+	return response, nil
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
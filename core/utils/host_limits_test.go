@@ -0,0 +1,112 @@
+package utils_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHostLimits(t *testing.T) {
+	t.Parallel()
+
+	limits, err := utils.ParseHostLimits(" example.org=120, API.example.org=60/5 ,")
+	require.NoError(t, err)
+	assert.Equal(t, utils.HostLimits{
+		{Domain: "api.example.org", RequestsPerMinute: 60, MaxConcurrent: 5},
+		{Domain: "example.org", RequestsPerMinute: 120},
+	}, limits)
+	assert.Equal(t, "api.example.org=60/5,example.org=120", limits.String())
+
+	limit, ok := limits.For("api.example.org")
+	require.True(t, ok)
+	assert.Equal(t, uint64(60), limit.RequestsPerMinute)
+	limit, ok = limits.For("eu.example.org")
+	require.True(t, ok)
+	assert.Equal(t, uint64(120), limit.RequestsPerMinute)
+	_, ok = limits.For("notexample.org")
+	assert.False(t, ok)
+
+	for _, input := range []string{"example.org", "=60", "example.org=fast", "example.org=60/5/1", "example.org=60/-1"} {
+		_, err := utils.ParseHostLimits(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestHostLimitedTransport_MaxConcurrent(t *testing.T) {
+	var current, highest int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			h := atomic.LoadInt32(&highest)
+			if n <= h || atomic.CompareAndSwapInt32(&highest, h, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	limits, err := utils.ParseHostLimits("127.0.0.1=0/2")
+	require.NoError(t, err)
+	client := &http.Client{Transport: utils.NewHostLimitedTransport(limits, http.DefaultTransport)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := client.Get(server.URL)
+			if assert.NoError(t, err) {
+				response.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&highest))
+}
+
+func TestHostLimitedTransport_RequestsPerMinute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	limits, err := utils.ParseHostLimits("localhost=1200")
+	require.NoError(t, err)
+	client := &http.Client{Transport: utils.NewHostLimitedTransport(limits, http.DefaultTransport)}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		response, err := client.Get(url)
+		require.NoError(t, err)
+		response.Body.Close()
+	}
+	// Requests are spaced 50ms apart
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+
+	// A request which could not be sent before its deadline fails at once
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		request, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		require.NoError(t, err)
+		response, err := client.Do(request)
+		if err != nil {
+			assert.Contains(t, err.Error(), "rate limit of 1200 requests per minute to localhost would be exceeded")
+			return
+		}
+		response.Body.Close()
+	}
+	t.Fatal("expected a request to exceed the rate limit")
+}
@@ -0,0 +1,75 @@
+package utils
+
+import "fmt"
+
+// JSONTooDeepError is returned when a JSON document nests objects and arrays
+// more deeply than allowed.
+type JSONTooDeepError struct {
+	Limit int
+}
+
+func (e *JSONTooDeepError) Error() string {
+	return fmt.Sprintf("JSON is nested more than %d levels deep", e.Limit)
+}
+
+// CheckJSONDepth errors if the JSON document nests objects and arrays more
+// than limit levels deep. The document is scanned a byte at a time without
+// being decoded, so the check allocates nothing however large the document
+// is. It does not validate the document. A limit of zero disables the check.
+func CheckJSONDepth(data []byte, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	var s jsonDepthScanner
+	for _, c := range data {
+		if s.step(c) > limit {
+			return &JSONTooDeepError{limit}
+		}
+	}
+	return nil
+}
+
+// CheckJSONStringDepth is CheckJSONDepth for a document held in a string,
+// which saves copying it to a byte slice.
+func CheckJSONStringDepth(data string, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	var s jsonDepthScanner
+	for i := 0; i < len(data); i++ {
+		if s.step(data[i]) > limit {
+			return &JSONTooDeepError{limit}
+		}
+	}
+	return nil
+}
+
+// jsonDepthScanner tracks how deeply nested each byte of a JSON document is,
+// ignoring brackets and braces within strings.
+type jsonDepthScanner struct {
+	depth    int
+	inString bool
+	escaped  bool
+}
+
+// step moves the scanner over c and returns the depth reached.
+func (s *jsonDepthScanner) step(c byte) int {
+	switch {
+	case s.escaped:
+		s.escaped = false
+	case s.inString:
+		switch c {
+		case '\\':
+			s.escaped = true
+		case '"':
+			s.inString = false
+		}
+	case c == '"':
+		s.inString = true
+	case c == '{' || c == '[':
+		s.depth++
+	case c == '}' || c == ']':
+		s.depth--
+	}
+	return s.depth
+}
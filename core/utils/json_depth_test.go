@@ -0,0 +1,36 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckJSONDepth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		json    string
+		limit   int
+		wantErr bool
+	}{
+		{"scalar", `"value"`, 1, false},
+		{"within limit", `{"a": [1, {"b": 2}]}`, 3, false},
+		{"over limit", `{"a": [1, {"b": 2}]}`, 2, true},
+		{"brackets in strings", `{"a": "[[{{"}`, 1, false},
+		{"escaped quotes in strings", `{"a": "\"[[{{"}`, 1, false},
+		{"escaped backslash ends string", `{"a": "\\", "b": [[1]]}`, 2, true},
+		{"no limit", `[[[[[[1]]]]]]`, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := utils.CheckJSONDepth([]byte(test.json), test.limit)
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, err, utils.CheckJSONStringDepth(test.json, test.limit))
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// ProviderQuota is the number of requests which the node's subscription to an
+// API provider allows it to send to a domain and its subdomains each hour or
+// each day.
+type ProviderQuota struct {
+	Domain   string
+	Requests uint64
+	Period   time.Duration
+}
+
+// ProviderQuotas are the quotas of the API providers the node sends requests
+// to, most specific domain first.
+type ProviderQuotas []ProviderQuota
+
+// ParseProviderQuotas parses a comma separated list of quotas of the form
+// domain=requests/hour or domain=requests/day, for instance
+// "api.example.com=100000/day,example.org=500/hour".
+func ParseProviderQuotas(input string) (ProviderQuotas, error) {
+	quotas := ProviderQuotas{}
+	for _, entry := range strings.Split(input, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, "=")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid provider quota %q, expected domain=requests/hour or domain=requests/day", entry)
+		}
+		values := strings.Split(parts[1], "/")
+		if len(values) != 2 {
+			return nil, fmt.Errorf("invalid provider quota %q, expected domain=requests/hour or domain=requests/day", entry)
+		}
+		quota := ProviderQuota{Domain: strings.ToLower(strings.TrimSpace(parts[0]))}
+		var err error
+		if quota.Requests, err = strconv.ParseUint(strings.TrimSpace(values[0]), 10, 64); err != nil || quota.Requests == 0 {
+			return nil, fmt.Errorf("invalid number of requests in provider quota %q", entry)
+		}
+		switch strings.TrimSpace(values[1]) {
+		case "hour":
+			quota.Period = time.Hour
+		case "day":
+			quota.Period = 24 * time.Hour
+		default:
+			return nil, fmt.Errorf("invalid period in provider quota %q, must be hour or day", entry)
+		}
+		quotas = append(quotas, quota)
+	}
+	sort.SliceStable(quotas, func(i, j int) bool {
+		return len(quotas[i].Domain) > len(quotas[j].Domain)
+	})
+	return quotas, nil
+}
+
+// String returns the quotas in the form parsed by ParseProviderQuotas.
+func (pq ProviderQuotas) String() string {
+	entries := make([]string, len(pq))
+	for i, quota := range pq {
+		entries[i] = fmt.Sprintf("%s=%d/%s", quota.Domain, quota.Requests, quota.PeriodName())
+	}
+	return strings.Join(entries, ",")
+}
+
+// PeriodName returns "hour" or "day".
+func (q ProviderQuota) PeriodName() string {
+	if q.Period == time.Hour {
+		return "hour"
+	}
+	return "day"
+}
+
+func (q ProviderQuota) covers(host string) bool {
+	return host == q.Domain || strings.HasSuffix(host, "."+q.Domain)
+}
+
+// providerStatsRetention is how long request counts are kept.
+const providerStatsRetention = 7 * 24 * time.Hour
+
+// providerStatsHours is the number of hours request counts are kept for.
+const providerStatsHours = int64(providerStatsRetention / time.Hour)
+
+// ProviderStats counts the requests sent to each external host by the hour,
+// and warns when the requests to a provider's domain approach its quota.
+// Hours and days are in UTC.
+//
+// Each host, and each domain with a quota, has its own ring of hourly
+// counts, so that recording a request only locks and updates the counts of
+// its host and of the quotas covering it.
+type ProviderStats struct {
+	mu      sync.RWMutex
+	hosts   map[string]*hourlyCounts
+	domains map[string]*hourlyCounts
+
+	alertMu sync.Mutex
+	alerted map[string]time.Time
+}
+
+// ProviderRequests counts every request the node sends to external hosts on
+// behalf of its jobs.
+var ProviderRequests = NewProviderStats()
+
+// NewProviderStats returns an empty ProviderStats.
+func NewProviderStats() *ProviderStats {
+	return &ProviderStats{
+		hosts:   make(map[string]*hourlyCounts),
+		domains: make(map[string]*hourlyCounts),
+		alerted: make(map[string]time.Time),
+	}
+}
+
+// Record counts a request sent to host at the given time. If the request
+// takes the provider's usage of one of its quotas to alertPercent of it, or
+// past the quota itself, a warning is logged, once per period.
+func (s *ProviderStats) Record(host string, at time.Time, quotas ProviderQuotas, alertPercent uint64) {
+	host = strings.ToLower(host)
+	at = at.UTC()
+	s.counts(s.hosts, host).add(at)
+
+	counted := map[string]bool{}
+	for _, quota := range quotas {
+		if !quota.covers(host) {
+			continue
+		}
+		domain := s.counts(s.domains, quota.Domain)
+		if !counted[quota.Domain] {
+			domain.add(at)
+			counted[quota.Domain] = true
+		}
+		start := at.Truncate(quota.Period)
+		used := domain.since(start)
+		if used > quota.Requests {
+			s.alert(quota, start, "exceeded", used)
+		} else if alertPercent > 0 && used*100 >= quota.Requests*alertPercent {
+			s.alert(quota, start, "approaching", used)
+		}
+	}
+}
+
+// counts returns the counts of the host or domain, adding them if there are
+// none yet.
+func (s *ProviderStats) counts(all map[string]*hourlyCounts, name string) *hourlyCounts {
+	s.mu.RLock()
+	counts, ok := all[name]
+	s.mu.RUnlock()
+	if ok {
+		return counts
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if counts, ok = all[name]; !ok {
+		counts = &hourlyCounts{}
+		all[name] = counts
+	}
+	return counts
+}
+
+func (s *ProviderStats) alert(quota ProviderQuota, start time.Time, level string, used uint64) {
+	key := fmt.Sprintf("%s/%s/%s", quota.Domain, quota.PeriodName(), level)
+	s.alertMu.Lock()
+	defer s.alertMu.Unlock()
+	if s.alerted[key].Equal(start) {
+		return
+	}
+	s.alerted[key] = start
+	logger.Warnw(fmt.Sprintf("Requests to %s %s its quota of %d per %s", quota.Domain, level, quota.Requests, quota.PeriodName()),
+		"domain", quota.Domain, "requests", used, "quota", quota.Requests, "period", quota.PeriodName())
+}
+
+// hourlyCounts is a ring of the number of requests sent in each hour of the
+// last week, indexed by the hour.
+type hourlyCounts struct {
+	mu    sync.Mutex
+	hours [providerStatsHours]PeriodCount
+}
+
+// add counts a request at the given time, unless it is older than the hour
+// which has since taken its place in the ring.
+func (h *hourlyCounts) add(at time.Time) {
+	hour := at.Truncate(time.Hour)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	slot := &h.hours[hour.Unix()/int64(time.Hour/time.Second)%providerStatsHours]
+	if slot.Start.After(hour) {
+		return
+	} else if !slot.Start.Equal(hour) {
+		*slot = PeriodCount{Start: hour}
+	}
+	slot.Requests++
+}
+
+// since returns the number of requests sent since start, which is the
+// start of an hour less than a week ago.
+func (h *hourlyCounts) since(start time.Time) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var count uint64
+	for _, hour := range h.hours {
+		if !hour.Start.Before(start) {
+			count += hour.Requests
+		}
+	}
+	return count
+}
+
+// after returns the counts of the hours since cutoff in which requests were
+// sent.
+func (h *hourlyCounts) after(cutoff time.Time) []PeriodCount {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var counts []PeriodCount
+	for _, hour := range h.hours {
+		if hour.Requests > 0 && hour.Start.After(cutoff) {
+			counts = append(counts, hour)
+		}
+	}
+	return counts
+}
+
+// ProviderUsage is the number of requests sent to a host in the current hour
+// and day, and in each of the hours and days before.
+type ProviderUsage struct {
+	Host     string        `json:"host"`
+	ThisHour uint64        `json:"thisHour"`
+	Today    uint64        `json:"today"`
+	Hourly   []PeriodCount `json:"hourly"`
+	Daily    []PeriodCount `json:"daily"`
+}
+
+// PeriodCount is the number of requests sent in the hour or day starting at
+// Start.
+type PeriodCount struct {
+	Start    time.Time `json:"start"`
+	Requests uint64    `json:"requests"`
+}
+
+// QuotaUsage is how much of a provider's quota has been used in the current
+// period.
+type QuotaUsage struct {
+	Domain      string  `json:"domain"`
+	Period      string  `json:"period"`
+	Quota       uint64  `json:"quota"`
+	Requests    uint64  `json:"requests"`
+	PercentUsed float64 `json:"percentUsed"`
+}
+
+// Usage returns the usage of each host requests have been sent to in the
+// last week, sorted by host, with the hours and days it was used in, oldest
+// first, and the usage of each quota.
+func (s *ProviderStats) Usage(now time.Time, quotas ProviderQuotas) ([]ProviderUsage, []QuotaUsage) {
+	now = now.UTC()
+	cutoff := now.Add(-providerStatsRetention)
+	thisHour := now.Truncate(time.Hour)
+	today := now.Truncate(24 * time.Hour)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usages := []ProviderUsage{}
+	recent := map[string][]PeriodCount{}
+	for host, counts := range s.hosts {
+		hours := counts.after(cutoff)
+		if len(hours) == 0 {
+			delete(s.hosts, host)
+			continue
+		}
+		recent[host] = hours
+		usage := ProviderUsage{Host: host, Hourly: hours, Daily: []PeriodCount{}}
+		days := map[time.Time]uint64{}
+		for _, hour := range hours {
+			days[hour.Start.Truncate(24*time.Hour)] += hour.Requests
+			if hour.Start.Equal(thisHour) {
+				usage.ThisHour += hour.Requests
+			}
+			if !hour.Start.Before(today) {
+				usage.Today += hour.Requests
+			}
+		}
+		for day, n := range days {
+			usage.Daily = append(usage.Daily, PeriodCount{Start: day, Requests: n})
+		}
+		sortPeriodCounts(usage.Hourly)
+		sortPeriodCounts(usage.Daily)
+		usages = append(usages, usage)
+	}
+	sort.Slice(usages, func(i, j int) bool { return usages[i].Host < usages[j].Host })
+
+	quotaUsages := []QuotaUsage{}
+	for _, quota := range quotas {
+		start := now.Truncate(quota.Period)
+		var used uint64
+		for host, hours := range recent {
+			if !quota.covers(host) {
+				continue
+			}
+			for _, hour := range hours {
+				if !hour.Start.Before(start) {
+					used += hour.Requests
+				}
+			}
+		}
+		quotaUsages = append(quotaUsages, QuotaUsage{
+			Domain:      quota.Domain,
+			Period:      quota.PeriodName(),
+			Quota:       quota.Requests,
+			Requests:    used,
+			PercentUsed: float64(used) * 100 / float64(quota.Requests),
+		})
+	}
+	return usages, quotaUsages
+}
+
+func sortPeriodCounts(counts []PeriodCount) {
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Start.Before(counts[j].Start) })
+}
+
+// NewProviderCountingTransport returns a RoundTripper which counts each
+// request in stats before passing it on to transport.
+func NewProviderCountingTransport(stats *ProviderStats, quotas ProviderQuotas, alertPercent uint64, transport http.RoundTripper) http.RoundTripper {
+	return &providerCountingTransport{stats: stats, quotas: quotas, alertPercent: alertPercent, transport: transport}
+}
+
+type providerCountingTransport struct {
+	stats        *ProviderStats
+	quotas       ProviderQuotas
+	alertPercent uint64
+	transport    http.RoundTripper
+}
+
+func (t *providerCountingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.stats.Record(request.URL.Hostname(), time.Now(), t.quotas, t.alertPercent)
+	return t.transport.RoundTrip(request)
+}
@@ -0,0 +1,66 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProviderQuotas(t *testing.T) {
+	t.Parallel()
+
+	quotas, err := utils.ParseProviderQuotas("example.org=500/hour, api.example.org=100000/day")
+	require.NoError(t, err)
+	assert.Equal(t, utils.ProviderQuotas{
+		{Domain: "api.example.org", Requests: 100000, Period: 24 * time.Hour},
+		{Domain: "example.org", Requests: 500, Period: time.Hour},
+	}, quotas)
+	assert.Equal(t, "api.example.org=100000/day,example.org=500/hour", quotas.String())
+
+	for _, input := range []string{"example.org=500", "example.org=500/week", "example.org=0/day", "=5/day"} {
+		_, err := utils.ParseProviderQuotas(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestProviderStats_Usage(t *testing.T) {
+	t.Parallel()
+
+	stats := utils.NewProviderStats()
+	quotas, err := utils.ParseProviderQuotas("example.org=4/hour,example.org=10/day")
+	require.NoError(t, err)
+
+	now := time.Date(2020, 10, 20, 15, 30, 0, 0, time.UTC)
+	stats.Record("api.example.org", now.Add(-24*time.Hour), quotas, 80)
+	stats.Record("api.example.org", now.Add(-time.Hour), quotas, 80)
+	stats.Record("API.example.org", now, quotas, 80)
+	stats.Record("eu.example.org", now, quotas, 80)
+	stats.Record("other.com", now, quotas, 80)
+	stats.Record("other.com", now.Add(-8*24*time.Hour), quotas, 80)
+
+	providers, quotaUsages := stats.Usage(now, quotas)
+	require.Len(t, providers, 3)
+	api := providers[0]
+	assert.Equal(t, "api.example.org", api.Host)
+	assert.Equal(t, uint64(1), api.ThisHour)
+	assert.Equal(t, uint64(2), api.Today)
+	require.Len(t, api.Hourly, 3)
+	assert.Equal(t, now.Add(-24*time.Hour).Truncate(time.Hour), api.Hourly[0].Start)
+	assert.Equal(t, []utils.PeriodCount{
+		{Start: time.Date(2020, 10, 19, 0, 0, 0, 0, time.UTC), Requests: 1},
+		{Start: time.Date(2020, 10, 20, 0, 0, 0, 0, time.UTC), Requests: 2},
+	}, api.Daily)
+	assert.Equal(t, "eu.example.org", providers[1].Host)
+	// Requests older than a week are forgotten
+	assert.Equal(t, "other.com", providers[2].Host)
+	assert.Len(t, providers[2].Hourly, 1)
+
+	assert.Equal(t, []utils.QuotaUsage{
+		{Domain: "example.org", Period: "hour", Quota: 4, Requests: 2, PercentUsed: 50},
+		{Domain: "example.org", Period: "day", Quota: 10, Requests: 3, PercentUsed: 30},
+	}, quotaUsages)
+}
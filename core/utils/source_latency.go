@@ -0,0 +1,274 @@
+package utils
+
+import (
+	"container/list"
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// sourceLatencySamples is the number of most recent latencies kept for each
+// source.
+const sourceLatencySamples = 1000
+
+// MaxLatencySources is the number of sources whose latencies are kept. The
+// latencies of the source which has gone the longest without a request are
+// dropped to make room for a new source, as jobs may send requests to any
+// number of hosts.
+const MaxLatencySources = 500
+
+// minAdaptiveTimeoutSamples is the number of latencies which must have been
+// recorded for a source before a timeout is derived from them.
+const minAdaptiveTimeoutSamples = 20
+
+// SourceLatencies keeps the most recent latencies of the requests sent to
+// each external source, which is a host and port, so that timeouts can be
+// derived from how quickly each source usually answers.
+type SourceLatencies struct {
+	mu      sync.RWMutex
+	samples map[string]*list.Element
+	// recent orders the sources from the one most recently sent a request
+	recent  *list.List
+	changed map[string]bool
+}
+
+// sourceSamples are the latencies of a source, oldest first.
+type sourceSamples struct {
+	source    string
+	latencies []time.Duration
+}
+
+// SourceRequestLatencies holds the latencies of every request the node sends
+// to external sources on behalf of its jobs.
+var SourceRequestLatencies = NewSourceLatencies()
+
+// NewSourceLatencies returns an empty SourceLatencies.
+func NewSourceLatencies() *SourceLatencies {
+	return &SourceLatencies{
+		samples: make(map[string]*list.Element),
+		recent:  list.New(),
+		changed: make(map[string]bool),
+	}
+}
+
+// Record adds the latency of a request to source, dropping the oldest latency
+// once the source has sourceLatencySamples of them.
+func (s *SourceLatencies) Record(source string, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changed[source] = true
+	samples := s.touch(source)
+	if len(samples.latencies) < sourceLatencySamples {
+		samples.latencies = append(samples.latencies, latency)
+		return
+	}
+	copy(samples.latencies, samples.latencies[1:])
+	samples.latencies[len(samples.latencies)-1] = latency
+}
+
+// touch returns the samples of source, making it the most recent source and
+// dropping the least recent if there are more than MaxLatencySources.
+func (s *SourceLatencies) touch(source string) *sourceSamples {
+	if element, ok := s.samples[source]; ok {
+		s.recent.MoveToFront(element)
+		return element.Value.(*sourceSamples)
+	}
+	samples := &sourceSamples{source: source}
+	s.samples[source] = s.recent.PushFront(samples)
+	if s.recent.Len() > MaxLatencySources {
+		oldest := s.recent.Remove(s.recent.Back()).(*sourceSamples)
+		delete(s.samples, oldest.source)
+		delete(s.changed, oldest.source)
+	}
+	return samples
+}
+
+// latencies returns the latencies of source, or nil if it has none.
+func (s *SourceLatencies) latencies(source string) []time.Duration {
+	if element, ok := s.samples[source]; ok {
+		return element.Value.(*sourceSamples).latencies
+	}
+	return nil
+}
+
+// Percentile returns the latency under which the given percentage of the
+// source's requests answered, or false if none have been recorded.
+func (s *SourceLatencies) Percentile(source string, percent float64) (time.Duration, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	samples := s.latencies(source)
+	if len(samples) == 0 {
+		return 0, false
+	}
+	return DurationPercentiles(samples, percent)[0], true
+}
+
+// AdaptiveTimeout returns the 99th percentile latency of the source plus
+// margin, or false if too few latencies have been recorded for the source to
+// derive a timeout from.
+func (s *SourceLatencies) AdaptiveTimeout(source string, margin time.Duration) (time.Duration, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	samples := s.latencies(source)
+	if len(samples) < minAdaptiveTimeoutSamples {
+		return 0, false
+	}
+	return DurationPercentiles(samples, 99)[0] + margin, true
+}
+
+// SourceLatencyStats are the latency percentiles of the recent requests sent
+// to a source, in milliseconds, and the timeout derived from them if adaptive
+// timeouts are enabled.
+type SourceLatencyStats struct {
+	Source          string   `json:"source"`
+	Samples         int      `json:"samples"`
+	P50             float64  `json:"p50"`
+	P90             float64  `json:"p90"`
+	P99             float64  `json:"p99"`
+	AdaptiveTimeout *float64 `json:"adaptiveTimeout"`
+}
+
+// Stats returns the latency percentiles of each source, sorted by source. If
+// margin is positive, the adaptive timeout of each source with enough
+// latencies is included.
+func (s *SourceLatencies) Stats(margin time.Duration) []SourceLatencyStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stats := []SourceLatencyStats{}
+	for element := s.recent.Front(); element != nil; element = element.Next() {
+		samples := element.Value.(*sourceSamples)
+		ps := DurationPercentiles(samples.latencies, 50, 90, 99)
+		stat := SourceLatencyStats{
+			Source:  samples.source,
+			Samples: len(samples.latencies),
+			P50:     milliseconds(ps[0]),
+			P90:     milliseconds(ps[1]),
+			P99:     milliseconds(ps[2]),
+		}
+		if margin > 0 && len(samples.latencies) >= minAdaptiveTimeoutSamples {
+			timeout := milliseconds(ps[2] + margin)
+			stat.AdaptiveTimeout = &timeout
+		}
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Source < stats[j].Source })
+	return stats
+}
+
+// Snapshot returns a copy of the latencies of each source, oldest first.
+func (s *SourceLatencies) Snapshot() map[string][]time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snapshot := make(map[string][]time.Duration, len(s.samples))
+	for source, element := range s.samples {
+		snapshot[source] = append([]time.Duration(nil), element.Value.(*sourceSamples).latencies...)
+	}
+	return snapshot
+}
+
+// Changes returns a copy of the latencies of each source which has recorded
+// any since Changes was last called, oldest first.
+func (s *SourceLatencies) Changes() map[string][]time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changes := make(map[string][]time.Duration, len(s.changed))
+	for source := range s.changed {
+		changes[source] = append([]time.Duration(nil), s.latencies(source)...)
+	}
+	s.changed = make(map[string]bool)
+	return changes
+}
+
+// Load replaces the latencies of each source in snapshot, as returned by
+// Snapshot, up to MaxLatencySources sources.
+func (s *SourceLatencies) Load(snapshot map[string][]time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for source, samples := range snapshot {
+		if len(samples) > sourceLatencySamples {
+			samples = samples[len(samples)-sourceLatencySamples:]
+		}
+		s.touch(source).latencies = append([]time.Duration(nil), samples...)
+	}
+}
+
+// DurationPercentiles returns the nearest rank percentiles of samples, which
+// must not be empty.
+func DurationPercentiles(samples []time.Duration, percents ...float64) []time.Duration {
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	results := make([]time.Duration, len(percents))
+	for i, percent := range percents {
+		rank := int(math.Ceil(percent / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		results[i] = sorted[rank-1]
+	}
+	return results
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// NewLatencyTrackingTransport returns a RoundTripper which records how long
+// each source takes to answer in latencies before passing requests on to
+// transport. If margin is positive, requests to a source time out after its
+// adaptive timeout, once enough of its latencies have been recorded.
+func NewLatencyTrackingTransport(latencies *SourceLatencies, margin time.Duration, transport http.RoundTripper) http.RoundTripper {
+	return &latencyTrackingTransport{latencies: latencies, margin: margin, transport: transport}
+}
+
+type latencyTrackingTransport struct {
+	latencies *SourceLatencies
+	margin    time.Duration
+	transport http.RoundTripper
+}
+
+func (t *latencyTrackingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	source := request.URL.Host
+	var cancel context.CancelFunc
+	if t.margin > 0 {
+		if timeout, ok := t.latencies.AdaptiveTimeout(source, t.margin); ok {
+			var ctx context.Context
+			ctx, cancel = context.WithTimeout(request.Context(), timeout)
+			request = request.WithContext(ctx)
+		}
+	}
+
+	start := time.Now()
+	response, err := t.transport.RoundTrip(request)
+	if err != nil {
+		// Requests cut off by a deadline still count, so that a source
+		// which slows down raises its timeout rather than failing
+		if request.Context().Err() == context.DeadlineExceeded {
+			t.latencies.Record(source, time.Since(start))
+		}
+		if cancel != nil {
+			cancel()
+		}
+		return nil, err
+	}
+	t.latencies.Record(source, time.Since(start))
+	if cancel != nil {
+		response.Body = &cancelOnCloseBody{ReadCloser: response.Body, cancel: cancel}
+	}
+	return response, nil
+}
+
+// cancelOnCloseBody releases the context of a request once its response body
+// has been read.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,114 @@
+package utils_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceLatencies_Stats(t *testing.T) {
+	t.Parallel()
+
+	latencies := utils.NewSourceLatencies()
+	for i := 1; i <= 100; i++ {
+		latencies.Record("api.example.org", time.Duration(i)*time.Millisecond)
+	}
+	latencies.Record("slow.example.org", time.Second)
+
+	p90, ok := latencies.Percentile("api.example.org", 90)
+	require.True(t, ok)
+	assert.Equal(t, 90*time.Millisecond, p90)
+	_, ok = latencies.Percentile("unknown.example.org", 90)
+	assert.False(t, ok)
+
+	timeout, ok := latencies.AdaptiveTimeout("api.example.org", 50*time.Millisecond)
+	require.True(t, ok)
+	assert.Equal(t, 149*time.Millisecond, timeout)
+	// Too few latencies to derive a timeout from
+	_, ok = latencies.AdaptiveTimeout("slow.example.org", 50*time.Millisecond)
+	assert.False(t, ok)
+
+	stats := latencies.Stats(50 * time.Millisecond)
+	require.Len(t, stats, 2)
+	assert.Equal(t, "api.example.org", stats[0].Source)
+	assert.Equal(t, 100, stats[0].Samples)
+	assert.Equal(t, float64(50), stats[0].P50)
+	assert.Equal(t, float64(99), stats[0].P99)
+	require.NotNil(t, stats[0].AdaptiveTimeout)
+	assert.Equal(t, float64(149), *stats[0].AdaptiveTimeout)
+	assert.Equal(t, "slow.example.org", stats[1].Source)
+	assert.Nil(t, stats[1].AdaptiveTimeout)
+
+	restored := utils.NewSourceLatencies()
+	restored.Load(latencies.Snapshot())
+	assert.Equal(t, stats, restored.Stats(50*time.Millisecond))
+}
+
+func TestSourceLatencies_DropsLeastRecentSource(t *testing.T) {
+	t.Parallel()
+
+	latencies := utils.NewSourceLatencies()
+	for i := 0; i < utils.MaxLatencySources; i++ {
+		latencies.Record(fmt.Sprintf("%d.example.org", i), time.Millisecond)
+	}
+	assert.Len(t, latencies.Changes(), utils.MaxLatencySources)
+	assert.Empty(t, latencies.Changes())
+
+	// The first source is used again, so the second is the least recent
+	latencies.Record("0.example.org", time.Millisecond)
+	latencies.Record("new.example.org", time.Millisecond)
+
+	snapshot := latencies.Snapshot()
+	assert.Len(t, snapshot, utils.MaxLatencySources)
+	assert.Contains(t, snapshot, "0.example.org")
+	assert.Contains(t, snapshot, "new.example.org")
+	assert.NotContains(t, snapshot, "1.example.org")
+	_, ok := latencies.Percentile("1.example.org", 50)
+	assert.False(t, ok)
+
+	changes := latencies.Changes()
+	assert.Len(t, changes, 2)
+	assert.Equal(t, []time.Duration{time.Millisecond, time.Millisecond}, changes["0.example.org"])
+}
+
+func TestLatencyTrackingTransport_AdaptiveTimeout(t *testing.T) {
+	t.Parallel()
+
+	delay := make(chan time.Duration, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(<-delay):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	latencies := utils.NewSourceLatencies()
+	for i := 0; i < 20; i++ {
+		latencies.Record(serverURL.Host, 10*time.Millisecond)
+	}
+	client := &http.Client{Transport: utils.NewLatencyTrackingTransport(latencies, 50*time.Millisecond, http.DefaultTransport)}
+
+	delay <- 0
+	response, err := client.Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+
+	delay <- time.Second
+	_, err = client.Get(server.URL)
+	require.Error(t, err)
+
+	stats := latencies.Stats(0)
+	require.Len(t, stats, 1)
+	assert.Equal(t, 22, stats[0].Samples)
+}
@@ -24,6 +24,9 @@ const (
 	// KeyPreviousLink is the name of the key that contains the HREF for the
 	// previous document in a paginated response.
 	KeyPreviousLink = "prev"
+	// KeyNextCursor is the name of the meta key that contains the cursor of
+	// the next document in a cursor paginated response.
+	KeyNextCursor = "nextCursor"
 )
 
 // ParsePaginatedRequest parses the parameters that control pagination for a
@@ -98,6 +101,30 @@ func NewPaginatedResponse(url url.URL, size, page, count int, resource interface
 	return json.Marshal(document)
 }
 
+// NewCursorPaginatedResponse returns a jsonapi.Document for a page of a
+// collection paginated by cursor, with the cursor of the next page and a link
+// to it if there is one. Unlike NewPaginatedResponse it carries no count,
+// since counting is what makes offset pagination slow on large collections.
+func NewCursorPaginatedResponse(url url.URL, size int, nextCursor string, resource interface{}) ([]byte, error) {
+	document, err := jsonapi.MarshalToStruct(resource, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource to struct: %+v", err)
+	}
+
+	document.Meta = make(jsonapi.Meta)
+	document.Links = make(jsonapi.Links)
+	if nextCursor != "" {
+		document.Meta[KeyNextCursor] = nextCursor
+		query := url.Query()
+		query.Set("size", strconv.Itoa(size))
+		query.Set("cursor", nextCursor)
+		query.Del("page")
+		url.RawQuery = query.Encode()
+		document.Links[KeyNextLink] = jsonapi.Link{Href: url.String()}
+	}
+	return json.Marshal(document)
+}
+
 // ParsePaginatedResponse parse a JSONAPI response for a document with links
 func ParsePaginatedResponse(input []byte, resource interface{}, links *jsonapi.Links) error {
 	err := ParseJSONAPIResponse(input, resource)
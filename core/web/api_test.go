@@ -6,6 +6,7 @@ import (
 
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestApi_ParsePaginatedRequest(t *testing.T) {
@@ -121,6 +122,38 @@ func TestApi_NewPaginatedResponse(t *testing.T) {
 	}
 }
 
+func TestApi_NewCursorPaginatedResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		nextCursor string
+		output     string
+	}{
+		{
+			"last page",
+			"/v2/index?cursor=abc", "",
+			`{"data":[{"type":"testResources","id":"1","attributes":{"Title":"Item 1"}}]}`,
+		},
+		{
+			"page with a next page",
+			"/v2/index?cursor=&page=3&sort=-createdAt", "def",
+			`{"links":{"next":"/v2/index?cursor=def\u0026size=5\u0026sort=-createdAt"},"data":[{"type":"testResources","id":"1","attributes":{"Title":"Item 1"}}],"meta":{"nextCursor":"def"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			url, err := url.Parse(test.path)
+			require.NoError(t, err)
+			buffer, err := NewCursorPaginatedResponse(*url, 5, test.nextCursor, []TestResource{{Title: "Item 1"}})
+			require.NoError(t, err)
+			assert.Equal(t, test.output, string(buffer))
+		})
+	}
+}
+
 func TestPagination_ParsePaginatedResponse(t *testing.T) {
 	t.Parallel()
 
@@ -1,7 +1,13 @@
 package web
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -23,12 +29,23 @@ const (
 	// ExternalInitiatorSecretHeader is the header name for the secret used by
 	// external initiators to authenticate
 	ExternalInitiatorSecretHeader = "X-Chainlink-EA-Secret"
+	// ExternalInitiatorTimestampHeader is the header name for the unix time,
+	// in seconds, at which a request of the version 2 protocol was signed
+	ExternalInitiatorTimestampHeader = "X-Chainlink-EA-Timestamp"
+	// ExternalInitiatorNonceHeader is the header name for the nonce which
+	// makes each request of the version 2 protocol unique
+	ExternalInitiatorNonceHeader = "X-Chainlink-EA-Nonce"
+	// ExternalInitiatorSignatureHeader is the header name for the signature
+	// of a request of the version 2 protocol
+	ExternalInitiatorSignatureHeader = "X-Chainlink-EA-Signature"
 )
 
 type AuthStorer interface {
 	AuthorizedUserWithSession(sessionID string) (models.User, error)
 	FindExternalInitiator(eia *auth.Token) (*models.ExternalInitiator, error)
 	FindUser() (models.User, error)
+	FindNamespaceToken(accessKey string) (models.NamespaceToken, error)
+	UseNonce(signer, nonce string, expiresAt time.Time) (bool, error)
 }
 
 type authType func(store AuthStorer, ctx *gin.Context) error
@@ -54,7 +71,12 @@ func AuthenticateExternalInitiator(store AuthStorer, c *gin.Context) error {
 		return errors.Wrap(err, "finding external intiator")
 	}
 
-	ok, err := models.AuthenticateExternalInitiator(eia, ei)
+	var ok bool
+	if ei.ProtocolVersion >= models.ExternalInitiatorProtocolV2 {
+		ok, err = authenticateExternalInitiatorSignature(store, c, *ei)
+	} else {
+		ok, err = models.AuthenticateExternalInitiator(eia, ei)
+	}
 	if err != nil {
 		return err
 	}
@@ -69,6 +91,46 @@ func AuthenticateExternalInitiator(store AuthStorer, c *gin.Context) error {
 
 var _ authType = AuthenticateExternalInitiator
 
+// authenticateExternalInitiatorSignature checks that a request of the version
+// 2 protocol was signed by the external initiator within
+// ExternalInitiatorSignatureMaxAge, and that its nonce has not been used
+// before, so that a request which is intercepted cannot be replayed. Used
+// nonces are stored, so that they are still refused after the node
+// restarts.
+func authenticateExternalInitiatorSignature(store AuthStorer, c *gin.Context, ei models.ExternalInitiator) (bool, error) {
+	timestamp, err := strconv.ParseInt(c.GetHeader(ExternalInitiatorTimestampHeader), 10, 64)
+	if err != nil {
+		return false, nil
+	}
+	signedAt := time.Unix(timestamp, 0)
+	if age := time.Since(signedAt); age > models.ExternalInitiatorSignatureMaxAge || age < -models.ExternalInitiatorSignatureMaxAge {
+		return false, nil
+	}
+	nonce := c.GetHeader(ExternalInitiatorNonceHeader)
+	if nonce == "" {
+		return false, nil
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, models.MaxExternalInitiatorRequestSize))
+	if err != nil {
+		return false, errors.Wrap(err, "reading signed request")
+	}
+	c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	signature := []byte(c.GetHeader(ExternalInitiatorSignatureHeader))
+	for _, key := range ei.IncomingSigningKeys() {
+		if key == "" {
+			continue
+		}
+		expected := models.ExternalInitiatorSignature(key, timestamp, nonce, c.Request.Method, c.Request.URL.RequestURI(), body)
+		if hmac.Equal([]byte(expected), signature) {
+			signer := fmt.Sprintf("external_initiator/%d", ei.ID)
+			return store.UseNonce(signer, nonce, signedAt.Add(models.ExternalInitiatorSignatureMaxAge))
+		}
+	}
+	return false, nil
+}
+
 func authenticatedEI(c *gin.Context) (*models.ExternalInitiator, bool) {
 	obj, ok := c.Get(SessionExternalInitiatorKey)
 	if !ok {
@@ -77,7 +139,8 @@ func authenticatedEI(c *gin.Context) (*models.ExternalInitiator, bool) {
 	return obj.(*models.ExternalInitiator), ok
 }
 
-// AuthenticateByToken authenticates a User by their API token.
+// AuthenticateByToken authenticates a User by their API token, or by an API
+// token of a namespace, which confines the request to that namespace.
 func AuthenticateByToken(store AuthStorer, c *gin.Context) error {
 	token := &auth.Token{
 		AccessKey: c.GetHeader(APIKey),
@@ -95,12 +158,44 @@ func AuthenticateByToken(store AuthStorer, c *gin.Context) error {
 	if err != nil {
 		return err
 	} else if !ok {
-		return auth.ErrorAuthFailed
+		nt, err := store.FindNamespaceToken(token.AccessKey)
+		if errors.Cause(err) == orm.ErrorNotFound {
+			return auth.ErrorAuthFailed
+		} else if err != nil {
+			return errors.Wrap(err, "finding namespace token")
+		}
+		if ok, err = nt.Authenticate(token); err != nil {
+			return err
+		} else if !ok {
+			return auth.ErrorAuthFailed
+		}
+		c.Set(SessionNamespaceTokenKey, nt.Namespace)
 	}
 	c.Set(SessionUserKey, &user)
 	return nil
 }
 
+// tokenNamespace returns the namespace the request's API token is confined
+// to, if it was authenticated by a namespace's token.
+func tokenNamespace(c *gin.Context) (string, bool) {
+	name := c.GetString(SessionNamespaceTokenKey)
+	return name, name != ""
+}
+
+// RequireNodeAdmin refuses requests authenticated by a namespace's API
+// token, for the routes which manage the node as a whole rather than the
+// jobs of a namespace.
+func RequireNodeAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if name, ok := tokenNamespace(c); ok {
+			jsonAPIError(c, http.StatusForbidden, fmt.Errorf("the API token of namespace %s cannot manage the node", name))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
 var _ authType = AuthenticateByToken
 
 func AuthenticateBySession(store AuthStorer, c *gin.Context) error {
@@ -3,7 +3,10 @@ package web_test
 import (
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
@@ -47,6 +50,45 @@ func (u userFindSuccesser) FindUser() (models.User, error) {
 	return u.user, nil
 }
 
+type externalInitiatorFinder struct {
+	*store.Store
+	ei *models.ExternalInitiator
+}
+
+func (e externalInitiatorFinder) FindExternalInitiator(*auth.Token) (*models.ExternalInitiator, error) {
+	return e.ei, nil
+}
+
+func (e externalInitiatorFinder) UseNonce(string, string, time.Time) (bool, error) {
+	return true, nil
+}
+
+func TestAuthenticateExternalInitiator_RequestSizeLimit(t *testing.T) {
+	eia := auth.NewToken()
+	ei, err := models.NewExternalInitiator(eia, &models.ExternalInitiatorRequest{
+		Name:            "bitcoin",
+		ProtocolVersion: models.ExternalInitiatorProtocolV2,
+	})
+	require.NoError(t, err)
+	store := externalInitiatorFinder{ei: ei}
+
+	authenticate := func(body string) error {
+		timestamp := time.Now().Unix()
+		req := httptest.NewRequest(http.MethodPost, "/v2/specs", strings.NewReader(body))
+		req.Header.Set(web.ExternalInitiatorAccessKeyHeader, eia.AccessKey)
+		req.Header.Set(web.ExternalInitiatorTimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(web.ExternalInitiatorNonceHeader, "nonce")
+		req.Header.Set(web.ExternalInitiatorSignatureHeader,
+			models.ExternalInitiatorSignature(ei.IncomingSigningKey, timestamp, "nonce", http.MethodPost, "/v2/specs", []byte(body)))
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = req
+		return web.AuthenticateExternalInitiator(store, c)
+	}
+
+	assert.NoError(t, authenticate(`{"result":"100"}`))
+	assert.Error(t, authenticate(strings.Repeat(" ", models.MaxExternalInitiatorRequestSize+1)))
+}
+
 func TestAuthenticateByToken_Success(t *testing.T) {
 	user := cltest.MustRandomUser()
 	apiToken := auth.Token{AccessKey: cltest.APIKey, Secret: cltest.APISecret}
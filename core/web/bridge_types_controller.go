@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/lib/pq"
+	"github.com/smartcontractkit/chainlink/core/adapters"
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -32,6 +33,7 @@ func (btc *BridgeTypesController) Create(c *gin.Context) {
 		jsonAPIError(c, StatusCodeForError(err), err)
 		return
 	}
+	bt.Namespace = requestNamespace(c)
 	if e := services.ValidateBridgeType(btr, btc.App.GetStore()); e != nil {
 		jsonAPIError(c, http.StatusBadRequest, e)
 		return
@@ -61,7 +63,7 @@ func (btc *BridgeTypesController) Create(c *gin.Context) {
 
 // Index lists Bridges, one page at a time.
 func (btc *BridgeTypesController) Index(c *gin.Context, size, page, offset int) {
-	bridges, count, err := btc.App.GetStore().BridgeTypes(offset, size)
+	bridges, count, err := btc.App.GetStore().BridgeTypes(requestNamespace(c), offset, size)
 	paginatedResponse(c, "Bridges", size, page, bridges, count, err)
 }
 
@@ -76,7 +78,7 @@ func (btc *BridgeTypesController) Show(c *gin.Context) {
 	}
 
 	bt, err := btc.App.GetStore().FindBridge(taskType)
-	if errors.Cause(err) == orm.ErrorNotFound {
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, bt.Namespace)) {
 		jsonAPIError(c, http.StatusNotFound, errors.New("bridge not found"))
 		return
 	}
@@ -100,7 +102,7 @@ func (btc *BridgeTypesController) Update(c *gin.Context) {
 	}
 
 	bt, err := btc.App.GetStore().FindBridge(taskType)
-	if errors.Cause(err) == orm.ErrorNotFound {
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, bt.Namespace)) {
 		jsonAPIError(c, http.StatusNotFound, errors.New("bridge not found"))
 		return
 	}
@@ -121,6 +123,9 @@ func (btc *BridgeTypesController) Update(c *gin.Context) {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
+	// The bridge is tried again at once, as the update may have fixed what
+	// it was failing with
+	adapters.ResetBridgeCircuit(bt.Name.String())
 
 	jsonAPIResponse(c, bt, "bridge")
 }
@@ -136,7 +141,7 @@ func (btc *BridgeTypesController) Destroy(c *gin.Context) {
 	}
 
 	bt, err := btc.App.GetStore().FindBridge(taskType)
-	if errors.Cause(err) == orm.ErrorNotFound {
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, bt.Namespace)) {
 		jsonAPIError(c, http.StatusNotFound, errors.New("bridge not found"))
 		return
 	}
@@ -29,7 +29,8 @@
 //
 // JobSpecErrorsController
 //
-// JobSpecErrorsController allows for errors to be dismissed
+// JobSpecErrorsController lists the errors of a job, and allows for
+// them to be acknowledged or dismissed
 //
 // Router
 //
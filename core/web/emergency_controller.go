@@ -0,0 +1,105 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// EmergencyController halts all on-chain writes of the node during contract
+// incidents. Runs keep executing while transmissions are halted, but no new
+// transaction is broadcast and OCR reports are not transmitted until an
+// operator resumes them, with their password and a reason.
+type EmergencyController struct {
+	App chainlink.Application
+}
+
+// StopTransmissions halts transmissions node-wide at once. Halting
+// transmissions which are already halted returns the active halt.
+// Example:
+//  "<application>/emergency/stop-transmissions"
+func (ec *EmergencyController) StopTransmissions(c *gin.Context) {
+	var request models.TransmissionHaltRequest
+	if err := c.ShouldBindJSON(&request); err != nil && c.Request.ContentLength > 0 {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	store := ec.App.GetStore()
+	active, err := store.ActiveTransmissionHalt()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	} else if active != nil {
+		jsonAPIResponse(c, *active, "transmission_halt")
+		return
+	}
+
+	halt, err := store.HaltTransmissions(request.Reason, auditUser(c))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, errors.Wrap(err, "while halting transmissions"))
+		return
+	}
+	logger.Warnw("Transmissions halted", "id", halt.ID, "haltedBy", halt.HaltedBy, "reason", halt.Reason)
+	jsonAPIResponseWithStatus(c, halt, "transmission_halt", http.StatusCreated)
+}
+
+// ResumeTransmissions resumes halted transmissions, sending the transactions
+// which were created while they were halted.
+// Example:
+//  "<application>/emergency/resume-transmissions"
+func (ec *EmergencyController) ResumeTransmissions(c *gin.Context) {
+	var request models.TransmissionResumeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if request.Reason == "" {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("a reason is required to resume transmissions"))
+		return
+	}
+	store := ec.App.GetStore()
+	user, err := store.FindUser()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, fmt.Errorf("failed to obtain current user record: %+v", err))
+		return
+	}
+	if !utils.CheckPasswordHash(request.Password, user.HashedPassword) {
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("incorrect password"))
+		return
+	}
+
+	halt, err := store.ResumeTransmissions(request.Reason, auditUser(c))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusConflict, errors.New("transmissions are not halted"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Warnw("Transmissions resumed", "id", halt.ID, "resumedBy", halt.ResumedBy.String, "reason", halt.ResumeReason.String)
+	if store.NotifyNewEthTx != nil {
+		store.NotifyNewEthTx.Trigger()
+	}
+	jsonAPIResponse(c, halt, "transmission_halt")
+}
+
+// Index lists every halt of transmissions, most recent first.
+// Example:
+//  "<application>/emergency/transmission-halts"
+func (ec *EmergencyController) Index(c *gin.Context) {
+	halts, err := ec.App.GetStore().TransmissionHalts()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, halts, "transmission_halts")
+}
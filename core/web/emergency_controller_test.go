@@ -0,0 +1,76 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmergencyController_HaltAndResumeTransmissions(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/emergency/stop-transmissions", bytes.NewBufferString(`{"reason": "aggregator exploited"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	var halt models.TransmissionHalt
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &halt))
+	assert.Equal(t, "aggregator exploited", halt.Reason)
+	assert.Equal(t, cltest.APIEmail, halt.HaltedBy)
+	assert.True(t, halt.Active())
+
+	halted, err := app.Store.TransmissionsHalted()
+	require.NoError(t, err)
+	assert.True(t, halted)
+
+	// Halting again keeps the active halt
+	resp, cleanup = client.Post("/v2/emergency/stop-transmissions", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var again models.TransmissionHalt
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &again))
+	assert.Equal(t, halt.ID, again.ID)
+
+	resp, cleanup = client.Post("/v2/emergency/resume-transmissions", bytes.NewBufferString(`{"password": "wrong", "reason": "fixed"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnauthorized)
+
+	resp, cleanup = client.Post("/v2/emergency/resume-transmissions", bytes.NewBufferString(fmt.Sprintf(`{"password": "%s"}`, cltest.Password)))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	resp, cleanup = client.Post("/v2/emergency/resume-transmissions", bytes.NewBufferString(fmt.Sprintf(`{"password": "%s", "reason": "fixed"}`, cltest.Password)))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &halt))
+	assert.False(t, halt.Active())
+	assert.Equal(t, "fixed", halt.ResumeReason.String)
+	assert.Equal(t, cltest.APIEmail, halt.ResumedBy.String)
+
+	halted, err = app.Store.TransmissionsHalted()
+	require.NoError(t, err)
+	assert.False(t, halted)
+
+	resp, cleanup = client.Post("/v2/emergency/resume-transmissions", bytes.NewBufferString(fmt.Sprintf(`{"password": "%s", "reason": "fixed"}`, cltest.Password)))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Get("/v2/emergency/transmission-halts")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var halts []models.TransmissionHalt
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &halts))
+	require.Len(t, halts, 1)
+	assert.Equal(t, "fixed", halts[0].ResumeReason.String)
+}
@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/pkg/errors"
 )
@@ -43,10 +46,43 @@ func newNotifyHTTPRequest(jsn JobSpecNotice, ei models.ExternalInitiator) (*http
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set(ExternalInitiatorAccessKeyHeader, ei.OutgoingToken)
-	req.Header.Set(ExternalInitiatorSecretHeader, ei.OutgoingSecret)
+	if ei.ProtocolVersion >= models.ExternalInitiatorProtocolV2 {
+		// The secret is never sent, the external initiator checks the
+		// signature made with it instead
+		timestamp := time.Now().Unix()
+		nonce := utils.NewBytes32ID()
+		req.Header.Set(ExternalInitiatorTimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(ExternalInitiatorNonceHeader, nonce)
+		req.Header.Set(ExternalInitiatorSignatureHeader, models.ExternalInitiatorSignature(
+			ei.ActiveOutgoingSecret(), timestamp, nonce, req.Method, req.URL.RequestURI(), buf))
+	} else {
+		req.Header.Set(ExternalInitiatorSecretHeader, ei.ActiveOutgoingSecret())
+	}
 	return req, nil
 }
 
+// checkExternalInitiator returns an error if the External Initiator of the
+// Job Spec, if it has one, could not be notified of it, so that Job Specs
+// whose External Initiators are only notified once they are saved can be
+// refused beforehand.
+func checkExternalInitiator(js models.JobSpec, store *store.Store) error {
+	initrs := js.InitiatorsFor(models.InitiatorExternal)
+	if len(initrs) > 1 {
+		return errors.New("must have one or less External Initiators")
+	}
+	if len(initrs) == 0 {
+		return nil
+	}
+	ei, err := store.FindExternalInitiatorByName(initrs[0].Name)
+	if err != nil {
+		return errors.Wrap(err, "external initiator")
+	}
+	if ei.URL != nil && initrs[0].Body == nil {
+		return errors.New("external initiator body must be defined")
+	}
+	return nil
+}
+
 // NotifyExternalInitiator sends a POST notification to the External Initiator
 // responsible for initiating the Job Spec.
 func NotifyExternalInitiator(
@@ -39,6 +39,7 @@ func (eic *ExternalInitiatorsController) Create(c *gin.Context) {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
+	ei.Namespace = requestNamespace(c)
 
 	if err := services.ValidateExternalInitiator(eir, eic.App.GetStore()); err != nil {
 		jsonAPIError(c, http.StatusBadRequest, err)
@@ -73,3 +74,47 @@ func (eic *ExternalInitiatorsController) Destroy(c *gin.Context) {
 
 	jsonAPIResponseWithStatus(c, nil, "external initiator", http.StatusNoContent)
 }
+
+// RotateKeys replaces the signing key the external initiator signs its
+// requests with and the secret the node authenticates its notifications
+// with, returning the new ones. For the overlap given, an hour by default,
+// requests signed with the previous key are still accepted and
+// notifications are still signed with the previous secret, while the
+// external initiator switches over.
+// Example:
+//  "<application>/external_initiators/:Name/keys"
+func (eic *ExternalInitiatorsController) RotateKeys(c *gin.Context) {
+	var request models.ExternalInitiatorKeyRotationRequest
+	if err := c.ShouldBindJSON(&request); err != nil && c.Request.ContentLength > 0 {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	overlap := models.DefaultExternalInitiatorKeyOverlap
+	if request.Overlap != nil {
+		overlap = request.Overlap.Duration()
+	}
+
+	store := eic.App.GetStore()
+	ei, err := store.FindExternalInitiatorByName(c.Param("Name"))
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, ei.Namespace)) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("external initiator not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if ei.ProtocolVersion < models.ExternalInitiatorProtocolV2 {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("only the keys of external initiators speaking version 2 of the protocol can be rotated"))
+		return
+	}
+
+	ei.RotateKeys(overlap)
+	if err := store.SaveExternalInitiator(&ei); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := presenters.NewExternalInitiatorAuthentication(ei, auth.Token{})
+	jsonAPIResponse(c, resp, "external initiator authentication")
+}
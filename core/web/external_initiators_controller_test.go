@@ -81,6 +81,46 @@ func TestExternalInitiatorsController_Create_invalid(t *testing.T) {
 	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
 }
 
+func TestExternalInitiatorsController_RotateKeys(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/external_initiators", bytes.NewBufferString(`{"name":"bitcoin","protocolVersion":2}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	created := &presenters.ExternalInitiatorAuthentication{}
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, created))
+	assert.Equal(t, int32(models.ExternalInitiatorProtocolV2), created.ProtocolVersion)
+	assert.NotEmpty(t, created.IncomingSigningKey)
+
+	resp, cleanup = client.Post("/v2/external_initiators/bitcoin/keys", bytes.NewBufferString(`{"overlap":"10m"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	rotated := &presenters.ExternalInitiatorAuthentication{}
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, rotated))
+	assert.NotEqual(t, created.IncomingSigningKey, rotated.IncomingSigningKey)
+	assert.NotEqual(t, created.OutgoingSecret, rotated.OutgoingSecret)
+	assert.Empty(t, rotated.Secret)
+
+	ei, err := app.Store.FindExternalInitiatorByName("bitcoin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{rotated.IncomingSigningKey, created.IncomingSigningKey}, ei.IncomingSigningKeys())
+
+	resp, cleanup = client.Post("/v2/external_initiators", bytes.NewBufferString(`{"name":"litecoin"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	resp, cleanup = client.Post("/v2/external_initiators/litecoin/keys", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+	resp, cleanup = client.Post("/v2/external_initiators/dogecoin/keys", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
 func TestExternalInitiatorsController_Delete(t *testing.T) {
 	t.Parallel()
 
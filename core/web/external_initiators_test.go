@@ -3,6 +3,7 @@ package web_test
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/auth"
@@ -125,6 +126,50 @@ func TestNotifyExternalInitiator_Notified(t *testing.T) {
 	}
 }
 
+func TestNotifyExternalInitiator_SignedV2(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	var header http.Header
+	var body string
+	eiMockServer, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", "",
+		func(h http.Header, b string) {
+			header, body = h, b
+		},
+	)
+	defer assertCalled()
+
+	url := cltest.WebURL(t, eiMockServer.URL)
+	ei, err := models.NewExternalInitiator(auth.NewToken(), &models.ExternalInitiatorRequest{
+		Name:            "somecoin",
+		URL:             &url,
+		ProtocolVersion: models.ExternalInitiatorProtocolV2,
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.CreateExternalInitiator(ei))
+	job := models.JobSpec{
+		ID: models.NewID(),
+		Initiators: []models.Initiator{{
+			Type: models.InitiatorExternal,
+			InitiatorParams: models.InitiatorParams{
+				Name: "somecoin",
+				Body: JSONFromString(t, `{"foo":"bar"}`),
+			},
+		}},
+	}
+	require.NoError(t, store.CreateJob(&job))
+
+	require.NoError(t, web.NotifyExternalInitiator(job, store))
+	assert.Equal(t, ei.OutgoingToken, header.Get(web.ExternalInitiatorAccessKeyHeader))
+	assert.Empty(t, header.Get(web.ExternalInitiatorSecretHeader), "the secret is never sent")
+	timestamp, err := strconv.ParseInt(header.Get(web.ExternalInitiatorTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t,
+		models.ExternalInitiatorSignature(ei.OutgoingSecret, timestamp, header.Get(web.ExternalInitiatorNonceHeader), http.MethodPost, "/", []byte(body)),
+		header.Get(web.ExternalInitiatorSignatureHeader),
+	)
+}
+
 func TestNotifyExternalInitiator_NotNotified(t *testing.T) {
 	tests := []struct {
 		Name    string
@@ -0,0 +1,28 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FleetBundleController serves the bundle which other nodes of a fleet sync
+// to, when this node is the fleet's source of truth.
+type FleetBundleController struct {
+	App chainlink.Application
+}
+
+// Show returns the bridges, external initiators and jobs of the requester's
+// namespace as plain JSON, with their credentials.
+// Example:
+//  "<application>/fleet_bundle"
+func (fbc *FleetBundleController) Show(c *gin.Context) {
+	bundle, err := fbc.App.GetStore().FleetBundle(requestNamespace(c))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, bundle)
+}
@@ -0,0 +1,43 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleetBundleController_Show(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	_, bt := cltest.NewBridgeType(t, "fleetbridge", "http://bridge.example.com")
+	require.NoError(t, app.Store.CreateBridgeType(bt))
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+	archived := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&archived))
+	require.NoError(t, app.Store.ArchiveJob(archived.ID))
+
+	resp, cleanup := client.Get("/v2/fleet_bundle")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var bundle models.FleetBundle
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &bundle))
+	require.Len(t, bundle.Bridges, 1)
+	assert.Equal(t, bt.Name, bundle.Bridges[0].Name)
+	assert.Equal(t, bt.IncomingTokenHash, bundle.Bridges[0].IncomingTokenHash)
+	require.Len(t, bundle.Jobs, 1)
+	assert.Equal(t, job.ID, bundle.Jobs[0].ID)
+	assert.Empty(t, bundle.ExternalInitiators)
+}
@@ -0,0 +1,200 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	null "gopkg.in/guregu/null.v3"
+)
+
+// FundsSweepsController moves the funds of retired or compromised accounts to
+// the configured treasury address. A sweep must be requested and then
+// approved separately, with the user's password, before any transaction is
+// created.
+type FundsSweepsController struct {
+	App chainlink.Application
+}
+
+// Index lists every funds sweep, most recent first.
+// Example:
+//  "<application>/funds_sweeps"
+func (fsc *FundsSweepsController) Index(c *gin.Context) {
+	sweeps, err := fsc.App.GetStore().FundsSweeps()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, sweeps, "funds_sweeps")
+}
+
+// Create requests a sweep of all of an account's ETH and LINK to the treasury.
+// Example:
+//  "<application>/funds_sweeps"
+func (fsc *FundsSweepsController) Create(c *gin.Context) {
+	var request models.FundsSweepRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	store := fsc.App.GetStore()
+	treasury := store.Config.TreasuryAddress()
+	if treasury == utils.ZeroAddress {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("TREASURY_ADDRESS is not configured"))
+		return
+	}
+	if !store.Config.EnableBulletproofTxManager() {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("funds sweeps require ENABLE_BULLETPROOF_TX_MANAGER"))
+		return
+	}
+	if _, err := store.KeyStore.GetAccountByAddress(request.Address); err != nil {
+		jsonAPIError(c, http.StatusNotFound, fmt.Errorf("no account %s in the keystore", request.Address.Hex()))
+		return
+	}
+	if request.Address == treasury {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("cannot sweep the treasury account"))
+		return
+	}
+
+	sweep := models.FundsSweep{
+		FromAddress: request.Address,
+		ToAddress:   treasury,
+		Status:      models.FundsSweepStatusPending,
+		Reason:      request.Reason,
+		RequestedBy: auditUser(c),
+	}
+	if err := store.CreateFundsSweep(&sweep); err != nil {
+		jsonAPIError(c, http.StatusConflict, errors.Wrap(err, "while requesting funds sweep"))
+		return
+	}
+	logger.Infow("Funds sweep requested", "id", sweep.ID, "from", sweep.FromAddress.Hex(), "to", sweep.ToAddress.Hex(), "requestedBy", sweep.RequestedBy)
+
+	jsonAPIResponseWithStatus(c, sweep, "funds_sweep", http.StatusCreated)
+}
+
+// Approve creates the transactions for a pending sweep, once
+// FUNDS_SWEEP_APPROVAL_DELAY has passed since it was requested.
+// Example:
+//  "<application>/funds_sweeps/:SweepID/approve"
+func (fsc *FundsSweepsController) Approve(c *gin.Context) {
+	var request models.FundsSweepReviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	store := fsc.App.GetStore()
+	user, err := store.FindUser()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, fmt.Errorf("failed to obtain current user record: %+v", err))
+		return
+	}
+	if !utils.CheckPasswordHash(request.Password, user.HashedPassword) {
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("incorrect password"))
+		return
+	}
+
+	sweep, ok := fsc.findPendingSweep(c)
+	if !ok {
+		return
+	}
+	delay := store.Config.FundsSweepApprovalDelay().Duration()
+	if wait := time.Until(sweep.CreatedAt.Add(delay)); wait > 0 {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("funds sweep cannot be approved for another %s", wait.Round(time.Second)))
+		return
+	}
+	if sweep.ToAddress != store.Config.TreasuryAddress() {
+		jsonAPIError(c, http.StatusConflict, errors.New("TREASURY_ADDRESS has changed since the funds sweep was requested"))
+		return
+	}
+
+	sweep.Status = models.FundsSweepStatusExecuted
+	sweep.ReviewedBy = null.StringFrom(auditUser(c))
+	sweep.ReviewedAt = null.TimeFrom(time.Now())
+	_, _, err = bulletprooftxmanager.SweepFunds(store, sweep.FromAddress, sweep.ToAddress, func(tx *gorm.DB, ethTransfer, linkTransfer *models.EthTx) error {
+		if ethTransfer != nil {
+			sweep.EthTransferEthTxID = null.IntFrom(ethTransfer.ID)
+		}
+		if linkTransfer != nil {
+			sweep.LinkTransferEthTxID = null.IntFrom(linkTransfer.ID)
+		}
+		return orm.ReviewFundsSweep(tx, &sweep)
+	})
+	if errors.Cause(err) == orm.ErrOptimisticUpdateConflict {
+		jsonAPIError(c, http.StatusConflict, errors.New("funds sweep has already been reviewed"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, fmt.Errorf("funds sweep failed: %v", err))
+		return
+	}
+	fsc.reviewed(c, sweep)
+}
+
+// Reject closes a pending sweep without creating any transactions.
+// Example:
+//  "<application>/funds_sweeps/:SweepID/reject"
+func (fsc *FundsSweepsController) Reject(c *gin.Context) {
+	sweep, ok := fsc.findPendingSweep(c)
+	if !ok {
+		return
+	}
+	sweep.Status = models.FundsSweepStatusRejected
+	sweep.ReviewedBy = null.StringFrom(auditUser(c))
+	sweep.ReviewedAt = null.TimeFrom(time.Now())
+	err := fsc.App.GetStore().ReviewFundsSweep(&sweep)
+	if errors.Cause(err) == orm.ErrOptimisticUpdateConflict {
+		jsonAPIError(c, http.StatusConflict, errors.New("funds sweep has already been reviewed"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	fsc.reviewed(c, sweep)
+}
+
+func (fsc *FundsSweepsController) findPendingSweep(c *gin.Context) (models.FundsSweep, bool) {
+	id, err := strconv.ParseInt(c.Param("SweepID"), 10, 64)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return models.FundsSweep{}, false
+	}
+	sweep, err := fsc.App.GetStore().FindFundsSweep(id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("funds sweep not found"))
+		return sweep, false
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return sweep, false
+	}
+	if sweep.Status != models.FundsSweepStatusPending {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("funds sweep is already %s", sweep.Status))
+		return sweep, false
+	}
+	return sweep, true
+}
+
+func (fsc *FundsSweepsController) reviewed(c *gin.Context, sweep models.FundsSweep) {
+	logger.Infow("Funds sweep reviewed", "id", sweep.ID, "status", sweep.Status, "reviewedBy", sweep.ReviewedBy.String,
+		"ethTransferEthTxID", sweep.EthTransferEthTxID.Int64, "linkTransferEthTxID", sweep.LinkTransferEthTxID.Int64)
+	jsonAPIResponse(c, sweep, "funds_sweep")
+}
+
+// auditUser returns the email of the user making the request, which is
+// recorded by the reviews and emergency actions kept as audit records.
+func auditUser(c *gin.Context) string {
+	if user, ok := authenticatedUser(c); ok {
+		return user.Email
+	}
+	return "unknown"
+}
@@ -0,0 +1,162 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFundsSweepsApp(t *testing.T, approvalDelay string) (*cltest.TestApplication, common.Address, func()) {
+	config, _ := cltest.NewConfig(t)
+	treasury := cltest.NewAddress()
+	config.Set("ENABLE_BULLETPROOF_TX_MANAGER", true)
+	config.Set("TREASURY_ADDRESS", treasury.Hex())
+	config.Set("FUNDS_SWEEP_APPROVAL_DELAY", approvalDelay)
+	app, cleanup := cltest.NewApplicationWithConfigAndKey(t, config, cltest.LenientEthMock)
+	require.NoError(t, app.Start())
+	return app, treasury, cleanup
+}
+
+func requestFundsSweep(t *testing.T, client cltest.HTTPClientCleaner, address common.Address) (*http.Response, func()) {
+	body, err := json.Marshal(models.FundsSweepRequest{Address: address, Reason: "retired"})
+	require.NoError(t, err)
+	return client.Post("/v2/funds_sweeps", bytes.NewBuffer(body))
+}
+
+func TestFundsSweepsController_ApproveLifecycle(t *testing.T) {
+	t.Parallel()
+
+	app, treasury, cleanup := setupFundsSweepsApp(t, "0s")
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	account, err := app.Store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+
+	resp, cleanup := requestFundsSweep(t, client, account.Address)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	var sweep models.FundsSweep
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &sweep))
+	assert.Equal(t, models.FundsSweepStatusPending, sweep.Status)
+	assert.Equal(t, treasury, sweep.ToAddress)
+	assert.Equal(t, cltest.APIEmail, sweep.RequestedBy)
+
+	// Only one pending sweep per account
+	resp, cleanup = requestFundsSweep(t, client, account.Address)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	approveURL := fmt.Sprintf("/v2/funds_sweeps/%d/approve", sweep.ID)
+	resp, cleanup = client.Post(approveURL, bytes.NewBufferString(`{"password": "wrong"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnauthorized)
+
+	oneEth := big.NewInt(1000000000000000000)
+	app.EthMock.Register("eth_call", "0x100")
+	app.EthMock.Register("eth_getBalance", "0x"+oneEth.Text(16))
+
+	resp, cleanup = client.Post(approveURL, bytes.NewBufferString(fmt.Sprintf(`{"password": "%s"}`, cltest.Password)))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &sweep))
+	assert.Equal(t, models.FundsSweepStatusExecuted, sweep.Status)
+	assert.Equal(t, cltest.APIEmail, sweep.ReviewedBy.String)
+	require.True(t, sweep.LinkTransferEthTxID.Valid)
+	require.True(t, sweep.EthTransferEthTxID.Valid)
+
+	var linkTransfer, ethTransfer models.EthTx
+	require.NoError(t, app.Store.DB.First(&linkTransfer, sweep.LinkTransferEthTxID.Int64).Error)
+	require.NoError(t, app.Store.DB.First(&ethTransfer, sweep.EthTransferEthTxID.Int64).Error)
+	assert.Equal(t, common.HexToAddress(app.Store.Config.LinkContractAddress()), linkTransfer.ToAddress)
+	assert.Equal(t, account.Address, linkTransfer.FromAddress)
+	assert.Equal(t, treasury, ethTransfer.ToAddress)
+
+	gasPrice := app.Store.Config.EthGasPriceDefault()
+	gas := new(big.Int).SetUint64(ethTransfer.GasLimit + linkTransfer.GasLimit)
+	expected := new(big.Int).Sub(oneEth, new(big.Int).Mul(gasPrice, gas))
+	assert.Equal(t, expected.String(), ethTransfer.Value.ToInt().String())
+
+	resp, cleanup = client.Post(fmt.Sprintf("/v2/funds_sweeps/%d/reject", sweep.ID), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Get("/v2/funds_sweeps")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var sweeps []models.FundsSweep
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &sweeps))
+	require.Len(t, sweeps, 1)
+	assert.Equal(t, models.FundsSweepStatusExecuted, sweeps[0].Status)
+}
+
+func TestFundsSweepsController_Reject(t *testing.T) {
+	t.Parallel()
+
+	app, _, cleanup := setupFundsSweepsApp(t, "1h")
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	account, err := app.Store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+
+	resp, cleanup := requestFundsSweep(t, client, account.Address)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	var sweep models.FundsSweep
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &sweep))
+
+	resp, cleanup = client.Post(fmt.Sprintf("/v2/funds_sweeps/%d/approve", sweep.ID), bytes.NewBufferString(fmt.Sprintf(`{"password": "%s"}`, cltest.Password)))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Post(fmt.Sprintf("/v2/funds_sweeps/%d/reject", sweep.ID), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &sweep))
+	assert.Equal(t, models.FundsSweepStatusRejected, sweep.Status)
+	assert.False(t, sweep.EthTransferEthTxID.Valid)
+
+	count, err := app.Store.ORM.CountOf(&models.EthTx{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	resp, cleanup = client.Post("/v2/funds_sweeps/9999/reject", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
+func TestFundsSweepsController_Create_Errors(t *testing.T) {
+	t.Parallel()
+
+	app, treasury, cleanup := setupFundsSweepsApp(t, "0s")
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, cleanup := requestFundsSweep(t, client, cltest.NewAddress())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = requestFundsSweep(t, client, treasury)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	noTreasuryApp, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, noTreasuryApp.Start())
+	account, err := noTreasuryApp.Store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+	resp, cleanup = requestFundsSweep(t, noTreasuryApp.NewHTTPClient(), account.Address)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+}
@@ -29,6 +29,8 @@ func jsonAPIError(c *gin.Context, statusCode int, err error) {
 	switch v := err.(type) {
 	case *models.JSONAPIErrors:
 		c.JSON(statusCode, v)
+	case *models.JobSpecSyntaxError:
+		c.JSON(statusCode, models.JSONAPIErrors{Errors: []models.JSONAPIError{v.JSONAPIError()}})
 	default:
 		c.JSON(statusCode, models.NewJSONAPIErrorsWith(err.Error()))
 	}
@@ -56,6 +58,43 @@ func paginatedResponse(
 	}
 }
 
+func cursorPaginatedResponse(
+	c *gin.Context,
+	name string,
+	size int,
+	resource interface{},
+	next *orm.Cursor,
+	err error,
+) {
+	var nextCursor string
+	if next != nil {
+		nextCursor = next.String()
+	}
+
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, fmt.Errorf("error getting paged %s: %+v", name, err))
+	} else if buffer, err := NewCursorPaginatedResponse(*c.Request.URL, size, nextCursor, resource); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, fmt.Errorf("failed to marshal document: %+v", err))
+	} else {
+		c.Data(http.StatusOK, MediaType, buffer)
+	}
+}
+
+// requestCursor returns whether the request asks for cursor pagination, by
+// passing a cursor param, and the cursor it passes, which is nil for the
+// first page.
+func requestCursor(c *gin.Context) (*orm.Cursor, bool, error) {
+	param, ok := c.GetQuery("cursor")
+	if !ok || param == "" {
+		return nil, ok, nil
+	}
+	cursor, err := orm.ParseCursor(param)
+	if err != nil {
+		return nil, true, err
+	}
+	return &cursor, true, nil
+}
+
 func paginatedRequest(action func(*gin.Context, int, int, int)) func(*gin.Context) {
 	return func(c *gin.Context) {
 		size, page, offset, err := ParsePaginatedRequest(c.Query("size"), c.Query("page"))
@@ -0,0 +1,114 @@
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// IdentityController returns the node's public identities.
+type IdentityController struct {
+	App chainlink.Application
+}
+
+// Show returns the node's account addresses, OCR and P2P public keys and TLS
+// certificate fingerprint, signed by the node's first account, along with
+// the payload signed.
+// Example:
+//  "<application>/identity"
+func (ic *IdentityController) Show(c *gin.Context) {
+	identity, err := nodeIdentity(ic.App.GetStore())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, identity, "identity")
+}
+
+func nodeIdentity(store *store.Store) (*presenters.NodeIdentity, error) {
+	var identity presenters.Identity
+	for _, account := range store.KeyStore.GetAccounts() {
+		identity.AccountAddresses = append(identity.AccountAddresses, account.Address)
+	}
+
+	ocrKeys, err := store.FindEncryptedOCRKeyBundles()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading OCR key bundles")
+	}
+	for _, k := range ocrKeys {
+		identity.OCRKeyBundles = append(identity.OCRKeyBundles, presenters.OCRKeyIdentity{
+			ID:                    k.ID,
+			OnChainSigningAddress: common.Address(k.OnChainSigningAddress),
+			OffChainPublicKey:     []byte(k.OffChainPublicKey),
+		})
+	}
+
+	p2pKeys, err := store.FindEncryptedP2PKeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading P2P keys")
+	}
+	for _, k := range p2pKeys {
+		identity.P2PKeys = append(identity.P2PKeys, presenters.P2PKeyIdentity{
+			PeerID:    k.PeerID,
+			PublicKey: k.PubKey,
+		})
+	}
+
+	if certPath := store.Config.TLSCertPath(); certPath != "" {
+		identity.TLSCertFingerprint, err = certFingerprint(certPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	account, err := store.KeyStore.GetFirstAccount()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := json.Marshal(identity)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := utils.Keccak256(msg)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := store.KeyStore.SignHashWithAccount(account, common.BytesToHash(hash))
+	if err != nil {
+		return nil, errors.Wrap(err, "while signing node identity")
+	}
+
+	return &presenters.NodeIdentity{
+		Identity:      identity,
+		SignedPayload: string(msg),
+		Signer:        account.Address,
+		Signature:     signature,
+	}, nil
+}
+
+// certFingerprint returns the hex encoded SHA-256 digest of the DER encoding
+// of the first certificate in the PEM file at path.
+func certFingerprint(path string) (string, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "while reading TLS certificate")
+	}
+	block, _ := pem.Decode(contents)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", errors.Errorf("no certificate found in %s", path)
+	}
+	digest := sha256.Sum256(block.Bytes)
+	return hex.EncodeToString(digest[:]), nil
+}
@@ -0,0 +1,86 @@
+package web_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityController_Show(t *testing.T) {
+	t.Parallel()
+
+	config := cltest.NewTestConfig(t)
+	certDER := writeSelfSignedCert(t, filepath.Join(config.RootDir(), "server.crt"))
+	config.Set("TLS_CERT_PATH", filepath.Join(config.RootDir(), "server.crt"))
+
+	app, cleanup := cltest.NewApplicationWithConfigAndKey(t, config, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	resp, cleanup := client.Get("/v2/identity")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var identity presenters.NodeIdentity
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &identity))
+
+	account, err := app.Store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, identity.Signer)
+	assert.Contains(t, identity.AccountAddresses, account.Address)
+
+	digest := sha256.Sum256(certDER)
+	assert.Equal(t, hex.EncodeToString(digest[:]), identity.TLSCertFingerprint)
+
+	var signed presenters.Identity
+	require.NoError(t, json.Unmarshal([]byte(identity.SignedPayload), &signed))
+	assert.Equal(t, identity.Identity, signed)
+
+	hash, err := utils.Keccak256([]byte(identity.SignedPayload))
+	require.NoError(t, err)
+	prefixed, err := utils.Keccak256(append([]byte(strpkg.EthereumMessageHashPrefix), hash...))
+	require.NoError(t, err)
+	pubKey, err := crypto.SigToPub(prefixed, identity.Signature.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, identity.Signer, crypto.PubkeyToAddress(*pubKey))
+}
+
+func writeSelfSignedCert(t *testing.T, path string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	contents := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, ioutil.WriteFile(path, contents, 0600))
+	return der
+}
@@ -0,0 +1,140 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// JobExportsController moves jobs, along with their state, between nodes.
+type JobExportsController struct {
+	App chainlink.Application
+}
+
+// Show returns the export of a job and its state, as plain JSON which can be
+// saved and imported on another node. Passing format=spec returns only the
+// job's canonical spec instead, which can be kept in version control.
+// Example:
+//  "<application>/specs/:SpecID/export"
+//  "<application>/specs/:SpecID/export?format=spec"
+func (jec *JobExportsController) Show(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	format := c.DefaultQuery("format", "export")
+	if format != "export" && format != "spec" {
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid format %q, must be export or spec", format))
+		return
+	}
+
+	store := jec.App.GetStore()
+	job, err := store.FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, job.Namespace)) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if format == "spec" {
+		spec, err := models.CanonicalJobSpec(job)
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Data(http.StatusOK, "application/json", spec)
+		return
+	}
+
+	logs, rounds, err := store.JobExportState(job)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.NewJobExport(job, logs, rounds))
+}
+
+// Create imports a job exported from another node, keeping its ID, and
+// records its state before starting it. Passing start=false imports the job
+// without starting it.
+// Example:
+//  "<application>/job_imports"
+//  "<application>/job_imports?start=false"
+func (jec *JobExportsController) Create(c *gin.Context) {
+	start, err := strconv.ParseBool(c.DefaultQuery("start", "true"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid start parameter"))
+		return
+	}
+
+	var export models.JobExport
+	if err := c.ShouldBindJSON(&export); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := export.Validate(); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := jec.App.GetStore()
+	if _, err := store.Unscoped().FindJob(export.ID); err == nil {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("job %s already exists on this node", export.ID))
+		return
+	} else if errors.Cause(err) != orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	js := export.JobSpec()
+	js.Namespace = requestNamespace(c)
+	jsc := JobSpecsController{jec.App}
+	js, httpStatus, err := jsc.checkJobSpec(js)
+	if err != nil {
+		jsonAPIError(c, httpStatus, err)
+		return
+	}
+	if err := store.CheckJobQuota(js.Namespace); orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The job is saved stopped, along with its consumed logs and rounds in
+	// the same transaction, so that its initiators only start once those are
+	// known and a failed import leaves nothing behind
+	js.Status = models.JobSpecStatusStopped
+	if err := NotifyExternalInitiator(js, store); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if err := store.CreateImportedJob(&js, export); orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if start {
+		if err := jec.App.StartJob(js.ID); err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		js.Status = models.JobSpecStatusActive
+	}
+
+	jsonAPIResponse(c, presenters.JobSpec{JobSpec: js}, "job")
+}
@@ -0,0 +1,153 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobExportsController_ExportAndImport(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks[0].Name = "first"
+	require.NoError(t, app.Store.CreateJob(&job))
+	blockHash := cltest.NewHash()
+	require.NoError(t, app.Store.MarkLogConsumed(blockHash, 1, job.ID, 10))
+	require.NoError(t, app.Store.MarkLogConsumed(blockHash, 2, job.ID, 12))
+
+	resp, cleanup := client.Get("/v2/specs/" + job.ID.String() + "/export")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	body := cltest.ParseResponseBody(t, resp)
+
+	var export models.JobExport
+	require.NoError(t, json.Unmarshal(body, &export))
+	assert.Equal(t, models.JobExportVersion, export.Version)
+	assert.Equal(t, job.ID, export.ID)
+	assert.Equal(t, uint64(12), export.LastProcessedBlock)
+	require.Len(t, export.LogConsumptions, 2)
+	require.Len(t, export.Job.Tasks, 1)
+	assert.Equal(t, "first", export.Job.Tasks[0].Name)
+
+	// The job already exists here
+	resp, cleanup = client.Post("/v2/job_imports", bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	// Remove the job as if it had only ever existed on another node
+	require.NoError(t, app.Store.DB.Exec("DELETE FROM initiators WHERE job_spec_id = ?", job.ID).Error)
+	require.NoError(t, app.Store.DB.Exec("DELETE FROM job_specs WHERE id = ?", job.ID).Error)
+
+	resp, cleanup = client.Post("/v2/job_imports", bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	imported, err := app.Store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobSpecStatusActive, imported.Status)
+	assert.Equal(t, "first", imported.Tasks[0].Name)
+	count, err := app.Store.CountOf(&models.LogConsumption{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	consumed, err := app.Store.HasConsumedLog(blockHash, 2, job.ID)
+	require.NoError(t, err)
+	assert.True(t, consumed)
+}
+
+func TestJobExportsController_ExportAndImport_WebhookSecret(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	externalJobID := uuid.NewV4()
+	job := cltest.NewJob()
+	job.ExternalJobID = &externalJobID
+	job.Initiators = []models.Initiator{{
+		Type:            models.InitiatorWebhook,
+		InitiatorParams: models.InitiatorParams{WebhookSecret: "0123456789abcdef"},
+	}}
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "noop")}
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	resp, cleanup := client.Get("/v2/specs/" + job.ID.String() + "/export")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	body := cltest.ParseResponseBody(t, resp)
+
+	require.NoError(t, app.Store.DB.Exec("DELETE FROM initiators WHERE job_spec_id = ?", job.ID).Error)
+	require.NoError(t, app.Store.DB.Exec("DELETE FROM job_specs WHERE id = ?", job.ID).Error)
+	resp, cleanup = client.Post("/v2/job_imports", bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	// The imported job accepts the requests its callers already sign
+	imported, err := app.Store.FindJob(job.ID)
+	require.NoError(t, err)
+	require.Len(t, imported.Initiators, 1)
+	assert.Equal(t, "0123456789abcdef", imported.Initiators[0].WebhookSecret)
+}
+
+func TestJobExportsController_Show_SpecFormat(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks[0].Name = "first"
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	resp, cleanup := client.Get("/v2/specs/" + job.ID.String() + "/export?format=spec")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	body := cltest.ParseResponseBody(t, resp)
+
+	var spec models.JobSpecRequest
+	require.NoError(t, json.Unmarshal(body, &spec))
+	require.Len(t, spec.Tasks, 1)
+	assert.Equal(t, "first", spec.Tasks[0].Name)
+	assert.Less(t, bytes.Index(body, []byte(`"initiators"`)), bytes.Index(body, []byte(`"tasks"`)), "keys should be sorted")
+	assert.NotContains(t, string(body), "logConsumptions")
+
+	// The canonical spec can be submitted as it is
+	resp, cleanup = client.Post("/v2/specs", bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	resp, cleanup = client.Get("/v2/specs/" + job.ID.String() + "/export?format=toml")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
+func TestJobExportsController_Create_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	body := `{"version": 99, "id": "` + models.NewID().String() + `", "job": {"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]}}`
+	resp, cleanup := client.Post("/v2/job_imports", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
@@ -1,11 +1,20 @@
 package web
 
 import (
+	"crypto/hmac"
+	"encoding/csv"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
@@ -13,6 +22,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/ulule/limiter"
+	"github.com/ulule/limiter/drivers/store/memory"
 )
 
 // JobRunsController manages JobRun requests in the node.
@@ -20,9 +32,12 @@ type JobRunsController struct {
 	App chainlink.Application
 }
 
-// Index returns paginated JobRuns for a given JobSpec
+// Index returns paginated JobRuns for a given JobSpec. Passing a cursor param,
+// empty for the first page, pages by the nextCursor of the previous page
+// instead of by page number.
 // Example:
 //  "<application>/runs?jobSpecId=:jobSpecId&size=1&page=2"
+//  "<application>/runs?jobSpecId=:jobSpecId&size=100&cursor=:nextCursor"
 func (jrc *JobRunsController) Index(c *gin.Context, size, page, offset int) {
 	id := c.Query("jobSpecId")
 
@@ -32,28 +47,47 @@ func (jrc *JobRunsController) Index(c *gin.Context, size, page, offset int) {
 	}
 
 	store := jrc.App.GetStore()
-	var runs []models.JobRun
-	var count int
-	var err error
-	if id == "" {
-		runs, count, err = store.JobRunsSorted(order, offset, size)
-	} else {
-		var runID *models.ID
-		runID, err = models.NewIDFromString(id)
+	var jobSpecID *models.ID
+	if id != "" {
+		var err error
+		jobSpecID, err = models.NewIDFromString(id)
 		if err != nil {
 			jsonAPIError(c, http.StatusUnprocessableEntity, err)
 			return
 		}
+		jsc := JobSpecsController{jrc.App}
+		if !jsc.findJobInNamespace(c, jobSpecID) {
+			return
+		}
+	}
 
-		runs, count, err = store.JobRunsSortedFor(runID, order, offset, size)
+	cursor, keyset, err := requestCursor(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if keyset {
+		runs, next, err := store.JobRunsAfter(requestNamespace(c), jobSpecID, order, cursor, size)
+		cursorPaginatedResponse(c, "JobRuns", size, runs, next, err)
+		return
+	}
+
+	var runs []models.JobRun
+	var count int
+	if jobSpecID == nil {
+		runs, count, err = store.JobRunsSorted(requestNamespace(c), order, offset, size)
+	} else {
+		runs, count, err = store.JobRunsSortedFor(jobSpecID, order, offset, size)
 	}
 
 	paginatedResponse(c, "JobRuns", size, page, runs, count, err)
 }
 
-// Create starts a new Run for the requested JobSpec.
+// Create starts a new Run for the requested JobSpec. Passing profile=true
+// has the run's executions profiled, see Profile.
 // Example:
 //  "<application>/specs/:SpecID/runs"
+//  "<application>/specs/:SpecID/runs?profile=true"
 func (jrc *JobRunsController) Create(c *gin.Context) {
 	id, err := models.NewIDFromString(c.Param("SpecID"))
 	if err != nil {
@@ -62,7 +96,7 @@ func (jrc *JobRunsController) Create(c *gin.Context) {
 	}
 
 	j, err := jrc.App.GetStore().FindJob(id)
-	if errors.Cause(err) == orm.ErrorNotFound {
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, j.Namespace)) {
 		jsonAPIError(c, http.StatusNotFound, errors.New("Job not found"))
 		return
 	}
@@ -83,17 +117,31 @@ func (jrc *JobRunsController) Create(c *gin.Context) {
 		return
 	}
 
-	jr, err := jrc.App.Create(j.ID, initiator, nil, &models.RunRequest{RequestParams: data})
+	runRequest := &models.RunRequest{RequestParams: data, Profile: c.Query("profile") == "true"}
+	jr, err := jrc.App.Create(j.ID, initiator, nil, runRequest)
 	if errors.Cause(err) == orm.ErrorNotFound {
 		jsonAPIError(c, http.StatusNotFound, errors.New("Job not found"))
 		return
 	}
+	if orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	}
 	if err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	jsonAPIResponse(c, presenters.JobRun{JobRun: *jr}, "job run")
+	jsonAPIResponse(c, presenters.JobRun{JobRun: authorizedRunData(c, *jr)}, "job run")
+}
+
+// authorizedRunData returns the run without its data if it is confidential
+// and the request was not made by the node's user.
+func authorizedRunData(c *gin.Context, jr models.JobRun) models.JobRun {
+	if _, ok := authenticatedUser(c); ok {
+		return jr
+	}
+	return jr.Redacted()
 }
 
 // getInitiator returns the Job Spec's initiator for the given web context.
@@ -123,6 +171,144 @@ func getRunData(c *gin.Context) (models.JSON, error) {
 	return models.ParseJSON(b)
 }
 
+// WebhookSignatureHeader carries the signature of a request running a job
+// with a webhook initiator, the hex encoded HMAC-SHA256 of the request's
+// timestamp and body keyed by the initiator's secret, as returned by
+// models.WebhookSignature.
+const WebhookSignatureHeader = "X-Chainlink-Signature"
+
+// WebhookTimestampHeader carries the Unix time, in seconds, at which a
+// webhook request was signed.
+const WebhookTimestampHeader = "X-Chainlink-Timestamp"
+
+// Webhook starts a new Run for the job with the given external job ID, taking
+// the request body as input, if the request was signed with the secret of
+// one of the job's webhook initiators within models.WebhookSignatureMaxAge.
+// Each signature is accepted only once, so that a request which is
+// intercepted cannot be replayed.
+// Example:
+//  "<application>/jobs/:ExternalJobID/runs"
+func (jrc *JobRunsController) Webhook(c *gin.Context) {
+	externalJobID, err := uuid.FromString(c.Param("ExternalJobID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	j, err := jrc.App.GetStore().FindJobByExternalID(requestNamespace(c), externalJobID)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && len(j.InitiatorsFor(models.InitiatorWebhook)) == 0) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Job not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	initiator, err := webhookInitiator(jrc.App.GetStore(), j, body, c.GetHeader(WebhookTimestampHeader), c.GetHeader(WebhookSignatureHeader))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if initiator == nil {
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("invalid webhook signature"))
+		return
+	}
+	if reached, err := webhookRateLimitReached(c, *initiator); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	} else if reached {
+		jsonAPIError(c, http.StatusTooManyRequests, fmt.Errorf("webhook is limited to %d runs per minute", initiator.WebhookRateLimit))
+		return
+	}
+	data, err := models.ParseJSON(body)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jr, err := jrc.App.Create(j.ID, initiator, nil, &models.RunRequest{RequestParams: data})
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Job not found"))
+		return
+	}
+	if orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.JobRun{JobRun: authorizedRunData(c, *jr)}, "job run")
+}
+
+// webhookInitiator returns the webhook initiator of the job whose secret the
+// request's timestamp and body were signed with, or nil if there is none or
+// the signature is too old or has been used before. The secret an initiator
+// had before it was rotated is accepted until it expires.
+func webhookInitiator(store *store.Store, js models.JobSpec, body []byte, timestampHeader, signature string) (*models.Initiator, error) {
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+	signedAt := time.Unix(timestamp, 0)
+	if age := time.Since(signedAt); age > models.WebhookSignatureMaxAge || age < -models.WebhookSignatureMaxAge {
+		return nil, nil
+	}
+	initiators := js.InitiatorsFor(models.InitiatorWebhook)
+	for i := range initiators {
+		valid := webhookSignatureValid(initiators[i].WebhookSecret, timestamp, body, signature)
+		expiresAt := initiators[i].PreviousWebhookSecretExpiresAt
+		if !valid && expiresAt.Valid && time.Now().Before(expiresAt.Time) {
+			valid = webhookSignatureValid(initiators[i].PreviousWebhookSecret, timestamp, body, signature)
+		}
+		if !valid {
+			continue
+		}
+		// The signature itself serves as the nonce of the request
+		signer := fmt.Sprintf("webhook/%d", initiators[i].ID)
+		if fresh, err := store.UseNonce(signer, signature, signedAt.Add(models.WebhookSignatureMaxAge)); err != nil || !fresh {
+			return nil, err
+		}
+		return &initiators[i], nil
+	}
+	return nil, nil
+}
+
+func webhookSignatureValid(secret string, timestamp int64, body []byte, signature string) bool {
+	if secret == "" {
+		return false
+	}
+	expected := models.WebhookSignature(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// webhookRateLimits counts the runs each webhook initiator has created in
+// the current minute.
+var webhookRateLimits = memory.NewStore()
+
+// webhookRateLimitReached counts a run of the webhook initiator, returning
+// whether it has already created as many runs this minute as it may.
+func webhookRateLimitReached(c *gin.Context, initiator models.Initiator) (bool, error) {
+	if initiator.WebhookRateLimit <= 0 {
+		return false, nil
+	}
+	rate := limiter.Rate{Period: time.Minute, Limit: int64(initiator.WebhookRateLimit)}
+	key := fmt.Sprintf("%d-%d", initiator.ID, initiator.WebhookRateLimit)
+	limit, err := limiter.New(webhookRateLimits, rate).Get(c.Request.Context(), key)
+	if err != nil {
+		return false, err
+	}
+	return limit.Reached, nil
+}
+
 // Show returns the details of a JobRun.
 // Example:
 //  "<application>/runs/:RunID"
@@ -133,9 +319,32 @@ func (jrc *JobRunsController) Show(c *gin.Context) {
 		return
 	}
 
-	jr, err := jrc.App.GetStore().FindJobRun(id)
+	jr, ok := jrc.findRunInNamespace(c, id)
+	if !ok {
+		return
+	}
+
+	jsonAPIResponse(c, presenters.JobRun{JobRun: jr}, "job run")
+}
+
+// Profile downloads the pprof profile of the last execution of a run created
+// with profile=true. The type param selects the cpu profile, the default, or
+// the heap profile.
+// Example:
+//  "<application>/runs/:RunID/profile?type=heap"
+func (jrc *JobRunsController) Profile(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("RunID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if _, ok := jrc.findRunInNamespace(c, id); !ok {
+		return
+	}
+	profile, err := jrc.App.GetStore().FindRunProfile(id)
 	if errors.Cause(err) == orm.ErrorNotFound {
-		jsonAPIError(c, http.StatusNotFound, errors.New("Job run not found"))
+		jsonAPIError(c, http.StatusNotFound, errors.New("Run profile not found"))
 		return
 	}
 	if err != nil {
@@ -143,7 +352,24 @@ func (jrc *JobRunsController) Show(c *gin.Context) {
 		return
 	}
 
-	jsonAPIResponse(c, presenters.JobRun{JobRun: jr}, "job run")
+	kind := c.DefaultQuery("type", "cpu")
+	var data []byte
+	switch kind {
+	case "cpu":
+		data = profile.CPU
+	case "heap":
+		data = profile.Heap
+	default:
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("unknown profile type %s, must be cpu or heap", kind))
+		return
+	}
+	if len(data) == 0 {
+		jsonAPIError(c, http.StatusNotFound, fmt.Errorf("no %s profile was taken of the run", kind))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="run-%s-%s.pprof"`, id, kind))
+	c.Data(http.StatusOK, "application/octet-stream", data)
 }
 
 // Update allows external adapters to resume a JobRun, reporting the result of
@@ -185,6 +411,16 @@ func (jrc *JobRunsController) Update(c *gin.Context) {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
+	job, err := unscoped.FindJob(jr.JobSpecID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	// Only a bridge of the run's own namespace may resume it
+	if bt.Namespace != job.Namespace {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Job Run not found"))
+		return
+	}
 
 	ok, err := models.AuthenticateBridgeType(&bt, authToken)
 	if err != nil {
@@ -205,7 +441,7 @@ func (jrc *JobRunsController) Update(c *gin.Context) {
 		return
 	}
 
-	jsonAPIResponse(c, jr, "job run")
+	jsonAPIResponse(c, jr.Redacted(), "job run")
 }
 
 // Cancel stops a Run from continuing.
@@ -218,6 +454,9 @@ func (jrc *JobRunsController) Cancel(c *gin.Context) {
 		return
 	}
 
+	if _, ok := jrc.findRunInNamespace(c, id); !ok {
+		return
+	}
 	jr, err := jrc.App.Cancel(id)
 	if errors.Cause(err) == orm.ErrorNotFound {
 		jsonAPIError(c, http.StatusNotFound, errors.New("Job run not found"))
@@ -230,3 +469,172 @@ func (jrc *JobRunsController) Cancel(c *gin.Context) {
 
 	jsonAPIResponse(c, presenters.JobRun{JobRun: *jr}, "job run")
 }
+
+// replayConfirmedTaskTypes are the types of task which are only replayed
+// once confirmed, as replaying them sends a transaction the first attempt
+// may already have sent.
+var replayConfirmedTaskTypes = map[models.TaskType]bool{
+	adapters.TaskTypeEthTx:             true,
+	adapters.TaskTypeEthTxABIEncode:    true,
+	adapters.TaskTypeEthTxCommitReveal: true,
+}
+
+// Replay resumes an errored Run from the task which errored, reusing the
+// results of the tasks before it. The runs of jobs which cannot be run now,
+// such as archived, paused or quarantined jobs, are not replayed. A run
+// whose replay would perform a transaction task again is only replayed with
+// confirmTransactions=true.
+// Example:
+//  "<application>/runs/:RunID/replay?confirmTransactions=true"
+func (jrc *JobRunsController) Replay(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("RunID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	run, ok := jrc.findRunInNamespace(c, id)
+	if !ok {
+		return
+	}
+	if !run.GetStatus().Errored() {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("cannot replay a job run with status %s", run.GetStatus()))
+		return
+	}
+	if c.Query("confirmTransactions") != "true" {
+		for _, tr := range run.ReplayedTaskRuns() {
+			if replayConfirmedTaskTypes[tr.TaskSpec.Type] {
+				jsonAPIError(c, http.StatusConflict, fmt.Errorf("replaying run %s performs its %s task again, which may send a second transaction; pass confirmTransactions=true to replay it", run.ID, tr.TaskSpec.Type))
+				return
+			}
+		}
+	}
+
+	jr, err := jrc.App.Replay(id)
+	if services.ExpectedRecurringScheduleJobError(err) {
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.JobRun{JobRun: *jr}, "job run")
+}
+
+// findRunInNamespace returns the run with the given ID, responding with not
+// found if there is none or if its job is outside the request's namespace.
+// The runs of archived jobs are still found.
+func (jrc *JobRunsController) findRunInNamespace(c *gin.Context, id *models.ID) (models.JobRun, bool) {
+	store := jrc.App.GetStore()
+	jr, err := store.FindJobRun(id)
+	if err == nil {
+		var job models.JobSpec
+		job, err = store.Unscoped().FindJob(jr.JobSpecID)
+		if err == nil && outsideNamespace(c, job.Namespace) {
+			err = orm.ErrorNotFound
+		}
+	}
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Job run not found"))
+		return jr, false
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return jr, false
+	}
+	return jr, true
+}
+
+// jobRunsCSVHeader names the columns of the CSV export of a job's runs.
+var jobRunsCSVHeader = []string{"id", "status", "result", "error", "payment", "createdAt", "finishedAt", "latencyMs"}
+
+// ExportCSV streams the runs of a job as CSV, oldest first, for analysis in
+// spreadsheets and BI tools. The runs may be limited to those created in
+// [from, to), given as RFC3339 times. The latency of a finished run is the
+// time from its creation to its completion or failure.
+// Example:
+//  "<application>/specs/:SpecID/runs.csv?from=2020-10-01T00:00:00Z&to=2020-11-01T00:00:00Z"
+func (jrc *JobRunsController) ExportCSV(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	from, to, ok := requestTimeRange(c)
+	if !ok {
+		return
+	}
+
+	store := jrc.App.GetStore()
+	job, err := store.FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, job.Namespace)) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-runs.csv"`, job.ID))
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	if err := w.Write(jobRunsCSVHeader); err != nil {
+		return
+	}
+	err = store.EachJobRunBetween(job.ID, from, to, func(run *models.JobRun) error {
+		return w.Write(jobRunCSVRecord(run))
+	})
+	w.Flush()
+	if err != nil {
+		// The response has already begun, so the error can only be logged
+		logger.Errorw("Error exporting job runs as CSV", "job", job.ID.String(), "error", err)
+	}
+}
+
+// requestTimeRange returns the from and to query parameters of a CSV export
+// as times, zero if absent, responding with an error if either is not RFC3339.
+func requestTimeRange(c *gin.Context) (from, to time.Time, ok bool) {
+	for param, t := range map[string]*time.Time{"from": &from, "to": &to} {
+		if value := c.Query(param); value != "" {
+			var err error
+			if *t, err = time.Parse(time.RFC3339, value); err != nil {
+				jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid %s: %v", param, err))
+				return time.Time{}, time.Time{}, false
+			}
+		}
+	}
+	return from, to, true
+}
+
+func jobRunCSVRecord(run *models.JobRun) []string {
+	var payment, finishedAt, latency string
+	if run.Payment != nil {
+		payment = run.Payment.String()
+	}
+	if run.FinishedAt.Valid {
+		finishedAt = run.FinishedAt.Time.UTC().Format(time.RFC3339Nano)
+		latency = strconv.FormatInt(run.FinishedAt.Time.Sub(run.CreatedAt).Milliseconds(), 10)
+	}
+	return []string{
+		run.ID.String(),
+		string(run.Status),
+		csvSafeCell(run.Result.Data.Get("result").String()),
+		csvSafeCell(run.Result.ErrorMessage.String),
+		payment,
+		run.CreatedAt.UTC().Format(time.RFC3339Nano),
+		finishedAt,
+		latency,
+	}
+}
+
+// csvSafeCell prefixes a cell which a spreadsheet would read as a formula
+// with ', so that results and errors from outside the node cannot run
+// formulas when the export is opened. Negative numbers are prefixed too, and
+// so are read as text.
+func csvSafeCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
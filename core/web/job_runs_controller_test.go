@@ -2,8 +2,12 @@ package web_test
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
@@ -14,8 +18,10 @@ import (
 	"github.com/smartcontractkit/chainlink/core/web"
 
 	"github.com/manyminds/api2go/jsonapi"
+	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v3"
 )
 
 func BenchmarkJobRunsController_Index(b *testing.B) {
@@ -206,6 +212,61 @@ func TestJobRunsController_Create_ExternalInitiator_Success(t *testing.T) {
 	assert.Equal(t, "100", value)
 }
 
+func TestJobRunsController_Create_ExternalInitiatorV2(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	app.Start()
+	defer cleanup()
+
+	eia := auth.NewToken()
+	ei, err := models.NewExternalInitiator(eia, &models.ExternalInitiatorRequest{
+		Name:            "bitcoin",
+		ProtocolVersion: models.ExternalInitiatorProtocolV2,
+	})
+	require.NoError(t, err)
+	require.NoError(t, app.Store.CreateExternalInitiator(ei))
+	j := cltest.NewJobWithExternalInitiator(ei)
+	require.NoError(t, app.Store.CreateJob(&j))
+
+	body := `{"result":"100"}`
+	path := "/v2/specs/" + j.ID.String() + "/runs"
+	url := app.Config.ClientNodeURL() + path
+	signed := func(key string, timestamp int64, nonce string) map[string]string {
+		return map[string]string{
+			web.ExternalInitiatorAccessKeyHeader: eia.AccessKey,
+			web.ExternalInitiatorTimestampHeader: strconv.FormatInt(timestamp, 10),
+			web.ExternalInitiatorNonceHeader:     nonce,
+			web.ExternalInitiatorSignatureHeader: models.ExternalInitiatorSignature(key, timestamp, nonce, http.MethodPost, path, []byte(body)),
+		}
+	}
+
+	now := time.Now().Unix()
+	headers := signed(ei.IncomingSigningKey, now, "first")
+	resp, cleanup := cltest.UnauthenticatedPost(t, url, bytes.NewBufferString(body), headers)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{"replayed", headers},
+		{"stale", signed(ei.IncomingSigningKey, now-int64(models.ExternalInitiatorSignatureMaxAge/time.Second)-60, "stale")},
+		{"wrong key", signed("wrong key", now, "wrong")},
+		{"version 1 credentials", map[string]string{
+			web.ExternalInitiatorAccessKeyHeader: eia.AccessKey,
+			web.ExternalInitiatorSecretHeader:    eia.Secret,
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, cleanup := cltest.UnauthenticatedPost(t, url, bytes.NewBufferString(body), test.headers)
+			defer cleanup()
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+}
+
 func TestJobRunsController_Create_Archived(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
@@ -289,6 +350,111 @@ func TestJobRunsController_Create_InvalidID(t *testing.T) {
 	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "Response should be unprocessable entity")
 }
 
+func TestJobRunsController_Webhook(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	app.Start()
+	defer cleanup()
+
+	secret := "0123456789abcdef"
+	externalJobID := uuid.NewV4()
+	j := cltest.NewJob()
+	j.ExternalJobID = &externalJobID
+	j.Initiators = []models.Initiator{{
+		JobSpecID:       j.ID,
+		Type:            models.InitiatorWebhook,
+		InitiatorParams: models.InitiatorParams{WebhookSecret: secret},
+	}}
+	require.NoError(t, app.Store.CreateJob(&j))
+
+	body := `{"result":"100"}`
+	timestamp := time.Now().Unix()
+	signature := models.WebhookSignature(secret, timestamp, []byte(body))
+	staleTimestamp := time.Now().Add(-2 * models.WebhookSignatureMaxAge).Unix()
+	url := app.Config.ClientNodeURL() + "/v2/jobs/" + externalJobID.String() + "/runs"
+
+	tests := []struct {
+		name      string
+		url       string
+		timestamp int64
+		signature string
+		status    int
+	}{
+		{"bad signature", url, timestamp, hex.EncodeToString([]byte("signature")), http.StatusUnauthorized},
+		{"no signature", url, timestamp, "", http.StatusUnauthorized},
+		{"other timestamp", url, timestamp + 1, signature, http.StatusUnauthorized},
+		{"stale timestamp", url, staleTimestamp, models.WebhookSignature(secret, staleTimestamp, []byte(body)), http.StatusUnauthorized},
+		{"unknown job", app.Config.ClientNodeURL() + "/v2/jobs/" + uuid.NewV4().String() + "/runs", timestamp, signature, http.StatusNotFound},
+		{"invalid external job ID", app.Config.ClientNodeURL() + "/v2/jobs/garbageID/runs", timestamp, signature, http.StatusUnprocessableEntity},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := map[string]string{
+				web.WebhookTimestampHeader: strconv.FormatInt(test.timestamp, 10),
+				web.WebhookSignatureHeader: test.signature,
+			}
+			resp, cleanup := cltest.UnauthenticatedPost(t, test.url, bytes.NewBufferString(body), headers)
+			defer cleanup()
+			assert.Equal(t, test.status, resp.StatusCode)
+		})
+	}
+
+	headers := map[string]string{
+		web.WebhookTimestampHeader: strconv.FormatInt(timestamp, 10),
+		web.WebhookSignatureHeader: signature,
+	}
+	resp, cleanup := cltest.UnauthenticatedPost(t, url, bytes.NewBufferString(body), headers)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var jr models.JobRun
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &jr))
+	jr = cltest.WaitForJobRunToComplete(t, app.Store, jr)
+	assert.Equal(t, "100", cltest.MustResultString(t, jr.Result))
+
+	// The same signed request is not accepted twice
+	resp, cleanup = cltest.UnauthenticatedPost(t, url, bytes.NewBufferString(body), headers)
+	defer cleanup()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestJobRunsController_WebhookRateLimit(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	app.Start()
+	defer cleanup()
+
+	secret := "0123456789abcdef"
+	externalJobID := uuid.NewV4()
+	j := cltest.NewJob()
+	j.ExternalJobID = &externalJobID
+	j.Initiators = []models.Initiator{{
+		JobSpecID:       j.ID,
+		Type:            models.InitiatorWebhook,
+		InitiatorParams: models.InitiatorParams{WebhookSecret: secret, WebhookRateLimit: 1},
+	}}
+	require.NoError(t, app.Store.CreateJob(&j))
+
+	url := app.Config.ClientNodeURL() + "/v2/jobs/" + externalJobID.String() + "/runs"
+	body := `{"result":"100"}`
+	resp, cleanup := cltest.UnauthenticatedPost(t, url, bytes.NewBufferString(body), webhookHeaders(secret, body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	body = `{"result":"101"}`
+	resp, cleanup = cltest.UnauthenticatedPost(t, url, bytes.NewBufferString(body), webhookHeaders(secret, body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusTooManyRequests)
+}
+
+// webhookHeaders returns the headers of a webhook request with the given
+// body, signed now with secret.
+func webhookHeaders(secret, body string) map[string]string {
+	timestamp := time.Now().Unix()
+	return map[string]string{
+		web.WebhookTimestampHeader: strconv.FormatInt(timestamp, 10),
+		web.WebhookSignatureHeader: models.WebhookSignature(secret, timestamp, []byte(body)),
+	}
+}
+
 func TestJobRunsController_Update_Success(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
@@ -485,6 +651,41 @@ func TestJobRunsController_Show_Found(t *testing.T) {
 	assert.Equal(t, jr.ID, respJobRun.ID, "should have job run id")
 }
 
+func TestJobRunsController_Profile(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	app.Start()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+
+	resp, cleanupResp := client.Post("/v2/specs/"+j.ID.String()+"/runs?profile=true", bytes.NewBufferString(`{"result":"100"}`))
+	defer cleanupResp()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var jr models.JobRun
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &jr))
+	assert.True(t, jr.Profile)
+	cltest.WaitForJobRunToComplete(t, app.Store, jr)
+
+	resp, cleanupResp = client.Get("/v2/runs/" + jr.ID.String() + "/profile?type=heap")
+	defer cleanupResp()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "heap.pprof")
+	assert.NotEmpty(t, cltest.ParseResponseBody(t, resp))
+
+	resp, cleanupResp = client.Get("/v2/runs/" + jr.ID.String() + "/profile?type=goroutine")
+	defer cleanupResp()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+
+	unprofiled := cltest.CreateJobRunViaWeb(t, app, j, `{"result":"100"}`)
+	cltest.WaitForJobRunToComplete(t, app.Store, unprofiled)
+	resp, cleanupResp = client.Get("/v2/runs/" + unprofiled.ID.String() + "/profile")
+	defer cleanupResp()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
 func TestJobRunsController_Show_NotFound(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
@@ -555,3 +756,143 @@ func TestJobRunsController_Cancel(t *testing.T) {
 		assert.Equal(t, models.RunStatusCancelled, r.GetStatus())
 	})
 }
+
+func TestJobRunsController_Replay(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	t.Run("missing run", func(t *testing.T) {
+		resp, cleanup := client.Put("/v2/runs/29023583-0D39-4844-9696-451102590936/replay", nil)
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	})
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "noop"), cltest.NewTask(t, "noop")}
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	t.Run("run which has not errored", func(t *testing.T) {
+		run := cltest.NewJobRun(job)
+		require.NoError(t, app.Store.CreateJobRun(&run))
+
+		resp, cleanup := client.Put(fmt.Sprintf("/v2/runs/%s/replay", run.ID), nil)
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusConflict)
+	})
+
+	t.Run("errored run", func(t *testing.T) {
+		run := cltest.NewJobRun(job)
+		run.TaskRuns[0].ApplyOutput(models.NewRunOutputCompleteWithResult("upstream"))
+		run.TaskRuns[1].SetError(errors.New("bridge unavailable"))
+		run.SetError(errors.New("bridge unavailable"))
+		require.NoError(t, app.Store.CreateJobRun(&run))
+
+		resp, cleanup := client.Put(fmt.Sprintf("/v2/runs/%s/replay", run.ID), nil)
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		run = cltest.WaitForJobRunToComplete(t, app.Store, run)
+		assert.Equal(t, "upstream", run.TaskRuns[0].Result.Data.Get("result").String())
+		assert.Zero(t, run.TaskRuns[0].ReplayCount)
+		assert.Equal(t, models.RunStatusCompleted, run.TaskRuns[1].Status)
+		assert.Equal(t, "upstream", run.TaskRuns[1].Result.Data.Get("result").String())
+		assert.Equal(t, uint32(1), run.TaskRuns[1].ReplayCount)
+	})
+
+	t.Run("errored run of a paused job", func(t *testing.T) {
+		paused := cltest.NewJobWithWebInitiator()
+		paused.Status = models.JobSpecStatusPaused
+		require.NoError(t, app.Store.CreateJob(&paused))
+		run := cltest.NewJobRun(paused)
+		run.TaskRuns[0].SetError(errors.New("bridge unavailable"))
+		run.SetError(errors.New("bridge unavailable"))
+		require.NoError(t, app.Store.CreateJobRun(&run))
+
+		resp, cleanup := client.Put(fmt.Sprintf("/v2/runs/%s/replay", run.ID), nil)
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+		run, err := app.Store.FindJobRun(run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RunStatusErrored, run.GetStatus())
+	})
+
+	t.Run("errored run with a transaction left to send", func(t *testing.T) {
+		txJob := cltest.NewJobWithWebInitiator()
+		txJob.Tasks = []models.TaskSpec{cltest.NewTask(t, "noop"), cltest.NewTask(t, "ethtx")}
+		require.NoError(t, app.Store.CreateJob(&txJob))
+		run := cltest.NewJobRun(txJob)
+		run.TaskRuns[0].SetError(errors.New("bridge unavailable"))
+		run.SetError(errors.New("bridge unavailable"))
+		require.NoError(t, app.Store.CreateJobRun(&run))
+
+		resp, cleanup := client.Put(fmt.Sprintf("/v2/runs/%s/replay", run.ID), nil)
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+		run, err := app.Store.FindJobRun(run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RunStatusErrored, run.GetStatus())
+		assert.Zero(t, run.TaskRuns[0].ReplayCount)
+	})
+}
+
+func TestJobRunsController_ExportCSV(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	app.Start()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+
+	created := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	completed := cltest.NewJobRun(j)
+	completed.CreatedAt = created
+	completed.ApplyOutput(models.NewRunOutputCompleteWithResult("123"))
+	completed.FinishedAt = null.TimeFrom(created.Add(1500 * time.Millisecond))
+	require.NoError(t, app.Store.CreateJobRun(&completed))
+	errored := cltest.NewJobRun(j)
+	errored.CreatedAt = created.Add(time.Hour)
+	errored.SetError(errors.New("boom"))
+	require.NoError(t, app.Store.CreateJobRun(&errored))
+	formula := cltest.NewJobRun(j)
+	formula.CreatedAt = created.Add(2 * time.Hour)
+	formula.ApplyOutput(models.NewRunOutputCompleteWithResult(`=HYPERLINK("http://example.com")`))
+	require.NoError(t, app.Store.CreateJobRun(&formula))
+
+	resp, cleanup := client.Get("/v2/specs/" + j.ID.String() + "/runs.csv")
+	defer cleanup()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, []string{"id", "status", "result", "error", "payment", "createdAt", "finishedAt", "latencyMs"}, records[0])
+	assert.Equal(t, []string{completed.ID.String(), "completed", "123", "", "", "2020-10-01T12:00:00Z", "2020-10-01T12:00:01.5Z", "1500"}, records[1])
+	assert.Equal(t, errored.ID.String(), records[2][0])
+	assert.Equal(t, []string{"errored", "", "boom"}, records[2][1:4])
+	assert.Equal(t, `'=HYPERLINK("http://example.com")`, records[3][2])
+
+	resp, cleanup = client.Get("/v2/specs/" + j.ID.String() + "/runs.csv?from=2020-10-01T12:30:00Z&to=2020-10-01T13:30:00Z")
+	defer cleanup()
+	records, err = csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, errored.ID.String(), records[1][0])
+
+	resp, cleanup = client.Get("/v2/specs/" + j.ID.String() + "/runs.csv?to=yesterday")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+
+	resp, cleanup = client.Get("/v2/specs/" + models.NewID().String() + "/runs.csv")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
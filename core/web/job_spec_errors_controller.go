@@ -3,10 +3,12 @@ package web
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 )
 
@@ -15,6 +17,66 @@ type JobSpecErrorsController struct {
 	App chainlink.Application
 }
 
+// Index lists the errors of a job, leaving out those which have been
+// acknowledged unless asked for with the acknowledged query parameter.
+// Example:
+//  "<application>/specs/:SpecID/errors?acknowledged=true"
+func (jsec *JobSpecErrorsController) Index(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	includeAcknowledged := false
+	if value := c.Query("acknowledged"); value != "" {
+		if includeAcknowledged, err = strconv.ParseBool(value); err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid acknowledged"))
+			return
+		}
+	}
+
+	store := jsec.App.GetStore()
+	job, err := store.FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, job.Namespace)) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jobSpecErrs, err := store.JobSpecErrorsFor(job.ID, includeAcknowledged)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, jobSpecErrs, "job_spec_errors")
+}
+
+// Acknowledge marks a JobSpecError as seen by the operator, hiding it from
+// the job's errors until it occurs again.
+// Example:
+//  "<application>/job_spec_errors/:jobSpecErrorID/acknowledgement"
+func (jsec *JobSpecErrorsController) Acknowledge(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("jobSpecErrorID"), 10, 64)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jobSpecErr, err := jsec.App.GetStore().AcknowledgeJobSpecError(requestNamespace(c), int64(id), time.Now())
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpecError not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, jobSpecErr, "job_spec_errors")
+}
+
 // Destroy deletes a JobSpecError record from the database, effectively
 // silencing the error notification
 func (jsec *JobSpecErrorsController) Destroy(c *gin.Context) {
@@ -24,7 +86,7 @@ func (jsec *JobSpecErrorsController) Destroy(c *gin.Context) {
 		return
 	}
 
-	err = jsec.App.GetStore().DeleteJobSpecError(int64(id))
+	err = jsec.App.GetStore().DeleteJobSpecError(requestNamespace(c), int64(id))
 	if errors.Cause(err) == orm.ErrorNotFound {
 		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpecError not found"))
 		return
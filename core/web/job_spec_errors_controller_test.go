@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -76,3 +78,76 @@ func TestJobSpecErrorsController_Delete_Unauthenticated(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "Response should be forbidden")
 }
+
+func TestJobSpecErrorsController_Index(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJob()
+	require.NoError(t, app.Store.CreateJob(&j))
+	app.Store.UpsertErrorFor(j.ID, "first")
+	app.Store.UpsertErrorFor(j.ID, "second")
+	jse, err := app.Store.FindJobSpecError(j.ID, "first")
+	require.NoError(t, err)
+	_, err = app.Store.AcknowledgeJobSpecError(models.DefaultNamespace, jse.ID, time.Now())
+	require.NoError(t, err)
+
+	resp, cleanup := client.Get(fmt.Sprintf("/v2/specs/%s/errors", j.ID))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var jses []models.JobSpecError
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &jses))
+	require.Len(t, jses, 1)
+	assert.Equal(t, "second", jses[0].Description)
+
+	resp, cleanup = client.Get(fmt.Sprintf("/v2/specs/%s/errors?acknowledged=true", j.ID))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	jses = nil
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &jses))
+	require.Len(t, jses, 2)
+	assert.Equal(t, "first", jses[0].Description)
+	assert.True(t, jses[0].AcknowledgedAt.Valid)
+
+	resp, cleanup = client.Get(fmt.Sprintf("/v2/specs/%s/errors", models.NewID()))
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestJobSpecErrorsController_Acknowledge(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJob()
+	require.NoError(t, app.Store.CreateJob(&j))
+	description := "job spec error description"
+	app.Store.UpsertErrorFor(j.ID, description)
+	jse, err := app.Store.FindJobSpecError(j.ID, description)
+	require.NoError(t, err)
+
+	resp, cleanup := client.Put(fmt.Sprintf("/v2/job_spec_errors/%v/acknowledgement", jse.ID), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var acknowledged models.JobSpecError
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &acknowledged))
+	assert.Equal(t, jse.ID, acknowledged.ID)
+	assert.True(t, acknowledged.AcknowledgedAt.Valid)
+
+	jse, err = app.Store.FindJobSpecError(j.ID, description)
+	require.NoError(t, err)
+	assert.True(t, jse.AcknowledgedAt.Valid)
+
+	resp, cleanup = client.Put("/v2/job_spec_errors/999999/acknowledgement", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,45 @@
+package web
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobSpecGeneratorsController generates the specs of jobs following common
+// patterns, such as price feeds, from a few parameters.
+type JobSpecGeneratorsController struct {
+	App chainlink.Application
+}
+
+// Create returns the spec generated for the pattern from the parameters in
+// the request body, as plain JSON which can be reviewed and then posted to
+// create the job. The spec is validated as it would be on creation, but no
+// job is created.
+// Example:
+//  "<application>/job_spec_generators/median-price-feed"
+func (jsgc *JobSpecGeneratorsController) Create(c *gin.Context) {
+	params, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	jsr, err := models.GenerateJobSpec(c.Param("Pattern"), params)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	js := models.NewJobFromRequest(jsr)
+	js.Namespace = requestNamespace(c)
+	jsc := JobSpecsController{jsgc.App}
+	if _, httpStatus, err := jsc.checkJobSpec(js); err != nil {
+		jsonAPIError(c, httpStatus, err)
+		return
+	}
+	c.JSON(http.StatusOK, jsr)
+}
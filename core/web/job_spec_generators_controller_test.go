@@ -0,0 +1,47 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobSpecGeneratorsController_Create(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/job_spec_generators/median-price-feed", bytes.NewBufferString(`{
+		"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42",
+		"sources": ["https://example.com/eth", "https://example.org/eth", "https://example.net/eth"]
+	}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var jsr models.JobSpecRequest
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jsr))
+	require.Len(t, jsr.Initiators, 1)
+	assert.Equal(t, int32(2), jsr.Initiators[0].MinAnswers)
+	assert.Len(t, jsr.Tasks, 3)
+
+	// Validated as on creation
+	resp, cleanup = client.Post("/v2/job_spec_generators/median-price-feed", bytes.NewBufferString(`{
+		"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42",
+		"sources": ["nosuchbridge"]
+	}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	resp, cleanup = client.Post("/v2/job_spec_generators/mean-price-feed", bytes.NewBufferString(`{}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+}
@@ -0,0 +1,126 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// JobSpecTemplatesController manages the templates which many similar jobs
+// can be created from, such as the same feed deployed for many contracts.
+type JobSpecTemplatesController struct {
+	App chainlink.Application
+}
+
+// Index lists the templates of the namespace.
+// Example:
+//  "<application>/job_spec_templates"
+func (jstc *JobSpecTemplatesController) Index(c *gin.Context) {
+	templates, err := jstc.App.GetStore().JobSpecTemplates(requestNamespace(c))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, templates, "job_spec_templates")
+}
+
+// Create adds a template to the namespace.
+// Example:
+//  "<application>/job_spec_templates"
+func (jstc *JobSpecTemplatesController) Create(c *gin.Context) {
+	var request models.JobSpecTemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	t, err := models.NewJobSpecTemplate(request.Name, request.Template)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	t.Namespace = requestNamespace(c)
+
+	store := jstc.App.GetStore()
+	if _, err := store.FindJobSpecTemplate(t.Namespace, t.Name); err == nil {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("template %s already exists", t.Name))
+		return
+	} else if errors.Cause(err) != orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if err := store.CreateJobSpecTemplate(&t); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, t, "job_spec_template", http.StatusCreated)
+}
+
+// Destroy removes a template. Jobs created from it are kept.
+// Example:
+//  "<application>/job_spec_templates/:Name"
+func (jstc *JobSpecTemplatesController) Destroy(c *gin.Context) {
+	err := jstc.App.GetStore().DeleteJobSpecTemplate(requestNamespace(c), c.Param("Name"))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("template not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, nil, "job_spec_template", http.StatusNoContent)
+}
+
+// CreateSpec creates a job from a template and the values of its variables,
+// validating and saving it as JobSpecsController.Create does. Passing
+// start=false saves the job without starting it.
+// Example:
+//  "<application>/job_spec_templates/:Name/specs"
+func (jstc *JobSpecTemplatesController) CreateSpec(c *gin.Context) {
+	start, err := strconv.ParseBool(c.DefaultQuery("start", "true"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid start parameter"))
+		return
+	}
+	var request models.JobSpecFromTemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	namespace := requestNamespace(c)
+	t, err := jstc.App.GetStore().FindJobSpecTemplate(namespace, c.Param("Name"))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("template not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	body, err := t.Render(request.Variables)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	var jsr models.JobSpecRequest
+	if err := decodeJobSpecRequest(c, body, &jsr); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	js := models.NewJobFromRequest(jsr)
+	js.Namespace = namespace
+
+	jsc := JobSpecsController{jstc.App}
+	js, httpStatus, err := jsc.checkJobSpec(js)
+	if err != nil {
+		jsonAPIError(c, httpStatus, err)
+		return
+	}
+	jsc.create(c, js, start)
+}
@@ -0,0 +1,65 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobSpecTemplatesController_CreateSpec(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	template := `{"initiators": [{"type": "web"}], "tasks": [
+		{"type": "httpget", "params": {"get": "{{ url }}"}},
+		{"type": "multiply", "params": {"times": {{times}}}}
+	]}`
+	body, err := json.Marshal(models.JobSpecTemplateRequest{Name: "price-feed", Template: template})
+	require.NoError(t, err)
+	resp, cleanup := client.Post("/v2/job_spec_templates", bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	resp, cleanup = client.Post("/v2/job_spec_templates", bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Get("/v2/job_spec_templates")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var templates []models.JobSpecTemplate
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &templates))
+	require.Len(t, templates, 1)
+	assert.Equal(t, []string{"times", "url"}, templates[0].Variables())
+
+	resp, cleanup = client.Post("/v2/job_spec_templates/price-feed/specs",
+		bytes.NewBufferString(`{"variables": {"url": "https://example.com/eth?q=\"usd\"", "times": "100"}}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var job models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &job))
+	require.Len(t, job.Tasks, 2)
+	assert.Equal(t, `https://example.com/eth?q="usd"`, job.Tasks[0].Params.Get("get").String())
+	assert.Equal(t, int64(100), job.Tasks[1].Params.Get("times").Int())
+
+	resp, cleanup = client.Post("/v2/job_spec_templates/price-feed/specs", bytes.NewBufferString(`{"variables": {"url": "https://example.com"}}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	resp, cleanup = client.Delete("/v2/job_spec_templates/price-feed")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+	resp, cleanup = client.Post("/v2/job_spec_templates/price-feed/specs", bytes.NewBufferString(`{"variables": {}}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
@@ -0,0 +1,85 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// JobSpecVersionsController shows the history of a job's spec, and rolls the
+// job back to earlier versions of it.
+type JobSpecVersionsController struct {
+	App chainlink.Application
+}
+
+// Index lists every version of the job's spec, latest first.
+// Example:
+//  "<application>/specs/:SpecID/versions"
+func (jsvc *JobSpecVersionsController) Index(c *gin.Context) {
+	id, ok := jsvc.requestJobID(c)
+	if !ok {
+		return
+	}
+	versions, err := jsvc.App.GetStore().JobSpecVersions(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, versions, "job_spec_versions")
+}
+
+// Rollback replaces the job's spec with an earlier version of it, as an
+// update would, recording the rollback as the job's latest version.
+// Example:
+//  "<application>/specs/:SpecID/versions/:Version/rollback"
+func (jsvc *JobSpecVersionsController) Rollback(c *gin.Context) {
+	id, ok := jsvc.requestJobID(c)
+	if !ok {
+		return
+	}
+	number, err := strconv.ParseInt(c.Param("Version"), 10, 32)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid version %q", c.Param("Version")))
+		return
+	}
+
+	version, err := jsvc.App.GetStore().FindJobSpecVersion(id, int32(number))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("version not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsr, err := version.Request()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	js := models.NewJobFromRequest(jsr)
+	js.ID = id
+	js.Namespace = requestNamespace(c)
+	jsc := JobSpecsController{jsvc.App}
+	jsc.update(c, js)
+}
+
+// requestJobID returns the ID of the job given by the request, responding
+// with an error if it is invalid or the job is not in the request's
+// namespace.
+func (jsvc *JobSpecVersionsController) requestJobID(c *gin.Context) (*models.ID, bool) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return nil, false
+	}
+	jsc := JobSpecsController{jsvc.App}
+	return id, jsc.findJobInNamespace(c, id)
+}
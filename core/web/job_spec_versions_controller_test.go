@@ -0,0 +1,64 @@
+package web_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobSpecVersionsController(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "noop")}
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	body := `{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}, {"name": "second", "type": "noop"}]}`
+	resp, cleanup := client.Patch("/v2/specs/"+job.ID.String(), bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	resp, cleanup = client.Get("/v2/specs/" + job.ID.String() + "/versions")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var versions []models.JobSpecVersion
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &versions))
+	require.Len(t, versions, 2)
+	assert.Equal(t, int32(2), versions[0].Version)
+	assert.Equal(t, int32(1), versions[1].Version)
+
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/versions/1/rollback", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	found, err := app.Store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.Len(t, found.Tasks, 1)
+
+	// The rollback is recorded as the latest version, keeping the one rolled back
+	versions, err = app.Store.JobSpecVersions(job.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, int32(3), versions[0].Version)
+	assert.JSONEq(t, versions[2].Spec.String(), versions[0].Spec.String())
+
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/versions/9/rollback", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/versions/first/rollback", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+	resp, cleanup = client.Get("/v2/specs/" + models.NewID().String() + "/versions")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
@@ -1,8 +1,16 @@
 package web
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -11,6 +19,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	null "gopkg.in/guregu/null.v3"
 )
 
 // JobSpecsController manages JobSpec requests.
@@ -18,9 +28,17 @@ type JobSpecsController struct {
 	App chainlink.Application
 }
 
-// Index lists JobSpecs, one page at a time.
+// Index lists JobSpecs, one page at a time. They can be filtered by status,
+// initiator type, task type (such as a bridge's name), creation time, and by
+// text found in their IDs or task names. Passing a cursor param, empty for the
+// first page, pages by the nextCursor of the previous page instead. Archived
+// jobs are only listed when includeArchived=true.
 // Example:
 //  "<application>/specs?size=1&page=2"
+//  "<application>/specs?initiatorType=runlog&taskType=coinmarketcap&createdAfter=2020-10-01T00:00:00Z"
+//  "<application>/specs?search=fetchPrice"
+//  "<application>/specs?size=100&cursor=:nextCursor"
+//  "<application>/specs?includeArchived=true"
 func (jsc *JobSpecsController) Index(c *gin.Context, size, page, offset int) {
 	var order orm.SortType
 	if c.Query("sort") == "-createdAt" {
@@ -29,13 +47,53 @@ func (jsc *JobSpecsController) Index(c *gin.Context, size, page, offset int) {
 		order = orm.Ascending
 	}
 
-	jobs, count, err := jsc.App.GetStore().JobsSorted(order, offset, size)
+	filter := orm.JobSpecFilter{
+		Namespace:     requestNamespace(c),
+		Status:        models.JobSpecStatus(c.Query("status")),
+		InitiatorType: c.Query("initiatorType"),
+		TaskType:      c.Query("taskType"),
+		Search:        c.Query("search"),
+	}
+	if value := c.Query("includeArchived"); value != "" {
+		includeArchived, err := strconv.ParseBool(value)
+		if err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid includeArchived parameter"))
+			return
+		}
+		filter.IncludeArchived = includeArchived
+	}
+	for param, t := range map[string]*time.Time{"createdAfter": &filter.CreatedAfter, "createdBefore": &filter.CreatedBefore} {
+		if value := c.Query(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrapf(err, "invalid %s parameter", param))
+				return
+			}
+			*t = parsed
+		}
+	}
+
+	cursor, keyset, err := requestCursor(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if keyset {
+		jobs, next, err := jsc.App.GetStore().JobsAfter(filter, order, cursor, size)
+		cursorPaginatedResponse(c, "Jobs", size, presentJobSpecs(jobs), next, err)
+		return
+	}
+
+	jobs, count, err := jsc.App.GetStore().JobsFiltered(filter, order, offset, size)
+	paginatedResponse(c, "Jobs", size, page, presentJobSpecs(jobs), count, err)
+}
+
+func presentJobSpecs(jobs []models.JobSpec) []presenters.JobSpec {
 	pjs := make([]presenters.JobSpec, len(jobs))
 	for i, j := range jobs {
 		pjs[i] = presenters.JobSpec{JobSpec: j}
 	}
-
-	paginatedResponse(c, "Jobs", size, page, pjs, count, err)
+	return pjs
 }
 
 // requireImplented verifies if a Job Spec's feature is enabled according to
@@ -56,12 +114,49 @@ func (jsc *JobSpecsController) requireImplemented(js models.JobSpec) error {
 func (jsc *JobSpecsController) getAndCheckJobSpec(
 	c *gin.Context) (js models.JobSpec, httpStatus int, err error) {
 	var jsr models.JobSpecRequest
-	if err := c.ShouldBindJSON(&jsr); err != nil {
-		// TODO(alx): Better parsing and more specific error messages
-		// https://www.pivotaltracker.com/story/show/171164115
+	if err := bindJobSpecRequest(c, &jsr); err != nil {
 		return models.JobSpec{}, http.StatusBadRequest, err
 	}
 	js = models.NewJobFromRequest(jsr)
+	js.Namespace = requestNamespace(c)
+	return jsc.checkJobSpec(js)
+}
+
+// bindJobSpecRequest decodes the body of c into request.
+func bindJobSpecRequest(c *gin.Context, request interface{}) error {
+	body, err := c.GetRawData()
+	if err != nil {
+		return err
+	}
+	return decodeJobSpecRequest(c, body, request)
+}
+
+// decodeJobSpecRequest decodes a job spec given to c into request. Keys which
+// are not part of a job spec are rejected, as they are most likely mistyped,
+// unless the request asks for strict=false, in which case they are logged
+// and ignored.
+func decodeJobSpecRequest(c *gin.Context, document []byte, request interface{}) error {
+	unknown, err := models.DecodeJobSpecRequest(document, request)
+	if err != nil {
+		return err
+	} else if len(unknown) == 0 {
+		return nil
+	}
+	if strictJobSpecs(c) {
+		return &models.UnknownKeysError{Keys: unknown}
+	}
+	logger.Warnw("Ignoring unknown keys in job spec", "keys", unknown)
+	return nil
+}
+
+// strictJobSpecs returns whether the job specs given to c are rejected for
+// keys which are not part of a job spec, which they are unless the request
+// opts out with strict=false.
+func strictJobSpecs(c *gin.Context) bool {
+	return c.Query("strict") != "false"
+}
+
+func (jsc *JobSpecsController) checkJobSpec(js models.JobSpec) (models.JobSpec, int, error) {
 	if err := jsc.requireImplemented(js); err != nil {
 		return models.JobSpec{}, http.StatusNotImplemented, err
 	}
@@ -71,25 +166,486 @@ func (jsc *JobSpecsController) getAndCheckJobSpec(
 	return js, 0, nil
 }
 
-// Create adds validates, saves, and starts a new JobSpec.
+// Create adds validates, saves, and starts a new JobSpec. Passing start=false
+// saves the JobSpec without starting it. A JobSpec with an externalJobID
+// already used in the namespace is not created again; the existing one is
+// returned instead if it has the same spec, so that retried requests are
+// safe, and 409 Conflict is returned if its spec differs.
 // Example:
 //  "<application>/specs"
+//  "<application>/specs?start=false"
 func (jsc *JobSpecsController) Create(c *gin.Context) {
+	start, err := strconv.ParseBool(c.DefaultQuery("start", "true"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid start parameter"))
+		return
+	}
+
 	js, httpStatus, err := jsc.getAndCheckJobSpec(c)
 	if err != nil {
 		jsonAPIError(c, httpStatus, err)
 		return
 	}
+	jsc.create(c, js, start)
+}
+
+// create saves and, unless start is false, starts a validated JobSpec,
+// responding with it.
+func (jsc *JobSpecsController) create(c *gin.Context, js models.JobSpec, start bool) {
+	if existing, err := jsc.findExternalJob(js); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	} else if existing != nil {
+		respondWithExternalJob(c, *existing, js)
+		return
+	}
+	if err := jsc.App.GetStore().CheckJobQuota(js.Namespace); orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !start {
+		js.Status = models.JobSpecStatusStopped
+	}
 	if err := NotifyExternalInitiator(js, jsc.App.GetStore()); err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
-	if err := jsc.App.AddJob(js); err != nil {
+	if err := jsc.App.AddJob(js); orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	} else if err != nil {
+		// A concurrent request with the same external job ID may have
+		// created the job first
+		if existing, _ := jsc.findExternalJob(js); existing != nil {
+			respondWithExternalJob(c, *existing, js)
+			return
+		}
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
 	// TODO: https://www.pivotaltracker.com/story/show/171169052
-	jsonAPIResponse(c, presenters.JobSpec{JobSpec: js}, "job")
+	jsonAPIResponse(c, presenters.NewCreatedJobSpec(js), "job")
+}
+
+// CreateBatch validates and saves many JobSpecs at once, given as a JSON
+// array or as a sequence of JSON documents. Either every JobSpec is saved, or,
+// if any is invalid, none are and the errors of each invalid JobSpec are
+// returned. Passing start=false saves the JobSpecs without starting them.
+// External initiators are notified of their JobSpecs once all are saved.
+// Example:
+//  "<application>/job_spec_batches"
+//  "<application>/job_spec_batches?start=false"
+func (jsc *JobSpecsController) CreateBatch(c *gin.Context) {
+	start, err := strconv.ParseBool(c.DefaultQuery("start", "true"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid start parameter"))
+		return
+	}
+
+	documents, _, ok := readJobSpecDocuments(c)
+	if !ok {
+		return
+	}
+
+	namespace := requestNamespace(c)
+	jobs := make([]models.JobSpec, 0, len(documents))
+	validationErrors := models.NewJSONAPIErrors()
+	for i, document := range documents {
+		var jsr models.JobSpecRequest
+		if err := decodeJobSpecRequest(c, document, &jsr); err != nil {
+			validationErrors.Add(fmt.Sprintf("job spec %d: %v", i, err))
+			continue
+		}
+		js := models.NewJobFromRequest(jsr)
+		js.Namespace = namespace
+		js, _, err := jsc.checkJobSpec(js)
+		if err == nil {
+			err = checkExternalInitiator(js, jsc.App.GetStore())
+		}
+		if err != nil {
+			validationErrors.Add(fmt.Sprintf("job spec %d: %v", i, err))
+			continue
+		}
+		if !start {
+			js.Status = models.JobSpecStatusStopped
+		}
+		jobs = append(jobs, js)
+	}
+	if err := validationErrors.CoerceEmptyToNil(); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := jsc.App.GetStore().CheckJobsQuota(namespace, len(jobs)); orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if err := jsc.App.AddJobs(jobs); orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	// External initiators are only told of jobs once they are all saved
+	var notifyErr error
+	for _, js := range jobs {
+		if err := NotifyExternalInitiator(js, jsc.App.GetStore()); err != nil {
+			notifyErr = multierr.Append(notifyErr, errors.Wrapf(err, "job %s", js.ID))
+		}
+	}
+	if notifyErr != nil {
+		jsonAPIError(c, http.StatusBadGateway, errors.Wrap(notifyErr, "the jobs were created, but notifying their external initiators failed"))
+		return
+	}
+
+	presented := make([]presenters.JobSpec, len(jobs))
+	for i, js := range jobs {
+		presented[i] = presenters.NewCreatedJobSpec(js)
+	}
+	jsonAPIResponse(c, presented, "jobs")
+}
+
+// readJobSpecDocuments returns each job spec given to c, and whether they
+// were given as a batch rather than as a lone JSON object, or responds with
+// an error if there are none, or if they are more than MaxJobSpecBatchSize
+// bytes between them.
+func readJobSpecDocuments(c *gin.Context) (documents []json.RawMessage, batch bool, ok bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.MaxJobSpecBatchSize)
+	body, err := c.GetRawData()
+	if err != nil {
+		jsonAPIError(c, http.StatusRequestEntityTooLarge, fmt.Errorf("job specs must be at most %d bytes between them: %v", models.MaxJobSpecBatchSize, err))
+		return nil, false, false
+	}
+	documents, batch, err = splitJobSpecDocuments(body)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return nil, false, false
+	} else if len(documents) == 0 {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("no job specs given"))
+		return nil, false, false
+	}
+	return documents, batch, true
+}
+
+// splitJobSpecDocuments returns each job spec of a JSON array, or of a
+// sequence of JSON documents, and whether there was an array or more than
+// one document.
+func splitJobSpecDocuments(body []byte) ([]json.RawMessage, bool, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var documents []json.RawMessage
+		return documents, true, json.Unmarshal(trimmed, &documents)
+	}
+	documents := []json.RawMessage{}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	for {
+		var document json.RawMessage
+		if err := decoder.Decode(&document); err == io.EOF {
+			return documents, len(documents) > 1, nil
+		} else if err != nil {
+			return nil, false, errors.Wrapf(err, "invalid job spec document %d", len(documents))
+		}
+		documents = append(documents, document)
+	}
+}
+
+// Update validates a JobSpec and replaces the spec of an existing job with
+// it, keeping the job's ID and runs. A job which had been started is
+// restarted with the new spec.
+// Example:
+//  "<application>/specs/:SpecID"
+func (jsc *JobSpecsController) Update(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if !jsc.findJobInNamespace(c, id) {
+		return
+	}
+
+	var jsr models.JobSpecRequest
+	if err := bindJobSpecRequest(c, &jsr); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	js := models.NewJobFromRequest(jsr)
+	js.ID = id
+	js.Namespace = requestNamespace(c)
+	jsc.update(c, js)
+}
+
+// update validates the job's new spec and replaces its spec with it,
+// responding with the updated job.
+func (jsc *JobSpecsController) update(c *gin.Context, js models.JobSpec) {
+	js, httpStatus, err := jsc.checkJobSpec(js)
+	if err != nil {
+		jsonAPIError(c, httpStatus, err)
+		return
+	}
+	if existing, err := jsc.findExternalJob(js); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	} else if existing != nil && existing.ID.String() != js.ID.String() {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("externalJobID %s is already used by job %s", js.ExternalJobID, existing.ID))
+		return
+	}
+	if err := NotifyExternalInitiator(js, jsc.App.GetStore()); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = jsc.App.UpdateJob(js)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	j, err := jsc.App.GetStore().FindJobWithErrors(js.ID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, showJobPresenter(jsc, j), "job")
+}
+
+// Duplicate creates a new job with the spec of an existing one, replacing
+// the values given in the request body, which maps paths of the spec to
+// their new values. Passing start=false saves the new job without starting
+// it. Passing a ttl makes the new job a temporary one, for trying out changes
+// against a production spec: it ends and is archived once the ttl has passed.
+// Example:
+//  "<application>/specs/:SpecID/duplicate"
+//  "<application>/specs/:SpecID/duplicate?ttl=2h"
+//  {"initiators.0.params.address": "0x356a04bCe728ba4c62A30294A55E6A8600a320B3"}
+func (jsc *JobSpecsController) Duplicate(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	start, err := strconv.ParseBool(c.DefaultQuery("start", "true"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid start parameter"))
+		return
+	}
+	var ttl time.Duration
+	if value := c.Query("ttl"); value != "" {
+		if ttl, err = time.ParseDuration(value); err != nil || ttl <= 0 {
+			jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid ttl parameter %q, must be a positive duration", value))
+			return
+		}
+	}
+	var overrides map[string]json.RawMessage
+	if body, err := c.GetRawData(); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	} else if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &overrides); err != nil {
+			jsonAPIError(c, http.StatusBadRequest, errors.Wrap(err, "overrides must be an object of spec paths to values"))
+			return
+		}
+	}
+
+	store := jsc.App.GetStore()
+	original, err := store.FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, original.Namespace)) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsr, err := models.DuplicateJobSpecRequest(original, overrides)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	js := models.NewJobFromRequest(jsr)
+	js.Namespace = requestNamespace(c)
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		js.ExpiresAt = null.TimeFrom(expiresAt)
+		if !js.EndAt.Valid || js.EndAt.Time.After(expiresAt) {
+			js.EndAt = null.TimeFrom(expiresAt)
+		}
+	}
+	js, httpStatus, err := jsc.checkJobSpec(js)
+	if err != nil {
+		jsonAPIError(c, httpStatus, err)
+		return
+	}
+	if err := store.CheckJobQuota(js.Namespace); orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !start {
+		js.Status = models.JobSpecStatusStopped
+	}
+	if err := NotifyExternalInitiator(js, store); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if err := jsc.App.AddJob(js); orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewCreatedJobSpec(js), "job")
+}
+
+// Preview validates a JobSpec and performs its tasks once, returning each
+// task's result or error, and an estimate of what running the job costs.
+// Nothing is saved and no transactions are sent.
+// The HTTP responses received are returned too if recordHTTP is set, and
+// httpFixtures recorded by an earlier preview are replayed if given.
+// Example:
+//  "<application>/job_spec_previews"
+func (jsc *JobSpecsController) Preview(c *gin.Context) {
+	var request models.JobSpecPreviewRequest
+	if err := bindJobSpecRequest(c, &request); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	js := models.NewJobFromRequest(request.JobSpecRequest)
+	js.Namespace = requestNamespace(c)
+	js, httpStatus, err := jsc.checkJobSpec(js)
+	if err != nil {
+		jsonAPIError(c, httpStatus, err)
+		return
+	}
+
+	var fixtures *adapters.HTTPFixtures
+	if len(request.HTTPFixtures) > 0 {
+		fixtures = adapters.NewHTTPReplayer(request.HTTPFixtures)
+	} else if request.RecordHTTP {
+		fixtures = adapters.NewHTTPRecorder()
+	}
+	preview := services.PreviewJob(jsc.App.GetStore(), js, request.Params, fixtures)
+	if request.RecordHTTP {
+		preview.HTTPFixtures = fixtures.Recorded()
+	}
+	jsonAPIResponse(c, preview, "preview")
+}
+
+// Validate checks JobSpecs as Create and CreateBatch would, without saving
+// them, so that specs kept elsewhere can be checked before they are deployed.
+// The JobSpecs, given as a JSON object, a JSON array or a sequence of JSON
+// documents, are returned if they are valid. Otherwise the response is a 422
+// listing each problem found, with the index of the JobSpec it was found in
+// as the "document" of its meta, and where it can be located its "line",
+// "column" and "key" in that JobSpec.
+// Example:
+//  "<application>/job_spec_validations"
+func (jsc *JobSpecsController) Validate(c *gin.Context) {
+	documents, _, ok := readJobSpecDocuments(c)
+	if !ok {
+		return
+	}
+
+	presented := make([]presenters.JobSpec, 0, len(documents))
+	problems := models.NewJSONAPIErrors()
+	for i, document := range documents {
+		var jsr models.JobSpecRequest
+		err := decodeJobSpecRequest(c, document, &jsr)
+		if err == nil {
+			js := models.NewJobFromRequest(jsr)
+			js.Namespace = requestNamespace(c)
+			if js, _, err = jsc.checkJobSpec(js); err == nil {
+				err = checkExternalInitiator(js, jsc.App.GetStore())
+			}
+			if err == nil {
+				presented = append(presented, presenters.JobSpec{JobSpec: js})
+				continue
+			}
+		}
+		from := len(problems.Errors)
+		problems.Merge(err)
+		for j := from; j < len(problems.Errors); j++ {
+			if problems.Errors[j].Meta == nil {
+				problems.Errors[j].Meta = map[string]interface{}{}
+			}
+			problems.Errors[j].Meta["document"] = i
+		}
+	}
+	if err := problems.CoerceEmptyToNil(); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	jsonAPIResponse(c, presented, "jobs")
+}
+
+// Lint checks JobSpecs without creating them, returning every problem found
+// in each along with the graph of its initiators and tasks. A lone JobSpec
+// gets a single lint in response, while a JSON array or a sequence of JSON
+// documents gets a list with a lint for each JobSpec, in order. A spec which
+// cannot be parsed is reported as a single problem. Keys which are not part
+// of a job spec are reported as problems, or as warnings with strict=false.
+// Example:
+//  "<application>/job_spec_lints"
+func (jsc *JobSpecsController) Lint(c *gin.Context) {
+	documents, batch, ok := readJobSpecDocuments(c)
+	if !ok {
+		return
+	}
+	lints := make([]presenters.JobSpecLint, len(documents))
+	for i, document := range documents {
+		lints[i] = jsc.lint(c, document)
+	}
+	if !batch {
+		jsonAPIResponse(c, lints[0], "lint")
+		return
+	}
+	jsonAPIResponse(c, lints, "lints")
+}
+
+func (jsc *JobSpecsController) lint(c *gin.Context, document []byte) presenters.JobSpecLint {
+	var jsr models.JobSpecRequest
+	unknown, err := models.DecodeJobSpecRequest(document, &jsr)
+	if err != nil {
+		return presenters.JobSpecLint{
+			ID:       models.NewID(),
+			Problems: []string{err.Error()},
+			Warnings: []string{},
+			Graph:    presenters.TaskGraph{Nodes: []presenters.TaskGraphNode{}, Edges: []presenters.TaskGraphEdge{}},
+		}
+	}
+	js := models.NewJobFromRequest(jsr)
+	js.Namespace = requestNamespace(c)
+	lint := services.LintJob(jsc.App.GetStore(), js)
+	if len(unknown) > 0 {
+		unknownErr := &models.UnknownKeysError{Keys: unknown}
+		if strictJobSpecs(c) {
+			lint.Problems = append(lint.Problems, unknownErr.Error())
+			lint.Valid = false
+		} else {
+			lint.Warnings = append(lint.Warnings, unknownErr.Error())
+		}
+	}
+	if err := jsc.requireImplemented(js); err != nil {
+		lint.Problems = append(lint.Problems, err.Error())
+		lint.Valid = false
+	}
+	return lint
 }
 
 // Show returns the details of a JobSpec.
@@ -103,10 +659,100 @@ func (jsc *JobSpecsController) Show(c *gin.Context) {
 	}
 
 	j, err := jsc.App.GetStore().FindJobWithErrors(id)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, j.Namespace)) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, showJobPresenter(jsc, j), "job")
+}
+
+// Start starts a JobSpec which was created in the stopped state.
+// Example:
+//  "<application>/specs/:SpecID/start"
+func (jsc *JobSpecsController) Start(c *gin.Context) {
+	jsc.changeStatus(c, jsc.App.StartJob)
+}
+
+// Pause stops triggering runs for an active JobSpec, without archiving it.
+// Example:
+//  "<application>/specs/:SpecID/pause"
+func (jsc *JobSpecsController) Pause(c *gin.Context) {
+	jsc.changeStatus(c, jsc.App.PauseJob)
+}
+
+// Resume starts triggering runs for a paused JobSpec again.
+// Example:
+//  "<application>/specs/:SpecID/resume"
+func (jsc *JobSpecsController) Resume(c *gin.Context) {
+	jsc.changeStatus(c, jsc.App.ResumeJob)
+}
+
+// changeStatus applies change to the job given by the request and responds
+// with the job, or with a conflict if change refuses the job's status.
+func (jsc *JobSpecsController) changeStatus(c *gin.Context, change func(*models.ID) error) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if !jsc.findJobInNamespace(c, id) {
+		return
+	}
+
+	err = change(id)
 	if errors.Cause(err) == orm.ErrorNotFound {
 		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
 		return
 	}
+	if err != nil {
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	}
+
+	j, err := jsc.App.GetStore().FindJobWithErrors(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, showJobPresenter(jsc, j), "job")
+}
+
+// Retry attempts to start a job spec which was quarantined after failing to
+// start on boot.
+// Example:
+//  "<application>/specs/:SpecID/retry"
+func (jsc *JobSpecsController) Retry(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	j, err := jsc.App.GetStore().FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, j.Namespace)) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	} else if !j.Quarantined() {
+		jsonAPIError(c, http.StatusConflict, errors.Errorf("job %s has not failed to start", id))
+		return
+	}
+
+	if err = jsc.App.RetryJob(id); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	j, err = jsc.App.GetStore().FindJobWithErrors(id)
 	if err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
@@ -125,6 +771,10 @@ func (jsc *JobSpecsController) Destroy(c *gin.Context) {
 		return
 	}
 
+	if !jsc.findJobInNamespace(c, id) {
+		return
+	}
+
 	err = jsc.App.ArchiveJob(id)
 	if errors.Cause(err) == orm.ErrorNotFound {
 		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
@@ -138,6 +788,105 @@ func (jsc *JobSpecsController) Destroy(c *gin.Context) {
 	jsonAPIResponseWithStatus(c, nil, "job", http.StatusNoContent)
 }
 
+// Restore brings back an archived job spec, with its runs. An ephemeral job
+// which has expired is not restored, as it would be archived again at once.
+// Example:
+//  "<application>/specs/:SpecID/restore"
+func (jsc *JobSpecsController) Restore(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := jsc.App.GetStore()
+	j, err := store.Unscoped().FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, j.Namespace)) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !j.Archived() {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("job %s is not archived", id))
+		return
+	}
+	if j.Expired(time.Now()) {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("job %s expired at %s", id, j.ExpiresAt.Time.Format(time.RFC3339)))
+		return
+	}
+	if existing, err := jsc.findExternalJob(j); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	} else if existing != nil {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("job %s has the same externalJobID", existing.ID))
+		return
+	}
+	// The job is checked as if it were created again, as the node and its
+	// namespace's policy may have changed since it was archived
+	if _, httpStatus, err := jsc.checkJobSpec(j); err != nil {
+		jsonAPIError(c, httpStatus, err)
+		return
+	}
+
+	if err := jsc.App.RestoreJob(id); orm.IsQuotaExceeded(err) {
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	j, err = store.FindJobWithErrors(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, showJobPresenter(jsc, j), "job")
+}
+
+// findJobInNamespace responds with an error and returns false unless the job
+// exists in the request's namespace.
+func (jsc *JobSpecsController) findJobInNamespace(c *gin.Context, id *models.ID) bool {
+	j, err := jsc.App.GetStore().FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, j.Namespace)) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return false
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return false
+	}
+	return true
+}
+
+// findExternalJob returns the job already created in the namespace of js
+// with its external job ID, if it has one and there is such a job.
+func (jsc *JobSpecsController) findExternalJob(js models.JobSpec) (*models.JobSpec, error) {
+	if js.ExternalJobID == nil {
+		return nil, nil
+	}
+	existing, err := jsc.App.GetStore().FindJobByExternalID(js.Namespace, *js.ExternalJobID)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		return nil, nil
+	}
+	return &existing, err
+}
+
+// respondWithExternalJob responds to a request to create js with the job
+// already created with its externalJobID, if js has the same spec, so that
+// retries succeed. A different spec reusing the ID is a conflict.
+func respondWithExternalJob(c *gin.Context, existing, js models.JobSpec) {
+	same, err := models.SameJobSpec(existing, js)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	} else if !same {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("externalJobID %s is already used by job %s, which has a different spec", js.ExternalJobID, existing.ID))
+		return
+	}
+	jsonAPIResponse(c, presenters.JobSpec{JobSpec: existing}, "job")
+}
+
 func showJobPresenter(jsc *JobSpecsController, job models.JobSpec) presenters.JobSpec {
 	store := jsc.App.GetStore()
 	jobLinkEarned, _ := store.LinkEarnedFor(&job)
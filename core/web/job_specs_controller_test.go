@@ -3,7 +3,10 @@ package web_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -11,14 +14,17 @@ import (
 	"github.com/smartcontractkit/chainlink/core/adapters"
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/smartcontractkit/chainlink/core/web"
 
 	"github.com/manyminds/api2go/jsonapi"
+	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v3"
 )
 
 func BenchmarkJobSpecsController_Index(b *testing.B) {
@@ -84,6 +90,33 @@ func TestJobSpecsController_Index_noSort(t *testing.T) {
 	assert.NotEqual(t, true, jobs[0].Initiators[0].Ran, "should ignore fields for other initiators")
 }
 
+func TestJobSpecsController_Index_filters(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	webJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&webJob))
+	runLog := cltest.NewJobWithRunLogInitiator()
+	require.NoError(t, app.Store.CreateJob(&runLog))
+
+	resp, cleanup := client.Get("/v2/specs?initiatorType=runlog&createdAfter=2020-01-01T00:00:00Z")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var links jsonapi.Links
+	jobs := []models.JobSpec{}
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &jobs, &links))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, runLog.ID, jobs[0].ID)
+
+	resp, cleanup = client.Get("/v2/specs?createdBefore=yesterday")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
 func TestJobSpecsController_Index_sortCreatedAt(t *testing.T) {
 	t.Parallel()
 
@@ -207,6 +240,53 @@ func TestJobSpecsController_Create_HappyPath(t *testing.T) {
 	assert.Equal(t, httpGet.GetURL(), "https://bitstamp.net/api/ticker/")
 }
 
+func TestJobSpecsController_Create_ExternalJobID(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	externalJobID := uuid.NewV4()
+	body := fmt.Sprintf(`{"externalJobID": "%s", "initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]}`, externalJobID)
+
+	resp, cleanup := client.Post("/v2/specs", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var created models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &created))
+	require.NotNil(t, created.ExternalJobID)
+	assert.Equal(t, externalJobID, *created.ExternalJobID)
+
+	resp, cleanup = client.Post("/v2/specs", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var retried models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &retried))
+	assert.Equal(t, created.ID, retried.ID)
+
+	// Reusing the ID for a different spec is a conflict
+	different := fmt.Sprintf(`{"externalJobID": "%s", "initiators": [{"type": "web"}], "tasks": [{"type": "noop"}, {"type": "noop"}]}`, externalJobID)
+	resp, cleanup = client.Post("/v2/specs", bytes.NewBufferString(different))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	count, err := app.Store.ORM.CountOf(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// A duplicate does not inherit the external job ID
+	resp, cleanup = client.Post("/v2/specs/"+created.ID.String()+"/duplicate", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var duplicate models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &duplicate))
+	assert.NotEqual(t, created.ID, duplicate.ID)
+	assert.Nil(t, duplicate.ExternalJobID)
+}
+
 func TestJobSpecsController_CreateExternalInitiator_Success(t *testing.T) {
 	t.Parallel()
 
@@ -246,6 +326,47 @@ func TestJobSpecsController_CreateExternalInitiator_Success(t *testing.T) {
 	cltest.WaitForJobRunToComplete(t, app.Store, jobRun)
 }
 
+func TestJobSpecsController_CreateBatch_ExternalInitiator(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	var notified []*models.ID
+	eiMockServer, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", "",
+		func(header http.Header, body string) {
+			var notice web.JobSpecNotice
+			require.NoError(t, json.Unmarshal([]byte(body), &notice))
+			_, err := app.Store.FindJob(notice.JobID)
+			assert.NoError(t, err, "external initiators are notified once the jobs are saved")
+			notified = append(notified, notice.JobID)
+		},
+	)
+	defer assertCalled()
+
+	url := cltest.WebURL(t, eiMockServer.URL)
+	ei, err := models.NewExternalInitiator(auth.NewToken(), &models.ExternalInitiatorRequest{Name: "someCoin", URL: &url})
+	require.NoError(t, err)
+	require.NoError(t, app.Store.CreateExternalInitiator(ei))
+
+	client := app.NewHTTPClient()
+	external := `{"initiators": [{"type": "external", "params": {"name": "somecoin", "body": {"foo": "bar"}}}], "tasks": [{"type": "noop"}]}`
+	unknown := `{"initiators": [{"type": "external", "params": {"name": "nosuchcoin", "body": {}}}], "tasks": [{"type": "noop"}]}`
+	resp, cleanup := client.Post("/v2/job_spec_batches", bytes.NewBufferString("["+external+","+unknown+"]"))
+	defer cleanup()
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Empty(t, notified)
+
+	resp, cleanup = client.Post("/v2/job_spec_batches", bytes.NewBufferString(external))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var jobs []models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &jobs))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, []*models.ID{jobs[0].ID}, notified)
+}
+
 func TestJobSpecsController_Create_CaseInsensitiveTypes(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
@@ -396,6 +517,139 @@ func TestJobSpecsController_Create_InvalidJob(t *testing.T) {
 	assert.Equal(t, expected, strings.TrimSpace(body))
 }
 
+func TestJobSpecsController_Create_UnknownKeys(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	body := `{"initiators": [{"type": "web"}], "tasks": [{"type": "noop", "confirmatoins": 2}], "endsAt": "2030-01-01T00:00:00Z"}`
+	resp, cleanup := client.Post("/v2/specs", bytes.NewBufferString(body))
+	defer cleanup()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Response should be caller error")
+	expected := `{"errors":[{"detail":"unknown keys in job spec: endsAt, tasks[0].confirmatoins"}]}`
+	assert.Equal(t, expected, strings.TrimSpace(string(cltest.ParseResponseBody(t, resp))))
+
+	count, err := app.Store.ORM.CountOf(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// With strict=false unknown keys are ignored
+	resp, cleanup = client.Post("/v2/specs?strict=false", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	count, err = app.Store.ORM.CountOf(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestJobSpecsController_Create_SyntaxError(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	body := "{\n  \"initiators\": [{\"type\": \"web\"}],\n  \"tasks\": [{\"type\": \"noop\"}],\n  \"finalResultOnly\": 1\n}"
+	resp, cleanup := client.Post("/v2/specs", bytes.NewBufferString(body))
+	defer cleanup()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var errs models.JSONAPIErrors
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &errs))
+	require.Len(t, errs.Errors, 1)
+	meta := errs.Errors[0].Meta
+	assert.Equal(t, float64(4), meta["line"])
+	assert.Equal(t, float64(22), meta["column"])
+	assert.Equal(t, "finalResultOnly", meta["key"])
+}
+
+func TestJobSpecsController_Validate(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	valid := `{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]}`
+	resp, cleanup := client.Post("/v2/job_spec_validations", bytes.NewBufferString(valid))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var jobs []models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &jobs))
+	require.Len(t, jobs, 1)
+
+	body := valid + "\n" +
+		`{"initiators": [{"type": "web"}], "tasks": [{"type": "noop", "confirmatoins": 2}]}` + "\n" +
+		"{\n  \"initiators\": [{\"type\": \"web\"}],\n  \"tasks\": [{\"type\": \"noop\"}],\n  \"finalResultOnly\": 1\n}" + "\n" +
+		`{"initiators": [{"type": "web"}], "tasks": []}`
+	resp, cleanup = client.Post("/v2/job_spec_validations", bytes.NewBufferString(body))
+	defer cleanup()
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	var errs models.JSONAPIErrors
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &errs))
+	require.Len(t, errs.Errors, 3)
+	assert.Equal(t, map[string]interface{}{"document": float64(1), "key": "tasks[0].confirmatoins"}, errs.Errors[0].Meta)
+	assert.Equal(t, map[string]interface{}{"document": float64(2), "line": float64(4), "column": float64(22), "key": "finalResultOnly"}, errs.Errors[1].Meta)
+	assert.Equal(t, map[string]interface{}{"document": float64(3)}, errs.Errors[2].Meta)
+
+	count, err := app.Store.ORM.CountOf(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Zero(t, count, "validated jobs should not be saved")
+}
+
+func TestJobSpecsController_CreateBatch(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	invalid := `[
+		{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]},
+		{"initiators": [{"type": "web"}], "tasks": [{"type": "noop", "confirmatoins": 2}]},
+		{"initiators": [{"type": "web"}], "tasks": []}
+	]`
+	resp, cleanup := client.Post("/v2/job_spec_batches", bytes.NewBufferString(invalid))
+	defer cleanup()
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	errs := models.JSONAPIErrors{}
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &errs))
+	require.Len(t, errs.Errors, 2)
+	assert.Equal(t, "job spec 1: unknown keys in job spec: tasks[0].confirmatoins", errs.Errors[0].Detail)
+	assert.Contains(t, errs.Errors[1].Detail, "job spec 2: ")
+
+	count, err := app.Store.ORM.CountOf(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Zero(t, count, "no job should be saved when any is invalid")
+
+	documents := `
+		{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]}
+		{"initiators": [{"type": "cron", "params": {"schedule": "CRON_TZ=UTC 0 0 * * *"}}], "tasks": [{"type": "noop"}]}
+	`
+	resp, cleanup = client.Post("/v2/job_spec_batches?start=false", bytes.NewBufferString(documents))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var jobs []models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &jobs))
+	require.Len(t, jobs, 2)
+	for _, j := range jobs {
+		saved, err := app.Store.FindJob(j.ID)
+		require.NoError(t, err)
+		assert.True(t, saved.Stopped())
+	}
+	assert.Equal(t, models.InitiatorCron, jobs[1].Initiators[0].Type)
+}
+
 func TestJobSpecsController_Create_InvalidCron(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
@@ -604,6 +858,264 @@ func TestJobSpecsController_Show_Unauthenticated(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "Response should be forbidden")
 }
 
+func TestJobSpecsController_Duplicate(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	job := cltest.NewJobWithRunLogInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "noop"), cltest.NewTask(t, "multiply", `{"times": 100}`)}
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	address := cltest.NewAddress()
+	body := fmt.Sprintf(`{"initiators.0.params.address": "%s", "tasks.1.params.times": 1000}`, address.Hex())
+	resp, cleanup := client.Post("/v2/specs/"+job.ID.String()+"/duplicate?start=false", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var duplicate presenters.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &duplicate))
+	assert.NotEqual(t, job.ID, duplicate.ID)
+
+	found, err := app.Store.FindJob(duplicate.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobSpecStatusStopped, found.Status)
+	require.Len(t, found.Initiators, 1)
+	assert.Equal(t, models.InitiatorRunLog, found.Initiators[0].Type)
+	assert.Equal(t, address, found.Initiators[0].Address)
+	require.Len(t, found.Tasks, 2)
+	assert.Equal(t, adapters.TaskTypeNoOp, found.Tasks[0].Type)
+	assert.Equal(t, int64(1000), found.Tasks[1].Params.Get("times").Int())
+
+	// The original is unchanged
+	original, err := app.Store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job.Initiators[0].Address, original.Initiators[0].Address)
+	assert.Equal(t, int64(100), original.Tasks[1].Params.Get("times").Int())
+}
+
+func TestJobSpecsController_Duplicate_TTL(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	before := time.Now()
+	resp, cleanup := client.Post("/v2/specs/"+job.ID.String()+"/duplicate?ttl=2h", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var duplicate presenters.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &duplicate))
+
+	found, err := app.Store.FindJob(duplicate.ID)
+	require.NoError(t, err)
+	require.True(t, found.Ephemeral())
+	assert.True(t, found.ExpiresAt.Time.After(before.Add(2*time.Hour-time.Second)))
+	assert.True(t, found.EndAt.Valid)
+	assert.Equal(t, found.ExpiresAt.Time.Unix(), found.EndAt.Time.Unix())
+
+	original, err := app.Store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.False(t, original.Ephemeral())
+
+	for _, ttl := range []string{"soon", "-1h", "0s"} {
+		resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/duplicate?ttl="+ttl, nil)
+		defer cleanup()
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, ttl)
+	}
+}
+
+func TestJobSpecsController_Duplicate_Errors(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	resp, cleanup := client.Post("/v2/specs/"+job.ID.String()+"/duplicate", bytes.NewBufferString(`{"initiators.0.params.adress": "0x0"}`))
+	defer cleanup()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, string(cltest.ParseResponseBody(t, resp)), "adress")
+
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/duplicate", bytes.NewBufferString(`["tasks"]`))
+	defer cleanup()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, cleanup = client.Post("/v2/specs/"+models.NewID().String()+"/duplicate", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	count, err := app.Store.ORM.CountOf(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestJobSpecsController_Update(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "noop")}
+	require.NoError(t, app.Store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+	require.NoError(t, app.Store.CreateJobRun(&run))
+
+	body := `{
+		"initiators": [{"type": "web"}],
+		"tasks": [{"type": "noop"}, {"name": "second", "type": "noop"}],
+		"finalResultOnly": true
+	}`
+	resp, cleanup := client.Patch("/v2/specs/"+job.ID.String(), bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var updated presenters.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &updated))
+	assert.Equal(t, job.ID, updated.ID)
+	assert.True(t, updated.FinalResultOnly)
+
+	found, err := app.Store.FindJob(job.ID)
+	require.NoError(t, err)
+	require.Len(t, found.Initiators, 1)
+	require.Len(t, found.Tasks, 2)
+	assert.Equal(t, "second", found.Tasks[1].Name)
+	assert.NotEqual(t, job.Tasks[0].ID, found.Tasks[0].ID)
+
+	// The earlier run keeps the task it was created with
+	run, err = app.Store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	require.Len(t, run.TaskRuns, 1)
+	assert.Equal(t, job.Tasks[0].ID, run.TaskRuns[0].TaskSpec.ID)
+
+	// New runs are created with the new spec
+	newRun := cltest.CreateJobRunViaWeb(t, app, found)
+	newRun = cltest.WaitForJobRunToComplete(t, app.Store, newRun)
+	assert.Len(t, newRun.TaskRuns, 2)
+
+	resp, cleanup = client.Patch("/v2/specs/"+job.ID.String(), bytes.NewBufferString(`{"initiators": [{"type": "web"}], "tasks": []}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	resp, cleanup = client.Patch("/v2/specs/"+models.NewID().String(), bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
+func TestJobSpecsController_Update_KeepsExternalJobIDAndExpiry(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	externalJobID := uuid.NewV4()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	job := cltest.NewJobWithWebInitiator()
+	job.ExternalJobID = &externalJobID
+	job.ExpiresAt = null.TimeFrom(expiresAt)
+	job.EndAt = null.TimeFrom(expiresAt)
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	body := `{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]}`
+	resp, cleanup := client.Patch("/v2/specs/"+job.ID.String(), bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	found, err := app.Store.FindJob(job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found.ExternalJobID)
+	assert.Equal(t, externalJobID, *found.ExternalJobID)
+	require.True(t, found.ExpiresAt.Valid)
+	assert.True(t, expiresAt.Equal(found.ExpiresAt.Time))
+	// The job may not run past its expiry
+	require.True(t, found.EndAt.Valid)
+	assert.True(t, expiresAt.Equal(found.EndAt.Time))
+
+	// Another job's externalJobID may not be taken
+	other := cltest.NewJobWithWebInitiator()
+	otherExternalJobID := uuid.NewV4()
+	other.ExternalJobID = &otherExternalJobID
+	require.NoError(t, app.Store.CreateJob(&other))
+	body = fmt.Sprintf(`{"externalJobID": "%s", "initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]}`, otherExternalJobID)
+	resp, cleanup = client.Patch("/v2/specs/"+job.ID.String(), bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+}
+
+func TestJobSpecsController_WebhookSecret(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	secret := "0123456789abcdef"
+	externalJobID := uuid.NewV4()
+	job := cltest.NewJob()
+	job.ExternalJobID = &externalJobID
+	job.Initiators = []models.Initiator{{
+		JobSpecID:       job.ID,
+		Type:            models.InitiatorWebhook,
+		InitiatorParams: models.InitiatorParams{WebhookSecret: secret},
+	}}
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "noop")}
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	// The secret is not part of the job's spec
+	resp, cleanup := client.Get("/v2/specs/" + job.ID.String() + "/export?format=spec")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.NotContains(t, string(cltest.ParseResponseBody(t, resp)), secret)
+
+	// A duplicate gets a secret of its own
+	overrides := fmt.Sprintf(`{"externalJobID": "%s"}`, uuid.NewV4())
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/duplicate?start=false", bytes.NewBufferString(overrides))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var duplicate presenters.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &duplicate))
+	found, err := app.Store.FindJob(duplicate.ID)
+	require.NoError(t, err)
+	require.Len(t, found.Initiators, 1)
+	assert.NotEmpty(t, found.Initiators[0].WebhookSecret)
+	assert.NotEqual(t, secret, found.Initiators[0].WebhookSecret)
+	// and returns it once, in the response creating it
+	assert.Equal(t, []string{found.Initiators[0].WebhookSecret}, duplicate.WebhookSecrets)
+
+	// An updated job keeps its secret
+	body := fmt.Sprintf(`{
+		"externalJobID": "%s",
+		"initiators": [{"type": "webhook", "params": {"rateLimit": 10}}],
+		"tasks": [{"type": "noop"}]
+	}`, externalJobID)
+	resp, cleanup = client.Patch("/v2/specs/"+job.ID.String(), bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	found, err = app.Store.FindJob(job.ID)
+	require.NoError(t, err)
+	require.Len(t, found.Initiators, 1)
+	assert.Equal(t, int32(10), found.Initiators[0].WebhookRateLimit)
+	assert.Equal(t, secret, found.Initiators[0].WebhookSecret)
+}
+
 func TestJobSpecsController_Destroy(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
@@ -621,6 +1133,91 @@ func TestJobSpecsController_Destroy(t *testing.T) {
 	assert.Equal(t, 0, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
 }
 
+func TestJobSpecsController_Restore(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	job := cltest.NewJobWithLogInitiator()
+	require.NoError(t, app.AddJob(job))
+
+	resp, cleanup := client.Post("/v2/specs/"+job.ID.String()+"/restore", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, cleanup = client.Delete("/v2/specs/" + job.ID.String())
+	defer cleanup()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, cleanup = client.Get("/v2/specs?includeArchived=true")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var archived []models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &archived))
+	require.Len(t, archived, 1)
+	assert.Equal(t, job.ID, archived[0].ID)
+
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/restore", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var restored presenters.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &restored))
+	assert.Equal(t, job.ID, restored.ID)
+
+	require.NoError(t, utils.JustError(app.Store.FindJob(job.ID)))
+	assert.Equal(t, 1, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
+
+	resp, cleanup = client.Post("/v2/specs/"+models.NewID().String()+"/restore", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	expired := cltest.NewJobWithWebInitiator()
+	expired.ExpiresAt = null.TimeFrom(time.Now().Add(-time.Minute))
+	require.NoError(t, app.Store.CreateJob(&expired))
+	require.NoError(t, app.Store.ArchiveJob(expired.ID))
+
+	resp, cleanup = client.Post("/v2/specs/"+expired.ID.String()+"/restore", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Error(t, utils.JustError(app.Store.FindJob(expired.ID)))
+}
+
+func TestJobSpecsController_Restore_ChecksJobAsCreated(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.AddJob(job))
+	require.NoError(t, app.ArchiveJob(job.ID))
+
+	// The namespace's policy no longer allows the job's tasks
+	policy := models.NamespaceSpecPolicy{AllowedTaskTypes: []models.TaskType{adapters.TaskTypeHTTPGet}}
+	require.NoError(t, app.Store.UpdateNamespaceSpecPolicy(models.DefaultNamespace, policy))
+	resp, cleanup := client.Post("/v2/specs/"+job.ID.String()+"/restore", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.NoError(t, app.Store.UpdateNamespaceSpecPolicy(models.DefaultNamespace, models.NamespaceSpecPolicy{}))
+
+	// The namespace is full
+	require.NoError(t, app.Store.UpdateNamespaceQuotas(models.DefaultNamespace, models.NamespaceQuotas{MaxJobs: clnull.Int64From(1)}))
+	other := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.AddJob(other))
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/restore", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Error(t, utils.JustError(app.Store.FindJob(job.ID)))
+
+	require.NoError(t, app.ArchiveJob(other.ID))
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/restore", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+}
+
 func TestJobSpecsController_Destroy_MultipleJobs(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
@@ -645,3 +1242,480 @@ func TestJobSpecsController_Destroy_MultipleJobs(t *testing.T) {
 	assert.Error(t, utils.JustError(app.Store.FindJob(job2.ID)))
 	assert.Equal(t, 0, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
 }
+
+func TestJobSpecsController_Create_Stopped(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/specs?start=false", bytes.NewBuffer(cltest.MustReadFile(t, "testdata/hello_world_job.json")))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var j models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &j))
+	assert.Equal(t, models.JobSpecStatusStopped, j.Status)
+
+	j, err := app.Store.FindJob(j.ID)
+	require.NoError(t, err)
+	assert.True(t, j.Stopped())
+
+	resp, cleanup = client.Post("/v2/specs?start=maybe", bytes.NewBuffer(cltest.MustReadFile(t, "testdata/hello_world_job.json")))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
+func TestJobSpecsController_Start(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	job := cltest.NewJobWithLogInitiator()
+	job.Status = models.JobSpecStatusStopped
+	require.NoError(t, app.AddJob(job))
+	assert.Equal(t, 0, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
+
+	resp, cleanup := client.Post("/v2/specs/"+job.ID.String()+"/start", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var j models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &j))
+	assert.Equal(t, models.JobSpecStatusActive, j.Status)
+	assert.Equal(t, 1, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
+
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/start", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Post("/v2/specs/"+models.NewID().String()+"/start", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
+func TestJobSpecsController_PauseAndResume(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	job := cltest.NewJobWithLogInitiator()
+	require.NoError(t, app.AddJob(job))
+	assert.Equal(t, 1, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
+
+	resp, cleanup := client.Post("/v2/specs/"+job.ID.String()+"/resume", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/pause", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var j models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &j))
+	assert.Equal(t, models.JobSpecStatusPaused, j.Status)
+	assert.Equal(t, 0, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
+
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/pause", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	_, err := app.Create(job.ID, &job.Initiators[0], nil, &models.RunRequest{})
+	assert.Error(t, err)
+
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/resume", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &j))
+	assert.Equal(t, models.JobSpecStatusActive, j.Status)
+	assert.Equal(t, 1, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
+
+	resp, cleanup = client.Post("/v2/specs/"+models.NewID().String()+"/pause", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
+func TestJobSpecsController_Retry(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	job := cltest.NewJobWithLogInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	resp, cleanup := client.Post("/v2/specs/"+job.ID.String()+"/retry", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	require.NoError(t, app.Store.QuarantineJob(job.ID, "unable to subscribe"))
+
+	resp, cleanup = client.Get("/v2/specs/" + job.ID.String())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var j models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &j))
+	assert.Equal(t, models.JobSpecStatusFailed, j.Status)
+	assert.Equal(t, "unable to subscribe", j.StatusReason)
+
+	resp, cleanup = client.Post("/v2/specs/"+job.ID.String()+"/retry", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &j))
+	assert.Equal(t, models.JobSpecStatusActive, j.Status)
+	assert.Empty(t, j.StatusReason)
+	assert.Equal(t, 1, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
+
+	resp, cleanup = client.Post("/v2/specs/"+models.NewID().String()+"/retry", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
+func TestJobSpecsController_Preview(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	app.EthMock.RegisterOptional("eth_gasPrice", "0x3b9aca00")
+
+	mockServer, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"last": "10.5"}`)
+	defer assertCalled()
+
+	body := fmt.Sprintf(`{
+		"initiators": [{"type": "web"}],
+		"tasks": [
+			{"type": "httpgetwithunrestrictednetworkaccess", "params": {"get": "%s"}},
+			{"type": "jsonparse", "params": {"path": ["last"]}},
+			{"type": "multiply"},
+			{"type": "ethuint256"},
+			{"type": "ethtx", "params": {"address": "0x356a04bCe728ba4c62A30294A55E6A8600a320B3", "functionSelector": "0x609ff1bd"}}
+		],
+		"params": {"times": 100}
+	}`, mockServer.URL)
+
+	resp, cleanup := client.Post("/v2/job_spec_previews", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var preview presenters.JobPreview
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &preview))
+	assert.Equal(t, models.RunStatusCompleted, preview.Status)
+	require.Len(t, preview.Tasks, 5)
+	assert.Equal(t, "10.5", preview.Tasks[1].Result.Get("result").String())
+	assert.Equal(t, "1050", preview.Tasks[2].Result.Get("result").String())
+	assert.Equal(t, models.RunStatusCompleted, preview.Tasks[3].Status)
+	assert.True(t, preview.Tasks[4].Skipped)
+	assert.Equal(t, adapters.TaskTypeEthTx, preview.Tasks[4].Type)
+
+	estimate := preview.Estimate
+	gasLimit := app.Store.Config.EthGasLimitDefault()
+	gasPrice := big.NewInt(1000000000)
+	assert.Equal(t, 2, estimate.RPCCallsPerRun)
+	assert.Equal(t, gasLimit, estimate.GasPerRun)
+	assert.Equal(t, gasPrice.String(), estimate.GasPrice.String())
+	assert.Equal(t, new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice).String(), estimate.WeiPerRun.String())
+	assert.Equal(t, 1, estimate.ExternalCallsPerRun)
+	assert.Zero(t, estimate.RunsPerDay)
+
+	count, err := app.Store.ORM.CountOf(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	count, err = app.Store.ORM.CountOf(&models.JobRun{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestJobSpecsController_Preview_EstimatesDailyCost(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	mockServer, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"last": "10.5"}`)
+	defer assertCalled()
+
+	body := fmt.Sprintf(`{
+		"initiators": [{"type": "cron", "params": {"schedule": "CRON_TZ=UTC 0 * * * *"}}],
+		"tasks": [
+			{"type": "httpgetwithunrestrictednetworkaccess", "params": {"get": "%[1]s"}},
+			{"type": "httpgetwithunrestrictednetworkaccess", "params": {"get": "%[1]s"}},
+			{"type": "delay", "params": {"duration": "1m"}},
+			{"type": "ethtx", "params": {"address": "0x356a04bCe728ba4c62A30294A55E6A8600a320B3", "functionSelector": "0x609ff1bd", "gasLimit": 50000, "gasPrice": "2"}}
+		]
+	}`, mockServer.URL)
+	resp, cleanup := client.Post("/v2/job_spec_previews", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var preview presenters.JobPreview
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &preview))
+	estimate := preview.Estimate
+	assert.Equal(t, uint64(50000), estimate.GasPerRun)
+	assert.Equal(t, "100000", estimate.WeiPerRun.String())
+	assert.GreaterOrEqual(t, int64(estimate.RunDuration.Duration()), int64(time.Minute))
+	assert.Equal(t, 2, estimate.ExternalCallsPerRun)
+	assert.InDelta(t, 24, estimate.RunsPerDay, 1)
+	assert.Equal(t, estimate.RunsPerDay*2, estimate.ExternalCallsPerDay)
+}
+
+func TestJobSpecsController_Preview_SkipsImpureTasks(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("preview sent a %s request to %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	body := fmt.Sprintf(`{
+		"initiators": [{"type": "web"}],
+		"tasks": [
+			{"type": "httppostwithunrestrictednetworkaccess", "params": {"post": "%s"}},
+			{"type": "nooppendoutgoing"},
+			{"type": "noop"}
+		]
+	}`, server.URL)
+	resp, cleanup := client.Post("/v2/job_spec_previews", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var preview presenters.JobPreview
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &preview))
+	assert.Equal(t, models.RunStatusCompleted, preview.Status)
+	require.Len(t, preview.Tasks, 3)
+	assert.True(t, preview.Tasks[0].Skipped, "a POST may change what the endpoint holds")
+	assert.True(t, preview.Tasks[1].Skipped)
+	assert.False(t, preview.Tasks[2].Skipped)
+}
+
+func TestJobSpecsController_Preview_RecordAndReplayHTTP(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	mockServer, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"last": "10.5"}`)
+	defer assertCalled()
+
+	spec := fmt.Sprintf(`
+		"initiators": [{"type": "web"}],
+		"tasks": [
+			{"type": "httpgetwithunrestrictednetworkaccess", "params": {"get": "%s"}},
+			{"type": "jsonparse", "params": {"path": ["last"]}}
+		]`, mockServer.URL)
+
+	resp, cleanup := client.Post("/v2/job_spec_previews", bytes.NewBufferString(`{`+spec+`, "recordHTTP": true}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var recorded presenters.JobPreview
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &recorded))
+	assert.Equal(t, models.RunStatusCompleted, recorded.Status)
+	require.Len(t, recorded.HTTPFixtures, 1)
+	assert.Equal(t, "GET", recorded.HTTPFixtures[0].Method)
+	assert.Equal(t, mockServer.URL, recorded.HTTPFixtures[0].URL)
+	assert.Equal(t, http.StatusOK, recorded.HTTPFixtures[0].StatusCode)
+	assert.JSONEq(t, `{"last": "10.5"}`, recorded.HTTPFixtures[0].ResponseBody)
+
+	// The mock server is closed, so the replayed response is the only one
+	// the preview can get
+	mockServer.Close()
+	fixtures := `[{"method": "GET", "url": "` + mockServer.URL + `", "statusCode": 200, "responseBody": "{\"last\": \"11\"}"}]`
+	resp, cleanup = client.Post("/v2/job_spec_previews", bytes.NewBufferString(`{`+spec+`, "httpFixtures": `+fixtures+`}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var replayed presenters.JobPreview
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &replayed))
+	assert.Equal(t, models.RunStatusCompleted, replayed.Status)
+	assert.Equal(t, "11", replayed.Tasks[1].Result.Get("result").String())
+	assert.Empty(t, replayed.HTTPFixtures)
+
+	// A request without a recorded response fails
+	fixtures = `[{"method": "GET", "url": "http://example.com", "statusCode": 200, "responseBody": "{}"}]`
+	resp, cleanup = client.Post("/v2/job_spec_previews", bytes.NewBufferString(`{`+spec+`, "httpFixtures": `+fixtures+`}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var unmatched presenters.JobPreview
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &unmatched))
+	assert.Equal(t, models.RunStatusErrored, unmatched.Status)
+	assert.Contains(t, unmatched.Tasks[0].Error, "no recorded response to GET "+mockServer.URL)
+}
+
+func TestJobSpecsController_Lint(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	t.Run("valid spec", func(t *testing.T) {
+		body := `{
+			"initiators": [{"type": "web"}],
+			"tasks": [
+				{"name": "price", "type": "noop"},
+				{"type": "merge", "params": {"values": {"price": "$(price.result)"}}}
+			]
+		}`
+		resp, cleanup := client.Post("/v2/job_spec_lints", bytes.NewBufferString(body))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var lint presenters.JobSpecLint
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &lint))
+		assert.True(t, lint.Valid)
+		assert.Empty(t, lint.Problems)
+		require.Len(t, lint.Graph.Nodes, 3)
+		assert.Equal(t, presenters.TaskGraphNode{ID: "task0", Kind: "task", Type: "noop", Name: "price"}, lint.Graph.Nodes[1])
+		assert.Equal(t, []presenters.TaskGraphEdge{
+			{From: "initiator0", To: "task0", Kind: "input"},
+			{From: "task0", To: "task1", Kind: "input"},
+			{From: "task0", To: "task1", Kind: "variable"},
+		}, lint.Graph.Edges)
+		assert.Contains(t, lint.DOT, "task0 -> task1 [style=dashed];")
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		body := `{
+			"initiators": [{"type": "web"}],
+			"tasks": [
+				{"type": "merge", "params": {"values": {"price": "$(later.result)"}}},
+				{"name": "later", "type": "nosuchadapter"}
+			]
+		}`
+		resp, cleanup := client.Post("/v2/job_spec_lints", bytes.NewBufferString(body))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var lint presenters.JobSpecLint
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &lint))
+		assert.False(t, lint.Valid)
+		require.Len(t, lint.Problems, 2)
+		assert.Contains(t, lint.Problems[0], "nosuchadapter")
+		assert.Equal(t, "task 0 refers to $(later.result), but no earlier task is named later", lint.Problems[1])
+		assert.Len(t, lint.Graph.Edges, 2)
+	})
+
+	t.Run("unknown keys", func(t *testing.T) {
+		body := `{"initiators": [{"type": "web"}], "tasks": [{"type": "noop", "confirmatoins": 2}]}`
+		resp, cleanup := client.Post("/v2/job_spec_lints", bytes.NewBufferString(body))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var lint presenters.JobSpecLint
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &lint))
+		assert.False(t, lint.Valid)
+		assert.Equal(t, []string{"unknown keys in job spec: tasks[0].confirmatoins"}, lint.Problems)
+
+		resp, cleanup = client.Post("/v2/job_spec_lints?strict=false", bytes.NewBufferString(body))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		lint = presenters.JobSpecLint{}
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &lint))
+		assert.True(t, lint.Valid)
+		assert.Empty(t, lint.Problems)
+		assert.Equal(t, []string{"unknown keys in job spec: tasks[0].confirmatoins"}, lint.Warnings)
+	})
+
+	t.Run("unparsable spec", func(t *testing.T) {
+		resp, cleanup := client.Post("/v2/job_spec_lints", bytes.NewBufferString(`{"tasks": "noop"}`))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var lint presenters.JobSpecLint
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &lint))
+		assert.False(t, lint.Valid)
+		assert.Len(t, lint.Problems, 1)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		body := `[
+			{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]},
+			{"initiators": [{"type": "web"}], "tasks": [{"type": "noop", "confirmatoins": 2}]},
+			{"initiators": [], "tasks": [{"type": "nosuchadapter"}]}
+		]`
+		resp, cleanup := client.Post("/v2/job_spec_lints", bytes.NewBufferString(body))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var lints []presenters.JobSpecLint
+		require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &lints))
+		require.Len(t, lints, 3)
+		assert.True(t, lints[0].Valid)
+		assert.Equal(t, []string{"unknown keys in job spec: tasks[0].confirmatoins"}, lints[1].Problems)
+		assert.False(t, lints[2].Valid)
+		require.Len(t, lints[2].Problems, 2)
+		assert.Equal(t, "Must have at least one Initiator and one Task", lints[2].Problems[0])
+		assert.Contains(t, lints[2].Problems[1], "nosuchadapter")
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		body := `{"initiators": [{"type": "web"}], "tasks": [{"type": "noop", "params": {"padding": "` +
+			strings.Repeat("x", int(models.MaxJobSpecBatchSize)) + `"}}]}`
+		resp, cleanup := client.Post("/v2/job_spec_lints", bytes.NewBufferString(body))
+		defer cleanup()
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	count, err := app.Store.ORM.CountOf(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestJobSpecsController_Preview_StopsAtError(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	mockServer, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"last": "10.5"}`)
+	defer assertCalled()
+
+	body := fmt.Sprintf(`{
+		"initiators": [{"type": "web"}],
+		"tasks": [
+			{"type": "httpgetwithunrestrictednetworkaccess", "params": {"get": "%s"}},
+			{"type": "jsonparse", "params": {"path": ["missing"]}},
+			{"type": "noop"}
+		]
+	}`, mockServer.URL)
+
+	resp, cleanup := client.Post("/v2/job_spec_previews", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var preview presenters.JobPreview
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &preview))
+	assert.Equal(t, models.RunStatusErrored, preview.Status)
+	require.Len(t, preview.Tasks, 3)
+	assert.Equal(t, models.RunStatusErrored, preview.Tasks[1].Status)
+	assert.NotEmpty(t, preview.Tasks[1].Error)
+	assert.True(t, preview.Tasks[2].Skipped)
+
+	resp, cleanup = client.Post("/v2/job_spec_previews", bytes.NewBufferString(`{"initiators": [{"type": "web"}], "tasks": []}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+}
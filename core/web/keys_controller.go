@@ -38,6 +38,10 @@ func (kc *KeysController) Create(c *gin.Context) {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
+	if err := kc.App.GetStore().SetKeyNamespace(account.Address, requestNamespace(c)); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
 
 	jsonAPIResponseWithStatus(c, presenters.NewAccount{Account: &account}, "account", http.StatusCreated)
 }
@@ -0,0 +1,278 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+const (
+	// NamespaceHeader is the header name used to select the namespace an API
+	// request acts on. Requests without it act on the default namespace.
+	NamespaceHeader = "X-Chainlink-Namespace"
+	// namespaceKey is the namespace key in the request context
+	namespaceKey = "namespace"
+)
+
+// RequireNamespace resolves the namespace of an authenticated request.
+// External initiators and the API tokens of namespaces are always confined
+// to their own namespace, and requests selecting another are forbidden; the
+// node's user selects one with the NamespaceHeader.
+func RequireNamespace(store *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ei, ok := authenticatedEI(c); ok {
+			c.Set(namespaceKey, ei.Namespace)
+			c.Next()
+			return
+		}
+		if bound, ok := tokenNamespace(c); ok {
+			if name := c.GetHeader(NamespaceHeader); name != "" && name != bound {
+				jsonAPIError(c, http.StatusForbidden, fmt.Errorf("the API token is confined to namespace %s", bound))
+				c.Abort()
+				return
+			}
+			c.Set(namespaceKey, bound)
+			c.Next()
+			return
+		}
+
+		name := c.GetHeader(NamespaceHeader)
+		if name == "" || name == models.DefaultNamespace {
+			c.Set(namespaceKey, models.DefaultNamespace)
+			c.Next()
+			return
+		}
+
+		_, err := store.FindNamespace(name)
+		if errors.Cause(err) == orm.ErrorNotFound {
+			jsonAPIError(c, http.StatusNotFound, fmt.Errorf("namespace %s not found", name))
+			c.Abort()
+			return
+		} else if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			c.Abort()
+			return
+		}
+		c.Set(namespaceKey, name)
+		c.Next()
+	}
+}
+
+// requestNamespace returns the namespace resolved by RequireNamespace.
+func requestNamespace(c *gin.Context) string {
+	if name := c.GetString(namespaceKey); name != "" {
+		return name
+	}
+	return models.DefaultNamespace
+}
+
+// outsideNamespace reports whether a record in namespace is hidden from the
+// request. Such records are reported as not found.
+func outsideNamespace(c *gin.Context, namespace string) bool {
+	return namespace != requestNamespace(c)
+}
+
+// NamespacesController manages the namespaces which partition a node
+// between the teams sharing it.
+type NamespacesController struct {
+	App chainlink.Application
+}
+
+// Index lists every namespace.
+// Example:
+//  "<application>/namespaces"
+func (nc *NamespacesController) Index(c *gin.Context) {
+	namespaces, err := nc.App.GetStore().Namespaces()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, namespaces, "namespaces")
+}
+
+// Create adds a namespace.
+// Example:
+//  "<application>/namespaces"
+func (nc *NamespacesController) Create(c *gin.Context) {
+	var request models.NamespaceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	ns, err := models.NewNamespace(request.Name)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := request.NamespaceQuotas.Validate(); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := request.SpecPolicy.Validate(); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	ns.NamespaceQuotas = request.NamespaceQuotas
+	ns.SpecPolicy = request.SpecPolicy
+
+	store := nc.App.GetStore()
+	if _, err := store.FindNamespace(ns.Name); err == nil {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("namespace %s already exists", ns.Name))
+		return
+	} else if errors.Cause(err) != orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if err := store.CreateNamespace(&ns); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, ns, "namespace", http.StatusCreated)
+}
+
+// Update replaces the quotas of a namespace. Quotas left out of the request
+// are removed.
+// Example:
+//  "<application>/namespaces/:Name"
+func (nc *NamespacesController) Update(c *gin.Context) {
+	var quotas models.NamespaceQuotas
+	if err := c.ShouldBindJSON(&quotas); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err := quotas.Validate(); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	store := nc.App.GetStore()
+	err := store.UpdateNamespaceQuotas(c.Param("Name"), quotas)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("namespace not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	ns, err := store.FindNamespace(c.Param("Name"))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, ns, "namespace")
+}
+
+// UpdateSpecPolicy replaces the policy the jobs of a namespace must satisfy.
+// Jobs created before are not checked against the new policy.
+// Example:
+//  "<application>/namespaces/:Name/spec_policy"
+func (nc *NamespacesController) UpdateSpecPolicy(c *gin.Context) {
+	var policy models.NamespaceSpecPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err := policy.Validate(); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	store := nc.App.GetStore()
+	err := store.UpdateNamespaceSpecPolicy(c.Param("Name"), policy)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("namespace not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	ns, err := store.FindNamespace(c.Param("Name"))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, ns, "namespace")
+}
+
+// AddKey moves one of the node's keys into a namespace, so that only the jobs
+// of that namespace may send transactions from it.
+// Example:
+//  "<application>/namespaces/:Name/keys"
+func (nc *NamespacesController) AddKey(c *gin.Context) {
+	var request models.NamespaceKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := nc.App.GetStore()
+	ns, err := store.FindNamespace(c.Param("Name"))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("namespace not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = store.SetKeyNamespace(request.Address.Address(), ns.Name)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, fmt.Errorf("no key %s in the keystore", request.Address))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, ns, "namespace")
+}
+
+// CreateToken creates an API token confined to a namespace, whose secret is
+// returned only this once.
+// Example:
+//  "<application>/namespaces/:Name/tokens"
+func (nc *NamespacesController) CreateToken(c *gin.Context) {
+	store := nc.App.GetStore()
+	ns, err := store.FindNamespace(c.Param("Name"))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("namespace not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	token := auth.NewToken()
+	nt, err := models.NewNamespaceToken(ns.Name, token)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if err := store.CreateNamespaceToken(&nt); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, token, "auth_token", http.StatusCreated)
+}
+
+// DestroyToken deletes an API token of a namespace.
+// Example:
+//  "<application>/namespaces/:Name/tokens/:AccessKey"
+func (nc *NamespacesController) DestroyToken(c *gin.Context) {
+	err := nc.App.GetStore().DeleteNamespaceToken(c.Param("Name"), c.Param("AccessKey"))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("token not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, nil, "auth_token", http.StatusNoContent)
+}
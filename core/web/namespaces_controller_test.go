@@ -0,0 +1,346 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/auth"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createNamespace(t *testing.T, client cltest.HTTPClientCleaner, name string) *http.Response {
+	resp, cleanup := client.Post("/v2/namespaces", bytes.NewBufferString(fmt.Sprintf(`{"name": "%s"}`, name)))
+	defer cleanup()
+	return resp
+}
+
+func specsMetaCount(t *testing.T, client cltest.HTTPClientCleaner) int {
+	resp, cleanup := client.Get("/v2/specs")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	count, err := cltest.ParseJSONAPIResponseMetaCount(cltest.ParseResponseBody(t, resp))
+	require.NoError(t, err)
+	return count
+}
+
+func TestNamespacesController_Create(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	cltest.AssertServerResponse(t, createNamespace(t, client, "team-a"), http.StatusCreated)
+	cltest.AssertServerResponse(t, createNamespace(t, client, "team-a"), http.StatusConflict)
+	cltest.AssertServerResponse(t, createNamespace(t, client, "Team A"), http.StatusBadRequest)
+
+	resp, cleanup := client.Get("/v2/namespaces")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var namespaces []models.Namespace
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &namespaces))
+	require.Len(t, namespaces, 2)
+	assert.Equal(t, models.DefaultNamespace, namespaces[0].Name)
+	assert.Equal(t, "team-a", namespaces[1].Name)
+}
+
+func TestNamespaces_IsolateJobsAndBridges(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	cltest.AssertServerResponse(t, createNamespace(t, client, "team-a"), http.StatusCreated)
+	app.Config.Set("CLIENT_NAMESPACE", "team-a")
+
+	resp, cleanup := client.Post("/v2/bridge_types", bytes.NewBufferString(`{"name": "teamabridge", "url": "http://localhost:8080"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	jobJSON := `{"initiators": [{"type": "web"}], "tasks": [{"type": "teamabridge"}]}`
+	resp, cleanup = client.Post("/v2/specs", bytes.NewBufferString(jobJSON))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var job models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &job))
+	assert.Equal(t, "team-a", job.Namespace)
+	assert.Equal(t, 1, specsMetaCount(t, client))
+
+	// The default namespace can see neither the job nor the bridge, nor use it
+	app.Config.Set("CLIENT_NAMESPACE", "")
+	assert.Equal(t, 0, specsMetaCount(t, client))
+
+	resp, cleanup = client.Get("/v2/specs/" + job.ID.String())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = client.Get("/v2/bridge_types/teamabridge")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = client.Post("/v2/specs", bytes.NewBufferString(jobJSON))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	app.Config.Set("CLIENT_NAMESPACE", "unknown")
+	resp, cleanup = client.Get("/v2/specs")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
+func TestNamespacesController_Tokens(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	cltest.AssertServerResponse(t, createNamespace(t, client, "team-a"), http.StatusCreated)
+	resp, cleanup := client.Post("/v2/namespaces/team-a/tokens", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	var token auth.Token
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &token))
+
+	request := func(method, path, namespace, body string) *http.Response {
+		req, err := http.NewRequest(method, app.Server.URL+path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set(web.APIKey, token.AccessKey)
+		req.Header.Set(web.APISecret, token.Secret)
+		if namespace != "" {
+			req.Header.Set(web.NamespaceHeader, namespace)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// The token acts on its own namespace
+	resp = request(http.MethodPost, "/v2/specs", "", `{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]}`)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var job models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &job))
+	assert.Equal(t, "team-a", job.Namespace)
+	cltest.AssertServerResponse(t, request(http.MethodGet, "/v2/specs", "team-a", ""), http.StatusOK)
+
+	// And may neither select another namespace nor manage the node
+	cltest.AssertServerResponse(t, request(http.MethodGet, "/v2/specs", models.DefaultNamespace, ""), http.StatusForbidden)
+	cltest.AssertServerResponse(t, request(http.MethodGet, "/v2/namespaces", "", ""), http.StatusForbidden)
+	cltest.AssertServerResponse(t, request(http.MethodPost, "/v2/namespaces/team-a/tokens", "", ""), http.StatusForbidden)
+	cltest.AssertServerResponse(t, request(http.MethodPatch, "/v2/config", "", `{"ethGasPriceDefault": "1"}`), http.StatusForbidden)
+
+	resp, cleanup = client.Delete("/v2/namespaces/team-a/tokens/" + token.AccessKey)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+	cltest.AssertServerResponse(t, request(http.MethodGet, "/v2/specs", "", ""), http.StatusUnauthorized)
+}
+
+func TestNamespaces_IsolateKeys(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	account, err := app.Store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+	cltest.AssertServerResponse(t, createNamespace(t, client, "team-a"), http.StatusCreated)
+	app.Config.Set("CLIENT_NAMESPACE", "team-a")
+
+	ethTxJob := func(fromAddresses string) string {
+		return fmt.Sprintf(`{"initiators": [{"type": "web"}], "tasks": [{"type": "ethtx", "params": {
+			"address": "%s", "functionSelector": "0x12345678"%s}}]}`, cltest.NewAddress().Hex(), fromAddresses)
+	}
+	withFromAddress := ethTxJob(fmt.Sprintf(`, "fromAddresses": ["%s"]`, account.Address.Hex()))
+
+	resp, cleanup := client.Post("/v2/specs", bytes.NewBufferString(ethTxJob("")))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	resp, cleanup = client.Post("/v2/specs", bytes.NewBufferString(withFromAddress))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	resp, cleanup = client.Post("/v2/namespaces/team-a/keys", bytes.NewBufferString(fmt.Sprintf(`{"address": "%s"}`, account.Address.Hex())))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	resp, cleanup = client.Post("/v2/specs", bytes.NewBufferString(withFromAddress))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	key, err := app.Store.KeyByAddress(account.Address)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", key.Namespace)
+}
+
+func TestNamespaces_IsolateRuns(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	cltest.AssertServerResponse(t, createNamespace(t, client, "team-a"), http.StatusCreated)
+	job := cltest.NewJobWithWebInitiator()
+	job.Namespace = "team-a"
+	require.NoError(t, app.Store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+	require.NoError(t, app.Store.CreateJobRun(&run))
+	app.Store.UpsertErrorFor(job.ID, "team-a error")
+	jse, err := app.Store.FindJobSpecError(job.ID, "team-a error")
+	require.NoError(t, err)
+
+	// The default namespace can neither see nor act on team-a's runs
+	runsCount := func() int {
+		resp, cleanup := client.Get("/v2/runs")
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+		count, err := cltest.ParseJSONAPIResponseMetaCount(cltest.ParseResponseBody(t, resp))
+		require.NoError(t, err)
+		return count
+	}
+	assert.Equal(t, 0, runsCount())
+
+	for _, path := range []string{
+		"/v2/runs?jobSpecId=" + job.ID.String(),
+		"/v2/runs/" + run.ID.String(),
+		"/v2/runs/" + run.ID.String() + "/profile",
+	} {
+		resp, cleanup := client.Get(path)
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	}
+	for _, path := range []string{
+		"/v2/runs/" + run.ID.String() + "/cancellation",
+		"/v2/runs/" + run.ID.String() + "/replay",
+		fmt.Sprintf("/v2/job_spec_errors/%d/acknowledgement", jse.ID),
+	} {
+		resp, cleanup := client.Put(path, nil)
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	}
+	resp, cleanup := client.Delete(fmt.Sprintf("/v2/job_spec_errors/%d", jse.ID))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = client.Get("/v2/stats")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var stats models.NodeStats
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	assert.Empty(t, stats.Jobs)
+	assert.Zero(t, stats.JobSpecErrors)
+
+	app.Config.Set("CLIENT_NAMESPACE", "team-a")
+	assert.Equal(t, 1, runsCount())
+
+	resp, cleanup = client.Get("/v2/runs/" + run.ID.String())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	resp, cleanup = client.Put(fmt.Sprintf("/v2/job_spec_errors/%d/acknowledgement", jse.ID), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+}
+
+func TestNamespacesController_Update(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Patch("/v2/namespaces/default", bytes.NewBufferString(`{"maxJobs": -1}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	resp, cleanup = client.Patch("/v2/namespaces/nope", bytes.NewBufferString(`{"maxJobs": 1}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = client.Patch("/v2/namespaces/default", bytes.NewBufferString(`{"maxJobs": 1}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var ns models.Namespace
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &ns))
+	assert.Equal(t, int64(1), ns.MaxJobs.Int64)
+	assert.False(t, ns.MaxRunsPerMinute.Valid)
+
+	jobJSON := `{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]}`
+	resp, cleanup = client.Post("/v2/specs", bytes.NewBufferString(jobJSON))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	resp, cleanup = client.Post("/v2/specs", bytes.NewBufferString(jobJSON))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusTooManyRequests)
+}
+
+func TestNamespacesController_UpdateSpecPolicy(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	cltest.AssertServerResponse(t, createNamespace(t, client, "feeds"), http.StatusCreated)
+
+	resp, cleanup := client.Put("/v2/namespaces/feeds/spec_policy", bytes.NewBufferString(`{"allowedMethods": ["DELETE"]}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	policy := `{"allowedURLs": ["https://api.example.com/*"], "allowedMethods": ["GET"], "allowedTaskTypes": ["httpget", "jsonparse", "noop"]}`
+	resp, cleanup = client.Put("/v2/namespaces/nope/spec_policy", bytes.NewBufferString(policy))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = client.Put("/v2/namespaces/feeds/spec_policy", bytes.NewBufferString(policy))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var ns models.Namespace
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &ns))
+	assert.Equal(t, []string{"https://api.example.com/*"}, ns.SpecPolicy.AllowedURLs)
+	assert.Equal(t, []string{"GET"}, ns.SpecPolicy.AllowedMethods)
+	assert.Len(t, ns.SpecPolicy.AllowedTaskTypes, 3)
+
+	app.Config.Set("CLIENT_NAMESPACE", "feeds")
+	tests := []struct {
+		name   string
+		tasks  string
+		status int
+	}{
+		{"allowed URL", `[{"type": "httpget", "params": {"get": "https://api.example.com/price"}}, {"type": "jsonparse"}]`, http.StatusOK},
+		{"internal URL", `[{"type": "httpget", "params": {"get": "http://10.0.0.1/price"}}]`, http.StatusBadRequest},
+		{"URL from the run", `[{"type": "httpget"}]`, http.StatusBadRequest},
+		{"disallowed task type", `[{"type": "noop"}, {"type": "ethtx"}]`, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		jobJSON := fmt.Sprintf(`{"initiators": [{"type": "web"}], "tasks": %s}`, test.tasks)
+		resp, cleanup := client.Post("/v2/specs", bytes.NewBufferString(jobJSON))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, test.status)
+	}
+
+	// Other namespaces are not restricted
+	app.Config.Set("CLIENT_NAMESPACE", "")
+	resp, cleanup = client.Post("/v2/specs", bytes.NewBufferString(`{"initiators": [{"type": "web"}], "tasks": [{"type": "httpget", "params": {"get": "http://10.0.0.1/price"}}]}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+}
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/fleetsync"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// recentRunsPeriod is how far back the runs counted in a node's stats go.
+const recentRunsPeriod = 24 * time.Hour
+
+// NodeStatsController reports the health of the node, and of the fleet of
+// nodes it belongs to.
+type NodeStatsController struct {
+	App chainlink.Application
+}
+
+// FleetStats are the stats of the node and of each of its FLEET_PEERS, along
+// with their totals over the nodes which could be reached.
+type FleetStats struct {
+	Node   models.NodeStats      `json:"node"`
+	Peers  []fleetsync.PeerStats `json:"peers"`
+	Totals models.NodeStats      `json:"totals"`
+}
+
+// Show returns the stats of the node, as plain JSON. Its jobs, runs and job
+// spec errors are counted in the request's namespace only.
+// Example:
+//  "<application>/stats"
+func (nsc *NodeStatsController) Show(c *gin.Context) {
+	stats, err := nsc.nodeStats(requestNamespace(c))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// Fleet returns the stats of the node along with those fetched from each of
+// its FLEET_PEERS, as plain JSON. A peer which cannot be reached is reported
+// with its error, and left out of the totals. The peers count every job of
+// their default namespace, so the fleet's stats are only available in the
+// default namespace.
+// Example:
+//  "<application>/stats/fleet"
+func (nsc *NodeStatsController) Fleet(c *gin.Context) {
+	if outsideNamespace(c, models.DefaultNamespace) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("fleet stats are only available in the default namespace"))
+		return
+	}
+	config := nsc.App.GetStore().Config
+	peers := config.FleetPeers()
+	if len(peers) == 0 {
+		jsonAPIError(c, http.StatusNotFound, errors.New("no FLEET_PEERS are configured"))
+		return
+	}
+	stats, err := nsc.nodeStats(models.DefaultNamespace)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	fleet := FleetStats{
+		Node:   stats,
+		Peers:  fleetsync.FetchPeerStats(peers, config.DefaultHTTPTimeout().Duration()),
+		Totals: models.NodeStats{Version: stats.Version},
+	}
+	fleet.Totals.Add(stats)
+	for _, peer := range fleet.Peers {
+		if peer.Stats != nil {
+			fleet.Totals.Add(*peer.Stats)
+		}
+	}
+	c.JSON(http.StatusOK, fleet)
+}
+
+func (nsc *NodeStatsController) nodeStats(namespace string) (models.NodeStats, error) {
+	stats, err := nsc.App.GetStore().NodeStats(namespace, time.Now().Add(-recentRunsPeriod))
+	stats.Version = store.Version
+	stats.EthRPCCalls = eth.RPCCalls()
+	return stats, err
+}
@@ -0,0 +1,108 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/web"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeStatsController_Show(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+	run.SetStatus(models.RunStatusCompleted)
+	require.NoError(t, app.Store.CreateJobRun(&run))
+	app.Store.UpsertErrorFor(job.ID, "job spec error")
+	require.NoError(t, app.Store.IdempotentInsertHead(models.Head{Hash: cltest.NewHash(), Number: 42}))
+
+	resp, cleanup := client.Get("/v2/stats")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var stats models.NodeStats
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &stats))
+	assert.Equal(t, 1, stats.Jobs[models.JobSpecStatusActive])
+	assert.Equal(t, 1, stats.RecentRuns[models.RunStatusCompleted])
+	assert.Equal(t, 1, stats.JobSpecErrors)
+	require.NotNil(t, stats.LatestHead)
+	assert.GreaterOrEqual(t, *stats.LatestHead, int64(42))
+	assert.Greater(t, stats.DatabaseSize, int64(0))
+}
+
+func TestNodeStatsController_Fleet(t *testing.T) {
+	t.Parallel()
+
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/stats", r.URL.Path)
+		if r.Header.Get("X-API-KEY") != "peerkey" || r.Header.Get("X-API-SECRET") != "peersecret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"version": "peer", "jobs": {"active": 2}, "recentRuns": {"completed": 5}, "jobSpecErrors": 3, "latestHead": 100}`))
+	}))
+	defer peer.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	peerURL := strings.Replace(peer.URL, "http://", "http://peerkey:peersecret@", 1)
+	config, cfgCleanup := cltest.NewConfig(t)
+	defer cfgCleanup()
+	config.Set("FLEET_PEERS", peerURL+", "+unreachable.URL)
+	app, cleanup := cltest.NewApplicationWithConfig(t, config, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	resp, cleanup := client.Get("/v2/stats/fleet")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var fleet web.FleetStats
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &fleet))
+	assert.Equal(t, 1, fleet.Node.Jobs[models.JobSpecStatusActive])
+	require.Len(t, fleet.Peers, 2)
+	assert.Equal(t, peer.URL, fleet.Peers[0].URL)
+	require.NotNil(t, fleet.Peers[0].Stats)
+	assert.Equal(t, "peer", fleet.Peers[0].Stats.Version)
+	assert.Equal(t, unreachable.URL, fleet.Peers[1].URL)
+	assert.Nil(t, fleet.Peers[1].Stats)
+	assert.NotEmpty(t, fleet.Peers[1].Error)
+
+	assert.Equal(t, 3, fleet.Totals.Jobs[models.JobSpecStatusActive])
+	assert.Equal(t, 5, fleet.Totals.RecentRuns[models.RunStatusCompleted])
+	assert.Equal(t, 3, fleet.Totals.JobSpecErrors)
+	require.NotNil(t, fleet.Totals.LatestHead)
+	assert.Equal(t, int64(100), *fleet.Totals.LatestHead)
+}
+
+func TestNodeStatsController_Fleet_NoPeers(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/stats/fleet")
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,480 @@
+package web
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/auth"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/guregu/null.v3"
+)
+
+// openAPIOperation annotates a route of the API for its OpenAPI description.
+type openAPIOperation struct {
+	Summary string
+	// Paginated routes take the size and page params, and the cursor param as
+	// well if Cursor is set.
+	Paginated bool
+	Cursor    bool
+	// Public routes need no session or API token.
+	Public bool
+	// Request is the value the route binds its body to and Response the
+	// value it responds with, from whose types the schemas of the body and
+	// response are derived. Responses are JSON:API documents presenting
+	// Response, or Response itself when Plain is set.
+	Request  interface{}
+	Response interface{}
+	Plain    bool
+}
+
+// openAPIOperations annotates each route of the API by its method and path.
+// The paths of the OpenAPI document are taken from the routes the router
+// has, so every route is described, with these annotations adding what
+// cannot be read from the router.
+var openAPIOperations = map[string]openAPIOperation{
+	"POST /sessions":   {Summary: "Sign in, starting a session", Public: true, Request: models.SessionRequest{}, Response: Session{}},
+	"DELETE /sessions": {Summary: "Sign out, ending the session", Response: Session{}},
+
+	"GET /v2/openapi.json": {Summary: "Get this OpenAPI description of the API", Public: true},
+
+	"PATCH /v2/user/password":              {Summary: "Change the user's password", Request: models.ChangePasswordRequest{}, Response: presenters.UserPresenter{}},
+	"GET /v2/user/balances":                {Summary: "List the ETH and LINK balances of the node's accounts", Response: []presenters.AccountBalance{}},
+	"POST /v2/user/token":                  {Summary: "Create an API token", Request: models.ChangeAuthTokenRequest{}, Response: auth.Token{}},
+	"POST /v2/user/token/delete":           {Summary: "Delete the API token", Request: models.ChangeAuthTokenRequest{}},
+	"POST /v2/external_initiators":         {Summary: "Create an external initiator", Request: models.ExternalInitiatorRequest{}, Response: presenters.ExternalInitiatorAuthentication{}},
+	"DELETE /v2/external_initiators/:Name": {Summary: "Delete an external initiator"},
+
+	"POST /v2/external_initiators/:Name/keys": {Summary: "Rotate the signing keys of an external initiator", Request: models.ExternalInitiatorKeyRotationRequest{}, Response: presenters.ExternalInitiatorAuthentication{}},
+
+	"POST /v2/specs":                             {Summary: "Create a job spec", Request: models.JobSpecRequest{}, Response: presenters.JobSpec{}},
+	"GET /v2/specs":                              {Summary: "List job specs", Paginated: true, Cursor: true, Response: []presenters.JobSpec{}},
+	"GET /v2/specs/:SpecID":                      {Summary: "Get a job spec", Response: presenters.JobSpec{}},
+	"PATCH /v2/specs/:SpecID":                    {Summary: "Update a job spec", Request: models.JobSpecRequest{}, Response: presenters.JobSpec{}},
+	"POST /v2/specs/:SpecID/start":               {Summary: "Start a stopped job", Response: presenters.JobSpec{}},
+	"POST /v2/specs/:SpecID/pause":               {Summary: "Pause a job", Response: presenters.JobSpec{}},
+	"POST /v2/specs/:SpecID/resume":              {Summary: "Resume a paused job", Response: presenters.JobSpec{}},
+	"POST /v2/specs/:SpecID/retry":               {Summary: "Retry a job's errored runs"},
+	"POST /v2/specs/:SpecID/duplicate":           {Summary: "Create a copy of a job spec with some values replaced", Request: map[string]interface{}{}, Response: presenters.JobSpec{}},
+	"DELETE /v2/specs/:SpecID":                   {Summary: "Archive a job spec"},
+	"POST /v2/specs/:SpecID/restore":             {Summary: "Restore an archived job spec", Response: presenters.JobSpec{}},
+	"GET /v2/specs/:SpecID/export":               {Summary: "Export a job spec", Response: models.JobExport{}, Plain: true},
+	"GET /v2/specs/:SpecID/runs.csv":             {Summary: "Export a job's runs as CSV"},
+	"POST /v2/specs/:SpecID/runs":                {Summary: "Run a job", Request: models.JSON{}, Response: presenters.JobRun{}},
+	"POST /v2/job_spec_previews":                 {Summary: "Perform a job spec's tasks once without saving it", Request: models.JobSpecPreviewRequest{}, Response: presenters.JobPreview{}},
+	"POST /v2/job_spec_lints":                    {Summary: "Lint job specs and render their task graphs", Request: models.JobSpecRequest{}, Response: []presenters.JobSpecLint{}},
+	"POST /v2/job_spec_validations":              {Summary: "Validate job specs without saving them", Request: models.JobSpecRequest{}, Response: []presenters.JobSpec{}},
+	"POST /v2/job_spec_batches":                  {Summary: "Create many job specs at once", Request: []models.JobSpecRequest{}, Response: []presenters.JobSpec{}},
+	"POST /v2/job_imports":                       {Summary: "Import an exported job spec", Request: models.JobExport{}, Response: presenters.JobSpec{}},
+	"GET /v2/specs/:SpecID/errors":               {Summary: "List a job's errors", Response: []models.JobSpecError{}},
+	"DELETE /v2/job_spec_errors/:jobSpecErrorID": {Summary: "Dismiss a job spec error"},
+
+	"PUT /v2/job_spec_errors/:jobSpecErrorID/acknowledgement": {Summary: "Acknowledge a job spec error", Response: models.JobSpecError{}},
+
+	"GET /v2/specs/:SpecID/versions":                    {Summary: "List the versions of a job's spec", Response: []models.JobSpecVersion{}},
+	"POST /v2/specs/:SpecID/versions/:Version/rollback": {Summary: "Roll a job back to an earlier version of its spec", Response: presenters.JobSpec{}},
+	"POST /v2/specs/:SpecID/webhook_secret":             {Summary: "Rotate the secrets of a webhook job's initiators, keeping the previous ones valid for an overlap", Request: models.WebhookSecretRotationRequest{}, Response: models.WebhookSecretRotation{}},
+
+	"GET /v2/job_spec_templates":              {Summary: "List job spec templates", Response: []models.JobSpecTemplate{}},
+	"POST /v2/job_spec_templates":             {Summary: "Create a job spec template with {{variable}} placeholders", Request: models.JobSpecTemplateRequest{}, Response: models.JobSpecTemplate{}},
+	"DELETE /v2/job_spec_templates/:Name":     {Summary: "Delete a job spec template"},
+	"POST /v2/job_spec_templates/:Name/specs": {Summary: "Create a job from a template and the values of its variables", Request: models.JobSpecFromTemplateRequest{}, Response: presenters.JobSpec{}},
+	"POST /v2/job_spec_generators/:Pattern":   {Summary: "Generate a job spec following a common pattern", Request: models.JSON{}, Response: models.JobSpecRequest{}, Plain: true},
+
+	"GET /v2/runs":                      {Summary: "List job runs", Paginated: true, Cursor: true, Response: []presenters.JobRun{}},
+	"GET /v2/runs/:RunID":               {Summary: "Get a job run", Response: presenters.JobRun{}},
+	"GET /v2/runs/:RunID/profile":       {Summary: "Download the CPU or heap profile of a run created with profile=true"},
+	"PATCH /v2/runs/:RunID":             {Summary: "Resume a pending run with a bridge's result", Public: true, Request: models.BridgeRunResult{}, Response: presenters.JobRun{}},
+	"PUT /v2/runs/:RunID/cancellation":  {Summary: "Cancel a job run", Response: presenters.JobRun{}},
+	"PUT /v2/runs/:RunID/replay":        {Summary: "Replay a job run", Response: presenters.JobRun{}},
+	"GET /v2/jobs/:SpecID/runs/ws":      {Summary: "Stream a job's run updates over a websocket"},
+	"POST /v2/jobs/:ExternalJobID/runs": {Summary: "Run a webhook job with a signed request", Public: true, Request: models.JSON{}, Response: presenters.JobRun{}},
+	"GET /v2/run_events/ws":             {Summary: "Stream the creation, completion and failure of runs over a websocket"},
+	"DELETE /v2/bulk_delete_runs":       {Summary: "Delete runs in bulk", Request: models.BulkDeleteRunRequest{}},
+
+	"POST /v2/service_agreements":      {Summary: "Create a service agreement", Public: true, Request: models.ServiceAgreementRequest{}, Response: presenters.ServiceAgreement{}},
+	"GET /v2/service_agreements/:SAID": {Summary: "Get a service agreement", Response: presenters.ServiceAgreement{}},
+
+	"GET /v2/fleet_bundle":    {Summary: "Export the node's jobs and bridges for another node", Response: models.FleetBundle{}, Plain: true},
+	"GET /v2/stats/providers": {Summary: "Get statistics on the node's data providers", Response: ProviderStats{}, Plain: true},
+	"GET /v2/stats/latencies": {Summary: "Get the latencies and adaptive timeouts of the node's data sources", Response: []utils.SourceLatencyStats{}, Plain: true},
+	"GET /v2/stats/bridges":   {Summary: "Get the errors returned by each bridge, by category", Response: []adapters.BridgeErrorStats{}, Plain: true},
+	"GET /v2/stats":           {Summary: "Get the node's jobs, recent runs, errors, latest head, database size and ethereum calls", Response: models.NodeStats{}, Plain: true},
+	"GET /v2/stats/fleet":     {Summary: "Get the stats of the node and of its fleet peers", Response: FleetStats{}, Plain: true},
+
+	"GET /v2/bridge_types":                {Summary: "List bridges", Paginated: true, Response: []models.BridgeType{}},
+	"POST /v2/bridge_types":               {Summary: "Create a bridge", Request: models.BridgeTypeRequest{}, Response: models.BridgeTypeAuthentication{}},
+	"GET /v2/bridge_types/:BridgeName":    {Summary: "Get a bridge", Response: models.BridgeType{}},
+	"PATCH /v2/bridge_types/:BridgeName":  {Summary: "Update a bridge", Request: models.BridgeTypeRequest{}, Response: models.BridgeType{}},
+	"DELETE /v2/bridge_types/:BridgeName": {Summary: "Delete a bridge", Response: models.BridgeType{}},
+
+	"POST /v2/transfers":     {Summary: "Transfer ETH or LINK from the node's account", Request: models.SendEtherRequest{}, Response: presenters.Tx{}},
+	"POST /v2/registrations": {Summary: "Register the node with an oracle contract", Request: models.RegistrationRequest{}, Response: []presenters.RegistrationTx{}},
+
+	"GET /v2/funds_sweeps":                   {Summary: "List funds sweeps", Response: []models.FundsSweep{}},
+	"POST /v2/funds_sweeps":                  {Summary: "Propose a funds sweep", Request: models.FundsSweepRequest{}, Response: models.FundsSweep{}},
+	"POST /v2/funds_sweeps/:SweepID/approve": {Summary: "Approve a funds sweep", Request: models.FundsSweepReviewRequest{}, Response: models.FundsSweep{}},
+	"POST /v2/funds_sweeps/:SweepID/reject":  {Summary: "Reject a funds sweep", Request: models.FundsSweepReviewRequest{}, Response: models.FundsSweep{}},
+
+	"GET /v2/emergency/transmission-halts":    {Summary: "List the halts of the node's transmissions", Response: []models.TransmissionHalt{}},
+	"POST /v2/emergency/stop-transmissions":   {Summary: "Halt all new transactions and OCR transmissions", Request: models.TransmissionHaltRequest{}, Response: models.TransmissionHalt{}},
+	"POST /v2/emergency/resume-transmissions": {Summary: "Resume halted transmissions", Request: models.TransmissionResumeRequest{}, Response: models.TransmissionHalt{}},
+
+	"GET /v2/transaction_allowlist":             {Summary: "List the contracts added to the transaction allowlist", Response: []models.TransactionAllowlistEntry{}},
+	"POST /v2/transaction_allowlist":            {Summary: "Allow transactions to a contract", Request: models.TransactionAllowlistRequest{}, Response: models.TransactionAllowlistEntry{}},
+	"DELETE /v2/transaction_allowlist/:Address": {Summary: "Remove a contract from the transaction allowlist"},
+
+	"POST /v2/keys": {Summary: "Create an Ethereum key (development mode only)", Request: models.CreateKeyRequest{}, Response: presenters.NewAccount{}},
+
+	"GET /v2/namespaces":                   {Summary: "List namespaces", Response: []models.Namespace{}},
+	"POST /v2/namespaces":                  {Summary: "Create a namespace", Request: models.NamespaceRequest{}, Response: models.Namespace{}},
+	"PATCH /v2/namespaces/:Name":           {Summary: "Update a namespace", Request: models.NamespaceQuotas{}, Response: models.Namespace{}},
+	"PUT /v2/namespaces/:Name/spec_policy": {Summary: "Replace the policy a namespace's jobs must satisfy", Request: models.NamespaceSpecPolicy{}, Response: models.Namespace{}},
+	"POST /v2/namespaces/:Name/keys":       {Summary: "Give a namespace an Ethereum key", Request: models.NamespaceKeyRequest{}, Response: models.Namespace{}},
+	"POST /v2/namespaces/:Name/tokens":     {Summary: "Create an API token confined to a namespace", Response: auth.Token{}},
+
+	"DELETE /v2/namespaces/:Name/tokens/:AccessKey": {Summary: "Delete an API token of a namespace"},
+
+	"GET /v2/worker_groups":                        {Summary: "List worker groups and their jobs", Response: []models.WorkerGroup{}},
+	"POST /v2/worker_groups":                       {Summary: "Create a worker group capping how many runs of its jobs execute at once, and so how many of the shared database connections they use", Request: models.WorkerGroupRequest{}, Response: models.WorkerGroup{}},
+	"PATCH /v2/worker_groups/:Name":                {Summary: "Change the concurrency of a worker group", Request: models.WorkerGroupRequest{}, Response: models.WorkerGroup{}},
+	"DELETE /v2/worker_groups/:Name":               {Summary: "Delete a worker group, returning its jobs to the shared pool"},
+	"PUT /v2/worker_groups/:Name/specs/:SpecID":    {Summary: "Move a job into a worker group", Response: models.WorkerGroup{}},
+	"DELETE /v2/worker_groups/:Name/specs/:SpecID": {Summary: "Move a job out of a worker group", Response: models.WorkerGroup{}},
+
+	"GET /v2/identity":             {Summary: "Get the node's identity", Response: presenters.NodeIdentity{}},
+	"GET /v2/config":               {Summary: "Get the node's configuration", Response: presenters.ConfigPrinter{}},
+	"PATCH /v2/config":             {Summary: "Update the node's configuration", Request: configPatchRequest{}, Response: ConfigPatchResponse{}},
+	"GET /v2/ping":                 {Summary: "Check authentication", Response: map[string]string{}, Plain: true},
+	"GET /v2/tx_attempts":          {Summary: "List transaction attempts", Paginated: true, Response: []models.TxAttempt{}},
+	"GET /v2/transactions":         {Summary: "List transactions", Paginated: true, Response: []presenters.Tx{}},
+	"GET /v2/transactions.csv":     {Summary: "Export the transactions of the namespace's keys as CSV"},
+	"GET /v2/transactions/:TxHash": {Summary: "Get a transaction", Response: presenters.Tx{}},
+}
+
+var openAPIPathParam = regexp.MustCompile(`:(\w+)`)
+
+// OpenAPIDocument returns an OpenAPI 3 description of the given routes. Only
+// the API's routes, under /v2 and /sessions, are described.
+func OpenAPIDocument(routes gin.RoutesInfo) map[string]interface{} {
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Path < routes[j].Path || (routes[i].Path == routes[j].Path && routes[i].Method < routes[j].Method)
+	})
+
+	schemas := openAPISchemas{}
+	paths := map[string]interface{}{}
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, "/v2/") && route.Path != "/sessions" {
+			continue
+		}
+		annotation := openAPIOperations[route.Method+" "+route.Path]
+		path := openAPIPathParam.ReplaceAllString(route.Path, "{$1}")
+
+		parameters := []interface{}{}
+		for _, match := range openAPIPathParam.FindAllStringSubmatch(route.Path, -1) {
+			parameters = append(parameters, map[string]interface{}{
+				"name": match[1], "in": "path", "required": true,
+				"schema": map[string]interface{}{"type": "string"},
+			})
+		}
+		if annotation.Paginated {
+			for _, param := range []struct{ name, typ, description string }{
+				{"size", "integer", "The number of records in a page"},
+				{"page", "integer", "The page, counting from 1"},
+				{"cursor", "string", "The nextCursor of the previous page, empty for the first page"},
+			} {
+				if param.name == "cursor" && !annotation.Cursor {
+					continue
+				}
+				parameters = append(parameters, map[string]interface{}{
+					"name": param.name, "in": "query", "description": param.description,
+					"schema": map[string]interface{}{"type": param.typ},
+				})
+			}
+		}
+
+		responseType := MediaType
+		if annotation.Plain {
+			responseType = "application/json"
+		}
+		operation := map[string]interface{}{
+			"summary":     annotation.Summary,
+			"operationId": openAPIOperationID(route.Method, route.Path),
+			"tags":        []string{openAPITag(route.Path)},
+			"parameters":  parameters,
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "OK",
+					"content": map[string]interface{}{
+						responseType: map[string]interface{}{"schema": schemas.response(annotation)},
+					},
+				},
+				"default": map[string]interface{}{
+					"description": "An error",
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{"schema": map[string]interface{}{"$ref": "#/components/schemas/Errors"}},
+					},
+				},
+			},
+		}
+		if route.Method == http.MethodPost || route.Method == http.MethodPatch || route.Method == http.MethodPut {
+			operation["requestBody"] = map[string]interface{}{
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": schemas.request(annotation)},
+				},
+			}
+		}
+		if annotation.Public {
+			operation["security"] = []interface{}{}
+		}
+
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Chainlink node API",
+			"version": store.Version,
+		},
+		"paths": paths,
+		"security": []interface{}{
+			map[string]interface{}{"session": []string{}},
+			map[string]interface{}{"apiKey": []string{}, "apiSecret": []string{}},
+		},
+		"components": map[string]interface{}{
+			"securitySchemes": map[string]interface{}{
+				"session":   map[string]interface{}{"type": "apiKey", "in": "cookie", "name": SessionName},
+				"apiKey":    map[string]interface{}{"type": "apiKey", "in": "header", "name": APIKey},
+				"apiSecret": map[string]interface{}{"type": "apiKey", "in": "header", "name": APISecret},
+			},
+			"schemas": schemas.with(map[string]interface{}{
+				"Errors": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"errors": map[string]interface{}{
+							"type": "array",
+							"items": map[string]interface{}{
+								"type":       "object",
+								"properties": map[string]interface{}{"detail": map[string]interface{}{"type": "string"}},
+							},
+						},
+					},
+				},
+			}),
+		},
+	}
+}
+
+// openAPISchemas holds the schemas of the named types described so far,
+// which are referred to as components of the document.
+type openAPISchemas map[string]interface{}
+
+var (
+	openAPIJSONMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	openAPITextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// openAPIKnownSchemas describes the types whose JSON is neither that of
+	// their fields nor text.
+	openAPIKnownSchemas = map[reflect.Type]map[string]interface{}{
+		reflect.TypeOf(time.Time{}):       {"type": "string", "format": "date-time"},
+		reflect.TypeOf(null.Time{}):       {"type": "string", "format": "date-time", "nullable": true},
+		reflect.TypeOf(null.String{}):     {"type": "string", "nullable": true},
+		reflect.TypeOf(null.Int{}):        {"type": "integer", "nullable": true},
+		reflect.TypeOf(null.Float{}):      {"type": "number", "nullable": true},
+		reflect.TypeOf(null.Bool{}):       {"type": "boolean", "nullable": true},
+		reflect.TypeOf(clnull.Int64{}):    {"type": "integer", "nullable": true},
+		reflect.TypeOf(clnull.Uint32{}):   {"type": "integer", "nullable": true},
+		reflect.TypeOf(models.JSON{}):     {"type": "object"},
+		reflect.TypeOf(models.Duration{}): {"type": "string", "example": "1m30s"},
+		reflect.TypeOf(models.WebURL{}):   {"type": "string", "format": "uri"},
+	}
+)
+
+// with returns the schemas described along with the ones given.
+func (schemas openAPISchemas) with(others map[string]interface{}) map[string]interface{} {
+	for name, schema := range others {
+		schemas[name] = schema
+	}
+	return schemas
+}
+
+// request returns the schema of the operation's request body.
+func (schemas openAPISchemas) request(annotation openAPIOperation) map[string]interface{} {
+	if annotation.Request == nil {
+		return map[string]interface{}{"type": "object"}
+	}
+	return schemas.of(reflect.TypeOf(annotation.Request))
+}
+
+// response returns the schema of the operation's response, the JSON:API
+// document presenting its Response as resources unless it is Plain.
+func (schemas openAPISchemas) response(annotation openAPIOperation) map[string]interface{} {
+	if annotation.Response == nil {
+		return map[string]interface{}{"type": "object"}
+	}
+	t := reflect.TypeOf(annotation.Response)
+	if annotation.Plain {
+		return schemas.of(t)
+	}
+	resource := func(t reflect.Type) map[string]interface{} {
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"id":         map[string]interface{}{"type": "string"},
+				"type":       map[string]interface{}{"type": "string"},
+				"attributes": schemas.of(t),
+			},
+		}
+	}
+	data := resource(t)
+	if t.Kind() == reflect.Slice {
+		data = map[string]interface{}{"type": "array", "items": resource(t.Elem())}
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"data": data},
+	}
+}
+
+// of returns the schema of the JSON encoding of values of type t. Named
+// structs are described once, as components which are referred to by the
+// name of their package and type, such as models.JobSpecRequest.
+func (schemas openAPISchemas) of(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if schema, ok := openAPIKnownSchemas[t]; ok {
+		return schema
+	}
+	if t.Implements(openAPITextMarshaler) || reflect.PtrTo(t).Implements(openAPITextMarshaler) {
+		return map[string]interface{}{"type": "string"}
+	}
+	marshaler := t.Implements(openAPIJSONMarshaler) || reflect.PtrTo(t).Implements(openAPIJSONMarshaler)
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return schemas.object(t)
+		}
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := schemas[name]; !ok {
+			schemas[name] = map[string]interface{}{}
+			schema := schemas.object(t)
+			if properties, _ := schema["properties"].(map[string]interface{}); marshaler && len(properties) == 0 {
+				// Marshaled from unexported fields
+				schema = map[string]interface{}{}
+			}
+			schemas[name] = schema
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	if marshaler {
+		return map[string]interface{}{}
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": schemas.of(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemas.of(t.Elem())}
+	}
+	return map[string]interface{}{}
+}
+
+// object returns the schema of a struct, whose properties are its exported
+// fields named as encoding/json names them, with the fields of embedded
+// structs promoted.
+func (schemas openAPISchemas) object(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if _, known := openAPIKnownSchemas[fieldType]; !known {
+				embedded := schemas.object(fieldType)
+				for name, schema := range embedded["properties"].(map[string]interface{}) {
+					if _, ok := properties[name]; !ok {
+						properties[name] = schema
+					}
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = schemas.of(field.Type)
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+// openAPIOperationID names an operation after its method and path, such as
+// postSpecsSpecIDStart for POST /v2/specs/:SpecID/start.
+func openAPIOperationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.FieldsFunc(strings.TrimPrefix(path, "/v2"), func(r rune) bool {
+		return r == '/' || r == '_' || r == '.' || r == ':'
+	}) {
+		id += strings.ToUpper(segment[:1]) + segment[1:]
+	}
+	return id
+}
+
+// openAPITag groups operations by the first segment of their path.
+func openAPITag(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/v2"), "/")
+	return segments[1]
+}
+
+// OpenAPIController serves the OpenAPI description of the API.
+type OpenAPIController struct {
+	Engine *gin.Engine
+}
+
+// Show returns the OpenAPI 3 description of the API's routes.
+// Example:
+//  "<application>/v2/openapi.json"
+func (oc *OpenAPIController) Show(c *gin.Context) {
+	c.JSON(http.StatusOK, OpenAPIDocument(oc.Engine.Routes()))
+}
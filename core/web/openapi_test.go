@@ -0,0 +1,102 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/web"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIController_Show(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	resp, err := http.Get(app.Server.URL + "/v2/openapi.json")
+	require.NoError(t, err)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var document struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			Summary     string `json:"summary"`
+			OperationID string `json:"operationId"`
+			Parameters  []struct {
+				Name string `json:"name"`
+				In   string `json:"in"`
+			} `json:"parameters"`
+			Security    []interface{} `json:"security"`
+			RequestBody struct {
+				Content map[string]struct {
+					Schema map[string]interface{} `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+			Responses map[string]struct {
+				Content map[string]struct {
+					Schema map[string]interface{} `json:"schema"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Type       string                            `json:"type"`
+				Properties map[string]map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &document))
+	assert.Equal(t, "3.0.3", document.OpenAPI)
+
+	for path, item := range document.Paths {
+		for method, operation := range item {
+			assert.NotEmpty(t, operation.Summary, "%s %s has no summary in openAPIOperations", method, path)
+		}
+	}
+
+	start := document.Paths["/v2/specs/{SpecID}/start"]["post"]
+	assert.Equal(t, "postSpecsSpecIDStart", start.OperationID)
+	require.Len(t, start.Parameters, 1)
+	assert.Equal(t, "SpecID", start.Parameters[0].Name)
+	assert.Equal(t, "path", start.Parameters[0].In)
+	assert.Nil(t, start.Security)
+
+	var params []string
+	for _, param := range document.Paths["/v2/runs"]["get"].Parameters {
+		params = append(params, param.Name)
+	}
+	assert.Equal(t, []string{"size", "page", "cursor"}, params)
+
+	create := document.Paths["/v2/specs"]["post"]
+	request := create.RequestBody.Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/models.JobSpecRequest", request["$ref"])
+	jobSpecRequest := document.Components.Schemas["models.JobSpecRequest"]
+	assert.Equal(t, "object", jobSpecRequest.Type)
+	assert.Equal(t, "array", jobSpecRequest.Properties["initiators"]["type"])
+	assert.Contains(t, jobSpecRequest.Properties, "tasks")
+
+	response := create.Responses["200"].Content[web.MediaType].Schema
+	data := response["properties"].(map[string]interface{})["data"].(map[string]interface{})
+	attributes := data["properties"].(map[string]interface{})["attributes"].(map[string]interface{})
+	assert.Equal(t, "#/components/schemas/presenters.JobSpec", attributes["$ref"])
+	jobSpec := document.Components.Schemas["presenters.JobSpec"]
+	assert.Contains(t, jobSpec.Properties, "earnings", "the presenter's own fields")
+	assert.Contains(t, jobSpec.Properties, "initiators", "the fields of the embedded job")
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, jobSpec.Properties["createdAt"])
+
+	runs := document.Paths["/v2/runs"]["get"].Responses["200"].Content[web.MediaType].Schema
+	data = runs["properties"].(map[string]interface{})["data"].(map[string]interface{})
+	assert.Equal(t, "array", data["type"])
+
+	stats := document.Paths["/v2/stats"]["get"].Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/models.NodeStats", stats["$ref"])
+
+	assert.NotNil(t, document.Paths["/v2/openapi.json"]["get"].Security, "the description itself needs no authentication")
+	assert.NotContains(t, document.Paths, "/debug/vars")
+}
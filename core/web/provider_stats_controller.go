@@ -0,0 +1,53 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProviderStatsController reports the requests the node has sent to each
+// external API provider, to help operators plan their subscriptions.
+type ProviderStatsController struct {
+	App chainlink.Application
+}
+
+// ProviderStats are the request counts of each host the node has sent
+// requests to in the last week, and the usage of each configured quota.
+type ProviderStats struct {
+	Providers []utils.ProviderUsage `json:"providers"`
+	Quotas    []utils.QuotaUsage    `json:"quotas"`
+}
+
+// Show returns the request counts of each provider by hour and by day, as
+// plain JSON.
+// Example:
+//  "<application>/stats/providers"
+func (psc *ProviderStatsController) Show(c *gin.Context) {
+	providers, quotas := utils.ProviderRequests.Usage(time.Now(), psc.App.GetStore().Config.ProviderQuotas())
+	c.JSON(http.StatusOK, ProviderStats{Providers: providers, Quotas: quotas})
+}
+
+// Latencies returns the latency percentiles of the recent requests sent to
+// each source, in milliseconds, with the timeouts derived from them when
+// adaptive timeouts are enabled, as plain JSON.
+// Example:
+//  "<application>/stats/latencies"
+func (psc *ProviderStatsController) Latencies(c *gin.Context) {
+	margin := psc.App.GetStore().Config.HTTPAdaptiveTimeoutMargin().Duration()
+	c.JSON(http.StatusOK, utils.SourceRequestLatencies.Stats(margin))
+}
+
+// Bridges returns the errors each bridge has returned since the node started,
+// counted by category, and whether each is being skipped until its circuit
+// closes, as plain JSON.
+// Example:
+//  "<application>/stats/bridges"
+func (psc *ProviderStatsController) Bridges(c *gin.Context) {
+	c.JSON(http.StatusOK, adapters.BridgeErrors())
+}
@@ -0,0 +1,118 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/adapters"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	"github.com/smartcontractkit/chainlink/core/web"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderStatsController_Show(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig(t)
+	defer cfgCleanup()
+	config.Set("PROVIDER_QUOTAS", "providerstats.example.com=100/day")
+	app, cleanup := cltest.NewApplicationWithConfig(t, config, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	utils.ProviderRequests.Record("api.providerstats.example.com", time.Now(), nil, 0)
+
+	resp, cleanup := client.Get("/v2/stats/providers")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var stats web.ProviderStats
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &stats))
+	var found bool
+	for _, provider := range stats.Providers {
+		if provider.Host == "api.providerstats.example.com" {
+			found = true
+			assert.Equal(t, uint64(1), provider.ThisHour)
+		}
+	}
+	assert.True(t, found)
+	require.Len(t, stats.Quotas, 1)
+	assert.Equal(t, "providerstats.example.com", stats.Quotas[0].Domain)
+	assert.Equal(t, uint64(1), stats.Quotas[0].Requests)
+}
+
+func TestProviderStatsController_Latencies(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig(t)
+	defer cfgCleanup()
+	config.Set("HTTP_ADAPTIVE_TIMEOUT_MARGIN", "100ms")
+	app, cleanup := cltest.NewApplicationWithConfig(t, config, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	for i := 0; i < 20; i++ {
+		utils.SourceRequestLatencies.Record("latencies.example.com:8080", 10*time.Millisecond)
+	}
+
+	resp, cleanup := client.Get("/v2/stats/latencies")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var stats []utils.SourceLatencyStats
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &stats))
+	var found bool
+	for _, stat := range stats {
+		if stat.Source == "latencies.example.com:8080" {
+			found = true
+			assert.Equal(t, float64(10), stat.P99)
+			require.NotNil(t, stat.AdaptiveTimeout)
+			assert.Equal(t, float64(110), *stat.AdaptiveTimeout)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestProviderStatsController_Bridges(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	_, bt := cltest.NewBridgeType(t, "statsforbidden", server.URL)
+	ba := &adapters.Bridge{BridgeType: *bt}
+	input := *models.NewRunInput(models.NewID(), *models.NewID(), cltest.JSONFromString(t, `{}`), models.RunStatusUnstarted)
+	require.Error(t, ba.Perform(input, app.Store).Error())
+
+	resp, cleanup := client.Get("/v2/stats/bridges")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var stats []adapters.BridgeErrorStats
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(t, resp), &stats))
+	var found bool
+	for _, stat := range stats {
+		if stat.Bridge == "statsforbidden" {
+			found = true
+			assert.Equal(t, uint64(1), stat.Errors[models.BridgeErrorAuth])
+			assert.Equal(t, models.BridgeErrorAuth, stat.Category)
+			assert.NotNil(t, stat.OpenUntil)
+		}
+	}
+	assert.True(t, found)
+}
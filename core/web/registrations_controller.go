@@ -0,0 +1,140 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// RegistrationsController builds the transactions which register the node's
+// accounts with oracle, operator and aggregator contracts.
+type RegistrationsController struct {
+	App chainlink.Application
+}
+
+// Create returns the unsigned registration transactions for the requested
+// method. When submit is set, the transactions are also sent from one of the
+// node's accounts, which only succeeds if that account is allowed to call the
+// method.
+// Example:
+//  "<application>/registrations"
+func (rc *RegistrationsController) Create(c *gin.Context) {
+	var request models.RegistrationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	store := rc.App.GetStore()
+	params, err := registrationParams(store, request)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	calls, err := contracts.NewRegistrationCalls(params)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	txs := make([]presenters.RegistrationTx, len(calls))
+	for i, call := range calls {
+		txs[i] = presenters.RegistrationTx{
+			ID:     strconv.Itoa(i),
+			Method: call.Method,
+			To:     call.To,
+			Data:   call.Data,
+		}
+	}
+
+	if request.Submit {
+		if err := submitRegistrationTxs(store, request.FromAddress, txs); err != nil {
+			jsonAPIError(c, http.StatusBadRequest, fmt.Errorf("transaction failed: %v", err))
+			return
+		}
+	}
+
+	jsonAPIResponse(c, txs, "registrations")
+}
+
+func registrationParams(store *store.Store, request models.RegistrationRequest) (contracts.RegistrationParams, error) {
+	params := contracts.RegistrationParams{
+		Method:   request.Method,
+		Contract: request.Contract,
+		Nodes:    request.Senders,
+		Oracle:   request.Oracle,
+		NewAdmin: request.NewAdmin,
+	}
+	if len(params.Nodes) == 0 {
+		for _, account := range store.KeyStore.GetAccounts() {
+			params.Nodes = append(params.Nodes, account.Address)
+		}
+	}
+	if params.Oracle == utils.ZeroAddress {
+		account, err := store.KeyStore.GetFirstAccount()
+		if err != nil {
+			return params, err
+		}
+		params.Oracle = account.Address
+	}
+	return params, nil
+}
+
+// submitRegistrationTxs sends the transactions from the given account, or the
+// node's first account if none is given. The legacy tx manager sends from an
+// account of its own choosing, so an account cannot be given when it is in
+// use. The bulletproof tx manager queues all of the transactions or none of
+// them, whereas the legacy tx manager sends each straight away, and so the
+// transactions before one which fails are still sent.
+func submitRegistrationTxs(store *store.Store, from common.Address, txs []presenters.RegistrationTx) error {
+	if !store.Config.EnableBulletproofTxManager() && from != utils.ZeroAddress {
+		return errors.New("from can only be given when ENABLE_BULLETPROOF_TX_MANAGER is set, as the legacy tx manager picks the account itself")
+	}
+	if from == utils.ZeroAddress {
+		account, err := store.KeyStore.GetFirstAccount()
+		if err != nil {
+			return err
+		}
+		from = account.Address
+	} else if _, err := store.KeyStore.GetAccountByAddress(from); err != nil {
+		return err
+	}
+
+	if store.Config.EnableBulletproofTxManager() {
+		calls := make([]bulletprooftxmanager.EthCall, len(txs))
+		for i := range txs {
+			calls[i] = bulletprooftxmanager.EthCall{To: txs[i].To, Payload: txs[i].Data}
+		}
+		etxs, err := bulletprooftxmanager.CreateEthTransactions(store, from, calls)
+		if err != nil {
+			return err
+		}
+		for i := range txs {
+			txs[i].From = &from
+			txs[i].EthTxID = etxs[i].ID
+		}
+		return nil
+	}
+
+	for i := range txs {
+		tx, err := store.TxManager.CreateTx(txs[i].To, txs[i].Data)
+		if err != nil {
+			return err
+		}
+		txs[i].From = &tx.From
+		txs[i].TxHash = &tx.Hash
+	}
+	return nil
+}
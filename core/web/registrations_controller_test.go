@@ -0,0 +1,134 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrationsController_Create(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	account, err := app.Store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+	contract := cltest.NewAddress()
+
+	request := models.RegistrationRequest{
+		Method:   contracts.SetFulfillmentPermissionMethod,
+		Contract: contract,
+	}
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	resp, cleanup := client.Post("/v2/registrations", bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var txs []presenters.RegistrationTx
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &txs))
+	require.Len(t, txs, len(app.Store.KeyStore.GetAccounts()))
+	assert.Equal(t, contracts.SetFulfillmentPermissionMethod, txs[0].Method)
+	assert.Equal(t, contract, txs[0].To)
+	assert.Contains(t, string(txs[0].Data), string(account.Address.Bytes()))
+	assert.Nil(t, txs[0].From)
+	assert.Zero(t, txs[0].EthTxID)
+
+	count, err := app.Store.ORM.CountOf(&models.EthTx{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestRegistrationsController_Create_Submit(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig(t)
+	defer cfgCleanup()
+	config.Set("ENABLE_BULLETPROOF_TX_MANAGER", true)
+	app, cleanup := cltest.NewApplicationWithConfigAndKey(t, config, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	account, err := app.Store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+	contract := cltest.NewAddress()
+
+	request := models.RegistrationRequest{
+		Method:   contracts.AcceptAdminMethod,
+		Contract: contract,
+		Submit:   true,
+	}
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	resp, cleanup := client.Post("/v2/registrations", bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var txs []presenters.RegistrationTx
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &txs))
+	require.Len(t, txs, 1)
+	require.NotNil(t, txs[0].From)
+	assert.Equal(t, account.Address, *txs[0].From)
+	require.NotZero(t, txs[0].EthTxID)
+
+	var etx models.EthTx
+	require.NoError(t, app.Store.DB.First(&etx, txs[0].EthTxID).Error)
+	assert.Equal(t, contract, etx.ToAddress)
+	assert.Equal(t, account.Address, etx.FromAddress)
+	assert.Equal(t, []byte(txs[0].Data), etx.EncodedPayload)
+}
+
+func TestRegistrationsController_Create_Errors(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+	account, err := app.Store.KeyStore.GetFirstAccount()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		request models.RegistrationRequest
+	}{
+		{"unknown method", models.RegistrationRequest{Method: "renounceOwnership", Contract: cltest.NewAddress()}},
+		{"missing contract", models.RegistrationRequest{Method: contracts.AcceptAdminMethod}},
+		{"foreign from address", models.RegistrationRequest{
+			Method:      contracts.AcceptAdminMethod,
+			Contract:    cltest.NewAddress(),
+			Submit:      true,
+			FromAddress: cltest.NewAddress(),
+		}},
+		{"from address with the legacy tx manager", models.RegistrationRequest{
+			Method:      contracts.AcceptAdminMethod,
+			Contract:    cltest.NewAddress(),
+			Submit:      true,
+			FromAddress: account.Address,
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, err := json.Marshal(test.request)
+			require.NoError(t, err)
+			resp, cleanup := client.Post("/v2/registrations", bytes.NewBuffer(body))
+			defer cleanup()
+			cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+		})
+	}
+}
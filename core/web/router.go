@@ -58,6 +58,9 @@ const (
 	SessionUserKey = "user"
 	// SessionExternalInitiatorKey is the External Initiator key in the session map
 	SessionExternalInitiatorKey = "external_initiator"
+	// SessionNamespaceTokenKey is the key of the namespace the request's API
+	// token is confined to in the session map
+	SessionNamespaceTokenKey = "namespace_token"
 )
 
 func explorerStatus(app chainlink.Application) gin.HandlerFunc {
@@ -107,6 +110,8 @@ func Router(app chainlink.Application) *gin.Engine {
 	metricRoutes(app, api)
 	sessionRoutes(app, api)
 	v2Routes(app, api)
+	oac := OpenAPIController{engine}
+	api.GET("/v2/openapi.json", oac.Show)
 
 	guiAssetRoutes(app.NewBox(), engine)
 
@@ -198,6 +203,7 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 
 	jr := JobRunsController{app}
 	unauthedv2.PATCH("/runs/:RunID", jr.Update)
+	unauthedv2.POST("/jobs/:ExternalJobID/runs", RequireNamespace(app.GetStore()), jr.Webhook)
 
 	sa := ServiceAgreementsController{app}
 	unauthedv2.POST("/service_agreements", sa.Create)
@@ -205,29 +211,87 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 	j := JobSpecsController{app}
 	jsec := JobSpecErrorsController{app}
 
-	authv2 := r.Group("/v2", RequireAuth(app.GetStore(), AuthenticateByToken, AuthenticateBySession))
+	authv2 := r.Group("/v2", RequireAuth(app.GetStore(), AuthenticateByToken, AuthenticateBySession), RequireNamespace(app.GetStore()))
 	{
+		admin := RequireNodeAdmin()
+
 		uc := UserController{app}
-		authv2.PATCH("/user/password", uc.UpdatePassword)
-		authv2.GET("/user/balances", uc.AccountBalances)
-		authv2.POST("/user/token", uc.NewAPIToken)
-		authv2.POST("/user/token/delete", uc.DeleteAPIToken)
+		authv2.PATCH("/user/password", admin, uc.UpdatePassword)
+		authv2.GET("/user/balances", admin, uc.AccountBalances)
+		authv2.POST("/user/token", admin, uc.NewAPIToken)
+		authv2.POST("/user/token/delete", admin, uc.DeleteAPIToken)
 
 		eia := ExternalInitiatorsController{app}
 		authv2.POST("/external_initiators", eia.Create)
 		authv2.DELETE("/external_initiators/:Name", eia.Destroy)
+		authv2.POST("/external_initiators/:Name/keys", eia.RotateKeys)
 
 		authv2.POST("/specs", j.Create)
 		authv2.GET("/specs", paginatedRequest(j.Index))
 		authv2.GET("/specs/:SpecID", j.Show)
+		authv2.PATCH("/specs/:SpecID", j.Update)
+		authv2.POST("/specs/:SpecID/start", j.Start)
+		authv2.POST("/specs/:SpecID/pause", j.Pause)
+		authv2.POST("/specs/:SpecID/resume", j.Resume)
+		authv2.POST("/specs/:SpecID/retry", j.Retry)
+		authv2.POST("/specs/:SpecID/duplicate", j.Duplicate)
 		authv2.DELETE("/specs/:SpecID", j.Destroy)
+		authv2.POST("/specs/:SpecID/restore", j.Restore)
 
 		authv2.GET("/runs", paginatedRequest(jr.Index))
 		authv2.GET("/runs/:RunID", jr.Show)
+		authv2.GET("/runs/:RunID/profile", jr.Profile)
 		authv2.PUT("/runs/:RunID/cancellation", jr.Cancel)
+		authv2.PUT("/runs/:RunID/replay", jr.Replay)
+
+		ru := RunUpdatesController{app}
+		authv2.GET("/jobs/:SpecID/runs/ws", ru.Stream)
+		// Registered outside /runs, which gin cannot mix with /runs/:RunID
+		authv2.GET("/run_events/ws", ru.Subscribe)
 
+		authv2.GET("/specs/:SpecID/errors", jsec.Index)
+		authv2.PUT("/job_spec_errors/:jobSpecErrorID/acknowledgement", jsec.Acknowledge)
 		authv2.DELETE("/job_spec_errors/:jobSpecErrorID", jsec.Destroy)
 
+		// Registered outside /specs, which gin cannot mix with /specs/:SpecID/...
+		authv2.POST("/job_spec_previews", j.Preview)
+		authv2.POST("/job_spec_lints", j.Lint)
+		authv2.POST("/job_spec_validations", j.Validate)
+		authv2.POST("/job_spec_batches", j.CreateBatch)
+
+		jst := JobSpecTemplatesController{app}
+		authv2.GET("/job_spec_templates", jst.Index)
+		authv2.POST("/job_spec_templates", jst.Create)
+		authv2.DELETE("/job_spec_templates/:Name", jst.Destroy)
+		authv2.POST("/job_spec_templates/:Name/specs", jst.CreateSpec)
+
+		jsg := JobSpecGeneratorsController{app}
+		authv2.POST("/job_spec_generators/:Pattern", jsg.Create)
+
+		jsv := JobSpecVersionsController{app}
+		authv2.GET("/specs/:SpecID/versions", jsv.Index)
+		authv2.POST("/specs/:SpecID/versions/:Version/rollback", jsv.Rollback)
+
+		wsc := WebhookSecretsController{app}
+		authv2.POST("/specs/:SpecID/webhook_secret", wsc.Rotate)
+
+		je := JobExportsController{app}
+		authv2.GET("/specs/:SpecID/export", je.Show)
+		authv2.GET("/specs/:SpecID/runs.csv", jr.ExportCSV)
+		authv2.POST("/job_imports", je.Create)
+
+		fb := FleetBundleController{app}
+		authv2.GET("/fleet_bundle", fb.Show)
+
+		ps := ProviderStatsController{app}
+		authv2.GET("/stats/providers", admin, ps.Show)
+		authv2.GET("/stats/latencies", admin, ps.Latencies)
+		authv2.GET("/stats/bridges", admin, ps.Bridges)
+
+		nsc := NodeStatsController{app}
+		authv2.GET("/stats", nsc.Show)
+		authv2.GET("/stats/fleet", admin, nsc.Fleet)
+
 		authv2.GET("/service_agreements/:SAID", sa.Show)
 
 		bt := BridgeTypesController{app}
@@ -238,26 +302,66 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.DELETE("/bridge_types/:BridgeName", bt.Destroy)
 
 		ts := TransfersController{app}
-		authv2.POST("/transfers", ts.Create)
+		authv2.POST("/transfers", admin, ts.Create)
+
+		rc := RegistrationsController{app}
+		authv2.POST("/registrations", admin, rc.Create)
+
+		fsc := FundsSweepsController{app}
+		authv2.GET("/funds_sweeps", admin, fsc.Index)
+		authv2.POST("/funds_sweeps", admin, fsc.Create)
+		authv2.POST("/funds_sweeps/:SweepID/approve", admin, fsc.Approve)
+		authv2.POST("/funds_sweeps/:SweepID/reject", admin, fsc.Reject)
+
+		ec := EmergencyController{app}
+		authv2.GET("/emergency/transmission-halts", admin, ec.Index)
+		authv2.POST("/emergency/stop-transmissions", admin, ec.StopTransmissions)
+		authv2.POST("/emergency/resume-transmissions", admin, ec.ResumeTransmissions)
+
+		tac := TransactionAllowlistController{app}
+		authv2.GET("/transaction_allowlist", admin, tac.Index)
+		authv2.POST("/transaction_allowlist", admin, tac.Create)
+		authv2.DELETE("/transaction_allowlist/:Address", admin, tac.Destroy)
 
 		if app.GetStore().Config.Dev() {
 			kc := KeysController{app}
-			authv2.POST("/keys", kc.Create)
+			authv2.POST("/keys", admin, kc.Create)
 		}
 
+		nc := NamespacesController{app}
+		authv2.GET("/namespaces", admin, nc.Index)
+		authv2.POST("/namespaces", admin, nc.Create)
+		authv2.PATCH("/namespaces/:Name", admin, nc.Update)
+		authv2.PUT("/namespaces/:Name/spec_policy", admin, nc.UpdateSpecPolicy)
+		authv2.POST("/namespaces/:Name/keys", admin, nc.AddKey)
+		authv2.POST("/namespaces/:Name/tokens", admin, nc.CreateToken)
+		authv2.DELETE("/namespaces/:Name/tokens/:AccessKey", admin, nc.DestroyToken)
+
+		wgc := WorkerGroupsController{app}
+		authv2.GET("/worker_groups", admin, wgc.Index)
+		authv2.POST("/worker_groups", admin, wgc.Create)
+		authv2.PATCH("/worker_groups/:Name", admin, wgc.Update)
+		authv2.DELETE("/worker_groups/:Name", admin, wgc.Destroy)
+		authv2.PUT("/worker_groups/:Name/specs/:SpecID", admin, wgc.AddJob)
+		authv2.DELETE("/worker_groups/:Name/specs/:SpecID", admin, wgc.RemoveJob)
+
+		ic := IdentityController{app}
+		authv2.GET("/identity", admin, ic.Show)
+
 		cc := ConfigController{app}
-		authv2.GET("/config", cc.Show)
-		authv2.PATCH("/config", cc.Patch)
+		authv2.GET("/config", admin, cc.Show)
+		authv2.PATCH("/config", admin, cc.Patch)
 
 		tas := TxAttemptsController{app}
-		authv2.GET("/tx_attempts", paginatedRequest(tas.Index))
+		authv2.GET("/tx_attempts", admin, paginatedRequest(tas.Index))
 
 		txs := TransactionsController{app}
-		authv2.GET("/transactions", paginatedRequest(txs.Index))
-		authv2.GET("/transactions/:TxHash", txs.Show)
+		authv2.GET("/transactions", admin, paginatedRequest(txs.Index))
+		authv2.GET("/transactions.csv", txs.ExportCSV)
+		authv2.GET("/transactions/:TxHash", admin, txs.Show)
 
 		bdc := BulkDeletesController{app}
-		authv2.DELETE("/bulk_delete_runs", bdc.Delete)
+		authv2.DELETE("/bulk_delete_runs", admin, bdc.Delete)
 	}
 
 	ping := PingController{app}
@@ -265,7 +369,7 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		AuthenticateExternalInitiator,
 		AuthenticateByToken,
 		AuthenticateBySession,
-	))
+	), RequireNamespace(app.GetStore()))
 	userOrEI.POST("/specs/:SpecID/runs", jr.Create)
 	userOrEI.GET("/ping", ping.Show)
 }
@@ -0,0 +1,214 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+const (
+	runUpdatesWriteTimeout = 10 * time.Second
+	runUpdatesPingInterval = 30 * time.Second
+)
+
+// runUpdatesUpgrader accepts the origins in ALLOW_ORIGINS, the same as the
+// CORS handler does for the rest of the API, which browsers do not apply to
+// WebSockets. Clients which send no Origin, which are not browsers, are
+// accepted.
+func runUpdatesUpgrader(config orm.ConfigReader) websocket.Upgrader {
+	return websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" || config.AllowOrigins() == "*" {
+				return true
+			}
+			for _, allowed := range strings.Split(config.AllowOrigins(), ",") {
+				if origin == allowed {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// RunUpdatesController streams the status changes of a job's runs, and the
+// creation, completion and failure of the runs of every job.
+type RunUpdatesController struct {
+	App chainlink.Application
+}
+
+// Stream upgrades the request to a WebSocket, over which each status change
+// of the job's runs and their task runs is sent as a JSON message, until the
+// client closes the connection. A client which cannot keep up is
+// disconnected, and should reload the runs before reconnecting.
+// Example:
+//  "<application>/jobs/:SpecID/runs/ws"
+func (ruc *RunUpdatesController) Stream(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	job, err := ruc.App.GetStore().FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, job.Namespace)) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	conn, err := runUpdatesUpgrader(ruc.App.GetStore().Config).Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already responded with the error
+		logger.Debugw("Could not upgrade run updates connection", "error", err)
+		return
+	}
+	defer logger.ErrorIfCalling(conn.Close)
+
+	sub := ruc.App.GetRunUpdateBroadcaster().Subscribe(job.ID)
+	defer sub.Unsubscribe()
+
+	chClosed := readUntilClosed(conn)
+	ticker := time.NewTicker(runUpdatesPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-chClosed:
+			return
+		case update, ok := <-sub.Updates():
+			if !ok {
+				closeRunUpdates(conn)
+				return
+			}
+			if err := writeRunUpdate(conn, update); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := pingRunUpdates(conn); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Subscribe upgrades the request to a WebSocket, over which the creation,
+// completion and failure of runs is sent as a JSON message, until the client
+// closes the connection. Only the runs of the jobs given by the jobId
+// parameters are followed, if there are any, and otherwise those of every
+// job in the request's namespace.
+// Example:
+//  "<application>/run_events/ws?jobId=:SpecID"
+func (ruc *RunUpdatesController) Subscribe(c *gin.Context) {
+	var jobIDs []*models.ID
+	for _, param := range c.QueryArray("jobId") {
+		id, err := models.NewIDFromString(param)
+		if err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		job, err := ruc.App.GetStore().FindJob(id)
+		if errors.Cause(err) == orm.ErrorNotFound || (err == nil && outsideNamespace(c, job.Namespace)) {
+			jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+			return
+		} else if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		jobIDs = append(jobIDs, id)
+	}
+
+	conn, err := runUpdatesUpgrader(ruc.App.GetStore().Config).Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Debugw("Could not upgrade run events connection", "error", err)
+		return
+	}
+	defer logger.ErrorIfCalling(conn.Close)
+
+	sub := ruc.App.GetRunUpdateBroadcaster().SubscribeEvents(jobIDs)
+	defer sub.Unsubscribe()
+
+	// The namespaces of the jobs seen, to pass on only the events of the
+	// request's namespace
+	namespaces := make(map[string]string)
+	inNamespace := func(jobID *models.ID) bool {
+		namespace, ok := namespaces[jobID.String()]
+		if !ok {
+			job, err := ruc.App.GetStore().Unscoped().FindJob(jobID)
+			if err != nil {
+				logger.Errorw("Could not find job of run event", "job", jobID.String(), "error", err)
+				return false
+			}
+			namespace = job.Namespace
+			namespaces[jobID.String()] = namespace
+		}
+		return !outsideNamespace(c, namespace)
+	}
+
+	chClosed := readUntilClosed(conn)
+	ticker := time.NewTicker(runUpdatesPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-chClosed:
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				closeRunUpdates(conn)
+				return
+			}
+			if !inNamespace(event.JobID) {
+				continue
+			}
+			if err := writeRunUpdate(conn, event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := pingRunUpdates(conn); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readUntilClosed reads from conn, which is needed to process the client's
+// pings and close message, and returns a channel closed once the connection
+// is.
+func readUntilClosed(conn *websocket.Conn) <-chan struct{} {
+	chClosed := make(chan struct{})
+	go func() {
+		defer close(chClosed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+	return chClosed
+}
+
+func writeRunUpdate(conn *websocket.Conn, update interface{}) error {
+	_ = conn.SetWriteDeadline(time.Now().Add(runUpdatesWriteTimeout))
+	return conn.WriteJSON(update)
+}
+
+func pingRunUpdates(conn *websocket.Conn) error {
+	return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(runUpdatesWriteTimeout))
+}
+
+// closeRunUpdates tells the client that the updates have ended, so that it
+// reconnects.
+func closeRunUpdates(conn *websocket.Conn) {
+	msg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "run updates ended")
+	_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(runUpdatesWriteTimeout))
+}
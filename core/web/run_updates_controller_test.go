@@ -0,0 +1,137 @@
+package web_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunUpdatesController_Stream(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	url := "ws" + strings.TrimPrefix(app.Server.URL, "http") + "/v2/jobs/" + job.ID.String() + "/runs/ws"
+	cookie := cltest.MustGenerateSessionCookie(app.MustSeedNewSession())
+	conn, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Cookie": {cookie.String()}})
+	require.NoError(t, err)
+	defer conn.Close()
+	defer resp.Body.Close()
+
+	run := cltest.CreateJobRunViaWeb(t, app, job)
+
+	var taskUpdates, runUpdates []models.RunUpdate
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+	for len(runUpdates) == 0 || runUpdates[len(runUpdates)-1].Status != models.RunStatusCompleted {
+		var update models.RunUpdate
+		require.NoError(t, conn.ReadJSON(&update))
+		assert.Equal(t, job.ID, update.JobID)
+		assert.Equal(t, run.ID, update.JobRunID)
+		if update.TaskRunID != nil {
+			taskUpdates = append(taskUpdates, update)
+		} else {
+			runUpdates = append(runUpdates, update)
+		}
+	}
+	require.NotEmpty(t, taskUpdates)
+	assert.Equal(t, models.RunStatusCompleted, taskUpdates[len(taskUpdates)-1].Status)
+}
+
+func TestRunUpdatesController_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+	otherJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&otherJob))
+
+	url := "ws" + strings.TrimPrefix(app.Server.URL, "http") + "/v2/run_events/ws?jobId=" + job.ID.String()
+	cookie := cltest.MustGenerateSessionCookie(app.MustSeedNewSession())
+	conn, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Cookie": {cookie.String()}})
+	require.NoError(t, err)
+	defer conn.Close()
+	defer resp.Body.Close()
+
+	cltest.CreateJobRunViaWeb(t, app, otherJob)
+	run := cltest.CreateJobRunViaWeb(t, app, job)
+
+	var events []models.RunEvent
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+	for len(events) < 2 {
+		var event models.RunEvent
+		require.NoError(t, conn.ReadJSON(&event))
+		assert.Equal(t, job.ID, event.JobID)
+		assert.Equal(t, run.ID, event.JobRunID)
+		events = append(events, event)
+	}
+	assert.Equal(t, models.RunEventCreated, events[0].Event)
+	assert.Equal(t, models.RunEventCompleted, events[1].Event)
+	assert.Equal(t, models.RunStatusCompleted, events[1].Status)
+}
+
+func TestRunUpdatesController_Subscribe_NotFound(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/run_events/ws?jobId=" + models.NewID().String())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
+func TestRunUpdatesController_Stream_NotFound(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/jobs/" + models.NewID().String() + "/runs/ws")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
+func TestRunUpdatesController_Stream_Origin(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	url := "ws" + strings.TrimPrefix(app.Server.URL, "http") + "/v2/jobs/" + job.ID.String() + "/runs/ws"
+	cookie := cltest.MustGenerateSessionCookie(app.MustSeedNewSession())
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Cookie": {cookie.String()}, "Origin": {"http://evil.example.com"}})
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	conn, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Cookie": {cookie.String()}, "Origin": {"http://localhost:3000"}})
+	require.NoError(t, err)
+	defer conn.Close()
+	defer resp.Body.Close()
+}
@@ -0,0 +1,78 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// TransactionAllowlistController manages the contracts the node may transact
+// with besides those used by its jobs. The allowlist is only enforced when
+// TRANSACTION_ALLOWLIST_ENABLED is set.
+type TransactionAllowlistController struct {
+	App chainlink.Application
+}
+
+// Index lists the contracts added to the transaction allowlist.
+// Example:
+//  "<application>/transaction_allowlist"
+func (tac *TransactionAllowlistController) Index(c *gin.Context) {
+	entries, err := tac.App.GetStore().TransactionAllowlist()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, entries, "transaction_allowlist_entries")
+}
+
+// Create adds a contract to the transaction allowlist.
+// Example:
+//  "<application>/transaction_allowlist"
+func (tac *TransactionAllowlistController) Create(c *gin.Context) {
+	var request models.TransactionAllowlistRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if request.Address == (common.Address{}) {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("an address is required"))
+		return
+	}
+
+	entry, err := tac.App.GetStore().AddToTransactionAllowlist(request.Address, request.Note, auditUser(c))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, errors.Wrap(err, "while adding to the transaction allowlist"))
+		return
+	}
+	logger.Warnw("Contract added to the transaction allowlist", "address", entry.Address.Hex(), "addedBy", entry.AddedBy, "note", entry.Note)
+	jsonAPIResponseWithStatus(c, entry, "transaction_allowlist_entry", http.StatusCreated)
+}
+
+// Destroy removes a contract from the transaction allowlist.
+// Example:
+//  "<application>/transaction_allowlist/:Address"
+func (tac *TransactionAllowlistController) Destroy(c *gin.Context) {
+	if !common.IsHexAddress(c.Param("Address")) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("invalid address"))
+		return
+	}
+	address := common.HexToAddress(c.Param("Address"))
+
+	err := tac.App.GetStore().RemoveFromTransactionAllowlist(address)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("contract is not on the transaction allowlist"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Warnw("Contract removed from the transaction allowlist", "address", address.Hex(), "removedBy", auditUser(c))
+	jsonAPIResponseWithStatus(c, nil, "transaction_allowlist_entry", http.StatusNoContent)
+}
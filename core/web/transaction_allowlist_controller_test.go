@@ -0,0 +1,66 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionAllowlistController(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+	address := cltest.NewAddress()
+
+	resp, cleanup := client.Post("/v2/transaction_allowlist", bytes.NewBufferString(`{"note": "missing address"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	body := fmt.Sprintf(`{"address": "%s", "note": "multisig"}`, address.Hex())
+	resp, cleanup = client.Post("/v2/transaction_allowlist", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	var entry models.TransactionAllowlistEntry
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &entry))
+	assert.Equal(t, address, entry.Address)
+	assert.Equal(t, "multisig", entry.Note)
+	assert.Equal(t, cltest.APIEmail, entry.AddedBy)
+
+	allowed, err := app.Store.TransactionAllowed(cltest.NewAddress(), address)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	resp, cleanup = client.Get("/v2/transaction_allowlist")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var entries []models.TransactionAllowlistEntry
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, address, entries[0].Address)
+
+	resp, cleanup = client.Delete("/v2/transaction_allowlist/" + address.Hex())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+
+	allowed, err = app.Store.TransactionAllowed(cltest.NewAddress(), address)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	resp, cleanup = client.Delete("/v2/transaction_allowlist/" + address.Hex())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = client.Delete("/v2/transaction_allowlist/notanaddress")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
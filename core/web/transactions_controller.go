@@ -1,9 +1,14 @@
 package web
 
 import (
+	"encoding/csv"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
 
@@ -46,3 +51,100 @@ func (tc *TransactionsController) Show(c *gin.Context) {
 
 	jsonAPIResponse(c, presenters.NewTxFromAttempt(*txAttempt), "transaction")
 }
+
+// transactionsCSVHeader names the columns of the CSV export of transactions.
+// The manager column says which tx manager sent a transaction, as the IDs of
+// each are numbered separately.
+var transactionsCSVHeader = []string{"id", "hash", "from", "to", "nonce", "value", "gasLimit", "gasPrice", "confirmed", "sentAt", "createdAt", "manager"}
+
+// ExportCSV streams the transactions sent from the keys of the request's
+// namespace as CSV, for reconciliation and accounting: those of the legacy
+// tx manager oldest first, followed by those of the bulletproof tx manager
+// oldest first. The transactions may be limited to those created in
+// [from, to), given as RFC3339 times. Unlike paging through Index, the
+// export reads a single snapshot of the database, so transactions sent or
+// confirmed while it runs are neither skipped nor repeated.
+// Example:
+//  "<application>/transactions.csv?from=2020-10-01T00:00:00Z&to=2020-11-01T00:00:00Z"
+func (tc *TransactionsController) ExportCSV(c *gin.Context) {
+	from, to, ok := requestTimeRange(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", `attachment; filename="transactions.csv"`)
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	if err := w.Write(transactionsCSVHeader); err != nil {
+		return
+	}
+	err := tc.App.GetStore().EachTransactionBetween(requestNamespace(c), from, to,
+		func(tx *models.Tx) error {
+			return w.Write(transactionCSVRecord(tx))
+		},
+		func(etx *models.EthTx) error {
+			return w.Write(ethTxCSVRecord(etx))
+		})
+	w.Flush()
+	if err != nil {
+		// The response has already begun, so the error can only be logged
+		logger.Errorw("Error exporting transactions as CSV", "error", err)
+	}
+}
+
+func transactionCSVRecord(tx *models.Tx) []string {
+	return []string{
+		strconv.FormatUint(tx.ID, 10),
+		tx.Hash.Hex(),
+		tx.From.Hex(),
+		tx.To.Hex(),
+		strconv.FormatUint(tx.Nonce, 10),
+		tx.Value.String(),
+		strconv.FormatUint(tx.GasLimit, 10),
+		tx.GasPrice.String(),
+		strconv.FormatBool(tx.Confirmed),
+		strconv.FormatUint(tx.SentAt, 10),
+		tx.CreatedAt.UTC().Format(time.RFC3339Nano),
+		"legacy",
+	}
+}
+
+// ethTxCSVRecord exports a transaction of the bulletproof tx manager with
+// the hash, gas price and block of the attempt which was mined, or of its
+// latest attempt if none has been. The nonce and those columns are empty
+// until the transaction has been broadcast.
+func ethTxCSVRecord(etx *models.EthTx) []string {
+	var hash, nonce, gasPrice, sentAt string
+	if etx.Nonce != nil {
+		nonce = strconv.FormatInt(*etx.Nonce, 10)
+	}
+	if len(etx.EthTxAttempts) > 0 {
+		attempt := etx.EthTxAttempts[0]
+		for _, a := range etx.EthTxAttempts {
+			if len(a.EthReceipts) > 0 {
+				attempt = a
+				break
+			}
+		}
+		hash = attempt.Hash.Hex()
+		gasPrice = attempt.GasPrice.String()
+		if attempt.BroadcastBeforeBlockNum != nil {
+			sentAt = strconv.FormatInt(*attempt.BroadcastBeforeBlockNum, 10)
+		}
+	}
+	return []string{
+		strconv.FormatInt(etx.ID, 10),
+		hash,
+		etx.FromAddress.Hex(),
+		etx.ToAddress.Hex(),
+		nonce,
+		etx.Value.ToInt().String(),
+		strconv.FormatUint(etx.GasLimit, 10),
+		gasPrice,
+		strconv.FormatBool(etx.State == models.EthTxConfirmed),
+		sentAt,
+		etx.CreatedAt.UTC().Format(time.RFC3339Nano),
+		"bulletproof",
+	}
+}
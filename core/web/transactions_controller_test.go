@@ -1,7 +1,9 @@
 package web_test
 
 import (
+	"encoding/csv"
 	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
@@ -159,3 +161,61 @@ func TestTransactionsController_Show_NotFound(t *testing.T) {
 	defer cleanup()
 	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
 }
+
+func TestTransactionsController_ExportCSV(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		cltest.EthMockRegisterChainID,
+		cltest.EthMockRegisterGetBalance,
+	)
+	defer cleanup()
+
+	ethMock := app.EthMock
+	ethMock.Context("app.Start()", func(ethMock *cltest.EthMock) {
+		ethMock.Register("eth_getTransactionCount", "0x100")
+	})
+
+	require.NoError(t, app.Start())
+	store := app.GetStore()
+	client := app.NewHTTPClient()
+
+	from := cltest.GetAccountAddress(t, store)
+	tx1 := cltest.CreateTxWithNonceAndGasPrice(t, store, from, 1, 0, 1)
+	tx2 := cltest.CreateTxWithNonceAndGasPrice(t, store, from, 3, 1, 1)
+	etx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 7, 42, from)
+
+	// Transactions sent from the keys of another namespace are left out
+	other := cltest.MustInsertRandomKey(t, store)
+	require.NoError(t, store.CreateNamespace(&models.Namespace{Name: "other"}))
+	require.NoError(t, store.DB.Model(&models.Key{}).Where("address = ?", other.Address).Update("namespace", "other").Error)
+	cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 0, 42, other.Address.Address())
+
+	resp, cleanup := client.Get("/v2/transactions.csv")
+	defer cleanup()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, []string{"id", "hash", "from", "to", "nonce", "value", "gasLimit", "gasPrice", "confirmed", "sentAt", "createdAt", "manager"}, records[0])
+	assert.Equal(t, strconv.FormatUint(tx1.ID, 10), records[1][0])
+	assert.Equal(t, tx1.Hash.Hex(), records[1][1])
+	assert.Equal(t, from.Hex(), records[1][2])
+	assert.Equal(t, []string{"0", "false", "1", "legacy"}, []string{records[1][4], records[1][8], records[1][9], records[1][11]})
+	assert.Equal(t, strconv.FormatUint(tx2.ID, 10), records[2][0])
+	assert.Equal(t, strconv.FormatInt(etx.ID, 10), records[3][0])
+	assert.Equal(t, etx.EthTxAttempts[0].Hash.Hex(), records[3][1])
+	assert.Equal(t, []string{"7", "true", "42", "bulletproof"}, []string{records[3][4], records[3][8], records[3][9], records[3][11]})
+
+	resp, cleanup = client.Get("/v2/transactions.csv?from=2100-01-01T00:00:00Z")
+	defer cleanup()
+	records, err = csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	resp, cleanup = client.Get("/v2/transactions.csv?to=yesterday")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
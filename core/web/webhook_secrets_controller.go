@@ -0,0 +1,64 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// WebhookSecretsController rotates the secrets of webhook jobs, so that a
+// secret which may have leaked can be replaced without downtime. The
+// secrets are generated by the node, are not part of the jobs' specs, and
+// are only returned when a job is created and when they are rotated.
+type WebhookSecretsController struct {
+	App chainlink.Application
+}
+
+// Rotate gives each of the job's webhook initiators, or only the one given,
+// a new secret of its own, which is returned only this once. The previous
+// secrets are still accepted for the overlap given, an hour by default and
+// at most a week, while callers switch over.
+// Example:
+//  "<application>/specs/:SpecID/webhook_secret"
+func (wsc *WebhookSecretsController) Rotate(c *gin.Context) {
+	var request models.WebhookSecretRotationRequest
+	if err := c.ShouldBindJSON(&request); err != nil && c.Request.ContentLength > 0 {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	overlap, err := request.OverlapOrDefault()
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	jsc := JobSpecsController{wsc.App}
+	if !jsc.findJobInNamespace(c, id) {
+		return
+	}
+
+	rotation := models.WebhookSecretRotation{
+		JobSpecID:               id,
+		PreviousSecretExpiresAt: time.Now().Add(overlap),
+	}
+	rotation.Secrets, err = wsc.App.GetStore().RotateWebhookSecrets(id, request.InitiatorID, rotation.PreviousSecretExpiresAt)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("job has no such webhook initiator"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, rotation, "webhook_secret")
+}
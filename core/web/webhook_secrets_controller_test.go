@@ -0,0 +1,92 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSecretsController_Rotate(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	secret := "0123456789abcdef"
+	externalJobID := uuid.NewV4()
+	j := cltest.NewJob()
+	j.ExternalJobID = &externalJobID
+	j.Initiators = []models.Initiator{{
+		JobSpecID:       j.ID,
+		Type:            models.InitiatorWebhook,
+		InitiatorParams: models.InitiatorParams{WebhookSecret: secret},
+	}, {
+		JobSpecID:       j.ID,
+		Type:            models.InitiatorWebhook,
+		InitiatorParams: models.InitiatorParams{WebhookSecret: "fedcba9876543210"},
+	}}
+	require.NoError(t, app.Store.CreateJob(&j))
+
+	resp, cleanup := client.Post("/v2/specs/"+j.ID.String()+"/webhook_secret", bytes.NewBufferString(`{"overlap": "1h"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var rotation models.WebhookSecretRotation
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &rotation))
+	require.Len(t, rotation.Secrets, 2)
+	assert.Equal(t, j.Initiators[0].ID, rotation.Secrets[0].InitiatorID)
+	assert.NotEqual(t, secret, rotation.Secrets[0].Secret)
+	assert.NotEqual(t, rotation.Secrets[0].Secret, rotation.Secrets[1].Secret, "each initiator should get a secret of its own")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), rotation.PreviousSecretExpiresAt, time.Minute)
+
+	// Both the new and the previous secret are accepted during the overlap
+	body := `{"result":"100"}`
+	url := app.Config.ClientNodeURL() + "/v2/jobs/" + externalJobID.String() + "/runs"
+	for _, s := range []string{rotation.Secrets[0].Secret, secret} {
+		resp, cleanup = cltest.UnauthenticatedPost(t, url, bytes.NewBufferString(body), webhookHeaders(s, body))
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+	}
+
+	// Rotating one initiator with no overlap stops accepting its previous
+	// secret at once, and leaves the other initiator's secret alone
+	request := fmt.Sprintf(`{"overlap": "0s", "initiatorId": %d}`, j.Initiators[0].ID)
+	resp, cleanup = client.Post("/v2/specs/"+j.ID.String()+"/webhook_secret", bytes.NewBufferString(request))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var single models.WebhookSecretRotation
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &single))
+	require.Len(t, single.Secrets, 1)
+	assert.Equal(t, j.Initiators[0].ID, single.Secrets[0].InitiatorID)
+	body = `{"result":"101"}`
+	resp, cleanup = cltest.UnauthenticatedPost(t, url, bytes.NewBufferString(body), webhookHeaders(rotation.Secrets[0].Secret, body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnauthorized)
+	body = `{"result":"102"}`
+	resp, cleanup = cltest.UnauthenticatedPost(t, url, bytes.NewBufferString(body), webhookHeaders(rotation.Secrets[1].Secret, body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	resp, cleanup = client.Post("/v2/specs/"+j.ID.String()+"/webhook_secret", bytes.NewBufferString(`{"overlap": "720h"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+	resp, cleanup = client.Post("/v2/specs/"+j.ID.String()+"/webhook_secret", bytes.NewBufferString(`{"overlap": "-1h"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	other := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&other))
+	resp, cleanup = client.Post("/v2/specs/"+other.ID.String()+"/webhook_secret", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
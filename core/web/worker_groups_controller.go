@@ -0,0 +1,181 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// WorkerGroupsController manages the worker groups which cap how many runs
+// of a set of jobs execute at once, isolating them from the node's other
+// jobs.
+type WorkerGroupsController struct {
+	App chainlink.Application
+}
+
+// Index lists every worker group and its jobs.
+// Example:
+//  "<application>/worker_groups"
+func (wgc *WorkerGroupsController) Index(c *gin.Context) {
+	groups, err := wgc.App.GetStore().WorkerGroups()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, groups, "worker_groups")
+}
+
+// Create adds a worker group, with no jobs.
+// Example:
+//  "<application>/worker_groups"
+func (wgc *WorkerGroupsController) Create(c *gin.Context) {
+	var request models.WorkerGroupRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	wg, err := models.NewWorkerGroup(request.Name, request.Concurrency)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	store := wgc.App.GetStore()
+	if _, err := store.FindWorkerGroup(wg.Name); err == nil {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("worker group %s already exists", wg.Name))
+		return
+	} else if errors.Cause(err) != orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if err := store.CreateWorkerGroup(&wg); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	wg.JobSpecIDs = []string{}
+	jsonAPIResponseWithStatus(c, wg, "worker_group", http.StatusCreated)
+}
+
+// Update changes the concurrency of a worker group. Runs waiting for the
+// group pick up the new concurrency when they next start executing.
+// Example:
+//  "<application>/worker_groups/:Name"
+func (wgc *WorkerGroupsController) Update(c *gin.Context) {
+	var request models.WorkerGroupRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err := models.ValidateWorkerGroupConcurrency(request.Concurrency); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	store := wgc.App.GetStore()
+	err := store.UpdateWorkerGroupConcurrency(c.Param("Name"), request.Concurrency)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("worker group not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	wgc.respondWithGroup(c, c.Param("Name"))
+}
+
+// Destroy removes a worker group. Its jobs return to the shared pool.
+// Example:
+//  "<application>/worker_groups/:Name"
+func (wgc *WorkerGroupsController) Destroy(c *gin.Context) {
+	err := wgc.App.GetStore().DeleteWorkerGroup(c.Param("Name"))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("worker group not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, nil, "worker_group", http.StatusNoContent)
+}
+
+// AddJob moves a job into a worker group, out of the shared pool or another
+// group.
+// Example:
+//  "<application>/worker_groups/:Name/specs/:SpecID"
+func (wgc *WorkerGroupsController) AddJob(c *gin.Context) {
+	id, ok := wgc.requestJobID(c)
+	if !ok {
+		return
+	}
+	name := c.Param("Name")
+	if _, err := wgc.App.GetStore().FindWorkerGroup(name); errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("worker group not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	wgc.setJobWorkerGroup(c, id, &name)
+}
+
+// RemoveJob moves a job out of a worker group, back to the shared pool.
+// Example:
+//  "<application>/worker_groups/:Name/specs/:SpecID"
+func (wgc *WorkerGroupsController) RemoveJob(c *gin.Context) {
+	id, ok := wgc.requestJobID(c)
+	if !ok {
+		return
+	}
+	group, err := wgc.App.GetStore().FindJobWorkerGroup(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	} else if group == nil || group.Name != c.Param("Name") {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec is not in the worker group"))
+		return
+	}
+	wgc.setJobWorkerGroup(c, id, nil)
+}
+
+// requestJobID returns the ID of the job given by the request, responding
+// with an error if it is invalid or the job is not in the request's
+// namespace.
+func (wgc *WorkerGroupsController) requestJobID(c *gin.Context) (*models.ID, bool) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return nil, false
+	}
+	jsc := JobSpecsController{wgc.App}
+	return id, jsc.findJobInNamespace(c, id)
+}
+
+func (wgc *WorkerGroupsController) setJobWorkerGroup(c *gin.Context, id *models.ID, group *string) {
+	err := wgc.App.GetStore().SetJobWorkerGroup(id, group)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	wgc.respondWithGroup(c, c.Param("Name"))
+}
+
+func (wgc *WorkerGroupsController) respondWithGroup(c *gin.Context, name string) {
+	wg, err := wgc.App.GetStore().FindWorkerGroup(name)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("worker group not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, wg, "worker_group")
+}
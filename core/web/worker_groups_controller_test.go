@@ -0,0 +1,71 @@
+package web_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerGroupsController(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+	store := app.GetStore()
+
+	resp, cleanup := client.Post("/v2/worker_groups", bytes.NewBufferString(`{"name": "webhooks", "concurrency": 2}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	resp, cleanup = client.Post("/v2/worker_groups", bytes.NewBufferString(`{"name": "webhooks", "concurrency": 2}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+	resp, cleanup = client.Post("/v2/worker_groups", bytes.NewBufferString(`{"name": "ocr", "concurrency": 0}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+
+	resp, cleanup = client.Put("/v2/worker_groups/webhooks/specs/"+job.ID.String(), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var wg models.WorkerGroup
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &wg))
+	assert.Equal(t, []string{job.ID.String()}, wg.JobSpecIDs)
+
+	resp, cleanup = client.Patch("/v2/worker_groups/webhooks", bytes.NewBufferString(`{"concurrency": 5}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	group, err := store.FindJobWorkerGroup(job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, group)
+	assert.Equal(t, 5, group.Concurrency)
+
+	resp, cleanup = client.Delete("/v2/worker_groups/other/specs/" + job.ID.String())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	resp, cleanup = client.Delete("/v2/worker_groups/webhooks/specs/" + job.ID.String())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	group, err = store.FindJobWorkerGroup(job.ID)
+	require.NoError(t, err)
+	assert.Nil(t, group)
+
+	resp, cleanup = client.Put("/v2/worker_groups/webhooks/specs/"+job.ID.String(), nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	resp, cleanup = client.Delete("/v2/worker_groups/webhooks")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+	group, err = store.FindJobWorkerGroup(job.ID)
+	require.NoError(t, err)
+	assert.Nil(t, group, "jobs return to the shared pool when their group is deleted")
+}